@@ -6,6 +6,11 @@ import (
 	"os"
 
 	services "github.com/appnetorg/online-boutique-arpc/services"
+	"github.com/appnetorg/online-boutique-arpc/services/bench"
+	"github.com/appnetorg/online-boutique-arpc/services/client"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/probe"
+	"github.com/appnetorg/online-boutique-arpc/services/replay"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
 	"github.com/opentracing/opentracing-go"
 )
@@ -27,6 +32,10 @@ func main() {
 		checkoutport       = flag.Int("checkoutport", 11007, "checkout service port")
 		recommendationport = flag.Int("recommendationport", 11008, "recommendation service port")
 		adport             = flag.Int("adport", 11009, "ad service port")
+		addressport        = flag.Int("addressport", 11010, "address service port")
+		walletport         = flag.Int("walletport", 11011, "wallet service port")
+		analyticsport      = flag.Int("analyticsport", 11012, "analytics service port")
+		supportport        = flag.Int("supportport", 11013, "support service port")
 	)
 	flag.Parse()
 
@@ -34,6 +43,19 @@ func main() {
 	var cmd = os.Args[1]
 	println("cmd parsed: ", cmd)
 
+	// The probe, replay, bench and client are clients of a running
+	// deployment rather than services.
+	switch cmd {
+	case "bench":
+		os.Exit(bench.Main(os.Args[2:]))
+	case "client":
+		os.Exit(client.Main(os.Args[2:]))
+	case "probe":
+		os.Exit(probe.Main(os.Args[2:]))
+	case "replay":
+		os.Exit(replay.Main(os.Args[2:]))
+	}
+
 	tracer, closer, err := tracing.Init(cmd)
 	if err != nil {
 		log.Fatalf("ERROR: cannot init Jaeger: %v\n", err)
@@ -42,6 +64,9 @@ func main() {
 	opentracing.SetGlobalTracer(tracer)
 	log.Printf("Jaeger Tracer Initialised for %s", cmd)
 
+	// Settings and data files are re-read on SIGHUP.
+	config.WatchSignals()
+
 	switch cmd {
 	case "cart":
 		srv = services.NewCartService(*cartport)
@@ -61,12 +86,24 @@ func main() {
 		srv = services.NewRecommendationService(*recommendationport)
 	case "ad":
 		srv = services.NewAdService(*adport)
+	case "address":
+		srv = services.NewAddressService(*addressport)
+	case "wallet":
+		srv = services.NewWalletService(*walletport)
+	case "analytics":
+		srv = services.NewAnalyticsService(*analyticsport)
+	case "support":
+		srv = services.NewSupportService(*supportport)
 	case "frontend":
 		srv = services.NewFrontendServer(*frontendport)
 	default:
 		log.Fatalf("unknown cmd: %s", cmd)
 	}
 
+	// Profiles are captured when goroutines or the heap grow past their
+	// thresholds.
+	services.StartWatchdog(cmd)
+
 	if err := srv.Run(); err != nil {
 		log.Fatalf("run %s error: %v", cmd, err)
 	}
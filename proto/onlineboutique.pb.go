@@ -36,9 +36,11 @@ const (
 )
 
 type CartItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// The SKU chosen for products that come in variants.
+	VariantId     string `protobuf:"bytes,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -87,10 +89,18 @@ func (x *CartItem) GetQuantity() int32 {
 	return 0
 }
 
+func (x *CartItem) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
 type AddItemRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Item          *CartItem              `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string    `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Item          *CartItem `protobuf:"bytes,2,opt,name=item,proto3" json:"item,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -139,16 +149,81 @@ func (x *AddItemRequest) GetItem() *CartItem {
 	return nil
 }
 
+// CartLimitExceeded details the RESOURCE_EXHAUSTED status of an AddItem call
+// that would take the cart past a limit.
+type CartLimitExceeded struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "items" for the number of distinct items in the cart, or "quantity"
+	// for that of one product.
+	Limit         string `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Max           int32  `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+	ProductId     string `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CartLimitExceeded) Reset() {
+	*x = CartLimitExceeded{}
+	mi := &file_onlineboutique_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CartLimitExceeded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CartLimitExceeded) ProtoMessage() {}
+
+func (x *CartLimitExceeded) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CartLimitExceeded.ProtoReflect.Descriptor instead.
+func (*CartLimitExceeded) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CartLimitExceeded) GetLimit() string {
+	if x != nil {
+		return x.Limit
+	}
+	return ""
+}
+
+func (x *CartLimitExceeded) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *CartLimitExceeded) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
 type EmptyCartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EmptyCartRequest) Reset() {
 	*x = EmptyCartRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[2]
+	mi := &file_onlineboutique_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -160,7 +235,7 @@ func (x *EmptyCartRequest) String() string {
 func (*EmptyCartRequest) ProtoMessage() {}
 
 func (x *EmptyCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[2]
+	mi := &file_onlineboutique_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -173,7 +248,7 @@ func (x *EmptyCartRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EmptyCartRequest.ProtoReflect.Descriptor instead.
 func (*EmptyCartRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{2}
+	return file_onlineboutique_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *EmptyCartRequest) GetUserId() string {
@@ -184,15 +259,16 @@ func (x *EmptyCartRequest) GetUserId() string {
 }
 
 type GetCartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetCartRequest) Reset() {
 	*x = GetCartRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[3]
+	mi := &file_onlineboutique_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -204,7 +280,7 @@ func (x *GetCartRequest) String() string {
 func (*GetCartRequest) ProtoMessage() {}
 
 func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[3]
+	mi := &file_onlineboutique_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -217,7 +293,7 @@ func (x *GetCartRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCartRequest.ProtoReflect.Descriptor instead.
 func (*GetCartRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{3}
+	return file_onlineboutique_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetCartRequest) GetUserId() string {
@@ -237,7 +313,7 @@ type Cart struct {
 
 func (x *Cart) Reset() {
 	*x = Cart{}
-	mi := &file_onlineboutique_proto_msgTypes[4]
+	mi := &file_onlineboutique_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -249,7 +325,7 @@ func (x *Cart) String() string {
 func (*Cart) ProtoMessage() {}
 
 func (x *Cart) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[4]
+	mi := &file_onlineboutique_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -262,7 +338,7 @@ func (x *Cart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Cart.ProtoReflect.Descriptor instead.
 func (*Cart) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{4}
+	return file_onlineboutique_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Cart) GetUserId() string {
@@ -279,27 +355,32 @@ func (x *Cart) GetItems() []*CartItem {
 	return nil
 }
 
-type Empty struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+// CatalogUpdated is published when products change other than by restocks,
+// for caches of them to be dropped.
+type CatalogUpdated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The products that changed; empty if the whole catalog may have.
+	ProductIds    []string `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	Reason        string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Empty) Reset() {
-	*x = Empty{}
-	mi := &file_onlineboutique_proto_msgTypes[5]
+func (x *CatalogUpdated) Reset() {
+	*x = CatalogUpdated{}
+	mi := &file_onlineboutique_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Empty) String() string {
+func (x *CatalogUpdated) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Empty) ProtoMessage() {}
+func (*CatalogUpdated) ProtoMessage() {}
 
-func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[5]
+func (x *CatalogUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -310,77 +391,50 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
-func (*Empty) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{5}
-}
-
-type EmptyUser struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *EmptyUser) Reset() {
-	*x = EmptyUser{}
-	mi := &file_onlineboutique_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *EmptyUser) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use CatalogUpdated.ProtoReflect.Descriptor instead.
+func (*CatalogUpdated) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{6}
 }
 
-func (*EmptyUser) ProtoMessage() {}
-
-func (x *EmptyUser) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[6]
+func (x *CatalogUpdated) GetProductIds() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ProductIds
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use EmptyUser.ProtoReflect.Descriptor instead.
-func (*EmptyUser) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{6}
+	return nil
 }
 
-func (x *EmptyUser) GetUserId() string {
+func (x *CatalogUpdated) GetReason() string {
 	if x != nil {
-		return x.UserId
+		return x.Reason
 	}
 	return ""
 }
 
-type ListRecommendationsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	ProductIds    []string               `protobuf:"bytes,2,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+// CurrencyUpdated is published when the exchange rates or the supported
+// currencies change, for caches of them to be dropped.
+type CurrencyUpdated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The currencies supported from now on.
+	CurrencyCodes []string `protobuf:"bytes,1,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`
+	Reason        string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRecommendationsRequest) Reset() {
-	*x = ListRecommendationsRequest{}
+func (x *CurrencyUpdated) Reset() {
+	*x = CurrencyUpdated{}
 	mi := &file_onlineboutique_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRecommendationsRequest) String() string {
+func (x *CurrencyUpdated) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRecommendationsRequest) ProtoMessage() {}
+func (*CurrencyUpdated) ProtoMessage() {}
 
-func (x *ListRecommendationsRequest) ProtoReflect() protoreflect.Message {
+func (x *CurrencyUpdated) ProtoReflect() protoreflect.Message {
 	mi := &file_onlineboutique_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -392,46 +446,55 @@ func (x *ListRecommendationsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRecommendationsRequest.ProtoReflect.Descriptor instead.
-func (*ListRecommendationsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CurrencyUpdated.ProtoReflect.Descriptor instead.
+func (*CurrencyUpdated) Descriptor() ([]byte, []int) {
 	return file_onlineboutique_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListRecommendationsRequest) GetUserId() string {
+func (x *CurrencyUpdated) GetCurrencyCodes() []string {
 	if x != nil {
-		return x.UserId
+		return x.CurrencyCodes
 	}
-	return ""
+	return nil
 }
 
-func (x *ListRecommendationsRequest) GetProductIds() []string {
+func (x *CurrencyUpdated) GetReason() string {
 	if x != nil {
-		return x.ProductIds
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-type ListRecommendationsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductIds    []string               `protobuf:"bytes,1,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+// CartAbandoned is published when a cart was left untouched for longer
+// than CART_ABANDONED_AFTER.
+type CartAbandoned struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Cart  *Cart                  `protobuf:"bytes,1,opt,name=cart,proto3" json:"cart,omitempty"`
+	// Unix time the cart was last changed.
+	UpdatedAt int64 `protobuf:"varint,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// The address and language of the shopper's last order, if they placed
+	// one; the cart is not otherwise tied to an address.
+	Email         string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Locale        string `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	Tenant        string `protobuf:"bytes,5,opt,name=tenant,proto3" json:"tenant,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRecommendationsResponse) Reset() {
-	*x = ListRecommendationsResponse{}
+func (x *CartAbandoned) Reset() {
+	*x = CartAbandoned{}
 	mi := &file_onlineboutique_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRecommendationsResponse) String() string {
+func (x *CartAbandoned) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRecommendationsResponse) ProtoMessage() {}
+func (*CartAbandoned) ProtoMessage() {}
 
-func (x *ListRecommendationsResponse) ProtoReflect() protoreflect.Message {
+func (x *CartAbandoned) ProtoReflect() protoreflect.Message {
 	mi := &file_onlineboutique_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -443,126 +506,67 @@ func (x *ListRecommendationsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRecommendationsResponse.ProtoReflect.Descriptor instead.
-func (*ListRecommendationsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CartAbandoned.ProtoReflect.Descriptor instead.
+func (*CartAbandoned) Descriptor() ([]byte, []int) {
 	return file_onlineboutique_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListRecommendationsResponse) GetProductIds() []string {
+func (x *CartAbandoned) GetCart() *Cart {
 	if x != nil {
-		return x.ProductIds
+		return x.Cart
 	}
 	return nil
 }
 
-type Product struct {
-	state       protoimpl.MessageState `protogen:"open.v1"`
-	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Picture     string                 `protobuf:"bytes,4,opt,name=picture,proto3" json:"picture,omitempty"`
-	PriceUsd    *Money                 `protobuf:"bytes,5,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
-	// Categories such as "clothing" or "kitchen" that can be used to look up
-	// other related products.
-	Categories    []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *Product) Reset() {
-	*x = Product{}
-	mi := &file_onlineboutique_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *Product) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Product) ProtoMessage() {}
-
-func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[9]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Product.ProtoReflect.Descriptor instead.
-func (*Product) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *Product) GetId() string {
+func (x *CartAbandoned) GetUpdatedAt() int64 {
 	if x != nil {
-		return x.Id
+		return x.UpdatedAt
 	}
-	return ""
+	return 0
 }
 
-func (x *Product) GetName() string {
+func (x *CartAbandoned) GetEmail() string {
 	if x != nil {
-		return x.Name
+		return x.Email
 	}
 	return ""
 }
 
-func (x *Product) GetDescription() string {
+func (x *CartAbandoned) GetLocale() string {
 	if x != nil {
-		return x.Description
+		return x.Locale
 	}
 	return ""
 }
 
-func (x *Product) GetPicture() string {
+func (x *CartAbandoned) GetTenant() string {
 	if x != nil {
-		return x.Picture
+		return x.Tenant
 	}
 	return ""
 }
 
-func (x *Product) GetPriceUsd() *Money {
-	if x != nil {
-		return x.PriceUsd
-	}
-	return nil
-}
-
-func (x *Product) GetCategories() []string {
-	if x != nil {
-		return x.Categories
-	}
-	return nil
-}
-
-type ListProductsResponse struct {
+type Empty struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[10]
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_onlineboutique_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *Empty) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*Empty) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[10]
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -573,40 +577,6795 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
-	if x != nil {
+type EmptyUser struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyUser) Reset() {
+	*x = EmptyUser{}
+	mi := &file_onlineboutique_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyUser) ProtoMessage() {}
+
+func (x *EmptyUser) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyUser.ProtoReflect.Descriptor instead.
+func (*EmptyUser) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EmptyUser) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListRecommendationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId     string   `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProductIds []string `protobuf:"bytes,2,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	// Seeds recommendations for sessions without product history.
+	PageContext   *PageContext `protobuf:"bytes,3,opt,name=page_context,json=pageContext,proto3" json:"page_context,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecommendationsRequest) Reset() {
+	*x = ListRecommendationsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecommendationsRequest) ProtoMessage() {}
+
+func (x *ListRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRecommendationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListRecommendationsRequest) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *ListRecommendationsRequest) GetPageContext() *PageContext {
+	if x != nil {
+		return x.PageContext
+	}
+	return nil
+}
+
+// PageContext describes the page recommendations are shown on.
+type PageContext struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Categories the page is about, most relevant first.
+	Categories    []string `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PageContext) Reset() {
+	*x = PageContext{}
+	mi := &file_onlineboutique_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageContext) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageContext) ProtoMessage() {}
+
+func (x *PageContext) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageContext.ProtoReflect.Descriptor instead.
+func (*PageContext) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PageContext) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type ListRecommendationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Recommended products, best first.
+	Recommendations []*Recommendation `protobuf:"bytes,1,rep,name=recommendations,proto3" json:"recommendations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListRecommendationsResponse) Reset() {
+	*x = ListRecommendationsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecommendationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecommendationsResponse) ProtoMessage() {}
+
+func (x *ListRecommendationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecommendationsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecommendationsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListRecommendationsResponse) GetRecommendations() []*Recommendation {
+	if x != nil {
+		return x.Recommendations
+	}
+	return nil
+}
+
+// Recommendation is a recommended product and why it was chosen.
+type Recommendation struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// "BOUGHT_TOGETHER", "SIMILAR", "IN_PAGE_CATEGORY" or
+	// "POPULAR_IN_CATEGORY"; empty if the product was picked at random.
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// What the reason refers to: the name of the product it is bought with
+	// or similar to, or the category it belongs to or is popular in.
+	ReasonDetail  string `protobuf:"bytes,3,opt,name=reason_detail,json=reasonDetail,proto3" json:"reason_detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recommendation) Reset() {
+	*x = Recommendation{}
+	mi := &file_onlineboutique_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recommendation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recommendation) ProtoMessage() {}
+
+func (x *Recommendation) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recommendation.ProtoReflect.Descriptor instead.
+func (*Recommendation) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Recommendation) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *Recommendation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Recommendation) GetReasonDetail() string {
+	if x != nil {
+		return x.ReasonDetail
+	}
+	return ""
+}
+
+type Product struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Picture     string                 `protobuf:"bytes,4,opt,name=picture,proto3" json:"picture,omitempty"`
+	PriceUsd    *Money                 `protobuf:"bytes,5,opt,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
+	// Categories such as "clothing" or "kitchen" that can be used to look up
+	// other related products.
+	Categories []string `protobuf:"bytes,6,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Scaled-down variants of picture.
+	Thumbnail *ProductImage `protobuf:"bytes,7,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	Medium    *ProductImage `protobuf:"bytes,8,opt,name=medium,proto3" json:"medium,omitempty"`
+	// A sale price, charged instead of price_usd from sale_start until
+	// sale_end, in Unix seconds. A zero start or end leaves the sale open on
+	// that side.
+	SalePriceUsd  *Money `protobuf:"bytes,9,opt,name=sale_price_usd,json=salePriceUsd,proto3" json:"sale_price_usd,omitempty"`
+	SaleStart     int64  `protobuf:"varint,10,opt,name=sale_start,json=saleStart,proto3" json:"sale_start,omitempty"`
+	SaleEnd       int64  `protobuf:"varint,11,opt,name=sale_end,json=saleEnd,proto3" json:"sale_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	mi := &file_onlineboutique_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Product) GetPicture() string {
+	if x != nil {
+		return x.Picture
+	}
+	return ""
+}
+
+func (x *Product) GetPriceUsd() *Money {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return nil
+}
+
+func (x *Product) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Product) GetThumbnail() *ProductImage {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return nil
+}
+
+func (x *Product) GetMedium() *ProductImage {
+	if x != nil {
+		return x.Medium
+	}
+	return nil
+}
+
+func (x *Product) GetSalePriceUsd() *Money {
+	if x != nil {
+		return x.SalePriceUsd
+	}
+	return nil
+}
+
+func (x *Product) GetSaleStart() int64 {
+	if x != nil {
+		return x.SaleStart
+	}
+	return 0
+}
+
+func (x *Product) GetSaleEnd() int64 {
+	if x != nil {
+		return x.SaleEnd
+	}
+	return 0
+}
+
+type ProductImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Width         int32                  `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductImage) Reset() {
+	*x = ProductImage{}
+	mi := &file_onlineboutique_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductImage) ProtoMessage() {}
+
+func (x *ProductImage) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
+func (*ProductImage) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ProductImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductImage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// ProductVariant is a purchasable SKU of a product, such as one size or color.
+type ProductVariant struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Attributes as "name=value" pairs, e.g. "size=M".
+	Attributes []string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	Stock      int32    `protobuf:"varint,4,opt,name=stock,proto3" json:"stock,omitempty"`
+	// Added to the product's price_usd.
+	PriceDeltaUsd *Money `protobuf:"bytes,5,opt,name=price_delta_usd,json=priceDeltaUsd,proto3" json:"price_delta_usd,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductVariant) Reset() {
+	*x = ProductVariant{}
+	mi := &file_onlineboutique_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductVariant) ProtoMessage() {}
+
+func (x *ProductVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
+func (*ProductVariant) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ProductVariant) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetAttributes() []string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetPriceDeltaUsd() *Money {
+	if x != nil {
+		return x.PriceDeltaUsd
+	}
+	return nil
+}
+
+type ListVariantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVariantsRequest) Reset() {
+	*x = ListVariantsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVariantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVariantsRequest) ProtoMessage() {}
+
+func (x *ListVariantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVariantsRequest.ProtoReflect.Descriptor instead.
+func (*ListVariantsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListVariantsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type ListVariantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Variants      []*ProductVariant      `protobuf:"bytes,1,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVariantsResponse) Reset() {
+	*x = ListVariantsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVariantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVariantsResponse) ProtoMessage() {}
+
+func (x *ListVariantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVariantsResponse.ProtoReflect.Descriptor instead.
+func (*ListVariantsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListVariantsResponse) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type GetVariantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantId     string                 `protobuf:"bytes,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVariantRequest) Reset() {
+	*x = GetVariantRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVariantRequest) ProtoMessage() {}
+
+func (x *GetVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVariantRequest.ProtoReflect.Descriptor instead.
+func (*GetVariantRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetVariantRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetVariantRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+type GetStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockRequest) Reset() {
+	*x = GetStockRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockRequest) ProtoMessage() {}
+
+func (x *GetStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockRequest.ProtoReflect.Descriptor instead.
+func (*GetStockRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetStockRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+type StockLevel struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Whether the stock of the product is tracked. Only that of products
+	// sold in variants is, and none is when inventory tracking is off.
+	Tracked bool `protobuf:"varint,2,opt,name=tracked,proto3" json:"tracked,omitempty"`
+	// Units left to sell, over every variant.
+	Quantity      int32 `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLevel) Reset() {
+	*x = StockLevel{}
+	mi := &file_onlineboutique_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLevel) ProtoMessage() {}
+
+func (x *StockLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLevel.ProtoReflect.Descriptor instead.
+func (*StockLevel) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StockLevel) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *StockLevel) GetTracked() bool {
+	if x != nil {
+		return x.Tracked
+	}
+	return false
+}
+
+func (x *StockLevel) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type RestockVariantRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantId     string                 `protobuf:"bytes,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestockVariantRequest) Reset() {
+	*x = RestockVariantRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestockVariantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestockVariantRequest) ProtoMessage() {}
+
+func (x *RestockVariantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestockVariantRequest.ProtoReflect.Descriptor instead.
+func (*RestockVariantRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RestockVariantRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RestockVariantRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *RestockVariantRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// NotifyWhenAvailableRequest asks for an email once a sold out product can be
+// bought again. Without a variant_id, any variant of the product coming back
+// in stock is enough.
+type NotifyWhenAvailableRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	VariantId     string                 `protobuf:"bytes,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyWhenAvailableRequest) Reset() {
+	*x = NotifyWhenAvailableRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyWhenAvailableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyWhenAvailableRequest) ProtoMessage() {}
+
+func (x *NotifyWhenAvailableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyWhenAvailableRequest.ProtoReflect.Descriptor instead.
+func (*NotifyWhenAvailableRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *NotifyWhenAvailableRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *NotifyWhenAvailableRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *NotifyWhenAvailableRequest) GetVariantId() string {
+	if x != nil {
+		return x.VariantId
+	}
+	return ""
+}
+
+func (x *NotifyWhenAvailableRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+// PricingRule is a promotion applied to the lines of orders at checkout.
+// BULK takes percent_off off lines of at least min_quantity units,
+// CATEGORY_SALE takes percent_off off every line of category, and BOGO makes
+// every second unit of a line free. A rule applies to the products listed
+// in product_ids, or else to those of category, or else to all of them.
+type PricingRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind  string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Shown to shoppers next to the adjustments the rule makes.
+	Description string   `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ProductIds  []string `protobuf:"bytes,4,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	Category    string   `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	MinQuantity int32    `protobuf:"varint,6,opt,name=min_quantity,json=minQuantity,proto3" json:"min_quantity,omitempty"`
+	// Whole percent, between 1 and 100.
+	PercentOff int32 `protobuf:"varint,7,opt,name=percent_off,json=percentOff,proto3" json:"percent_off,omitempty"`
+	// Unix times the rule is in effect from and until; zero is unbounded.
+	Start         int64 `protobuf:"varint,8,opt,name=start,proto3" json:"start,omitempty"`
+	End           int64 `protobuf:"varint,9,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricingRule) Reset() {
+	*x = PricingRule{}
+	mi := &file_onlineboutique_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricingRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricingRule) ProtoMessage() {}
+
+func (x *PricingRule) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricingRule.ProtoReflect.Descriptor instead.
+func (*PricingRule) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PricingRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PricingRule) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PricingRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PricingRule) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *PricingRule) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *PricingRule) GetMinQuantity() int32 {
+	if x != nil {
+		return x.MinQuantity
+	}
+	return 0
+}
+
+func (x *PricingRule) GetPercentOff() int32 {
+	if x != nil {
+		return x.PercentOff
+	}
+	return 0
+}
+
+func (x *PricingRule) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *PricingRule) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type PricingRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*PricingRule         `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricingRules) Reset() {
+	*x = PricingRules{}
+	mi := &file_onlineboutique_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricingRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricingRules) ProtoMessage() {}
+
+func (x *PricingRules) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricingRules.ProtoReflect.Descriptor instead.
+func (*PricingRules) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *PricingRules) GetRules() []*PricingRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// PriceChange puts products on sale for a window of time, such as a flash
+// sale: at sale_price_usd, or else percent_off their price. The catalog sets
+// the sale price of the products when the window starts, and restores the
+// sale they had before when it ends.
+type PriceChange struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Description  string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ProductIds   []string               `protobuf:"bytes,3,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	SalePriceUsd *Money                 `protobuf:"bytes,4,opt,name=sale_price_usd,json=salePriceUsd,proto3" json:"sale_price_usd,omitempty"`
+	// Whole percent, between 1 and 99.
+	PercentOff int32 `protobuf:"varint,5,opt,name=percent_off,json=percentOff,proto3" json:"percent_off,omitempty"`
+	// Unix times the change is in effect from and until.
+	Start int64 `protobuf:"varint,6,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,7,opt,name=end,proto3" json:"end,omitempty"`
+	// PENDING, ACTIVE or ENDED, in responses.
+	Status        string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_onlineboutique_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PriceChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceChange) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PriceChange) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *PriceChange) GetSalePriceUsd() *Money {
+	if x != nil {
+		return x.SalePriceUsd
+	}
+	return nil
+}
+
+func (x *PriceChange) GetPercentOff() int32 {
+	if x != nil {
+		return x.PercentOff
+	}
+	return 0
+}
+
+func (x *PriceChange) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *PriceChange) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *PriceChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type PriceChanges struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*PriceChange         `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChanges) Reset() {
+	*x = PriceChanges{}
+	mi := &file_onlineboutique_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChanges) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChanges) ProtoMessage() {}
+
+func (x *PriceChanges) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChanges.ProtoReflect.Descriptor instead.
+func (*PriceChanges) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PriceChanges) GetChanges() []*PriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type ListPriceChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only list the changes with this status. Without one, the pending and
+	// active changes are listed.
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceChangesRequest) Reset() {
+	*x = ListPriceChangesRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceChangesRequest) ProtoMessage() {}
+
+func (x *ListPriceChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceChangesRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListPriceChangesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ReserveStockRequest holds the stock of the variants among items for an
+// order until it is committed, released, or expires. Items without a
+// variant are not stocked and are left out.
+type ReserveStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReserveStockRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationRequest) Reset() {
+	*x = ReservationRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationRequest) ProtoMessage() {}
+
+func (x *ReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReservationRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReservationRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type StockReservation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items   []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Unix time the reservation expires at, unless committed first.
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// HELD, COMMITTED or RELEASED.
+	Status        string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockReservation) Reset() {
+	*x = StockReservation{}
+	mi := &file_onlineboutique_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockReservation) ProtoMessage() {}
+
+func (x *StockReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockReservation.ProtoReflect.Descriptor instead.
+func (*StockReservation) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StockReservation) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *StockReservation) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *StockReservation) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *StockReservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// ProductRestocked is published once per waiting subscriber when a sold out
+// variant is restocked.
+type ProductRestocked struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Variant       *ProductVariant        `protobuf:"bytes,2,opt,name=variant,proto3" json:"variant,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Locale        string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductRestocked) Reset() {
+	*x = ProductRestocked{}
+	mi := &file_onlineboutique_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductRestocked) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductRestocked) ProtoMessage() {}
+
+func (x *ProductRestocked) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductRestocked.ProtoReflect.Descriptor instead.
+func (*ProductRestocked) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ProductRestocked) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ProductRestocked) GetVariant() *ProductVariant {
+	if x != nil {
+		return x.Variant
+	}
+	return nil
+}
+
+func (x *ProductRestocked) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ProductRestocked) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+type GetProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductRequest) Reset() {
+	*x = GetProductRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductRequest) ProtoMessage() {}
+
+func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
+func (*GetProductRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetProductsRequest looks up several products at once. Products are returned
+// in the order of their IDs.
+type GetProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductsRequest) Reset() {
+	*x = GetProductsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductsRequest) ProtoMessage() {}
+
+func (x *GetProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductsRequest.ProtoReflect.Descriptor instead.
+func (*GetProductsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetProductsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type SearchProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Filters on the results: only products in category, and only products
+	// whose current price_usd is in price_range, one of the values of the
+	// price facet such as "25-50". Empty filters keep every result.
+	Category      string `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	PriceRange    string `protobuf:"bytes,3,opt,name=price_range,json=priceRange,proto3" json:"price_range,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetPriceRange() string {
+	if x != nil {
+		return x.PriceRange
+	}
+	return ""
+}
+
+type SearchProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*Product             `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Facets        *SearchFacets          `protobuf:"bytes,2,opt,name=facets,proto3" json:"facets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsResponse) ProtoMessage() {}
+
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SearchProductsResponse) GetResults() []*Product {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetFacets() *SearchFacets {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+// SearchFacets counts the results of a search by facet value. The counts of
+// a facet take every filter into account but the facet's own.
+type SearchFacets struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*FacetCount          `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFacets) Reset() {
+	*x = SearchFacets{}
+	mi := &file_onlineboutique_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFacets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacets) ProtoMessage() {}
+
+func (x *SearchFacets) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacets.ProtoReflect.Descriptor instead.
+func (*SearchFacets) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SearchFacets) GetCounts() []*FacetCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+// FacetCount is the number of results with a value of a facet, "category"
+// or "price".
+type FacetCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Facet         string                 `protobuf:"bytes,1,opt,name=facet,proto3" json:"facet,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	mi := &file_onlineboutique_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *FacetCount) GetFacet() string {
+	if x != nil {
+		return x.Facet
+	}
+	return ""
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// SuggestProductsRequest asks for the products whose name, or a word of it,
+// starts with prefix, for completing a search as it is typed. At most limit
+// are returned, 5 if unset.
+type SuggestProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestProductsRequest) Reset() {
+	*x = SuggestProductsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestProductsRequest) ProtoMessage() {}
+
+func (x *SuggestProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestProductsRequest.ProtoReflect.Descriptor instead.
+func (*SuggestProductsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SuggestProductsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SuggestProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*ProductSuggestion   `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestProductsResponse) Reset() {
+	*x = SuggestProductsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestProductsResponse) ProtoMessage() {}
+
+func (x *SuggestProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestProductsResponse.ProtoReflect.Descriptor instead.
+func (*SuggestProductsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SuggestProductsResponse) GetSuggestions() []*ProductSuggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type ProductSuggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductSuggestion) Reset() {
+	*x = ProductSuggestion{}
+	mi := &file_onlineboutique_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductSuggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductSuggestion) ProtoMessage() {}
+
+func (x *ProductSuggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductSuggestion.ProtoReflect.Descriptor instead.
+func (*ProductSuggestion) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ProductSuggestion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductSuggestion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ImportProductsRequest carries one chunk of a catalog import. Chunks that
+// share an import_id are staged until all chunk_count of them have arrived,
+// then validated and applied together.
+type ImportProductsRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ImportId   string                 `protobuf:"bytes,1,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`
+	ChunkIndex int32                  `protobuf:"varint,2,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	ChunkCount int32                  `protobuf:"varint,3,opt,name=chunk_count,json=chunkCount,proto3" json:"chunk_count,omitempty"`
+	Products   []*Product             `protobuf:"bytes,4,rep,name=products,proto3" json:"products,omitempty"`
+	// "REPLACE" swaps out the whole catalog, "MERGE" adds or updates products
+	// by ID. Defaults to MERGE.
+	Mode string `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Validate the import without applying it.
+	DryRun        bool `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsRequest) Reset() {
+	*x = ImportProductsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsRequest) ProtoMessage() {}
+
+func (x *ImportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ImportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ImportProductsRequest) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+func (x *ImportProductsRequest) GetChunkIndex() int32 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+func (x *ImportProductsRequest) GetChunkCount() int32 {
+	if x != nil {
+		return x.ChunkCount
+	}
+	return 0
+}
+
+func (x *ImportProductsRequest) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ImportProductsRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ImportProductsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type ImportProblem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProblem) Reset() {
+	*x = ImportProblem{}
+	mi := &file_onlineboutique_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProblem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProblem) ProtoMessage() {}
+
+func (x *ImportProblem) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProblem.ProtoReflect.Descriptor instead.
+func (*ImportProblem) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ImportProblem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ImportProblem) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportProductsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ImportId       string                 `protobuf:"bytes,1,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`
+	ChunksReceived int32                  `protobuf:"varint,2,opt,name=chunks_received,json=chunksReceived,proto3" json:"chunks_received,omitempty"`
+	// One of "STAGED", "VALIDATED", "APPLIED" or "REJECTED".
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// Number of products in the catalog after the import, or that it would
+	// have for a dry run.
+	ProductCount  int32            `protobuf:"varint,4,opt,name=product_count,json=productCount,proto3" json:"product_count,omitempty"`
+	Problems      []*ImportProblem `protobuf:"bytes,5,rep,name=problems,proto3" json:"problems,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsResponse) Reset() {
+	*x = ImportProductsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsResponse) ProtoMessage() {}
+
+func (x *ImportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ImportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ImportProductsResponse) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+func (x *ImportProductsResponse) GetChunksReceived() int32 {
+	if x != nil {
+		return x.ChunksReceived
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportProductsResponse) GetProductCount() int32 {
+	if x != nil {
+		return x.ProductCount
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetProblems() []*ImportProblem {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+type ExportProductsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int32                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Maximum number of products to return; 0 returns the rest.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportProductsRequest) Reset() {
+	*x = ExportProductsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsRequest) ProtoMessage() {}
+
+func (x *ExportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ExportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ExportProductsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ExportProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ExportProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportProductsResponse) Reset() {
+	*x = ExportProductsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsResponse) ProtoMessage() {}
+
+func (x *ExportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ExportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ExportProductsResponse) GetProducts() []*Product {
+	if x != nil {
 		return x.Products
 	}
-	return nil
+	return nil
+}
+
+func (x *ExportProductsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetQuoteRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Items   []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// The order subtotal in the shopper's currency, for the free shipping
+	// threshold. Optional.
+	Subtotal      *Money `protobuf:"bytes,3,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetQuoteRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *GetQuoteRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GetQuoteRequest) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+type GetQuoteResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	CostUsd *Money                 `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+	// The warehouse the order would ship from.
+	Origin *Warehouse `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	// Set when the subtotal qualifies for free shipping; cost_usd is then zero.
+	FreeShipping bool `protobuf:"varint,3,opt,name=free_shipping,json=freeShipping,proto3" json:"free_shipping,omitempty"`
+	// How much more, in the subtotal's currency, would qualify for free
+	// shipping. Unset if the order qualifies or the currency has no threshold.
+	FreeShippingRemaining *Money `protobuf:"bytes,4,opt,name=free_shipping_remaining,json=freeShippingRemaining,proto3" json:"free_shipping_remaining,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetQuoteResponse) Reset() {
+	*x = GetQuoteResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteResponse) ProtoMessage() {}
+
+func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteResponse.ProtoReflect.Descriptor instead.
+func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetQuoteResponse) GetCostUsd() *Money {
+	if x != nil {
+		return x.CostUsd
+	}
+	return nil
+}
+
+func (x *GetQuoteResponse) GetOrigin() *Warehouse {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+func (x *GetQuoteResponse) GetFreeShipping() bool {
+	if x != nil {
+		return x.FreeShipping
+	}
+	return false
+}
+
+func (x *GetQuoteResponse) GetFreeShippingRemaining() *Money {
+	if x != nil {
+		return x.FreeShippingRemaining
+	}
+	return nil
+}
+
+type ShipOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Items   []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Repeat calls with the same order ID return the same tracking ID.
+	OrderId string `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Where to send shipment notifications, and in which language.
+	Email  string `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Locale string `protobuf:"bytes,5,opt,name=locale,proto3" json:"locale,omitempty"`
+	// The shopper's session, notified on the storefront as the shipment
+	// progresses.
+	//
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId string `protobuf:"bytes,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Whether to gift-wrap the order, and the shopper's note to go with it.
+	GiftWrap bool   `protobuf:"varint,7,opt,name=gift_wrap,json=giftWrap,proto3" json:"gift_wrap,omitempty"`
+	Note     string `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	// For one shipment of an order split by PlanShipments: the warehouse it
+	// ships from and its part number, counted from 1. Orders shipped whole
+	// leave them unset.
+	WarehouseId string `protobuf:"bytes,9,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Part        int32  `protobuf:"varint,10,opt,name=part,proto3" json:"part,omitempty"`
+	// The delivery window the shopper chose, if any.
+	DeliveryWindow *DeliveryWindow `protobuf:"bytes,11,opt,name=delivery_window,json=deliveryWindow,proto3" json:"delivery_window,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ShipOrderRequest) Reset() {
+	*x = ShipOrderRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipOrderRequest) ProtoMessage() {}
+
+func (x *ShipOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipOrderRequest.ProtoReflect.Descriptor instead.
+func (*ShipOrderRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ShipOrderRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ShipOrderRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ShipOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ShipOrderRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ShipOrderRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ShipOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShipOrderRequest) GetGiftWrap() bool {
+	if x != nil {
+		return x.GiftWrap
+	}
+	return false
+}
+
+func (x *ShipOrderRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *ShipOrderRequest) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ShipOrderRequest) GetPart() int32 {
+	if x != nil {
+		return x.Part
+	}
+	return 0
+}
+
+func (x *ShipOrderRequest) GetDeliveryWindow() *DeliveryWindow {
+	if x != nil {
+		return x.DeliveryWindow
+	}
+	return nil
+}
+
+type PlanShipmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanShipmentsRequest) Reset() {
+	*x = PlanShipmentsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanShipmentsRequest) ProtoMessage() {}
+
+func (x *PlanShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*PlanShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *PlanShipmentsRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *PlanShipmentsRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ShipmentGroup is the items of an order that ship together.
+type ShipmentGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty if there are no warehouses.
+	WarehouseId string      `protobuf:"bytes,1,opt,name=warehouse_id,json=warehouseId,proto3" json:"warehouse_id,omitempty"`
+	Items       []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Set once the group has shipped.
+	TrackingId    string `protobuf:"bytes,3,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentGroup) Reset() {
+	*x = ShipmentGroup{}
+	mi := &file_onlineboutique_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentGroup) ProtoMessage() {}
+
+func (x *ShipmentGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentGroup.ProtoReflect.Descriptor instead.
+func (*ShipmentGroup) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ShipmentGroup) GetWarehouseId() string {
+	if x != nil {
+		return x.WarehouseId
+	}
+	return ""
+}
+
+func (x *ShipmentGroup) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ShipmentGroup) GetTrackingId() string {
+	if x != nil {
+		return x.TrackingId
+	}
+	return ""
+}
+
+type ShipmentGroups struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*ShipmentGroup       `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentGroups) Reset() {
+	*x = ShipmentGroups{}
+	mi := &file_onlineboutique_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentGroups) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentGroups) ProtoMessage() {}
+
+func (x *ShipmentGroups) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentGroups.ProtoReflect.Descriptor instead.
+func (*ShipmentGroups) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ShipmentGroups) GetGroups() []*ShipmentGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type GetDeliveryOptionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional: before an address is known, windows are offered for a
+	// domestic delivery.
+	Address       *Address    `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Items         []*CartItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeliveryOptionsRequest) Reset() {
+	*x = GetDeliveryOptionsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeliveryOptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeliveryOptionsRequest) ProtoMessage() {}
+
+func (x *GetDeliveryOptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeliveryOptionsRequest.ProtoReflect.Descriptor instead.
+func (*GetDeliveryOptionsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetDeliveryOptionsRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *GetDeliveryOptionsRequest) GetItems() []*CartItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// DeliveryWindow is a span of days, as YYYY-MM-DD dates in UTC, both
+// included, in which an order is to be delivered.
+type DeliveryWindow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     string                 `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       string                 `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryWindow) Reset() {
+	*x = DeliveryWindow{}
+	mi := &file_onlineboutique_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryWindow) ProtoMessage() {}
+
+func (x *DeliveryWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryWindow.ProtoReflect.Descriptor instead.
+func (*DeliveryWindow) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *DeliveryWindow) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *DeliveryWindow) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+// DeliveryOptions are the windows a shopper can choose from, earliest first.
+type DeliveryOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Windows       []*DeliveryWindow      `protobuf:"bytes,1,rep,name=windows,proto3" json:"windows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeliveryOptions) Reset() {
+	*x = DeliveryOptions{}
+	mi := &file_onlineboutique_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryOptions) ProtoMessage() {}
+
+func (x *DeliveryOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryOptions.ProtoReflect.Descriptor instead.
+func (*DeliveryOptions) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeliveryOptions) GetWindows() []*DeliveryWindow {
+	if x != nil {
+		return x.Windows
+	}
+	return nil
+}
+
+type ShipOrderResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TrackingId string                 `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	// The warehouse the order ships from.
+	Origin        *Warehouse `protobuf:"bytes,2,opt,name=origin,proto3" json:"origin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipOrderResponse) Reset() {
+	*x = ShipOrderResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipOrderResponse) ProtoMessage() {}
+
+func (x *ShipOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipOrderResponse.ProtoReflect.Descriptor instead.
+func (*ShipOrderResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ShipOrderResponse) GetTrackingId() string {
+	if x != nil {
+		return x.TrackingId
+	}
+	return ""
+}
+
+func (x *ShipOrderResponse) GetOrigin() *Warehouse {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+type Warehouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Address       *Address               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Warehouse) Reset() {
+	*x = Warehouse{}
+	mi := &file_onlineboutique_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Warehouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Warehouse) ProtoMessage() {}
+
+func (x *Warehouse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Warehouse.ProtoReflect.Descriptor instead.
+func (*Warehouse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *Warehouse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Warehouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Warehouse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type GetShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackingId    string                 `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShipmentRequest) Reset() {
+	*x = GetShipmentRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShipmentRequest) ProtoMessage() {}
+
+func (x *GetShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShipmentRequest.ProtoReflect.Descriptor instead.
+func (*GetShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetShipmentRequest) GetTrackingId() string {
+	if x != nil {
+		return x.TrackingId
+	}
+	return ""
+}
+
+// CancelShipmentRequest cancels a shipment the carrier has not picked up yet,
+// as when the order it is part of fails after it was created.
+type CancelShipmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TrackingId    string                 `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelShipmentRequest) Reset() {
+	*x = CancelShipmentRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelShipmentRequest) ProtoMessage() {}
+
+func (x *CancelShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CancelShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CancelShipmentRequest) GetTrackingId() string {
+	if x != nil {
+		return x.TrackingId
+	}
+	return ""
+}
+
+func (x *CancelShipmentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type Shipment struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TrackingId string                 `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+	OrderId    string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// One of LABEL_CREATED, PICKED_UP, IN_TRANSIT, OUT_FOR_DELIVERY or
+	// DELIVERED, or CANCELLED if it was cancelled before it was picked up.
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	// Unix seconds.
+	CreatedAt     int64      `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64      `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Origin        *Warehouse `protobuf:"bytes,6,opt,name=origin,proto3" json:"origin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_onlineboutique_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *Shipment) GetTrackingId() string {
+	if x != nil {
+		return x.TrackingId
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Shipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Shipment) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Shipment) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Shipment) GetOrigin() *Warehouse {
+	if x != nil {
+		return x.Origin
+	}
+	return nil
+}
+
+// Published on the event bus whenever a shipment changes status.
+type ShipmentStatusChanged struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Shipment       *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,2,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Locale         string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	UserId         string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Tenant the shipment was created for.
+	Tenant        string `protobuf:"bytes,6,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentStatusChanged) Reset() {
+	*x = ShipmentStatusChanged{}
+	mi := &file_onlineboutique_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentStatusChanged) ProtoMessage() {}
+
+func (x *ShipmentStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentStatusChanged.ProtoReflect.Descriptor instead.
+func (*ShipmentStatusChanged) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ShipmentStatusChanged) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+func (x *ShipmentStatusChanged) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *ShipmentStatusChanged) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ShipmentStatusChanged) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *ShipmentStatusChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShipmentStatusChanged) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type Address struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StreetAddress string                 `protobuf:"bytes,1,opt,name=street_address,json=streetAddress,proto3" json:"street_address,omitempty"`
+	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Country       string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
+	ZipCode       int32                  `protobuf:"varint,5,opt,name=zip_code,json=zipCode,proto3" json:"zip_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_onlineboutique_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *Address) GetStreetAddress() string {
+	if x != nil {
+		return x.StreetAddress
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetZipCode() int32 {
+	if x != nil {
+		return x.ZipCode
+	}
+	return 0
+}
+
+type ValidateAddressRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Address *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAddressRequest) Reset() {
+	*x = ValidateAddressRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAddressRequest) ProtoMessage() {}
+
+func (x *ValidateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAddressRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ValidateAddressRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *ValidateAddressRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// A problem with a single address field, for inline display.
+type AddressProblem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the Address field, e.g. "zip_code".
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Machine readable reason, e.g. "required" or "invalid_format".
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddressProblem) Reset() {
+	*x = AddressProblem{}
+	mi := &file_onlineboutique_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddressProblem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressProblem) ProtoMessage() {}
+
+func (x *AddressProblem) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressProblem.ProtoReflect.Descriptor instead.
+func (*AddressProblem) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *AddressProblem) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *AddressProblem) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AddressProblem) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ValidateAddressResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The address with whitespace, casing, state and country normalized.
+	Normalized *Address `protobuf:"bytes,1,opt,name=normalized,proto3" json:"normalized,omitempty"`
+	// Empty if the address is valid.
+	Problems      []*AddressProblem `protobuf:"bytes,2,rep,name=problems,proto3" json:"problems,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAddressResponse) Reset() {
+	*x = ValidateAddressResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAddressResponse) ProtoMessage() {}
+
+func (x *ValidateAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAddressResponse.ProtoReflect.Descriptor instead.
+func (*ValidateAddressResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ValidateAddressResponse) GetNormalized() *Address {
+	if x != nil {
+		return x.Normalized
+	}
+	return nil
+}
+
+func (x *ValidateAddressResponse) GetProblems() []*AddressProblem {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+// Represents an amount of money with its currency type.
+type Money struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 3-letter currency code defined in ISO 4217.
+	CurrencyCode string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	// The whole units of the amount.
+	// For example if `currencyCode` is `"USD"`, then 1 unit is one US dollar.
+	Units int64 `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
+	// Number of nano (10^-9) units of the amount.
+	// The value must be between -999,999,999 and +999,999,999 inclusive.
+	// If `units` is positive, `nanos` must be positive or zero.
+	// If `units` is zero, `nanos` can be positive, zero, or negative.
+	// If `units` is negative, `nanos` must be negative or zero.
+	// For example $-1.75 is represented as `units`=-1 and `nanos`=-750,000,000.
+	Nanos         int32 `protobuf:"varint,3,opt,name=nanos,proto3" json:"nanos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_onlineboutique_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Money) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Money) GetUnits() int64 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *Money) GetNanos() int32 {
+	if x != nil {
+		return x.Nanos
+	}
+	return 0
+}
+
+type GetSupportedCurrenciesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 3-letter currency code defined in ISO 4217.
+	CurrencyCodes []string `protobuf:"bytes,1,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSupportedCurrenciesResponse) Reset() {
+	*x = GetSupportedCurrenciesResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSupportedCurrenciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSupportedCurrenciesResponse) ProtoMessage() {}
+
+func (x *GetSupportedCurrenciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSupportedCurrenciesResponse.ProtoReflect.Descriptor instead.
+func (*GetSupportedCurrenciesResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetSupportedCurrenciesResponse) GetCurrencyCodes() []string {
+	if x != nil {
+		return x.CurrencyCodes
+	}
+	return nil
+}
+
+type CurrencyConversionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	From  *Money                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// The 3-letter currency code defined in ISO 4217.
+	ToCode string `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyConversionRequest) Reset() {
+	*x = CurrencyConversionRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyConversionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyConversionRequest) ProtoMessage() {}
+
+func (x *CurrencyConversionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyConversionRequest.ProtoReflect.Descriptor instead.
+func (*CurrencyConversionRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CurrencyConversionRequest) GetFrom() *Money {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *CurrencyConversionRequest) GetToCode() string {
+	if x != nil {
+		return x.ToCode
+	}
+	return ""
+}
+
+func (x *CurrencyConversionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CurrencyConversionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Money *Money                 `protobuf:"bytes,1,opt,name=money,proto3" json:"money,omitempty"`
+	// The from -> to rate that was applied, as a decimal string.
+	AppliedRate   string `protobuf:"bytes,2,opt,name=applied_rate,json=appliedRate,proto3" json:"applied_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CurrencyConversionResponse) Reset() {
+	*x = CurrencyConversionResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CurrencyConversionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CurrencyConversionResponse) ProtoMessage() {}
+
+func (x *CurrencyConversionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CurrencyConversionResponse.ProtoReflect.Descriptor instead.
+func (*CurrencyConversionResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CurrencyConversionResponse) GetMoney() *Money {
+	if x != nil {
+		return x.Money
+	}
+	return nil
+}
+
+func (x *CurrencyConversionResponse) GetAppliedRate() string {
+	if x != nil {
+		return x.AppliedRate
+	}
+	return ""
+}
+
+type ExchangeRateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The 3-letter currency codes defined in ISO 4217.
+	FromCode string `protobuf:"bytes,1,opt,name=from_code,json=fromCode,proto3" json:"from_code,omitempty"`
+	ToCode   string `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRateRequest) Reset() {
+	*x = ExchangeRateRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRateRequest) ProtoMessage() {}
+
+func (x *ExchangeRateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRateRequest.ProtoReflect.Descriptor instead.
+func (*ExchangeRateRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ExchangeRateRequest) GetFromCode() string {
+	if x != nil {
+		return x.FromCode
+	}
+	return ""
+}
+
+func (x *ExchangeRateRequest) GetToCode() string {
+	if x != nil {
+		return x.ToCode
+	}
+	return ""
+}
+
+func (x *ExchangeRateRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExchangeRateResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FromCode string                 `protobuf:"bytes,1,opt,name=from_code,json=fromCode,proto3" json:"from_code,omitempty"`
+	ToCode   string                 `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// The from -> to rate as a decimal string.
+	Rate string `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Where the rate data was loaded from.
+	Source string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	// When the rate data was last updated, in Unix seconds.
+	LastUpdated   int64 `protobuf:"varint,5,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExchangeRateResponse) Reset() {
+	*x = ExchangeRateResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExchangeRateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExchangeRateResponse) ProtoMessage() {}
+
+func (x *ExchangeRateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExchangeRateResponse.ProtoReflect.Descriptor instead.
+func (*ExchangeRateResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ExchangeRateResponse) GetFromCode() string {
+	if x != nil {
+		return x.FromCode
+	}
+	return ""
+}
+
+func (x *ExchangeRateResponse) GetToCode() string {
+	if x != nil {
+		return x.ToCode
+	}
+	return ""
+}
+
+func (x *ExchangeRateResponse) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *ExchangeRateResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ExchangeRateResponse) GetLastUpdated() int64 {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return 0
+}
+
+type RateAtRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The day to look up, formatted as YYYY-MM-DD (UTC). The rate is the
+	// last one in effect that day.
+	Date     string `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	FromCode string `protobuf:"bytes,2,opt,name=from_code,json=fromCode,proto3" json:"from_code,omitempty"`
+	ToCode   string `protobuf:"bytes,3,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// The moment to look up, in Unix seconds, used instead of date if set.
+	At            int64 `protobuf:"varint,5,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateAtRequest) Reset() {
+	*x = RateAtRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateAtRequest) ProtoMessage() {}
+
+func (x *RateAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateAtRequest.ProtoReflect.Descriptor instead.
+func (*RateAtRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RateAtRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *RateAtRequest) GetFromCode() string {
+	if x != nil {
+		return x.FromCode
+	}
+	return ""
+}
+
+func (x *RateAtRequest) GetToCode() string {
+	if x != nil {
+		return x.ToCode
+	}
+	return ""
+}
+
+func (x *RateAtRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RateAtRequest) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+type CreditCardInfo struct {
+	state                     protoimpl.MessageState `protogen:"open.v1"`
+	CreditCardNumber          string                 `protobuf:"bytes,1,opt,name=credit_card_number,json=creditCardNumber,proto3" json:"credit_card_number,omitempty"`
+	CreditCardCvv             int32                  `protobuf:"varint,2,opt,name=credit_card_cvv,json=creditCardCvv,proto3" json:"credit_card_cvv,omitempty"`
+	CreditCardExpirationYear  int32                  `protobuf:"varint,3,opt,name=credit_card_expiration_year,json=creditCardExpirationYear,proto3" json:"credit_card_expiration_year,omitempty"`
+	CreditCardExpirationMonth int32                  `protobuf:"varint,4,opt,name=credit_card_expiration_month,json=creditCardExpirationMonth,proto3" json:"credit_card_expiration_month,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *CreditCardInfo) Reset() {
+	*x = CreditCardInfo{}
+	mi := &file_onlineboutique_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditCardInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditCardInfo) ProtoMessage() {}
+
+func (x *CreditCardInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditCardInfo.ProtoReflect.Descriptor instead.
+func (*CreditCardInfo) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *CreditCardInfo) GetCreditCardNumber() string {
+	if x != nil {
+		return x.CreditCardNumber
+	}
+	return ""
+}
+
+func (x *CreditCardInfo) GetCreditCardCvv() int32 {
+	if x != nil {
+		return x.CreditCardCvv
+	}
+	return 0
+}
+
+func (x *CreditCardInfo) GetCreditCardExpirationYear() int32 {
+	if x != nil {
+		return x.CreditCardExpirationYear
+	}
+	return 0
+}
+
+func (x *CreditCardInfo) GetCreditCardExpirationMonth() int32 {
+	if x != nil {
+		return x.CreditCardExpirationMonth
+	}
+	return 0
+}
+
+type ChargeRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Amount     *Money                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreditCard *CreditCardInfo        `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Number of monthly payments to spread the amount over. Zero or one
+	// pays it at once.
+	Installments int32 `protobuf:"varint,4,opt,name=installments,proto3" json:"installments,omitempty"`
+	// The order the charge pays for, recorded with the transaction so that
+	// later changes to it can be traced to the order. Optional.
+	OrderId       string `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChargeRequest) Reset() {
+	*x = ChargeRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargeRequest) ProtoMessage() {}
+
+func (x *ChargeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargeRequest.ProtoReflect.Descriptor instead.
+func (*ChargeRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ChargeRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ChargeRequest) GetCreditCard() *CreditCardInfo {
+	if x != nil {
+		return x.CreditCard
+	}
+	return nil
+}
+
+func (x *ChargeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ChargeRequest) GetInstallments() int32 {
+	if x != nil {
+		return x.Installments
+	}
+	return 0
+}
+
+func (x *ChargeRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ChargeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Set for a charge paid in installments.
+	InstallmentPlan *InstallmentPlan `protobuf:"bytes,2,opt,name=installment_plan,json=installmentPlan,proto3" json:"installment_plan,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChargeResponse) Reset() {
+	*x = ChargeResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargeResponse) ProtoMessage() {}
+
+func (x *ChargeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargeResponse.ProtoReflect.Descriptor instead.
+func (*ChargeResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *ChargeResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ChargeResponse) GetInstallmentPlan() *InstallmentPlan {
+	if x != nil {
+		return x.InstallmentPlan
+	}
+	return nil
+}
+
+type Installment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Counted from 1.
+	Number int32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Amount *Money `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Unix seconds. The first installment is due at the time of the charge.
+	DueAt         int64 `protobuf:"varint,3,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Installment) Reset() {
+	*x = Installment{}
+	mi := &file_onlineboutique_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Installment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Installment) ProtoMessage() {}
+
+func (x *Installment) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Installment.ProtoReflect.Descriptor instead.
+func (*Installment) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *Installment) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Installment) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Installment) GetDueAt() int64 {
+	if x != nil {
+		return x.DueAt
+	}
+	return 0
+}
+
+// InstallmentPlan is the schedule of a charge paid in monthly installments,
+// which add up to the amount charged.
+type InstallmentPlan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Installments  []*Installment         `protobuf:"bytes,1,rep,name=installments,proto3" json:"installments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallmentPlan) Reset() {
+	*x = InstallmentPlan{}
+	mi := &file_onlineboutique_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallmentPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallmentPlan) ProtoMessage() {}
+
+func (x *InstallmentPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallmentPlan.ProtoReflect.Descriptor instead.
+func (*InstallmentPlan) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *InstallmentPlan) GetInstallments() []*Installment {
+	if x != nil {
+		return x.Installments
+	}
+	return nil
+}
+
+// A ledger entry for a single charge attempt.
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	CardBrand     string                 `protobuf:"bytes,4,opt,name=card_brand,json=cardBrand,proto3" json:"card_brand,omitempty"`
+	CardLastFour  string                 `protobuf:"bytes,5,opt,name=card_last_four,json=cardLastFour,proto3" json:"card_last_four,omitempty"`
+	// One of CHARGED or DECLINED and, as the processor reports back on a
+	// charge, CAPTURED, CAPTURE_FAILED or CHARGED_BACK, or REFUNDED once
+	// the charge was given back.
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// Reason a charge was declined or given back, empty otherwise.
+	FailureReason string `protobuf:"bytes,7,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	// Unix seconds.
+	CreatedAt int64 `protobuf:"varint,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt int64 `protobuf:"varint,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set for a charge paid in installments.
+	InstallmentPlan *InstallmentPlan `protobuf:"bytes,10,opt,name=installment_plan,json=installmentPlan,proto3" json:"installment_plan,omitempty"`
+	// The order the charge pays for, if known.
+	OrderId       string `protobuf:"bytes,11,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_onlineboutique_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *Transaction) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *Transaction) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Transaction) GetCardBrand() string {
+	if x != nil {
+		return x.CardBrand
+	}
+	return ""
+}
+
+func (x *Transaction) GetCardLastFour() string {
+	if x != nil {
+		return x.CardLastFour
+	}
+	return ""
+}
+
+func (x *Transaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transaction) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Transaction) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *Transaction) GetInstallmentPlan() *InstallmentPlan {
+	if x != nil {
+		return x.InstallmentPlan
+	}
+	return nil
+}
+
+func (x *Transaction) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// Published on the event bus whenever a processor webhook changes the
+// status of a transaction.
+type PaymentStatusChanged struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Transaction    *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,2,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	// The webhook, such as capture.succeeded.
+	Event string `protobuf:"bytes,3,opt,name=event,proto3" json:"event,omitempty"`
+	// Tenant the transaction was recorded for.
+	Tenant        string `protobuf:"bytes,4,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentStatusChanged) Reset() {
+	*x = PaymentStatusChanged{}
+	mi := &file_onlineboutique_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentStatusChanged) ProtoMessage() {}
+
+func (x *PaymentStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentStatusChanged.ProtoReflect.Descriptor instead.
+func (*PaymentStatusChanged) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *PaymentStatusChanged) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *PaymentStatusChanged) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *PaymentStatusChanged) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *PaymentStatusChanged) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *GetTransactionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type RefundRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A charge to give back in full. Refunding it again does nothing.
+	TransactionId string `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Why the charge is given back, such as the order it paid for failing.
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundRequest) Reset() {
+	*x = RefundRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundRequest) ProtoMessage() {}
+
+func (x *RefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundRequest.ProtoReflect.Descriptor instead.
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *RefundRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListTransactionsByUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId        string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsByUserRequest) Reset() {
+	*x = ListTransactionsByUserRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsByUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsByUserRequest) ProtoMessage() {}
+
+func (x *ListTransactionsByUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsByUserRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsByUserRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ListTransactionsByUserRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// An entry of a service's audit log of sensitive operations. Each entry
+// carries the hash of the one before it, so that changing or removing an
+// entry breaks the chain from there on.
+type AuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position in the log, from 1.
+	Seq int64 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Unix nanoseconds.
+	Time    int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	Service string `protobuf:"bytes,3,opt,name=service,proto3" json:"service,omitempty"`
+	Method  string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	UserId  string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Tenant  string `protobuf:"bytes,6,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// The request in JSON, card data scrubbed, or what changed.
+	Details string `protobuf:"bytes,7,opt,name=details,proto3" json:"details,omitempty"`
+	// Hex SHA-256 of the previous entry, empty for the first, and of this one.
+	PrevHash      string `protobuf:"bytes,8,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Hash          string `protobuf:"bytes,9,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_onlineboutique_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *AuditEntry) GetSeq() int64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetPrevHash() string {
+	if x != nil {
+		return x.PrevHash
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type ListAuditEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First entry to list, from 1.
+	FromSeq int64 `protobuf:"varint,1,opt,name=from_seq,json=fromSeq,proto3" json:"from_seq,omitempty"`
+	// At most this many entries; zero for a default page.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEntriesRequest) Reset() {
+	*x = ListAuditEntriesRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEntriesRequest) ProtoMessage() {}
+
+func (x *ListAuditEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListAuditEntriesRequest) GetFromSeq() int64 {
+	if x != nil {
+		return x.FromSeq
+	}
+	return 0
+}
+
+func (x *ListAuditEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AuditEntries struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Whether the entries chain to each other and to the entry before them.
+	Intact bool `protobuf:"varint,2,opt,name=intact,proto3" json:"intact,omitempty"`
+	// The first entry that does not, if not intact.
+	BrokenSeq     int64 `protobuf:"varint,3,opt,name=broken_seq,json=brokenSeq,proto3" json:"broken_seq,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntries) Reset() {
+	*x = AuditEntries{}
+	mi := &file_onlineboutique_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntries) ProtoMessage() {}
+
+func (x *AuditEntries) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntries.ProtoReflect.Descriptor instead.
+func (*AuditEntries) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *AuditEntries) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *AuditEntries) GetIntact() bool {
+	if x != nil {
+		return x.Intact
+	}
+	return false
+}
+
+func (x *AuditEntries) GetBrokenSeq() int64 {
+	if x != nil {
+		return x.BrokenSeq
+	}
+	return 0
+}
+
+type GetWalletBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrencyCode  string                 `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWalletBalanceRequest) Reset() {
+	*x = GetWalletBalanceRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWalletBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWalletBalanceRequest) ProtoMessage() {}
+
+func (x *GetWalletBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWalletBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetWalletBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *GetWalletBalanceRequest) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+type WalletBalance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       *Money                 `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletBalance) Reset() {
+	*x = WalletBalance{}
+	mi := &file_onlineboutique_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletBalance) ProtoMessage() {}
+
+func (x *WalletBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletBalance.ProtoReflect.Descriptor instead.
+func (*WalletBalance) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *WalletBalance) GetBalance() *Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+type RedeemGiftCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedeemGiftCardRequest) Reset() {
+	*x = RedeemGiftCardRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedeemGiftCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedeemGiftCardRequest) ProtoMessage() {}
+
+func (x *RedeemGiftCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedeemGiftCardRequest.ProtoReflect.Descriptor instead.
+func (*RedeemGiftCardRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *RedeemGiftCardRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type WalletDebitRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Amount *Money                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Identifies the debit, for a refund. Repeat calls with the same debit
+	// ID debit the wallet once.
+	DebitId       string `protobuf:"bytes,2,opt,name=debit_id,json=debitId,proto3" json:"debit_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletDebitRequest) Reset() {
+	*x = WalletDebitRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletDebitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletDebitRequest) ProtoMessage() {}
+
+func (x *WalletDebitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletDebitRequest.ProtoReflect.Descriptor instead.
+func (*WalletDebitRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *WalletDebitRequest) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *WalletDebitRequest) GetDebitId() string {
+	if x != nil {
+		return x.DebitId
+	}
+	return ""
+}
+
+type WalletRefundRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// A debit to give back in full. Refunding it again does nothing.
+	DebitId       string `protobuf:"bytes,1,opt,name=debit_id,json=debitId,proto3" json:"debit_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletRefundRequest) Reset() {
+	*x = WalletRefundRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletRefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletRefundRequest) ProtoMessage() {}
+
+func (x *WalletRefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletRefundRequest.ProtoReflect.Descriptor instead.
+func (*WalletRefundRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *WalletRefundRequest) GetDebitId() string {
+	if x != nil {
+		return x.DebitId
+	}
+	return ""
+}
+
+type SendTicketAcknowledgementRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Ticket *Ticket                `protobuf:"bytes,1,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	// As in SendOrderConfirmationRequest.
+	DedupeKey     string `protobuf:"bytes,2,opt,name=dedupe_key,json=dedupeKey,proto3" json:"dedupe_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTicketAcknowledgementRequest) Reset() {
+	*x = SendTicketAcknowledgementRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTicketAcknowledgementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTicketAcknowledgementRequest) ProtoMessage() {}
+
+func (x *SendTicketAcknowledgementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTicketAcknowledgementRequest.ProtoReflect.Descriptor instead.
+func (*SendTicketAcknowledgementRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *SendTicketAcknowledgementRequest) GetTicket() *Ticket {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+func (x *SendTicketAcknowledgementRequest) GetDedupeKey() string {
+	if x != nil {
+		return x.DedupeKey
+	}
+	return ""
+}
+
+type OrderItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *CartItem              `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Cost          *Money                 `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_onlineboutique_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *OrderItem) GetItem() *CartItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *OrderItem) GetCost() *Money {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+type OrderResult struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OrderId            string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ShippingTrackingId string                 `protobuf:"bytes,2,opt,name=shipping_tracking_id,json=shippingTrackingId,proto3" json:"shipping_tracking_id,omitempty"`
+	ShippingCost       *Money                 `protobuf:"bytes,3,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
+	ShippingAddress    *Address               `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	Items              []*OrderItem           `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Breakdown          *OrderBreakdown        `protobuf:"bytes,6,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	GiftWrap           bool                   `protobuf:"varint,7,opt,name=gift_wrap,json=giftWrap,proto3" json:"gift_wrap,omitempty"`
+	Note               string                 `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	// The shipments of an order that was split, whose first tracking ID is
+	// shipping_tracking_id. Unset for orders shipped whole.
+	Shipments *ShipmentGroups `protobuf:"bytes,9,opt,name=shipments,proto3" json:"shipments,omitempty"`
+	// The delivery window the shopper chose, if any.
+	DeliveryWindow *DeliveryWindow `protobuf:"bytes,10,opt,name=delivery_window,json=deliveryWindow,proto3" json:"delivery_window,omitempty"`
+	// Set if the order is paid in installments.
+	InstallmentPlan *InstallmentPlan `protobuf:"bytes,11,opt,name=installment_plan,json=installmentPlan,proto3" json:"installment_plan,omitempty"`
+	// The part of the total paid from the wallet; the rest was charged to
+	// the card. Unset if the wallet was not used.
+	WalletPaid *Money `protobuf:"bytes,12,opt,name=wallet_paid,json=walletPaid,proto3" json:"wallet_paid,omitempty"`
+	// How the steps run after the order was placed, such as emptying the
+	// cart, went. Not part of the confirmation email, which is one of them.
+	PostOrderSteps *PostOrderSteps `protobuf:"bytes,13,opt,name=post_order_steps,json=postOrderSteps,proto3" json:"post_order_steps,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrderResult) Reset() {
+	*x = OrderResult{}
+	mi := &file_onlineboutique_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderResult) ProtoMessage() {}
+
+func (x *OrderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderResult.ProtoReflect.Descriptor instead.
+func (*OrderResult) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *OrderResult) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderResult) GetShippingTrackingId() string {
+	if x != nil {
+		return x.ShippingTrackingId
+	}
+	return ""
+}
+
+func (x *OrderResult) GetShippingCost() *Money {
+	if x != nil {
+		return x.ShippingCost
+	}
+	return nil
+}
+
+func (x *OrderResult) GetShippingAddress() *Address {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *OrderResult) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderResult) GetBreakdown() *OrderBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+func (x *OrderResult) GetGiftWrap() bool {
+	if x != nil {
+		return x.GiftWrap
+	}
+	return false
+}
+
+func (x *OrderResult) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *OrderResult) GetShipments() *ShipmentGroups {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+func (x *OrderResult) GetDeliveryWindow() *DeliveryWindow {
+	if x != nil {
+		return x.DeliveryWindow
+	}
+	return nil
+}
+
+func (x *OrderResult) GetInstallmentPlan() *InstallmentPlan {
+	if x != nil {
+		return x.InstallmentPlan
+	}
+	return nil
+}
+
+func (x *OrderResult) GetWalletPaid() *Money {
+	if x != nil {
+		return x.WalletPaid
+	}
+	return nil
+}
+
+func (x *OrderResult) GetPostOrderSteps() *PostOrderSteps {
+	if x != nil {
+		return x.PostOrderSteps
+	}
+	return nil
+}
+
+// A step run after an order was placed that does not decide the order.
+type PostOrderStep struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Such as "empty_cart" or "confirmation_email".
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// DONE, FAILED, or PENDING if it was still running when the order was
+	// returned.
+	Outcome string `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	// Why it failed.
+	Error         string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostOrderStep) Reset() {
+	*x = PostOrderStep{}
+	mi := &file_onlineboutique_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostOrderStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostOrderStep) ProtoMessage() {}
+
+func (x *PostOrderStep) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostOrderStep.ProtoReflect.Descriptor instead.
+func (*PostOrderStep) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *PostOrderStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PostOrderStep) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *PostOrderStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type PostOrderSteps struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Steps         []*PostOrderStep       `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostOrderSteps) Reset() {
+	*x = PostOrderSteps{}
+	mi := &file_onlineboutique_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostOrderSteps) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostOrderSteps) ProtoMessage() {}
+
+func (x *PostOrderSteps) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostOrderSteps.ProtoReflect.Descriptor instead.
+func (*PostOrderSteps) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *PostOrderSteps) GetSteps() []*PostOrderStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+// How an order total was arrived at, in the order's currency.
+type OrderBreakdown struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Items    *Money                 `protobuf:"bytes,1,opt,name=items,proto3" json:"items,omitempty"`
+	Shipping *Money                 `protobuf:"bytes,2,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	// Tax is recorded for reconciliation; no taxes are applied yet, so it is
+	// zero. The discount is the sum of the adjustments below.
+	Tax      *Money `protobuf:"bytes,3,opt,name=tax,proto3" json:"tax,omitempty"`
+	Discount *Money `protobuf:"bytes,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	// items + shipping + gift_wrap + tax - discount, the amount charged.
+	Total       *Money               `protobuf:"bytes,5,opt,name=total,proto3" json:"total,omitempty"`
+	Conversions []*AppliedConversion `protobuf:"bytes,6,rep,name=conversions,proto3" json:"conversions,omitempty"`
+	// The gift-wrap fee, if the order is gift-wrapped.
+	GiftWrap *Money `protobuf:"bytes,7,opt,name=gift_wrap,json=giftWrap,proto3" json:"gift_wrap,omitempty"`
+	// The rates every conversion above was made at, fetched once per order.
+	PinnedRates *PinnedRates `protobuf:"bytes,8,opt,name=pinned_rates,json=pinnedRates,proto3" json:"pinned_rates,omitempty"`
+	// What the discount is made of, one adjustment per rule and line.
+	Adjustments   *PriceAdjustments `protobuf:"bytes,9,opt,name=adjustments,proto3" json:"adjustments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBreakdown) Reset() {
+	*x = OrderBreakdown{}
+	mi := &file_onlineboutique_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBreakdown) ProtoMessage() {}
+
+func (x *OrderBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBreakdown.ProtoReflect.Descriptor instead.
+func (*OrderBreakdown) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *OrderBreakdown) GetItems() *Money {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetShipping() *Money {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetTax() *Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetDiscount() *Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetConversions() []*AppliedConversion {
+	if x != nil {
+		return x.Conversions
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetGiftWrap() *Money {
+	if x != nil {
+		return x.GiftWrap
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetPinnedRates() *PinnedRates {
+	if x != nil {
+		return x.PinnedRates
+	}
+	return nil
+}
+
+func (x *OrderBreakdown) GetAdjustments() *PriceAdjustments {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+// An amount taken off a line of an order by a pricing rule.
+type PriceAdjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ProductId     string                 `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Amount        *Money                 `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceAdjustment) Reset() {
+	*x = PriceAdjustment{}
+	mi := &file_onlineboutique_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceAdjustment) ProtoMessage() {}
+
+func (x *PriceAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceAdjustment.ProtoReflect.Descriptor instead.
+func (*PriceAdjustment) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *PriceAdjustment) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceAdjustment) GetAmount() *Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type PriceAdjustments struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Adjustments   []*PriceAdjustment     `protobuf:"bytes,1,rep,name=adjustments,proto3" json:"adjustments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceAdjustments) Reset() {
+	*x = PriceAdjustments{}
+	mi := &file_onlineboutique_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceAdjustments) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceAdjustments) ProtoMessage() {}
+
+func (x *PriceAdjustments) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceAdjustments.ProtoReflect.Descriptor instead.
+func (*PriceAdjustments) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *PriceAdjustments) GetAdjustments() []*PriceAdjustment {
+	if x != nil {
+		return x.Adjustments
+	}
+	return nil
+}
+
+// An exchange rate fixed for the whole of an order.
+type PinnedRate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FromCode string                 `protobuf:"bytes,1,opt,name=from_code,json=fromCode,proto3" json:"from_code,omitempty"`
+	ToCode   string                 `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
+	// As a decimal string.
+	Rate string `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	// When the rate was fetched, in Unix seconds.
+	PinnedAt      int64 `protobuf:"varint,4,opt,name=pinned_at,json=pinnedAt,proto3" json:"pinned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedRate) Reset() {
+	*x = PinnedRate{}
+	mi := &file_onlineboutique_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedRate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedRate) ProtoMessage() {}
+
+func (x *PinnedRate) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedRate.ProtoReflect.Descriptor instead.
+func (*PinnedRate) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *PinnedRate) GetFromCode() string {
+	if x != nil {
+		return x.FromCode
+	}
+	return ""
+}
+
+func (x *PinnedRate) GetToCode() string {
+	if x != nil {
+		return x.ToCode
+	}
+	return ""
+}
+
+func (x *PinnedRate) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *PinnedRate) GetPinnedAt() int64 {
+	if x != nil {
+		return x.PinnedAt
+	}
+	return 0
+}
+
+type PinnedRates struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*PinnedRate          `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinnedRates) Reset() {
+	*x = PinnedRates{}
+	mi := &file_onlineboutique_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinnedRates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinnedRates) ProtoMessage() {}
+
+func (x *PinnedRates) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinnedRates.ProtoReflect.Descriptor instead.
+func (*PinnedRates) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *PinnedRates) GetRates() []*PinnedRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+// A currency conversion that went into an order total.
+type AppliedConversion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// What was converted: "shipping", or "item:<product_id>" for an item.
+	Component string `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`
+	From      *Money `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To        *Money `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	// The from -> to rate, as a decimal string.
+	Rate          string `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppliedConversion) Reset() {
+	*x = AppliedConversion{}
+	mi := &file_onlineboutique_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedConversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedConversion) ProtoMessage() {}
+
+func (x *AppliedConversion) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedConversion.ProtoReflect.Descriptor instead.
+func (*AppliedConversion) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *AppliedConversion) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *AppliedConversion) GetFrom() *Money {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *AppliedConversion) GetTo() *Money {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *AppliedConversion) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+type SendOrderConfirmationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Email string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Order *OrderResult           `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	// Language tag to render the email in, e.g. "en" or "fr".
+	Locale string `protobuf:"bytes,3,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Identifies the email, e.g. "<order id>:confirmation". A request whose
+	// key was already sent is acknowledged without sending it again.
+	DedupeKey     string `protobuf:"bytes,4,opt,name=dedupe_key,json=dedupeKey,proto3" json:"dedupe_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendOrderConfirmationRequest) Reset() {
+	*x = SendOrderConfirmationRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendOrderConfirmationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendOrderConfirmationRequest) ProtoMessage() {}
+
+func (x *SendOrderConfirmationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendOrderConfirmationRequest.ProtoReflect.Descriptor instead.
+func (*SendOrderConfirmationRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *SendOrderConfirmationRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SendOrderConfirmationRequest) GetOrder() *OrderResult {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *SendOrderConfirmationRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SendOrderConfirmationRequest) GetDedupeKey() string {
+	if x != nil {
+		return x.DedupeKey
+	}
+	return ""
+}
+
+// CampaignSegment selects the customers a campaign goes to, among those the
+// email service confirmed an order for. Unset fields match everyone.
+type CampaignSegment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Language tag the customer last ordered in.
+	Locale    string `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	MinOrders int32  `protobuf:"varint,2,opt,name=min_orders,json=minOrders,proto3" json:"min_orders,omitempty"`
+	// Only customers who ordered in the last that many days.
+	OrderedWithinDays int32 `protobuf:"varint,3,opt,name=ordered_within_days,json=orderedWithinDays,proto3" json:"ordered_within_days,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CampaignSegment) Reset() {
+	*x = CampaignSegment{}
+	mi := &file_onlineboutique_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignSegment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignSegment) ProtoMessage() {}
+
+func (x *CampaignSegment) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignSegment.ProtoReflect.Descriptor instead.
+func (*CampaignSegment) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *CampaignSegment) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *CampaignSegment) GetMinOrders() int32 {
+	if x != nil {
+		return x.MinOrders
+	}
+	return 0
+}
+
+func (x *CampaignSegment) GetOrderedWithinDays() int32 {
+	if x != nil {
+		return x.OrderedWithinDays
+	}
+	return 0
+}
+
+type SendCampaignRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the campaign. Each customer gets a campaign once, however
+	// many times it is sent.
+	CampaignId string `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	// Name of the campaign template, e.g. "new_arrivals".
+	Template string           `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`
+	Segment  *CampaignSegment `protobuf:"bytes,3,opt,name=segment,proto3" json:"segment,omitempty"`
+	// In test-send mode, when test_email is set, the campaign goes to
+	// test_email only, rendered in test_locale, and the segment is only
+	// counted.
+	TestEmail     string `protobuf:"bytes,4,opt,name=test_email,json=testEmail,proto3" json:"test_email,omitempty"`
+	TestLocale    string `protobuf:"bytes,5,opt,name=test_locale,json=testLocale,proto3" json:"test_locale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendCampaignRequest) Reset() {
+	*x = SendCampaignRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendCampaignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendCampaignRequest) ProtoMessage() {}
+
+func (x *SendCampaignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendCampaignRequest.ProtoReflect.Descriptor instead.
+func (*SendCampaignRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *SendCampaignRequest) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *SendCampaignRequest) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *SendCampaignRequest) GetSegment() *CampaignSegment {
+	if x != nil {
+		return x.Segment
+	}
+	return nil
+}
+
+func (x *SendCampaignRequest) GetTestEmail() string {
+	if x != nil {
+		return x.TestEmail
+	}
+	return ""
+}
+
+func (x *SendCampaignRequest) GetTestLocale() string {
+	if x != nil {
+		return x.TestLocale
+	}
+	return ""
+}
+
+type CampaignResult struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId string                 `protobuf:"bytes,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	// Customers in the segment, and those of them left out because they
+	// unsubscribed.
+	Matched    int32 `protobuf:"varint,2,opt,name=matched,proto3" json:"matched,omitempty"`
+	Suppressed int32 `protobuf:"varint,3,opt,name=suppressed,proto3" json:"suppressed,omitempty"`
+	// Emails going out. They are queued in the background and sent at the
+	// rate of the marketing provider.
+	Queued        int32 `protobuf:"varint,4,opt,name=queued,proto3" json:"queued,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignResult) Reset() {
+	*x = CampaignResult{}
+	mi := &file_onlineboutique_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignResult) ProtoMessage() {}
+
+func (x *CampaignResult) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignResult.ProtoReflect.Descriptor instead.
+func (*CampaignResult) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *CampaignResult) GetCampaignId() string {
+	if x != nil {
+		return x.CampaignId
+	}
+	return ""
+}
+
+func (x *CampaignResult) GetMatched() int32 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *CampaignResult) GetSuppressed() int32 {
+	if x != nil {
+		return x.Suppressed
+	}
+	return 0
+}
+
+func (x *CampaignResult) GetQueued() int32 {
+	if x != nil {
+		return x.Queued
+	}
+	return 0
+}
+
+type UnsubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnsubscribeRequest) Reset() {
+	*x = UnsubscribeRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnsubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnsubscribeRequest) ProtoMessage() {}
+
+func (x *UnsubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnsubscribeRequest.ProtoReflect.Descriptor instead.
+func (*UnsubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *UnsubscribeRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type GetReceiptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptRequest) Reset() {
+	*x = GetReceiptRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptRequest) ProtoMessage() {}
+
+func (x *GetReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *GetReceiptRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type GetReceiptResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The receipt as a PDF document. It is 7-bit ASCII.
+	Pdf           string `protobuf:"bytes,1,opt,name=pdf,proto3" json:"pdf,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReceiptResponse) Reset() {
+	*x = GetReceiptResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReceiptResponse) ProtoMessage() {}
+
+func (x *GetReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReceiptResponse.ProtoReflect.Descriptor instead.
+func (*GetReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *GetReceiptResponse) GetPdf() string {
+	if x != nil {
+		return x.Pdf
+	}
+	return ""
 }
 
-type GetProductRequest struct {
+type GetOrderStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusRequest) Reset() {
+	*x = GetOrderStatusRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusRequest) ProtoMessage() {}
+
+func (x *GetOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *GetOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+// The status of an order. An order is PENDING until it is paid for, then
+// PAID, SHIPPED once a carrier picks it up and DELIVERED once all of it is
+// delivered. It is CANCELLED if payment or shipping fails before it ships,
+// and REFUNDED if it is paid back, as on a chargeback.
+type OrderStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	// Why the order was cancelled or refunded.
+	Reason string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Unix seconds.
+	UpdatedAt int64 `protobuf:"varint,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// The card charge given back for a refunded order, in the currency the
+	// order was placed in and at the rates of when it was.
+	RefundedAmount *Money `protobuf:"bytes,6,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrderStatus) Reset() {
+	*x = OrderStatus{}
+	mi := &file_onlineboutique_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatus) ProtoMessage() {}
+
+func (x *OrderStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatus.ProtoReflect.Descriptor instead.
+func (*OrderStatus) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *OrderStatus) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderStatus) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *OrderStatus) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatus) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *OrderStatus) GetRefundedAmount() *Money {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return nil
+}
+
+// Published on the event bus whenever an order changes status.
+type OrderStatusChanged struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Status         *OrderStatus           `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,2,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	Email          string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Locale         string                 `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	UserId         string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Tenant         string                 `protobuf:"bytes,6,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	ReferralCode   string                 `protobuf:"bytes,7,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *OrderStatusChanged) Reset() {
+	*x = OrderStatusChanged{}
+	mi := &file_onlineboutique_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusChanged) ProtoMessage() {}
+
+func (x *OrderStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusChanged.ProtoReflect.Descriptor instead.
+func (*OrderStatusChanged) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *OrderStatusChanged) GetStatus() *OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *OrderStatusChanged) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusChanged) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *OrderStatusChanged) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *OrderStatusChanged) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrderStatusChanged) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *OrderStatusChanged) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+type PlaceOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId       string          `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserCurrency string          `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
+	Address      *Address        `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Email        string          `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
+	CreditCard   *CreditCardInfo `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	// Language tag of the shopper, passed on to the confirmation email.
+	Locale string `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"`
+	// Hash of the cart the shopper reviewed, as computed by CartHash. If set,
+	// the order is refused when the cart has changed since.
+	ExpectedCartHash string `protobuf:"bytes,8,opt,name=expected_cart_hash,json=expectedCartHash,proto3" json:"expected_cart_hash,omitempty"`
+	// Whether to gift-wrap the order, for a fee, and a note from the shopper,
+	// such as a gift message.
+	GiftWrap bool   `protobuf:"varint,9,opt,name=gift_wrap,json=giftWrap,proto3" json:"gift_wrap,omitempty"`
+	Note     string `protobuf:"bytes,10,opt,name=note,proto3" json:"note,omitempty"`
+	// A delivery window from GetDeliveryOptions. Optional.
+	DeliveryWindow *DeliveryWindow `protobuf:"bytes,11,opt,name=delivery_window,json=deliveryWindow,proto3" json:"delivery_window,omitempty"`
+	// Number of monthly payments to spread the total over, for totals that
+	// are eligible. Zero or one pays it at once.
+	Installments int32 `protobuf:"varint,12,opt,name=installments,proto3" json:"installments,omitempty"`
+	// How much of the total to pay from the shopper's wallet, in
+	// user_currency, at most the total; the rest is charged to the card.
+	// Optional.
+	WalletAmount *Money `protobuf:"bytes,13,opt,name=wallet_amount,json=walletAmount,proto3" json:"wallet_amount,omitempty"`
+	// The shopper's acceptance of the terms of sale and confirmation of
+	// their age, without which the order is refused.
+	Consent *Consent `protobuf:"bytes,14,opt,name=consent,proto3" json:"consent,omitempty"`
+	// The referral code the shopper arrived with, which the order is
+	// attributed to.
+	ReferralCode  string `protobuf:"bytes,15,opt,name=referral_code,json=referralCode,proto3" json:"referral_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductRequest) Reset() {
-	*x = GetProductRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[11]
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *PlaceOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetUserCurrency() string {
+	if x != nil {
+		return x.UserCurrency
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetCreditCard() *CreditCardInfo {
+	if x != nil {
+		return x.CreditCard
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetExpectedCartHash() string {
+	if x != nil {
+		return x.ExpectedCartHash
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetGiftWrap() bool {
+	if x != nil {
+		return x.GiftWrap
+	}
+	return false
+}
+
+func (x *PlaceOrderRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetDeliveryWindow() *DeliveryWindow {
+	if x != nil {
+		return x.DeliveryWindow
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetInstallments() int32 {
+	if x != nil {
+		return x.Installments
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetWalletAmount() *Money {
+	if x != nil {
+		return x.WalletAmount
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetConsent() *Consent {
+	if x != nil {
+		return x.Consent
+	}
+	return nil
+}
+
+func (x *PlaceOrderRequest) GetReferralCode() string {
+	if x != nil {
+		return x.ReferralCode
+	}
+	return ""
+}
+
+// What a shopper agreed to when checking out, with when they did, in Unix
+// seconds.
+type Consent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The version of the terms accepted, which must be the current one.
+	TermsVersion    string `protobuf:"bytes,1,opt,name=terms_version,json=termsVersion,proto3" json:"terms_version,omitempty"`
+	TermsAcceptedAt int64  `protobuf:"varint,2,opt,name=terms_accepted_at,json=termsAcceptedAt,proto3" json:"terms_accepted_at,omitempty"`
+	// When the shopper confirmed they are of the minimum age to buy.
+	AgeConfirmedAt int64 `protobuf:"varint,3,opt,name=age_confirmed_at,json=ageConfirmedAt,proto3" json:"age_confirmed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Consent) Reset() {
+	*x = Consent{}
+	mi := &file_onlineboutique_proto_msgTypes[116]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductRequest) String() string {
+func (x *Consent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductRequest) ProtoMessage() {}
+func (*Consent) ProtoMessage() {}
 
-func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[11]
+func (x *Consent) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[116]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,40 +7376,56 @@ func (x *GetProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductRequest.ProtoReflect.Descriptor instead.
-func (*GetProductRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use Consent.ProtoReflect.Descriptor instead.
+func (*Consent) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{116}
 }
 
-func (x *GetProductRequest) GetId() string {
+func (x *Consent) GetTermsVersion() string {
 	if x != nil {
-		return x.Id
+		return x.TermsVersion
 	}
 	return ""
 }
 
-type SearchProductsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+func (x *Consent) GetTermsAcceptedAt() int64 {
+	if x != nil {
+		return x.TermsAcceptedAt
+	}
+	return 0
+}
+
+func (x *Consent) GetAgeConfirmedAt() int64 {
+	if x != nil {
+		return x.AgeConfirmedAt
+	}
+	return 0
+}
+
+type PlaceOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *OrderResult           `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// The amount charged to the card, in the user's currency.
+	Total         *Money `protobuf:"bytes,2,opt,name=total,proto3" json:"total,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchProductsRequest) Reset() {
-	*x = SearchProductsRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[12]
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchProductsRequest) String() string {
+func (x *PlaceOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchProductsRequest) ProtoMessage() {}
+func (*PlaceOrderResponse) ProtoMessage() {}
 
-func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[12]
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -661,40 +7436,58 @@ func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
-func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{117}
 }
 
-func (x *SearchProductsRequest) GetQuery() string {
+func (x *PlaceOrderResponse) GetOrder() *OrderResult {
 	if x != nil {
-		return x.Query
+		return x.Order
 	}
-	return ""
+	return nil
 }
 
-type SearchProductsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*Product             `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+func (x *PlaceOrderResponse) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+// An order as PlaceOrder would price it.
+type OrderPreview struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Items     []*OrderItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Breakdown *OrderBreakdown        `protobuf:"bytes,2,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	// The shipping address, as corrected by validation.
+	ShippingAddress *Address `protobuf:"bytes,3,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	// The hash of the cart priced, to send as expected_cart_hash so that
+	// the order placed is the one previewed.
+	CartHash string `protobuf:"bytes,4,opt,name=cart_hash,json=cartHash,proto3" json:"cart_hash,omitempty"`
+	// How much of the total would be paid from the wallet, if any, and
+	// charged to the card.
+	WalletAmount  *Money `protobuf:"bytes,5,opt,name=wallet_amount,json=walletAmount,proto3" json:"wallet_amount,omitempty"`
+	CardAmount    *Money `protobuf:"bytes,6,opt,name=card_amount,json=cardAmount,proto3" json:"card_amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchProductsResponse) Reset() {
-	*x = SearchProductsResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[13]
+func (x *OrderPreview) Reset() {
+	*x = OrderPreview{}
+	mi := &file_onlineboutique_proto_msgTypes[118]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchProductsResponse) String() string {
+func (x *OrderPreview) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchProductsResponse) ProtoMessage() {}
+func (*OrderPreview) ProtoMessage() {}
 
-func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[13]
+func (x *OrderPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[118]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -705,92 +7498,81 @@ func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
-func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use OrderPreview.ProtoReflect.Descriptor instead.
+func (*OrderPreview) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{118}
 }
 
-func (x *SearchProductsResponse) GetResults() []*Product {
+func (x *OrderPreview) GetItems() []*OrderItem {
 	if x != nil {
-		return x.Results
+		return x.Items
 	}
 	return nil
 }
 
-type GetQuoteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetQuoteRequest) Reset() {
-	*x = GetQuoteRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetQuoteRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *OrderPreview) GetBreakdown() *OrderBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
 }
 
-func (*GetQuoteRequest) ProtoMessage() {}
-
-func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[14]
+func (x *OrderPreview) GetShippingAddress() *Address {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ShippingAddress
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
-func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{14}
+func (x *OrderPreview) GetCartHash() string {
+	if x != nil {
+		return x.CartHash
+	}
+	return ""
 }
 
-func (x *GetQuoteRequest) GetAddress() *Address {
+func (x *OrderPreview) GetWalletAmount() *Money {
 	if x != nil {
-		return x.Address
+		return x.WalletAmount
 	}
 	return nil
 }
 
-func (x *GetQuoteRequest) GetItems() []*CartItem {
+func (x *OrderPreview) GetCardAmount() *Money {
 	if x != nil {
-		return x.Items
+		return x.CardAmount
 	}
 	return nil
 }
 
-type GetQuoteResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CostUsd       *Money                 `protobuf:"bytes,1,opt,name=cost_usd,json=costUsd,proto3" json:"cost_usd,omitempty"`
+type AdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deprecated: the user is sent as x-shop-user call metadata.
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// List of important key words from the current page describing the context.
+	ContextKeys []string `protobuf:"bytes,2,rep,name=context_keys,json=contextKeys,proto3" json:"context_keys,omitempty"`
+	// Who the ads are for. Fields left empty are taken from the request
+	// metadata, if present.
+	AdContext     *AdContext `protobuf:"bytes,3,opt,name=ad_context,json=adContext,proto3" json:"ad_context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetQuoteResponse) Reset() {
-	*x = GetQuoteResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[15]
+func (x *AdRequest) Reset() {
+	*x = AdRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[119]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetQuoteResponse) String() string {
+func (x *AdRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetQuoteResponse) ProtoMessage() {}
+func (*AdRequest) ProtoMessage() {}
 
-func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[15]
+func (x *AdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[119]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -801,41 +7583,61 @@ func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetQuoteResponse.ProtoReflect.Descriptor instead.
-func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use AdRequest.ProtoReflect.Descriptor instead.
+func (*AdRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{119}
 }
 
-func (x *GetQuoteResponse) GetCostUsd() *Money {
+func (x *AdRequest) GetUserId() string {
 	if x != nil {
-		return x.CostUsd
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdRequest) GetContextKeys() []string {
+	if x != nil {
+		return x.ContextKeys
 	}
 	return nil
 }
 
-type ShipOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Items         []*CartItem            `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+func (x *AdRequest) GetAdContext() *AdContext {
+	if x != nil {
+		return x.AdContext
+	}
+	return nil
+}
+
+// AdContext describes the shopper so ads can be ranked for them.
+type AdContext struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Currency string                 `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Experiment arm of the shopper; "control" gets unranked ads.
+	Experiment string `protobuf:"bytes,2,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	// Categories of recently viewed products, most recent first.
+	RecentCategories []string `protobuf:"bytes,3,rep,name=recent_categories,json=recentCategories,proto3" json:"recent_categories,omitempty"`
+	// Browsing session, used to cap how often the same ad is shown.
+	SessionId     string `protobuf:"bytes,4,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShipOrderRequest) Reset() {
-	*x = ShipOrderRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[16]
+func (x *AdContext) Reset() {
+	*x = AdContext{}
+	mi := &file_onlineboutique_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShipOrderRequest) String() string {
+func (x *AdContext) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShipOrderRequest) ProtoMessage() {}
+func (*AdContext) ProtoMessage() {}
 
-func (x *ShipOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[16]
+func (x *AdContext) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -846,47 +7648,63 @@ func (x *ShipOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShipOrderRequest.ProtoReflect.Descriptor instead.
-func (*ShipOrderRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use AdContext.ProtoReflect.Descriptor instead.
+func (*AdContext) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *ShipOrderRequest) GetAddress() *Address {
+func (x *AdContext) GetCurrency() string {
 	if x != nil {
-		return x.Address
+		return x.Currency
 	}
-	return nil
+	return ""
 }
 
-func (x *ShipOrderRequest) GetItems() []*CartItem {
+func (x *AdContext) GetExperiment() string {
 	if x != nil {
-		return x.Items
+		return x.Experiment
+	}
+	return ""
+}
+
+func (x *AdContext) GetRecentCategories() []string {
+	if x != nil {
+		return x.RecentCategories
 	}
 	return nil
 }
 
-type ShipOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TrackingId    string                 `protobuf:"bytes,1,opt,name=tracking_id,json=trackingId,proto3" json:"tracking_id,omitempty"`
+func (x *AdContext) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type AdClickRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// redirect_url of the clicked ad.
+	RedirectUrl   string     `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	AdContext     *AdContext `protobuf:"bytes,2,opt,name=ad_context,json=adContext,proto3" json:"ad_context,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ShipOrderResponse) Reset() {
-	*x = ShipOrderResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[17]
+func (x *AdClickRequest) Reset() {
+	*x = AdClickRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[121]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ShipOrderResponse) String() string {
+func (x *AdClickRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ShipOrderResponse) ProtoMessage() {}
+func (*AdClickRequest) ProtoMessage() {}
 
-func (x *ShipOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[17]
+func (x *AdClickRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[121]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -897,44 +7715,53 @@ func (x *ShipOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ShipOrderResponse.ProtoReflect.Descriptor instead.
-func (*ShipOrderResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use AdClickRequest.ProtoReflect.Descriptor instead.
+func (*AdClickRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{121}
 }
 
-func (x *ShipOrderResponse) GetTrackingId() string {
+func (x *AdClickRequest) GetRedirectUrl() string {
 	if x != nil {
-		return x.TrackingId
+		return x.RedirectUrl
 	}
 	return ""
 }
 
-type Address struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	StreetAddress string                 `protobuf:"bytes,1,opt,name=street_address,json=streetAddress,proto3" json:"street_address,omitempty"`
-	City          string                 `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
-	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
-	Country       string                 `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
-	ZipCode       int32                  `protobuf:"varint,5,opt,name=zip_code,json=zipCode,proto3" json:"zip_code,omitempty"`
+func (x *AdClickRequest) GetAdContext() *AdContext {
+	if x != nil {
+		return x.AdContext
+	}
+	return nil
+}
+
+// AdEvent is an impression or click, published on the event bus in batches.
+type AdEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "IMPRESSION" or "CLICK".
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	RedirectUrl   string `protobuf:"bytes,2,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	SessionId     string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Experiment    string `protobuf:"bytes,4,opt,name=experiment,proto3" json:"experiment,omitempty"`
+	TimestampMs   int64  `protobuf:"varint,5,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Address) Reset() {
-	*x = Address{}
-	mi := &file_onlineboutique_proto_msgTypes[18]
+func (x *AdEvent) Reset() {
+	*x = AdEvent{}
+	mi := &file_onlineboutique_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Address) String() string {
+func (x *AdEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Address) ProtoMessage() {}
+func (*AdEvent) ProtoMessage() {}
 
-func (x *Address) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[18]
+func (x *AdEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -945,80 +7772,115 @@ func (x *Address) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Address.ProtoReflect.Descriptor instead.
-func (*Address) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use AdEvent.ProtoReflect.Descriptor instead.
+func (*AdEvent) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{122}
 }
 
-func (x *Address) GetStreetAddress() string {
+func (x *AdEvent) GetType() string {
 	if x != nil {
-		return x.StreetAddress
+		return x.Type
 	}
 	return ""
 }
 
-func (x *Address) GetCity() string {
+func (x *AdEvent) GetRedirectUrl() string {
 	if x != nil {
-		return x.City
+		return x.RedirectUrl
+	}
+	return ""
+}
+
+func (x *AdEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *Address) GetState() string {
+func (x *AdEvent) GetExperiment() string {
+	if x != nil {
+		return x.Experiment
+	}
+	return ""
+}
+
+func (x *AdEvent) GetTimestampMs() int64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+type AdResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ads           []*Ad                  `protobuf:"bytes,1,rep,name=ads,proto3" json:"ads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdResponse) Reset() {
+	*x = AdResponse{}
+	mi := &file_onlineboutique_proto_msgTypes[123]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdResponse) ProtoMessage() {}
+
+func (x *AdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[123]
 	if x != nil {
-		return x.State
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Address) GetCountry() string {
-	if x != nil {
-		return x.Country
-	}
-	return ""
+// Deprecated: Use AdResponse.ProtoReflect.Descriptor instead.
+func (*AdResponse) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *Address) GetZipCode() int32 {
+func (x *AdResponse) GetAds() []*Ad {
 	if x != nil {
-		return x.ZipCode
+		return x.Ads
 	}
-	return 0
+	return nil
 }
 
-// Represents an amount of money with its currency type.
-type Money struct {
+type Ad struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The 3-letter currency code defined in ISO 4217.
-	CurrencyCode string `protobuf:"bytes,1,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
-	// The whole units of the amount.
-	// For example if `currencyCode` is `"USD"`, then 1 unit is one US dollar.
-	Units int64 `protobuf:"varint,2,opt,name=units,proto3" json:"units,omitempty"`
-	// Number of nano (10^-9) units of the amount.
-	// The value must be between -999,999,999 and +999,999,999 inclusive.
-	// If `units` is positive, `nanos` must be positive or zero.
-	// If `units` is zero, `nanos` can be positive, zero, or negative.
-	// If `units` is negative, `nanos` must be negative or zero.
-	// For example $-1.75 is represented as `units`=-1 and `nanos`=-750,000,000.
-	Nanos         int32 `protobuf:"varint,3,opt,name=nanos,proto3" json:"nanos,omitempty"`
+	// url to redirect to when an ad is clicked.
+	RedirectUrl string `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
+	// short advertisement text to display.
+	Text          string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Money) Reset() {
-	*x = Money{}
-	mi := &file_onlineboutique_proto_msgTypes[19]
+func (x *Ad) Reset() {
+	*x = Ad{}
+	mi := &file_onlineboutique_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Money) String() string {
+func (x *Ad) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Money) ProtoMessage() {}
+func (*Ad) ProtoMessage() {}
 
-func (x *Money) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[19]
+func (x *Ad) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1029,55 +7891,51 @@ func (x *Money) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Money.ProtoReflect.Descriptor instead.
-func (*Money) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use Ad.ProtoReflect.Descriptor instead.
+func (*Ad) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *Money) GetCurrencyCode() string {
+func (x *Ad) GetRedirectUrl() string {
 	if x != nil {
-		return x.CurrencyCode
+		return x.RedirectUrl
 	}
 	return ""
 }
 
-func (x *Money) GetUnits() int64 {
-	if x != nil {
-		return x.Units
-	}
-	return 0
-}
-
-func (x *Money) GetNanos() int32 {
+func (x *Ad) GetText() string {
 	if x != nil {
-		return x.Nanos
+		return x.Text
 	}
-	return 0
+	return ""
 }
 
-type GetSupportedCurrenciesResponse struct {
+type UserDataRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// The 3-letter currency code defined in ISO 4217.
-	CurrencyCodes []string `protobuf:"bytes,1,rep,name=currency_codes,json=currencyCodes,proto3" json:"currency_codes,omitempty"`
+	// The addresses the shopper is known by, for the services that keep
+	// data by email address. They are taken from the orders the shopper
+	// placed, never from the shopper, so that no one can ask for the data
+	// of another address.
+	Emails        []string `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSupportedCurrenciesResponse) Reset() {
-	*x = GetSupportedCurrenciesResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[20]
+func (x *UserDataRequest) Reset() {
+	*x = UserDataRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSupportedCurrenciesResponse) String() string {
+func (x *UserDataRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSupportedCurrenciesResponse) ProtoMessage() {}
+func (*UserDataRequest) ProtoMessage() {}
 
-func (x *GetSupportedCurrenciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[20]
+func (x *UserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1088,43 +7946,42 @@ func (x *GetSupportedCurrenciesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSupportedCurrenciesResponse.ProtoReflect.Descriptor instead.
-func (*GetSupportedCurrenciesResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use UserDataRequest.ProtoReflect.Descriptor instead.
+func (*UserDataRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{125}
 }
 
-func (x *GetSupportedCurrenciesResponse) GetCurrencyCodes() []string {
+func (x *UserDataRequest) GetEmails() []string {
 	if x != nil {
-		return x.CurrencyCodes
+		return x.Emails
 	}
 	return nil
 }
 
-type CurrencyConversionRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	From  *Money                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	// The 3-letter currency code defined in ISO 4217.
-	ToCode        string `protobuf:"bytes,2,opt,name=to_code,json=toCode,proto3" json:"to_code,omitempty"`
-	UserId        string `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+type UserData struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Records []*UserDataRecord      `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	// The addresses the service knows the shopper by.
+	Emails        *EmailAddresses `protobuf:"bytes,2,opt,name=emails,proto3" json:"emails,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CurrencyConversionRequest) Reset() {
-	*x = CurrencyConversionRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[21]
+func (x *UserData) Reset() {
+	*x = UserData{}
+	mi := &file_onlineboutique_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CurrencyConversionRequest) String() string {
+func (x *UserData) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CurrencyConversionRequest) ProtoMessage() {}
+func (*UserData) ProtoMessage() {}
 
-func (x *CurrencyConversionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[21]
+func (x *UserData) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1135,57 +7992,53 @@ func (x *CurrencyConversionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CurrencyConversionRequest.ProtoReflect.Descriptor instead.
-func (*CurrencyConversionRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use UserData.ProtoReflect.Descriptor instead.
+func (*UserData) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{126}
 }
 
-func (x *CurrencyConversionRequest) GetFrom() *Money {
+func (x *UserData) GetRecords() []*UserDataRecord {
 	if x != nil {
-		return x.From
+		return x.Records
 	}
 	return nil
 }
 
-func (x *CurrencyConversionRequest) GetToCode() string {
-	if x != nil {
-		return x.ToCode
-	}
-	return ""
-}
-
-func (x *CurrencyConversionRequest) GetUserId() string {
+func (x *UserData) GetEmails() *EmailAddresses {
 	if x != nil {
-		return x.UserId
+		return x.Emails
 	}
-	return ""
+	return nil
 }
 
-type CreditCardInfo struct {
-	state                     protoimpl.MessageState `protogen:"open.v1"`
-	CreditCardNumber          string                 `protobuf:"bytes,1,opt,name=credit_card_number,json=creditCardNumber,proto3" json:"credit_card_number,omitempty"`
-	CreditCardCvv             int32                  `protobuf:"varint,2,opt,name=credit_card_cvv,json=creditCardCvv,proto3" json:"credit_card_cvv,omitempty"`
-	CreditCardExpirationYear  int32                  `protobuf:"varint,3,opt,name=credit_card_expiration_year,json=creditCardExpirationYear,proto3" json:"credit_card_expiration_year,omitempty"`
-	CreditCardExpirationMonth int32                  `protobuf:"varint,4,opt,name=credit_card_expiration_month,json=creditCardExpirationMonth,proto3" json:"credit_card_expiration_month,omitempty"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+// The data of one kind a service keeps about a shopper.
+type UserDataRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Such as "cart", "orders" or "transactions".
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// How many entries of the kind there are.
+	Count int64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// The entries, as JSON. Empty in the response of DeleteUserData.
+	Json          string `protobuf:"bytes,3,opt,name=json,proto3" json:"json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreditCardInfo) Reset() {
-	*x = CreditCardInfo{}
-	mi := &file_onlineboutique_proto_msgTypes[22]
+func (x *UserDataRecord) Reset() {
+	*x = UserDataRecord{}
+	mi := &file_onlineboutique_proto_msgTypes[127]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreditCardInfo) String() string {
+func (x *UserDataRecord) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreditCardInfo) ProtoMessage() {}
+func (*UserDataRecord) ProtoMessage() {}
 
-func (x *CreditCardInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[22]
+func (x *UserDataRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[127]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1196,62 +8049,54 @@ func (x *CreditCardInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreditCardInfo.ProtoReflect.Descriptor instead.
-func (*CreditCardInfo) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use UserDataRecord.ProtoReflect.Descriptor instead.
+func (*UserDataRecord) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{127}
 }
 
-func (x *CreditCardInfo) GetCreditCardNumber() string {
+func (x *UserDataRecord) GetKind() string {
 	if x != nil {
-		return x.CreditCardNumber
+		return x.Kind
 	}
 	return ""
 }
 
-func (x *CreditCardInfo) GetCreditCardCvv() int32 {
-	if x != nil {
-		return x.CreditCardCvv
-	}
-	return 0
-}
-
-func (x *CreditCardInfo) GetCreditCardExpirationYear() int32 {
+func (x *UserDataRecord) GetCount() int64 {
 	if x != nil {
-		return x.CreditCardExpirationYear
+		return x.Count
 	}
 	return 0
 }
 
-func (x *CreditCardInfo) GetCreditCardExpirationMonth() int32 {
+func (x *UserDataRecord) GetJson() string {
 	if x != nil {
-		return x.CreditCardExpirationMonth
+		return x.Json
 	}
-	return 0
+	return ""
 }
 
-type ChargeRequest struct {
+type EmailAddresses struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Amount        *Money                 `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
-	CreditCard    *CreditCardInfo        `protobuf:"bytes,2,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+	Addresses     []string               `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChargeRequest) Reset() {
-	*x = ChargeRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[23]
+func (x *EmailAddresses) Reset() {
+	*x = EmailAddresses{}
+	mi := &file_onlineboutique_proto_msgTypes[128]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChargeRequest) String() string {
+func (x *EmailAddresses) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChargeRequest) ProtoMessage() {}
+func (*EmailAddresses) ProtoMessage() {}
 
-func (x *ChargeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[23]
+func (x *EmailAddresses) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[128]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1262,47 +8107,43 @@ func (x *ChargeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChargeRequest.ProtoReflect.Descriptor instead.
-func (*ChargeRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{23}
-}
-
-func (x *ChargeRequest) GetAmount() *Money {
-	if x != nil {
-		return x.Amount
-	}
-	return nil
+// Deprecated: Use EmailAddresses.ProtoReflect.Descriptor instead.
+func (*EmailAddresses) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{128}
 }
 
-func (x *ChargeRequest) GetCreditCard() *CreditCardInfo {
+func (x *EmailAddresses) GetAddresses() []string {
 	if x != nil {
-		return x.CreditCard
+		return x.Addresses
 	}
 	return nil
 }
 
-type ChargeResponse struct {
+// ReferralVisited is published when a shopper first arrives with a referral
+// code.
+type ReferralVisited struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Tenant        string                 `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ChargeResponse) Reset() {
-	*x = ChargeResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[24]
+func (x *ReferralVisited) Reset() {
+	*x = ReferralVisited{}
+	mi := &file_onlineboutique_proto_msgTypes[129]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ChargeResponse) String() string {
+func (x *ReferralVisited) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChargeResponse) ProtoMessage() {}
+func (*ReferralVisited) ProtoMessage() {}
 
-func (x *ChargeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[24]
+func (x *ReferralVisited) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[129]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1313,41 +8154,48 @@ func (x *ChargeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChargeResponse.ProtoReflect.Descriptor instead.
-func (*ChargeResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ReferralVisited.ProtoReflect.Descriptor instead.
+func (*ReferralVisited) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{129}
 }
 
-func (x *ChargeResponse) GetTransactionId() string {
+func (x *ReferralVisited) GetCode() string {
 	if x != nil {
-		return x.TransactionId
+		return x.Code
 	}
 	return ""
 }
 
-type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *CartItem              `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
-	Cost          *Money                 `protobuf:"bytes,2,opt,name=cost,proto3" json:"cost,omitempty"`
+func (x *ReferralVisited) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+type ReferralStatsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The code to report on; empty reports on every code.
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_onlineboutique_proto_msgTypes[25]
+func (x *ReferralStatsRequest) Reset() {
+	*x = ReferralStatsRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[130]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *ReferralStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*ReferralStatsRequest) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[25]
+func (x *ReferralStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[130]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1358,51 +8206,46 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *OrderItem) GetItem() *CartItem {
-	if x != nil {
-		return x.Item
-	}
-	return nil
+// Deprecated: Use ReferralStatsRequest.ProtoReflect.Descriptor instead.
+func (*ReferralStatsRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{130}
 }
 
-func (x *OrderItem) GetCost() *Money {
+func (x *ReferralStatsRequest) GetCode() string {
 	if x != nil {
-		return x.Cost
+		return x.Code
 	}
-	return nil
+	return ""
 }
 
-type OrderResult struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	OrderId            string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	ShippingTrackingId string                 `protobuf:"bytes,2,opt,name=shipping_tracking_id,json=shippingTrackingId,proto3" json:"shipping_tracking_id,omitempty"`
-	ShippingCost       *Money                 `protobuf:"bytes,3,opt,name=shipping_cost,json=shippingCost,proto3" json:"shipping_cost,omitempty"`
-	ShippingAddress    *Address               `protobuf:"bytes,4,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
-	Items              []*OrderItem           `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type ReferralStat struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Code  string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Shoppers who arrived with the code.
+	Visits int64 `protobuf:"varint,2,opt,name=visits,proto3" json:"visits,omitempty"`
+	// Orders attributed to the code that were paid for, and those of them
+	// cancelled or refunded since.
+	Orders        int64 `protobuf:"varint,3,opt,name=orders,proto3" json:"orders,omitempty"`
+	Refunded      int64 `protobuf:"varint,4,opt,name=refunded,proto3" json:"refunded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderResult) Reset() {
-	*x = OrderResult{}
-	mi := &file_onlineboutique_proto_msgTypes[26]
+func (x *ReferralStat) Reset() {
+	*x = ReferralStat{}
+	mi := &file_onlineboutique_proto_msgTypes[131]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderResult) String() string {
+func (x *ReferralStat) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderResult) ProtoMessage() {}
+func (*ReferralStat) ProtoMessage() {}
 
-func (x *OrderResult) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[26]
+func (x *ReferralStat) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[131]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1413,69 +8256,61 @@ func (x *OrderResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderResult.ProtoReflect.Descriptor instead.
-func (*OrderResult) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{26}
-}
-
-func (x *OrderResult) GetOrderId() string {
-	if x != nil {
-		return x.OrderId
-	}
-	return ""
+// Deprecated: Use ReferralStat.ProtoReflect.Descriptor instead.
+func (*ReferralStat) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{131}
 }
 
-func (x *OrderResult) GetShippingTrackingId() string {
+func (x *ReferralStat) GetCode() string {
 	if x != nil {
-		return x.ShippingTrackingId
+		return x.Code
 	}
 	return ""
 }
 
-func (x *OrderResult) GetShippingCost() *Money {
+func (x *ReferralStat) GetVisits() int64 {
 	if x != nil {
-		return x.ShippingCost
+		return x.Visits
 	}
-	return nil
+	return 0
 }
 
-func (x *OrderResult) GetShippingAddress() *Address {
+func (x *ReferralStat) GetOrders() int64 {
 	if x != nil {
-		return x.ShippingAddress
+		return x.Orders
 	}
-	return nil
+	return 0
 }
 
-func (x *OrderResult) GetItems() []*OrderItem {
+func (x *ReferralStat) GetRefunded() int64 {
 	if x != nil {
-		return x.Items
+		return x.Refunded
 	}
-	return nil
+	return 0
 }
 
-type SendOrderConfirmationRequest struct {
+type ReferralStats struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Order         *OrderResult           `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Stats         []*ReferralStat        `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SendOrderConfirmationRequest) Reset() {
-	*x = SendOrderConfirmationRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[27]
+func (x *ReferralStats) Reset() {
+	*x = ReferralStats{}
+	mi := &file_onlineboutique_proto_msgTypes[132]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendOrderConfirmationRequest) String() string {
+func (x *ReferralStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendOrderConfirmationRequest) ProtoMessage() {}
+func (*ReferralStats) ProtoMessage() {}
 
-func (x *SendOrderConfirmationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[27]
+func (x *ReferralStats) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[132]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1486,51 +8321,48 @@ func (x *SendOrderConfirmationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendOrderConfirmationRequest.ProtoReflect.Descriptor instead.
-func (*SendOrderConfirmationRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *SendOrderConfirmationRequest) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
+// Deprecated: Use ReferralStats.ProtoReflect.Descriptor instead.
+func (*ReferralStats) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{132}
 }
 
-func (x *SendOrderConfirmationRequest) GetOrder() *OrderResult {
+func (x *ReferralStats) GetStats() []*ReferralStat {
 	if x != nil {
-		return x.Order
+		return x.Stats
 	}
 	return nil
 }
 
-type PlaceOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	UserCurrency  string                 `protobuf:"bytes,2,opt,name=user_currency,json=userCurrency,proto3" json:"user_currency,omitempty"`
-	Address       *Address               `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
-	Email         string                 `protobuf:"bytes,5,opt,name=email,proto3" json:"email,omitempty"`
-	CreditCard    *CreditCardInfo        `protobuf:"bytes,6,opt,name=credit_card,json=creditCard,proto3" json:"credit_card,omitempty"`
+type CreateTicketRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email   string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Subject string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Message string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// The order the ticket is about, if any.
+	OrderId string `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Language tag to acknowledge the ticket in.
+	Locale        string `protobuf:"bytes,7,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PlaceOrderRequest) Reset() {
-	*x = PlaceOrderRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[28]
+func (x *CreateTicketRequest) Reset() {
+	*x = CreateTicketRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[133]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PlaceOrderRequest) String() string {
+func (x *CreateTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PlaceOrderRequest) ProtoMessage() {}
+func (*CreateTicketRequest) ProtoMessage() {}
 
-func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[28]
+func (x *CreateTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[133]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1541,114 +8373,83 @@ func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
-func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use CreateTicketRequest.ProtoReflect.Descriptor instead.
+func (*CreateTicketRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{133}
 }
 
-func (x *PlaceOrderRequest) GetUserId() string {
+func (x *CreateTicketRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *PlaceOrderRequest) GetUserCurrency() string {
+func (x *CreateTicketRequest) GetName() string {
 	if x != nil {
-		return x.UserCurrency
+		return x.Name
 	}
 	return ""
 }
 
-func (x *PlaceOrderRequest) GetAddress() *Address {
+func (x *CreateTicketRequest) GetEmail() string {
 	if x != nil {
-		return x.Address
+		return x.Email
 	}
-	return nil
+	return ""
 }
 
-func (x *PlaceOrderRequest) GetEmail() string {
+func (x *CreateTicketRequest) GetSubject() string {
 	if x != nil {
-		return x.Email
+		return x.Subject
 	}
 	return ""
 }
 
-func (x *PlaceOrderRequest) GetCreditCard() *CreditCardInfo {
+func (x *CreateTicketRequest) GetMessage() string {
 	if x != nil {
-		return x.CreditCard
+		return x.Message
 	}
-	return nil
-}
-
-type PlaceOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *OrderResult           `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *PlaceOrderResponse) Reset() {
-	*x = PlaceOrderResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[29]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *PlaceOrderResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return ""
 }
 
-func (*PlaceOrderResponse) ProtoMessage() {}
-
-func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[29]
+func (x *CreateTicketRequest) GetOrderId() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.OrderId
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
-func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{29}
+	return ""
 }
 
-func (x *PlaceOrderResponse) GetOrder() *OrderResult {
+func (x *CreateTicketRequest) GetLocale() string {
 	if x != nil {
-		return x.Order
+		return x.Locale
 	}
-	return nil
+	return ""
 }
 
-type AdRequest struct {
-	state  protoimpl.MessageState `protogen:"open.v1"`
-	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	// List of important key words from the current page describing the context.
-	ContextKeys   []string `protobuf:"bytes,2,rep,name=context_keys,json=contextKeys,proto3" json:"context_keys,omitempty"`
+type GetTicketRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TicketId      string                 `protobuf:"bytes,2,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdRequest) Reset() {
-	*x = AdRequest{}
-	mi := &file_onlineboutique_proto_msgTypes[30]
+func (x *GetTicketRequest) Reset() {
+	*x = GetTicketRequest{}
+	mi := &file_onlineboutique_proto_msgTypes[134]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdRequest) String() string {
+func (x *GetTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdRequest) ProtoMessage() {}
+func (*GetTicketRequest) ProtoMessage() {}
 
-func (x *AdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[30]
+func (x *GetTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[134]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1659,47 +8460,59 @@ func (x *AdRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdRequest.ProtoReflect.Descriptor instead.
-func (*AdRequest) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use GetTicketRequest.ProtoReflect.Descriptor instead.
+func (*GetTicketRequest) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{134}
 }
 
-func (x *AdRequest) GetUserId() string {
+func (x *GetTicketRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *AdRequest) GetContextKeys() []string {
+func (x *GetTicketRequest) GetTicketId() string {
 	if x != nil {
-		return x.ContextKeys
+		return x.TicketId
 	}
-	return nil
+	return ""
 }
 
-type AdResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ads           []*Ad                  `protobuf:"bytes,1,rep,name=ads,proto3" json:"ads,omitempty"`
+type Ticket struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Email   string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Subject string                 `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Message string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	OrderId string                 `protobuf:"bytes,7,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Locale  string                 `protobuf:"bytes,8,opt,name=locale,proto3" json:"locale,omitempty"`
+	// OPEN or CLOSED.
+	Status    string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt int64  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Whether the shopper was emailed that the ticket was received.
+	Acknowledged  bool `protobuf:"varint,11,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdResponse) Reset() {
-	*x = AdResponse{}
-	mi := &file_onlineboutique_proto_msgTypes[31]
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_onlineboutique_proto_msgTypes[135]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdResponse) String() string {
+func (x *Ticket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdResponse) ProtoMessage() {}
+func (*Ticket) ProtoMessage() {}
 
-func (x *AdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[31]
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_onlineboutique_proto_msgTypes[135]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1710,101 +8523,147 @@ func (x *AdResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdResponse.ProtoReflect.Descriptor instead.
-func (*AdResponse) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_onlineboutique_proto_rawDescGZIP(), []int{135}
 }
 
-func (x *AdResponse) GetAds() []*Ad {
+func (x *Ticket) GetId() string {
 	if x != nil {
-		return x.Ads
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type Ad struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// url to redirect to when an ad is clicked.
-	RedirectUrl string `protobuf:"bytes,1,opt,name=redirect_url,json=redirectUrl,proto3" json:"redirect_url,omitempty"`
-	// short advertisement text to display.
-	Text          string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Ticket) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *Ad) Reset() {
-	*x = Ad{}
-	mi := &file_onlineboutique_proto_msgTypes[32]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Ticket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *Ad) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Ticket) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
 }
 
-func (*Ad) ProtoMessage() {}
+func (x *Ticket) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
 
-func (x *Ad) ProtoReflect() protoreflect.Message {
-	mi := &file_onlineboutique_proto_msgTypes[32]
+func (x *Ticket) GetMessage() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Message
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use Ad.ProtoReflect.Descriptor instead.
-func (*Ad) Descriptor() ([]byte, []int) {
-	return file_onlineboutique_proto_rawDescGZIP(), []int{32}
+func (x *Ticket) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
 }
 
-func (x *Ad) GetRedirectUrl() string {
+func (x *Ticket) GetLocale() string {
 	if x != nil {
-		return x.RedirectUrl
+		return x.Locale
 	}
 	return ""
 }
 
-func (x *Ad) GetText() string {
+func (x *Ticket) GetStatus() string {
 	if x != nil {
-		return x.Text
+		return x.Status
 	}
 	return ""
 }
 
+func (x *Ticket) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Ticket) GetAcknowledged() bool {
+	if x != nil {
+		return x.Acknowledged
+	}
+	return false
+}
+
 var File_onlineboutique_proto protoreflect.FileDescriptor
 
 const file_onlineboutique_proto_rawDesc = "" +
 	"\n" +
-	"\x14onlineboutique.proto\x12\x0eonlineboutique\"E\n" +
+	"\x14onlineboutique.proto\x12\x0eonlineboutique\"d\n" +
 	"\bCartItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"W\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\tR\tvariantId\"W\n" +
 	"\x0eAddItemRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12,\n" +
-	"\x04item\x18\x02 \x01(\v2\x18.onlineboutique.CartItemR\x04item\"+\n" +
+	"\x04item\x18\x02 \x01(\v2\x18.onlineboutique.CartItemR\x04item\"Z\n" +
+	"\x11CartLimitExceeded\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\tR\x05limit\x12\x10\n" +
+	"\x03max\x18\x02 \x01(\x05R\x03max\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\"+\n" +
 	"\x10EmptyCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\")\n" +
 	"\x0eGetCartRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"O\n" +
 	"\x04Cart\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12.\n" +
-	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\"\a\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\"I\n" +
+	"\x0eCatalogUpdated\x12\x1f\n" +
+	"\vproduct_ids\x18\x01 \x03(\tR\n" +
+	"productIds\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"P\n" +
+	"\x0fCurrencyUpdated\x12%\n" +
+	"\x0ecurrency_codes\x18\x01 \x03(\tR\rcurrencyCodes\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x9e\x01\n" +
+	"\rCartAbandoned\x12(\n" +
+	"\x04cart\x18\x01 \x01(\v2\x14.onlineboutique.CartR\x04cart\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\x03R\tupdatedAt\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x16\n" +
+	"\x06tenant\x18\x05 \x01(\tR\x06tenant\"\a\n" +
 	"\x05Empty\"$\n" +
 	"\tEmptyUser\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"V\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x96\x01\n" +
 	"\x1aListRecommendationsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
 	"\vproduct_ids\x18\x02 \x03(\tR\n" +
-	"productIds\">\n" +
-	"\x1bListRecommendationsResponse\x12\x1f\n" +
-	"\vproduct_ids\x18\x01 \x03(\tR\n" +
-	"productIds\"\xbd\x01\n" +
+	"productIds\x12>\n" +
+	"\fpage_context\x18\x03 \x01(\v2\x1b.onlineboutique.PageContextR\vpageContext\"-\n" +
+	"\vPageContext\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\tR\n" +
+	"categories\"g\n" +
+	"\x1bListRecommendationsResponse\x12H\n" +
+	"\x0frecommendations\x18\x01 \x03(\v2\x1e.onlineboutique.RecommendationR\x0frecommendations\"l\n" +
+	"\x0eRecommendation\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12#\n" +
+	"\rreason_detail\x18\x03 \x01(\tR\freasonDetail\"\xa6\x03\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
@@ -1813,32 +8672,248 @@ const file_onlineboutique_proto_rawDesc = "" +
 	"\tprice_usd\x18\x05 \x01(\v2\x15.onlineboutique.MoneyR\bpriceUsd\x12\x1e\n" +
 	"\n" +
 	"categories\x18\x06 \x03(\tR\n" +
-	"categories\"K\n" +
+	"categories\x12:\n" +
+	"\tthumbnail\x18\a \x01(\v2\x1c.onlineboutique.ProductImageR\tthumbnail\x124\n" +
+	"\x06medium\x18\b \x01(\v2\x1c.onlineboutique.ProductImageR\x06medium\x12;\n" +
+	"\x0esale_price_usd\x18\t \x01(\v2\x15.onlineboutique.MoneyR\fsalePriceUsd\x12\x1d\n" +
+	"\n" +
+	"sale_start\x18\n" +
+	" \x01(\x03R\tsaleStart\x12\x19\n" +
+	"\bsale_end\x18\v \x01(\x03R\asaleEnd\"6\n" +
+	"\fProductImage\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05width\x18\x02 \x01(\x05R\x05width\"K\n" +
 	"\x14ListProductsResponse\x123\n" +
-	"\bproducts\x18\x01 \x03(\v2\x17.onlineboutique.ProductR\bproducts\"#\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.onlineboutique.ProductR\bproducts\"\xb4\x01\n" +
+	"\x0eProductVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1e\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\tR\n" +
+	"attributes\x12\x14\n" +
+	"\x05stock\x18\x04 \x01(\x05R\x05stock\x12=\n" +
+	"\x0fprice_delta_usd\x18\x05 \x01(\v2\x15.onlineboutique.MoneyR\rpriceDeltaUsd\"4\n" +
+	"\x13ListVariantsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"R\n" +
+	"\x14ListVariantsResponse\x12:\n" +
+	"\bvariants\x18\x01 \x03(\v2\x1e.onlineboutique.ProductVariantR\bvariants\"Q\n" +
+	"\x11GetVariantRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\tR\tvariantId\"0\n" +
+	"\x0fGetStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\"a\n" +
+	"\n" +
+	"StockLevel\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
+	"\atracked\x18\x02 \x01(\bR\atracked\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"q\n" +
+	"\x15RestockVariantRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\tR\tvariantId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\"\x88\x01\n" +
+	"\x1aNotifyWhenAvailableRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\tR\tvariantId\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"\xfc\x01\n" +
+	"\vPricingRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vproduct_ids\x18\x04 \x03(\tR\n" +
+	"productIds\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12!\n" +
+	"\fmin_quantity\x18\x06 \x01(\x05R\vminQuantity\x12\x1f\n" +
+	"\vpercent_off\x18\a \x01(\x05R\n" +
+	"percentOff\x12\x14\n" +
+	"\x05start\x18\b \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\t \x01(\x03R\x03end\"A\n" +
+	"\fPricingRules\x121\n" +
+	"\x05rules\x18\x01 \x03(\v2\x1b.onlineboutique.PricingRuleR\x05rules\"\xfe\x01\n" +
+	"\vPriceChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vproduct_ids\x18\x03 \x03(\tR\n" +
+	"productIds\x12;\n" +
+	"\x0esale_price_usd\x18\x04 \x01(\v2\x15.onlineboutique.MoneyR\fsalePriceUsd\x12\x1f\n" +
+	"\vpercent_off\x18\x05 \x01(\x05R\n" +
+	"percentOff\x12\x14\n" +
+	"\x05start\x18\x06 \x01(\x03R\x05start\x12\x10\n" +
+	"\x03end\x18\a \x01(\x03R\x03end\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\"E\n" +
+	"\fPriceChanges\x125\n" +
+	"\achanges\x18\x01 \x03(\v2\x1b.onlineboutique.PriceChangeR\achanges\"1\n" +
+	"\x17ListPriceChangesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\"`\n" +
+	"\x13ReserveStockRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\"/\n" +
+	"\x12ReservationRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\x94\x01\n" +
+	"\x10StockReservation\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"\xad\x01\n" +
+	"\x10ProductRestocked\x121\n" +
+	"\aproduct\x18\x01 \x01(\v2\x17.onlineboutique.ProductR\aproduct\x128\n" +
+	"\avariant\x18\x02 \x01(\v2\x1e.onlineboutique.ProductVariantR\avariant\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\"#\n" +
 	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x12GetProductsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"j\n" +
 	"\x15SearchProductsRequest\x12\x14\n" +
-	"\x05query\x18\x01 \x01(\tR\x05query\"K\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x1f\n" +
+	"\vprice_range\x18\x03 \x01(\tR\n" +
+	"priceRange\"\x81\x01\n" +
 	"\x16SearchProductsResponse\x121\n" +
-	"\aresults\x18\x01 \x03(\v2\x17.onlineboutique.ProductR\aresults\"t\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.onlineboutique.ProductR\aresults\x124\n" +
+	"\x06facets\x18\x02 \x01(\v2\x1c.onlineboutique.SearchFacetsR\x06facets\"B\n" +
+	"\fSearchFacets\x122\n" +
+	"\x06counts\x18\x01 \x03(\v2\x1a.onlineboutique.FacetCountR\x06counts\"N\n" +
+	"\n" +
+	"FacetCount\x12\x14\n" +
+	"\x05facet\x18\x01 \x01(\tR\x05facet\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"F\n" +
+	"\x16SuggestProductsRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"^\n" +
+	"\x17SuggestProductsResponse\x12C\n" +
+	"\vsuggestions\x18\x01 \x03(\v2!.onlineboutique.ProductSuggestionR\vsuggestions\"7\n" +
+	"\x11ProductSuggestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xd8\x01\n" +
+	"\x15ImportProductsRequest\x12\x1b\n" +
+	"\timport_id\x18\x01 \x01(\tR\bimportId\x12\x1f\n" +
+	"\vchunk_index\x18\x02 \x01(\x05R\n" +
+	"chunkIndex\x12\x1f\n" +
+	"\vchunk_count\x18\x03 \x01(\x05R\n" +
+	"chunkCount\x123\n" +
+	"\bproducts\x18\x04 \x03(\v2\x17.onlineboutique.ProductR\bproducts\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\"H\n" +
+	"\rImportProblem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xd6\x01\n" +
+	"\x16ImportProductsResponse\x12\x1b\n" +
+	"\timport_id\x18\x01 \x01(\tR\bimportId\x12'\n" +
+	"\x0fchunks_received\x18\x02 \x01(\x05R\x0echunksReceived\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12#\n" +
+	"\rproduct_count\x18\x04 \x01(\x05R\fproductCount\x129\n" +
+	"\bproblems\x18\x05 \x03(\v2\x1d.onlineboutique.ImportProblemR\bproblems\"E\n" +
+	"\x15ExportProductsRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x05R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"c\n" +
+	"\x16ExportProductsResponse\x123\n" +
+	"\bproducts\x18\x01 \x03(\v2\x17.onlineboutique.ProductR\bproducts\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\xa7\x01\n" +
 	"\x0fGetQuoteRequest\x121\n" +
 	"\aaddress\x18\x01 \x01(\v2\x17.onlineboutique.AddressR\aaddress\x12.\n" +
-	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\"D\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\x121\n" +
+	"\bsubtotal\x18\x03 \x01(\v2\x15.onlineboutique.MoneyR\bsubtotal\"\xeb\x01\n" +
 	"\x10GetQuoteResponse\x120\n" +
-	"\bcost_usd\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\acostUsd\"u\n" +
+	"\bcost_usd\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\acostUsd\x121\n" +
+	"\x06origin\x18\x02 \x01(\v2\x19.onlineboutique.WarehouseR\x06origin\x12#\n" +
+	"\rfree_shipping\x18\x03 \x01(\bR\ffreeShipping\x12M\n" +
+	"\x17free_shipping_remaining\x18\x04 \x01(\v2\x15.onlineboutique.MoneyR\x15freeShippingRemaining\"\x88\x03\n" +
 	"\x10ShipOrderRequest\x121\n" +
 	"\aaddress\x18\x01 \x01(\v2\x17.onlineboutique.AddressR\aaddress\x12.\n" +
-	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\"4\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x16\n" +
+	"\x06locale\x18\x05 \x01(\tR\x06locale\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tgift_wrap\x18\a \x01(\bR\bgiftWrap\x12\x12\n" +
+	"\x04note\x18\b \x01(\tR\x04note\x12!\n" +
+	"\fwarehouse_id\x18\t \x01(\tR\vwarehouseId\x12\x12\n" +
+	"\x04part\x18\n" +
+	" \x01(\x05R\x04part\x12G\n" +
+	"\x0fdelivery_window\x18\v \x01(\v2\x1e.onlineboutique.DeliveryWindowR\x0edeliveryWindow\"y\n" +
+	"\x14PlanShipmentsRequest\x121\n" +
+	"\aaddress\x18\x01 \x01(\v2\x17.onlineboutique.AddressR\aaddress\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\"\x83\x01\n" +
+	"\rShipmentGroup\x12!\n" +
+	"\fwarehouse_id\x18\x01 \x01(\tR\vwarehouseId\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\x12\x1f\n" +
+	"\vtracking_id\x18\x03 \x01(\tR\n" +
+	"trackingId\"G\n" +
+	"\x0eShipmentGroups\x125\n" +
+	"\x06groups\x18\x01 \x03(\v2\x1d.onlineboutique.ShipmentGroupR\x06groups\"~\n" +
+	"\x19GetDeliveryOptionsRequest\x121\n" +
+	"\aaddress\x18\x01 \x01(\v2\x17.onlineboutique.AddressR\aaddress\x12.\n" +
+	"\x05items\x18\x02 \x03(\v2\x18.onlineboutique.CartItemR\x05items\"J\n" +
+	"\x0eDeliveryWindow\x12\x1d\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\tR\tstartDate\x12\x19\n" +
+	"\bend_date\x18\x02 \x01(\tR\aendDate\"K\n" +
+	"\x0fDeliveryOptions\x128\n" +
+	"\awindows\x18\x01 \x03(\v2\x1e.onlineboutique.DeliveryWindowR\awindows\"g\n" +
 	"\x11ShipOrderResponse\x12\x1f\n" +
 	"\vtracking_id\x18\x01 \x01(\tR\n" +
-	"trackingId\"\x8f\x01\n" +
+	"trackingId\x121\n" +
+	"\x06origin\x18\x02 \x01(\v2\x19.onlineboutique.WarehouseR\x06origin\"b\n" +
+	"\tWarehouse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
+	"\aaddress\x18\x03 \x01(\v2\x17.onlineboutique.AddressR\aaddress\"5\n" +
+	"\x12GetShipmentRequest\x12\x1f\n" +
+	"\vtracking_id\x18\x01 \x01(\tR\n" +
+	"trackingId\"P\n" +
+	"\x15CancelShipmentRequest\x12\x1f\n" +
+	"\vtracking_id\x18\x01 \x01(\tR\n" +
+	"trackingId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xcf\x01\n" +
+	"\bShipment\x12\x1f\n" +
+	"\vtracking_id\x18\x01 \x01(\tR\n" +
+	"trackingId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x121\n" +
+	"\x06origin\x18\x06 \x01(\v2\x19.onlineboutique.WarehouseR\x06origin\"\xd5\x01\n" +
+	"\x15ShipmentStatusChanged\x124\n" +
+	"\bshipment\x18\x01 \x01(\v2\x18.onlineboutique.ShipmentR\bshipment\x12'\n" +
+	"\x0fprevious_status\x18\x02 \x01(\tR\x0epreviousStatus\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06tenant\x18\x06 \x01(\tR\x06tenant\"\x8f\x01\n" +
 	"\aAddress\x12%\n" +
 	"\x0estreet_address\x18\x01 \x01(\tR\rstreetAddress\x12\x12\n" +
 	"\x04city\x18\x02 \x01(\tR\x04city\x12\x14\n" +
 	"\x05state\x18\x03 \x01(\tR\x05state\x12\x18\n" +
 	"\acountry\x18\x04 \x01(\tR\acountry\x12\x19\n" +
-	"\bzip_code\x18\x05 \x01(\x05R\azipCode\"X\n" +
+	"\bzip_code\x18\x05 \x01(\x05R\azipCode\"d\n" +
+	"\x16ValidateAddressRequest\x121\n" +
+	"\aaddress\x18\x01 \x01(\v2\x17.onlineboutique.AddressR\aaddress\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"T\n" +
+	"\x0eAddressProblem\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x8e\x01\n" +
+	"\x17ValidateAddressResponse\x127\n" +
+	"\n" +
+	"normalized\x18\x01 \x01(\v2\x17.onlineboutique.AddressR\n" +
+	"normalized\x12:\n" +
+	"\bproblems\x18\x02 \x03(\v2\x1e.onlineboutique.AddressProblemR\bproblems\"X\n" +
 	"\x05Money\x12#\n" +
 	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\x12\x14\n" +
 	"\x05units\x18\x02 \x01(\x03R\x05units\x12\x14\n" +
@@ -1848,74 +8923,409 @@ const file_onlineboutique_proto_rawDesc = "" +
 	"\x19CurrencyConversionRequest\x12)\n" +
 	"\x04from\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\x04from\x12\x17\n" +
 	"\ato_code\x18\x02 \x01(\tR\x06toCode\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\"\xe6\x01\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"l\n" +
+	"\x1aCurrencyConversionResponse\x12+\n" +
+	"\x05money\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\x05money\x12!\n" +
+	"\fapplied_rate\x18\x02 \x01(\tR\vappliedRate\"d\n" +
+	"\x13ExchangeRateRequest\x12\x1b\n" +
+	"\tfrom_code\x18\x01 \x01(\tR\bfromCode\x12\x17\n" +
+	"\ato_code\x18\x02 \x01(\tR\x06toCode\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\"\x9b\x01\n" +
+	"\x14ExchangeRateResponse\x12\x1b\n" +
+	"\tfrom_code\x18\x01 \x01(\tR\bfromCode\x12\x17\n" +
+	"\ato_code\x18\x02 \x01(\tR\x06toCode\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12!\n" +
+	"\flast_updated\x18\x05 \x01(\x03R\vlastUpdated\"\x82\x01\n" +
+	"\rRateAtRequest\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12\x1b\n" +
+	"\tfrom_code\x18\x02 \x01(\tR\bfromCode\x12\x17\n" +
+	"\ato_code\x18\x03 \x01(\tR\x06toCode\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x0e\n" +
+	"\x02at\x18\x05 \x01(\x03R\x02at\"\xe6\x01\n" +
 	"\x0eCreditCardInfo\x12,\n" +
 	"\x12credit_card_number\x18\x01 \x01(\tR\x10creditCardNumber\x12&\n" +
 	"\x0fcredit_card_cvv\x18\x02 \x01(\x05R\rcreditCardCvv\x12=\n" +
 	"\x1bcredit_card_expiration_year\x18\x03 \x01(\x05R\x18creditCardExpirationYear\x12?\n" +
-	"\x1ccredit_card_expiration_month\x18\x04 \x01(\x05R\x19creditCardExpirationMonth\"\x7f\n" +
+	"\x1ccredit_card_expiration_month\x18\x04 \x01(\x05R\x19creditCardExpirationMonth\"\xd7\x01\n" +
 	"\rChargeRequest\x12-\n" +
 	"\x06amount\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\x06amount\x12?\n" +
 	"\vcredit_card\x18\x02 \x01(\v2\x1e.onlineboutique.CreditCardInfoR\n" +
-	"creditCard\"7\n" +
+	"creditCard\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\"\n" +
+	"\finstallments\x18\x04 \x01(\x05R\finstallments\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\"\x83\x01\n" +
 	"\x0eChargeResponse\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"d\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12J\n" +
+	"\x10installment_plan\x18\x02 \x01(\v2\x1f.onlineboutique.InstallmentPlanR\x0finstallmentPlan\"k\n" +
+	"\vInstallment\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12-\n" +
+	"\x06amount\x18\x02 \x01(\v2\x15.onlineboutique.MoneyR\x06amount\x12\x15\n" +
+	"\x06due_at\x18\x03 \x01(\x03R\x05dueAt\"R\n" +
+	"\x0fInstallmentPlan\x12?\n" +
+	"\finstallments\x18\x01 \x03(\v2\x1b.onlineboutique.InstallmentR\finstallments\"\xa5\x03\n" +
+	"\vTransaction\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x06amount\x18\x03 \x01(\v2\x15.onlineboutique.MoneyR\x06amount\x12\x1d\n" +
+	"\n" +
+	"card_brand\x18\x04 \x01(\tR\tcardBrand\x12$\n" +
+	"\x0ecard_last_four\x18\x05 \x01(\tR\fcardLastFour\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\a \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\b \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\x03R\tupdatedAt\x12J\n" +
+	"\x10installment_plan\x18\n" +
+	" \x01(\v2\x1f.onlineboutique.InstallmentPlanR\x0finstallmentPlan\x12\x19\n" +
+	"\border_id\x18\v \x01(\tR\aorderId\"\xac\x01\n" +
+	"\x14PaymentStatusChanged\x12=\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1b.onlineboutique.TransactionR\vtransaction\x12'\n" +
+	"\x0fprevious_status\x18\x02 \x01(\tR\x0epreviousStatus\x12\x14\n" +
+	"\x05event\x18\x03 \x01(\tR\x05event\x12\x16\n" +
+	"\x06tenant\x18\x04 \x01(\tR\x06tenant\">\n" +
+	"\x15GetTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"N\n" +
+	"\rRefundRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"8\n" +
+	"\x1dListTransactionsByUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"[\n" +
+	"\x18ListTransactionsResponse\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.onlineboutique.TransactionR\ftransactions\"\xe0\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x03R\x03seq\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\x12\x18\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\x12\x16\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06tenant\x18\x06 \x01(\tR\x06tenant\x12\x18\n" +
+	"\adetails\x18\a \x01(\tR\adetails\x12\x1b\n" +
+	"\tprev_hash\x18\b \x01(\tR\bprevHash\x12\x12\n" +
+	"\x04hash\x18\t \x01(\tR\x04hash\"J\n" +
+	"\x17ListAuditEntriesRequest\x12\x19\n" +
+	"\bfrom_seq\x18\x01 \x01(\x03R\afromSeq\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"{\n" +
+	"\fAuditEntries\x124\n" +
+	"\aentries\x18\x01 \x03(\v2\x1a.onlineboutique.AuditEntryR\aentries\x12\x16\n" +
+	"\x06intact\x18\x02 \x01(\bR\x06intact\x12\x1d\n" +
+	"\n" +
+	"broken_seq\x18\x03 \x01(\x03R\tbrokenSeq\">\n" +
+	"\x17GetWalletBalanceRequest\x12#\n" +
+	"\rcurrency_code\x18\x01 \x01(\tR\fcurrencyCode\"@\n" +
+	"\rWalletBalance\x12/\n" +
+	"\abalance\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\abalance\"+\n" +
+	"\x15RedeemGiftCardRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"^\n" +
+	"\x12WalletDebitRequest\x12-\n" +
+	"\x06amount\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\x06amount\x12\x19\n" +
+	"\bdebit_id\x18\x02 \x01(\tR\adebitId\"0\n" +
+	"\x13WalletRefundRequest\x12\x19\n" +
+	"\bdebit_id\x18\x01 \x01(\tR\adebitId\"q\n" +
+	" SendTicketAcknowledgementRequest\x12.\n" +
+	"\x06ticket\x18\x01 \x01(\v2\x16.onlineboutique.TicketR\x06ticket\x12\x1d\n" +
+	"\n" +
+	"dedupe_key\x18\x02 \x01(\tR\tdedupeKey\"d\n" +
 	"\tOrderItem\x12,\n" +
 	"\x04item\x18\x01 \x01(\v2\x18.onlineboutique.CartItemR\x04item\x12)\n" +
-	"\x04cost\x18\x02 \x01(\v2\x15.onlineboutique.MoneyR\x04cost\"\x8b\x02\n" +
+	"\x04cost\x18\x02 \x01(\v2\x15.onlineboutique.MoneyR\x04cost\"\xcf\x05\n" +
 	"\vOrderResult\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x120\n" +
 	"\x14shipping_tracking_id\x18\x02 \x01(\tR\x12shippingTrackingId\x12:\n" +
 	"\rshipping_cost\x18\x03 \x01(\v2\x15.onlineboutique.MoneyR\fshippingCost\x12B\n" +
 	"\x10shipping_address\x18\x04 \x01(\v2\x17.onlineboutique.AddressR\x0fshippingAddress\x12/\n" +
-	"\x05items\x18\x05 \x03(\v2\x19.onlineboutique.OrderItemR\x05items\"g\n" +
+	"\x05items\x18\x05 \x03(\v2\x19.onlineboutique.OrderItemR\x05items\x12<\n" +
+	"\tbreakdown\x18\x06 \x01(\v2\x1e.onlineboutique.OrderBreakdownR\tbreakdown\x12\x1b\n" +
+	"\tgift_wrap\x18\a \x01(\bR\bgiftWrap\x12\x12\n" +
+	"\x04note\x18\b \x01(\tR\x04note\x12<\n" +
+	"\tshipments\x18\t \x01(\v2\x1e.onlineboutique.ShipmentGroupsR\tshipments\x12G\n" +
+	"\x0fdelivery_window\x18\n" +
+	" \x01(\v2\x1e.onlineboutique.DeliveryWindowR\x0edeliveryWindow\x12J\n" +
+	"\x10installment_plan\x18\v \x01(\v2\x1f.onlineboutique.InstallmentPlanR\x0finstallmentPlan\x126\n" +
+	"\vwallet_paid\x18\f \x01(\v2\x15.onlineboutique.MoneyR\n" +
+	"walletPaid\x12H\n" +
+	"\x10post_order_steps\x18\r \x01(\v2\x1e.onlineboutique.PostOrderStepsR\x0epostOrderSteps\"S\n" +
+	"\rPostOrderStep\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"E\n" +
+	"\x0ePostOrderSteps\x123\n" +
+	"\x05steps\x18\x01 \x03(\v2\x1d.onlineboutique.PostOrderStepR\x05steps\"\xf6\x03\n" +
+	"\x0eOrderBreakdown\x12+\n" +
+	"\x05items\x18\x01 \x01(\v2\x15.onlineboutique.MoneyR\x05items\x121\n" +
+	"\bshipping\x18\x02 \x01(\v2\x15.onlineboutique.MoneyR\bshipping\x12'\n" +
+	"\x03tax\x18\x03 \x01(\v2\x15.onlineboutique.MoneyR\x03tax\x121\n" +
+	"\bdiscount\x18\x04 \x01(\v2\x15.onlineboutique.MoneyR\bdiscount\x12+\n" +
+	"\x05total\x18\x05 \x01(\v2\x15.onlineboutique.MoneyR\x05total\x12C\n" +
+	"\vconversions\x18\x06 \x03(\v2!.onlineboutique.AppliedConversionR\vconversions\x122\n" +
+	"\tgift_wrap\x18\a \x01(\v2\x15.onlineboutique.MoneyR\bgiftWrap\x12>\n" +
+	"\fpinned_rates\x18\b \x01(\v2\x1b.onlineboutique.PinnedRatesR\vpinnedRates\x12B\n" +
+	"\vadjustments\x18\t \x01(\v2 .onlineboutique.PriceAdjustmentsR\vadjustments\"\xae\x01\n" +
+	"\x0fPriceAdjustment\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\tR\tproductId\x12-\n" +
+	"\x06amount\x18\x05 \x01(\v2\x15.onlineboutique.MoneyR\x06amount\"U\n" +
+	"\x10PriceAdjustments\x12A\n" +
+	"\vadjustments\x18\x01 \x03(\v2\x1f.onlineboutique.PriceAdjustmentR\vadjustments\"s\n" +
+	"\n" +
+	"PinnedRate\x12\x1b\n" +
+	"\tfrom_code\x18\x01 \x01(\tR\bfromCode\x12\x17\n" +
+	"\ato_code\x18\x02 \x01(\tR\x06toCode\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\tR\x04rate\x12\x1b\n" +
+	"\tpinned_at\x18\x04 \x01(\x03R\bpinnedAt\"?\n" +
+	"\vPinnedRates\x120\n" +
+	"\x05rates\x18\x01 \x03(\v2\x1a.onlineboutique.PinnedRateR\x05rates\"\x97\x01\n" +
+	"\x11AppliedConversion\x12\x1c\n" +
+	"\tcomponent\x18\x01 \x01(\tR\tcomponent\x12)\n" +
+	"\x04from\x18\x02 \x01(\v2\x15.onlineboutique.MoneyR\x04from\x12%\n" +
+	"\x02to\x18\x03 \x01(\v2\x15.onlineboutique.MoneyR\x02to\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\"\x9e\x01\n" +
 	"\x1cSendOrderConfirmationRequest\x12\x14\n" +
 	"\x05email\x18\x01 \x01(\tR\x05email\x121\n" +
-	"\x05order\x18\x02 \x01(\v2\x1b.onlineboutique.OrderResultR\x05order\"\xdb\x01\n" +
+	"\x05order\x18\x02 \x01(\v2\x1b.onlineboutique.OrderResultR\x05order\x12\x16\n" +
+	"\x06locale\x18\x03 \x01(\tR\x06locale\x12\x1d\n" +
+	"\n" +
+	"dedupe_key\x18\x04 \x01(\tR\tdedupeKey\"x\n" +
+	"\x0fCampaignSegment\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x1d\n" +
+	"\n" +
+	"min_orders\x18\x02 \x01(\x05R\tminOrders\x12.\n" +
+	"\x13ordered_within_days\x18\x03 \x01(\x05R\x11orderedWithinDays\"\xcd\x01\n" +
+	"\x13SendCampaignRequest\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\x129\n" +
+	"\asegment\x18\x03 \x01(\v2\x1f.onlineboutique.CampaignSegmentR\asegment\x12\x1d\n" +
+	"\n" +
+	"test_email\x18\x04 \x01(\tR\ttestEmail\x12\x1f\n" +
+	"\vtest_locale\x18\x05 \x01(\tR\n" +
+	"testLocale\"\x83\x01\n" +
+	"\x0eCampaignResult\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\tR\n" +
+	"campaignId\x12\x18\n" +
+	"\amatched\x18\x02 \x01(\x05R\amatched\x12\x1e\n" +
+	"\n" +
+	"suppressed\x18\x03 \x01(\x05R\n" +
+	"suppressed\x12\x16\n" +
+	"\x06queued\x18\x04 \x01(\x05R\x06queued\"*\n" +
+	"\x12UnsubscribeRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\".\n" +
+	"\x11GetReceiptRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"&\n" +
+	"\x12GetReceiptResponse\x12\x10\n" +
+	"\x03pdf\x18\x01 \x01(\tR\x03pdf\"2\n" +
+	"\x15GetOrderStatusRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"\xde\x01\n" +
+	"\vOrderStatus\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\x03R\tupdatedAt\x12>\n" +
+	"\x0frefunded_amount\x18\x06 \x01(\v2\x15.onlineboutique.MoneyR\x0erefundedAmount\"\xf6\x01\n" +
+	"\x12OrderStatusChanged\x123\n" +
+	"\x06status\x18\x01 \x01(\v2\x1b.onlineboutique.OrderStatusR\x06status\x12'\n" +
+	"\x0fprevious_status\x18\x02 \x01(\tR\x0epreviousStatus\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x16\n" +
+	"\x06locale\x18\x04 \x01(\tR\x06locale\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06tenant\x18\x06 \x01(\tR\x06tenant\x12#\n" +
+	"\rreferral_code\x18\a \x01(\tR\freferralCode\"\xd3\x04\n" +
 	"\x11PlaceOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
 	"\ruser_currency\x18\x02 \x01(\tR\fuserCurrency\x121\n" +
 	"\aaddress\x18\x03 \x01(\v2\x17.onlineboutique.AddressR\aaddress\x12\x14\n" +
 	"\x05email\x18\x05 \x01(\tR\x05email\x12?\n" +
 	"\vcredit_card\x18\x06 \x01(\v2\x1e.onlineboutique.CreditCardInfoR\n" +
-	"creditCard\"G\n" +
+	"creditCard\x12\x16\n" +
+	"\x06locale\x18\a \x01(\tR\x06locale\x12,\n" +
+	"\x12expected_cart_hash\x18\b \x01(\tR\x10expectedCartHash\x12\x1b\n" +
+	"\tgift_wrap\x18\t \x01(\bR\bgiftWrap\x12\x12\n" +
+	"\x04note\x18\n" +
+	" \x01(\tR\x04note\x12G\n" +
+	"\x0fdelivery_window\x18\v \x01(\v2\x1e.onlineboutique.DeliveryWindowR\x0edeliveryWindow\x12\"\n" +
+	"\finstallments\x18\f \x01(\x05R\finstallments\x12:\n" +
+	"\rwallet_amount\x18\r \x01(\v2\x15.onlineboutique.MoneyR\fwalletAmount\x121\n" +
+	"\aconsent\x18\x0e \x01(\v2\x17.onlineboutique.ConsentR\aconsent\x12#\n" +
+	"\rreferral_code\x18\x0f \x01(\tR\freferralCode\"\x84\x01\n" +
+	"\aConsent\x12#\n" +
+	"\rterms_version\x18\x01 \x01(\tR\ftermsVersion\x12*\n" +
+	"\x11terms_accepted_at\x18\x02 \x01(\x03R\x0ftermsAcceptedAt\x12(\n" +
+	"\x10age_confirmed_at\x18\x03 \x01(\x03R\x0eageConfirmedAt\"t\n" +
 	"\x12PlaceOrderResponse\x121\n" +
-	"\x05order\x18\x01 \x01(\v2\x1b.onlineboutique.OrderResultR\x05order\"G\n" +
+	"\x05order\x18\x01 \x01(\v2\x1b.onlineboutique.OrderResultR\x05order\x12+\n" +
+	"\x05total\x18\x02 \x01(\v2\x15.onlineboutique.MoneyR\x05total\"\xd2\x02\n" +
+	"\fOrderPreview\x12/\n" +
+	"\x05items\x18\x01 \x03(\v2\x19.onlineboutique.OrderItemR\x05items\x12<\n" +
+	"\tbreakdown\x18\x02 \x01(\v2\x1e.onlineboutique.OrderBreakdownR\tbreakdown\x12B\n" +
+	"\x10shipping_address\x18\x03 \x01(\v2\x17.onlineboutique.AddressR\x0fshippingAddress\x12\x1b\n" +
+	"\tcart_hash\x18\x04 \x01(\tR\bcartHash\x12:\n" +
+	"\rwallet_amount\x18\x05 \x01(\v2\x15.onlineboutique.MoneyR\fwalletAmount\x126\n" +
+	"\vcard_amount\x18\x06 \x01(\v2\x15.onlineboutique.MoneyR\n" +
+	"cardAmount\"\x81\x01\n" +
 	"\tAdRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12!\n" +
-	"\fcontext_keys\x18\x02 \x03(\tR\vcontextKeys\"2\n" +
+	"\fcontext_keys\x18\x02 \x03(\tR\vcontextKeys\x128\n" +
+	"\n" +
+	"ad_context\x18\x03 \x01(\v2\x19.onlineboutique.AdContextR\tadContext\"\x93\x01\n" +
+	"\tAdContext\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x1e\n" +
+	"\n" +
+	"experiment\x18\x02 \x01(\tR\n" +
+	"experiment\x12+\n" +
+	"\x11recent_categories\x18\x03 \x03(\tR\x10recentCategories\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x04 \x01(\tR\tsessionId\"m\n" +
+	"\x0eAdClickRequest\x12!\n" +
+	"\fredirect_url\x18\x01 \x01(\tR\vredirectUrl\x128\n" +
+	"\n" +
+	"ad_context\x18\x02 \x01(\v2\x19.onlineboutique.AdContextR\tadContext\"\xa2\x01\n" +
+	"\aAdEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12!\n" +
+	"\fredirect_url\x18\x02 \x01(\tR\vredirectUrl\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\x12\x1e\n" +
+	"\n" +
+	"experiment\x18\x04 \x01(\tR\n" +
+	"experiment\x12!\n" +
+	"\ftimestamp_ms\x18\x05 \x01(\x03R\vtimestampMs\"2\n" +
 	"\n" +
 	"AdResponse\x12$\n" +
 	"\x03ads\x18\x01 \x03(\v2\x12.onlineboutique.AdR\x03ads\";\n" +
 	"\x02Ad\x12!\n" +
 	"\fredirect_url\x18\x01 \x01(\tR\vredirectUrl\x12\x12\n" +
-	"\x04text\x18\x02 \x01(\tR\x04text2\xdc\x01\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\")\n" +
+	"\x0fUserDataRequest\x12\x16\n" +
+	"\x06emails\x18\x01 \x03(\tR\x06emails\"|\n" +
+	"\bUserData\x128\n" +
+	"\arecords\x18\x01 \x03(\v2\x1e.onlineboutique.UserDataRecordR\arecords\x126\n" +
+	"\x06emails\x18\x02 \x01(\v2\x1e.onlineboutique.EmailAddressesR\x06emails\"N\n" +
+	"\x0eUserDataRecord\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\x12\x12\n" +
+	"\x04json\x18\x03 \x01(\tR\x04json\".\n" +
+	"\x0eEmailAddresses\x12\x1c\n" +
+	"\taddresses\x18\x01 \x03(\tR\taddresses\"=\n" +
+	"\x0fReferralVisited\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06tenant\x18\x02 \x01(\tR\x06tenant\"*\n" +
+	"\x14ReferralStatsRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"n\n" +
+	"\fReferralStat\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x16\n" +
+	"\x06visits\x18\x02 \x01(\x03R\x06visits\x12\x16\n" +
+	"\x06orders\x18\x03 \x01(\x03R\x06orders\x12\x1a\n" +
+	"\brefunded\x18\x04 \x01(\x03R\brefunded\"C\n" +
+	"\rReferralStats\x122\n" +
+	"\x05stats\x18\x01 \x03(\v2\x1c.onlineboutique.ReferralStatR\x05stats\"\xbf\x01\n" +
+	"\x13CreateTicketRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x19\n" +
+	"\border_id\x18\x06 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06locale\x18\a \x01(\tR\x06locale\"H\n" +
+	"\x10GetTicketRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tticket_id\x18\x02 \x01(\tR\bticketId\"\x9d\x02\n" +
+	"\x06Ticket\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x18\n" +
+	"\asubject\x18\x05 \x01(\tR\asubject\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\x19\n" +
+	"\border_id\x18\a \x01(\tR\aorderId\x12\x16\n" +
+	"\x06locale\x18\b \x01(\tR\x06locale\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12\"\n" +
+	"\facknowledged\x18\v \x01(\bR\facknowledged2\xdc\x01\n" +
 	"\vCartService\x12B\n" +
 	"\aAddItem\x12\x1e.onlineboutique.AddItemRequest\x1a\x15.onlineboutique.Empty\"\x00\x12A\n" +
 	"\aGetCart\x12\x1e.onlineboutique.GetCartRequest\x1a\x14.onlineboutique.Cart\"\x00\x12F\n" +
 	"\tEmptyCart\x12 .onlineboutique.EmptyCartRequest\x1a\x15.onlineboutique.Empty\"\x002\x89\x01\n" +
 	"\x15RecommendationService\x12p\n" +
-	"\x13ListRecommendations\x12*.onlineboutique.ListRecommendationsRequest\x1a+.onlineboutique.ListRecommendationsResponse\"\x002\x99\x02\n" +
+	"\x13ListRecommendations\x12*.onlineboutique.ListRecommendationsRequest\x1a+.onlineboutique.ListRecommendationsResponse\"\x002\xdf\f\n" +
 	"\x15ProductCatalogService\x12Q\n" +
 	"\fListProducts\x12\x19.onlineboutique.EmptyUser\x1a$.onlineboutique.ListProductsResponse\"\x00\x12J\n" +
 	"\n" +
-	"GetProduct\x12!.onlineboutique.GetProductRequest\x1a\x17.onlineboutique.Product\"\x00\x12a\n" +
-	"\x0eSearchProducts\x12%.onlineboutique.SearchProductsRequest\x1a&.onlineboutique.SearchProductsResponse\"\x002\xb6\x01\n" +
+	"GetProduct\x12!.onlineboutique.GetProductRequest\x1a\x17.onlineboutique.Product\"\x00\x12Y\n" +
+	"\vGetProducts\x12\".onlineboutique.GetProductsRequest\x1a$.onlineboutique.ListProductsResponse\"\x00\x12a\n" +
+	"\x0eSearchProducts\x12%.onlineboutique.SearchProductsRequest\x1a&.onlineboutique.SearchProductsResponse\"\x00\x12d\n" +
+	"\x0fSuggestProducts\x12&.onlineboutique.SuggestProductsRequest\x1a'.onlineboutique.SuggestProductsResponse\"\x00\x12a\n" +
+	"\x0eImportProducts\x12%.onlineboutique.ImportProductsRequest\x1a&.onlineboutique.ImportProductsResponse\"\x00\x12a\n" +
+	"\x0eExportProducts\x12%.onlineboutique.ExportProductsRequest\x1a&.onlineboutique.ExportProductsResponse\"\x00\x12[\n" +
+	"\fListVariants\x12#.onlineboutique.ListVariantsRequest\x1a$.onlineboutique.ListVariantsResponse\"\x00\x12Q\n" +
+	"\n" +
+	"GetVariant\x12!.onlineboutique.GetVariantRequest\x1a\x1e.onlineboutique.ProductVariant\"\x00\x12I\n" +
+	"\bGetStock\x12\x1f.onlineboutique.GetStockRequest\x1a\x1a.onlineboutique.StockLevel\"\x00\x12Y\n" +
+	"\x0eRestockVariant\x12%.onlineboutique.RestockVariantRequest\x1a\x1e.onlineboutique.ProductVariant\"\x00\x12Z\n" +
+	"\x13NotifyWhenAvailable\x12*.onlineboutique.NotifyWhenAvailableRequest\x1a\x15.onlineboutique.Empty\"\x00\x12W\n" +
+	"\fReserveStock\x12#.onlineboutique.ReserveStockRequest\x1a .onlineboutique.StockReservation\"\x00\x12[\n" +
+	"\x11CommitReservation\x12\".onlineboutique.ReservationRequest\x1a .onlineboutique.StockReservation\"\x00\x12\\\n" +
+	"\x12ReleaseReservation\x12\".onlineboutique.ReservationRequest\x1a .onlineboutique.StockReservation\"\x00\x12I\n" +
+	"\x10ListPricingRules\x12\x15.onlineboutique.Empty\x1a\x1c.onlineboutique.PricingRules\"\x00\x12O\n" +
+	"\x0fSetPricingRules\x12\x1c.onlineboutique.PricingRules\x1a\x1c.onlineboutique.PricingRules\"\x00\x12[\n" +
+	"\x10ListPriceChanges\x12'.onlineboutique.ListPriceChangesRequest\x1a\x1c.onlineboutique.PriceChanges\"\x002\x97\x04\n" +
 	"\x0fShippingService\x12O\n" +
 	"\bGetQuote\x12\x1f.onlineboutique.GetQuoteRequest\x1a .onlineboutique.GetQuoteResponse\"\x00\x12R\n" +
-	"\tShipOrder\x12 .onlineboutique.ShipOrderRequest\x1a!.onlineboutique.ShipOrderResponse\"\x002\xc7\x01\n" +
+	"\tShipOrder\x12 .onlineboutique.ShipOrderRequest\x1a!.onlineboutique.ShipOrderResponse\"\x00\x12M\n" +
+	"\vGetShipment\x12\".onlineboutique.GetShipmentRequest\x1a\x18.onlineboutique.Shipment\"\x00\x12S\n" +
+	"\x0eCancelShipment\x12%.onlineboutique.CancelShipmentRequest\x1a\x18.onlineboutique.Shipment\"\x00\x12W\n" +
+	"\rPlanShipments\x12$.onlineboutique.PlanShipmentsRequest\x1a\x1e.onlineboutique.ShipmentGroups\"\x00\x12b\n" +
+	"\x12GetDeliveryOptions\x12).onlineboutique.GetDeliveryOptionsRequest\x1a\x1f.onlineboutique.DeliveryOptions\"\x002v\n" +
+	"\x0eAddressService\x12d\n" +
+	"\x0fValidateAddress\x12&.onlineboutique.ValidateAddressRequest\x1a'.onlineboutique.ValidateAddressResponse\"\x002\x8d\x03\n" +
 	"\x0fCurrencyService\x12e\n" +
-	"\x16GetSupportedCurrencies\x12\x19.onlineboutique.EmptyUser\x1a..onlineboutique.GetSupportedCurrenciesResponse\"\x00\x12M\n" +
-	"\aConvert\x12).onlineboutique.CurrencyConversionRequest\x1a\x15.onlineboutique.Money\"\x002[\n" +
+	"\x16GetSupportedCurrencies\x12\x19.onlineboutique.EmptyUser\x1a..onlineboutique.GetSupportedCurrenciesResponse\"\x00\x12b\n" +
+	"\aConvert\x12).onlineboutique.CurrencyConversionRequest\x1a*.onlineboutique.CurrencyConversionResponse\"\x00\x12^\n" +
+	"\x0fGetExchangeRate\x12#.onlineboutique.ExchangeRateRequest\x1a$.onlineboutique.ExchangeRateResponse\"\x00\x12O\n" +
+	"\x06RateAt\x12\x1d.onlineboutique.RateAtRequest\x1a$.onlineboutique.ExchangeRateResponse\"\x002\xcd\x03\n" +
 	"\x0ePaymentService\x12I\n" +
-	"\x06Charge\x12\x1d.onlineboutique.ChargeRequest\x1a\x1e.onlineboutique.ChargeResponse\"\x002n\n" +
+	"\x06Charge\x12\x1d.onlineboutique.ChargeRequest\x1a\x1e.onlineboutique.ChargeResponse\"\x00\x12V\n" +
+	"\x0eGetTransaction\x12%.onlineboutique.GetTransactionRequest\x1a\x1b.onlineboutique.Transaction\"\x00\x12s\n" +
+	"\x16ListTransactionsByUser\x12-.onlineboutique.ListTransactionsByUserRequest\x1a(.onlineboutique.ListTransactionsResponse\"\x00\x12[\n" +
+	"\x10ListAuditEntries\x12'.onlineboutique.ListAuditEntriesRequest\x1a\x1c.onlineboutique.AuditEntries\"\x00\x12F\n" +
+	"\x06Refund\x12\x1d.onlineboutique.RefundRequest\x1a\x1b.onlineboutique.Transaction\"\x002\xbc\x03\n" +
+	"\rWalletService\x12V\n" +
+	"\n" +
+	"GetBalance\x12'.onlineboutique.GetWalletBalanceRequest\x1a\x1d.onlineboutique.WalletBalance\"\x00\x12X\n" +
+	"\x0eRedeemGiftCard\x12%.onlineboutique.RedeemGiftCardRequest\x1a\x1d.onlineboutique.WalletBalance\"\x00\x12L\n" +
+	"\x05Debit\x12\".onlineboutique.WalletDebitRequest\x1a\x1d.onlineboutique.WalletBalance\"\x00\x12N\n" +
+	"\x06Refund\x12#.onlineboutique.WalletRefundRequest\x1a\x1d.onlineboutique.WalletBalance\"\x00\x12[\n" +
+	"\x10ListAuditEntries\x12'.onlineboutique.ListAuditEntriesRequest\x1a\x1c.onlineboutique.AuditEntries\"\x002\xd0\x03\n" +
 	"\fEmailService\x12^\n" +
-	"\x15SendOrderConfirmation\x12,.onlineboutique.SendOrderConfirmationRequest\x1a\x15.onlineboutique.Empty\"\x002h\n" +
+	"\x15SendOrderConfirmation\x12,.onlineboutique.SendOrderConfirmationRequest\x1a\x15.onlineboutique.Empty\"\x00\x12U\n" +
+	"\n" +
+	"GetReceipt\x12!.onlineboutique.GetReceiptRequest\x1a\".onlineboutique.GetReceiptResponse\"\x00\x12U\n" +
+	"\fSendCampaign\x12#.onlineboutique.SendCampaignRequest\x1a\x1e.onlineboutique.CampaignResult\"\x00\x12J\n" +
+	"\vUnsubscribe\x12\".onlineboutique.UnsubscribeRequest\x1a\x15.onlineboutique.Empty\"\x00\x12f\n" +
+	"\x19SendTicketAcknowledgement\x120.onlineboutique.SendTicketAcknowledgementRequest\x1a\x15.onlineboutique.Empty\"\x002\x93\x02\n" +
 	"\x0fCheckoutService\x12U\n" +
 	"\n" +
-	"PlaceOrder\x12!.onlineboutique.PlaceOrderRequest\x1a\".onlineboutique.PlaceOrderResponse\"\x002N\n" +
+	"PlaceOrder\x12!.onlineboutique.PlaceOrderRequest\x1a\".onlineboutique.PlaceOrderResponse\"\x00\x12Q\n" +
+	"\fPreviewOrder\x12!.onlineboutique.PlaceOrderRequest\x1a\x1c.onlineboutique.OrderPreview\"\x00\x12V\n" +
+	"\x0eGetOrderStatus\x12%.onlineboutique.GetOrderStatusRequest\x1a\x1b.onlineboutique.OrderStatus\"\x002\x98\x01\n" +
 	"\tAdService\x12A\n" +
-	"\x06GetAds\x12\x19.onlineboutique.AdRequest\x1a\x1a.onlineboutique.AdResponse\"\x00B\x19Z\x17./protos/onlineboutiqueb\x06proto3"
+	"\x06GetAds\x12\x19.onlineboutique.AdRequest\x1a\x1a.onlineboutique.AdResponse\"\x00\x12H\n" +
+	"\rRecordAdClick\x12\x1e.onlineboutique.AdClickRequest\x1a\x15.onlineboutique.Empty\"\x002\xae\x01\n" +
+	"\x0ePrivacyService\x12M\n" +
+	"\x0eExportUserData\x12\x1f.onlineboutique.UserDataRequest\x1a\x18.onlineboutique.UserData\"\x00\x12M\n" +
+	"\x0eDeleteUserData\x12\x1f.onlineboutique.UserDataRequest\x1a\x18.onlineboutique.UserData\"\x002m\n" +
+	"\x10AnalyticsService\x12Y\n" +
+	"\x10GetReferralStats\x12$.onlineboutique.ReferralStatsRequest\x1a\x1d.onlineboutique.ReferralStats\"\x002\xa8\x01\n" +
+	"\x0eSupportService\x12M\n" +
+	"\fCreateTicket\x12#.onlineboutique.CreateTicketRequest\x1a\x16.onlineboutique.Ticket\"\x00\x12G\n" +
+	"\tGetTicket\x12 .onlineboutique.GetTicketRequest\x1a\x16.onlineboutique.Ticket\"\x00B\x19Z\x17./protos/onlineboutiqueb\x06proto3"
 
 var (
 	file_onlineboutique_proto_rawDescOnce sync.Once
@@ -1929,101 +9339,379 @@ func file_onlineboutique_proto_rawDescGZIP() []byte {
 	return file_onlineboutique_proto_rawDescData
 }
 
-var file_onlineboutique_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_onlineboutique_proto_msgTypes = make([]protoimpl.MessageInfo, 136)
 var file_onlineboutique_proto_goTypes = []any{
-	(*CartItem)(nil),                       // 0: onlineboutique.CartItem
-	(*AddItemRequest)(nil),                 // 1: onlineboutique.AddItemRequest
-	(*EmptyCartRequest)(nil),               // 2: onlineboutique.EmptyCartRequest
-	(*GetCartRequest)(nil),                 // 3: onlineboutique.GetCartRequest
-	(*Cart)(nil),                           // 4: onlineboutique.Cart
-	(*Empty)(nil),                          // 5: onlineboutique.Empty
-	(*EmptyUser)(nil),                      // 6: onlineboutique.EmptyUser
-	(*ListRecommendationsRequest)(nil),     // 7: onlineboutique.ListRecommendationsRequest
-	(*ListRecommendationsResponse)(nil),    // 8: onlineboutique.ListRecommendationsResponse
-	(*Product)(nil),                        // 9: onlineboutique.Product
-	(*ListProductsResponse)(nil),           // 10: onlineboutique.ListProductsResponse
-	(*GetProductRequest)(nil),              // 11: onlineboutique.GetProductRequest
-	(*SearchProductsRequest)(nil),          // 12: onlineboutique.SearchProductsRequest
-	(*SearchProductsResponse)(nil),         // 13: onlineboutique.SearchProductsResponse
-	(*GetQuoteRequest)(nil),                // 14: onlineboutique.GetQuoteRequest
-	(*GetQuoteResponse)(nil),               // 15: onlineboutique.GetQuoteResponse
-	(*ShipOrderRequest)(nil),               // 16: onlineboutique.ShipOrderRequest
-	(*ShipOrderResponse)(nil),              // 17: onlineboutique.ShipOrderResponse
-	(*Address)(nil),                        // 18: onlineboutique.Address
-	(*Money)(nil),                          // 19: onlineboutique.Money
-	(*GetSupportedCurrenciesResponse)(nil), // 20: onlineboutique.GetSupportedCurrenciesResponse
-	(*CurrencyConversionRequest)(nil),      // 21: onlineboutique.CurrencyConversionRequest
-	(*CreditCardInfo)(nil),                 // 22: onlineboutique.CreditCardInfo
-	(*ChargeRequest)(nil),                  // 23: onlineboutique.ChargeRequest
-	(*ChargeResponse)(nil),                 // 24: onlineboutique.ChargeResponse
-	(*OrderItem)(nil),                      // 25: onlineboutique.OrderItem
-	(*OrderResult)(nil),                    // 26: onlineboutique.OrderResult
-	(*SendOrderConfirmationRequest)(nil),   // 27: onlineboutique.SendOrderConfirmationRequest
-	(*PlaceOrderRequest)(nil),              // 28: onlineboutique.PlaceOrderRequest
-	(*PlaceOrderResponse)(nil),             // 29: onlineboutique.PlaceOrderResponse
-	(*AdRequest)(nil),                      // 30: onlineboutique.AdRequest
-	(*AdResponse)(nil),                     // 31: onlineboutique.AdResponse
-	(*Ad)(nil),                             // 32: onlineboutique.Ad
+	(*CartItem)(nil),                         // 0: onlineboutique.CartItem
+	(*AddItemRequest)(nil),                   // 1: onlineboutique.AddItemRequest
+	(*CartLimitExceeded)(nil),                // 2: onlineboutique.CartLimitExceeded
+	(*EmptyCartRequest)(nil),                 // 3: onlineboutique.EmptyCartRequest
+	(*GetCartRequest)(nil),                   // 4: onlineboutique.GetCartRequest
+	(*Cart)(nil),                             // 5: onlineboutique.Cart
+	(*CatalogUpdated)(nil),                   // 6: onlineboutique.CatalogUpdated
+	(*CurrencyUpdated)(nil),                  // 7: onlineboutique.CurrencyUpdated
+	(*CartAbandoned)(nil),                    // 8: onlineboutique.CartAbandoned
+	(*Empty)(nil),                            // 9: onlineboutique.Empty
+	(*EmptyUser)(nil),                        // 10: onlineboutique.EmptyUser
+	(*ListRecommendationsRequest)(nil),       // 11: onlineboutique.ListRecommendationsRequest
+	(*PageContext)(nil),                      // 12: onlineboutique.PageContext
+	(*ListRecommendationsResponse)(nil),      // 13: onlineboutique.ListRecommendationsResponse
+	(*Recommendation)(nil),                   // 14: onlineboutique.Recommendation
+	(*Product)(nil),                          // 15: onlineboutique.Product
+	(*ProductImage)(nil),                     // 16: onlineboutique.ProductImage
+	(*ListProductsResponse)(nil),             // 17: onlineboutique.ListProductsResponse
+	(*ProductVariant)(nil),                   // 18: onlineboutique.ProductVariant
+	(*ListVariantsRequest)(nil),              // 19: onlineboutique.ListVariantsRequest
+	(*ListVariantsResponse)(nil),             // 20: onlineboutique.ListVariantsResponse
+	(*GetVariantRequest)(nil),                // 21: onlineboutique.GetVariantRequest
+	(*GetStockRequest)(nil),                  // 22: onlineboutique.GetStockRequest
+	(*StockLevel)(nil),                       // 23: onlineboutique.StockLevel
+	(*RestockVariantRequest)(nil),            // 24: onlineboutique.RestockVariantRequest
+	(*NotifyWhenAvailableRequest)(nil),       // 25: onlineboutique.NotifyWhenAvailableRequest
+	(*PricingRule)(nil),                      // 26: onlineboutique.PricingRule
+	(*PricingRules)(nil),                     // 27: onlineboutique.PricingRules
+	(*PriceChange)(nil),                      // 28: onlineboutique.PriceChange
+	(*PriceChanges)(nil),                     // 29: onlineboutique.PriceChanges
+	(*ListPriceChangesRequest)(nil),          // 30: onlineboutique.ListPriceChangesRequest
+	(*ReserveStockRequest)(nil),              // 31: onlineboutique.ReserveStockRequest
+	(*ReservationRequest)(nil),               // 32: onlineboutique.ReservationRequest
+	(*StockReservation)(nil),                 // 33: onlineboutique.StockReservation
+	(*ProductRestocked)(nil),                 // 34: onlineboutique.ProductRestocked
+	(*GetProductRequest)(nil),                // 35: onlineboutique.GetProductRequest
+	(*GetProductsRequest)(nil),               // 36: onlineboutique.GetProductsRequest
+	(*SearchProductsRequest)(nil),            // 37: onlineboutique.SearchProductsRequest
+	(*SearchProductsResponse)(nil),           // 38: onlineboutique.SearchProductsResponse
+	(*SearchFacets)(nil),                     // 39: onlineboutique.SearchFacets
+	(*FacetCount)(nil),                       // 40: onlineboutique.FacetCount
+	(*SuggestProductsRequest)(nil),           // 41: onlineboutique.SuggestProductsRequest
+	(*SuggestProductsResponse)(nil),          // 42: onlineboutique.SuggestProductsResponse
+	(*ProductSuggestion)(nil),                // 43: onlineboutique.ProductSuggestion
+	(*ImportProductsRequest)(nil),            // 44: onlineboutique.ImportProductsRequest
+	(*ImportProblem)(nil),                    // 45: onlineboutique.ImportProblem
+	(*ImportProductsResponse)(nil),           // 46: onlineboutique.ImportProductsResponse
+	(*ExportProductsRequest)(nil),            // 47: onlineboutique.ExportProductsRequest
+	(*ExportProductsResponse)(nil),           // 48: onlineboutique.ExportProductsResponse
+	(*GetQuoteRequest)(nil),                  // 49: onlineboutique.GetQuoteRequest
+	(*GetQuoteResponse)(nil),                 // 50: onlineboutique.GetQuoteResponse
+	(*ShipOrderRequest)(nil),                 // 51: onlineboutique.ShipOrderRequest
+	(*PlanShipmentsRequest)(nil),             // 52: onlineboutique.PlanShipmentsRequest
+	(*ShipmentGroup)(nil),                    // 53: onlineboutique.ShipmentGroup
+	(*ShipmentGroups)(nil),                   // 54: onlineboutique.ShipmentGroups
+	(*GetDeliveryOptionsRequest)(nil),        // 55: onlineboutique.GetDeliveryOptionsRequest
+	(*DeliveryWindow)(nil),                   // 56: onlineboutique.DeliveryWindow
+	(*DeliveryOptions)(nil),                  // 57: onlineboutique.DeliveryOptions
+	(*ShipOrderResponse)(nil),                // 58: onlineboutique.ShipOrderResponse
+	(*Warehouse)(nil),                        // 59: onlineboutique.Warehouse
+	(*GetShipmentRequest)(nil),               // 60: onlineboutique.GetShipmentRequest
+	(*CancelShipmentRequest)(nil),            // 61: onlineboutique.CancelShipmentRequest
+	(*Shipment)(nil),                         // 62: onlineboutique.Shipment
+	(*ShipmentStatusChanged)(nil),            // 63: onlineboutique.ShipmentStatusChanged
+	(*Address)(nil),                          // 64: onlineboutique.Address
+	(*ValidateAddressRequest)(nil),           // 65: onlineboutique.ValidateAddressRequest
+	(*AddressProblem)(nil),                   // 66: onlineboutique.AddressProblem
+	(*ValidateAddressResponse)(nil),          // 67: onlineboutique.ValidateAddressResponse
+	(*Money)(nil),                            // 68: onlineboutique.Money
+	(*GetSupportedCurrenciesResponse)(nil),   // 69: onlineboutique.GetSupportedCurrenciesResponse
+	(*CurrencyConversionRequest)(nil),        // 70: onlineboutique.CurrencyConversionRequest
+	(*CurrencyConversionResponse)(nil),       // 71: onlineboutique.CurrencyConversionResponse
+	(*ExchangeRateRequest)(nil),              // 72: onlineboutique.ExchangeRateRequest
+	(*ExchangeRateResponse)(nil),             // 73: onlineboutique.ExchangeRateResponse
+	(*RateAtRequest)(nil),                    // 74: onlineboutique.RateAtRequest
+	(*CreditCardInfo)(nil),                   // 75: onlineboutique.CreditCardInfo
+	(*ChargeRequest)(nil),                    // 76: onlineboutique.ChargeRequest
+	(*ChargeResponse)(nil),                   // 77: onlineboutique.ChargeResponse
+	(*Installment)(nil),                      // 78: onlineboutique.Installment
+	(*InstallmentPlan)(nil),                  // 79: onlineboutique.InstallmentPlan
+	(*Transaction)(nil),                      // 80: onlineboutique.Transaction
+	(*PaymentStatusChanged)(nil),             // 81: onlineboutique.PaymentStatusChanged
+	(*GetTransactionRequest)(nil),            // 82: onlineboutique.GetTransactionRequest
+	(*RefundRequest)(nil),                    // 83: onlineboutique.RefundRequest
+	(*ListTransactionsByUserRequest)(nil),    // 84: onlineboutique.ListTransactionsByUserRequest
+	(*ListTransactionsResponse)(nil),         // 85: onlineboutique.ListTransactionsResponse
+	(*AuditEntry)(nil),                       // 86: onlineboutique.AuditEntry
+	(*ListAuditEntriesRequest)(nil),          // 87: onlineboutique.ListAuditEntriesRequest
+	(*AuditEntries)(nil),                     // 88: onlineboutique.AuditEntries
+	(*GetWalletBalanceRequest)(nil),          // 89: onlineboutique.GetWalletBalanceRequest
+	(*WalletBalance)(nil),                    // 90: onlineboutique.WalletBalance
+	(*RedeemGiftCardRequest)(nil),            // 91: onlineboutique.RedeemGiftCardRequest
+	(*WalletDebitRequest)(nil),               // 92: onlineboutique.WalletDebitRequest
+	(*WalletRefundRequest)(nil),              // 93: onlineboutique.WalletRefundRequest
+	(*SendTicketAcknowledgementRequest)(nil), // 94: onlineboutique.SendTicketAcknowledgementRequest
+	(*OrderItem)(nil),                        // 95: onlineboutique.OrderItem
+	(*OrderResult)(nil),                      // 96: onlineboutique.OrderResult
+	(*PostOrderStep)(nil),                    // 97: onlineboutique.PostOrderStep
+	(*PostOrderSteps)(nil),                   // 98: onlineboutique.PostOrderSteps
+	(*OrderBreakdown)(nil),                   // 99: onlineboutique.OrderBreakdown
+	(*PriceAdjustment)(nil),                  // 100: onlineboutique.PriceAdjustment
+	(*PriceAdjustments)(nil),                 // 101: onlineboutique.PriceAdjustments
+	(*PinnedRate)(nil),                       // 102: onlineboutique.PinnedRate
+	(*PinnedRates)(nil),                      // 103: onlineboutique.PinnedRates
+	(*AppliedConversion)(nil),                // 104: onlineboutique.AppliedConversion
+	(*SendOrderConfirmationRequest)(nil),     // 105: onlineboutique.SendOrderConfirmationRequest
+	(*CampaignSegment)(nil),                  // 106: onlineboutique.CampaignSegment
+	(*SendCampaignRequest)(nil),              // 107: onlineboutique.SendCampaignRequest
+	(*CampaignResult)(nil),                   // 108: onlineboutique.CampaignResult
+	(*UnsubscribeRequest)(nil),               // 109: onlineboutique.UnsubscribeRequest
+	(*GetReceiptRequest)(nil),                // 110: onlineboutique.GetReceiptRequest
+	(*GetReceiptResponse)(nil),               // 111: onlineboutique.GetReceiptResponse
+	(*GetOrderStatusRequest)(nil),            // 112: onlineboutique.GetOrderStatusRequest
+	(*OrderStatus)(nil),                      // 113: onlineboutique.OrderStatus
+	(*OrderStatusChanged)(nil),               // 114: onlineboutique.OrderStatusChanged
+	(*PlaceOrderRequest)(nil),                // 115: onlineboutique.PlaceOrderRequest
+	(*Consent)(nil),                          // 116: onlineboutique.Consent
+	(*PlaceOrderResponse)(nil),               // 117: onlineboutique.PlaceOrderResponse
+	(*OrderPreview)(nil),                     // 118: onlineboutique.OrderPreview
+	(*AdRequest)(nil),                        // 119: onlineboutique.AdRequest
+	(*AdContext)(nil),                        // 120: onlineboutique.AdContext
+	(*AdClickRequest)(nil),                   // 121: onlineboutique.AdClickRequest
+	(*AdEvent)(nil),                          // 122: onlineboutique.AdEvent
+	(*AdResponse)(nil),                       // 123: onlineboutique.AdResponse
+	(*Ad)(nil),                               // 124: onlineboutique.Ad
+	(*UserDataRequest)(nil),                  // 125: onlineboutique.UserDataRequest
+	(*UserData)(nil),                         // 126: onlineboutique.UserData
+	(*UserDataRecord)(nil),                   // 127: onlineboutique.UserDataRecord
+	(*EmailAddresses)(nil),                   // 128: onlineboutique.EmailAddresses
+	(*ReferralVisited)(nil),                  // 129: onlineboutique.ReferralVisited
+	(*ReferralStatsRequest)(nil),             // 130: onlineboutique.ReferralStatsRequest
+	(*ReferralStat)(nil),                     // 131: onlineboutique.ReferralStat
+	(*ReferralStats)(nil),                    // 132: onlineboutique.ReferralStats
+	(*CreateTicketRequest)(nil),              // 133: onlineboutique.CreateTicketRequest
+	(*GetTicketRequest)(nil),                 // 134: onlineboutique.GetTicketRequest
+	(*Ticket)(nil),                           // 135: onlineboutique.Ticket
 }
 var file_onlineboutique_proto_depIdxs = []int32{
-	0,  // 0: onlineboutique.AddItemRequest.item:type_name -> onlineboutique.CartItem
-	0,  // 1: onlineboutique.Cart.items:type_name -> onlineboutique.CartItem
-	19, // 2: onlineboutique.Product.price_usd:type_name -> onlineboutique.Money
-	9,  // 3: onlineboutique.ListProductsResponse.products:type_name -> onlineboutique.Product
-	9,  // 4: onlineboutique.SearchProductsResponse.results:type_name -> onlineboutique.Product
-	18, // 5: onlineboutique.GetQuoteRequest.address:type_name -> onlineboutique.Address
-	0,  // 6: onlineboutique.GetQuoteRequest.items:type_name -> onlineboutique.CartItem
-	19, // 7: onlineboutique.GetQuoteResponse.cost_usd:type_name -> onlineboutique.Money
-	18, // 8: onlineboutique.ShipOrderRequest.address:type_name -> onlineboutique.Address
-	0,  // 9: onlineboutique.ShipOrderRequest.items:type_name -> onlineboutique.CartItem
-	19, // 10: onlineboutique.CurrencyConversionRequest.from:type_name -> onlineboutique.Money
-	19, // 11: onlineboutique.ChargeRequest.amount:type_name -> onlineboutique.Money
-	22, // 12: onlineboutique.ChargeRequest.credit_card:type_name -> onlineboutique.CreditCardInfo
-	0,  // 13: onlineboutique.OrderItem.item:type_name -> onlineboutique.CartItem
-	19, // 14: onlineboutique.OrderItem.cost:type_name -> onlineboutique.Money
-	19, // 15: onlineboutique.OrderResult.shipping_cost:type_name -> onlineboutique.Money
-	18, // 16: onlineboutique.OrderResult.shipping_address:type_name -> onlineboutique.Address
-	25, // 17: onlineboutique.OrderResult.items:type_name -> onlineboutique.OrderItem
-	26, // 18: onlineboutique.SendOrderConfirmationRequest.order:type_name -> onlineboutique.OrderResult
-	18, // 19: onlineboutique.PlaceOrderRequest.address:type_name -> onlineboutique.Address
-	22, // 20: onlineboutique.PlaceOrderRequest.credit_card:type_name -> onlineboutique.CreditCardInfo
-	26, // 21: onlineboutique.PlaceOrderResponse.order:type_name -> onlineboutique.OrderResult
-	32, // 22: onlineboutique.AdResponse.ads:type_name -> onlineboutique.Ad
-	1,  // 23: onlineboutique.CartService.AddItem:input_type -> onlineboutique.AddItemRequest
-	3,  // 24: onlineboutique.CartService.GetCart:input_type -> onlineboutique.GetCartRequest
-	2,  // 25: onlineboutique.CartService.EmptyCart:input_type -> onlineboutique.EmptyCartRequest
-	7,  // 26: onlineboutique.RecommendationService.ListRecommendations:input_type -> onlineboutique.ListRecommendationsRequest
-	6,  // 27: onlineboutique.ProductCatalogService.ListProducts:input_type -> onlineboutique.EmptyUser
-	11, // 28: onlineboutique.ProductCatalogService.GetProduct:input_type -> onlineboutique.GetProductRequest
-	12, // 29: onlineboutique.ProductCatalogService.SearchProducts:input_type -> onlineboutique.SearchProductsRequest
-	14, // 30: onlineboutique.ShippingService.GetQuote:input_type -> onlineboutique.GetQuoteRequest
-	16, // 31: onlineboutique.ShippingService.ShipOrder:input_type -> onlineboutique.ShipOrderRequest
-	6,  // 32: onlineboutique.CurrencyService.GetSupportedCurrencies:input_type -> onlineboutique.EmptyUser
-	21, // 33: onlineboutique.CurrencyService.Convert:input_type -> onlineboutique.CurrencyConversionRequest
-	23, // 34: onlineboutique.PaymentService.Charge:input_type -> onlineboutique.ChargeRequest
-	27, // 35: onlineboutique.EmailService.SendOrderConfirmation:input_type -> onlineboutique.SendOrderConfirmationRequest
-	28, // 36: onlineboutique.CheckoutService.PlaceOrder:input_type -> onlineboutique.PlaceOrderRequest
-	30, // 37: onlineboutique.AdService.GetAds:input_type -> onlineboutique.AdRequest
-	5,  // 38: onlineboutique.CartService.AddItem:output_type -> onlineboutique.Empty
-	4,  // 39: onlineboutique.CartService.GetCart:output_type -> onlineboutique.Cart
-	5,  // 40: onlineboutique.CartService.EmptyCart:output_type -> onlineboutique.Empty
-	8,  // 41: onlineboutique.RecommendationService.ListRecommendations:output_type -> onlineboutique.ListRecommendationsResponse
-	10, // 42: onlineboutique.ProductCatalogService.ListProducts:output_type -> onlineboutique.ListProductsResponse
-	9,  // 43: onlineboutique.ProductCatalogService.GetProduct:output_type -> onlineboutique.Product
-	13, // 44: onlineboutique.ProductCatalogService.SearchProducts:output_type -> onlineboutique.SearchProductsResponse
-	15, // 45: onlineboutique.ShippingService.GetQuote:output_type -> onlineboutique.GetQuoteResponse
-	17, // 46: onlineboutique.ShippingService.ShipOrder:output_type -> onlineboutique.ShipOrderResponse
-	20, // 47: onlineboutique.CurrencyService.GetSupportedCurrencies:output_type -> onlineboutique.GetSupportedCurrenciesResponse
-	19, // 48: onlineboutique.CurrencyService.Convert:output_type -> onlineboutique.Money
-	24, // 49: onlineboutique.PaymentService.Charge:output_type -> onlineboutique.ChargeResponse
-	5,  // 50: onlineboutique.EmailService.SendOrderConfirmation:output_type -> onlineboutique.Empty
-	29, // 51: onlineboutique.CheckoutService.PlaceOrder:output_type -> onlineboutique.PlaceOrderResponse
-	31, // 52: onlineboutique.AdService.GetAds:output_type -> onlineboutique.AdResponse
-	38, // [38:53] is the sub-list for method output_type
-	23, // [23:38] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	0,   // 0: onlineboutique.AddItemRequest.item:type_name -> onlineboutique.CartItem
+	0,   // 1: onlineboutique.Cart.items:type_name -> onlineboutique.CartItem
+	5,   // 2: onlineboutique.CartAbandoned.cart:type_name -> onlineboutique.Cart
+	12,  // 3: onlineboutique.ListRecommendationsRequest.page_context:type_name -> onlineboutique.PageContext
+	14,  // 4: onlineboutique.ListRecommendationsResponse.recommendations:type_name -> onlineboutique.Recommendation
+	68,  // 5: onlineboutique.Product.price_usd:type_name -> onlineboutique.Money
+	16,  // 6: onlineboutique.Product.thumbnail:type_name -> onlineboutique.ProductImage
+	16,  // 7: onlineboutique.Product.medium:type_name -> onlineboutique.ProductImage
+	68,  // 8: onlineboutique.Product.sale_price_usd:type_name -> onlineboutique.Money
+	15,  // 9: onlineboutique.ListProductsResponse.products:type_name -> onlineboutique.Product
+	68,  // 10: onlineboutique.ProductVariant.price_delta_usd:type_name -> onlineboutique.Money
+	18,  // 11: onlineboutique.ListVariantsResponse.variants:type_name -> onlineboutique.ProductVariant
+	26,  // 12: onlineboutique.PricingRules.rules:type_name -> onlineboutique.PricingRule
+	68,  // 13: onlineboutique.PriceChange.sale_price_usd:type_name -> onlineboutique.Money
+	28,  // 14: onlineboutique.PriceChanges.changes:type_name -> onlineboutique.PriceChange
+	0,   // 15: onlineboutique.ReserveStockRequest.items:type_name -> onlineboutique.CartItem
+	0,   // 16: onlineboutique.StockReservation.items:type_name -> onlineboutique.CartItem
+	15,  // 17: onlineboutique.ProductRestocked.product:type_name -> onlineboutique.Product
+	18,  // 18: onlineboutique.ProductRestocked.variant:type_name -> onlineboutique.ProductVariant
+	15,  // 19: onlineboutique.SearchProductsResponse.results:type_name -> onlineboutique.Product
+	39,  // 20: onlineboutique.SearchProductsResponse.facets:type_name -> onlineboutique.SearchFacets
+	40,  // 21: onlineboutique.SearchFacets.counts:type_name -> onlineboutique.FacetCount
+	43,  // 22: onlineboutique.SuggestProductsResponse.suggestions:type_name -> onlineboutique.ProductSuggestion
+	15,  // 23: onlineboutique.ImportProductsRequest.products:type_name -> onlineboutique.Product
+	45,  // 24: onlineboutique.ImportProductsResponse.problems:type_name -> onlineboutique.ImportProblem
+	15,  // 25: onlineboutique.ExportProductsResponse.products:type_name -> onlineboutique.Product
+	64,  // 26: onlineboutique.GetQuoteRequest.address:type_name -> onlineboutique.Address
+	0,   // 27: onlineboutique.GetQuoteRequest.items:type_name -> onlineboutique.CartItem
+	68,  // 28: onlineboutique.GetQuoteRequest.subtotal:type_name -> onlineboutique.Money
+	68,  // 29: onlineboutique.GetQuoteResponse.cost_usd:type_name -> onlineboutique.Money
+	59,  // 30: onlineboutique.GetQuoteResponse.origin:type_name -> onlineboutique.Warehouse
+	68,  // 31: onlineboutique.GetQuoteResponse.free_shipping_remaining:type_name -> onlineboutique.Money
+	64,  // 32: onlineboutique.ShipOrderRequest.address:type_name -> onlineboutique.Address
+	0,   // 33: onlineboutique.ShipOrderRequest.items:type_name -> onlineboutique.CartItem
+	56,  // 34: onlineboutique.ShipOrderRequest.delivery_window:type_name -> onlineboutique.DeliveryWindow
+	64,  // 35: onlineboutique.PlanShipmentsRequest.address:type_name -> onlineboutique.Address
+	0,   // 36: onlineboutique.PlanShipmentsRequest.items:type_name -> onlineboutique.CartItem
+	0,   // 37: onlineboutique.ShipmentGroup.items:type_name -> onlineboutique.CartItem
+	53,  // 38: onlineboutique.ShipmentGroups.groups:type_name -> onlineboutique.ShipmentGroup
+	64,  // 39: onlineboutique.GetDeliveryOptionsRequest.address:type_name -> onlineboutique.Address
+	0,   // 40: onlineboutique.GetDeliveryOptionsRequest.items:type_name -> onlineboutique.CartItem
+	56,  // 41: onlineboutique.DeliveryOptions.windows:type_name -> onlineboutique.DeliveryWindow
+	59,  // 42: onlineboutique.ShipOrderResponse.origin:type_name -> onlineboutique.Warehouse
+	64,  // 43: onlineboutique.Warehouse.address:type_name -> onlineboutique.Address
+	59,  // 44: onlineboutique.Shipment.origin:type_name -> onlineboutique.Warehouse
+	62,  // 45: onlineboutique.ShipmentStatusChanged.shipment:type_name -> onlineboutique.Shipment
+	64,  // 46: onlineboutique.ValidateAddressRequest.address:type_name -> onlineboutique.Address
+	64,  // 47: onlineboutique.ValidateAddressResponse.normalized:type_name -> onlineboutique.Address
+	66,  // 48: onlineboutique.ValidateAddressResponse.problems:type_name -> onlineboutique.AddressProblem
+	68,  // 49: onlineboutique.CurrencyConversionRequest.from:type_name -> onlineboutique.Money
+	68,  // 50: onlineboutique.CurrencyConversionResponse.money:type_name -> onlineboutique.Money
+	68,  // 51: onlineboutique.ChargeRequest.amount:type_name -> onlineboutique.Money
+	75,  // 52: onlineboutique.ChargeRequest.credit_card:type_name -> onlineboutique.CreditCardInfo
+	79,  // 53: onlineboutique.ChargeResponse.installment_plan:type_name -> onlineboutique.InstallmentPlan
+	68,  // 54: onlineboutique.Installment.amount:type_name -> onlineboutique.Money
+	78,  // 55: onlineboutique.InstallmentPlan.installments:type_name -> onlineboutique.Installment
+	68,  // 56: onlineboutique.Transaction.amount:type_name -> onlineboutique.Money
+	79,  // 57: onlineboutique.Transaction.installment_plan:type_name -> onlineboutique.InstallmentPlan
+	80,  // 58: onlineboutique.PaymentStatusChanged.transaction:type_name -> onlineboutique.Transaction
+	80,  // 59: onlineboutique.ListTransactionsResponse.transactions:type_name -> onlineboutique.Transaction
+	86,  // 60: onlineboutique.AuditEntries.entries:type_name -> onlineboutique.AuditEntry
+	68,  // 61: onlineboutique.WalletBalance.balance:type_name -> onlineboutique.Money
+	68,  // 62: onlineboutique.WalletDebitRequest.amount:type_name -> onlineboutique.Money
+	135, // 63: onlineboutique.SendTicketAcknowledgementRequest.ticket:type_name -> onlineboutique.Ticket
+	0,   // 64: onlineboutique.OrderItem.item:type_name -> onlineboutique.CartItem
+	68,  // 65: onlineboutique.OrderItem.cost:type_name -> onlineboutique.Money
+	68,  // 66: onlineboutique.OrderResult.shipping_cost:type_name -> onlineboutique.Money
+	64,  // 67: onlineboutique.OrderResult.shipping_address:type_name -> onlineboutique.Address
+	95,  // 68: onlineboutique.OrderResult.items:type_name -> onlineboutique.OrderItem
+	99,  // 69: onlineboutique.OrderResult.breakdown:type_name -> onlineboutique.OrderBreakdown
+	54,  // 70: onlineboutique.OrderResult.shipments:type_name -> onlineboutique.ShipmentGroups
+	56,  // 71: onlineboutique.OrderResult.delivery_window:type_name -> onlineboutique.DeliveryWindow
+	79,  // 72: onlineboutique.OrderResult.installment_plan:type_name -> onlineboutique.InstallmentPlan
+	68,  // 73: onlineboutique.OrderResult.wallet_paid:type_name -> onlineboutique.Money
+	98,  // 74: onlineboutique.OrderResult.post_order_steps:type_name -> onlineboutique.PostOrderSteps
+	97,  // 75: onlineboutique.PostOrderSteps.steps:type_name -> onlineboutique.PostOrderStep
+	68,  // 76: onlineboutique.OrderBreakdown.items:type_name -> onlineboutique.Money
+	68,  // 77: onlineboutique.OrderBreakdown.shipping:type_name -> onlineboutique.Money
+	68,  // 78: onlineboutique.OrderBreakdown.tax:type_name -> onlineboutique.Money
+	68,  // 79: onlineboutique.OrderBreakdown.discount:type_name -> onlineboutique.Money
+	68,  // 80: onlineboutique.OrderBreakdown.total:type_name -> onlineboutique.Money
+	104, // 81: onlineboutique.OrderBreakdown.conversions:type_name -> onlineboutique.AppliedConversion
+	68,  // 82: onlineboutique.OrderBreakdown.gift_wrap:type_name -> onlineboutique.Money
+	103, // 83: onlineboutique.OrderBreakdown.pinned_rates:type_name -> onlineboutique.PinnedRates
+	101, // 84: onlineboutique.OrderBreakdown.adjustments:type_name -> onlineboutique.PriceAdjustments
+	68,  // 85: onlineboutique.PriceAdjustment.amount:type_name -> onlineboutique.Money
+	100, // 86: onlineboutique.PriceAdjustments.adjustments:type_name -> onlineboutique.PriceAdjustment
+	102, // 87: onlineboutique.PinnedRates.rates:type_name -> onlineboutique.PinnedRate
+	68,  // 88: onlineboutique.AppliedConversion.from:type_name -> onlineboutique.Money
+	68,  // 89: onlineboutique.AppliedConversion.to:type_name -> onlineboutique.Money
+	96,  // 90: onlineboutique.SendOrderConfirmationRequest.order:type_name -> onlineboutique.OrderResult
+	106, // 91: onlineboutique.SendCampaignRequest.segment:type_name -> onlineboutique.CampaignSegment
+	68,  // 92: onlineboutique.OrderStatus.refunded_amount:type_name -> onlineboutique.Money
+	113, // 93: onlineboutique.OrderStatusChanged.status:type_name -> onlineboutique.OrderStatus
+	64,  // 94: onlineboutique.PlaceOrderRequest.address:type_name -> onlineboutique.Address
+	75,  // 95: onlineboutique.PlaceOrderRequest.credit_card:type_name -> onlineboutique.CreditCardInfo
+	56,  // 96: onlineboutique.PlaceOrderRequest.delivery_window:type_name -> onlineboutique.DeliveryWindow
+	68,  // 97: onlineboutique.PlaceOrderRequest.wallet_amount:type_name -> onlineboutique.Money
+	116, // 98: onlineboutique.PlaceOrderRequest.consent:type_name -> onlineboutique.Consent
+	96,  // 99: onlineboutique.PlaceOrderResponse.order:type_name -> onlineboutique.OrderResult
+	68,  // 100: onlineboutique.PlaceOrderResponse.total:type_name -> onlineboutique.Money
+	95,  // 101: onlineboutique.OrderPreview.items:type_name -> onlineboutique.OrderItem
+	99,  // 102: onlineboutique.OrderPreview.breakdown:type_name -> onlineboutique.OrderBreakdown
+	64,  // 103: onlineboutique.OrderPreview.shipping_address:type_name -> onlineboutique.Address
+	68,  // 104: onlineboutique.OrderPreview.wallet_amount:type_name -> onlineboutique.Money
+	68,  // 105: onlineboutique.OrderPreview.card_amount:type_name -> onlineboutique.Money
+	120, // 106: onlineboutique.AdRequest.ad_context:type_name -> onlineboutique.AdContext
+	120, // 107: onlineboutique.AdClickRequest.ad_context:type_name -> onlineboutique.AdContext
+	124, // 108: onlineboutique.AdResponse.ads:type_name -> onlineboutique.Ad
+	127, // 109: onlineboutique.UserData.records:type_name -> onlineboutique.UserDataRecord
+	128, // 110: onlineboutique.UserData.emails:type_name -> onlineboutique.EmailAddresses
+	131, // 111: onlineboutique.ReferralStats.stats:type_name -> onlineboutique.ReferralStat
+	1,   // 112: onlineboutique.CartService.AddItem:input_type -> onlineboutique.AddItemRequest
+	4,   // 113: onlineboutique.CartService.GetCart:input_type -> onlineboutique.GetCartRequest
+	3,   // 114: onlineboutique.CartService.EmptyCart:input_type -> onlineboutique.EmptyCartRequest
+	11,  // 115: onlineboutique.RecommendationService.ListRecommendations:input_type -> onlineboutique.ListRecommendationsRequest
+	10,  // 116: onlineboutique.ProductCatalogService.ListProducts:input_type -> onlineboutique.EmptyUser
+	35,  // 117: onlineboutique.ProductCatalogService.GetProduct:input_type -> onlineboutique.GetProductRequest
+	36,  // 118: onlineboutique.ProductCatalogService.GetProducts:input_type -> onlineboutique.GetProductsRequest
+	37,  // 119: onlineboutique.ProductCatalogService.SearchProducts:input_type -> onlineboutique.SearchProductsRequest
+	41,  // 120: onlineboutique.ProductCatalogService.SuggestProducts:input_type -> onlineboutique.SuggestProductsRequest
+	44,  // 121: onlineboutique.ProductCatalogService.ImportProducts:input_type -> onlineboutique.ImportProductsRequest
+	47,  // 122: onlineboutique.ProductCatalogService.ExportProducts:input_type -> onlineboutique.ExportProductsRequest
+	19,  // 123: onlineboutique.ProductCatalogService.ListVariants:input_type -> onlineboutique.ListVariantsRequest
+	21,  // 124: onlineboutique.ProductCatalogService.GetVariant:input_type -> onlineboutique.GetVariantRequest
+	22,  // 125: onlineboutique.ProductCatalogService.GetStock:input_type -> onlineboutique.GetStockRequest
+	24,  // 126: onlineboutique.ProductCatalogService.RestockVariant:input_type -> onlineboutique.RestockVariantRequest
+	25,  // 127: onlineboutique.ProductCatalogService.NotifyWhenAvailable:input_type -> onlineboutique.NotifyWhenAvailableRequest
+	31,  // 128: onlineboutique.ProductCatalogService.ReserveStock:input_type -> onlineboutique.ReserveStockRequest
+	32,  // 129: onlineboutique.ProductCatalogService.CommitReservation:input_type -> onlineboutique.ReservationRequest
+	32,  // 130: onlineboutique.ProductCatalogService.ReleaseReservation:input_type -> onlineboutique.ReservationRequest
+	9,   // 131: onlineboutique.ProductCatalogService.ListPricingRules:input_type -> onlineboutique.Empty
+	27,  // 132: onlineboutique.ProductCatalogService.SetPricingRules:input_type -> onlineboutique.PricingRules
+	30,  // 133: onlineboutique.ProductCatalogService.ListPriceChanges:input_type -> onlineboutique.ListPriceChangesRequest
+	49,  // 134: onlineboutique.ShippingService.GetQuote:input_type -> onlineboutique.GetQuoteRequest
+	51,  // 135: onlineboutique.ShippingService.ShipOrder:input_type -> onlineboutique.ShipOrderRequest
+	60,  // 136: onlineboutique.ShippingService.GetShipment:input_type -> onlineboutique.GetShipmentRequest
+	61,  // 137: onlineboutique.ShippingService.CancelShipment:input_type -> onlineboutique.CancelShipmentRequest
+	52,  // 138: onlineboutique.ShippingService.PlanShipments:input_type -> onlineboutique.PlanShipmentsRequest
+	55,  // 139: onlineboutique.ShippingService.GetDeliveryOptions:input_type -> onlineboutique.GetDeliveryOptionsRequest
+	65,  // 140: onlineboutique.AddressService.ValidateAddress:input_type -> onlineboutique.ValidateAddressRequest
+	10,  // 141: onlineboutique.CurrencyService.GetSupportedCurrencies:input_type -> onlineboutique.EmptyUser
+	70,  // 142: onlineboutique.CurrencyService.Convert:input_type -> onlineboutique.CurrencyConversionRequest
+	72,  // 143: onlineboutique.CurrencyService.GetExchangeRate:input_type -> onlineboutique.ExchangeRateRequest
+	74,  // 144: onlineboutique.CurrencyService.RateAt:input_type -> onlineboutique.RateAtRequest
+	76,  // 145: onlineboutique.PaymentService.Charge:input_type -> onlineboutique.ChargeRequest
+	82,  // 146: onlineboutique.PaymentService.GetTransaction:input_type -> onlineboutique.GetTransactionRequest
+	84,  // 147: onlineboutique.PaymentService.ListTransactionsByUser:input_type -> onlineboutique.ListTransactionsByUserRequest
+	87,  // 148: onlineboutique.PaymentService.ListAuditEntries:input_type -> onlineboutique.ListAuditEntriesRequest
+	83,  // 149: onlineboutique.PaymentService.Refund:input_type -> onlineboutique.RefundRequest
+	89,  // 150: onlineboutique.WalletService.GetBalance:input_type -> onlineboutique.GetWalletBalanceRequest
+	91,  // 151: onlineboutique.WalletService.RedeemGiftCard:input_type -> onlineboutique.RedeemGiftCardRequest
+	92,  // 152: onlineboutique.WalletService.Debit:input_type -> onlineboutique.WalletDebitRequest
+	93,  // 153: onlineboutique.WalletService.Refund:input_type -> onlineboutique.WalletRefundRequest
+	87,  // 154: onlineboutique.WalletService.ListAuditEntries:input_type -> onlineboutique.ListAuditEntriesRequest
+	105, // 155: onlineboutique.EmailService.SendOrderConfirmation:input_type -> onlineboutique.SendOrderConfirmationRequest
+	110, // 156: onlineboutique.EmailService.GetReceipt:input_type -> onlineboutique.GetReceiptRequest
+	107, // 157: onlineboutique.EmailService.SendCampaign:input_type -> onlineboutique.SendCampaignRequest
+	109, // 158: onlineboutique.EmailService.Unsubscribe:input_type -> onlineboutique.UnsubscribeRequest
+	94,  // 159: onlineboutique.EmailService.SendTicketAcknowledgement:input_type -> onlineboutique.SendTicketAcknowledgementRequest
+	115, // 160: onlineboutique.CheckoutService.PlaceOrder:input_type -> onlineboutique.PlaceOrderRequest
+	115, // 161: onlineboutique.CheckoutService.PreviewOrder:input_type -> onlineboutique.PlaceOrderRequest
+	112, // 162: onlineboutique.CheckoutService.GetOrderStatus:input_type -> onlineboutique.GetOrderStatusRequest
+	119, // 163: onlineboutique.AdService.GetAds:input_type -> onlineboutique.AdRequest
+	121, // 164: onlineboutique.AdService.RecordAdClick:input_type -> onlineboutique.AdClickRequest
+	125, // 165: onlineboutique.PrivacyService.ExportUserData:input_type -> onlineboutique.UserDataRequest
+	125, // 166: onlineboutique.PrivacyService.DeleteUserData:input_type -> onlineboutique.UserDataRequest
+	130, // 167: onlineboutique.AnalyticsService.GetReferralStats:input_type -> onlineboutique.ReferralStatsRequest
+	133, // 168: onlineboutique.SupportService.CreateTicket:input_type -> onlineboutique.CreateTicketRequest
+	134, // 169: onlineboutique.SupportService.GetTicket:input_type -> onlineboutique.GetTicketRequest
+	9,   // 170: onlineboutique.CartService.AddItem:output_type -> onlineboutique.Empty
+	5,   // 171: onlineboutique.CartService.GetCart:output_type -> onlineboutique.Cart
+	9,   // 172: onlineboutique.CartService.EmptyCart:output_type -> onlineboutique.Empty
+	13,  // 173: onlineboutique.RecommendationService.ListRecommendations:output_type -> onlineboutique.ListRecommendationsResponse
+	17,  // 174: onlineboutique.ProductCatalogService.ListProducts:output_type -> onlineboutique.ListProductsResponse
+	15,  // 175: onlineboutique.ProductCatalogService.GetProduct:output_type -> onlineboutique.Product
+	17,  // 176: onlineboutique.ProductCatalogService.GetProducts:output_type -> onlineboutique.ListProductsResponse
+	38,  // 177: onlineboutique.ProductCatalogService.SearchProducts:output_type -> onlineboutique.SearchProductsResponse
+	42,  // 178: onlineboutique.ProductCatalogService.SuggestProducts:output_type -> onlineboutique.SuggestProductsResponse
+	46,  // 179: onlineboutique.ProductCatalogService.ImportProducts:output_type -> onlineboutique.ImportProductsResponse
+	48,  // 180: onlineboutique.ProductCatalogService.ExportProducts:output_type -> onlineboutique.ExportProductsResponse
+	20,  // 181: onlineboutique.ProductCatalogService.ListVariants:output_type -> onlineboutique.ListVariantsResponse
+	18,  // 182: onlineboutique.ProductCatalogService.GetVariant:output_type -> onlineboutique.ProductVariant
+	23,  // 183: onlineboutique.ProductCatalogService.GetStock:output_type -> onlineboutique.StockLevel
+	18,  // 184: onlineboutique.ProductCatalogService.RestockVariant:output_type -> onlineboutique.ProductVariant
+	9,   // 185: onlineboutique.ProductCatalogService.NotifyWhenAvailable:output_type -> onlineboutique.Empty
+	33,  // 186: onlineboutique.ProductCatalogService.ReserveStock:output_type -> onlineboutique.StockReservation
+	33,  // 187: onlineboutique.ProductCatalogService.CommitReservation:output_type -> onlineboutique.StockReservation
+	33,  // 188: onlineboutique.ProductCatalogService.ReleaseReservation:output_type -> onlineboutique.StockReservation
+	27,  // 189: onlineboutique.ProductCatalogService.ListPricingRules:output_type -> onlineboutique.PricingRules
+	27,  // 190: onlineboutique.ProductCatalogService.SetPricingRules:output_type -> onlineboutique.PricingRules
+	29,  // 191: onlineboutique.ProductCatalogService.ListPriceChanges:output_type -> onlineboutique.PriceChanges
+	50,  // 192: onlineboutique.ShippingService.GetQuote:output_type -> onlineboutique.GetQuoteResponse
+	58,  // 193: onlineboutique.ShippingService.ShipOrder:output_type -> onlineboutique.ShipOrderResponse
+	62,  // 194: onlineboutique.ShippingService.GetShipment:output_type -> onlineboutique.Shipment
+	62,  // 195: onlineboutique.ShippingService.CancelShipment:output_type -> onlineboutique.Shipment
+	54,  // 196: onlineboutique.ShippingService.PlanShipments:output_type -> onlineboutique.ShipmentGroups
+	57,  // 197: onlineboutique.ShippingService.GetDeliveryOptions:output_type -> onlineboutique.DeliveryOptions
+	67,  // 198: onlineboutique.AddressService.ValidateAddress:output_type -> onlineboutique.ValidateAddressResponse
+	69,  // 199: onlineboutique.CurrencyService.GetSupportedCurrencies:output_type -> onlineboutique.GetSupportedCurrenciesResponse
+	71,  // 200: onlineboutique.CurrencyService.Convert:output_type -> onlineboutique.CurrencyConversionResponse
+	73,  // 201: onlineboutique.CurrencyService.GetExchangeRate:output_type -> onlineboutique.ExchangeRateResponse
+	73,  // 202: onlineboutique.CurrencyService.RateAt:output_type -> onlineboutique.ExchangeRateResponse
+	77,  // 203: onlineboutique.PaymentService.Charge:output_type -> onlineboutique.ChargeResponse
+	80,  // 204: onlineboutique.PaymentService.GetTransaction:output_type -> onlineboutique.Transaction
+	85,  // 205: onlineboutique.PaymentService.ListTransactionsByUser:output_type -> onlineboutique.ListTransactionsResponse
+	88,  // 206: onlineboutique.PaymentService.ListAuditEntries:output_type -> onlineboutique.AuditEntries
+	80,  // 207: onlineboutique.PaymentService.Refund:output_type -> onlineboutique.Transaction
+	90,  // 208: onlineboutique.WalletService.GetBalance:output_type -> onlineboutique.WalletBalance
+	90,  // 209: onlineboutique.WalletService.RedeemGiftCard:output_type -> onlineboutique.WalletBalance
+	90,  // 210: onlineboutique.WalletService.Debit:output_type -> onlineboutique.WalletBalance
+	90,  // 211: onlineboutique.WalletService.Refund:output_type -> onlineboutique.WalletBalance
+	88,  // 212: onlineboutique.WalletService.ListAuditEntries:output_type -> onlineboutique.AuditEntries
+	9,   // 213: onlineboutique.EmailService.SendOrderConfirmation:output_type -> onlineboutique.Empty
+	111, // 214: onlineboutique.EmailService.GetReceipt:output_type -> onlineboutique.GetReceiptResponse
+	108, // 215: onlineboutique.EmailService.SendCampaign:output_type -> onlineboutique.CampaignResult
+	9,   // 216: onlineboutique.EmailService.Unsubscribe:output_type -> onlineboutique.Empty
+	9,   // 217: onlineboutique.EmailService.SendTicketAcknowledgement:output_type -> onlineboutique.Empty
+	117, // 218: onlineboutique.CheckoutService.PlaceOrder:output_type -> onlineboutique.PlaceOrderResponse
+	118, // 219: onlineboutique.CheckoutService.PreviewOrder:output_type -> onlineboutique.OrderPreview
+	113, // 220: onlineboutique.CheckoutService.GetOrderStatus:output_type -> onlineboutique.OrderStatus
+	123, // 221: onlineboutique.AdService.GetAds:output_type -> onlineboutique.AdResponse
+	9,   // 222: onlineboutique.AdService.RecordAdClick:output_type -> onlineboutique.Empty
+	126, // 223: onlineboutique.PrivacyService.ExportUserData:output_type -> onlineboutique.UserData
+	126, // 224: onlineboutique.PrivacyService.DeleteUserData:output_type -> onlineboutique.UserData
+	132, // 225: onlineboutique.AnalyticsService.GetReferralStats:output_type -> onlineboutique.ReferralStats
+	135, // 226: onlineboutique.SupportService.CreateTicket:output_type -> onlineboutique.Ticket
+	135, // 227: onlineboutique.SupportService.GetTicket:output_type -> onlineboutique.Ticket
+	170, // [170:228] is the sub-list for method output_type
+	112, // [112:170] is the sub-list for method input_type
+	112, // [112:112] is the sub-list for extension type_name
+	112, // [112:112] is the sub-list for extension extendee
+	0,   // [0:112] is the sub-list for field type_name
 }
 
 func init() { file_onlineboutique_proto_init() }
@@ -2037,9 +9725,9 @@ func file_onlineboutique_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_onlineboutique_proto_rawDesc), len(file_onlineboutique_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   33,
+			NumMessages:   136,
 			NumExtensions: 0,
-			NumServices:   9,
+			NumServices:   14,
 		},
 		GoTypes:           file_onlineboutique_proto_goTypes,
 		DependencyIndexes: file_onlineboutique_proto_depIdxs,
@@ -8,12 +8,12 @@ import (
 
 func (m *CartItem) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 55)
+	buf := make([]byte, 0, 102)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2}...)
+	buf = append(buf, []byte{1, 2, 3}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
@@ -28,6 +28,14 @@ func (m *CartItem) MarshalSymphony() ([]byte, error) {
 
 	offset += 4 // Quantity
 
+	// Field 3 (VariantId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of VariantId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.VariantId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.VariantId)
+
 	// === DATA REGION SECTION ===
 
 	// Write string or bytes field (ProductId)
@@ -37,29 +45,32 @@ func (m *CartItem) MarshalSymphony() ([]byte, error) {
 	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Quantity))
 	buf = append(buf, temp[:4]...)
 
+	// Write string or bytes field (VariantId)
+	buf = append(buf, []byte(m.VariantId)...)
+
 	return buf, nil
 }
 
 func (m *CartItem) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 3 {
+	if len(data) < 4 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+2]
-	offset += 2
+	fieldOrder := data[offset : offset+3]
+	offset += 3
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 10
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 2; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -88,6 +99,12 @@ func (m *CartItem) UnmarshalSymphony(data []byte) error {
 			}
 			m.Quantity = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
 			dataOffset += 4
+		case 3: // VariantId
+			// Unmarshal string or []byte field (VariantId)
+			if entry, ok := offsets[3]; ok {
+				m.VariantId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
 		}
 	}
 
@@ -208,6 +225,111 @@ func (m *AddItemRequest) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
+func (m *CartLimitExceeded) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 102)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Limit): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Limit
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Limit)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Limit)
+
+	offset += 4 // Max
+
+	// Field 3 (ProductId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Limit)
+	buf = append(buf, []byte(m.Limit)...)
+
+	// Write fixed field (Max)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Max))
+	buf = append(buf, temp[:4]...)
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	return buf, nil
+}
+
+func (m *CartLimitExceeded) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Limit
+			// Unmarshal string or []byte field (Limit)
+			if entry, ok := offsets[1]; ok {
+				m.Limit = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Max
+			// Unmarshal fixed field (Max)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Max = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 3: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[3]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (m *EmptyCartRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
 	buf := make([]byte, 0, 48)
@@ -496,67 +618,73 @@ func (m *Cart) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *Empty) MarshalSymphony() ([]byte, error) {
-	// Empty message - just return header
-	return []byte{0x00}, nil
-}
-
-func (m *Empty) UnmarshalSymphony(data []byte) error {
-	// Empty message - just validate header
-	if len(data) < 1 || data[0] != 0x00 {
-		return fmt.Errorf("invalid empty message data")
-	}
-	return nil
-}
-
-func (m *EmptyUser) MarshalSymphony() ([]byte, error) {
+func (m *CatalogUpdated) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 48)
+	buf := make([]byte, 0, 96)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
+	buf = append(buf, []byte{1, 2}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (UserId): string or bytes
+	// Field 1 (ProductIds): repeated variable-length
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductIds
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	totalLen := 0
+	for _, item := range m.ProductIds {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.UserId)
+	offset += totalLen
+
+	// Field 2 (Reason): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Reason
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Reason)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Reason)
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (UserId)
-	buf = append(buf, []byte(m.UserId)...)
+	// Write repeated variable-length field (ProductIds)
+	for _, item := range m.ProductIds {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	// Write string or bytes field (Reason)
+	buf = append(buf, []byte(m.Reason)...)
 
 	return buf, nil
 }
 
-func (m *EmptyUser) UnmarshalSymphony(data []byte) error {
+func (m *CatalogUpdated) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 2 {
+	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+1]
-	offset += 1
+	fieldOrder := data[offset : offset+2]
+	offset += 2
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 10
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 2; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -572,10 +700,35 @@ func (m *EmptyUser) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // UserId
-			// Unmarshal string or []byte field (UserId)
+		case 1: // ProductIds
+			// Unmarshal repeated variable-length field (ProductIds)
 			if entry, ok := offsets[1]; ok {
-				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.ProductIds = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.ProductIds = append(m.ProductIds, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.ProductIds = append(m.ProductIds, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Reason
+			// Unmarshal string or []byte field (Reason)
+			if entry, ok := offsets[2]; ok {
+				m.Reason = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -584,7 +737,7 @@ func (m *EmptyUser) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *ListRecommendationsRequest) MarshalSymphony() ([]byte, error) {
+func (m *CurrencyUpdated) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
 	buf := make([]byte, 0, 96)
 	var temp [8]byte // Reusable temp buffer for encoding
@@ -596,42 +749,42 @@ func (m *ListRecommendationsRequest) MarshalSymphony() ([]byte, error) {
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (UserId): string or bytes
+	// Field 1 (CurrencyCodes): repeated variable-length
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
-	buf = append(buf, temp[:2]...)
-	offset += len(m.UserId)
-
-	// Field 2 (ProductIds): repeated variable-length
-	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductIds
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CurrencyCodes
 	buf = append(buf, temp[:2]...)
 	totalLen := 0
-	for _, item := range m.ProductIds {
+	for _, item := range m.CurrencyCodes {
 		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
 	}
 	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
 	offset += totalLen
 
-	// === DATA REGION SECTION ===
+	// Field 2 (Reason): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Reason
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Reason)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Reason)
 
-	// Write string or bytes field (UserId)
-	buf = append(buf, []byte(m.UserId)...)
+	// === DATA REGION SECTION ===
 
-	// Write repeated variable-length field (ProductIds)
-	for _, item := range m.ProductIds {
+	// Write repeated variable-length field (CurrencyCodes)
+	for _, item := range m.CurrencyCodes {
 		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
 		buf = append(buf, temp[:4]...)
 		buf = append(buf, []byte(item)...)
 	}
 
+	// Write string or bytes field (Reason)
+	buf = append(buf, []byte(m.Reason)...)
+
 	return buf, nil
 }
 
-func (m *ListRecommendationsRequest) UnmarshalSymphony(data []byte) error {
+func (m *CurrencyUpdated) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
@@ -666,16 +819,10 @@ func (m *ListRecommendationsRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // UserId
-			// Unmarshal string or []byte field (UserId)
+		case 1: // CurrencyCodes
+			// Unmarshal repeated variable-length field (CurrencyCodes)
 			if entry, ok := offsets[1]; ok {
-				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 2: // ProductIds
-			// Unmarshal repeated variable-length field (ProductIds)
-			if entry, ok := offsets[2]; ok {
-				m.ProductIds = make([]string, 0)
+				m.CurrencyCodes = make([]string, 0)
 				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
 				fieldOffset := 0
 				for fieldOffset < len(fieldData) {
@@ -685,7 +832,7 @@ func (m *ListRecommendationsRequest) UnmarshalSymphony(data []byte) error {
 					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
 					fieldOffset += 4
 					if itemLen == 0 {
-						m.ProductIds = append(m.ProductIds, "")
+						m.CurrencyCodes = append(m.CurrencyCodes, "")
 						continue
 					}
 					if fieldOffset+int(itemLen) > len(fieldData) {
@@ -693,53 +840,225 @@ func (m *ListRecommendationsRequest) UnmarshalSymphony(data []byte) error {
 					}
 					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
 					fieldOffset += int(itemLen)
-					m.ProductIds = append(m.ProductIds, string(itemData))
+					m.CurrencyCodes = append(m.CurrencyCodes, string(itemData))
 				}
 				dataOffset += int(entry.length)
 			}
+		case 2: // Reason
+			// Unmarshal string or []byte field (Reason)
+			if entry, ok := offsets[2]; ok {
+				m.Reason = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
 		}
 	}
 
 	return nil
 }
 
-func (m *ListRecommendationsResponse) MarshalSymphony() ([]byte, error) {
+func (m *CartAbandoned) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 48)
+	buf := make([]byte, 0, 242)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Cart): singular message
+	if m.Cart != nil {
+		cachedSingularMessages[1], err = m.Cart.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Cart: %w", err)
+		}
+	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (ProductIds): repeated variable-length
+	// Field 1 (Cart): nested message
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductIds
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range m.ProductIds {
-		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(cachedSingularMessages[1])
+
+	offset += 8 // UpdatedAt
+
+	// Field 3 (Email): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 4 (Locale): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// Field 5 (Tenant): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Tenant
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Tenant)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Tenant)
 
 	// === DATA REGION SECTION ===
 
-	// Write repeated variable-length field (ProductIds)
-	for _, item := range m.ProductIds {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, []byte(item)...)
+	// Write nested message field (Cart)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write fixed field (UpdatedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.UpdatedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write string or bytes field (Tenant)
+	buf = append(buf, []byte(m.Tenant)...)
+
+	return buf, nil
+}
+
+func (m *CartAbandoned) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Cart
+			// Unmarshal nested message field (Cart)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Cart = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Cart == nil {
+						m.Cart = &Cart{}
+					}
+					if err := m.Cart.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // UpdatedAt
+			// Unmarshal fixed field (UpdatedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.UpdatedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 3: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[3]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[4]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // Tenant
+			// Unmarshal string or []byte field (Tenant)
+			if entry, ok := offsets[5]; ok {
+				m.Tenant = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Empty) MarshalSymphony() ([]byte, error) {
+	// Empty message - just return header
+	return []byte{0x00}, nil
+}
+
+func (m *Empty) UnmarshalSymphony(data []byte) error {
+	// Empty message - just validate header
+	if len(data) < 1 || data[0] != 0x00 {
+		return fmt.Errorf("invalid empty message data")
 	}
+	return nil
+}
+
+func (m *EmptyUser) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (UserId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
 
 	return buf, nil
 }
 
-func (m *ListRecommendationsResponse) UnmarshalSymphony(data []byte) error {
+func (m *EmptyUser) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
@@ -774,29 +1093,10 @@ func (m *ListRecommendationsResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // ProductIds
-			// Unmarshal repeated variable-length field (ProductIds)
+		case 1: // UserId
+			// Unmarshal string or []byte field (UserId)
 			if entry, ok := offsets[1]; ok {
-				m.ProductIds = make([]string, 0)
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.ProductIds = append(m.ProductIds, "")
-						continue
-					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item data")
-					}
-					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					m.ProductIds = append(m.ProductIds, string(itemData))
-				}
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -805,129 +1105,13534 @@ func (m *ListRecommendationsResponse) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *Product) MarshalSymphony() ([]byte, error) {
+func (m *ListRecommendationsRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 326)
+	buf := make([]byte, 0, 183)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2, 3, 4, 5, 6}...)
+	buf = append(buf, []byte{1, 2, 3}...)
 
 	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
 
 	var err error
 	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 5 (PriceUsd): singular message
-	if m.PriceUsd != nil {
-		cachedSingularMessages[5], err = m.PriceUsd.MarshalSymphony()
+	// Cache field 3 (PageContext): singular message
+	if m.PageContext != nil {
+		cachedSingularMessages[3], err = m.PageContext.MarshalSymphony()
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field PriceUsd: %w", err)
+			return nil, fmt.Errorf("failed to marshal singular message field PageContext: %w", err)
 		}
 	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Id): string or bytes
+	// Field 1 (UserId): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Id)
+	offset += len(m.UserId)
 
-	// Field 2 (Name): string or bytes
+	// Field 2 (ProductIds): repeated variable-length
 	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Name
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductIds
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Name)))
+	totalLen := 0
+	for _, item := range m.ProductIds {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Name)
+	offset += totalLen
 
-	// Field 3 (Description): string or bytes
+	// Field 3 (PageContext): nested message
 	buf = append(buf, byte(3))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Description
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Description)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Description)
+	offset += len(cachedSingularMessages[3])
 
-	// Field 4 (Picture): string or bytes
-	buf = append(buf, byte(4))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Picture
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Picture)))
-	buf = append(buf, temp[:2]...)
-	offset += len(m.Picture)
+	// === DATA REGION SECTION ===
 
-	// Field 5 (PriceUsd): nested message
-	buf = append(buf, byte(5))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[5])))
-	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[5])
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write repeated variable-length field (ProductIds)
+	for _, item := range m.ProductIds {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	// Write nested message field (PageContext)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	return buf, nil
+}
+
+func (m *ListRecommendationsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[1]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ProductIds
+			// Unmarshal repeated variable-length field (ProductIds)
+			if entry, ok := offsets[2]; ok {
+				m.ProductIds = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.ProductIds = append(m.ProductIds, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.ProductIds = append(m.ProductIds, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // PageContext
+			// Unmarshal nested message field (PageContext)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.PageContext = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.PageContext == nil {
+						m.PageContext = &PageContext{}
+					}
+					if err := m.PageContext.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PageContext) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Categories): repeated variable-length
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Categories
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.Categories {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write repeated variable-length field (Categories)
+	for _, item := range m.Categories {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	return buf, nil
+}
+
+func (m *PageContext) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Categories
+			// Unmarshal repeated variable-length field (Categories)
+			if entry, ok := offsets[1]; ok {
+				m.Categories = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Categories = append(m.Categories, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.Categories = append(m.Categories, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ListRecommendationsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Recommendations): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Recommendations))
+	for i, item := range m.Recommendations {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Recommendations[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Recommendations): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Recommendations)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ListRecommendationsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Recommendations
+			// Unmarshal nested message field (Recommendations)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Recommendations = make([]*Recommendation, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Recommendations = append(m.Recommendations, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Recommendation{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Recommendations = append(m.Recommendations, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Recommendation) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 143)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ProductId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// Field 2 (Reason): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Reason
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Reason)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Reason)
+
+	// Field 3 (ReasonDetail): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ReasonDetail
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ReasonDetail)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ReasonDetail)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write string or bytes field (Reason)
+	buf = append(buf, []byte(m.Reason)...)
+
+	// Write string or bytes field (ReasonDetail)
+	buf = append(buf, []byte(m.ReasonDetail)...)
+
+	return buf, nil
+}
+
+func (m *Recommendation) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[1]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Reason
+			// Unmarshal string or []byte field (Reason)
+			if entry, ok := offsets[2]; ok {
+				m.Reason = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // ReasonDetail
+			// Unmarshal string or []byte field (ReasonDetail)
+			if entry, ok := offsets[3]; ok {
+				m.ReasonDetail = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Product) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 611)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 5 (PriceUsd): singular message
+	if m.PriceUsd != nil {
+		cachedSingularMessages[5], err = m.PriceUsd.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field PriceUsd: %w", err)
+		}
+	}
+
+	// Cache field 7 (Thumbnail): singular message
+	if m.Thumbnail != nil {
+		cachedSingularMessages[7], err = m.Thumbnail.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Thumbnail: %w", err)
+		}
+	}
+
+	// Cache field 8 (Medium): singular message
+	if m.Medium != nil {
+		cachedSingularMessages[8], err = m.Medium.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Medium: %w", err)
+		}
+	}
+
+	// Cache field 9 (SalePriceUsd): singular message
+	if m.SalePriceUsd != nil {
+		cachedSingularMessages[9], err = m.SalePriceUsd.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field SalePriceUsd: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Id): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Id)
+
+	// Field 2 (Name): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Name
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Name)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Name)
+
+	// Field 3 (Description): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Description
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Description)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Description)
+
+	// Field 4 (Picture): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Picture
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Picture)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Picture)
+
+	// Field 5 (PriceUsd): nested message
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[5])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[5])
+
+	// Field 6 (Categories): repeated variable-length
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Categories
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.Categories {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 7 (Thumbnail): nested message
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[7])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[7])
+
+	// Field 8 (Medium): nested message
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[8])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[8])
+
+	// Field 9 (SalePriceUsd): nested message
+	buf = append(buf, byte(9))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[9])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[9])
+
+	offset += 8 // SaleStart
+
+	offset += 8 // SaleEnd
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
+
+	// Write string or bytes field (Name)
+	buf = append(buf, []byte(m.Name)...)
+
+	// Write string or bytes field (Description)
+	buf = append(buf, []byte(m.Description)...)
+
+	// Write string or bytes field (Picture)
+	buf = append(buf, []byte(m.Picture)...)
+
+	// Write nested message field (PriceUsd)
+	buf = append(buf, cachedSingularMessages[5]...)
+
+	// Write repeated variable-length field (Categories)
+	for _, item := range m.Categories {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	// Write nested message field (Thumbnail)
+	buf = append(buf, cachedSingularMessages[7]...)
+
+	// Write nested message field (Medium)
+	buf = append(buf, cachedSingularMessages[8]...)
+
+	// Write nested message field (SalePriceUsd)
+	buf = append(buf, cachedSingularMessages[9]...)
+
+	// Write fixed field (SaleStart)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.SaleStart))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (SaleEnd)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.SaleEnd))
+	buf = append(buf, temp[:8]...)
+
+	return buf, nil
+}
+
+func (m *Product) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 12 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+11]
+	offset += 11
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 45
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 9; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
+			if entry, ok := offsets[1]; ok {
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Name
+			// Unmarshal string or []byte field (Name)
+			if entry, ok := offsets[2]; ok {
+				m.Name = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Description
+			// Unmarshal string or []byte field (Description)
+			if entry, ok := offsets[3]; ok {
+				m.Description = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Picture
+			// Unmarshal string or []byte field (Picture)
+			if entry, ok := offsets[4]; ok {
+				m.Picture = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // PriceUsd
+			// Unmarshal nested message field (PriceUsd)
+			if entry, ok := offsets[5]; ok {
+				if entry.length == 0 {
+					m.PriceUsd = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.PriceUsd == nil {
+						m.PriceUsd = &Money{}
+					}
+					if err := m.PriceUsd.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 6: // Categories
+			// Unmarshal repeated variable-length field (Categories)
+			if entry, ok := offsets[6]; ok {
+				m.Categories = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Categories = append(m.Categories, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.Categories = append(m.Categories, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		case 7: // Thumbnail
+			// Unmarshal nested message field (Thumbnail)
+			if entry, ok := offsets[7]; ok {
+				if entry.length == 0 {
+					m.Thumbnail = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Thumbnail == nil {
+						m.Thumbnail = &ProductImage{}
+					}
+					if err := m.Thumbnail.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 8: // Medium
+			// Unmarshal nested message field (Medium)
+			if entry, ok := offsets[8]; ok {
+				if entry.length == 0 {
+					m.Medium = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Medium == nil {
+						m.Medium = &ProductImage{}
+					}
+					if err := m.Medium.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 9: // SalePriceUsd
+			// Unmarshal nested message field (SalePriceUsd)
+			if entry, ok := offsets[9]; ok {
+				if entry.length == 0 {
+					m.SalePriceUsd = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.SalePriceUsd == nil {
+						m.SalePriceUsd = &Money{}
+					}
+					if err := m.SalePriceUsd.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 10: // SaleStart
+			// Unmarshal fixed field (SaleStart)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.SaleStart = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 11: // SaleEnd
+			// Unmarshal fixed field (SaleEnd)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.SaleEnd = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		}
+	}
+
+	return nil
+}
+
+func (m *ProductImage) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 55)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Url): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Url
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Url)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Url)
+
+	offset += 4 // Width
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Url)
+	buf = append(buf, []byte(m.Url)...)
+
+	// Write fixed field (Width)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Width))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *ProductImage) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Url
+			// Unmarshal string or []byte field (Url)
+			if entry, ok := offsets[1]; ok {
+				m.Url = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Width
+			// Unmarshal fixed field (Width)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Width = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *ListProductsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Products): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Products))
+	for i, item := range m.Products {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Products[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Products): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Products)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ListProductsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Products
+			// Unmarshal nested message field (Products)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Products = make([]*Product, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Products = append(m.Products, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Product{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Products = append(m.Products, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ProductVariant) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 237)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 5 (PriceDeltaUsd): singular message
+	if m.PriceDeltaUsd != nil {
+		cachedSingularMessages[5], err = m.PriceDeltaUsd.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field PriceDeltaUsd: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Id): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Id)
+
+	// Field 2 (ProductId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// Field 3 (Attributes): repeated variable-length
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Attributes
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.Attributes {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	offset += 4 // Stock
+
+	// Field 5 (PriceDeltaUsd): nested message
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[5])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[5])
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write repeated variable-length field (Attributes)
+	for _, item := range m.Attributes {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	// Write fixed field (Stock)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Stock))
+	buf = append(buf, temp[:4]...)
+
+	// Write nested message field (PriceDeltaUsd)
+	buf = append(buf, cachedSingularMessages[5]...)
+
+	return buf, nil
+}
+
+func (m *ProductVariant) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
+			if entry, ok := offsets[1]; ok {
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[2]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Attributes
+			// Unmarshal repeated variable-length field (Attributes)
+			if entry, ok := offsets[3]; ok {
+				m.Attributes = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Attributes = append(m.Attributes, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.Attributes = append(m.Attributes, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // Stock
+			// Unmarshal fixed field (Stock)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Stock = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 5: // PriceDeltaUsd
+			// Unmarshal nested message field (PriceDeltaUsd)
+			if entry, ok := offsets[5]; ok {
+				if entry.length == 0 {
+					m.PriceDeltaUsd = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.PriceDeltaUsd == nil {
+						m.PriceDeltaUsd = &Money{}
+					}
+					if err := m.PriceDeltaUsd.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ListVariantsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ProductId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	return buf, nil
+}
+
+func (m *ListVariantsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[1]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ListVariantsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Variants): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Variants))
+	for i, item := range m.Variants {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Variants[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Variants): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Variants)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ListVariantsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Variants
+			// Unmarshal nested message field (Variants)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Variants = make([]*ProductVariant, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Variants = append(m.Variants, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &ProductVariant{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Variants = append(m.Variants, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetVariantRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 96)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ProductId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// Field 2 (VariantId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of VariantId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.VariantId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.VariantId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write string or bytes field (VariantId)
+	buf = append(buf, []byte(m.VariantId)...)
+
+	return buf, nil
+}
+
+func (m *GetVariantRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[1]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // VariantId
+			// Unmarshal string or []byte field (VariantId)
+			if entry, ok := offsets[2]; ok {
+				m.VariantId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetStockRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ProductId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	return buf, nil
+}
+
+func (m *GetStockRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[1]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *StockLevel) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 57)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ProductId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	offset += 1 // Tracked
+
+	offset += 4 // Quantity
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write fixed field (Tracked)
+	if m.Tracked {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	// Write fixed field (Quantity)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Quantity))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *StockLevel) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[1]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Tracked
+			// Unmarshal fixed field (Tracked)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Tracked = dataRegion[dataOffset] != 0
+			dataOffset += 1
+		case 3: // Quantity
+			// Unmarshal fixed field (Quantity)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Quantity = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *RestockVariantRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 102)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ProductId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// Field 2 (VariantId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of VariantId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.VariantId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.VariantId)
+
+	offset += 4 // Quantity
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write string or bytes field (VariantId)
+	buf = append(buf, []byte(m.VariantId)...)
+
+	// Write fixed field (Quantity)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Quantity))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *RestockVariantRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[1]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // VariantId
+			// Unmarshal string or []byte field (VariantId)
+			if entry, ok := offsets[2]; ok {
+				m.VariantId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Quantity
+			// Unmarshal fixed field (Quantity)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Quantity = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *NotifyWhenAvailableRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 191)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Email): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 2 (ProductId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// Field 3 (VariantId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of VariantId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.VariantId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.VariantId)
+
+	// Field 4 (Locale): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write string or bytes field (VariantId)
+	buf = append(buf, []byte(m.VariantId)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	return buf, nil
+}
+
+func (m *NotifyWhenAvailableRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[1]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[2]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // VariantId
+			// Unmarshal string or []byte field (VariantId)
+			if entry, ok := offsets[3]; ok {
+				m.VariantId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[4]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PricingRule) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 273)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Id): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Id)
+
+	// Field 2 (Kind): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Kind
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Kind)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Kind)
+
+	// Field 3 (Description): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Description
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Description)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Description)
+
+	// Field 4 (ProductIds): repeated variable-length
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductIds
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.ProductIds {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 5 (Category): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Category
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Category)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Category)
+
+	offset += 4 // MinQuantity
+
+	offset += 4 // PercentOff
+
+	offset += 8 // Start
+
+	offset += 8 // End
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
+
+	// Write string or bytes field (Kind)
+	buf = append(buf, []byte(m.Kind)...)
+
+	// Write string or bytes field (Description)
+	buf = append(buf, []byte(m.Description)...)
+
+	// Write repeated variable-length field (ProductIds)
+	for _, item := range m.ProductIds {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	// Write string or bytes field (Category)
+	buf = append(buf, []byte(m.Category)...)
+
+	// Write fixed field (MinQuantity)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.MinQuantity))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (PercentOff)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.PercentOff))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (Start)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Start))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (End)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.End))
+	buf = append(buf, temp[:8]...)
+
+	return buf, nil
+}
+
+func (m *PricingRule) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 10 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+9]
+	offset += 9
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 25
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 5; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
+			if entry, ok := offsets[1]; ok {
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Kind
+			// Unmarshal string or []byte field (Kind)
+			if entry, ok := offsets[2]; ok {
+				m.Kind = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Description
+			// Unmarshal string or []byte field (Description)
+			if entry, ok := offsets[3]; ok {
+				m.Description = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // ProductIds
+			// Unmarshal repeated variable-length field (ProductIds)
+			if entry, ok := offsets[4]; ok {
+				m.ProductIds = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.ProductIds = append(m.ProductIds, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.ProductIds = append(m.ProductIds, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		case 5: // Category
+			// Unmarshal string or []byte field (Category)
+			if entry, ok := offsets[5]; ok {
+				m.Category = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // MinQuantity
+			// Unmarshal fixed field (MinQuantity)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.MinQuantity = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 7: // PercentOff
+			// Unmarshal fixed field (PercentOff)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.PercentOff = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 8: // Start
+			// Unmarshal fixed field (Start)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Start = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 9: // End
+			// Unmarshal fixed field (End)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.End = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		}
+	}
+
+	return nil
+}
+
+func (m *PricingRules) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Rules): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Rules))
+	for i, item := range m.Rules {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Rules[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Rules): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Rules)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *PricingRules) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Rules
+			// Unmarshal nested message field (Rules)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Rules = make([]*PricingRule, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Rules = append(m.Rules, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &PricingRule{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Rules = append(m.Rules, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PriceChange) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 307)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 4 (SalePriceUsd): singular message
+	if m.SalePriceUsd != nil {
+		cachedSingularMessages[4], err = m.SalePriceUsd.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field SalePriceUsd: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Id): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Id)
+
+	// Field 2 (Description): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Description
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Description)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Description)
+
+	// Field 3 (ProductIds): repeated variable-length
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductIds
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.ProductIds {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 4 (SalePriceUsd): nested message
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[4])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[4])
+
+	offset += 4 // PercentOff
+
+	offset += 8 // Start
+
+	offset += 8 // End
+
+	// Field 8 (Status): string or bytes
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
+
+	// Write string or bytes field (Description)
+	buf = append(buf, []byte(m.Description)...)
+
+	// Write repeated variable-length field (ProductIds)
+	for _, item := range m.ProductIds {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	// Write nested message field (SalePriceUsd)
+	buf = append(buf, cachedSingularMessages[4]...)
+
+	// Write fixed field (PercentOff)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.PercentOff))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (Start)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Start))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (End)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.End))
+	buf = append(buf, temp[:8]...)
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	return buf, nil
+}
+
+func (m *PriceChange) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 9 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+8]
+	offset += 8
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 25
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 5; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
+			if entry, ok := offsets[1]; ok {
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Description
+			// Unmarshal string or []byte field (Description)
+			if entry, ok := offsets[2]; ok {
+				m.Description = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // ProductIds
+			// Unmarshal repeated variable-length field (ProductIds)
+			if entry, ok := offsets[3]; ok {
+				m.ProductIds = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.ProductIds = append(m.ProductIds, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.ProductIds = append(m.ProductIds, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // SalePriceUsd
+			// Unmarshal nested message field (SalePriceUsd)
+			if entry, ok := offsets[4]; ok {
+				if entry.length == 0 {
+					m.SalePriceUsd = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.SalePriceUsd == nil {
+						m.SalePriceUsd = &Money{}
+					}
+					if err := m.SalePriceUsd.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 5: // PercentOff
+			// Unmarshal fixed field (PercentOff)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.PercentOff = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 6: // Start
+			// Unmarshal fixed field (Start)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Start = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 7: // End
+			// Unmarshal fixed field (End)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.End = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 8: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[8]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PriceChanges) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Changes): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Changes))
+	for i, item := range m.Changes {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Changes[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Changes): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Changes)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *PriceChanges) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Changes
+			// Unmarshal nested message field (Changes)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Changes = make([]*PriceChange, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Changes = append(m.Changes, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &PriceChange{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Changes = append(m.Changes, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ListPriceChangesRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Status): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	return buf, nil
+}
+
+func (m *ListPriceChangesRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[1]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ReserveStockRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 136)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Items): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (OrderId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 2 (Items): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ReserveStockRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[1]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*CartItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &CartItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ReservationRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (OrderId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	return buf, nil
+}
+
+func (m *ReservationRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[1]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *StockReservation) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 195)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Items): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (OrderId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 2 (Items): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	offset += 8 // ExpiresAt
+
+	// Field 4 (Status): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write fixed field (ExpiresAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.ExpiresAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	return buf, nil
+}
+
+func (m *StockReservation) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[1]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*CartItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &CartItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // ExpiresAt
+			// Unmarshal fixed field (ExpiresAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.ExpiresAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 4: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[4]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ProductRestocked) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 271)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Product): singular message
+	if m.Product != nil {
+		cachedSingularMessages[1], err = m.Product.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Product: %w", err)
+		}
+	}
+
+	// Cache field 2 (Variant): singular message
+	if m.Variant != nil {
+		cachedSingularMessages[2], err = m.Variant.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Variant: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Product): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Variant): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// Field 3 (Email): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 4 (Locale): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Product)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Variant)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	return buf, nil
+}
+
+func (m *ProductRestocked) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Product
+			// Unmarshal nested message field (Product)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Product = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Product == nil {
+						m.Product = &Product{}
+					}
+					if err := m.Product.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Variant
+			// Unmarshal nested message field (Variant)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Variant = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Variant == nil {
+						m.Variant = &ProductVariant{}
+					}
+					if err := m.Variant.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[3]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[4]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetProductRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Id): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Id)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
+
+	return buf, nil
+}
+
+func (m *GetProductRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
+			if entry, ok := offsets[1]; ok {
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetProductsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Ids): repeated variable-length
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Ids
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.Ids {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write repeated variable-length field (Ids)
+	for _, item := range m.Ids {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	return buf, nil
+}
+
+func (m *GetProductsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Ids
+			// Unmarshal repeated variable-length field (Ids)
+			if entry, ok := offsets[1]; ok {
+				m.Ids = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Ids = append(m.Ids, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.Ids = append(m.Ids, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *SearchProductsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 143)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Query): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Query
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Query)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Query)
+
+	// Field 2 (Category): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Category
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Category)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Category)
+
+	// Field 3 (PriceRange): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of PriceRange
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.PriceRange)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.PriceRange)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Query)
+	buf = append(buf, []byte(m.Query)...)
+
+	// Write string or bytes field (Category)
+	buf = append(buf, []byte(m.Category)...)
+
+	// Write string or bytes field (PriceRange)
+	buf = append(buf, []byte(m.PriceRange)...)
+
+	return buf, nil
+}
+
+func (m *SearchProductsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Query
+			// Unmarshal string or []byte field (Query)
+			if entry, ok := offsets[1]; ok {
+				m.Query = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Category
+			// Unmarshal string or []byte field (Category)
+			if entry, ok := offsets[2]; ok {
+				m.Category = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // PriceRange
+			// Unmarshal string or []byte field (PriceRange)
+			if entry, ok := offsets[3]; ok {
+				m.PriceRange = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *SearchProductsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 176)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (Facets): singular message
+	if m.Facets != nil {
+		cachedSingularMessages[2], err = m.Facets.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Facets: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Results): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Results))
+	for i, item := range m.Results {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Results[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Results): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 2 (Facets): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Results)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write nested message field (Facets)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	return buf, nil
+}
+
+func (m *SearchProductsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Results
+			// Unmarshal nested message field (Results)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Results = make([]*Product, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Results = append(m.Results, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Product{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Results = append(m.Results, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Facets
+			// Unmarshal nested message field (Facets)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Facets = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Facets == nil {
+						m.Facets = &SearchFacets{}
+					}
+					if err := m.Facets.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *SearchFacets) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Counts): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Counts))
+	for i, item := range m.Counts {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Counts[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Counts): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Counts)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *SearchFacets) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Counts
+			// Unmarshal nested message field (Counts)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Counts = make([]*FacetCount, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Counts = append(m.Counts, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &FacetCount{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Counts = append(m.Counts, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *FacetCount) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 102)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Facet): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Facet
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Facet)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Facet)
+
+	// Field 2 (Value): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Value
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Value)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Value)
+
+	offset += 4 // Count
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Facet)
+	buf = append(buf, []byte(m.Facet)...)
+
+	// Write string or bytes field (Value)
+	buf = append(buf, []byte(m.Value)...)
+
+	// Write fixed field (Count)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Count))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *FacetCount) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Facet
+			// Unmarshal string or []byte field (Facet)
+			if entry, ok := offsets[1]; ok {
+				m.Facet = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Value
+			// Unmarshal string or []byte field (Value)
+			if entry, ok := offsets[2]; ok {
+				m.Value = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Count
+			// Unmarshal fixed field (Count)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Count = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *SuggestProductsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 55)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Prefix): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Prefix
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Prefix)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Prefix)
+
+	offset += 4 // Limit
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Prefix)
+	buf = append(buf, []byte(m.Prefix)...)
+
+	// Write fixed field (Limit)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Limit))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *SuggestProductsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Prefix
+			// Unmarshal string or []byte field (Prefix)
+			if entry, ok := offsets[1]; ok {
+				m.Prefix = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Limit
+			// Unmarshal fixed field (Limit)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Limit = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *SuggestProductsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Suggestions): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Suggestions))
+	for i, item := range m.Suggestions {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Suggestions[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Suggestions): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Suggestions)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *SuggestProductsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Suggestions
+			// Unmarshal nested message field (Suggestions)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Suggestions = make([]*ProductSuggestion, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Suggestions = append(m.Suggestions, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &ProductSuggestion{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Suggestions = append(m.Suggestions, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ProductSuggestion) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 96)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Id): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Id)
+
+	// Field 2 (Name): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Name
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Name)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Name)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
+
+	// Write string or bytes field (Name)
+	buf = append(buf, []byte(m.Name)...)
+
+	return buf, nil
+}
+
+func (m *ProductSuggestion) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
+			if entry, ok := offsets[1]; ok {
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Name
+			// Unmarshal string or []byte field (Name)
+			if entry, ok := offsets[2]; ok {
+				m.Name = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ImportProductsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 198)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 4 (Products): repeated message
+	cachedRepeatedMessages[4] = make([][]byte, len(m.Products))
+	for i, item := range m.Products {
+		if item != nil {
+			cachedRepeatedMessages[4][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Products[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ImportId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ImportId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ImportId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ImportId)
+
+	offset += 4 // ChunkIndex
+
+	offset += 4 // ChunkCount
+
+	// Field 4 (Products): nested message
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[4] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 5 (Mode): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Mode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Mode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Mode)
+
+	offset += 1 // DryRun
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ImportId)
+	buf = append(buf, []byte(m.ImportId)...)
+
+	// Write fixed field (ChunkIndex)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.ChunkIndex))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (ChunkCount)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.ChunkCount))
+	buf = append(buf, temp[:4]...)
+
+	// Write nested message field (Products)
+	for _, item := range cachedRepeatedMessages[4] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write string or bytes field (Mode)
+	buf = append(buf, []byte(m.Mode)...)
+
+	// Write fixed field (DryRun)
+	if m.DryRun {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	return buf, nil
+}
+
+func (m *ImportProductsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 7 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+6]
+	offset += 6
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ImportId
+			// Unmarshal string or []byte field (ImportId)
+			if entry, ok := offsets[1]; ok {
+				m.ImportId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ChunkIndex
+			// Unmarshal fixed field (ChunkIndex)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.ChunkIndex = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 3: // ChunkCount
+			// Unmarshal fixed field (ChunkCount)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.ChunkCount = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 4: // Products
+			// Unmarshal nested message field (Products)
+			if entry, ok := offsets[4]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Products = make([]*Product, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Products = append(m.Products, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Product{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Products = append(m.Products, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 5: // Mode
+			// Unmarshal string or []byte field (Mode)
+			if entry, ok := offsets[5]; ok {
+				m.Mode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // DryRun
+			// Unmarshal fixed field (DryRun)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.DryRun = dataRegion[dataOffset] != 0
+			dataOffset += 1
+		}
+	}
+
+	return nil
+}
+
+func (m *ImportProblem) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 96)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ProductId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// Field 2 (Message): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Message
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Message)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Message)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write string or bytes field (Message)
+	buf = append(buf, []byte(m.Message)...)
+
+	return buf, nil
+}
+
+func (m *ImportProblem) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[1]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Message
+			// Unmarshal string or []byte field (Message)
+			if entry, ok := offsets[2]; ok {
+				m.Message = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ImportProductsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 196)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 5 (Problems): repeated message
+	cachedRepeatedMessages[5] = make([][]byte, len(m.Problems))
+	for i, item := range m.Problems {
+		if item != nil {
+			cachedRepeatedMessages[5][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Problems[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (ImportId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ImportId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ImportId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ImportId)
+
+	offset += 4 // ChunksReceived
+
+	// Field 3 (Status): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	offset += 4 // ProductCount
+
+	// Field 5 (Problems): nested message
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[5] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (ImportId)
+	buf = append(buf, []byte(m.ImportId)...)
+
+	// Write fixed field (ChunksReceived)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.ChunksReceived))
+	buf = append(buf, temp[:4]...)
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	// Write fixed field (ProductCount)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.ProductCount))
+	buf = append(buf, temp[:4]...)
+
+	// Write nested message field (Problems)
+	for _, item := range cachedRepeatedMessages[5] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ImportProductsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // ImportId
+			// Unmarshal string or []byte field (ImportId)
+			if entry, ok := offsets[1]; ok {
+				m.ImportId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ChunksReceived
+			// Unmarshal fixed field (ChunksReceived)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.ChunksReceived = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 3: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[3]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // ProductCount
+			// Unmarshal fixed field (ProductCount)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.ProductCount = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 5: // Problems
+			// Unmarshal nested message field (Problems)
+			if entry, ok := offsets[5]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Problems = make([]*ImportProblem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Problems = append(m.Problems, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &ImportProblem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Problems = append(m.Problems, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ExportProductsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 13)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	offset += 4 // Offset
+
+	offset += 4 // Limit
+
+	// === DATA REGION SECTION ===
+
+	// Write fixed field (Offset)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Offset))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (Limit)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Limit))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *ExportProductsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 0
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 0; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Offset
+			// Unmarshal fixed field (Offset)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Offset = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 2: // Limit
+			// Unmarshal fixed field (Limit)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Limit = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *ExportProductsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 95)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Products): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Products))
+	for i, item := range m.Products {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Products[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Products): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	offset += 4 // Total
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Products)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write fixed field (Total)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Total))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *ExportProductsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Products
+			// Unmarshal nested message field (Products)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Products = make([]*Product, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Products = append(m.Products, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Product{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Products = append(m.Products, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Total
+			// Unmarshal fixed field (Total)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Total = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *GetQuoteRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 263)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Address): singular message
+	if m.Address != nil {
+		cachedSingularMessages[1], err = m.Address.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+		}
+	}
+
+	// Cache field 3 (Subtotal): singular message
+	if m.Subtotal != nil {
+		cachedSingularMessages[3], err = m.Subtotal.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Subtotal: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Items): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Address): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Items): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 3 (Subtotal): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Address)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write nested message field (Subtotal)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	return buf, nil
+}
+
+func (m *GetQuoteRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Address
+			// Unmarshal nested message field (Address)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Address = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Address == nil {
+						m.Address = &Address{}
+					}
+					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*CartItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &CartItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // Subtotal
+			// Unmarshal nested message field (Subtotal)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.Subtotal = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Subtotal == nil {
+						m.Subtotal = &Money{}
+					}
+					if err := m.Subtotal.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetQuoteResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 266)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (CostUsd): singular message
+	if m.CostUsd != nil {
+		cachedSingularMessages[1], err = m.CostUsd.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field CostUsd: %w", err)
+		}
+	}
+
+	// Cache field 2 (Origin): singular message
+	if m.Origin != nil {
+		cachedSingularMessages[2], err = m.Origin.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Origin: %w", err)
+		}
+	}
+
+	// Cache field 4 (FreeShippingRemaining): singular message
+	if m.FreeShippingRemaining != nil {
+		cachedSingularMessages[4], err = m.FreeShippingRemaining.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field FreeShippingRemaining: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (CostUsd): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Origin): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	offset += 1 // FreeShipping
+
+	// Field 4 (FreeShippingRemaining): nested message
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[4])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[4])
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (CostUsd)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Origin)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write fixed field (FreeShipping)
+	if m.FreeShipping {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	// Write nested message field (FreeShippingRemaining)
+	buf = append(buf, cachedSingularMessages[4]...)
+
+	return buf, nil
+}
+
+func (m *GetQuoteResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // CostUsd
+			// Unmarshal nested message field (CostUsd)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.CostUsd = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.CostUsd == nil {
+						m.CostUsd = &Money{}
+					}
+					if err := m.CostUsd.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Origin
+			// Unmarshal nested message field (Origin)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Origin = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Origin == nil {
+						m.Origin = &Warehouse{}
+					}
+					if err := m.Origin.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // FreeShipping
+			// Unmarshal fixed field (FreeShipping)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.FreeShipping = dataRegion[dataOffset] != 0
+			dataOffset += 1
+		case 4: // FreeShippingRemaining
+			// Unmarshal nested message field (FreeShippingRemaining)
+			if entry, ok := offsets[4]; ok {
+				if entry.length == 0 {
+					m.FreeShippingRemaining = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.FreeShippingRemaining == nil {
+						m.FreeShippingRemaining = &Money{}
+					}
+					if err := m.FreeShippingRemaining.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ShipOrderRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 557)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Address): singular message
+	if m.Address != nil {
+		cachedSingularMessages[1], err = m.Address.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+		}
+	}
+
+	// Cache field 11 (DeliveryWindow): singular message
+	if m.DeliveryWindow != nil {
+		cachedSingularMessages[11], err = m.DeliveryWindow.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field DeliveryWindow: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Items): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Address): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Items): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 3 (OrderId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 4 (Email): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 5 (Locale): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// Field 6 (UserId): string or bytes
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	offset += 1 // GiftWrap
+
+	// Field 8 (Note): string or bytes
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Note
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Note)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Note)
+
+	// Field 9 (WarehouseId): string or bytes
+	buf = append(buf, byte(9))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of WarehouseId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.WarehouseId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.WarehouseId)
+
+	offset += 4 // Part
+
+	// Field 11 (DeliveryWindow): nested message
+	buf = append(buf, byte(11))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[11])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[11])
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Address)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write fixed field (GiftWrap)
+	if m.GiftWrap {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	// Write string or bytes field (Note)
+	buf = append(buf, []byte(m.Note)...)
+
+	// Write string or bytes field (WarehouseId)
+	buf = append(buf, []byte(m.WarehouseId)...)
+
+	// Write fixed field (Part)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Part))
+	buf = append(buf, temp[:4]...)
+
+	// Write nested message field (DeliveryWindow)
+	buf = append(buf, cachedSingularMessages[11]...)
+
+	return buf, nil
+}
+
+func (m *ShipOrderRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 12 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+11]
+	offset += 11
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 45
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 9; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Address
+			// Unmarshal nested message field (Address)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Address = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Address == nil {
+						m.Address = &Address{}
+					}
+					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*CartItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &CartItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[3]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[4]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[5]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[6]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 7: // GiftWrap
+			// Unmarshal fixed field (GiftWrap)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.GiftWrap = dataRegion[dataOffset] != 0
+			dataOffset += 1
+		case 8: // Note
+			// Unmarshal string or []byte field (Note)
+			if entry, ok := offsets[8]; ok {
+				m.Note = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 9: // WarehouseId
+			// Unmarshal string or []byte field (WarehouseId)
+			if entry, ok := offsets[9]; ok {
+				m.WarehouseId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 10: // Part
+			// Unmarshal fixed field (Part)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Part = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 11: // DeliveryWindow
+			// Unmarshal nested message field (DeliveryWindow)
+			if entry, ok := offsets[11]; ok {
+				if entry.length == 0 {
+					m.DeliveryWindow = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.DeliveryWindow == nil {
+						m.DeliveryWindow = &DeliveryWindow{}
+					}
+					if err := m.DeliveryWindow.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PlanShipmentsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 176)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Address): singular message
+	if m.Address != nil {
+		cachedSingularMessages[1], err = m.Address.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Items): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Address): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Items): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Address)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *PlanShipmentsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Address
+			// Unmarshal nested message field (Address)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Address = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Address == nil {
+						m.Address = &Address{}
+					}
+					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*CartItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &CartItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ShipmentGroup) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 183)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Items): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (WarehouseId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of WarehouseId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.WarehouseId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.WarehouseId)
+
+	// Field 2 (Items): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 3 (TrackingId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TrackingId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TrackingId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TrackingId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (WarehouseId)
+	buf = append(buf, []byte(m.WarehouseId)...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write string or bytes field (TrackingId)
+	buf = append(buf, []byte(m.TrackingId)...)
+
+	return buf, nil
+}
+
+func (m *ShipmentGroup) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // WarehouseId
+			// Unmarshal string or []byte field (WarehouseId)
+			if entry, ok := offsets[1]; ok {
+				m.WarehouseId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*CartItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &CartItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // TrackingId
+			// Unmarshal string or []byte field (TrackingId)
+			if entry, ok := offsets[3]; ok {
+				m.TrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ShipmentGroups) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Groups): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Groups))
+	for i, item := range m.Groups {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Groups[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Groups): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Groups)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ShipmentGroups) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Groups
+			// Unmarshal nested message field (Groups)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Groups = make([]*ShipmentGroup, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Groups = append(m.Groups, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &ShipmentGroup{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Groups = append(m.Groups, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetDeliveryOptionsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 176)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Address): singular message
+	if m.Address != nil {
+		cachedSingularMessages[1], err = m.Address.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Items): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Address): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Items): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Address)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *GetDeliveryOptionsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Address
+			// Unmarshal nested message field (Address)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Address = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Address == nil {
+						m.Address = &Address{}
+					}
+					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*CartItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &CartItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *DeliveryWindow) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 96)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (StartDate): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of StartDate
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.StartDate)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.StartDate)
+
+	// Field 2 (EndDate): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of EndDate
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.EndDate)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.EndDate)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (StartDate)
+	buf = append(buf, []byte(m.StartDate)...)
+
+	// Write string or bytes field (EndDate)
+	buf = append(buf, []byte(m.EndDate)...)
+
+	return buf, nil
+}
+
+func (m *DeliveryWindow) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // StartDate
+			// Unmarshal string or []byte field (StartDate)
+			if entry, ok := offsets[1]; ok {
+				m.StartDate = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // EndDate
+			// Unmarshal string or []byte field (EndDate)
+			if entry, ok := offsets[2]; ok {
+				m.EndDate = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *DeliveryOptions) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Windows): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Windows))
+	for i, item := range m.Windows {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Windows[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Windows): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Windows)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *DeliveryOptions) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Windows
+			// Unmarshal nested message field (Windows)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Windows = make([]*DeliveryWindow, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Windows = append(m.Windows, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &DeliveryWindow{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Windows = append(m.Windows, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ShipOrderResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 136)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (Origin): singular message
+	if m.Origin != nil {
+		cachedSingularMessages[2], err = m.Origin.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Origin: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TrackingId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TrackingId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TrackingId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TrackingId)
+
+	// Field 2 (Origin): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TrackingId)
+	buf = append(buf, []byte(m.TrackingId)...)
+
+	// Write nested message field (Origin)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	return buf, nil
+}
+
+func (m *ShipOrderResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TrackingId
+			// Unmarshal string or []byte field (TrackingId)
+			if entry, ok := offsets[1]; ok {
+				m.TrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Origin
+			// Unmarshal nested message field (Origin)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Origin = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Origin == nil {
+						m.Origin = &Warehouse{}
+					}
+					if err := m.Origin.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Warehouse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 183)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 3 (Address): singular message
+	if m.Address != nil {
+		cachedSingularMessages[3], err = m.Address.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Id): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Id)
+
+	// Field 2 (Name): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Name
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Name)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Name)
+
+	// Field 3 (Address): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
+
+	// Write string or bytes field (Name)
+	buf = append(buf, []byte(m.Name)...)
+
+	// Write nested message field (Address)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	return buf, nil
+}
+
+func (m *Warehouse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
+			if entry, ok := offsets[1]; ok {
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Name
+			// Unmarshal string or []byte field (Name)
+			if entry, ok := offsets[2]; ok {
+				m.Name = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Address
+			// Unmarshal nested message field (Address)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.Address = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Address == nil {
+						m.Address = &Address{}
+					}
+					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetShipmentRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TrackingId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TrackingId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TrackingId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TrackingId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TrackingId)
+	buf = append(buf, []byte(m.TrackingId)...)
+
+	return buf, nil
+}
+
+func (m *GetShipmentRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TrackingId
+			// Unmarshal string or []byte field (TrackingId)
+			if entry, ok := offsets[1]; ok {
+				m.TrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *CancelShipmentRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 96)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TrackingId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TrackingId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TrackingId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TrackingId)
+
+	// Field 2 (Reason): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Reason
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Reason)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Reason)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TrackingId)
+	buf = append(buf, []byte(m.TrackingId)...)
+
+	// Write string or bytes field (Reason)
+	buf = append(buf, []byte(m.Reason)...)
+
+	return buf, nil
+}
+
+func (m *CancelShipmentRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TrackingId
+			// Unmarshal string or []byte field (TrackingId)
+			if entry, ok := offsets[1]; ok {
+				m.TrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Reason
+			// Unmarshal string or []byte field (Reason)
+			if entry, ok := offsets[2]; ok {
+				m.Reason = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Shipment) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 253)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 6 (Origin): singular message
+	if m.Origin != nil {
+		cachedSingularMessages[6], err = m.Origin.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Origin: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TrackingId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TrackingId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TrackingId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TrackingId)
+
+	// Field 2 (OrderId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 3 (Status): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	offset += 8 // CreatedAt
+
+	offset += 8 // UpdatedAt
+
+	// Field 6 (Origin): nested message
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[6])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[6])
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TrackingId)
+	buf = append(buf, []byte(m.TrackingId)...)
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	// Write fixed field (CreatedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.CreatedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (UpdatedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.UpdatedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write nested message field (Origin)
+	buf = append(buf, cachedSingularMessages[6]...)
+
+	return buf, nil
+}
+
+func (m *Shipment) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 7 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+6]
+	offset += 6
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TrackingId
+			// Unmarshal string or []byte field (TrackingId)
+			if entry, ok := offsets[1]; ok {
+				m.TrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[2]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[3]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // CreatedAt
+			// Unmarshal fixed field (CreatedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.CreatedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 5: // UpdatedAt
+			// Unmarshal fixed field (UpdatedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.UpdatedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 6: // Origin
+			// Unmarshal nested message field (Origin)
+			if entry, ok := offsets[6]; ok {
+				if entry.length == 0 {
+					m.Origin = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Origin == nil {
+						m.Origin = &Warehouse{}
+					}
+					if err := m.Origin.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ShipmentStatusChanged) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 326)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Shipment): singular message
+	if m.Shipment != nil {
+		cachedSingularMessages[1], err = m.Shipment.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Shipment: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Shipment): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (PreviousStatus): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of PreviousStatus
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.PreviousStatus)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.PreviousStatus)
+
+	// Field 3 (Email): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 4 (Locale): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// Field 5 (UserId): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// Field 6 (Tenant): string or bytes
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Tenant
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Tenant)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Tenant)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Shipment)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (PreviousStatus)
+	buf = append(buf, []byte(m.PreviousStatus)...)
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write string or bytes field (Tenant)
+	buf = append(buf, []byte(m.Tenant)...)
+
+	return buf, nil
+}
+
+func (m *ShipmentStatusChanged) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 7 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+6]
+	offset += 6
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 30
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 6; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Shipment
+			// Unmarshal nested message field (Shipment)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Shipment = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Shipment == nil {
+						m.Shipment = &Shipment{}
+					}
+					if err := m.Shipment.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // PreviousStatus
+			// Unmarshal string or []byte field (PreviousStatus)
+			if entry, ok := offsets[2]; ok {
+				m.PreviousStatus = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[3]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[4]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[5]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // Tenant
+			// Unmarshal string or []byte field (Tenant)
+			if entry, ok := offsets[6]; ok {
+				m.Tenant = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Address) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 197)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (StreetAddress): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of StreetAddress
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.StreetAddress)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.StreetAddress)
+
+	// Field 2 (City): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of City
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.City)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.City)
+
+	// Field 3 (State): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of State
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.State)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.State)
+
+	// Field 4 (Country): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Country
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Country)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Country)
+
+	offset += 4 // ZipCode
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (StreetAddress)
+	buf = append(buf, []byte(m.StreetAddress)...)
+
+	// Write string or bytes field (City)
+	buf = append(buf, []byte(m.City)...)
+
+	// Write string or bytes field (State)
+	buf = append(buf, []byte(m.State)...)
+
+	// Write string or bytes field (Country)
+	buf = append(buf, []byte(m.Country)...)
+
+	// Write fixed field (ZipCode)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.ZipCode))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *Address) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // StreetAddress
+			// Unmarshal string or []byte field (StreetAddress)
+			if entry, ok := offsets[1]; ok {
+				m.StreetAddress = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // City
+			// Unmarshal string or []byte field (City)
+			if entry, ok := offsets[2]; ok {
+				m.City = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // State
+			// Unmarshal string or []byte field (State)
+			if entry, ok := offsets[3]; ok {
+				m.State = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Country
+			// Unmarshal string or []byte field (Country)
+			if entry, ok := offsets[4]; ok {
+				m.Country = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // ZipCode
+			// Unmarshal fixed field (ZipCode)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.ZipCode = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *ValidateAddressRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 136)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Address): singular message
+	if m.Address != nil {
+		cachedSingularMessages[1], err = m.Address.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Address): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (UserId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Address)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	return buf, nil
+}
+
+func (m *ValidateAddressRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Address
+			// Unmarshal nested message field (Address)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Address = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Address == nil {
+						m.Address = &Address{}
+					}
+					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[2]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *AddressProblem) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 143)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Field): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Field
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Field)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Field)
+
+	// Field 2 (Code): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Code
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Code)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Code)
+
+	// Field 3 (Message): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Message
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Message)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Message)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Field)
+	buf = append(buf, []byte(m.Field)...)
+
+	// Write string or bytes field (Code)
+	buf = append(buf, []byte(m.Code)...)
+
+	// Write string or bytes field (Message)
+	buf = append(buf, []byte(m.Message)...)
+
+	return buf, nil
+}
+
+func (m *AddressProblem) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Field
+			// Unmarshal string or []byte field (Field)
+			if entry, ok := offsets[1]; ok {
+				m.Field = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Code
+			// Unmarshal string or []byte field (Code)
+			if entry, ok := offsets[2]; ok {
+				m.Code = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Message
+			// Unmarshal string or []byte field (Message)
+			if entry, ok := offsets[3]; ok {
+				m.Message = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ValidateAddressResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 176)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Normalized): singular message
+	if m.Normalized != nil {
+		cachedSingularMessages[1], err = m.Normalized.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Normalized: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 2 (Problems): repeated message
+	cachedRepeatedMessages[2] = make([][]byte, len(m.Problems))
+	for i, item := range m.Problems {
+		if item != nil {
+			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Problems[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Normalized): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Problems): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[2] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Normalized)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Problems)
+	for _, item := range cachedRepeatedMessages[2] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ValidateAddressResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Normalized
+			// Unmarshal nested message field (Normalized)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Normalized = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Normalized == nil {
+						m.Normalized = &Address{}
+					}
+					if err := m.Normalized.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Problems
+			// Unmarshal nested message field (Problems)
+			if entry, ok := offsets[2]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Problems = make([]*AddressProblem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Problems = append(m.Problems, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &AddressProblem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Problems = append(m.Problems, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Money) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 66)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (CurrencyCode): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CurrencyCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CurrencyCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CurrencyCode)
+
+	offset += 8 // Units
+
+	offset += 4 // Nanos
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (CurrencyCode)
+	buf = append(buf, []byte(m.CurrencyCode)...)
+
+	// Write fixed field (Units)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Units))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (Nanos)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Nanos))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *Money) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // CurrencyCode
+			// Unmarshal string or []byte field (CurrencyCode)
+			if entry, ok := offsets[1]; ok {
+				m.CurrencyCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Units
+			// Unmarshal fixed field (Units)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Units = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 3: // Nanos
+			// Unmarshal fixed field (Nanos)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Nanos = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *GetSupportedCurrenciesResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (CurrencyCodes): repeated variable-length
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CurrencyCodes
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.CurrencyCodes {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write repeated variable-length field (CurrencyCodes)
+	for _, item := range m.CurrencyCodes {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	return buf, nil
+}
+
+func (m *GetSupportedCurrenciesResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // CurrencyCodes
+			// Unmarshal repeated variable-length field (CurrencyCodes)
+			if entry, ok := offsets[1]; ok {
+				m.CurrencyCodes = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.CurrencyCodes = append(m.CurrencyCodes, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.CurrencyCodes = append(m.CurrencyCodes, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *CurrencyConversionRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 183)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (From): singular message
+	if m.From != nil {
+		cachedSingularMessages[1], err = m.From.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field From: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (From): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (ToCode): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ToCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ToCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ToCode)
+
+	// Field 3 (UserId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (From)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (ToCode)
+	buf = append(buf, []byte(m.ToCode)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	return buf, nil
+}
+
+func (m *CurrencyConversionRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // From
+			// Unmarshal nested message field (From)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.From = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.From == nil {
+						m.From = &Money{}
+					}
+					if err := m.From.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // ToCode
+			// Unmarshal string or []byte field (ToCode)
+			if entry, ok := offsets[2]; ok {
+				m.ToCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[3]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *CurrencyConversionResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 136)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Money): singular message
+	if m.Money != nil {
+		cachedSingularMessages[1], err = m.Money.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Money: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Money): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (AppliedRate): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of AppliedRate
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.AppliedRate)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.AppliedRate)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Money)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (AppliedRate)
+	buf = append(buf, []byte(m.AppliedRate)...)
+
+	return buf, nil
+}
+
+func (m *CurrencyConversionResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Money
+			// Unmarshal nested message field (Money)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Money = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Money == nil {
+						m.Money = &Money{}
+					}
+					if err := m.Money.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // AppliedRate
+			// Unmarshal string or []byte field (AppliedRate)
+			if entry, ok := offsets[2]; ok {
+				m.AppliedRate = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ExchangeRateRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 143)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (FromCode): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of FromCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.FromCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.FromCode)
+
+	// Field 2 (ToCode): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ToCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ToCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ToCode)
+
+	// Field 3 (UserId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (FromCode)
+	buf = append(buf, []byte(m.FromCode)...)
+
+	// Write string or bytes field (ToCode)
+	buf = append(buf, []byte(m.ToCode)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	return buf, nil
+}
+
+func (m *ExchangeRateRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // FromCode
+			// Unmarshal string or []byte field (FromCode)
+			if entry, ok := offsets[1]; ok {
+				m.FromCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ToCode
+			// Unmarshal string or []byte field (ToCode)
+			if entry, ok := offsets[2]; ok {
+				m.ToCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[3]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ExchangeRateResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 202)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (FromCode): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of FromCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.FromCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.FromCode)
+
+	// Field 2 (ToCode): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ToCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ToCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ToCode)
+
+	// Field 3 (Rate): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Rate
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Rate)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Rate)
+
+	// Field 4 (Source): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Source
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Source)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Source)
+
+	offset += 8 // LastUpdated
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (FromCode)
+	buf = append(buf, []byte(m.FromCode)...)
+
+	// Write string or bytes field (ToCode)
+	buf = append(buf, []byte(m.ToCode)...)
+
+	// Write string or bytes field (Rate)
+	buf = append(buf, []byte(m.Rate)...)
+
+	// Write string or bytes field (Source)
+	buf = append(buf, []byte(m.Source)...)
+
+	// Write fixed field (LastUpdated)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.LastUpdated))
+	buf = append(buf, temp[:8]...)
+
+	return buf, nil
+}
+
+func (m *ExchangeRateResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // FromCode
+			// Unmarshal string or []byte field (FromCode)
+			if entry, ok := offsets[1]; ok {
+				m.FromCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ToCode
+			// Unmarshal string or []byte field (ToCode)
+			if entry, ok := offsets[2]; ok {
+				m.ToCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Rate
+			// Unmarshal string or []byte field (Rate)
+			if entry, ok := offsets[3]; ok {
+				m.Rate = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Source
+			// Unmarshal string or []byte field (Source)
+			if entry, ok := offsets[4]; ok {
+				m.Source = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // LastUpdated
+			// Unmarshal fixed field (LastUpdated)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.LastUpdated = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		}
+	}
+
+	return nil
+}
+
+func (m *RateAtRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 191)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Date): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Date
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Date)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Date)
+
+	// Field 2 (FromCode): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of FromCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.FromCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.FromCode)
+
+	// Field 3 (ToCode): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ToCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ToCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ToCode)
+
+	// Field 4 (UserId): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	offset += 8 // At
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Date)
+	buf = append(buf, []byte(m.Date)...)
+
+	// Write string or bytes field (FromCode)
+	buf = append(buf, []byte(m.FromCode)...)
+
+	// Write string or bytes field (ToCode)
+	buf = append(buf, []byte(m.ToCode)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write fixed field (At)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.At))
+	buf = append(buf, temp[:8]...)
+
+	return buf, nil
+}
+
+func (m *RateAtRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Date
+			// Unmarshal string or []byte field (Date)
+			if entry, ok := offsets[1]; ok {
+				m.Date = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // FromCode
+			// Unmarshal string or []byte field (FromCode)
+			if entry, ok := offsets[2]; ok {
+				m.FromCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // ToCode
+			// Unmarshal string or []byte field (ToCode)
+			if entry, ok := offsets[3]; ok {
+				m.ToCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[4]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // At
+			// Unmarshal fixed field (At)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.At = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		}
+	}
+
+	return nil
+}
+
+func (m *CreditCardInfo) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 67)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (CreditCardNumber): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CreditCardNumber
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CreditCardNumber)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CreditCardNumber)
+
+	offset += 4 // CreditCardCvv
+
+	offset += 4 // CreditCardExpirationYear
+
+	offset += 4 // CreditCardExpirationMonth
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (CreditCardNumber)
+	buf = append(buf, []byte(m.CreditCardNumber)...)
+
+	// Write fixed field (CreditCardCvv)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.CreditCardCvv))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (CreditCardExpirationYear)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.CreditCardExpirationYear))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (CreditCardExpirationMonth)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.CreditCardExpirationMonth))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *CreditCardInfo) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // CreditCardNumber
+			// Unmarshal string or []byte field (CreditCardNumber)
+			if entry, ok := offsets[1]; ok {
+				m.CreditCardNumber = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // CreditCardCvv
+			// Unmarshal fixed field (CreditCardCvv)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.CreditCardCvv = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 3: // CreditCardExpirationYear
+			// Unmarshal fixed field (CreditCardExpirationYear)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.CreditCardExpirationYear = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 4: // CreditCardExpirationMonth
+			// Unmarshal fixed field (CreditCardExpirationMonth)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.CreditCardExpirationMonth = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *ChargeRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 277)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Amount): singular message
+	if m.Amount != nil {
+		cachedSingularMessages[1], err = m.Amount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Amount: %w", err)
+		}
+	}
+
+	// Cache field 2 (CreditCard): singular message
+	if m.CreditCard != nil {
+		cachedSingularMessages[2], err = m.CreditCard.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field CreditCard: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Amount): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (CreditCard): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// Field 3 (UserId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	offset += 4 // Installments
+
+	// Field 5 (OrderId): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Amount)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (CreditCard)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write fixed field (Installments)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Installments))
+	buf = append(buf, temp[:4]...)
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	return buf, nil
+}
+
+func (m *ChargeRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Amount
+			// Unmarshal nested message field (Amount)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Amount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Amount == nil {
+						m.Amount = &Money{}
+					}
+					if err := m.Amount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // CreditCard
+			// Unmarshal nested message field (CreditCard)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.CreditCard = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.CreditCard == nil {
+						m.CreditCard = &CreditCardInfo{}
+					}
+					if err := m.CreditCard.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[3]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Installments
+			// Unmarshal fixed field (Installments)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Installments = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 5: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[5]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ChargeResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 136)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (InstallmentPlan): singular message
+	if m.InstallmentPlan != nil {
+		cachedSingularMessages[2], err = m.InstallmentPlan.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field InstallmentPlan: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TransactionId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TransactionId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TransactionId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TransactionId)
+
+	// Field 2 (InstallmentPlan): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TransactionId)
+	buf = append(buf, []byte(m.TransactionId)...)
+
+	// Write nested message field (InstallmentPlan)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	return buf, nil
+}
+
+func (m *ChargeResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TransactionId
+			// Unmarshal string or []byte field (TransactionId)
+			if entry, ok := offsets[1]; ok {
+				m.TransactionId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // InstallmentPlan
+			// Unmarshal nested message field (InstallmentPlan)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.InstallmentPlan = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.InstallmentPlan == nil {
+						m.InstallmentPlan = &InstallmentPlan{}
+					}
+					if err := m.InstallmentPlan.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Installment) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 106)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (Amount): singular message
+	if m.Amount != nil {
+		cachedSingularMessages[2], err = m.Amount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Amount: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	offset += 4 // Number
+
+	// Field 2 (Amount): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	offset += 8 // DueAt
+
+	// === DATA REGION SECTION ===
+
+	// Write fixed field (Number)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Number))
+	buf = append(buf, temp[:4]...)
+
+	// Write nested message field (Amount)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write fixed field (DueAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.DueAt))
+	buf = append(buf, temp[:8]...)
+
+	return buf, nil
+}
+
+func (m *Installment) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Number
+			// Unmarshal fixed field (Number)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Number = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 2: // Amount
+			// Unmarshal nested message field (Amount)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Amount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Amount == nil {
+						m.Amount = &Money{}
+					}
+					if err := m.Amount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // DueAt
+			// Unmarshal fixed field (DueAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.DueAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		}
+	}
+
+	return nil
+}
+
+func (m *InstallmentPlan) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Installments): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Installments))
+	for i, item := range m.Installments {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Installments[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Installments): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Installments)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *InstallmentPlan) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Installments
+			// Unmarshal nested message field (Installments)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Installments = make([]*Installment, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Installments = append(m.Installments, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Installment{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Installments = append(m.Installments, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *Transaction) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 531)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 3 (Amount): singular message
+	if m.Amount != nil {
+		cachedSingularMessages[3], err = m.Amount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Amount: %w", err)
+		}
+	}
+
+	// Cache field 10 (InstallmentPlan): singular message
+	if m.InstallmentPlan != nil {
+		cachedSingularMessages[10], err = m.InstallmentPlan.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field InstallmentPlan: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TransactionId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TransactionId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TransactionId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TransactionId)
+
+	// Field 2 (UserId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// Field 3 (Amount): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// Field 4 (CardBrand): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CardBrand
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CardBrand)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CardBrand)
+
+	// Field 5 (CardLastFour): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CardLastFour
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CardLastFour)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CardLastFour)
+
+	// Field 6 (Status): string or bytes
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	// Field 7 (FailureReason): string or bytes
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of FailureReason
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.FailureReason)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.FailureReason)
+
+	offset += 8 // CreatedAt
+
+	offset += 8 // UpdatedAt
+
+	// Field 10 (InstallmentPlan): nested message
+	buf = append(buf, byte(10))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[10])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[10])
+
+	// Field 11 (OrderId): string or bytes
+	buf = append(buf, byte(11))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TransactionId)
+	buf = append(buf, []byte(m.TransactionId)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write nested message field (Amount)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	// Write string or bytes field (CardBrand)
+	buf = append(buf, []byte(m.CardBrand)...)
+
+	// Write string or bytes field (CardLastFour)
+	buf = append(buf, []byte(m.CardLastFour)...)
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	// Write string or bytes field (FailureReason)
+	buf = append(buf, []byte(m.FailureReason)...)
+
+	// Write fixed field (CreatedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.CreatedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (UpdatedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.UpdatedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write nested message field (InstallmentPlan)
+	buf = append(buf, cachedSingularMessages[10]...)
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	return buf, nil
+}
+
+func (m *Transaction) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 12 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+11]
+	offset += 11
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 45
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 9; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TransactionId
+			// Unmarshal string or []byte field (TransactionId)
+			if entry, ok := offsets[1]; ok {
+				m.TransactionId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[2]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Amount
+			// Unmarshal nested message field (Amount)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.Amount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Amount == nil {
+						m.Amount = &Money{}
+					}
+					if err := m.Amount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // CardBrand
+			// Unmarshal string or []byte field (CardBrand)
+			if entry, ok := offsets[4]; ok {
+				m.CardBrand = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // CardLastFour
+			// Unmarshal string or []byte field (CardLastFour)
+			if entry, ok := offsets[5]; ok {
+				m.CardLastFour = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[6]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 7: // FailureReason
+			// Unmarshal string or []byte field (FailureReason)
+			if entry, ok := offsets[7]; ok {
+				m.FailureReason = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 8: // CreatedAt
+			// Unmarshal fixed field (CreatedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.CreatedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 9: // UpdatedAt
+			// Unmarshal fixed field (UpdatedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.UpdatedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 10: // InstallmentPlan
+			// Unmarshal nested message field (InstallmentPlan)
+			if entry, ok := offsets[10]; ok {
+				if entry.length == 0 {
+					m.InstallmentPlan = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.InstallmentPlan == nil {
+						m.InstallmentPlan = &InstallmentPlan{}
+					}
+					if err := m.InstallmentPlan.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 11: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[11]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PaymentStatusChanged) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 231)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Transaction): singular message
+	if m.Transaction != nil {
+		cachedSingularMessages[1], err = m.Transaction.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Transaction: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Transaction): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (PreviousStatus): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of PreviousStatus
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.PreviousStatus)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.PreviousStatus)
+
+	// Field 3 (Event): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Event
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Event)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Event)
+
+	// Field 4 (Tenant): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Tenant
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Tenant)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Tenant)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Transaction)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (PreviousStatus)
+	buf = append(buf, []byte(m.PreviousStatus)...)
+
+	// Write string or bytes field (Event)
+	buf = append(buf, []byte(m.Event)...)
+
+	// Write string or bytes field (Tenant)
+	buf = append(buf, []byte(m.Tenant)...)
+
+	return buf, nil
+}
+
+func (m *PaymentStatusChanged) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Transaction
+			// Unmarshal nested message field (Transaction)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Transaction = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Transaction == nil {
+						m.Transaction = &Transaction{}
+					}
+					if err := m.Transaction.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // PreviousStatus
+			// Unmarshal string or []byte field (PreviousStatus)
+			if entry, ok := offsets[2]; ok {
+				m.PreviousStatus = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Event
+			// Unmarshal string or []byte field (Event)
+			if entry, ok := offsets[3]; ok {
+				m.Event = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Tenant
+			// Unmarshal string or []byte field (Tenant)
+			if entry, ok := offsets[4]; ok {
+				m.Tenant = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *GetTransactionRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TransactionId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TransactionId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TransactionId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TransactionId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TransactionId)
+	buf = append(buf, []byte(m.TransactionId)...)
+
+	return buf, nil
+}
+
+func (m *GetTransactionRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TransactionId
+			// Unmarshal string or []byte field (TransactionId)
+			if entry, ok := offsets[1]; ok {
+				m.TransactionId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RefundRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 96)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (TransactionId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TransactionId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TransactionId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TransactionId)
+
+	// Field 2 (Reason): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Reason
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Reason)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Reason)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (TransactionId)
+	buf = append(buf, []byte(m.TransactionId)...)
+
+	// Write string or bytes field (Reason)
+	buf = append(buf, []byte(m.Reason)...)
+
+	return buf, nil
+}
+
+func (m *RefundRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // TransactionId
+			// Unmarshal string or []byte field (TransactionId)
+			if entry, ok := offsets[1]; ok {
+				m.TransactionId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Reason
+			// Unmarshal string or []byte field (Reason)
+			if entry, ok := offsets[2]; ok {
+				m.Reason = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ListTransactionsByUserRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (UserId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	return buf, nil
+}
+
+func (m *ListTransactionsByUserRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[1]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ListTransactionsResponse) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Transactions): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Transactions))
+	for i, item := range m.Transactions {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Transactions[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Transactions): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Transactions)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *ListTransactionsResponse) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Transactions
+			// Unmarshal nested message field (Transactions)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Transactions = make([]*Transaction, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Transactions = append(m.Transactions, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Transaction{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Transactions = append(m.Transactions, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEntry) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 356)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	offset += 8 // Seq
+
+	offset += 8 // Time
+
+	// Field 3 (Service): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Service
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Service)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Service)
+
+	// Field 4 (Method): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Method
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Method)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Method)
+
+	// Field 5 (UserId): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// Field 6 (Tenant): string or bytes
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Tenant
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Tenant)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Tenant)
+
+	// Field 7 (Details): string or bytes
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Details
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Details)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Details)
+
+	// Field 8 (PrevHash): string or bytes
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of PrevHash
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.PrevHash)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.PrevHash)
+
+	// Field 9 (Hash): string or bytes
+	buf = append(buf, byte(9))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Hash
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Hash)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Hash)
+
+	// === DATA REGION SECTION ===
+
+	// Write fixed field (Seq)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Seq))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (Time)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Time))
+	buf = append(buf, temp[:8]...)
+
+	// Write string or bytes field (Service)
+	buf = append(buf, []byte(m.Service)...)
+
+	// Write string or bytes field (Method)
+	buf = append(buf, []byte(m.Method)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write string or bytes field (Tenant)
+	buf = append(buf, []byte(m.Tenant)...)
+
+	// Write string or bytes field (Details)
+	buf = append(buf, []byte(m.Details)...)
+
+	// Write string or bytes field (PrevHash)
+	buf = append(buf, []byte(m.PrevHash)...)
+
+	// Write string or bytes field (Hash)
+	buf = append(buf, []byte(m.Hash)...)
+
+	return buf, nil
+}
+
+func (m *AuditEntry) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 10 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+9]
+	offset += 9
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 35
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 7; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Seq
+			// Unmarshal fixed field (Seq)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Seq = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 2: // Time
+			// Unmarshal fixed field (Time)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Time = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 3: // Service
+			// Unmarshal string or []byte field (Service)
+			if entry, ok := offsets[3]; ok {
+				m.Service = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Method
+			// Unmarshal string or []byte field (Method)
+			if entry, ok := offsets[4]; ok {
+				m.Method = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[5]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // Tenant
+			// Unmarshal string or []byte field (Tenant)
+			if entry, ok := offsets[6]; ok {
+				m.Tenant = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 7: // Details
+			// Unmarshal string or []byte field (Details)
+			if entry, ok := offsets[7]; ok {
+				m.Details = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 8: // PrevHash
+			// Unmarshal string or []byte field (PrevHash)
+			if entry, ok := offsets[8]; ok {
+				m.PrevHash = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 9: // Hash
+			// Unmarshal string or []byte field (Hash)
+			if entry, ok := offsets[9]; ok {
+				m.Hash = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ListAuditEntriesRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 18)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	offset += 8 // FromSeq
+
+	offset += 4 // Limit
+
+	// === DATA REGION SECTION ===
+
+	// Write fixed field (FromSeq)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.FromSeq))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (Limit)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Limit))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *ListAuditEntriesRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 0
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 0; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // FromSeq
+			// Unmarshal fixed field (FromSeq)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.FromSeq = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 2: // Limit
+			// Unmarshal fixed field (Limit)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Limit = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *AuditEntries) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 102)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Entries): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Entries))
+	for i, item := range m.Entries {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Entries[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Entries): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	offset += 1 // Intact
+
+	offset += 8 // BrokenSeq
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Entries)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write fixed field (Intact)
+	if m.Intact {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	// Write fixed field (BrokenSeq)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.BrokenSeq))
+	buf = append(buf, temp[:8]...)
+
+	return buf, nil
+}
+
+func (m *AuditEntries) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Entries
+			// Unmarshal nested message field (Entries)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Entries = make([]*AuditEntry, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Entries = append(m.Entries, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &AuditEntry{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Entries = append(m.Entries, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Intact
+			// Unmarshal fixed field (Intact)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Intact = dataRegion[dataOffset] != 0
+			dataOffset += 1
+		case 3: // BrokenSeq
+			// Unmarshal fixed field (BrokenSeq)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.BrokenSeq = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		}
+	}
+
+	return nil
+}
+
+func (m *GetWalletBalanceRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (CurrencyCode): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CurrencyCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CurrencyCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CurrencyCode)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (CurrencyCode)
+	buf = append(buf, []byte(m.CurrencyCode)...)
+
+	return buf, nil
+}
+
+func (m *GetWalletBalanceRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // CurrencyCode
+			// Unmarshal string or []byte field (CurrencyCode)
+			if entry, ok := offsets[1]; ok {
+				m.CurrencyCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *WalletBalance) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Balance): singular message
+	if m.Balance != nil {
+		cachedSingularMessages[1], err = m.Balance.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Balance: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Balance): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Balance)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	return buf, nil
+}
+
+func (m *WalletBalance) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Balance
+			// Unmarshal nested message field (Balance)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Balance = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Balance == nil {
+						m.Balance = &Money{}
+					}
+					if err := m.Balance.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *RedeemGiftCardRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Code): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Code
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Code)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Code)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Code)
+	buf = append(buf, []byte(m.Code)...)
+
+	return buf, nil
+}
+
+func (m *RedeemGiftCardRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Code
+			// Unmarshal string or []byte field (Code)
+			if entry, ok := offsets[1]; ok {
+				m.Code = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *WalletDebitRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 136)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Amount): singular message
+	if m.Amount != nil {
+		cachedSingularMessages[1], err = m.Amount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Amount: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Amount): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (DebitId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of DebitId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.DebitId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.DebitId)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Amount)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (DebitId)
+	buf = append(buf, []byte(m.DebitId)...)
+
+	return buf, nil
+}
+
+func (m *WalletDebitRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Amount
+			// Unmarshal nested message field (Amount)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Amount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Amount == nil {
+						m.Amount = &Money{}
+					}
+					if err := m.Amount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // DebitId
+			// Unmarshal string or []byte field (DebitId)
+			if entry, ok := offsets[2]; ok {
+				m.DebitId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *WalletRefundRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (DebitId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of DebitId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.DebitId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.DebitId)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (DebitId)
+	buf = append(buf, []byte(m.DebitId)...)
+
+	return buf, nil
+}
+
+func (m *WalletRefundRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // DebitId
+			// Unmarshal string or []byte field (DebitId)
+			if entry, ok := offsets[1]; ok {
+				m.DebitId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *SendTicketAcknowledgementRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 136)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Ticket): singular message
+	if m.Ticket != nil {
+		cachedSingularMessages[1], err = m.Ticket.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Ticket: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Ticket): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (DedupeKey): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of DedupeKey
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.DedupeKey)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.DedupeKey)
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Ticket)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (DedupeKey)
+	buf = append(buf, []byte(m.DedupeKey)...)
+
+	return buf, nil
+}
+
+func (m *SendTicketAcknowledgementRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Ticket
+			// Unmarshal nested message field (Ticket)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Ticket = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Ticket == nil {
+						m.Ticket = &Ticket{}
+					}
+					if err := m.Ticket.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // DedupeKey
+			// Unmarshal string or []byte field (DedupeKey)
+			if entry, ok := offsets[2]; ok {
+				m.DedupeKey = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *OrderItem) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 176)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Item): singular message
+	if m.Item != nil {
+		cachedSingularMessages[1], err = m.Item.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Item: %w", err)
+		}
+	}
+
+	// Cache field 2 (Cost): singular message
+	if m.Cost != nil {
+		cachedSingularMessages[2], err = m.Cost.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Cost: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Item): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Cost): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Item)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Cost)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	return buf, nil
+}
+
+func (m *OrderItem) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 3 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+2]
+	offset += 2
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Item
+			// Unmarshal nested message field (Item)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Item = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Item == nil {
+						m.Item = &CartItem{}
+					}
+					if err := m.Item.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Cost
+			// Unmarshal nested message field (Cost)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Cost = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Cost == nil {
+						m.Cost = &Money{}
+					}
+					if err := m.Cost.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *OrderResult) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 933)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 3 (ShippingCost): singular message
+	if m.ShippingCost != nil {
+		cachedSingularMessages[3], err = m.ShippingCost.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field ShippingCost: %w", err)
+		}
+	}
+
+	// Cache field 4 (ShippingAddress): singular message
+	if m.ShippingAddress != nil {
+		cachedSingularMessages[4], err = m.ShippingAddress.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field ShippingAddress: %w", err)
+		}
+	}
+
+	// Cache field 6 (Breakdown): singular message
+	if m.Breakdown != nil {
+		cachedSingularMessages[6], err = m.Breakdown.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Breakdown: %w", err)
+		}
+	}
+
+	// Cache field 9 (Shipments): singular message
+	if m.Shipments != nil {
+		cachedSingularMessages[9], err = m.Shipments.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Shipments: %w", err)
+		}
+	}
+
+	// Cache field 10 (DeliveryWindow): singular message
+	if m.DeliveryWindow != nil {
+		cachedSingularMessages[10], err = m.DeliveryWindow.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field DeliveryWindow: %w", err)
+		}
+	}
+
+	// Cache field 11 (InstallmentPlan): singular message
+	if m.InstallmentPlan != nil {
+		cachedSingularMessages[11], err = m.InstallmentPlan.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field InstallmentPlan: %w", err)
+		}
+	}
+
+	// Cache field 12 (WalletPaid): singular message
+	if m.WalletPaid != nil {
+		cachedSingularMessages[12], err = m.WalletPaid.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field WalletPaid: %w", err)
+		}
+	}
+
+	// Cache field 13 (PostOrderSteps): singular message
+	if m.PostOrderSteps != nil {
+		cachedSingularMessages[13], err = m.PostOrderSteps.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field PostOrderSteps: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 5 (Items): repeated message
+	cachedRepeatedMessages[5] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[5][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (OrderId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 2 (ShippingTrackingId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ShippingTrackingId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ShippingTrackingId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ShippingTrackingId)
+
+	// Field 3 (ShippingCost): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// Field 4 (ShippingAddress): nested message
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[4])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[4])
+
+	// Field 5 (Items): nested message
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[5] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 6 (Breakdown): nested message
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[6])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[6])
+
+	offset += 1 // GiftWrap
+
+	// Field 8 (Note): string or bytes
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Note
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Note)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Note)
+
+	// Field 9 (Shipments): nested message
+	buf = append(buf, byte(9))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[9])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[9])
+
+	// Field 10 (DeliveryWindow): nested message
+	buf = append(buf, byte(10))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[10])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[10])
+
+	// Field 11 (InstallmentPlan): nested message
+	buf = append(buf, byte(11))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[11])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[11])
+
+	// Field 12 (WalletPaid): nested message
+	buf = append(buf, byte(12))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[12])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[12])
+
+	// Field 13 (PostOrderSteps): nested message
+	buf = append(buf, byte(13))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[13])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[13])
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write string or bytes field (ShippingTrackingId)
+	buf = append(buf, []byte(m.ShippingTrackingId)...)
+
+	// Write nested message field (ShippingCost)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	// Write nested message field (ShippingAddress)
+	buf = append(buf, cachedSingularMessages[4]...)
+
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[5] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write nested message field (Breakdown)
+	buf = append(buf, cachedSingularMessages[6]...)
+
+	// Write fixed field (GiftWrap)
+	if m.GiftWrap {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	// Write string or bytes field (Note)
+	buf = append(buf, []byte(m.Note)...)
+
+	// Write nested message field (Shipments)
+	buf = append(buf, cachedSingularMessages[9]...)
+
+	// Write nested message field (DeliveryWindow)
+	buf = append(buf, cachedSingularMessages[10]...)
+
+	// Write nested message field (InstallmentPlan)
+	buf = append(buf, cachedSingularMessages[11]...)
+
+	// Write nested message field (WalletPaid)
+	buf = append(buf, cachedSingularMessages[12]...)
+
+	// Write nested message field (PostOrderSteps)
+	buf = append(buf, cachedSingularMessages[13]...)
+
+	return buf, nil
+}
+
+func (m *OrderResult) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 14 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+13]
+	offset += 13
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 60
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 12; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[1]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ShippingTrackingId
+			// Unmarshal string or []byte field (ShippingTrackingId)
+			if entry, ok := offsets[2]; ok {
+				m.ShippingTrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // ShippingCost
+			// Unmarshal nested message field (ShippingCost)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.ShippingCost = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.ShippingCost == nil {
+						m.ShippingCost = &Money{}
+					}
+					if err := m.ShippingCost.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // ShippingAddress
+			// Unmarshal nested message field (ShippingAddress)
+			if entry, ok := offsets[4]; ok {
+				if entry.length == 0 {
+					m.ShippingAddress = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.ShippingAddress == nil {
+						m.ShippingAddress = &Address{}
+					}
+					if err := m.ShippingAddress.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 5: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[5]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*OrderItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &OrderItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 6: // Breakdown
+			// Unmarshal nested message field (Breakdown)
+			if entry, ok := offsets[6]; ok {
+				if entry.length == 0 {
+					m.Breakdown = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Breakdown == nil {
+						m.Breakdown = &OrderBreakdown{}
+					}
+					if err := m.Breakdown.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 7: // GiftWrap
+			// Unmarshal fixed field (GiftWrap)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.GiftWrap = dataRegion[dataOffset] != 0
+			dataOffset += 1
+		case 8: // Note
+			// Unmarshal string or []byte field (Note)
+			if entry, ok := offsets[8]; ok {
+				m.Note = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 9: // Shipments
+			// Unmarshal nested message field (Shipments)
+			if entry, ok := offsets[9]; ok {
+				if entry.length == 0 {
+					m.Shipments = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Shipments == nil {
+						m.Shipments = &ShipmentGroups{}
+					}
+					if err := m.Shipments.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 10: // DeliveryWindow
+			// Unmarshal nested message field (DeliveryWindow)
+			if entry, ok := offsets[10]; ok {
+				if entry.length == 0 {
+					m.DeliveryWindow = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.DeliveryWindow == nil {
+						m.DeliveryWindow = &DeliveryWindow{}
+					}
+					if err := m.DeliveryWindow.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 11: // InstallmentPlan
+			// Unmarshal nested message field (InstallmentPlan)
+			if entry, ok := offsets[11]; ok {
+				if entry.length == 0 {
+					m.InstallmentPlan = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.InstallmentPlan == nil {
+						m.InstallmentPlan = &InstallmentPlan{}
+					}
+					if err := m.InstallmentPlan.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 12: // WalletPaid
+			// Unmarshal nested message field (WalletPaid)
+			if entry, ok := offsets[12]; ok {
+				if entry.length == 0 {
+					m.WalletPaid = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.WalletPaid == nil {
+						m.WalletPaid = &Money{}
+					}
+					if err := m.WalletPaid.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 13: // PostOrderSteps
+			// Unmarshal nested message field (PostOrderSteps)
+			if entry, ok := offsets[13]; ok {
+				if entry.length == 0 {
+					m.PostOrderSteps = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.PostOrderSteps == nil {
+						m.PostOrderSteps = &PostOrderSteps{}
+					}
+					if err := m.PostOrderSteps.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PostOrderStep) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 143)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Name): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Name
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Name)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Name)
+
+	// Field 2 (Outcome): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Outcome
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Outcome)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Outcome)
+
+	// Field 3 (Error): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Error
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Error)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Error)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Name)
+	buf = append(buf, []byte(m.Name)...)
+
+	// Write string or bytes field (Outcome)
+	buf = append(buf, []byte(m.Outcome)...)
+
+	// Write string or bytes field (Error)
+	buf = append(buf, []byte(m.Error)...)
+
+	return buf, nil
+}
+
+func (m *PostOrderStep) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Name
+			// Unmarshal string or []byte field (Name)
+			if entry, ok := offsets[1]; ok {
+				m.Name = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Outcome
+			// Unmarshal string or []byte field (Outcome)
+			if entry, ok := offsets[2]; ok {
+				m.Outcome = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Error
+			// Unmarshal string or []byte field (Error)
+			if entry, ok := offsets[3]; ok {
+				m.Error = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PostOrderSteps) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Steps): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Steps))
+	for i, item := range m.Steps {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Steps[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Steps): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Steps)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *PostOrderSteps) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Steps
+			// Unmarshal nested message field (Steps)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Steps = make([]*PostOrderStep, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Steps = append(m.Steps, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &PostOrderStep{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Steps = append(m.Steps, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *OrderBreakdown) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 788)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Items): singular message
+	if m.Items != nil {
+		cachedSingularMessages[1], err = m.Items.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Items: %w", err)
+		}
+	}
+
+	// Cache field 2 (Shipping): singular message
+	if m.Shipping != nil {
+		cachedSingularMessages[2], err = m.Shipping.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Shipping: %w", err)
+		}
+	}
+
+	// Cache field 3 (Tax): singular message
+	if m.Tax != nil {
+		cachedSingularMessages[3], err = m.Tax.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Tax: %w", err)
+		}
+	}
+
+	// Cache field 4 (Discount): singular message
+	if m.Discount != nil {
+		cachedSingularMessages[4], err = m.Discount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Discount: %w", err)
+		}
+	}
+
+	// Cache field 5 (Total): singular message
+	if m.Total != nil {
+		cachedSingularMessages[5], err = m.Total.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Total: %w", err)
+		}
+	}
+
+	// Cache field 7 (GiftWrap): singular message
+	if m.GiftWrap != nil {
+		cachedSingularMessages[7], err = m.GiftWrap.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field GiftWrap: %w", err)
+		}
+	}
+
+	// Cache field 8 (PinnedRates): singular message
+	if m.PinnedRates != nil {
+		cachedSingularMessages[8], err = m.PinnedRates.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field PinnedRates: %w", err)
+		}
+	}
+
+	// Cache field 9 (Adjustments): singular message
+	if m.Adjustments != nil {
+		cachedSingularMessages[9], err = m.Adjustments.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Adjustments: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 6 (Conversions): repeated message
+	cachedRepeatedMessages[6] = make([][]byte, len(m.Conversions))
+	for i, item := range m.Conversions {
+		if item != nil {
+			cachedRepeatedMessages[6][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Conversions[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Items): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (Shipping): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// Field 3 (Tax): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// Field 4 (Discount): nested message
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[4])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[4])
+
+	// Field 5 (Total): nested message
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[5])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[5])
+
+	// Field 6 (Conversions): nested message
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[6] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 7 (GiftWrap): nested message
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[7])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[7])
+
+	// Field 8 (PinnedRates): nested message
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[8])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[8])
+
+	// Field 9 (Adjustments): nested message
+	buf = append(buf, byte(9))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[9])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[9])
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Items)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write nested message field (Shipping)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write nested message field (Tax)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	// Write nested message field (Discount)
+	buf = append(buf, cachedSingularMessages[4]...)
+
+	// Write nested message field (Total)
+	buf = append(buf, cachedSingularMessages[5]...)
+
+	// Write nested message field (Conversions)
+	for _, item := range cachedRepeatedMessages[6] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write nested message field (GiftWrap)
+	buf = append(buf, cachedSingularMessages[7]...)
+
+	// Write nested message field (PinnedRates)
+	buf = append(buf, cachedSingularMessages[8]...)
+
+	// Write nested message field (Adjustments)
+	buf = append(buf, cachedSingularMessages[9]...)
+
+	return buf, nil
+}
+
+func (m *OrderBreakdown) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 10 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+9]
+	offset += 9
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 45
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 9; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Items
+			// Unmarshal nested message field (Items)
+			if entry, ok := offsets[1]; ok {
+				if entry.length == 0 {
+					m.Items = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Items == nil {
+						m.Items = &Money{}
+					}
+					if err := m.Items.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Shipping
+			// Unmarshal nested message field (Shipping)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Shipping = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Shipping == nil {
+						m.Shipping = &Money{}
+					}
+					if err := m.Shipping.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // Tax
+			// Unmarshal nested message field (Tax)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.Tax = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Tax == nil {
+						m.Tax = &Money{}
+					}
+					if err := m.Tax.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // Discount
+			// Unmarshal nested message field (Discount)
+			if entry, ok := offsets[4]; ok {
+				if entry.length == 0 {
+					m.Discount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Discount == nil {
+						m.Discount = &Money{}
+					}
+					if err := m.Discount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 5: // Total
+			// Unmarshal nested message field (Total)
+			if entry, ok := offsets[5]; ok {
+				if entry.length == 0 {
+					m.Total = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Total == nil {
+						m.Total = &Money{}
+					}
+					if err := m.Total.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 6: // Conversions
+			// Unmarshal nested message field (Conversions)
+			if entry, ok := offsets[6]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Conversions = make([]*AppliedConversion, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Conversions = append(m.Conversions, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &AppliedConversion{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Conversions = append(m.Conversions, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 7: // GiftWrap
+			// Unmarshal nested message field (GiftWrap)
+			if entry, ok := offsets[7]; ok {
+				if entry.length == 0 {
+					m.GiftWrap = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.GiftWrap == nil {
+						m.GiftWrap = &Money{}
+					}
+					if err := m.GiftWrap.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 8: // PinnedRates
+			// Unmarshal nested message field (PinnedRates)
+			if entry, ok := offsets[8]; ok {
+				if entry.length == 0 {
+					m.PinnedRates = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.PinnedRates == nil {
+						m.PinnedRates = &PinnedRates{}
+					}
+					if err := m.PinnedRates.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 9: // Adjustments
+			// Unmarshal nested message field (Adjustments)
+			if entry, ok := offsets[9]; ok {
+				if entry.length == 0 {
+					m.Adjustments = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Adjustments == nil {
+						m.Adjustments = &PriceAdjustments{}
+					}
+					if err := m.Adjustments.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PriceAdjustment) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 278)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 5 (Amount): singular message
+	if m.Amount != nil {
+		cachedSingularMessages[5], err = m.Amount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Amount: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (RuleId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of RuleId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.RuleId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.RuleId)
+
+	// Field 2 (Kind): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Kind
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Kind)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Kind)
+
+	// Field 3 (Description): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Description
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Description)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Description)
+
+	// Field 4 (ProductId): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ProductId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ProductId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ProductId)
+
+	// Field 5 (Amount): nested message
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[5])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[5])
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (RuleId)
+	buf = append(buf, []byte(m.RuleId)...)
+
+	// Write string or bytes field (Kind)
+	buf = append(buf, []byte(m.Kind)...)
+
+	// Write string or bytes field (Description)
+	buf = append(buf, []byte(m.Description)...)
+
+	// Write string or bytes field (ProductId)
+	buf = append(buf, []byte(m.ProductId)...)
+
+	// Write nested message field (Amount)
+	buf = append(buf, cachedSingularMessages[5]...)
+
+	return buf, nil
+}
+
+func (m *PriceAdjustment) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 25
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 5; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // RuleId
+			// Unmarshal string or []byte field (RuleId)
+			if entry, ok := offsets[1]; ok {
+				m.RuleId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Kind
+			// Unmarshal string or []byte field (Kind)
+			if entry, ok := offsets[2]; ok {
+				m.Kind = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Description
+			// Unmarshal string or []byte field (Description)
+			if entry, ok := offsets[3]; ok {
+				m.Description = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // ProductId
+			// Unmarshal string or []byte field (ProductId)
+			if entry, ok := offsets[4]; ok {
+				m.ProductId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // Amount
+			// Unmarshal nested message field (Amount)
+			if entry, ok := offsets[5]; ok {
+				if entry.length == 0 {
+					m.Amount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Amount == nil {
+						m.Amount = &Money{}
+					}
+					if err := m.Amount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PriceAdjustments) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Adjustments): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Adjustments))
+	for i, item := range m.Adjustments {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Adjustments[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Adjustments): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Adjustments)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *PriceAdjustments) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Adjustments
+			// Unmarshal nested message field (Adjustments)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Adjustments = make([]*PriceAdjustment, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Adjustments = append(m.Adjustments, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &PriceAdjustment{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Adjustments = append(m.Adjustments, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PinnedRate) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 155)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (FromCode): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of FromCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.FromCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.FromCode)
+
+	// Field 2 (ToCode): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ToCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ToCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ToCode)
+
+	// Field 3 (Rate): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Rate
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Rate)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Rate)
+
+	offset += 8 // PinnedAt
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (FromCode)
+	buf = append(buf, []byte(m.FromCode)...)
+
+	// Write string or bytes field (ToCode)
+	buf = append(buf, []byte(m.ToCode)...)
+
+	// Write string or bytes field (Rate)
+	buf = append(buf, []byte(m.Rate)...)
+
+	// Write fixed field (PinnedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.PinnedAt))
+	buf = append(buf, temp[:8]...)
+
+	return buf, nil
+}
+
+func (m *PinnedRate) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 15
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 3; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // FromCode
+			// Unmarshal string or []byte field (FromCode)
+			if entry, ok := offsets[1]; ok {
+				m.FromCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // ToCode
+			// Unmarshal string or []byte field (ToCode)
+			if entry, ok := offsets[2]; ok {
+				m.ToCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Rate
+			// Unmarshal string or []byte field (Rate)
+			if entry, ok := offsets[3]; ok {
+				m.Rate = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // PinnedAt
+			// Unmarshal fixed field (PinnedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.PinnedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		}
+	}
+
+	return nil
+}
+
+func (m *PinnedRates) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 88)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Rates): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Rates))
+	for i, item := range m.Rates {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Rates[%d]: %w", i, err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Rates): nested message
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// === DATA REGION SECTION ===
+
+	// Write nested message field (Rates)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	return buf, nil
+}
+
+func (m *PinnedRates) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Rates
+			// Unmarshal nested message field (Rates)
+			if entry, ok := offsets[1]; ok {
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Rates = make([]*PinnedRate, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Rates = append(m.Rates, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &PinnedRate{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Rates = append(m.Rates, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *AppliedConversion) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 271)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (From): singular message
+	if m.From != nil {
+		cachedSingularMessages[2], err = m.From.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field From: %w", err)
+		}
+	}
+
+	// Cache field 3 (To): singular message
+	if m.To != nil {
+		cachedSingularMessages[3], err = m.To.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field To: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Component): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Component
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Component)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Component)
+
+	// Field 2 (From): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// Field 3 (To): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// Field 4 (Rate): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Rate
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Rate)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Rate)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Component)
+	buf = append(buf, []byte(m.Component)...)
+
+	// Write nested message field (From)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write nested message field (To)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	// Write string or bytes field (Rate)
+	buf = append(buf, []byte(m.Rate)...)
+
+	return buf, nil
+}
+
+func (m *AppliedConversion) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Component
+			// Unmarshal string or []byte field (Component)
+			if entry, ok := offsets[1]; ok {
+				m.Component = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // From
+			// Unmarshal nested message field (From)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.From = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.From == nil {
+						m.From = &Money{}
+					}
+					if err := m.From.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // To
+			// Unmarshal nested message field (To)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.To = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.To == nil {
+						m.To = &Money{}
+					}
+					if err := m.To.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // Rate
+			// Unmarshal string or []byte field (Rate)
+			if entry, ok := offsets[4]; ok {
+				m.Rate = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *SendOrderConfirmationRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 231)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (Order): singular message
+	if m.Order != nil {
+		cachedSingularMessages[2], err = m.Order.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Order: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Email): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 2 (Order): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// Field 3 (Locale): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// Field 4 (DedupeKey): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of DedupeKey
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.DedupeKey)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.DedupeKey)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write nested message field (Order)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write string or bytes field (DedupeKey)
+	buf = append(buf, []byte(m.DedupeKey)...)
+
+	return buf, nil
+}
+
+func (m *SendOrderConfirmationRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 20
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 4; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[1]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Order
+			// Unmarshal nested message field (Order)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Order = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Order == nil {
+						m.Order = &OrderResult{}
+					}
+					if err := m.Order.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[3]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // DedupeKey
+			// Unmarshal string or []byte field (DedupeKey)
+			if entry, ok := offsets[4]; ok {
+				m.DedupeKey = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *CampaignSegment) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 61)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Locale): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	offset += 4 // MinOrders
+
+	offset += 4 // OrderedWithinDays
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write fixed field (MinOrders)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.MinOrders))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (OrderedWithinDays)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.OrderedWithinDays))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *CampaignSegment) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[1]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // MinOrders
+			// Unmarshal fixed field (MinOrders)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.MinOrders = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 3: // OrderedWithinDays
+			// Unmarshal fixed field (OrderedWithinDays)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.OrderedWithinDays = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *SendCampaignRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 278)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 3 (Segment): singular message
+	if m.Segment != nil {
+		cachedSingularMessages[3], err = m.Segment.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Segment: %w", err)
+		}
+	}
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (CampaignId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CampaignId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CampaignId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CampaignId)
+
+	// Field 2 (Template): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Template
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Template)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Template)
+
+	// Field 3 (Segment): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// Field 4 (TestEmail): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TestEmail
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TestEmail)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TestEmail)
+
+	// Field 5 (TestLocale): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TestLocale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TestLocale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TestLocale)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (CampaignId)
+	buf = append(buf, []byte(m.CampaignId)...)
+
+	// Write string or bytes field (Template)
+	buf = append(buf, []byte(m.Template)...)
+
+	// Write nested message field (Segment)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	// Write string or bytes field (TestEmail)
+	buf = append(buf, []byte(m.TestEmail)...)
+
+	// Write string or bytes field (TestLocale)
+	buf = append(buf, []byte(m.TestLocale)...)
+
+	return buf, nil
+}
+
+func (m *SendCampaignRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 6 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+5]
+	offset += 5
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 25
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 5; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // CampaignId
+			// Unmarshal string or []byte field (CampaignId)
+			if entry, ok := offsets[1]; ok {
+				m.CampaignId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Template
+			// Unmarshal string or []byte field (Template)
+			if entry, ok := offsets[2]; ok {
+				m.Template = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Segment
+			// Unmarshal nested message field (Segment)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.Segment = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Segment == nil {
+						m.Segment = &CampaignSegment{}
+					}
+					if err := m.Segment.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // TestEmail
+			// Unmarshal string or []byte field (TestEmail)
+			if entry, ok := offsets[4]; ok {
+				m.TestEmail = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // TestLocale
+			// Unmarshal string or []byte field (TestLocale)
+			if entry, ok := offsets[5]; ok {
+				m.TestLocale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *CampaignResult) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 67)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 4}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (CampaignId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CampaignId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CampaignId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CampaignId)
+
+	offset += 4 // Matched
+
+	offset += 4 // Suppressed
+
+	offset += 4 // Queued
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (CampaignId)
+	buf = append(buf, []byte(m.CampaignId)...)
+
+	// Write fixed field (Matched)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Matched))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (Suppressed)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Suppressed))
+	buf = append(buf, temp[:4]...)
+
+	// Write fixed field (Queued)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Queued))
+	buf = append(buf, temp[:4]...)
+
+	return buf, nil
+}
+
+func (m *CampaignResult) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 5 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+4]
+	offset += 4
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // CampaignId
+			// Unmarshal string or []byte field (CampaignId)
+			if entry, ok := offsets[1]; ok {
+				m.CampaignId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Matched
+			// Unmarshal fixed field (Matched)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Matched = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 3: // Suppressed
+			// Unmarshal fixed field (Suppressed)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Suppressed = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 4: // Queued
+			// Unmarshal fixed field (Queued)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Queued = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		}
+	}
+
+	return nil
+}
+
+func (m *UnsubscribeRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Email): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	return buf, nil
+}
+
+func (m *UnsubscribeRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
 
-	// Field 6 (Categories): repeated variable-length
-	buf = append(buf, byte(6))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Categories
-	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range m.Categories {
-		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[1]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
 	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
-	buf = append(buf, temp[:2]...)
-	offset += totalLen
 
-	// === DATA REGION SECTION ===
+	return nil
+}
 
-	// Write string or bytes field (Id)
-	buf = append(buf, []byte(m.Id)...)
+func (m *GetReceiptRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
 
-	// Write string or bytes field (Name)
-	buf = append(buf, []byte(m.Name)...)
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
 
-	// Write string or bytes field (Description)
-	buf = append(buf, []byte(m.Description)...)
+	// === OFFSET TABLE SECTION ===
+	offset := 0
 
-	// Write string or bytes field (Picture)
-	buf = append(buf, []byte(m.Picture)...)
+	// Field 1 (OrderId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
 
-	// Write nested message field (PriceUsd)
-	buf = append(buf, cachedSingularMessages[5]...)
+	// === DATA REGION SECTION ===
 
-	// Write repeated variable-length field (Categories)
-	for _, item := range m.Categories {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, []byte(item)...)
-	}
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
 
 	return buf, nil
 }
 
-func (m *Product) UnmarshalSymphony(data []byte) error {
+func (m *GetReceiptRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 7 {
+	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+6]
-	offset += 6
+	fieldOrder := data[offset : offset+1]
+	offset += 1
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 30
+	offsetTableSize := 5
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 6; i++ {
+	for i := 0; i < 1; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -943,69 +14648,10 @@ func (m *Product) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Id
-			// Unmarshal string or []byte field (Id)
+		case 1: // OrderId
+			// Unmarshal string or []byte field (OrderId)
 			if entry, ok := offsets[1]; ok {
-				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 2: // Name
-			// Unmarshal string or []byte field (Name)
-			if entry, ok := offsets[2]; ok {
-				m.Name = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 3: // Description
-			// Unmarshal string or []byte field (Description)
-			if entry, ok := offsets[3]; ok {
-				m.Description = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 4: // Picture
-			// Unmarshal string or []byte field (Picture)
-			if entry, ok := offsets[4]; ok {
-				m.Picture = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 5: // PriceUsd
-			// Unmarshal nested message field (PriceUsd)
-			if entry, ok := offsets[5]; ok {
-				if entry.length == 0 {
-					m.PriceUsd = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.PriceUsd == nil {
-						m.PriceUsd = &Money{}
-					}
-					if err := m.PriceUsd.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
-				dataOffset += int(entry.length)
-			}
-		case 6: // Categories
-			// Unmarshal repeated variable-length field (Categories)
-			if entry, ok := offsets[6]; ok {
-				m.Categories = make([]string, 0)
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.Categories = append(m.Categories, "")
-						continue
-					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item data")
-					}
-					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					m.Categories = append(m.Categories, string(itemData))
-				}
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -1014,58 +14660,35 @@ func (m *Product) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *ListProductsResponse) MarshalSymphony() ([]byte, error) {
+func (m *GetReceiptResponse) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 88)
+	buf := make([]byte, 0, 48)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
 	buf = append(buf, []byte{1}...)
 
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
-
-	var err error
-	cachedRepeatedMessages := make(map[byte][][]byte)
-	// Cache field 1 (Products): repeated message
-	cachedRepeatedMessages[1] = make([][]byte, len(m.Products))
-	for i, item := range m.Products {
-		if item != nil {
-			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal repeated message field Products[%d]: %w", i, err)
-		}
-	}
-
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Products): nested message
+	// Field 1 (Pdf): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Pdf
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range cachedRepeatedMessages[1] {
-		totalLen += 4 + len(item) // 4 bytes for length + message data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Pdf)))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(m.Pdf)
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (Products)
-	for _, item := range cachedRepeatedMessages[1] {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, item...)
-	}
+	// Write string or bytes field (Pdf)
+	buf = append(buf, []byte(m.Pdf)...)
 
 	return buf, nil
 }
 
-func (m *ListProductsResponse) UnmarshalSymphony(data []byte) error {
+func (m *GetReceiptResponse) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
@@ -1100,33 +14723,10 @@ func (m *ListProductsResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Products
-			// Unmarshal nested message field (Products)
+		case 1: // Pdf
+			// Unmarshal string or []byte field (Pdf)
 			if entry, ok := offsets[1]; ok {
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				m.Products = make([]*Product, 0)
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.Products = append(m.Products, nil)
-						continue
-					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item bytes")
-					}
-					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					newItem := &Product{}
-					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
-						return fmt.Errorf("failed to unmarshal nested message: %w", err)
-					}
-					m.Products = append(m.Products, newItem)
-				}
+				m.Pdf = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -1135,7 +14735,7 @@ func (m *ListProductsResponse) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *GetProductRequest) MarshalSymphony() ([]byte, error) {
+func (m *GetOrderStatusRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
 	buf := make([]byte, 0, 48)
 	var temp [8]byte // Reusable temp buffer for encoding
@@ -1147,23 +14747,23 @@ func (m *GetProductRequest) MarshalSymphony() ([]byte, error) {
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Id): string or bytes
+	// Field 1 (OrderId): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Id)
+	offset += len(m.OrderId)
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (Id)
-	buf = append(buf, []byte(m.Id)...)
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
 
 	return buf, nil
 }
 
-func (m *GetProductRequest) UnmarshalSymphony(data []byte) error {
+func (m *GetOrderStatusRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
@@ -1198,10 +14798,10 @@ func (m *GetProductRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Id
-			// Unmarshal string or []byte field (Id)
+		case 1: // OrderId
+			// Unmarshal string or []byte field (OrderId)
 			if entry, ok := offsets[1]; ok {
-				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -1210,54 +14810,116 @@ func (m *GetProductRequest) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *SearchProductsRequest) MarshalSymphony() ([]byte, error) {
+func (m *OrderStatus) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 48)
+	buf := make([]byte, 0, 202)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 6 (RefundedAmount): singular message
+	if m.RefundedAmount != nil {
+		cachedSingularMessages[6], err = m.RefundedAmount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field RefundedAmount: %w", err)
+		}
+	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Query): string or bytes
+	// Field 1 (OrderId): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Query
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 2 (Status): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	// Field 3 (TransactionId): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TransactionId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TransactionId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TransactionId)
+
+	// Field 4 (Reason): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Reason
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Reason)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Reason)
+
+	offset += 8 // UpdatedAt
+
+	// Field 6 (RefundedAmount): nested message
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Query)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[6])))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Query)
+	offset += len(cachedSingularMessages[6])
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (Query)
-	buf = append(buf, []byte(m.Query)...)
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	// Write string or bytes field (TransactionId)
+	buf = append(buf, []byte(m.TransactionId)...)
+
+	// Write string or bytes field (Reason)
+	buf = append(buf, []byte(m.Reason)...)
+
+	// Write fixed field (UpdatedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.UpdatedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write nested message field (RefundedAmount)
+	buf = append(buf, cachedSingularMessages[6]...)
 
 	return buf, nil
 }
 
-func (m *SearchProductsRequest) UnmarshalSymphony(data []byte) error {
+func (m *OrderStatus) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 2 {
+	if len(data) < 7 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+1]
-	offset += 1
+	fieldOrder := data[offset : offset+6]
+	offset += 6
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 25
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 5; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -1273,10 +14935,51 @@ func (m *SearchProductsRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Query
-			// Unmarshal string or []byte field (Query)
+		case 1: // OrderId
+			// Unmarshal string or []byte field (OrderId)
 			if entry, ok := offsets[1]; ok {
-				m.Query = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[2]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // TransactionId
+			// Unmarshal string or []byte field (TransactionId)
+			if entry, ok := offsets[3]; ok {
+				m.TransactionId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Reason
+			// Unmarshal string or []byte field (Reason)
+			if entry, ok := offsets[4]; ok {
+				m.Reason = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // UpdatedAt
+			// Unmarshal fixed field (UpdatedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.UpdatedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 6: // RefundedAmount
+			// Unmarshal nested message field (RefundedAmount)
+			if entry, ok := offsets[6]; ok {
+				if entry.length == 0 {
+					m.RefundedAmount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.RefundedAmount == nil {
+						m.RefundedAmount = &Money{}
+					}
+					if err := m.RefundedAmount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
 				dataOffset += int(entry.length)
 			}
 		}
@@ -1285,77 +14988,132 @@ func (m *SearchProductsRequest) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *SearchProductsResponse) MarshalSymphony() ([]byte, error) {
+func (m *OrderStatusChanged) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 88)
+	buf := make([]byte, 0, 373)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7}...)
 
 	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
 
 	var err error
-	cachedRepeatedMessages := make(map[byte][][]byte)
-	// Cache field 1 (Results): repeated message
-	cachedRepeatedMessages[1] = make([][]byte, len(m.Results))
-	for i, item := range m.Results {
-		if item != nil {
-			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
-		}
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 1 (Status): singular message
+	if m.Status != nil {
+		cachedSingularMessages[1], err = m.Status.MarshalSymphony()
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal repeated message field Results[%d]: %w", i, err)
+			return nil, fmt.Errorf("failed to marshal singular message field Status: %w", err)
 		}
 	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Results): nested message
+	// Field 1 (Status): nested message
 	buf = append(buf, byte(1))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range cachedRepeatedMessages[1] {
-		totalLen += 4 + len(item) // 4 bytes for length + message data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(cachedSingularMessages[1])
+
+	// Field 2 (PreviousStatus): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of PreviousStatus
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.PreviousStatus)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.PreviousStatus)
+
+	// Field 3 (Email): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 4 (Locale): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// Field 5 (UserId): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// Field 6 (Tenant): string or bytes
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Tenant
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Tenant)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Tenant)
+
+	// Field 7 (ReferralCode): string or bytes
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ReferralCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ReferralCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ReferralCode)
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (Results)
-	for _, item := range cachedRepeatedMessages[1] {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, item...)
-	}
+	// Write nested message field (Status)
+	buf = append(buf, cachedSingularMessages[1]...)
+
+	// Write string or bytes field (PreviousStatus)
+	buf = append(buf, []byte(m.PreviousStatus)...)
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write string or bytes field (Tenant)
+	buf = append(buf, []byte(m.Tenant)...)
+
+	// Write string or bytes field (ReferralCode)
+	buf = append(buf, []byte(m.ReferralCode)...)
 
 	return buf, nil
 }
 
-func (m *SearchProductsResponse) UnmarshalSymphony(data []byte) error {
+func (m *OrderStatusChanged) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 2 {
+	if len(data) < 8 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+1]
-	offset += 1
+	fieldOrder := data[offset : offset+7]
+	offset += 7
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 35
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 7; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -1371,132 +15129,292 @@ func (m *SearchProductsResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Results
-			// Unmarshal nested message field (Results)
+		case 1: // Status
+			// Unmarshal nested message field (Status)
 			if entry, ok := offsets[1]; ok {
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				m.Results = make([]*Product, 0)
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.Results = append(m.Results, nil)
-						continue
-					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item bytes")
+				if entry.length == 0 {
+					m.Status = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Status == nil {
+						m.Status = &OrderStatus{}
 					}
-					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					newItem := &Product{}
-					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
-						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					if err := m.Status.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
 					}
-					m.Results = append(m.Results, newItem)
 				}
 				dataOffset += int(entry.length)
 			}
+		case 2: // PreviousStatus
+			// Unmarshal string or []byte field (PreviousStatus)
+			if entry, ok := offsets[2]; ok {
+				m.PreviousStatus = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[3]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[4]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // UserId
+			// Unmarshal string or []byte field (UserId)
+			if entry, ok := offsets[5]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // Tenant
+			// Unmarshal string or []byte field (Tenant)
+			if entry, ok := offsets[6]; ok {
+				m.Tenant = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 7: // ReferralCode
+			// Unmarshal string or []byte field (ReferralCode)
+			if entry, ok := offsets[7]; ok {
+				m.ReferralCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *PlaceOrderRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 780)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1, 2, 3, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 3 (Address): singular message
+	if m.Address != nil {
+		cachedSingularMessages[3], err = m.Address.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+		}
+	}
+
+	// Cache field 6 (CreditCard): singular message
+	if m.CreditCard != nil {
+		cachedSingularMessages[6], err = m.CreditCard.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field CreditCard: %w", err)
+		}
+	}
+
+	// Cache field 11 (DeliveryWindow): singular message
+	if m.DeliveryWindow != nil {
+		cachedSingularMessages[11], err = m.DeliveryWindow.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field DeliveryWindow: %w", err)
+		}
+	}
+
+	// Cache field 13 (WalletAmount): singular message
+	if m.WalletAmount != nil {
+		cachedSingularMessages[13], err = m.WalletAmount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field WalletAmount: %w", err)
+		}
+	}
+
+	// Cache field 14 (Consent): singular message
+	if m.Consent != nil {
+		cachedSingularMessages[14], err = m.Consent.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Consent: %w", err)
 		}
 	}
 
-	return nil
-}
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (UserId): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserId)
+
+	// Field 2 (UserCurrency): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserCurrency
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserCurrency)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.UserCurrency)
+
+	// Field 3 (Address): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// Field 5 (Email): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 6 (CreditCard): nested message
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[6])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[6])
 
-func (m *GetQuoteRequest) MarshalSymphony() ([]byte, error) {
-	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 176)
-	var temp [8]byte // Reusable temp buffer for encoding
+	// Field 7 (Locale): string or bytes
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
 
-	// === HEADER SECTION ===
-	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2}...)
+	// Field 8 (ExpectedCartHash): string or bytes
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ExpectedCartHash
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ExpectedCartHash)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ExpectedCartHash)
 
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+	offset += 1 // GiftWrap
 
-	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 1 (Address): singular message
-	if m.Address != nil {
-		cachedSingularMessages[1], err = m.Address.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
-		}
-	}
+	// Field 10 (Note): string or bytes
+	buf = append(buf, byte(10))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Note
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Note)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Note)
 
-	cachedRepeatedMessages := make(map[byte][][]byte)
-	// Cache field 2 (Items): repeated message
-	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
-	for i, item := range m.Items {
-		if item != nil {
-			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
-		}
-	}
+	// Field 11 (DeliveryWindow): nested message
+	buf = append(buf, byte(11))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[11])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[11])
 
-	// === OFFSET TABLE SECTION ===
-	offset := 0
+	offset += 4 // Installments
 
-	// Field 1 (Address): nested message
-	buf = append(buf, byte(1))
+	// Field 13 (WalletAmount): nested message
+	buf = append(buf, byte(13))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[13])))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[1])
+	offset += len(cachedSingularMessages[13])
 
-	// Field 2 (Items): nested message
-	buf = append(buf, byte(2))
+	// Field 14 (Consent): nested message
+	buf = append(buf, byte(14))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range cachedRepeatedMessages[2] {
-		totalLen += 4 + len(item) // 4 bytes for length + message data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[14])))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(cachedSingularMessages[14])
+
+	// Field 15 (ReferralCode): string or bytes
+	buf = append(buf, byte(15))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ReferralCode
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ReferralCode)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.ReferralCode)
 
 	// === DATA REGION SECTION ===
 
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write string or bytes field (UserCurrency)
+	buf = append(buf, []byte(m.UserCurrency)...)
+
 	// Write nested message field (Address)
-	buf = append(buf, cachedSingularMessages[1]...)
+	buf = append(buf, cachedSingularMessages[3]...)
 
-	// Write nested message field (Items)
-	for _, item := range cachedRepeatedMessages[2] {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, item...)
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write nested message field (CreditCard)
+	buf = append(buf, cachedSingularMessages[6]...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write string or bytes field (ExpectedCartHash)
+	buf = append(buf, []byte(m.ExpectedCartHash)...)
+
+	// Write fixed field (GiftWrap)
+	if m.GiftWrap {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
 	}
 
+	// Write string or bytes field (Note)
+	buf = append(buf, []byte(m.Note)...)
+
+	// Write nested message field (DeliveryWindow)
+	buf = append(buf, cachedSingularMessages[11]...)
+
+	// Write fixed field (Installments)
+	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Installments))
+	buf = append(buf, temp[:4]...)
+
+	// Write nested message field (WalletAmount)
+	buf = append(buf, cachedSingularMessages[13]...)
+
+	// Write nested message field (Consent)
+	buf = append(buf, cachedSingularMessages[14]...)
+
+	// Write string or bytes field (ReferralCode)
+	buf = append(buf, []byte(m.ReferralCode)...)
+
 	return buf, nil
 }
 
-func (m *GetQuoteRequest) UnmarshalSymphony(data []byte) error {
+func (m *PlaceOrderRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 3 {
+	if len(data) < 15 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+2]
-	offset += 2
+	fieldOrder := data[offset : offset+14]
+	offset += 14
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 10
+	offsetTableSize := 60
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 2; i++ {
+	for i := 0; i < 12; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -1512,9 +15430,21 @@ func (m *GetQuoteRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Address
-			// Unmarshal nested message field (Address)
+		case 1: // UserId
+			// Unmarshal string or []byte field (UserId)
 			if entry, ok := offsets[1]; ok {
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // UserCurrency
+			// Unmarshal string or []byte field (UserCurrency)
+			if entry, ok := offsets[2]; ok {
+				m.UserCurrency = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Address
+			// Unmarshal nested message field (Address)
+			if entry, ok := offsets[3]; ok {
 				if entry.length == 0 {
 					m.Address = nil
 				} else {
@@ -1528,92 +15458,171 @@ func (m *GetQuoteRequest) UnmarshalSymphony(data []byte) error {
 				}
 				dataOffset += int(entry.length)
 			}
-		case 2: // Items
-			// Unmarshal nested message field (Items)
-			if entry, ok := offsets[2]; ok {
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				m.Items = make([]*CartItem, 0)
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
+		case 5: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[5]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // CreditCard
+			// Unmarshal nested message field (CreditCard)
+			if entry, ok := offsets[6]; ok {
+				if entry.length == 0 {
+					m.CreditCard = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.CreditCard == nil {
+						m.CreditCard = &CreditCardInfo{}
 					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.Items = append(m.Items, nil)
-						continue
+					if err := m.CreditCard.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
 					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item bytes")
+				}
+				dataOffset += int(entry.length)
+			}
+		case 7: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[7]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 8: // ExpectedCartHash
+			// Unmarshal string or []byte field (ExpectedCartHash)
+			if entry, ok := offsets[8]; ok {
+				m.ExpectedCartHash = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 9: // GiftWrap
+			// Unmarshal fixed field (GiftWrap)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.GiftWrap = dataRegion[dataOffset] != 0
+			dataOffset += 1
+		case 10: // Note
+			// Unmarshal string or []byte field (Note)
+			if entry, ok := offsets[10]; ok {
+				m.Note = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 11: // DeliveryWindow
+			// Unmarshal nested message field (DeliveryWindow)
+			if entry, ok := offsets[11]; ok {
+				if entry.length == 0 {
+					m.DeliveryWindow = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.DeliveryWindow == nil {
+						m.DeliveryWindow = &DeliveryWindow{}
 					}
-					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					newItem := &CartItem{}
-					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
-						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					if err := m.DeliveryWindow.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 12: // Installments
+			// Unmarshal fixed field (Installments)
+			if dataOffset+4 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Installments = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
+			dataOffset += 4
+		case 13: // WalletAmount
+			// Unmarshal nested message field (WalletAmount)
+			if entry, ok := offsets[13]; ok {
+				if entry.length == 0 {
+					m.WalletAmount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.WalletAmount == nil {
+						m.WalletAmount = &Money{}
+					}
+					if err := m.WalletAmount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 14: // Consent
+			// Unmarshal nested message field (Consent)
+			if entry, ok := offsets[14]; ok {
+				if entry.length == 0 {
+					m.Consent = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Consent == nil {
+						m.Consent = &Consent{}
+					}
+					if err := m.Consent.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
 					}
-					m.Items = append(m.Items, newItem)
 				}
 				dataOffset += int(entry.length)
 			}
+		case 15: // ReferralCode
+			// Unmarshal string or []byte field (ReferralCode)
+			if entry, ok := offsets[15]; ok {
+				m.ReferralCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
 		}
 	}
 
 	return nil
 }
 
-func (m *GetQuoteResponse) MarshalSymphony() ([]byte, error) {
+func (m *Consent) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 88)
+	buf := make([]byte, 0, 71)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
-
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
-
-	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 1 (CostUsd): singular message
-	if m.CostUsd != nil {
-		cachedSingularMessages[1], err = m.CostUsd.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field CostUsd: %w", err)
-		}
-	}
+	buf = append(buf, []byte{1, 2, 3}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (CostUsd): nested message
+	// Field 1 (TermsVersion): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TermsVersion
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TermsVersion)))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[1])
+	offset += len(m.TermsVersion)
+
+	offset += 8 // TermsAcceptedAt
+
+	offset += 8 // AgeConfirmedAt
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (CostUsd)
-	buf = append(buf, cachedSingularMessages[1]...)
+	// Write string or bytes field (TermsVersion)
+	buf = append(buf, []byte(m.TermsVersion)...)
+
+	// Write fixed field (TermsAcceptedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.TermsAcceptedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (AgeConfirmedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.AgeConfirmedAt))
+	buf = append(buf, temp[:8]...)
 
 	return buf, nil
 }
 
-func (m *GetQuoteResponse) UnmarshalSymphony(data []byte) error {
+func (m *Consent) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 2 {
+	if len(data) < 4 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+1]
-	offset += 1
+	fieldOrder := data[offset : offset+3]
+	offset += 3
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
@@ -1638,29 +15647,33 @@ func (m *GetQuoteResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // CostUsd
-			// Unmarshal nested message field (CostUsd)
+		case 1: // TermsVersion
+			// Unmarshal string or []byte field (TermsVersion)
 			if entry, ok := offsets[1]; ok {
-				if entry.length == 0 {
-					m.CostUsd = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.CostUsd == nil {
-						m.CostUsd = &Money{}
-					}
-					if err := m.CostUsd.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
+				m.TermsVersion = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
+		case 2: // TermsAcceptedAt
+			// Unmarshal fixed field (TermsAcceptedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.TermsAcceptedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 3: // AgeConfirmedAt
+			// Unmarshal fixed field (AgeConfirmedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.AgeConfirmedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
 		}
 	}
 
 	return nil
 }
 
-func (m *ShipOrderRequest) MarshalSymphony() ([]byte, error) {
+func (m *PlaceOrderResponse) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
 	buf := make([]byte, 0, 176)
 	var temp [8]byte // Reusable temp buffer for encoding
@@ -1673,30 +15686,26 @@ func (m *ShipOrderRequest) MarshalSymphony() ([]byte, error) {
 
 	var err error
 	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 1 (Address): singular message
-	if m.Address != nil {
-		cachedSingularMessages[1], err = m.Address.MarshalSymphony()
+	// Cache field 1 (Order): singular message
+	if m.Order != nil {
+		cachedSingularMessages[1], err = m.Order.MarshalSymphony()
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
+			return nil, fmt.Errorf("failed to marshal singular message field Order: %w", err)
 		}
 	}
 
-	cachedRepeatedMessages := make(map[byte][][]byte)
-	// Cache field 2 (Items): repeated message
-	cachedRepeatedMessages[2] = make([][]byte, len(m.Items))
-	for i, item := range m.Items {
-		if item != nil {
-			cachedRepeatedMessages[2][i], err = item.MarshalSymphony()
-		}
+	// Cache field 2 (Total): singular message
+	if m.Total != nil {
+		cachedSingularMessages[2], err = m.Total.MarshalSymphony()
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+			return nil, fmt.Errorf("failed to marshal singular message field Total: %w", err)
 		}
 	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Address): nested message
+	// Field 1 (Order): nested message
 	buf = append(buf, byte(1))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
@@ -1704,34 +15713,26 @@ func (m *ShipOrderRequest) MarshalSymphony() ([]byte, error) {
 	buf = append(buf, temp[:2]...)
 	offset += len(cachedSingularMessages[1])
 
-	// Field 2 (Items): nested message
+	// Field 2 (Total): nested message
 	buf = append(buf, byte(2))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range cachedRepeatedMessages[2] {
-		totalLen += 4 + len(item) // 4 bytes for length + message data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(cachedSingularMessages[2])
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (Address)
+	// Write nested message field (Order)
 	buf = append(buf, cachedSingularMessages[1]...)
 
-	// Write nested message field (Items)
-	for _, item := range cachedRepeatedMessages[2] {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, item...)
-	}
+	// Write nested message field (Total)
+	buf = append(buf, cachedSingularMessages[2]...)
 
 	return buf, nil
 }
 
-func (m *ShipOrderRequest) UnmarshalSymphony(data []byte) error {
+func (m *PlaceOrderResponse) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
@@ -1766,48 +15767,35 @@ func (m *ShipOrderRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Address
-			// Unmarshal nested message field (Address)
+		case 1: // Order
+			// Unmarshal nested message field (Order)
 			if entry, ok := offsets[1]; ok {
 				if entry.length == 0 {
-					m.Address = nil
+					m.Order = nil
 				} else {
 					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.Address == nil {
-						m.Address = &Address{}
+					if m.Order == nil {
+						m.Order = &OrderResult{}
 					}
-					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
+					if err := m.Order.UnmarshalSymphony(fieldData); err != nil {
 						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
 					}
 				}
 				dataOffset += int(entry.length)
 			}
-		case 2: // Items
-			// Unmarshal nested message field (Items)
+		case 2: // Total
+			// Unmarshal nested message field (Total)
 			if entry, ok := offsets[2]; ok {
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				m.Items = make([]*CartItem, 0)
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.Items = append(m.Items, nil)
-						continue
-					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item bytes")
+				if entry.length == 0 {
+					m.Total = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Total == nil {
+						m.Total = &Money{}
 					}
-					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					newItem := &CartItem{}
-					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
-						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					if err := m.Total.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
 					}
-					m.Items = append(m.Items, newItem)
 				}
 				dataOffset += int(entry.length)
 			}
@@ -1817,54 +15805,165 @@ func (m *ShipOrderRequest) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *ShipOrderResponse) MarshalSymphony() ([]byte, error) {
+func (m *OrderPreview) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 48)
+	buf := make([]byte, 0, 486)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (Breakdown): singular message
+	if m.Breakdown != nil {
+		cachedSingularMessages[2], err = m.Breakdown.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field Breakdown: %w", err)
+		}
+	}
+
+	// Cache field 3 (ShippingAddress): singular message
+	if m.ShippingAddress != nil {
+		cachedSingularMessages[3], err = m.ShippingAddress.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field ShippingAddress: %w", err)
+		}
+	}
+
+	// Cache field 5 (WalletAmount): singular message
+	if m.WalletAmount != nil {
+		cachedSingularMessages[5], err = m.WalletAmount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field WalletAmount: %w", err)
+		}
+	}
+
+	// Cache field 6 (CardAmount): singular message
+	if m.CardAmount != nil {
+		cachedSingularMessages[6], err = m.CardAmount.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field CardAmount: %w", err)
+		}
+	}
+
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Items): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Items))
+	for i, item := range m.Items {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
+		}
+	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (TrackingId): string or bytes
+	// Field 1 (Items): nested message
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TrackingId
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TrackingId)))
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.TrackingId)
+	offset += totalLen
+
+	// Field 2 (Breakdown): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[2])
+
+	// Field 3 (ShippingAddress): nested message
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[3])
+
+	// Field 4 (CartHash): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CartHash
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CartHash)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.CartHash)
+
+	// Field 5 (WalletAmount): nested message
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[5])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[5])
+
+	// Field 6 (CardAmount): nested message
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[6])))
+	buf = append(buf, temp[:2]...)
+	offset += len(cachedSingularMessages[6])
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (TrackingId)
-	buf = append(buf, []byte(m.TrackingId)...)
+	// Write nested message field (Items)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
+
+	// Write nested message field (Breakdown)
+	buf = append(buf, cachedSingularMessages[2]...)
+
+	// Write nested message field (ShippingAddress)
+	buf = append(buf, cachedSingularMessages[3]...)
+
+	// Write string or bytes field (CartHash)
+	buf = append(buf, []byte(m.CartHash)...)
+
+	// Write nested message field (WalletAmount)
+	buf = append(buf, cachedSingularMessages[5]...)
+
+	// Write nested message field (CardAmount)
+	buf = append(buf, cachedSingularMessages[6]...)
 
 	return buf, nil
 }
 
-func (m *ShipOrderResponse) UnmarshalSymphony(data []byte) error {
+func (m *OrderPreview) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 2 {
+	if len(data) < 7 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+1]
-	offset += 1
+	fieldOrder := data[offset : offset+6]
+	offset += 6
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 30
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 6; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -1880,10 +15979,103 @@ func (m *ShipOrderResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // TrackingId
-			// Unmarshal string or []byte field (TrackingId)
+		case 1: // Items
+			// Unmarshal nested message field (Items)
 			if entry, ok := offsets[1]; ok {
-				m.TrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Items = make([]*OrderItem, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Items = append(m.Items, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &OrderItem{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Items = append(m.Items, newItem)
+				}
+				dataOffset += int(entry.length)
+			}
+		case 2: // Breakdown
+			// Unmarshal nested message field (Breakdown)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.Breakdown = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.Breakdown == nil {
+						m.Breakdown = &OrderBreakdown{}
+					}
+					if err := m.Breakdown.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 3: // ShippingAddress
+			// Unmarshal nested message field (ShippingAddress)
+			if entry, ok := offsets[3]; ok {
+				if entry.length == 0 {
+					m.ShippingAddress = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.ShippingAddress == nil {
+						m.ShippingAddress = &Address{}
+					}
+					if err := m.ShippingAddress.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // CartHash
+			// Unmarshal string or []byte field (CartHash)
+			if entry, ok := offsets[4]; ok {
+				m.CartHash = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // WalletAmount
+			// Unmarshal nested message field (WalletAmount)
+			if entry, ok := offsets[5]; ok {
+				if entry.length == 0 {
+					m.WalletAmount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.WalletAmount == nil {
+						m.WalletAmount = &Money{}
+					}
+					if err := m.WalletAmount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
+				dataOffset += int(entry.length)
+			}
+		case 6: // CardAmount
+			// Unmarshal nested message field (CardAmount)
+			if entry, ok := offsets[6]; ok {
+				if entry.length == 0 {
+					m.CardAmount = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.CardAmount == nil {
+						m.CardAmount = &Money{}
+					}
+					if err := m.CardAmount.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
 				dataOffset += int(entry.length)
 			}
 		}
@@ -1892,93 +16084,96 @@ func (m *ShipOrderResponse) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *Address) MarshalSymphony() ([]byte, error) {
+func (m *AdRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 197)
+	buf := make([]byte, 0, 183)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+	buf = append(buf, []byte{1, 2, 3}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 3 (AdContext): singular message
+	if m.AdContext != nil {
+		cachedSingularMessages[3], err = m.AdContext.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field AdContext: %w", err)
+		}
+	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (StreetAddress): string or bytes
+	// Field 1 (UserId): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of StreetAddress
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.StreetAddress)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.StreetAddress)
+	offset += len(m.UserId)
 
-	// Field 2 (City): string or bytes
+	// Field 2 (ContextKeys): repeated variable-length
 	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of City
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ContextKeys
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.City)))
+	totalLen := 0
+	for _, item := range m.ContextKeys {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.City)
+	offset += totalLen
 
-	// Field 3 (State): string or bytes
+	// Field 3 (AdContext): nested message
 	buf = append(buf, byte(3))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of State
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.State)))
-	buf = append(buf, temp[:2]...)
-	offset += len(m.State)
-
-	// Field 4 (Country): string or bytes
-	buf = append(buf, byte(4))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Country
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Country)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Country)
-
-	offset += 4 // ZipCode
+	offset += len(cachedSingularMessages[3])
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (StreetAddress)
-	buf = append(buf, []byte(m.StreetAddress)...)
-
-	// Write string or bytes field (City)
-	buf = append(buf, []byte(m.City)...)
-
-	// Write string or bytes field (State)
-	buf = append(buf, []byte(m.State)...)
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
 
-	// Write string or bytes field (Country)
-	buf = append(buf, []byte(m.Country)...)
+	// Write repeated variable-length field (ContextKeys)
+	for _, item := range m.ContextKeys {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
 
-	// Write fixed field (ZipCode)
-	binary.LittleEndian.PutUint32(temp[:4], uint32(m.ZipCode))
-	buf = append(buf, temp[:4]...)
+	// Write nested message field (AdContext)
+	buf = append(buf, cachedSingularMessages[3]...)
 
 	return buf, nil
 }
 
-func (m *Address) UnmarshalSymphony(data []byte) error {
+func (m *AdRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 6 {
+	if len(data) < 4 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+5]
-	offset += 5
+	fieldOrder := data[offset : offset+3]
+	offset += 3
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 20
+	offsetTableSize := 15
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 4; i++ {
+	for i := 0; i < 3; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -1994,103 +16189,148 @@ func (m *Address) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // StreetAddress
-			// Unmarshal string or []byte field (StreetAddress)
+		case 1: // UserId
+			// Unmarshal string or []byte field (UserId)
 			if entry, ok := offsets[1]; ok {
-				m.StreetAddress = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 2: // City
-			// Unmarshal string or []byte field (City)
+		case 2: // ContextKeys
+			// Unmarshal repeated variable-length field (ContextKeys)
 			if entry, ok := offsets[2]; ok {
-				m.City = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.ContextKeys = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.ContextKeys = append(m.ContextKeys, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.ContextKeys = append(m.ContextKeys, string(itemData))
+				}
 				dataOffset += int(entry.length)
 			}
-		case 3: // State
-			// Unmarshal string or []byte field (State)
+		case 3: // AdContext
+			// Unmarshal nested message field (AdContext)
 			if entry, ok := offsets[3]; ok {
-				m.State = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 4: // Country
-			// Unmarshal string or []byte field (Country)
-			if entry, ok := offsets[4]; ok {
-				m.Country = string(dataRegion[entry.offset : entry.offset+entry.length])
+				if entry.length == 0 {
+					m.AdContext = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.AdContext == nil {
+						m.AdContext = &AdContext{}
+					}
+					if err := m.AdContext.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					}
+				}
 				dataOffset += int(entry.length)
 			}
-		case 5: // ZipCode
-			// Unmarshal fixed field (ZipCode)
-			if dataOffset+4 > len(dataRegion) {
-				return fmt.Errorf("insufficient data for fixed field")
-			}
-			m.ZipCode = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
-			dataOffset += 4
 		}
 	}
 
 	return nil
 }
 
-func (m *Money) MarshalSymphony() ([]byte, error) {
+func (m *AdContext) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 66)
+	buf := make([]byte, 0, 191)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2, 3}...)
+	buf = append(buf, []byte{1, 2, 3, 4}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (CurrencyCode): string or bytes
+	// Field 1 (Currency): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CurrencyCode
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Currency
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CurrencyCode)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Currency)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.CurrencyCode)
+	offset += len(m.Currency)
 
-	offset += 8 // Units
+	// Field 2 (Experiment): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Experiment
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Experiment)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Experiment)
 
-	offset += 4 // Nanos
+	// Field 3 (RecentCategories): repeated variable-length
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of RecentCategories
+	buf = append(buf, temp[:2]...)
+	totalLen := 0
+	for _, item := range m.RecentCategories {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	buf = append(buf, temp[:2]...)
+	offset += totalLen
+
+	// Field 4 (SessionId): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of SessionId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.SessionId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.SessionId)
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (CurrencyCode)
-	buf = append(buf, []byte(m.CurrencyCode)...)
+	// Write string or bytes field (Currency)
+	buf = append(buf, []byte(m.Currency)...)
 
-	// Write fixed field (Units)
-	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Units))
-	buf = append(buf, temp[:8]...)
+	// Write string or bytes field (Experiment)
+	buf = append(buf, []byte(m.Experiment)...)
 
-	// Write fixed field (Nanos)
-	binary.LittleEndian.PutUint32(temp[:4], uint32(m.Nanos))
-	buf = append(buf, temp[:4]...)
+	// Write repeated variable-length field (RecentCategories)
+	for _, item := range m.RecentCategories {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
+
+	// Write string or bytes field (SessionId)
+	buf = append(buf, []byte(m.SessionId)...)
 
 	return buf, nil
 }
 
-func (m *Money) UnmarshalSymphony(data []byte) error {
+func (m *AdContext) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 4 {
+	if len(data) < 5 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+3]
-	offset += 3
+	fieldOrder := data[offset : offset+4]
+	offset += 4
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 20
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 4; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -2106,88 +16346,126 @@ func (m *Money) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // CurrencyCode
-			// Unmarshal string or []byte field (CurrencyCode)
+		case 1: // Currency
+			// Unmarshal string or []byte field (Currency)
 			if entry, ok := offsets[1]; ok {
-				m.CurrencyCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.Currency = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 2: // Units
-			// Unmarshal fixed field (Units)
-			if dataOffset+8 > len(dataRegion) {
-				return fmt.Errorf("insufficient data for fixed field")
+		case 2: // Experiment
+			// Unmarshal string or []byte field (Experiment)
+			if entry, ok := offsets[2]; ok {
+				m.Experiment = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
 			}
-			m.Units = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
-			dataOffset += 8
-		case 3: // Nanos
-			// Unmarshal fixed field (Nanos)
-			if dataOffset+4 > len(dataRegion) {
-				return fmt.Errorf("insufficient data for fixed field")
+		case 3: // RecentCategories
+			// Unmarshal repeated variable-length field (RecentCategories)
+			if entry, ok := offsets[3]; ok {
+				m.RecentCategories = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.RecentCategories = append(m.RecentCategories, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.RecentCategories = append(m.RecentCategories, string(itemData))
+				}
+				dataOffset += int(entry.length)
+			}
+		case 4: // SessionId
+			// Unmarshal string or []byte field (SessionId)
+			if entry, ok := offsets[4]; ok {
+				m.SessionId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
 			}
-			m.Nanos = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
-			dataOffset += 4
 		}
 	}
 
 	return nil
 }
 
-func (m *GetSupportedCurrenciesResponse) MarshalSymphony() ([]byte, error) {
+func (m *AdClickRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 48)
+	buf := make([]byte, 0, 136)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
+	buf = append(buf, []byte{1, 2}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedSingularMessages := make(map[byte][]byte)
+	// Cache field 2 (AdContext): singular message
+	if m.AdContext != nil {
+		cachedSingularMessages[2], err = m.AdContext.MarshalSymphony()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal singular message field AdContext: %w", err)
+		}
+	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (CurrencyCodes): repeated variable-length
+	// Field 1 (RedirectUrl): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CurrencyCodes
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of RedirectUrl
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range m.CurrencyCodes {
-		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.RedirectUrl)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.RedirectUrl)
+
+	// Field 2 (AdContext): nested message
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(cachedSingularMessages[2])
 
 	// === DATA REGION SECTION ===
 
-	// Write repeated variable-length field (CurrencyCodes)
-	for _, item := range m.CurrencyCodes {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, []byte(item)...)
-	}
+	// Write string or bytes field (RedirectUrl)
+	buf = append(buf, []byte(m.RedirectUrl)...)
+
+	// Write nested message field (AdContext)
+	buf = append(buf, cachedSingularMessages[2]...)
 
 	return buf, nil
 }
 
-func (m *GetSupportedCurrenciesResponse) UnmarshalSymphony(data []byte) error {
+func (m *AdClickRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 2 {
+	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+1]
-	offset += 1
+	fieldOrder := data[offset : offset+2]
+	offset += 2
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 10
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 2; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -2203,28 +16481,25 @@ func (m *GetSupportedCurrenciesResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // CurrencyCodes
-			// Unmarshal repeated variable-length field (CurrencyCodes)
+		case 1: // RedirectUrl
+			// Unmarshal string or []byte field (RedirectUrl)
 			if entry, ok := offsets[1]; ok {
-				m.CurrencyCodes = make([]string, 0)
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.CurrencyCodes = append(m.CurrencyCodes, "")
-						continue
+				m.RedirectUrl = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // AdContext
+			// Unmarshal nested message field (AdContext)
+			if entry, ok := offsets[2]; ok {
+				if entry.length == 0 {
+					m.AdContext = nil
+				} else {
+					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+					if m.AdContext == nil {
+						m.AdContext = &AdContext{}
 					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item data")
+					if err := m.AdContext.UnmarshalSymphony(fieldData); err != nil {
+						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
 					}
-					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					m.CurrencyCodes = append(m.CurrencyCodes, string(itemData))
 				}
 				dataOffset += int(entry.length)
 			}
@@ -2234,88 +16509,93 @@ func (m *GetSupportedCurrenciesResponse) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *CurrencyConversionRequest) MarshalSymphony() ([]byte, error) {
+func (m *AdEvent) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 183)
+	buf := make([]byte, 0, 202)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2, 3}...)
-
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
-
-	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 1 (From): singular message
-	if m.From != nil {
-		cachedSingularMessages[1], err = m.From.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field From: %w", err)
-		}
-	}
+	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (From): nested message
+	// Field 1 (Type): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Type
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Type)))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[1])
+	offset += len(m.Type)
 
-	// Field 2 (ToCode): string or bytes
+	// Field 2 (RedirectUrl): string or bytes
 	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ToCode
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of RedirectUrl
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ToCode)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.RedirectUrl)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.ToCode)
+	offset += len(m.RedirectUrl)
 
-	// Field 3 (UserId): string or bytes
+	// Field 3 (SessionId): string or bytes
 	buf = append(buf, byte(3))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of SessionId
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.SessionId)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.UserId)
+	offset += len(m.SessionId)
+
+	// Field 4 (Experiment): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Experiment
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Experiment)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Experiment)
+
+	offset += 8 // TimestampMs
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (From)
-	buf = append(buf, cachedSingularMessages[1]...)
+	// Write string or bytes field (Type)
+	buf = append(buf, []byte(m.Type)...)
 
-	// Write string or bytes field (ToCode)
-	buf = append(buf, []byte(m.ToCode)...)
+	// Write string or bytes field (RedirectUrl)
+	buf = append(buf, []byte(m.RedirectUrl)...)
 
-	// Write string or bytes field (UserId)
-	buf = append(buf, []byte(m.UserId)...)
+	// Write string or bytes field (SessionId)
+	buf = append(buf, []byte(m.SessionId)...)
+
+	// Write string or bytes field (Experiment)
+	buf = append(buf, []byte(m.Experiment)...)
+
+	// Write fixed field (TimestampMs)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.TimestampMs))
+	buf = append(buf, temp[:8]...)
 
 	return buf, nil
 }
 
-func (m *CurrencyConversionRequest) UnmarshalSymphony(data []byte) error {
+func (m *AdEvent) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 4 {
+	if len(data) < 6 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+3]
-	offset += 3
+	fieldOrder := data[offset : offset+5]
+	offset += 5
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 15
+	offsetTableSize := 20
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 3; i++ {
+	for i := 0; i < 4; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -2331,97 +16611,105 @@ func (m *CurrencyConversionRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // From
-			// Unmarshal nested message field (From)
+		case 1: // Type
+			// Unmarshal string or []byte field (Type)
 			if entry, ok := offsets[1]; ok {
-				if entry.length == 0 {
-					m.From = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.From == nil {
-						m.From = &Money{}
-					}
-					if err := m.From.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
+				m.Type = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 2: // ToCode
-			// Unmarshal string or []byte field (ToCode)
+		case 2: // RedirectUrl
+			// Unmarshal string or []byte field (RedirectUrl)
 			if entry, ok := offsets[2]; ok {
-				m.ToCode = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.RedirectUrl = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 3: // UserId
-			// Unmarshal string or []byte field (UserId)
+		case 3: // SessionId
+			// Unmarshal string or []byte field (SessionId)
 			if entry, ok := offsets[3]; ok {
-				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.SessionId = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
+		case 4: // Experiment
+			// Unmarshal string or []byte field (Experiment)
+			if entry, ok := offsets[4]; ok {
+				m.Experiment = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // TimestampMs
+			// Unmarshal fixed field (TimestampMs)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.TimestampMs = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
 		}
 	}
 
 	return nil
 }
 
-func (m *CreditCardInfo) MarshalSymphony() ([]byte, error) {
+func (m *AdResponse) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 67)
+	buf := make([]byte, 0, 88)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2, 3, 4}...)
+	buf = append(buf, []byte{1}...)
+
+	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+
+	var err error
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Ads): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Ads))
+	for i, item := range m.Ads {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repeated message field Ads[%d]: %w", i, err)
+		}
+	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (CreditCardNumber): string or bytes
+	// Field 1 (Ads): nested message
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of CreditCardNumber
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.CreditCardNumber)))
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.CreditCardNumber)
-
-	offset += 4 // CreditCardCvv
-
-	offset += 4 // CreditCardExpirationYear
-
-	offset += 4 // CreditCardExpirationMonth
+	offset += totalLen
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (CreditCardNumber)
-	buf = append(buf, []byte(m.CreditCardNumber)...)
-
-	// Write fixed field (CreditCardCvv)
-	binary.LittleEndian.PutUint32(temp[:4], uint32(m.CreditCardCvv))
-	buf = append(buf, temp[:4]...)
-
-	// Write fixed field (CreditCardExpirationYear)
-	binary.LittleEndian.PutUint32(temp[:4], uint32(m.CreditCardExpirationYear))
-	buf = append(buf, temp[:4]...)
-
-	// Write fixed field (CreditCardExpirationMonth)
-	binary.LittleEndian.PutUint32(temp[:4], uint32(m.CreditCardExpirationMonth))
-	buf = append(buf, temp[:4]...)
+	// Write nested message field (Ads)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
 
 	return buf, nil
 }
 
-func (m *CreditCardInfo) UnmarshalSymphony(data []byte) error {
+func (m *AdResponse) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 5 {
+	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+4]
-	offset += 4
+	fieldOrder := data[offset : offset+1]
+	offset += 1
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
@@ -2446,99 +16734,81 @@ func (m *CreditCardInfo) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // CreditCardNumber
-			// Unmarshal string or []byte field (CreditCardNumber)
+		case 1: // Ads
+			// Unmarshal nested message field (Ads)
 			if entry, ok := offsets[1]; ok {
-				m.CreditCardNumber = string(dataRegion[entry.offset : entry.offset+entry.length])
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Ads = make([]*Ad, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Ads = append(m.Ads, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &Ad{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
+					}
+					m.Ads = append(m.Ads, newItem)
+				}
 				dataOffset += int(entry.length)
 			}
-		case 2: // CreditCardCvv
-			// Unmarshal fixed field (CreditCardCvv)
-			if dataOffset+4 > len(dataRegion) {
-				return fmt.Errorf("insufficient data for fixed field")
-			}
-			m.CreditCardCvv = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
-			dataOffset += 4
-		case 3: // CreditCardExpirationYear
-			// Unmarshal fixed field (CreditCardExpirationYear)
-			if dataOffset+4 > len(dataRegion) {
-				return fmt.Errorf("insufficient data for fixed field")
-			}
-			m.CreditCardExpirationYear = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
-			dataOffset += 4
-		case 4: // CreditCardExpirationMonth
-			// Unmarshal fixed field (CreditCardExpirationMonth)
-			if dataOffset+4 > len(dataRegion) {
-				return fmt.Errorf("insufficient data for fixed field")
-			}
-			m.CreditCardExpirationMonth = int32(binary.LittleEndian.Uint32(dataRegion[dataOffset : dataOffset+4]))
-			dataOffset += 4
 		}
 	}
 
 	return nil
 }
 
-func (m *ChargeRequest) MarshalSymphony() ([]byte, error) {
+func (m *Ad) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 176)
+	buf := make([]byte, 0, 96)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
 	buf = append(buf, []byte{1, 2}...)
 
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
-
-	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 1 (Amount): singular message
-	if m.Amount != nil {
-		cachedSingularMessages[1], err = m.Amount.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Amount: %w", err)
-		}
-	}
-
-	// Cache field 2 (CreditCard): singular message
-	if m.CreditCard != nil {
-		cachedSingularMessages[2], err = m.CreditCard.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field CreditCard: %w", err)
-		}
-	}
-
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Amount): nested message
+	// Field 1 (RedirectUrl): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of RedirectUrl
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.RedirectUrl)))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[1])
+	offset += len(m.RedirectUrl)
 
-	// Field 2 (CreditCard): nested message
+	// Field 2 (Text): string or bytes
 	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Text
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Text)))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[2])
+	offset += len(m.Text)
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (Amount)
-	buf = append(buf, cachedSingularMessages[1]...)
+	// Write string or bytes field (RedirectUrl)
+	buf = append(buf, []byte(m.RedirectUrl)...)
 
-	// Write nested message field (CreditCard)
-	buf = append(buf, cachedSingularMessages[2]...)
+	// Write string or bytes field (Text)
+	buf = append(buf, []byte(m.Text)...)
 
 	return buf, nil
 }
 
-func (m *ChargeRequest) UnmarshalSymphony(data []byte) error {
+func (m *Ad) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
@@ -2573,36 +16843,16 @@ func (m *ChargeRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Amount
-			// Unmarshal nested message field (Amount)
+		case 1: // RedirectUrl
+			// Unmarshal string or []byte field (RedirectUrl)
 			if entry, ok := offsets[1]; ok {
-				if entry.length == 0 {
-					m.Amount = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.Amount == nil {
-						m.Amount = &Money{}
-					}
-					if err := m.Amount.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
+				m.RedirectUrl = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 2: // CreditCard
-			// Unmarshal nested message field (CreditCard)
+		case 2: // Text
+			// Unmarshal string or []byte field (Text)
 			if entry, ok := offsets[2]; ok {
-				if entry.length == 0 {
-					m.CreditCard = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.CreditCard == nil {
-						m.CreditCard = &CreditCardInfo{}
-					}
-					if err := m.CreditCard.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
+				m.Text = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -2611,7 +16861,7 @@ func (m *ChargeRequest) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *ChargeResponse) MarshalSymphony() ([]byte, error) {
+func (m *UserDataRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
 	buf := make([]byte, 0, 48)
 	var temp [8]byte // Reusable temp buffer for encoding
@@ -2623,23 +16873,31 @@ func (m *ChargeResponse) MarshalSymphony() ([]byte, error) {
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (TransactionId): string or bytes
+	// Field 1 (Emails): repeated variable-length
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TransactionId
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Emails
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TransactionId)))
+	totalLen := 0
+	for _, item := range m.Emails {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.TransactionId)
+	offset += totalLen
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (TransactionId)
-	buf = append(buf, []byte(m.TransactionId)...)
+	// Write repeated variable-length field (Emails)
+	for _, item := range m.Emails {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, []byte(item)...)
+	}
 
 	return buf, nil
 }
 
-func (m *ChargeResponse) UnmarshalSymphony(data []byte) error {
+func (m *UserDataRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
@@ -2674,10 +16932,29 @@ func (m *ChargeResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // TransactionId
-			// Unmarshal string or []byte field (TransactionId)
+		case 1: // Emails
+			// Unmarshal repeated variable-length field (Emails)
 			if entry, ok := offsets[1]; ok {
-				m.TransactionId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.Emails = make([]string, 0)
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
+					}
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Emails = append(m.Emails, "")
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item data")
+					}
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					m.Emails = append(m.Emails, string(itemData))
+				}
 				dataOffset += int(entry.length)
 			}
 		}
@@ -2686,7 +16963,7 @@ func (m *ChargeResponse) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *OrderItem) MarshalSymphony() ([]byte, error) {
+func (m *UserData) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
 	buf := make([]byte, 0, 176)
 	var temp [8]byte // Reusable temp buffer for encoding
@@ -2699,34 +16976,42 @@ func (m *OrderItem) MarshalSymphony() ([]byte, error) {
 
 	var err error
 	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 1 (Item): singular message
-	if m.Item != nil {
-		cachedSingularMessages[1], err = m.Item.MarshalSymphony()
+	// Cache field 2 (Emails): singular message
+	if m.Emails != nil {
+		cachedSingularMessages[2], err = m.Emails.MarshalSymphony()
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Item: %w", err)
+			return nil, fmt.Errorf("failed to marshal singular message field Emails: %w", err)
 		}
 	}
 
-	// Cache field 2 (Cost): singular message
-	if m.Cost != nil {
-		cachedSingularMessages[2], err = m.Cost.MarshalSymphony()
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Records): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Records))
+	for i, item := range m.Records {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Cost: %w", err)
+			return nil, fmt.Errorf("failed to marshal repeated message field Records[%d]: %w", i, err)
 		}
 	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Item): nested message
+	// Field 1 (Records): nested message
 	buf = append(buf, byte(1))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[1])
+	offset += totalLen
 
-	// Field 2 (Cost): nested message
+	// Field 2 (Emails): nested message
 	buf = append(buf, byte(2))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
@@ -2736,16 +17021,20 @@ func (m *OrderItem) MarshalSymphony() ([]byte, error) {
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (Item)
-	buf = append(buf, cachedSingularMessages[1]...)
+	// Write nested message field (Records)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
 
-	// Write nested message field (Cost)
+	// Write nested message field (Emails)
 	buf = append(buf, cachedSingularMessages[2]...)
 
 	return buf, nil
 }
 
-func (m *OrderItem) UnmarshalSymphony(data []byte) error {
+func (m *UserData) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
@@ -2780,33 +17069,46 @@ func (m *OrderItem) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Item
-			// Unmarshal nested message field (Item)
+		case 1: // Records
+			// Unmarshal nested message field (Records)
 			if entry, ok := offsets[1]; ok {
-				if entry.length == 0 {
-					m.Item = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.Item == nil {
-						m.Item = &CartItem{}
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Records = make([]*UserDataRecord, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
 					}
-					if err := m.Item.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Records = append(m.Records, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &UserDataRecord{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
 					}
+					m.Records = append(m.Records, newItem)
 				}
 				dataOffset += int(entry.length)
 			}
-		case 2: // Cost
-			// Unmarshal nested message field (Cost)
+		case 2: // Emails
+			// Unmarshal nested message field (Emails)
 			if entry, ok := offsets[2]; ok {
 				if entry.length == 0 {
-					m.Cost = nil
+					m.Emails = nil
 				} else {
 					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.Cost == nil {
-						m.Cost = &Money{}
+					if m.Emails == nil {
+						m.Emails = &EmailAddresses{}
 					}
-					if err := m.Cost.UnmarshalSymphony(fieldData); err != nil {
+					if err := m.Emails.UnmarshalSymphony(fieldData); err != nil {
 						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
 					}
 				}
@@ -2818,89 +17120,130 @@ func (m *OrderItem) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *OrderResult) MarshalSymphony() ([]byte, error) {
+func (m *UserDataRecord) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 358)
+	buf := make([]byte, 0, 107)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2, 3, 4, 5}...)
+	buf = append(buf, []byte{1, 2, 3}...)
 
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
+	// === OFFSET TABLE SECTION ===
+	offset := 0
 
-	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 3 (ShippingCost): singular message
-	if m.ShippingCost != nil {
-		cachedSingularMessages[3], err = m.ShippingCost.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field ShippingCost: %w", err)
+	// Field 1 (Kind): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Kind
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Kind)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Kind)
+
+	offset += 8 // Count
+
+	// Field 3 (Json): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Json
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Json)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Json)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Kind)
+	buf = append(buf, []byte(m.Kind)...)
+
+	// Write fixed field (Count)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Count))
+	buf = append(buf, temp[:8]...)
+
+	// Write string or bytes field (Json)
+	buf = append(buf, []byte(m.Json)...)
+
+	return buf, nil
+}
+
+func (m *UserDataRecord) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 4 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+3]
+	offset += 3
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 10
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 2; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Kind
+			// Unmarshal string or []byte field (Kind)
+			if entry, ok := offsets[1]; ok {
+				m.Kind = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // Count
+			// Unmarshal fixed field (Count)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Count = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 3: // Json
+			// Unmarshal string or []byte field (Json)
+			if entry, ok := offsets[3]; ok {
+				m.Json = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
 		}
 	}
 
-	// Cache field 4 (ShippingAddress): singular message
-	if m.ShippingAddress != nil {
-		cachedSingularMessages[4], err = m.ShippingAddress.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field ShippingAddress: %w", err)
-		}
-	}
+	return nil
+}
 
-	cachedRepeatedMessages := make(map[byte][][]byte)
-	// Cache field 5 (Items): repeated message
-	cachedRepeatedMessages[5] = make([][]byte, len(m.Items))
-	for i, item := range m.Items {
-		if item != nil {
-			cachedRepeatedMessages[5][i], err = item.MarshalSymphony()
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal repeated message field Items[%d]: %w", i, err)
-		}
-	}
+func (m *EmailAddresses) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (OrderId): string or bytes
+	// Field 1 (Addresses): repeated variable-length
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
-	buf = append(buf, temp[:2]...)
-	offset += len(m.OrderId)
-
-	// Field 2 (ShippingTrackingId): string or bytes
-	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ShippingTrackingId
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.ShippingTrackingId)))
-	buf = append(buf, temp[:2]...)
-	offset += len(m.ShippingTrackingId)
-
-	// Field 3 (ShippingCost): nested message
-	buf = append(buf, byte(3))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
-	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[3])
-
-	// Field 4 (ShippingAddress): nested message
-	buf = append(buf, byte(4))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[4])))
-	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[4])
-
-	// Field 5 (Items): nested message
-	buf = append(buf, byte(5))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Addresses
 	buf = append(buf, temp[:2]...)
 	totalLen := 0
-	for _, item := range cachedRepeatedMessages[5] {
-		totalLen += 4 + len(item) // 4 bytes for length + message data
+	for _, item := range m.Addresses {
+		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
 	}
 	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
@@ -2908,48 +17251,36 @@ func (m *OrderResult) MarshalSymphony() ([]byte, error) {
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (OrderId)
-	buf = append(buf, []byte(m.OrderId)...)
-
-	// Write string or bytes field (ShippingTrackingId)
-	buf = append(buf, []byte(m.ShippingTrackingId)...)
-
-	// Write nested message field (ShippingCost)
-	buf = append(buf, cachedSingularMessages[3]...)
-
-	// Write nested message field (ShippingAddress)
-	buf = append(buf, cachedSingularMessages[4]...)
-
-	// Write nested message field (Items)
-	for _, item := range cachedRepeatedMessages[5] {
+	// Write repeated variable-length field (Addresses)
+	for _, item := range m.Addresses {
 		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
 		buf = append(buf, temp[:4]...)
-		buf = append(buf, item...)
+		buf = append(buf, []byte(item)...)
 	}
 
 	return buf, nil
 }
 
-func (m *OrderResult) UnmarshalSymphony(data []byte) error {
+func (m *EmailAddresses) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 6 {
+	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+5]
-	offset += 5
+	fieldOrder := data[offset : offset+1]
+	offset += 1
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 25
+	offsetTableSize := 5
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 5; i++ {
+	for i := 0; i < 1; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -2965,55 +17296,11 @@ func (m *OrderResult) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // OrderId
-			// Unmarshal string or []byte field (OrderId)
+		case 1: // Addresses
+			// Unmarshal repeated variable-length field (Addresses)
 			if entry, ok := offsets[1]; ok {
-				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 2: // ShippingTrackingId
-			// Unmarshal string or []byte field (ShippingTrackingId)
-			if entry, ok := offsets[2]; ok {
-				m.ShippingTrackingId = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 3: // ShippingCost
-			// Unmarshal nested message field (ShippingCost)
-			if entry, ok := offsets[3]; ok {
-				if entry.length == 0 {
-					m.ShippingCost = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.ShippingCost == nil {
-						m.ShippingCost = &Money{}
-					}
-					if err := m.ShippingCost.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
-				dataOffset += int(entry.length)
-			}
-		case 4: // ShippingAddress
-			// Unmarshal nested message field (ShippingAddress)
-			if entry, ok := offsets[4]; ok {
-				if entry.length == 0 {
-					m.ShippingAddress = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.ShippingAddress == nil {
-						m.ShippingAddress = &Address{}
-					}
-					if err := m.ShippingAddress.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
-				dataOffset += int(entry.length)
-			}
-		case 5: // Items
-			// Unmarshal nested message field (Items)
-			if entry, ok := offsets[5]; ok {
+				m.Addresses = make([]string, 0)
 				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				m.Items = make([]*OrderItem, 0)
 				fieldOffset := 0
 				for fieldOffset < len(fieldData) {
 					if fieldOffset+4 > len(fieldData) {
@@ -3022,19 +17309,15 @@ func (m *OrderResult) UnmarshalSymphony(data []byte) error {
 					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
 					fieldOffset += 4
 					if itemLen == 0 {
-						m.Items = append(m.Items, nil)
+						m.Addresses = append(m.Addresses, "")
 						continue
 					}
 					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item bytes")
+						return fmt.Errorf("insufficient data for item data")
 					}
-					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
 					fieldOffset += int(itemLen)
-					newItem := &OrderItem{}
-					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
-						return fmt.Errorf("failed to unmarshal nested message: %w", err)
-					}
-					m.Items = append(m.Items, newItem)
+					m.Addresses = append(m.Addresses, string(itemData))
 				}
 				dataOffset += int(entry.length)
 			}
@@ -3044,58 +17327,46 @@ func (m *OrderResult) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *SendOrderConfirmationRequest) MarshalSymphony() ([]byte, error) {
+func (m *ReferralVisited) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 136)
+	buf := make([]byte, 0, 96)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
 	buf = append(buf, []byte{1, 2}...)
 
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
-
-	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 2 (Order): singular message
-	if m.Order != nil {
-		cachedSingularMessages[2], err = m.Order.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Order: %w", err)
-		}
-	}
-
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Email): string or bytes
+	// Field 1 (Code): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Code
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Code)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Email)
+	offset += len(m.Code)
 
-	// Field 2 (Order): nested message
+	// Field 2 (Tenant): string or bytes
 	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Tenant
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[2])))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Tenant)))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[2])
+	offset += len(m.Tenant)
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (Email)
-	buf = append(buf, []byte(m.Email)...)
+	// Write string or bytes field (Code)
+	buf = append(buf, []byte(m.Code)...)
 
-	// Write nested message field (Order)
-	buf = append(buf, cachedSingularMessages[2]...)
+	// Write string or bytes field (Tenant)
+	buf = append(buf, []byte(m.Tenant)...)
 
 	return buf, nil
 }
 
-func (m *SendOrderConfirmationRequest) UnmarshalSymphony(data []byte) error {
+func (m *ReferralVisited) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
@@ -3130,26 +17401,91 @@ func (m *SendOrderConfirmationRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Email
-			// Unmarshal string or []byte field (Email)
+		case 1: // Code
+			// Unmarshal string or []byte field (Code)
 			if entry, ok := offsets[1]; ok {
-				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.Code = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 2: // Order
-			// Unmarshal nested message field (Order)
+		case 2: // Tenant
+			// Unmarshal string or []byte field (Tenant)
 			if entry, ok := offsets[2]; ok {
-				if entry.length == 0 {
-					m.Order = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.Order == nil {
-						m.Order = &OrderResult{}
-					}
-					if err := m.Order.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
+				m.Tenant = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *ReferralStatsRequest) MarshalSymphony() ([]byte, error) {
+	// Pre-allocate buffer with estimated size
+	buf := make([]byte, 0, 48)
+	var temp [8]byte // Reusable temp buffer for encoding
+
+	// === HEADER SECTION ===
+	buf = append(buf, 0x00) // layout header
+	buf = append(buf, []byte{1}...)
+
+	// === OFFSET TABLE SECTION ===
+	offset := 0
+
+	// Field 1 (Code): string or bytes
+	buf = append(buf, byte(1))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Code
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Code)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Code)
+
+	// === DATA REGION SECTION ===
+
+	// Write string or bytes field (Code)
+	buf = append(buf, []byte(m.Code)...)
+
+	return buf, nil
+}
+
+func (m *ReferralStatsRequest) UnmarshalSymphony(data []byte) error {
+	// === HEADER PARSING SECTION ===
+	if len(data) < 2 {
+		return fmt.Errorf("data too short for header")
+	}
+	offset := 0
+	_ = data[offset] // header byte (currently unused)
+	offset++
+
+	fieldOrder := data[offset : offset+1]
+	offset += 1
+
+	// === OFFSET TABLE PARSING SECTION ===
+	type offsetEntry struct{ offset, length uint16 }
+	offsets := map[byte]offsetEntry{}
+	offsetTableSize := 5
+	if len(data) < offset+offsetTableSize {
+		return fmt.Errorf("data too short for offset table")
+	}
+	for i := 0; i < 1; i++ {
+		entryOffset := offset + i*5
+		fieldID := data[entryOffset]
+		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
+		len := binary.LittleEndian.Uint16(data[entryOffset+3 : entryOffset+5])
+		offsets[fieldID] = offsetEntry{off, len}
+	}
+	offset += offsetTableSize
+
+	// === DATA REGION EXTRACTION SECTION ===
+	dataRegion := data[offset:]
+	dataOffset := 0
+
+	// === FIELD UNMARSHALING SECTION ===
+	for _, fieldNum := range fieldOrder {
+		switch fieldNum {
+		case 1: // Code
+			// Unmarshal string or []byte field (Code)
+			if entry, ok := offsets[1]; ok {
+				m.Code = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -3158,118 +17494,72 @@ func (m *SendOrderConfirmationRequest) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *PlaceOrderRequest) MarshalSymphony() ([]byte, error) {
+func (m *ReferralStat) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 318)
+	buf := make([]byte, 0, 82)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2, 3, 5, 6}...)
-
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
-
-	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 3 (Address): singular message
-	if m.Address != nil {
-		cachedSingularMessages[3], err = m.Address.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Address: %w", err)
-		}
-	}
-
-	// Cache field 6 (CreditCard): singular message
-	if m.CreditCard != nil {
-		cachedSingularMessages[6], err = m.CreditCard.MarshalSymphony()
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field CreditCard: %w", err)
-		}
-	}
+	buf = append(buf, []byte{1, 2, 3, 4}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (UserId): string or bytes
+	// Field 1 (Code): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
-	buf = append(buf, temp[:2]...)
-	offset += len(m.UserId)
-
-	// Field 2 (UserCurrency): string or bytes
-	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserCurrency
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Code
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserCurrency)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Code)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.UserCurrency)
+	offset += len(m.Code)
 
-	// Field 3 (Address): nested message
-	buf = append(buf, byte(3))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[3])))
-	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[3])
+	offset += 8 // Visits
 
-	// Field 5 (Email): string or bytes
-	buf = append(buf, byte(5))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
-	buf = append(buf, temp[:2]...)
-	offset += len(m.Email)
+	offset += 8 // Orders
 
-	// Field 6 (CreditCard): nested message
-	buf = append(buf, byte(6))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
-	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[6])))
-	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[6])
+	offset += 8 // Refunded
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (UserId)
-	buf = append(buf, []byte(m.UserId)...)
-
-	// Write string or bytes field (UserCurrency)
-	buf = append(buf, []byte(m.UserCurrency)...)
+	// Write string or bytes field (Code)
+	buf = append(buf, []byte(m.Code)...)
 
-	// Write nested message field (Address)
-	buf = append(buf, cachedSingularMessages[3]...)
+	// Write fixed field (Visits)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Visits))
+	buf = append(buf, temp[:8]...)
 
-	// Write string or bytes field (Email)
-	buf = append(buf, []byte(m.Email)...)
+	// Write fixed field (Orders)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Orders))
+	buf = append(buf, temp[:8]...)
 
-	// Write nested message field (CreditCard)
-	buf = append(buf, cachedSingularMessages[6]...)
+	// Write fixed field (Refunded)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.Refunded))
+	buf = append(buf, temp[:8]...)
 
 	return buf, nil
 }
 
-func (m *PlaceOrderRequest) UnmarshalSymphony(data []byte) error {
+func (m *ReferralStat) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 6 {
+	if len(data) < 5 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+5]
-	offset += 5
+	fieldOrder := data[offset : offset+4]
+	offset += 4
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 25
+	offsetTableSize := 5
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 5; i++ {
+	for i := 0; i < 1; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -3285,63 +17575,40 @@ func (m *PlaceOrderRequest) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // UserId
-			// Unmarshal string or []byte field (UserId)
+		case 1: // Code
+			// Unmarshal string or []byte field (Code)
 			if entry, ok := offsets[1]; ok {
-				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
-			}
-		case 2: // UserCurrency
-			// Unmarshal string or []byte field (UserCurrency)
-			if entry, ok := offsets[2]; ok {
-				m.UserCurrency = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.Code = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 3: // Address
-			// Unmarshal nested message field (Address)
-			if entry, ok := offsets[3]; ok {
-				if entry.length == 0 {
-					m.Address = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.Address == nil {
-						m.Address = &Address{}
-					}
-					if err := m.Address.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
-				dataOffset += int(entry.length)
+		case 2: // Visits
+			// Unmarshal fixed field (Visits)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
 			}
-		case 5: // Email
-			// Unmarshal string or []byte field (Email)
-			if entry, ok := offsets[5]; ok {
-				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
-				dataOffset += int(entry.length)
+			m.Visits = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 3: // Orders
+			// Unmarshal fixed field (Orders)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
 			}
-		case 6: // CreditCard
-			// Unmarshal nested message field (CreditCard)
-			if entry, ok := offsets[6]; ok {
-				if entry.length == 0 {
-					m.CreditCard = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.CreditCard == nil {
-						m.CreditCard = &CreditCardInfo{}
-					}
-					if err := m.CreditCard.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
-					}
-				}
-				dataOffset += int(entry.length)
+			m.Orders = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 4: // Refunded
+			// Unmarshal fixed field (Refunded)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
 			}
+			m.Refunded = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
 		}
 	}
 
 	return nil
 }
 
-func (m *PlaceOrderResponse) MarshalSymphony() ([]byte, error) {
+func (m *ReferralStats) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
 	buf := make([]byte, 0, 88)
 	var temp [8]byte // Reusable temp buffer for encoding
@@ -3353,35 +17620,46 @@ func (m *PlaceOrderResponse) MarshalSymphony() ([]byte, error) {
 	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
 
 	var err error
-	cachedSingularMessages := make(map[byte][]byte)
-	// Cache field 1 (Order): singular message
-	if m.Order != nil {
-		cachedSingularMessages[1], err = m.Order.MarshalSymphony()
+	cachedRepeatedMessages := make(map[byte][][]byte)
+	// Cache field 1 (Stats): repeated message
+	cachedRepeatedMessages[1] = make([][]byte, len(m.Stats))
+	for i, item := range m.Stats {
+		if item != nil {
+			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
+		}
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal singular message field Order: %w", err)
+			return nil, fmt.Errorf("failed to marshal repeated message field Stats[%d]: %w", i, err)
 		}
 	}
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Order): nested message
+	// Field 1 (Stats): nested message
 	buf = append(buf, byte(1))
 	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(cachedSingularMessages[1])))
+	totalLen := 0
+	for _, item := range cachedRepeatedMessages[1] {
+		totalLen += 4 + len(item) // 4 bytes for length + message data
+	}
+	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
 	buf = append(buf, temp[:2]...)
-	offset += len(cachedSingularMessages[1])
+	offset += totalLen
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (Order)
-	buf = append(buf, cachedSingularMessages[1]...)
+	// Write nested message field (Stats)
+	for _, item := range cachedRepeatedMessages[1] {
+		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
+		buf = append(buf, temp[:4]...)
+		buf = append(buf, item...)
+	}
 
 	return buf, nil
 }
 
-func (m *PlaceOrderResponse) UnmarshalSymphony(data []byte) error {
+func (m *ReferralStats) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
 	if len(data) < 2 {
 		return fmt.Errorf("data too short for header")
@@ -3416,19 +17694,32 @@ func (m *PlaceOrderResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Order
-			// Unmarshal nested message field (Order)
+		case 1: // Stats
+			// Unmarshal nested message field (Stats)
 			if entry, ok := offsets[1]; ok {
-				if entry.length == 0 {
-					m.Order = nil
-				} else {
-					fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-					if m.Order == nil {
-						m.Order = &OrderResult{}
+				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
+				m.Stats = make([]*ReferralStat, 0)
+				fieldOffset := 0
+				for fieldOffset < len(fieldData) {
+					if fieldOffset+4 > len(fieldData) {
+						return fmt.Errorf("insufficient data for item length")
 					}
-					if err := m.Order.UnmarshalSymphony(fieldData); err != nil {
-						return fmt.Errorf("failed to unmarshal singular nested message: %w", err)
+					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
+					fieldOffset += 4
+					if itemLen == 0 {
+						m.Stats = append(m.Stats, nil)
+						continue
+					}
+					if fieldOffset+int(itemLen) > len(fieldData) {
+						return fmt.Errorf("insufficient data for item bytes")
+					}
+					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
+					fieldOffset += int(itemLen)
+					newItem := &ReferralStat{}
+					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
+						return fmt.Errorf("failed to unmarshal nested message: %w", err)
 					}
+					m.Stats = append(m.Stats, newItem)
 				}
 				dataOffset += int(entry.length)
 			}
@@ -3438,14 +17729,14 @@ func (m *PlaceOrderResponse) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *AdRequest) MarshalSymphony() ([]byte, error) {
+func (m *CreateTicketRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 96)
+	buf := make([]byte, 0, 333)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2}...)
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
@@ -3458,53 +17749,100 @@ func (m *AdRequest) MarshalSymphony() ([]byte, error) {
 	buf = append(buf, temp[:2]...)
 	offset += len(m.UserId)
 
-	// Field 2 (ContextKeys): repeated variable-length
+	// Field 2 (Name): string or bytes
 	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of ContextKeys
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Name
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range m.ContextKeys {
-		totalLen += 4 + len(item) // 4 bytes for length + (string or bytes) data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Name)))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(m.Name)
+
+	// Field 3 (Email): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 4 (Subject): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Subject
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Subject)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Subject)
+
+	// Field 5 (Message): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Message
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Message)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Message)
+
+	// Field 6 (OrderId): string or bytes
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 7 (Locale): string or bytes
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
 
 	// === DATA REGION SECTION ===
 
 	// Write string or bytes field (UserId)
 	buf = append(buf, []byte(m.UserId)...)
 
-	// Write repeated variable-length field (ContextKeys)
-	for _, item := range m.ContextKeys {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, []byte(item)...)
-	}
+	// Write string or bytes field (Name)
+	buf = append(buf, []byte(m.Name)...)
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (Subject)
+	buf = append(buf, []byte(m.Subject)...)
+
+	// Write string or bytes field (Message)
+	buf = append(buf, []byte(m.Message)...)
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
 
 	return buf, nil
 }
 
-func (m *AdRequest) UnmarshalSymphony(data []byte) error {
+func (m *CreateTicketRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 3 {
+	if len(data) < 8 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+2]
-	offset += 2
+	fieldOrder := data[offset : offset+7]
+	offset += 7
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 10
+	offsetTableSize := 35
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 2; i++ {
+	for i := 0; i < 7; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -3526,29 +17864,40 @@ func (m *AdRequest) UnmarshalSymphony(data []byte) error {
 				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 2: // ContextKeys
-			// Unmarshal repeated variable-length field (ContextKeys)
-			if entry, ok := offsets[2]; ok {
-				m.ContextKeys = make([]string, 0)
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.ContextKeys = append(m.ContextKeys, "")
-						continue
-					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item data")
-					}
-					itemData := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					m.ContextKeys = append(m.ContextKeys, string(itemData))
-				}
+		case 2: // Name
+			// Unmarshal string or []byte field (Name)
+			if entry, ok := offsets[2]; ok {
+				m.Name = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[3]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Subject
+			// Unmarshal string or []byte field (Subject)
+			if entry, ok := offsets[4]; ok {
+				m.Subject = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // Message
+			// Unmarshal string or []byte field (Message)
+			if entry, ok := offsets[5]; ok {
+				m.Message = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[6]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 7: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[7]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -3557,77 +17906,65 @@ func (m *AdRequest) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *AdResponse) MarshalSymphony() ([]byte, error) {
+func (m *GetTicketRequest) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 88)
+	buf := make([]byte, 0, 96)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1}...)
-
-	// === PRE-MARSHAL/CACHE SECTION FOR NESTED MESSAGES ===
-
-	var err error
-	cachedRepeatedMessages := make(map[byte][][]byte)
-	// Cache field 1 (Ads): repeated message
-	cachedRepeatedMessages[1] = make([][]byte, len(m.Ads))
-	for i, item := range m.Ads {
-		if item != nil {
-			cachedRepeatedMessages[1][i], err = item.MarshalSymphony()
-		}
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal repeated message field Ads[%d]: %w", i, err)
-		}
-	}
+	buf = append(buf, []byte{1, 2}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (Ads): nested message
+	// Field 1 (UserId): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
 	buf = append(buf, temp[:2]...)
-	totalLen := 0
-	for _, item := range cachedRepeatedMessages[1] {
-		totalLen += 4 + len(item) // 4 bytes for length + message data
-	}
-	binary.LittleEndian.PutUint16(temp[:2], uint16(totalLen))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
 	buf = append(buf, temp[:2]...)
-	offset += totalLen
+	offset += len(m.UserId)
+
+	// Field 2 (TicketId): string or bytes
+	buf = append(buf, byte(2))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of TicketId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.TicketId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.TicketId)
 
 	// === DATA REGION SECTION ===
 
-	// Write nested message field (Ads)
-	for _, item := range cachedRepeatedMessages[1] {
-		binary.LittleEndian.PutUint32(temp[:4], uint32(len(item)))
-		buf = append(buf, temp[:4]...)
-		buf = append(buf, item...)
-	}
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write string or bytes field (TicketId)
+	buf = append(buf, []byte(m.TicketId)...)
 
 	return buf, nil
 }
 
-func (m *AdResponse) UnmarshalSymphony(data []byte) error {
+func (m *GetTicketRequest) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 2 {
+	if len(data) < 3 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+1]
-	offset += 1
+	fieldOrder := data[offset : offset+2]
+	offset += 2
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 5
+	offsetTableSize := 10
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 1; i++ {
+	for i := 0; i < 2; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -3643,33 +17980,16 @@ func (m *AdResponse) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // Ads
-			// Unmarshal nested message field (Ads)
+		case 1: // UserId
+			// Unmarshal string or []byte field (UserId)
 			if entry, ok := offsets[1]; ok {
-				fieldData := dataRegion[entry.offset : entry.offset+entry.length]
-				m.Ads = make([]*Ad, 0)
-				fieldOffset := 0
-				for fieldOffset < len(fieldData) {
-					if fieldOffset+4 > len(fieldData) {
-						return fmt.Errorf("insufficient data for item length")
-					}
-					itemLen := binary.LittleEndian.Uint32(fieldData[fieldOffset : fieldOffset+4])
-					fieldOffset += 4
-					if itemLen == 0 {
-						m.Ads = append(m.Ads, nil)
-						continue
-					}
-					if fieldOffset+int(itemLen) > len(fieldData) {
-						return fmt.Errorf("insufficient data for item bytes")
-					}
-					itemBytes := fieldData[fieldOffset : fieldOffset+int(itemLen)]
-					fieldOffset += int(itemLen)
-					newItem := &Ad{}
-					if err := newItem.UnmarshalSymphony(itemBytes); err != nil {
-						return fmt.Errorf("failed to unmarshal nested message: %w", err)
-					}
-					m.Ads = append(m.Ads, newItem)
-				}
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 2: // TicketId
+			// Unmarshal string or []byte field (TicketId)
+			if entry, ok := offsets[2]; ok {
+				m.TicketId = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
 		}
@@ -3678,65 +17998,157 @@ func (m *AdResponse) UnmarshalSymphony(data []byte) error {
 	return nil
 }
 
-func (m *Ad) MarshalSymphony() ([]byte, error) {
+func (m *Ticket) MarshalSymphony() ([]byte, error) {
 	// Pre-allocate buffer with estimated size
-	buf := make([]byte, 0, 96)
+	buf := make([]byte, 0, 442)
 	var temp [8]byte // Reusable temp buffer for encoding
 
 	// === HEADER SECTION ===
 	buf = append(buf, 0x00) // layout header
-	buf = append(buf, []byte{1, 2}...)
+	buf = append(buf, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}...)
 
 	// === OFFSET TABLE SECTION ===
 	offset := 0
 
-	// Field 1 (RedirectUrl): string or bytes
+	// Field 1 (Id): string or bytes
 	buf = append(buf, byte(1))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of RedirectUrl
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Id
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.RedirectUrl)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Id)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.RedirectUrl)
+	offset += len(m.Id)
 
-	// Field 2 (Text): string or bytes
+	// Field 2 (UserId): string or bytes
 	buf = append(buf, byte(2))
-	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Text
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of UserId
 	buf = append(buf, temp[:2]...)
-	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Text)))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.UserId)))
 	buf = append(buf, temp[:2]...)
-	offset += len(m.Text)
+	offset += len(m.UserId)
+
+	// Field 3 (Name): string or bytes
+	buf = append(buf, byte(3))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Name
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Name)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Name)
+
+	// Field 4 (Email): string or bytes
+	buf = append(buf, byte(4))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Email
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Email)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Email)
+
+	// Field 5 (Subject): string or bytes
+	buf = append(buf, byte(5))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Subject
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Subject)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Subject)
+
+	// Field 6 (Message): string or bytes
+	buf = append(buf, byte(6))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Message
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Message)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Message)
+
+	// Field 7 (OrderId): string or bytes
+	buf = append(buf, byte(7))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of OrderId
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.OrderId)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.OrderId)
+
+	// Field 8 (Locale): string or bytes
+	buf = append(buf, byte(8))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Locale
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Locale)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Locale)
+
+	// Field 9 (Status): string or bytes
+	buf = append(buf, byte(9))
+	binary.LittleEndian.PutUint16(temp[:2], uint16(offset)) // offset of Status
+	buf = append(buf, temp[:2]...)
+	binary.LittleEndian.PutUint16(temp[:2], uint16(len(m.Status)))
+	buf = append(buf, temp[:2]...)
+	offset += len(m.Status)
+
+	offset += 8 // CreatedAt
+
+	offset += 1 // Acknowledged
 
 	// === DATA REGION SECTION ===
 
-	// Write string or bytes field (RedirectUrl)
-	buf = append(buf, []byte(m.RedirectUrl)...)
+	// Write string or bytes field (Id)
+	buf = append(buf, []byte(m.Id)...)
 
-	// Write string or bytes field (Text)
-	buf = append(buf, []byte(m.Text)...)
+	// Write string or bytes field (UserId)
+	buf = append(buf, []byte(m.UserId)...)
+
+	// Write string or bytes field (Name)
+	buf = append(buf, []byte(m.Name)...)
+
+	// Write string or bytes field (Email)
+	buf = append(buf, []byte(m.Email)...)
+
+	// Write string or bytes field (Subject)
+	buf = append(buf, []byte(m.Subject)...)
+
+	// Write string or bytes field (Message)
+	buf = append(buf, []byte(m.Message)...)
+
+	// Write string or bytes field (OrderId)
+	buf = append(buf, []byte(m.OrderId)...)
+
+	// Write string or bytes field (Locale)
+	buf = append(buf, []byte(m.Locale)...)
+
+	// Write string or bytes field (Status)
+	buf = append(buf, []byte(m.Status)...)
+
+	// Write fixed field (CreatedAt)
+	binary.LittleEndian.PutUint64(temp[:8], uint64(m.CreatedAt))
+	buf = append(buf, temp[:8]...)
+
+	// Write fixed field (Acknowledged)
+	if m.Acknowledged {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
 
 	return buf, nil
 }
 
-func (m *Ad) UnmarshalSymphony(data []byte) error {
+func (m *Ticket) UnmarshalSymphony(data []byte) error {
 	// === HEADER PARSING SECTION ===
-	if len(data) < 3 {
+	if len(data) < 12 {
 		return fmt.Errorf("data too short for header")
 	}
 	offset := 0
 	_ = data[offset] // header byte (currently unused)
 	offset++
 
-	fieldOrder := data[offset : offset+2]
-	offset += 2
+	fieldOrder := data[offset : offset+11]
+	offset += 11
 
 	// === OFFSET TABLE PARSING SECTION ===
 	type offsetEntry struct{ offset, length uint16 }
 	offsets := map[byte]offsetEntry{}
-	offsetTableSize := 10
+	offsetTableSize := 45
 	if len(data) < offset+offsetTableSize {
 		return fmt.Errorf("data too short for offset table")
 	}
-	for i := 0; i < 2; i++ {
+	for i := 0; i < 9; i++ {
 		entryOffset := offset + i*5
 		fieldID := data[entryOffset]
 		off := binary.LittleEndian.Uint16(data[entryOffset+1 : entryOffset+3])
@@ -3752,18 +18164,74 @@ func (m *Ad) UnmarshalSymphony(data []byte) error {
 	// === FIELD UNMARSHALING SECTION ===
 	for _, fieldNum := range fieldOrder {
 		switch fieldNum {
-		case 1: // RedirectUrl
-			// Unmarshal string or []byte field (RedirectUrl)
+		case 1: // Id
+			// Unmarshal string or []byte field (Id)
 			if entry, ok := offsets[1]; ok {
-				m.RedirectUrl = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.Id = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
-		case 2: // Text
-			// Unmarshal string or []byte field (Text)
+		case 2: // UserId
+			// Unmarshal string or []byte field (UserId)
 			if entry, ok := offsets[2]; ok {
-				m.Text = string(dataRegion[entry.offset : entry.offset+entry.length])
+				m.UserId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 3: // Name
+			// Unmarshal string or []byte field (Name)
+			if entry, ok := offsets[3]; ok {
+				m.Name = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 4: // Email
+			// Unmarshal string or []byte field (Email)
+			if entry, ok := offsets[4]; ok {
+				m.Email = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 5: // Subject
+			// Unmarshal string or []byte field (Subject)
+			if entry, ok := offsets[5]; ok {
+				m.Subject = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 6: // Message
+			// Unmarshal string or []byte field (Message)
+			if entry, ok := offsets[6]; ok {
+				m.Message = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 7: // OrderId
+			// Unmarshal string or []byte field (OrderId)
+			if entry, ok := offsets[7]; ok {
+				m.OrderId = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 8: // Locale
+			// Unmarshal string or []byte field (Locale)
+			if entry, ok := offsets[8]; ok {
+				m.Locale = string(dataRegion[entry.offset : entry.offset+entry.length])
 				dataOffset += int(entry.length)
 			}
+		case 9: // Status
+			// Unmarshal string or []byte field (Status)
+			if entry, ok := offsets[9]; ok {
+				m.Status = string(dataRegion[entry.offset : entry.offset+entry.length])
+				dataOffset += int(entry.length)
+			}
+		case 10: // CreatedAt
+			// Unmarshal fixed field (CreatedAt)
+			if dataOffset+8 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.CreatedAt = int64(binary.LittleEndian.Uint64(dataRegion[dataOffset : dataOffset+8]))
+			dataOffset += 8
+		case 11: // Acknowledged
+			// Unmarshal fixed field (Acknowledged)
+			if dataOffset+1 > len(dataRegion) {
+				return fmt.Errorf("insufficient data for fixed field")
+			}
+			m.Acknowledged = dataRegion[dataOffset] != 0
+			dataOffset += 1
 		}
 	}
 
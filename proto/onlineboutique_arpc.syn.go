@@ -211,7 +211,22 @@ func _RecommendationService_ListRecommendations_Handler(srv any, ctx context.Con
 type ProductCatalogServiceClient interface {
 	ListProducts(ctx context.Context, req *EmptyUser) (*ListProductsResponse, error)
 	GetProduct(ctx context.Context, req *GetProductRequest) (*Product, error)
+	GetProducts(ctx context.Context, req *GetProductsRequest) (*ListProductsResponse, error)
 	SearchProducts(ctx context.Context, req *SearchProductsRequest) (*SearchProductsResponse, error)
+	SuggestProducts(ctx context.Context, req *SuggestProductsRequest) (*SuggestProductsResponse, error)
+	ImportProducts(ctx context.Context, req *ImportProductsRequest) (*ImportProductsResponse, error)
+	ExportProducts(ctx context.Context, req *ExportProductsRequest) (*ExportProductsResponse, error)
+	ListVariants(ctx context.Context, req *ListVariantsRequest) (*ListVariantsResponse, error)
+	GetVariant(ctx context.Context, req *GetVariantRequest) (*ProductVariant, error)
+	GetStock(ctx context.Context, req *GetStockRequest) (*StockLevel, error)
+	RestockVariant(ctx context.Context, req *RestockVariantRequest) (*ProductVariant, error)
+	NotifyWhenAvailable(ctx context.Context, req *NotifyWhenAvailableRequest) (*Empty, error)
+	ReserveStock(ctx context.Context, req *ReserveStockRequest) (*StockReservation, error)
+	CommitReservation(ctx context.Context, req *ReservationRequest) (*StockReservation, error)
+	ReleaseReservation(ctx context.Context, req *ReservationRequest) (*StockReservation, error)
+	ListPricingRules(ctx context.Context, req *Empty) (*PricingRules, error)
+	SetPricingRules(ctx context.Context, req *PricingRules) (*PricingRules, error)
+	ListPriceChanges(ctx context.Context, req *ListPriceChangesRequest) (*PriceChanges, error)
 }
 
 type arpcProductCatalogServiceClient struct {
@@ -238,6 +253,14 @@ func (c *arpcProductCatalogServiceClient) GetProduct(ctx context.Context, req *G
 	return resp, nil
 }
 
+func (c *arpcProductCatalogServiceClient) GetProducts(ctx context.Context, req *GetProductsRequest) (*ListProductsResponse, error) {
+	resp := new(ListProductsResponse)
+	if err := c.client.Call(ctx, "ProductCatalogService", "GetProducts", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *arpcProductCatalogServiceClient) SearchProducts(ctx context.Context, req *SearchProductsRequest) (*SearchProductsResponse, error) {
 	resp := new(SearchProductsResponse)
 	if err := c.client.Call(ctx, "ProductCatalogService", "SearchProducts", req, resp); err != nil {
@@ -246,35 +269,1852 @@ func (c *arpcProductCatalogServiceClient) SearchProducts(ctx context.Context, re
 	return resp, nil
 }
 
-type ProductCatalogServiceServer interface {
-	ListProducts(ctx context.Context, req *EmptyUser) (*ListProductsResponse, context.Context, error)
-	GetProduct(ctx context.Context, req *GetProductRequest) (*Product, context.Context, error)
-	SearchProducts(ctx context.Context, req *SearchProductsRequest) (*SearchProductsResponse, context.Context, error)
+func (c *arpcProductCatalogServiceClient) SuggestProducts(ctx context.Context, req *SuggestProductsRequest) (*SuggestProductsResponse, error) {
+	resp := new(SuggestProductsResponse)
+	if err := c.client.Call(ctx, "ProductCatalogService", "SuggestProducts", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) ImportProducts(ctx context.Context, req *ImportProductsRequest) (*ImportProductsResponse, error) {
+	resp := new(ImportProductsResponse)
+	if err := c.client.Call(ctx, "ProductCatalogService", "ImportProducts", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) ExportProducts(ctx context.Context, req *ExportProductsRequest) (*ExportProductsResponse, error) {
+	resp := new(ExportProductsResponse)
+	if err := c.client.Call(ctx, "ProductCatalogService", "ExportProducts", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) ListVariants(ctx context.Context, req *ListVariantsRequest) (*ListVariantsResponse, error) {
+	resp := new(ListVariantsResponse)
+	if err := c.client.Call(ctx, "ProductCatalogService", "ListVariants", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) GetVariant(ctx context.Context, req *GetVariantRequest) (*ProductVariant, error) {
+	resp := new(ProductVariant)
+	if err := c.client.Call(ctx, "ProductCatalogService", "GetVariant", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) GetStock(ctx context.Context, req *GetStockRequest) (*StockLevel, error) {
+	resp := new(StockLevel)
+	if err := c.client.Call(ctx, "ProductCatalogService", "GetStock", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) RestockVariant(ctx context.Context, req *RestockVariantRequest) (*ProductVariant, error) {
+	resp := new(ProductVariant)
+	if err := c.client.Call(ctx, "ProductCatalogService", "RestockVariant", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) NotifyWhenAvailable(ctx context.Context, req *NotifyWhenAvailableRequest) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.client.Call(ctx, "ProductCatalogService", "NotifyWhenAvailable", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) ReserveStock(ctx context.Context, req *ReserveStockRequest) (*StockReservation, error) {
+	resp := new(StockReservation)
+	if err := c.client.Call(ctx, "ProductCatalogService", "ReserveStock", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) CommitReservation(ctx context.Context, req *ReservationRequest) (*StockReservation, error) {
+	resp := new(StockReservation)
+	if err := c.client.Call(ctx, "ProductCatalogService", "CommitReservation", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) ReleaseReservation(ctx context.Context, req *ReservationRequest) (*StockReservation, error) {
+	resp := new(StockReservation)
+	if err := c.client.Call(ctx, "ProductCatalogService", "ReleaseReservation", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) ListPricingRules(ctx context.Context, req *Empty) (*PricingRules, error) {
+	resp := new(PricingRules)
+	if err := c.client.Call(ctx, "ProductCatalogService", "ListPricingRules", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) SetPricingRules(ctx context.Context, req *PricingRules) (*PricingRules, error) {
+	resp := new(PricingRules)
+	if err := c.client.Call(ctx, "ProductCatalogService", "SetPricingRules", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcProductCatalogServiceClient) ListPriceChanges(ctx context.Context, req *ListPriceChangesRequest) (*PriceChanges, error) {
+	resp := new(PriceChanges)
+	if err := c.client.Call(ctx, "ProductCatalogService", "ListPriceChanges", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type ProductCatalogServiceServer interface {
+	ListProducts(ctx context.Context, req *EmptyUser) (*ListProductsResponse, context.Context, error)
+	GetProduct(ctx context.Context, req *GetProductRequest) (*Product, context.Context, error)
+	GetProducts(ctx context.Context, req *GetProductsRequest) (*ListProductsResponse, context.Context, error)
+	SearchProducts(ctx context.Context, req *SearchProductsRequest) (*SearchProductsResponse, context.Context, error)
+	SuggestProducts(ctx context.Context, req *SuggestProductsRequest) (*SuggestProductsResponse, context.Context, error)
+	ImportProducts(ctx context.Context, req *ImportProductsRequest) (*ImportProductsResponse, context.Context, error)
+	ExportProducts(ctx context.Context, req *ExportProductsRequest) (*ExportProductsResponse, context.Context, error)
+	ListVariants(ctx context.Context, req *ListVariantsRequest) (*ListVariantsResponse, context.Context, error)
+	GetVariant(ctx context.Context, req *GetVariantRequest) (*ProductVariant, context.Context, error)
+	GetStock(ctx context.Context, req *GetStockRequest) (*StockLevel, context.Context, error)
+	RestockVariant(ctx context.Context, req *RestockVariantRequest) (*ProductVariant, context.Context, error)
+	NotifyWhenAvailable(ctx context.Context, req *NotifyWhenAvailableRequest) (*Empty, context.Context, error)
+	ReserveStock(ctx context.Context, req *ReserveStockRequest) (*StockReservation, context.Context, error)
+	CommitReservation(ctx context.Context, req *ReservationRequest) (*StockReservation, context.Context, error)
+	ReleaseReservation(ctx context.Context, req *ReservationRequest) (*StockReservation, context.Context, error)
+	ListPricingRules(ctx context.Context, req *Empty) (*PricingRules, context.Context, error)
+	SetPricingRules(ctx context.Context, req *PricingRules) (*PricingRules, context.Context, error)
+	ListPriceChanges(ctx context.Context, req *ListPriceChangesRequest) (*PriceChanges, context.Context, error)
+}
+
+func RegisterProductCatalogServiceServer(s *rpc.Server, srv ProductCatalogServiceServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceName: "ProductCatalogService",
+		ServiceImpl: srv,
+		Methods: map[string]*rpc.MethodDesc{
+			"ListProducts": {
+				MethodName: "ListProducts",
+				Handler:    _ProductCatalogService_ListProducts_Handler,
+			},
+			"GetProduct": {
+				MethodName: "GetProduct",
+				Handler:    _ProductCatalogService_GetProduct_Handler,
+			},
+			"GetProducts": {
+				MethodName: "GetProducts",
+				Handler:    _ProductCatalogService_GetProducts_Handler,
+			},
+			"SearchProducts": {
+				MethodName: "SearchProducts",
+				Handler:    _ProductCatalogService_SearchProducts_Handler,
+			},
+			"SuggestProducts": {
+				MethodName: "SuggestProducts",
+				Handler:    _ProductCatalogService_SuggestProducts_Handler,
+			},
+			"ImportProducts": {
+				MethodName: "ImportProducts",
+				Handler:    _ProductCatalogService_ImportProducts_Handler,
+			},
+			"ExportProducts": {
+				MethodName: "ExportProducts",
+				Handler:    _ProductCatalogService_ExportProducts_Handler,
+			},
+			"ListVariants": {
+				MethodName: "ListVariants",
+				Handler:    _ProductCatalogService_ListVariants_Handler,
+			},
+			"GetVariant": {
+				MethodName: "GetVariant",
+				Handler:    _ProductCatalogService_GetVariant_Handler,
+			},
+			"GetStock": {
+				MethodName: "GetStock",
+				Handler:    _ProductCatalogService_GetStock_Handler,
+			},
+			"RestockVariant": {
+				MethodName: "RestockVariant",
+				Handler:    _ProductCatalogService_RestockVariant_Handler,
+			},
+			"NotifyWhenAvailable": {
+				MethodName: "NotifyWhenAvailable",
+				Handler:    _ProductCatalogService_NotifyWhenAvailable_Handler,
+			},
+			"ReserveStock": {
+				MethodName: "ReserveStock",
+				Handler:    _ProductCatalogService_ReserveStock_Handler,
+			},
+			"CommitReservation": {
+				MethodName: "CommitReservation",
+				Handler:    _ProductCatalogService_CommitReservation_Handler,
+			},
+			"ReleaseReservation": {
+				MethodName: "ReleaseReservation",
+				Handler:    _ProductCatalogService_ReleaseReservation_Handler,
+			},
+			"ListPricingRules": {
+				MethodName: "ListPricingRules",
+				Handler:    _ProductCatalogService_ListPricingRules_Handler,
+			},
+			"SetPricingRules": {
+				MethodName: "SetPricingRules",
+				Handler:    _ProductCatalogService_SetPricingRules_Handler,
+			},
+			"ListPriceChanges": {
+				MethodName: "ListPriceChanges",
+				Handler:    _ProductCatalogService_ListPriceChanges_Handler,
+			},
+		},
+	}, srv)
+}
+
+func _ProductCatalogService_ListProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(EmptyUser)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ListProducts(ctx, req.Payload.(*EmptyUser))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_GetProduct_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetProductRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).GetProduct(ctx, req.Payload.(*GetProductRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_GetProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetProductsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).GetProducts(ctx, req.Payload.(*GetProductsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_SearchProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(SearchProductsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).SearchProducts(ctx, req.Payload.(*SearchProductsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_SuggestProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(SuggestProductsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).SuggestProducts(ctx, req.Payload.(*SuggestProductsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_ImportProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ImportProductsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ImportProducts(ctx, req.Payload.(*ImportProductsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_ExportProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ExportProductsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ExportProducts(ctx, req.Payload.(*ExportProductsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_ListVariants_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ListVariantsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ListVariants(ctx, req.Payload.(*ListVariantsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_GetVariant_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetVariantRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).GetVariant(ctx, req.Payload.(*GetVariantRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_GetStock_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetStockRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).GetStock(ctx, req.Payload.(*GetStockRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_RestockVariant_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(RestockVariantRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).RestockVariant(ctx, req.Payload.(*RestockVariantRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_NotifyWhenAvailable_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(NotifyWhenAvailableRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).NotifyWhenAvailable(ctx, req.Payload.(*NotifyWhenAvailableRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_ReserveStock_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ReserveStockRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ReserveStock(ctx, req.Payload.(*ReserveStockRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_CommitReservation_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ReservationRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).CommitReservation(ctx, req.Payload.(*ReservationRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_ReleaseReservation_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ReservationRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ReleaseReservation(ctx, req.Payload.(*ReservationRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_ListPricingRules_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(Empty)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ListPricingRules(ctx, req.Payload.(*Empty))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_SetPricingRules_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(PricingRules)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).SetPricingRules(ctx, req.Payload.(*PricingRules))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ProductCatalogService_ListPriceChanges_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ListPriceChangesRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ProductCatalogServiceServer).ListPriceChanges(ctx, req.Payload.(*ListPriceChangesRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+// ShippingServiceClient is the client API for ShippingService service.
+type ShippingServiceClient interface {
+	GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, error)
+	ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, error)
+	GetShipment(ctx context.Context, req *GetShipmentRequest) (*Shipment, error)
+	CancelShipment(ctx context.Context, req *CancelShipmentRequest) (*Shipment, error)
+	PlanShipments(ctx context.Context, req *PlanShipmentsRequest) (*ShipmentGroups, error)
+	GetDeliveryOptions(ctx context.Context, req *GetDeliveryOptionsRequest) (*DeliveryOptions, error)
+}
+
+type arpcShippingServiceClient struct {
+	client *rpc.Client
+}
+
+func NewShippingServiceClient(client *rpc.Client) ShippingServiceClient {
+	return &arpcShippingServiceClient{client: client}
+}
+
+func (c *arpcShippingServiceClient) GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, error) {
+	resp := new(GetQuoteResponse)
+	if err := c.client.Call(ctx, "ShippingService", "GetQuote", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcShippingServiceClient) ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, error) {
+	resp := new(ShipOrderResponse)
+	if err := c.client.Call(ctx, "ShippingService", "ShipOrder", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcShippingServiceClient) GetShipment(ctx context.Context, req *GetShipmentRequest) (*Shipment, error) {
+	resp := new(Shipment)
+	if err := c.client.Call(ctx, "ShippingService", "GetShipment", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcShippingServiceClient) CancelShipment(ctx context.Context, req *CancelShipmentRequest) (*Shipment, error) {
+	resp := new(Shipment)
+	if err := c.client.Call(ctx, "ShippingService", "CancelShipment", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcShippingServiceClient) PlanShipments(ctx context.Context, req *PlanShipmentsRequest) (*ShipmentGroups, error) {
+	resp := new(ShipmentGroups)
+	if err := c.client.Call(ctx, "ShippingService", "PlanShipments", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcShippingServiceClient) GetDeliveryOptions(ctx context.Context, req *GetDeliveryOptionsRequest) (*DeliveryOptions, error) {
+	resp := new(DeliveryOptions)
+	if err := c.client.Call(ctx, "ShippingService", "GetDeliveryOptions", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type ShippingServiceServer interface {
+	GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, context.Context, error)
+	ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, context.Context, error)
+	GetShipment(ctx context.Context, req *GetShipmentRequest) (*Shipment, context.Context, error)
+	CancelShipment(ctx context.Context, req *CancelShipmentRequest) (*Shipment, context.Context, error)
+	PlanShipments(ctx context.Context, req *PlanShipmentsRequest) (*ShipmentGroups, context.Context, error)
+	GetDeliveryOptions(ctx context.Context, req *GetDeliveryOptionsRequest) (*DeliveryOptions, context.Context, error)
+}
+
+func RegisterShippingServiceServer(s *rpc.Server, srv ShippingServiceServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceName: "ShippingService",
+		ServiceImpl: srv,
+		Methods: map[string]*rpc.MethodDesc{
+			"GetQuote": {
+				MethodName: "GetQuote",
+				Handler:    _ShippingService_GetQuote_Handler,
+			},
+			"ShipOrder": {
+				MethodName: "ShipOrder",
+				Handler:    _ShippingService_ShipOrder_Handler,
+			},
+			"GetShipment": {
+				MethodName: "GetShipment",
+				Handler:    _ShippingService_GetShipment_Handler,
+			},
+			"CancelShipment": {
+				MethodName: "CancelShipment",
+				Handler:    _ShippingService_CancelShipment_Handler,
+			},
+			"PlanShipments": {
+				MethodName: "PlanShipments",
+				Handler:    _ShippingService_PlanShipments_Handler,
+			},
+			"GetDeliveryOptions": {
+				MethodName: "GetDeliveryOptions",
+				Handler:    _ShippingService_GetDeliveryOptions_Handler,
+			},
+		},
+	}, srv)
+}
+
+func _ShippingService_GetQuote_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetQuoteRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ShippingServiceServer).GetQuote(ctx, req.Payload.(*GetQuoteRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ShippingService_ShipOrder_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ShipOrderRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ShippingServiceServer).ShipOrder(ctx, req.Payload.(*ShipOrderRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ShippingService_GetShipment_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetShipmentRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ShippingServiceServer).GetShipment(ctx, req.Payload.(*GetShipmentRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ShippingService_CancelShipment_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(CancelShipmentRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ShippingServiceServer).CancelShipment(ctx, req.Payload.(*CancelShipmentRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ShippingService_PlanShipments_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(PlanShipmentsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ShippingServiceServer).PlanShipments(ctx, req.Payload.(*PlanShipmentsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _ShippingService_GetDeliveryOptions_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetDeliveryOptionsRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(ShippingServiceServer).GetDeliveryOptions(ctx, req.Payload.(*GetDeliveryOptionsRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+// AddressServiceClient is the client API for AddressService service.
+type AddressServiceClient interface {
+	ValidateAddress(ctx context.Context, req *ValidateAddressRequest) (*ValidateAddressResponse, error)
+}
+
+type arpcAddressServiceClient struct {
+	client *rpc.Client
+}
+
+func NewAddressServiceClient(client *rpc.Client) AddressServiceClient {
+	return &arpcAddressServiceClient{client: client}
+}
+
+func (c *arpcAddressServiceClient) ValidateAddress(ctx context.Context, req *ValidateAddressRequest) (*ValidateAddressResponse, error) {
+	resp := new(ValidateAddressResponse)
+	if err := c.client.Call(ctx, "AddressService", "ValidateAddress", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type AddressServiceServer interface {
+	ValidateAddress(ctx context.Context, req *ValidateAddressRequest) (*ValidateAddressResponse, context.Context, error)
+}
+
+func RegisterAddressServiceServer(s *rpc.Server, srv AddressServiceServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceName: "AddressService",
+		ServiceImpl: srv,
+		Methods: map[string]*rpc.MethodDesc{
+			"ValidateAddress": {
+				MethodName: "ValidateAddress",
+				Handler:    _AddressService_ValidateAddress_Handler,
+			},
+		},
+	}, srv)
+}
+
+func _AddressService_ValidateAddress_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ValidateAddressRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(AddressServiceServer).ValidateAddress(ctx, req.Payload.(*ValidateAddressRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+// CurrencyServiceClient is the client API for CurrencyService service.
+type CurrencyServiceClient interface {
+	GetSupportedCurrencies(ctx context.Context, req *EmptyUser) (*GetSupportedCurrenciesResponse, error)
+	Convert(ctx context.Context, req *CurrencyConversionRequest) (*CurrencyConversionResponse, error)
+	GetExchangeRate(ctx context.Context, req *ExchangeRateRequest) (*ExchangeRateResponse, error)
+	RateAt(ctx context.Context, req *RateAtRequest) (*ExchangeRateResponse, error)
+}
+
+type arpcCurrencyServiceClient struct {
+	client *rpc.Client
+}
+
+func NewCurrencyServiceClient(client *rpc.Client) CurrencyServiceClient {
+	return &arpcCurrencyServiceClient{client: client}
+}
+
+func (c *arpcCurrencyServiceClient) GetSupportedCurrencies(ctx context.Context, req *EmptyUser) (*GetSupportedCurrenciesResponse, error) {
+	resp := new(GetSupportedCurrenciesResponse)
+	if err := c.client.Call(ctx, "CurrencyService", "GetSupportedCurrencies", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcCurrencyServiceClient) Convert(ctx context.Context, req *CurrencyConversionRequest) (*CurrencyConversionResponse, error) {
+	resp := new(CurrencyConversionResponse)
+	if err := c.client.Call(ctx, "CurrencyService", "Convert", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcCurrencyServiceClient) GetExchangeRate(ctx context.Context, req *ExchangeRateRequest) (*ExchangeRateResponse, error) {
+	resp := new(ExchangeRateResponse)
+	if err := c.client.Call(ctx, "CurrencyService", "GetExchangeRate", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcCurrencyServiceClient) RateAt(ctx context.Context, req *RateAtRequest) (*ExchangeRateResponse, error) {
+	resp := new(ExchangeRateResponse)
+	if err := c.client.Call(ctx, "CurrencyService", "RateAt", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type CurrencyServiceServer interface {
+	GetSupportedCurrencies(ctx context.Context, req *EmptyUser) (*GetSupportedCurrenciesResponse, context.Context, error)
+	Convert(ctx context.Context, req *CurrencyConversionRequest) (*CurrencyConversionResponse, context.Context, error)
+	GetExchangeRate(ctx context.Context, req *ExchangeRateRequest) (*ExchangeRateResponse, context.Context, error)
+	RateAt(ctx context.Context, req *RateAtRequest) (*ExchangeRateResponse, context.Context, error)
+}
+
+func RegisterCurrencyServiceServer(s *rpc.Server, srv CurrencyServiceServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceName: "CurrencyService",
+		ServiceImpl: srv,
+		Methods: map[string]*rpc.MethodDesc{
+			"GetSupportedCurrencies": {
+				MethodName: "GetSupportedCurrencies",
+				Handler:    _CurrencyService_GetSupportedCurrencies_Handler,
+			},
+			"Convert": {
+				MethodName: "Convert",
+				Handler:    _CurrencyService_Convert_Handler,
+			},
+			"GetExchangeRate": {
+				MethodName: "GetExchangeRate",
+				Handler:    _CurrencyService_GetExchangeRate_Handler,
+			},
+			"RateAt": {
+				MethodName: "RateAt",
+				Handler:    _CurrencyService_RateAt_Handler,
+			},
+		},
+	}, srv)
+}
+
+func _CurrencyService_GetSupportedCurrencies_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(EmptyUser)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(CurrencyServiceServer).GetSupportedCurrencies(ctx, req.Payload.(*EmptyUser))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _CurrencyService_Convert_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(CurrencyConversionRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(CurrencyServiceServer).Convert(ctx, req.Payload.(*CurrencyConversionRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _CurrencyService_GetExchangeRate_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ExchangeRateRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(CurrencyServiceServer).GetExchangeRate(ctx, req.Payload.(*ExchangeRateRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _CurrencyService_RateAt_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(RateAtRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(CurrencyServiceServer).RateAt(ctx, req.Payload.(*RateAtRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+// PaymentServiceClient is the client API for PaymentService service.
+type PaymentServiceClient interface {
+	Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error)
+	GetTransaction(ctx context.Context, req *GetTransactionRequest) (*Transaction, error)
+	ListTransactionsByUser(ctx context.Context, req *ListTransactionsByUserRequest) (*ListTransactionsResponse, error)
+	ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*AuditEntries, error)
+	Refund(ctx context.Context, req *RefundRequest) (*Transaction, error)
+}
+
+type arpcPaymentServiceClient struct {
+	client *rpc.Client
+}
+
+func NewPaymentServiceClient(client *rpc.Client) PaymentServiceClient {
+	return &arpcPaymentServiceClient{client: client}
+}
+
+func (c *arpcPaymentServiceClient) Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
+	resp := new(ChargeResponse)
+	if err := c.client.Call(ctx, "PaymentService", "Charge", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcPaymentServiceClient) GetTransaction(ctx context.Context, req *GetTransactionRequest) (*Transaction, error) {
+	resp := new(Transaction)
+	if err := c.client.Call(ctx, "PaymentService", "GetTransaction", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcPaymentServiceClient) ListTransactionsByUser(ctx context.Context, req *ListTransactionsByUserRequest) (*ListTransactionsResponse, error) {
+	resp := new(ListTransactionsResponse)
+	if err := c.client.Call(ctx, "PaymentService", "ListTransactionsByUser", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcPaymentServiceClient) ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*AuditEntries, error) {
+	resp := new(AuditEntries)
+	if err := c.client.Call(ctx, "PaymentService", "ListAuditEntries", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcPaymentServiceClient) Refund(ctx context.Context, req *RefundRequest) (*Transaction, error) {
+	resp := new(Transaction)
+	if err := c.client.Call(ctx, "PaymentService", "Refund", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type PaymentServiceServer interface {
+	Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, context.Context, error)
+	GetTransaction(ctx context.Context, req *GetTransactionRequest) (*Transaction, context.Context, error)
+	ListTransactionsByUser(ctx context.Context, req *ListTransactionsByUserRequest) (*ListTransactionsResponse, context.Context, error)
+	ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*AuditEntries, context.Context, error)
+	Refund(ctx context.Context, req *RefundRequest) (*Transaction, context.Context, error)
+}
+
+func RegisterPaymentServiceServer(s *rpc.Server, srv PaymentServiceServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceName: "PaymentService",
+		ServiceImpl: srv,
+		Methods: map[string]*rpc.MethodDesc{
+			"Charge": {
+				MethodName: "Charge",
+				Handler:    _PaymentService_Charge_Handler,
+			},
+			"GetTransaction": {
+				MethodName: "GetTransaction",
+				Handler:    _PaymentService_GetTransaction_Handler,
+			},
+			"ListTransactionsByUser": {
+				MethodName: "ListTransactionsByUser",
+				Handler:    _PaymentService_ListTransactionsByUser_Handler,
+			},
+			"ListAuditEntries": {
+				MethodName: "ListAuditEntries",
+				Handler:    _PaymentService_ListAuditEntries_Handler,
+			},
+			"Refund": {
+				MethodName: "Refund",
+				Handler:    _PaymentService_Refund_Handler,
+			},
+		},
+	}, srv)
+}
+
+func _PaymentService_Charge_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ChargeRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(PaymentServiceServer).Charge(ctx, req.Payload.(*ChargeRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _PaymentService_GetTransaction_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetTransactionRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(PaymentServiceServer).GetTransaction(ctx, req.Payload.(*GetTransactionRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _PaymentService_ListTransactionsByUser_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ListTransactionsByUserRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(PaymentServiceServer).ListTransactionsByUser(ctx, req.Payload.(*ListTransactionsByUserRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _PaymentService_ListAuditEntries_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ListAuditEntriesRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(PaymentServiceServer).ListAuditEntries(ctx, req.Payload.(*ListAuditEntriesRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _PaymentService_Refund_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(RefundRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(PaymentServiceServer).Refund(ctx, req.Payload.(*RefundRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+// WalletServiceClient is the client API for WalletService service.
+type WalletServiceClient interface {
+	GetBalance(ctx context.Context, req *GetWalletBalanceRequest) (*WalletBalance, error)
+	RedeemGiftCard(ctx context.Context, req *RedeemGiftCardRequest) (*WalletBalance, error)
+	Debit(ctx context.Context, req *WalletDebitRequest) (*WalletBalance, error)
+	Refund(ctx context.Context, req *WalletRefundRequest) (*WalletBalance, error)
+	ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*AuditEntries, error)
+}
+
+type arpcWalletServiceClient struct {
+	client *rpc.Client
+}
+
+func NewWalletServiceClient(client *rpc.Client) WalletServiceClient {
+	return &arpcWalletServiceClient{client: client}
+}
+
+func (c *arpcWalletServiceClient) GetBalance(ctx context.Context, req *GetWalletBalanceRequest) (*WalletBalance, error) {
+	resp := new(WalletBalance)
+	if err := c.client.Call(ctx, "WalletService", "GetBalance", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcWalletServiceClient) RedeemGiftCard(ctx context.Context, req *RedeemGiftCardRequest) (*WalletBalance, error) {
+	resp := new(WalletBalance)
+	if err := c.client.Call(ctx, "WalletService", "RedeemGiftCard", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcWalletServiceClient) Debit(ctx context.Context, req *WalletDebitRequest) (*WalletBalance, error) {
+	resp := new(WalletBalance)
+	if err := c.client.Call(ctx, "WalletService", "Debit", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcWalletServiceClient) Refund(ctx context.Context, req *WalletRefundRequest) (*WalletBalance, error) {
+	resp := new(WalletBalance)
+	if err := c.client.Call(ctx, "WalletService", "Refund", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcWalletServiceClient) ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*AuditEntries, error) {
+	resp := new(AuditEntries)
+	if err := c.client.Call(ctx, "WalletService", "ListAuditEntries", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type WalletServiceServer interface {
+	GetBalance(ctx context.Context, req *GetWalletBalanceRequest) (*WalletBalance, context.Context, error)
+	RedeemGiftCard(ctx context.Context, req *RedeemGiftCardRequest) (*WalletBalance, context.Context, error)
+	Debit(ctx context.Context, req *WalletDebitRequest) (*WalletBalance, context.Context, error)
+	Refund(ctx context.Context, req *WalletRefundRequest) (*WalletBalance, context.Context, error)
+	ListAuditEntries(ctx context.Context, req *ListAuditEntriesRequest) (*AuditEntries, context.Context, error)
+}
+
+func RegisterWalletServiceServer(s *rpc.Server, srv WalletServiceServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceName: "WalletService",
+		ServiceImpl: srv,
+		Methods: map[string]*rpc.MethodDesc{
+			"GetBalance": {
+				MethodName: "GetBalance",
+				Handler:    _WalletService_GetBalance_Handler,
+			},
+			"RedeemGiftCard": {
+				MethodName: "RedeemGiftCard",
+				Handler:    _WalletService_RedeemGiftCard_Handler,
+			},
+			"Debit": {
+				MethodName: "Debit",
+				Handler:    _WalletService_Debit_Handler,
+			},
+			"Refund": {
+				MethodName: "Refund",
+				Handler:    _WalletService_Refund_Handler,
+			},
+			"ListAuditEntries": {
+				MethodName: "ListAuditEntries",
+				Handler:    _WalletService_ListAuditEntries_Handler,
+			},
+		},
+	}, srv)
+}
+
+func _WalletService_GetBalance_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetWalletBalanceRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(WalletServiceServer).GetBalance(ctx, req.Payload.(*GetWalletBalanceRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _WalletService_RedeemGiftCard_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(RedeemGiftCardRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(WalletServiceServer).RedeemGiftCard(ctx, req.Payload.(*RedeemGiftCardRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _WalletService_Debit_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(WalletDebitRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(WalletServiceServer).Debit(ctx, req.Payload.(*WalletDebitRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _WalletService_Refund_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(WalletRefundRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(WalletServiceServer).Refund(ctx, req.Payload.(*WalletRefundRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _WalletService_ListAuditEntries_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ListAuditEntriesRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(WalletServiceServer).ListAuditEntries(ctx, req.Payload.(*ListAuditEntriesRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+// EmailServiceClient is the client API for EmailService service.
+type EmailServiceClient interface {
+	SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, error)
+	GetReceipt(ctx context.Context, req *GetReceiptRequest) (*GetReceiptResponse, error)
+	SendCampaign(ctx context.Context, req *SendCampaignRequest) (*CampaignResult, error)
+	Unsubscribe(ctx context.Context, req *UnsubscribeRequest) (*Empty, error)
+	SendTicketAcknowledgement(ctx context.Context, req *SendTicketAcknowledgementRequest) (*Empty, error)
+}
+
+type arpcEmailServiceClient struct {
+	client *rpc.Client
+}
+
+func NewEmailServiceClient(client *rpc.Client) EmailServiceClient {
+	return &arpcEmailServiceClient{client: client}
+}
+
+func (c *arpcEmailServiceClient) SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.client.Call(ctx, "EmailService", "SendOrderConfirmation", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcEmailServiceClient) GetReceipt(ctx context.Context, req *GetReceiptRequest) (*GetReceiptResponse, error) {
+	resp := new(GetReceiptResponse)
+	if err := c.client.Call(ctx, "EmailService", "GetReceipt", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcEmailServiceClient) SendCampaign(ctx context.Context, req *SendCampaignRequest) (*CampaignResult, error) {
+	resp := new(CampaignResult)
+	if err := c.client.Call(ctx, "EmailService", "SendCampaign", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcEmailServiceClient) Unsubscribe(ctx context.Context, req *UnsubscribeRequest) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.client.Call(ctx, "EmailService", "Unsubscribe", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcEmailServiceClient) SendTicketAcknowledgement(ctx context.Context, req *SendTicketAcknowledgementRequest) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.client.Call(ctx, "EmailService", "SendTicketAcknowledgement", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+type EmailServiceServer interface {
+	SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, context.Context, error)
+	GetReceipt(ctx context.Context, req *GetReceiptRequest) (*GetReceiptResponse, context.Context, error)
+	SendCampaign(ctx context.Context, req *SendCampaignRequest) (*CampaignResult, context.Context, error)
+	Unsubscribe(ctx context.Context, req *UnsubscribeRequest) (*Empty, context.Context, error)
+	SendTicketAcknowledgement(ctx context.Context, req *SendTicketAcknowledgementRequest) (*Empty, context.Context, error)
+}
+
+func RegisterEmailServiceServer(s *rpc.Server, srv EmailServiceServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceName: "EmailService",
+		ServiceImpl: srv,
+		Methods: map[string]*rpc.MethodDesc{
+			"SendOrderConfirmation": {
+				MethodName: "SendOrderConfirmation",
+				Handler:    _EmailService_SendOrderConfirmation_Handler,
+			},
+			"GetReceipt": {
+				MethodName: "GetReceipt",
+				Handler:    _EmailService_GetReceipt_Handler,
+			},
+			"SendCampaign": {
+				MethodName: "SendCampaign",
+				Handler:    _EmailService_SendCampaign_Handler,
+			},
+			"Unsubscribe": {
+				MethodName: "Unsubscribe",
+				Handler:    _EmailService_Unsubscribe_Handler,
+			},
+			"SendTicketAcknowledgement": {
+				MethodName: "SendTicketAcknowledgement",
+				Handler:    _EmailService_SendTicketAcknowledgement_Handler,
+			},
+		},
+	}, srv)
+}
+
+func _EmailService_SendOrderConfirmation_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(SendOrderConfirmationRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(EmailServiceServer).SendOrderConfirmation(ctx, req.Payload.(*SendOrderConfirmationRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _EmailService_GetReceipt_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetReceiptRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(EmailServiceServer).GetReceipt(ctx, req.Payload.(*GetReceiptRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _EmailService_SendCampaign_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(SendCampaignRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(EmailServiceServer).SendCampaign(ctx, req.Payload.(*SendCampaignRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _EmailService_Unsubscribe_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(UnsubscribeRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(EmailServiceServer).Unsubscribe(ctx, req.Payload.(*UnsubscribeRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+func _EmailService_SendTicketAcknowledgement_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(SendTicketAcknowledgementRequest)
+	if err := dec(req.Payload); err != nil {
+		return nil, ctx, err
+	}
+	req, ctx, err := chain.ProcessRequest(ctx, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	result, ctx, err := srv.(EmailServiceServer).SendTicketAcknowledgement(ctx, req.Payload.(*SendTicketAcknowledgementRequest))
+	if err != nil {
+		return nil, ctx, err
+	}
+	resp := &element.RPCResponse{
+		ID:     req.ID,
+		Result: result,
+	}
+	resp, ctx, err = chain.ProcessResponse(ctx, resp)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return resp, ctx, err
+}
+
+// CheckoutServiceClient is the client API for CheckoutService service.
+type CheckoutServiceClient interface {
+	PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error)
+	PreviewOrder(ctx context.Context, req *PlaceOrderRequest) (*OrderPreview, error)
+	GetOrderStatus(ctx context.Context, req *GetOrderStatusRequest) (*OrderStatus, error)
+}
+
+type arpcCheckoutServiceClient struct {
+	client *rpc.Client
+}
+
+func NewCheckoutServiceClient(client *rpc.Client) CheckoutServiceClient {
+	return &arpcCheckoutServiceClient{client: client}
+}
+
+func (c *arpcCheckoutServiceClient) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
+	resp := new(PlaceOrderResponse)
+	if err := c.client.Call(ctx, "CheckoutService", "PlaceOrder", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcCheckoutServiceClient) PreviewOrder(ctx context.Context, req *PlaceOrderRequest) (*OrderPreview, error) {
+	resp := new(OrderPreview)
+	if err := c.client.Call(ctx, "CheckoutService", "PreviewOrder", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *arpcCheckoutServiceClient) GetOrderStatus(ctx context.Context, req *GetOrderStatusRequest) (*OrderStatus, error) {
+	resp := new(OrderStatus)
+	if err := c.client.Call(ctx, "CheckoutService", "GetOrderStatus", req, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
-func RegisterProductCatalogServiceServer(s *rpc.Server, srv ProductCatalogServiceServer) {
+type CheckoutServiceServer interface {
+	PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, context.Context, error)
+	PreviewOrder(ctx context.Context, req *PlaceOrderRequest) (*OrderPreview, context.Context, error)
+	GetOrderStatus(ctx context.Context, req *GetOrderStatusRequest) (*OrderStatus, context.Context, error)
+}
+
+func RegisterCheckoutServiceServer(s *rpc.Server, srv CheckoutServiceServer) {
 	s.RegisterService(&rpc.ServiceDesc{
-		ServiceName: "ProductCatalogService",
+		ServiceName: "CheckoutService",
 		ServiceImpl: srv,
 		Methods: map[string]*rpc.MethodDesc{
-			"ListProducts": {
-				MethodName: "ListProducts",
-				Handler:    _ProductCatalogService_ListProducts_Handler,
+			"PlaceOrder": {
+				MethodName: "PlaceOrder",
+				Handler:    _CheckoutService_PlaceOrder_Handler,
 			},
-			"GetProduct": {
-				MethodName: "GetProduct",
-				Handler:    _ProductCatalogService_GetProduct_Handler,
+			"PreviewOrder": {
+				MethodName: "PreviewOrder",
+				Handler:    _CheckoutService_PreviewOrder_Handler,
 			},
-			"SearchProducts": {
-				MethodName: "SearchProducts",
-				Handler:    _ProductCatalogService_SearchProducts_Handler,
+			"GetOrderStatus": {
+				MethodName: "GetOrderStatus",
+				Handler:    _CheckoutService_GetOrderStatus_Handler,
 			},
 		},
 	}, srv)
 }
 
-func _ProductCatalogService_ListProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(EmptyUser)
+func _CheckoutService_PlaceOrder_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(PlaceOrderRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -282,7 +2122,7 @@ func _ProductCatalogService_ListProducts_Handler(srv any, ctx context.Context, d
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(ProductCatalogServiceServer).ListProducts(ctx, req.Payload.(*EmptyUser))
+	result, ctx, err := srv.(CheckoutServiceServer).PlaceOrder(ctx, req.Payload.(*PlaceOrderRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -297,8 +2137,8 @@ func _ProductCatalogService_ListProducts_Handler(srv any, ctx context.Context, d
 	return resp, ctx, err
 }
 
-func _ProductCatalogService_GetProduct_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(GetProductRequest)
+func _CheckoutService_PreviewOrder_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(PlaceOrderRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -306,7 +2146,7 @@ func _ProductCatalogService_GetProduct_Handler(srv any, ctx context.Context, dec
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(ProductCatalogServiceServer).GetProduct(ctx, req.Payload.(*GetProductRequest))
+	result, ctx, err := srv.(CheckoutServiceServer).PreviewOrder(ctx, req.Payload.(*PlaceOrderRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -321,8 +2161,8 @@ func _ProductCatalogService_GetProduct_Handler(srv any, ctx context.Context, dec
 	return resp, ctx, err
 }
 
-func _ProductCatalogService_SearchProducts_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(SearchProductsRequest)
+func _CheckoutService_GetOrderStatus_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetOrderStatusRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -330,7 +2170,7 @@ func _ProductCatalogService_SearchProducts_Handler(srv any, ctx context.Context,
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(ProductCatalogServiceServer).SearchProducts(ctx, req.Payload.(*SearchProductsRequest))
+	result, ctx, err := srv.(CheckoutServiceServer).GetOrderStatus(ctx, req.Payload.(*GetOrderStatusRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -345,60 +2185,60 @@ func _ProductCatalogService_SearchProducts_Handler(srv any, ctx context.Context,
 	return resp, ctx, err
 }
 
-// ShippingServiceClient is the client API for ShippingService service.
-type ShippingServiceClient interface {
-	GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, error)
-	ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, error)
+// AdServiceClient is the client API for AdService service.
+type AdServiceClient interface {
+	GetAds(ctx context.Context, req *AdRequest) (*AdResponse, error)
+	RecordAdClick(ctx context.Context, req *AdClickRequest) (*Empty, error)
 }
 
-type arpcShippingServiceClient struct {
+type arpcAdServiceClient struct {
 	client *rpc.Client
 }
 
-func NewShippingServiceClient(client *rpc.Client) ShippingServiceClient {
-	return &arpcShippingServiceClient{client: client}
+func NewAdServiceClient(client *rpc.Client) AdServiceClient {
+	return &arpcAdServiceClient{client: client}
 }
 
-func (c *arpcShippingServiceClient) GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, error) {
-	resp := new(GetQuoteResponse)
-	if err := c.client.Call(ctx, "ShippingService", "GetQuote", req, resp); err != nil {
+func (c *arpcAdServiceClient) GetAds(ctx context.Context, req *AdRequest) (*AdResponse, error) {
+	resp := new(AdResponse)
+	if err := c.client.Call(ctx, "AdService", "GetAds", req, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-func (c *arpcShippingServiceClient) ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, error) {
-	resp := new(ShipOrderResponse)
-	if err := c.client.Call(ctx, "ShippingService", "ShipOrder", req, resp); err != nil {
+func (c *arpcAdServiceClient) RecordAdClick(ctx context.Context, req *AdClickRequest) (*Empty, error) {
+	resp := new(Empty)
+	if err := c.client.Call(ctx, "AdService", "RecordAdClick", req, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-type ShippingServiceServer interface {
-	GetQuote(ctx context.Context, req *GetQuoteRequest) (*GetQuoteResponse, context.Context, error)
-	ShipOrder(ctx context.Context, req *ShipOrderRequest) (*ShipOrderResponse, context.Context, error)
+type AdServiceServer interface {
+	GetAds(ctx context.Context, req *AdRequest) (*AdResponse, context.Context, error)
+	RecordAdClick(ctx context.Context, req *AdClickRequest) (*Empty, context.Context, error)
 }
 
-func RegisterShippingServiceServer(s *rpc.Server, srv ShippingServiceServer) {
+func RegisterAdServiceServer(s *rpc.Server, srv AdServiceServer) {
 	s.RegisterService(&rpc.ServiceDesc{
-		ServiceName: "ShippingService",
+		ServiceName: "AdService",
 		ServiceImpl: srv,
 		Methods: map[string]*rpc.MethodDesc{
-			"GetQuote": {
-				MethodName: "GetQuote",
-				Handler:    _ShippingService_GetQuote_Handler,
+			"GetAds": {
+				MethodName: "GetAds",
+				Handler:    _AdService_GetAds_Handler,
 			},
-			"ShipOrder": {
-				MethodName: "ShipOrder",
-				Handler:    _ShippingService_ShipOrder_Handler,
+			"RecordAdClick": {
+				MethodName: "RecordAdClick",
+				Handler:    _AdService_RecordAdClick_Handler,
 			},
 		},
 	}, srv)
 }
 
-func _ShippingService_GetQuote_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(GetQuoteRequest)
+func _AdService_GetAds_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(AdRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -406,7 +2246,7 @@ func _ShippingService_GetQuote_Handler(srv any, ctx context.Context, dec func(an
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(ShippingServiceServer).GetQuote(ctx, req.Payload.(*GetQuoteRequest))
+	result, ctx, err := srv.(AdServiceServer).GetAds(ctx, req.Payload.(*AdRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -421,8 +2261,8 @@ func _ShippingService_GetQuote_Handler(srv any, ctx context.Context, dec func(an
 	return resp, ctx, err
 }
 
-func _ShippingService_ShipOrder_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(ShipOrderRequest)
+func _AdService_RecordAdClick_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(AdClickRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -430,7 +2270,7 @@ func _ShippingService_ShipOrder_Handler(srv any, ctx context.Context, dec func(a
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(ShippingServiceServer).ShipOrder(ctx, req.Payload.(*ShipOrderRequest))
+	result, ctx, err := srv.(AdServiceServer).RecordAdClick(ctx, req.Payload.(*AdClickRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -445,60 +2285,60 @@ func _ShippingService_ShipOrder_Handler(srv any, ctx context.Context, dec func(a
 	return resp, ctx, err
 }
 
-// CurrencyServiceClient is the client API for CurrencyService service.
-type CurrencyServiceClient interface {
-	GetSupportedCurrencies(ctx context.Context, req *EmptyUser) (*GetSupportedCurrenciesResponse, error)
-	Convert(ctx context.Context, req *CurrencyConversionRequest) (*Money, error)
+// PrivacyServiceClient is the client API for PrivacyService service.
+type PrivacyServiceClient interface {
+	ExportUserData(ctx context.Context, req *UserDataRequest) (*UserData, error)
+	DeleteUserData(ctx context.Context, req *UserDataRequest) (*UserData, error)
 }
 
-type arpcCurrencyServiceClient struct {
+type arpcPrivacyServiceClient struct {
 	client *rpc.Client
 }
 
-func NewCurrencyServiceClient(client *rpc.Client) CurrencyServiceClient {
-	return &arpcCurrencyServiceClient{client: client}
+func NewPrivacyServiceClient(client *rpc.Client) PrivacyServiceClient {
+	return &arpcPrivacyServiceClient{client: client}
 }
 
-func (c *arpcCurrencyServiceClient) GetSupportedCurrencies(ctx context.Context, req *EmptyUser) (*GetSupportedCurrenciesResponse, error) {
-	resp := new(GetSupportedCurrenciesResponse)
-	if err := c.client.Call(ctx, "CurrencyService", "GetSupportedCurrencies", req, resp); err != nil {
+func (c *arpcPrivacyServiceClient) ExportUserData(ctx context.Context, req *UserDataRequest) (*UserData, error) {
+	resp := new(UserData)
+	if err := c.client.Call(ctx, "PrivacyService", "ExportUserData", req, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-func (c *arpcCurrencyServiceClient) Convert(ctx context.Context, req *CurrencyConversionRequest) (*Money, error) {
-	resp := new(Money)
-	if err := c.client.Call(ctx, "CurrencyService", "Convert", req, resp); err != nil {
+func (c *arpcPrivacyServiceClient) DeleteUserData(ctx context.Context, req *UserDataRequest) (*UserData, error) {
+	resp := new(UserData)
+	if err := c.client.Call(ctx, "PrivacyService", "DeleteUserData", req, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-type CurrencyServiceServer interface {
-	GetSupportedCurrencies(ctx context.Context, req *EmptyUser) (*GetSupportedCurrenciesResponse, context.Context, error)
-	Convert(ctx context.Context, req *CurrencyConversionRequest) (*Money, context.Context, error)
+type PrivacyServiceServer interface {
+	ExportUserData(ctx context.Context, req *UserDataRequest) (*UserData, context.Context, error)
+	DeleteUserData(ctx context.Context, req *UserDataRequest) (*UserData, context.Context, error)
 }
 
-func RegisterCurrencyServiceServer(s *rpc.Server, srv CurrencyServiceServer) {
+func RegisterPrivacyServiceServer(s *rpc.Server, srv PrivacyServiceServer) {
 	s.RegisterService(&rpc.ServiceDesc{
-		ServiceName: "CurrencyService",
+		ServiceName: "PrivacyService",
 		ServiceImpl: srv,
 		Methods: map[string]*rpc.MethodDesc{
-			"GetSupportedCurrencies": {
-				MethodName: "GetSupportedCurrencies",
-				Handler:    _CurrencyService_GetSupportedCurrencies_Handler,
+			"ExportUserData": {
+				MethodName: "ExportUserData",
+				Handler:    _PrivacyService_ExportUserData_Handler,
 			},
-			"Convert": {
-				MethodName: "Convert",
-				Handler:    _CurrencyService_Convert_Handler,
+			"DeleteUserData": {
+				MethodName: "DeleteUserData",
+				Handler:    _PrivacyService_DeleteUserData_Handler,
 			},
 		},
 	}, srv)
 }
 
-func _CurrencyService_GetSupportedCurrencies_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(EmptyUser)
+func _PrivacyService_ExportUserData_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(UserDataRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -506,7 +2346,7 @@ func _CurrencyService_GetSupportedCurrencies_Handler(srv any, ctx context.Contex
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(CurrencyServiceServer).GetSupportedCurrencies(ctx, req.Payload.(*EmptyUser))
+	result, ctx, err := srv.(PrivacyServiceServer).ExportUserData(ctx, req.Payload.(*UserDataRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -521,8 +2361,8 @@ func _CurrencyService_GetSupportedCurrencies_Handler(srv any, ctx context.Contex
 	return resp, ctx, err
 }
 
-func _CurrencyService_Convert_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(CurrencyConversionRequest)
+func _PrivacyService_DeleteUserData_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(UserDataRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -530,7 +2370,7 @@ func _CurrencyService_Convert_Handler(srv any, ctx context.Context, dec func(any
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(CurrencyServiceServer).Convert(ctx, req.Payload.(*CurrencyConversionRequest))
+	result, ctx, err := srv.(PrivacyServiceServer).DeleteUserData(ctx, req.Payload.(*UserDataRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -545,46 +2385,46 @@ func _CurrencyService_Convert_Handler(srv any, ctx context.Context, dec func(any
 	return resp, ctx, err
 }
 
-// PaymentServiceClient is the client API for PaymentService service.
-type PaymentServiceClient interface {
-	Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error)
+// AnalyticsServiceClient is the client API for AnalyticsService service.
+type AnalyticsServiceClient interface {
+	GetReferralStats(ctx context.Context, req *ReferralStatsRequest) (*ReferralStats, error)
 }
 
-type arpcPaymentServiceClient struct {
+type arpcAnalyticsServiceClient struct {
 	client *rpc.Client
 }
 
-func NewPaymentServiceClient(client *rpc.Client) PaymentServiceClient {
-	return &arpcPaymentServiceClient{client: client}
+func NewAnalyticsServiceClient(client *rpc.Client) AnalyticsServiceClient {
+	return &arpcAnalyticsServiceClient{client: client}
 }
 
-func (c *arpcPaymentServiceClient) Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
-	resp := new(ChargeResponse)
-	if err := c.client.Call(ctx, "PaymentService", "Charge", req, resp); err != nil {
+func (c *arpcAnalyticsServiceClient) GetReferralStats(ctx context.Context, req *ReferralStatsRequest) (*ReferralStats, error) {
+	resp := new(ReferralStats)
+	if err := c.client.Call(ctx, "AnalyticsService", "GetReferralStats", req, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-type PaymentServiceServer interface {
-	Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, context.Context, error)
+type AnalyticsServiceServer interface {
+	GetReferralStats(ctx context.Context, req *ReferralStatsRequest) (*ReferralStats, context.Context, error)
 }
 
-func RegisterPaymentServiceServer(s *rpc.Server, srv PaymentServiceServer) {
+func RegisterAnalyticsServiceServer(s *rpc.Server, srv AnalyticsServiceServer) {
 	s.RegisterService(&rpc.ServiceDesc{
-		ServiceName: "PaymentService",
+		ServiceName: "AnalyticsService",
 		ServiceImpl: srv,
 		Methods: map[string]*rpc.MethodDesc{
-			"Charge": {
-				MethodName: "Charge",
-				Handler:    _PaymentService_Charge_Handler,
+			"GetReferralStats": {
+				MethodName: "GetReferralStats",
+				Handler:    _AnalyticsService_GetReferralStats_Handler,
 			},
 		},
 	}, srv)
 }
 
-func _PaymentService_Charge_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(ChargeRequest)
+func _AnalyticsService_GetReferralStats_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(ReferralStatsRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -592,7 +2432,7 @@ func _PaymentService_Charge_Handler(srv any, ctx context.Context, dec func(any)
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(PaymentServiceServer).Charge(ctx, req.Payload.(*ChargeRequest))
+	result, ctx, err := srv.(AnalyticsServiceServer).GetReferralStats(ctx, req.Payload.(*ReferralStatsRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -607,108 +2447,60 @@ func _PaymentService_Charge_Handler(srv any, ctx context.Context, dec func(any)
 	return resp, ctx, err
 }
 
-// EmailServiceClient is the client API for EmailService service.
-type EmailServiceClient interface {
-	SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, error)
+// SupportServiceClient is the client API for SupportService service.
+type SupportServiceClient interface {
+	CreateTicket(ctx context.Context, req *CreateTicketRequest) (*Ticket, error)
+	GetTicket(ctx context.Context, req *GetTicketRequest) (*Ticket, error)
 }
 
-type arpcEmailServiceClient struct {
+type arpcSupportServiceClient struct {
 	client *rpc.Client
 }
 
-func NewEmailServiceClient(client *rpc.Client) EmailServiceClient {
-	return &arpcEmailServiceClient{client: client}
+func NewSupportServiceClient(client *rpc.Client) SupportServiceClient {
+	return &arpcSupportServiceClient{client: client}
 }
 
-func (c *arpcEmailServiceClient) SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, error) {
-	resp := new(Empty)
-	if err := c.client.Call(ctx, "EmailService", "SendOrderConfirmation", req, resp); err != nil {
+func (c *arpcSupportServiceClient) CreateTicket(ctx context.Context, req *CreateTicketRequest) (*Ticket, error) {
+	resp := new(Ticket)
+	if err := c.client.Call(ctx, "SupportService", "CreateTicket", req, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-type EmailServiceServer interface {
-	SendOrderConfirmation(ctx context.Context, req *SendOrderConfirmationRequest) (*Empty, context.Context, error)
-}
-
-func RegisterEmailServiceServer(s *rpc.Server, srv EmailServiceServer) {
-	s.RegisterService(&rpc.ServiceDesc{
-		ServiceName: "EmailService",
-		ServiceImpl: srv,
-		Methods: map[string]*rpc.MethodDesc{
-			"SendOrderConfirmation": {
-				MethodName: "SendOrderConfirmation",
-				Handler:    _EmailService_SendOrderConfirmation_Handler,
-			},
-		},
-	}, srv)
-}
-
-func _EmailService_SendOrderConfirmation_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(SendOrderConfirmationRequest)
-	if err := dec(req.Payload); err != nil {
-		return nil, ctx, err
-	}
-	req, ctx, err := chain.ProcessRequest(ctx, req)
-	if err != nil {
-		return nil, ctx, err
-	}
-	result, ctx, err := srv.(EmailServiceServer).SendOrderConfirmation(ctx, req.Payload.(*SendOrderConfirmationRequest))
-	if err != nil {
-		return nil, ctx, err
-	}
-	resp := &element.RPCResponse{
-		ID:     req.ID,
-		Result: result,
-	}
-	resp, ctx, err = chain.ProcessResponse(ctx, resp)
-	if err != nil {
-		return nil, ctx, err
-	}
-	return resp, ctx, err
-}
-
-// CheckoutServiceClient is the client API for CheckoutService service.
-type CheckoutServiceClient interface {
-	PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error)
-}
-
-type arpcCheckoutServiceClient struct {
-	client *rpc.Client
-}
-
-func NewCheckoutServiceClient(client *rpc.Client) CheckoutServiceClient {
-	return &arpcCheckoutServiceClient{client: client}
-}
-
-func (c *arpcCheckoutServiceClient) PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, error) {
-	resp := new(PlaceOrderResponse)
-	if err := c.client.Call(ctx, "CheckoutService", "PlaceOrder", req, resp); err != nil {
+func (c *arpcSupportServiceClient) GetTicket(ctx context.Context, req *GetTicketRequest) (*Ticket, error) {
+	resp := new(Ticket)
+	if err := c.client.Call(ctx, "SupportService", "GetTicket", req, resp); err != nil {
 		return nil, err
 	}
 	return resp, nil
 }
 
-type CheckoutServiceServer interface {
-	PlaceOrder(ctx context.Context, req *PlaceOrderRequest) (*PlaceOrderResponse, context.Context, error)
+type SupportServiceServer interface {
+	CreateTicket(ctx context.Context, req *CreateTicketRequest) (*Ticket, context.Context, error)
+	GetTicket(ctx context.Context, req *GetTicketRequest) (*Ticket, context.Context, error)
 }
 
-func RegisterCheckoutServiceServer(s *rpc.Server, srv CheckoutServiceServer) {
+func RegisterSupportServiceServer(s *rpc.Server, srv SupportServiceServer) {
 	s.RegisterService(&rpc.ServiceDesc{
-		ServiceName: "CheckoutService",
+		ServiceName: "SupportService",
 		ServiceImpl: srv,
 		Methods: map[string]*rpc.MethodDesc{
-			"PlaceOrder": {
-				MethodName: "PlaceOrder",
-				Handler:    _CheckoutService_PlaceOrder_Handler,
+			"CreateTicket": {
+				MethodName: "CreateTicket",
+				Handler:    _SupportService_CreateTicket_Handler,
+			},
+			"GetTicket": {
+				MethodName: "GetTicket",
+				Handler:    _SupportService_GetTicket_Handler,
 			},
 		},
 	}, srv)
 }
 
-func _CheckoutService_PlaceOrder_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(PlaceOrderRequest)
+func _SupportService_CreateTicket_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(CreateTicketRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -716,7 +2508,7 @@ func _CheckoutService_PlaceOrder_Handler(srv any, ctx context.Context, dec func(
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(CheckoutServiceServer).PlaceOrder(ctx, req.Payload.(*PlaceOrderRequest))
+	result, ctx, err := srv.(SupportServiceServer).CreateTicket(ctx, req.Payload.(*CreateTicketRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -731,46 +2523,8 @@ func _CheckoutService_PlaceOrder_Handler(srv any, ctx context.Context, dec func(
 	return resp, ctx, err
 }
 
-// AdServiceClient is the client API for AdService service.
-type AdServiceClient interface {
-	GetAds(ctx context.Context, req *AdRequest) (*AdResponse, error)
-}
-
-type arpcAdServiceClient struct {
-	client *rpc.Client
-}
-
-func NewAdServiceClient(client *rpc.Client) AdServiceClient {
-	return &arpcAdServiceClient{client: client}
-}
-
-func (c *arpcAdServiceClient) GetAds(ctx context.Context, req *AdRequest) (*AdResponse, error) {
-	resp := new(AdResponse)
-	if err := c.client.Call(ctx, "AdService", "GetAds", req, resp); err != nil {
-		return nil, err
-	}
-	return resp, nil
-}
-
-type AdServiceServer interface {
-	GetAds(ctx context.Context, req *AdRequest) (*AdResponse, context.Context, error)
-}
-
-func RegisterAdServiceServer(s *rpc.Server, srv AdServiceServer) {
-	s.RegisterService(&rpc.ServiceDesc{
-		ServiceName: "AdService",
-		ServiceImpl: srv,
-		Methods: map[string]*rpc.MethodDesc{
-			"GetAds": {
-				MethodName: "GetAds",
-				Handler:    _AdService_GetAds_Handler,
-			},
-		},
-	}, srv)
-}
-
-func _AdService_GetAds_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
-	req.Payload = new(AdRequest)
+func _SupportService_GetTicket_Handler(srv any, ctx context.Context, dec func(any) error, req *element.RPCRequest, chain *element.RPCElementChain) (*element.RPCResponse, context.Context, error) {
+	req.Payload = new(GetTicketRequest)
 	if err := dec(req.Payload); err != nil {
 		return nil, ctx, err
 	}
@@ -778,7 +2532,7 @@ func _AdService_GetAds_Handler(srv any, ctx context.Context, dec func(any) error
 	if err != nil {
 		return nil, ctx, err
 	}
-	result, ctx, err := srv.(AdServiceServer).GetAds(ctx, req.Payload.(*AdRequest))
+	result, ctx, err := srv.(SupportServiceServer).GetTicket(ctx, req.Payload.(*GetTicketRequest))
 	if err != nil {
 		return nil, ctx, err
 	}
@@ -1,39 +1,105 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"math/rand"
+	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
+	"github.com/appnet-org/arpc/pkg/metadata"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/redis/go-redis/v9"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
 const (
 	maxAdsToServe = 2
 )
 
+// Arms of the ad ranking experiment.
+const (
+	adExperimentControl = "control"
+	adExperimentRanked  = "ranked"
+)
+
+// Metadata keys carrying the shopper's context to GetAds. Recent categories
+// are comma separated, most recent first.
+const (
+	mdShopCurrency         = "x-shop-currency"
+	mdShopExperiment       = "x-shop-experiment"
+	mdShopRecentCategories = "x-shop-recent-categories"
+	mdShopSession          = "x-shop-session"
+)
+
+// adCurrencies lists, by ad category, the currencies of the markets a
+// promotion is aimed at.
+var adCurrencies = map[string][]string{
+	"hair":        {"USD", "CAD"},
+	"clothing":    {"EUR", "GBP"},
+	"accessories": {"USD", "JPY"},
+	"decor":       {"EUR"},
+	"kitchen":     {"JPY"},
+}
+
 // NewAdService returns a new server for the AdService
 func NewAdService(port int) *AdService {
+	var current map[string]*pb.Ad
 	return &AdService{
 		port: port,
-		ads:  createAdsMap(),
+		ads: config.NewValue(func() map[string]*pb.Ad {
+			ads, err := loadAds("data/ads.json")
+			if err == nil {
+				current = ads
+			} else if current == nil {
+				log.Fatalf("Failed to load ads: %v", err)
+			} else {
+				log.Printf("Failed to reload ads, keeping current ones: %v", err)
+			}
+			return current
+		}),
 	}
 }
 
 // AdService implements the AdService
 type AdService struct {
 	port int
-	ads  map[string]*pb.Ad
+	ads  *config.Value[map[string]*pb.Ad]
+
+	rdb redis.UniversalClient // Session -> impressions per ad category
+
+	// impressionCap is how often a session sees an ad within
+	// impressionWindow before lower ranked ads take its place.
+	impressionCap    *config.Value[int]
+	impressionWindow *config.Value[time.Duration]
+
+	eventBusAddr string
+	// events batches impressions and clicks for the event bus.
+	events *eventbus.Batcher[*pb.AdEvent]
 }
 
+// Kinds of ad events.
+const (
+	adEventImpression = "IMPRESSION"
+	adEventClick      = "CLICK"
+)
+
 // Run starts the server
 func (s *AdService) Run() error {
 	err := logging.Init(getLoggingConfig())
@@ -41,8 +107,19 @@ func (s *AdService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
-	serializer := &serializer.SymphonySerializer{}
+	s.rdb = newRedisClient("AD")
+	s.impressionCap = config.NewValue(func() int { return envInt("AD_IMPRESSION_CAP", 3) })
+	s.impressionWindow = config.NewValue(func() time.Duration { return envDuration("AD_IMPRESSION_WINDOW", time.Hour) })
+
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.events = eventbus.NewBatcher[*pb.AdEvent](eventbus.New(s.eventBusAddr), eventbus.TopicAdEvents, eventbus.BatchConfig{
+		Size:     envInt("AD_EVENT_BATCH_SIZE", 100),
+		Interval: envDuration("AD_EVENT_FLUSH_INTERVAL", time.Second),
+		Buffer:   envInt("AD_EVENT_BUFFER", 10000),
+	})
+
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
+	serializer := codec.NewServer()
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
@@ -55,40 +132,201 @@ func (s *AdService) Run() error {
 }
 
 // GetAds returns a list of ads based on the context keys
-func (s *AdService) GetAds(ctx context.Context, req *pb.AdRequest) (*pb.AdResponse, context.Context, error) {
-	log.Printf("GetAds request with context_keys = %v", req.GetContextKeys())
+func (s *AdService) GetAds(ctx context.Context, req *pb.AdRequest) (_ *pb.AdResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	shopper := adContext(ctx, req.GetAdContext())
+
+	campaigns := s.tenantAds(ctx)
+	if shopper.GetExperiment() != adExperimentControl {
+		session := shopper.GetSessionId()
+		if session == "" {
+			session = usercontext.UserID(ctx, req.GetUserId())
+		}
+		categories := s.capImpressions(ctx, session, s.rankAds(campaigns, req.GetContextKeys(), shopper))
+		ads := make([]*pb.Ad, len(categories))
+		for i, category := range categories {
+			ads[i] = campaigns[category]
+		}
+		s.recordAdEvents(adEventImpression, shopper, ads...)
+		return &pb.AdResponse{Ads: ads}, ctx, nil
+	}
 
 	var allAds []*pb.Ad
 	keywords := req.GetContextKeys()
 
 	if len(keywords) > 0 {
 		for _, kw := range keywords {
-			allAds = append(allAds, s.getAdsByCategory(kw)...)
+			allAds = append(allAds, getAdsByCategory(campaigns, kw)...)
 		}
 		if len(allAds) == 0 {
 			// Serve random ads
-			allAds = s.getRandomAds()
+			allAds = getRandomAds(campaigns)
 		}
 	} else {
-		allAds = s.getRandomAds()
+		allAds = getRandomAds(campaigns)
 	}
+	s.recordAdEvents(adEventImpression, shopper, allAds...)
 
 	return &pb.AdResponse{
 		Ads: allAds,
 	}, ctx, nil
 }
 
-func (s *AdService) getAdsByCategory(category string) []*pb.Ad {
-	if adInstance, ok := s.ads[category]; ok {
+// RecordAdClick records a click on an ad. Like impressions, clicks are only
+// queued here and published in batches.
+func (s *AdService) RecordAdClick(ctx context.Context, req *pb.AdClickRequest) (_ *pb.Empty, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	shopper := adContext(ctx, req.GetAdContext())
+	s.recordAdEvents(adEventClick, shopper, &pb.Ad{RedirectUrl: req.GetRedirectUrl()})
+
+	return &pb.Empty{}, ctx, nil
+}
+
+// recordAdEvents queues an event of the given type for each ad.
+func (s *AdService) recordAdEvents(eventType string, shopper *pb.AdContext, ads ...*pb.Ad) {
+	now := time.Now().UnixMilli()
+	for _, ad := range ads {
+		s.events.Add(&pb.AdEvent{
+			Type:        eventType,
+			RedirectUrl: ad.GetRedirectUrl(),
+			SessionId:   shopper.GetSessionId(),
+			Experiment:  shopper.GetExperiment(),
+			TimestampMs: now,
+		})
+	}
+}
+
+// adContext returns the shopper context given with a request. Fields it
+// leaves empty are read from the incoming metadata.
+func adContext(ctx context.Context, given *pb.AdContext) *pb.AdContext {
+	md := metadata.FromIncomingContext(ctx)
+	shopper := &pb.AdContext{
+		Currency:         given.GetCurrency(),
+		Experiment:       given.GetExperiment(),
+		RecentCategories: given.GetRecentCategories(),
+		SessionId:        given.GetSessionId(),
+	}
+	if shopper.Currency == "" {
+		shopper.Currency = md.Get(mdShopCurrency)
+	}
+	if shopper.Experiment == "" {
+		shopper.Experiment = md.Get(mdShopExperiment)
+	}
+	if shopper.SessionId == "" {
+		shopper.SessionId = md.Get(mdShopSession)
+	}
+	if len(shopper.RecentCategories) == 0 {
+		if v := md.Get(mdShopRecentCategories); v != "" {
+			shopper.RecentCategories = strings.Split(v, ",")
+		}
+	}
+	return shopper
+}
+
+// rankAds orders the ad categories by how well they fit the page and the
+// shopper, best first. A page keyword outweighs the shopper's history, which
+// outweighs the currency. Categories that score the same are shuffled so
+// they take turns.
+func (s *AdService) rankAds(ads map[string]*pb.Ad, keywords []string, shopper *pb.AdContext) []string {
+	scores := make(map[string]float64, len(ads))
+	for category := range ads {
+		score := rand.Float64() * 0.1
+		if slices.Contains(keywords, category) {
+			score += 4
+		}
+		if i := slices.Index(shopper.GetRecentCategories(), category); i >= 0 {
+			score += 2 / float64(i+1)
+		}
+		if slices.Contains(adCurrencies[category], shopper.GetCurrency()) {
+			score++
+		}
+		scores[category] = score
+	}
+	ranked := slices.Collect(maps.Keys(scores))
+	slices.SortFunc(ranked, func(a, b string) int { return cmp.Compare(scores[b], scores[a]) })
+	return ranked
+}
+
+// capImpressions returns the first maxAdsToServe of the ranked categories
+// that the session has seen fewer than impressionCap times, and counts an
+// impression for each. If Redis is unavailable, the ads are served uncapped.
+func (s *AdService) capImpressions(ctx context.Context, session string, ranked []string) []string {
+	impressionCap := s.impressionCap.Get()
+	if session == "" || impressionCap <= 0 {
+		return ranked[:min(maxAdsToServe, len(ranked))]
+	}
+	key := tenant.Key(ctx, adImpressionsKey(session))
+	counts, err := s.rdb.HMGet(ctx, key, ranked...).Result()
+	if err != nil {
+		log.Printf("Failed to read ad impressions of session %v: %v", session, err)
+		return ranked[:min(maxAdsToServe, len(ranked))]
+	}
+
+	var serve []string
+	for i, category := range ranked {
+		if n, _ := counts[i].(string); n != "" {
+			if seen, err := strconv.Atoi(n); err == nil && seen >= impressionCap {
+				continue
+			}
+		}
+		if serve = append(serve, category); len(serve) == maxAdsToServe {
+			break
+		}
+	}
+	if len(serve) == 0 {
+		return nil
+	}
+
+	pipe := s.rdb.TxPipeline()
+	for _, category := range serve {
+		pipe.HIncrBy(ctx, key, category, 1)
+	}
+	pipe.Expire(ctx, key, s.impressionWindow.Get())
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to record ad impressions of session %v: %v", session, err)
+	}
+	return serve
+}
+
+// adImpressionsKey is the Redis hash counting a session's impressions by ad
+// category.
+func adImpressionsKey(session string) string {
+	return "ad-impressions:" + session
+}
+
+// tenantAds returns the ads of the campaigns the caller's tenant runs, keyed
+// by category.
+func (s *AdService) tenantAds(ctx context.Context) map[string]*pb.Ad {
+	ads := s.ads.Get()
+	t := tenant.Get(tenant.FromContext(ctx))
+	if t == nil || len(t.AdCategories) == 0 {
+		return ads
+	}
+	running := make(map[string]*pb.Ad, len(t.AdCategories))
+	for category, ad := range ads {
+		if t.HasAdCategory(category) {
+			running[category] = ad
+		}
+	}
+	return running
+}
+
+func getAdsByCategory(ads map[string]*pb.Ad, category string) []*pb.Ad {
+	if adInstance, ok := ads[category]; ok {
 		return []*pb.Ad{adInstance}
 	}
 	return nil
 }
 
-func (s *AdService) getRandomAds() []*pb.Ad {
+func getRandomAds(all map[string]*pb.Ad) []*pb.Ad {
+	if len(all) == 0 {
+		return nil
+	}
 	ads := make([]*pb.Ad, maxAdsToServe)
-	vals := make([]*pb.Ad, 0, len(s.ads))
-	for _, ad := range s.ads {
+	vals := make([]*pb.Ad, 0, len(all))
+	for _, ad := range all {
 		vals = append(vals, ad)
 	}
 	for i := 0; i < maxAdsToServe; i++ {
@@ -97,31 +335,15 @@ func (s *AdService) getRandomAds() []*pb.Ad {
 	return ads
 }
 
-func createAdsMap() map[string]*pb.Ad {
-	return map[string]*pb.Ad{
-		"hair": {
-			RedirectUrl: "/product/2ZYFJ3GM2N",
-			Text:        "Hairdryer for sale. 50% off.",
-		},
-		"clothing": {
-			RedirectUrl: "/product/66VCHSJNUP",
-			Text:        "Tank top for sale. 20% off.",
-		},
-		"accessories": {
-			RedirectUrl: "/product/1YMWWN1N4O",
-			Text:        "Watch for sale. Buy one, get second kit for free",
-		},
-		"footwear": {
-			RedirectUrl: "/product/L9ECAV7KIM",
-			Text:        "Loafers for sale. Buy one, get second one for free",
-		},
-		"decor": {
-			RedirectUrl: "/product/0PUK6V6EV0",
-			Text:        "Candle holder for sale. 30% off.",
-		},
-		"kitchen": {
-			RedirectUrl: "/product/9SIQT8TOJO",
-			Text:        "Bamboo glass jar for sale. 10% off.",
-		},
+// loadAds reads the ads, keyed by category, from a file.
+func loadAds(name string) (map[string]*pb.Ad, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var ads map[string]*pb.Ad
+	if err := json.Unmarshal(data, &ads); err != nil {
+		return nil, err
 	}
+	return ads, nil
 }
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/appnet-org/arpc/pkg/logging"
+	"github.com/appnet-org/arpc/pkg/rpc"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// Address problem codes returned by ValidateAddress.
+const (
+	addressProblemRequired      = "required"
+	addressProblemTooLong       = "too_long"
+	addressProblemInvalidFormat = "invalid_format"
+	addressProblemUnknownState  = "unknown_state"
+)
+
+const maxAddressFieldLen = 128
+
+// countryRules holds the per-country checks for a supported country.
+type countryRules struct {
+	name      string
+	zipDigits int
+	zipRegex  *regexp.Regexp
+	// states maps state codes to names; nil means the state is free text.
+	states map[string]string
+}
+
+var usStates = map[string]string{
+	"AL": "Alabama", "AK": "Alaska", "AZ": "Arizona", "AR": "Arkansas", "CA": "California",
+	"CO": "Colorado", "CT": "Connecticut", "DE": "Delaware", "DC": "District of Columbia",
+	"FL": "Florida", "GA": "Georgia", "HI": "Hawaii", "ID": "Idaho", "IL": "Illinois",
+	"IN": "Indiana", "IA": "Iowa", "KS": "Kansas", "KY": "Kentucky", "LA": "Louisiana",
+	"ME": "Maine", "MD": "Maryland", "MA": "Massachusetts", "MI": "Michigan", "MN": "Minnesota",
+	"MS": "Mississippi", "MO": "Missouri", "MT": "Montana", "NE": "Nebraska", "NV": "Nevada",
+	"NH": "New Hampshire", "NJ": "New Jersey", "NM": "New Mexico", "NY": "New York",
+	"NC": "North Carolina", "ND": "North Dakota", "OH": "Ohio", "OK": "Oklahoma", "OR": "Oregon",
+	"PA": "Pennsylvania", "RI": "Rhode Island", "SC": "South Carolina", "SD": "South Dakota",
+	"TN": "Tennessee", "TX": "Texas", "UT": "Utah", "VT": "Vermont", "VA": "Virginia",
+	"WA": "Washington", "WV": "West Virginia", "WI": "Wisconsin", "WY": "Wyoming",
+	"PR": "Puerto Rico",
+}
+
+var auStates = map[string]string{
+	"ACT": "Australian Capital Territory", "NSW": "New South Wales", "NT": "Northern Territory",
+	"QLD": "Queensland", "SA": "South Australia", "TAS": "Tasmania", "VIC": "Victoria",
+	"WA": "Western Australia",
+}
+
+// Only countries with numeric postal codes are listed, since Address.zip_code
+// is an integer. Other countries are normalized but not checked.
+var supportedCountries = map[string]*countryRules{
+	"united states": {name: "United States", zipDigits: 5, zipRegex: regexp.MustCompile(`^\d{5}$`), states: usStates},
+	"australia":     {name: "Australia", zipDigits: 4, zipRegex: regexp.MustCompile(`^[0-9]\d{3}$`), states: auStates},
+	"germany":       {name: "Germany", zipDigits: 5, zipRegex: regexp.MustCompile(`^(0[1-9]|[1-9]\d)\d{3}$`)},
+	"france":        {name: "France", zipDigits: 5, zipRegex: regexp.MustCompile(`^(0[1-9]|[1-8]\d|9[0-8])\d{3}$`)},
+	"italy":         {name: "Italy", zipDigits: 5, zipRegex: regexp.MustCompile(`^\d{5}$`)},
+	"spain":         {name: "Spain", zipDigits: 5, zipRegex: regexp.MustCompile(`^(0[1-9]|[1-4]\d|5[0-2])\d{3}$`)},
+	"japan":         {name: "Japan", zipDigits: 7, zipRegex: regexp.MustCompile(`^\d{7}$`)},
+	"india":         {name: "India", zipDigits: 6, zipRegex: regexp.MustCompile(`^[1-9]\d{5}$`)},
+}
+
+var countryAliases = map[string]string{
+	"us":                       "united states",
+	"usa":                      "united states",
+	"united states of america": "united states",
+	"au":                       "australia",
+	"de":                       "germany",
+	"deutschland":              "germany",
+	"fr":                       "france",
+	"it":                       "italy",
+	"italia":                   "italy",
+	"es":                       "spain",
+	"españa":                   "spain",
+	"jp":                       "japan",
+	"in":                       "india",
+}
+
+// NewAddressService returns a new server for the AddressService
+func NewAddressService(port int) *AddressService {
+	return &AddressService{
+		port: port,
+	}
+}
+
+// AddressService implements the AddressService
+type AddressService struct {
+	port int
+}
+
+// Run starts the server
+func (s *AddressService) Run() error {
+	err := logging.Init(getLoggingConfig())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
+	}
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
+	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
+	if err != nil {
+		log.Fatalf("Failed to start aRPC server: %v", err)
+	}
+
+	pb.RegisterAddressServiceServer(server, s)
+	log.Printf("AddressService running at port: %d", s.port)
+	server.Start()
+	return nil
+}
+
+// ValidateAddress normalizes an address and reports any problems per field
+func (s *AddressService) ValidateAddress(ctx context.Context, req *pb.ValidateAddressRequest) (_ *pb.ValidateAddressResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	normalized, problems := validateAddress(req.GetAddress())
+	if len(problems) > 0 {
+		log.Printf("Address has %d problem(s)", len(problems))
+	}
+	return &pb.ValidateAddressResponse{
+		Normalized: normalized,
+		Problems:   problems,
+	}, ctx, nil
+}
+
+// validateAddress returns the normalized form of addr along with any problems found.
+func validateAddress(addr *pb.Address) (*pb.Address, []*pb.AddressProblem) {
+	var problems []*pb.AddressProblem
+	problem := func(field, code, msg string) {
+		problems = append(problems, &pb.AddressProblem{Field: field, Code: code, Message: msg})
+	}
+	checkText := func(field, value string) {
+		switch {
+		case value == "":
+			problem(field, addressProblemRequired, "this field is required")
+		case len(value) > maxAddressFieldLen:
+			problem(field, addressProblemTooLong, fmt.Sprintf("must be at most %d characters", maxAddressFieldLen))
+		}
+	}
+
+	out := &pb.Address{
+		StreetAddress: titleCase(collapseSpaces(addr.GetStreetAddress())),
+		City:          titleCase(collapseSpaces(addr.GetCity())),
+		State:         collapseSpaces(addr.GetState()),
+		Country:       collapseSpaces(addr.GetCountry()),
+		ZipCode:       addr.GetZipCode(),
+	}
+	checkText("street_address", out.StreetAddress)
+	checkText("city", out.City)
+	checkText("country", out.Country)
+
+	key := strings.ToLower(out.Country)
+	if alias, ok := countryAliases[key]; ok {
+		key = alias
+	}
+	rules, ok := supportedCountries[key]
+	if !ok {
+		out.State = titleCase(out.State)
+		return out, problems
+	}
+	out.Country = rules.name
+
+	zip := strconv.Itoa(int(out.ZipCode))
+	if out.ZipCode <= 0 || len(zip) > rules.zipDigits {
+		problem("zip_code", addressProblemInvalidFormat, fmt.Sprintf("must be a %d digit postal code", rules.zipDigits))
+	} else if zip = fmt.Sprintf("%0*d", rules.zipDigits, out.ZipCode); !rules.zipRegex.MatchString(zip) {
+		problem("zip_code", addressProblemInvalidFormat, fmt.Sprintf("%s is not a valid postal code for %s", zip, rules.name))
+	}
+
+	if rules.states == nil {
+		out.State = titleCase(out.State)
+		return out, problems
+	}
+	if out.State == "" {
+		problem("state", addressProblemRequired, "this field is required")
+		return out, problems
+	}
+	code, ok := lookupState(rules.states, out.State)
+	if !ok {
+		problem("state", addressProblemUnknownState, fmt.Sprintf("%q is not a state of %s", out.State, rules.name))
+		return out, problems
+	}
+	out.State = code
+	return out, problems
+}
+
+// lookupState matches a state by code or name, case-insensitively.
+func lookupState(states map[string]string, state string) (string, bool) {
+	if _, ok := states[strings.ToUpper(state)]; ok {
+		return strings.ToUpper(state), true
+	}
+	for code, name := range states {
+		if strings.EqualFold(name, state) {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// collapseSpaces trims s and replaces runs of whitespace with a single space.
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// titleCase capitalizes the first letter of each word and lowercases the
+// rest. Words containing digits, such as "12B", are left as they are.
+func titleCase(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		if strings.ContainsFunc(w, unicode.IsDigit) {
+			continue
+		}
+		r := []rune(strings.ToLower(w))
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	return strings.Join(words, " ")
+}
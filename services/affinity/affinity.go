@@ -0,0 +1,65 @@
+// Package affinity stamps a routing key on aRPC calls so that a mesh can
+// route all the calls made for one shopper session consistently, for
+// experiments with consistent hashing and sticky routing.
+//
+// The key is a hash of the session, the user ID carried by usercontext, so
+// that it does not expose the session itself. Calls made while serving a
+// call pass on the key they received.
+package affinity
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// MetadataKey carries the routing key.
+const MetadataKey = "x-shop-route-key"
+
+// Key returns the routing key of session.
+func Key(session string) string {
+	h := fnv.New64a()
+	h.Write([]byte(session))
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// ClientElement attaches the routing key to outgoing calls.
+type ClientElement struct{}
+
+// NewClientElement creates a new client-side routing key element.
+func NewClientElement() element.RPCElement {
+	return &ClientElement{}
+}
+
+func (e *ClientElement) Name() string {
+	return "client-affinity"
+}
+
+func (e *ClientElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	if metadata.FromOutgoingContext(ctx).Get(MetadataKey) != "" {
+		return req, ctx, nil
+	}
+	key := metadata.FromIncomingContext(ctx).Get(MetadataKey)
+	if key == "" {
+		if session := usercontext.FromContext(ctx); session != "" {
+			key = Key(session)
+		}
+	}
+	if key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, key)
+	}
+	return req, ctx, nil
+}
+
+func (e *ClientElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	return resp, ctx, nil
+}
+
+func (e *ClientElement) Close() error {
+	return nil
+}
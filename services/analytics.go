@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/appnet-org/arpc/pkg/logging"
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/redis/go-redis/v9"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// NewAnalyticsService returns a new server for the AnalyticsService
+func NewAnalyticsService(port int) *AnalyticsService {
+	return &AnalyticsService{
+		port: port,
+	}
+}
+
+// AnalyticsService implements the AnalyticsService. It aggregates the events
+// of the other services, and keeps nothing about shoppers themselves.
+type AnalyticsService struct {
+	port int
+
+	rdb redis.UniversalClient // Referral visits and attributed orders
+
+	// Visits and orders are read from the event bus.
+	eventBusAddr string
+	bus          *eventbus.Bus
+}
+
+// Run starts the server
+func (s *AnalyticsService) Run() error {
+	err := logging.Init(getLoggingConfig())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
+	}
+
+	s.rdb = newRedisClient("ANALYTICS")
+	s.rdb.AddHook(tracing.RedisHook{})
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
+
+	checker := newStartupChecker()
+	checker.Add("redis", func(ctx context.Context) error {
+		return s.rdb.Ping(ctx).Err()
+	})
+	mustCheckStartup(checker)
+
+	go s.bus.Subscribe(context.Background(), eventbus.TopicReferralVisited, s.handleReferralVisited)
+	go s.bus.Subscribe(context.Background(), eventbus.TopicOrderStatusChanged, s.handleOrderStatusChanged)
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
+	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
+	if err != nil {
+		log.Fatalf("Failed to start aRPC server: %v", err)
+	}
+
+	pb.RegisterAnalyticsServiceServer(server, s)
+	log.Printf("AnalyticsService running at port: %d", s.port)
+	server.Start()
+	return nil
+}
+
+// handleReferralVisited counts a shopper arriving with a referral code.
+func (s *AnalyticsService) handleReferralVisited(payload []byte) error {
+	var event pb.ReferralVisited
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	if event.GetCode() == "" {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+	pipe := s.rdb.TxPipeline()
+	pipe.SAdd(ctx, referralCodesKey(ctx), event.GetCode())
+	pipe.HIncrBy(ctx, referralVisitsKey(ctx), event.GetCode(), 1)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// handleOrderStatusChanged attributes paid orders to the referral code they
+// were placed with, and notes those cancelled or refunded after being paid.
+// Orders are kept in sets, so an event delivered twice counts once.
+func (s *AnalyticsService) handleOrderStatusChanged(payload []byte) error {
+	var event pb.OrderStatusChanged
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	code := event.GetReferralCode()
+	if code == "" {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+	orderID := event.GetStatus().GetOrderId()
+	switch event.GetStatus().GetStatus() {
+	case orderPaid:
+		pipe := s.rdb.TxPipeline()
+		pipe.SAdd(ctx, referralCodesKey(ctx), code)
+		pipe.SAdd(ctx, referralOrdersKey(ctx, code), orderID)
+		_, err := pipe.Exec(ctx)
+		return err
+	case orderCancelled, orderRefunded:
+		if event.GetPreviousStatus() == orderPending {
+			// Never paid, so never counted.
+			return nil
+		}
+		return s.rdb.SAdd(ctx, referralRefundsKey(ctx, code), orderID).Err()
+	}
+	return nil
+}
+
+// GetReferralStats returns the visits and orders of a referral code, or of
+// every code seen, sorted by code.
+func (s *AnalyticsService) GetReferralStats(ctx context.Context, req *pb.ReferralStatsRequest) (_ *pb.ReferralStats, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	codes := []string{req.Code}
+	if req.Code == "" {
+		codes, err = s.rdb.SMembers(ctx, referralCodesKey(ctx)).Result()
+		if err != nil {
+			return nil, ctx, err
+		}
+		slices.Sort(codes)
+	}
+
+	stats := make([]*pb.ReferralStat, 0, len(codes))
+	for _, code := range codes {
+		pipe := s.rdb.Pipeline()
+		visits := pipe.HGet(ctx, referralVisitsKey(ctx), code)
+		orders := pipe.SCard(ctx, referralOrdersKey(ctx, code))
+		refunded := pipe.SCard(ctx, referralRefundsKey(ctx, code))
+		if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+			return nil, ctx, err
+		}
+		n, _ := visits.Int64()
+		stats = append(stats, &pb.ReferralStat{
+			Code:     code,
+			Visits:   n,
+			Orders:   orders.Val(),
+			Refunded: refunded.Val(),
+		})
+	}
+	return &pb.ReferralStats{Stats: stats}, ctx, nil
+}
+
+func referralCodesKey(ctx context.Context) string {
+	return tenant.Key(ctx, "referral-codes")
+}
+
+func referralVisitsKey(ctx context.Context) string {
+	return tenant.Key(ctx, "referral-visits")
+}
+
+func referralOrdersKey(ctx context.Context, code string) string {
+	return tenant.Key(ctx, "referral-orders:"+code)
+}
+
+func referralRefundsKey(ctx context.Context, code string) string {
+	return tenant.Key(ctx, "referral-refunds:"+code)
+}
@@ -0,0 +1,235 @@
+// Package audit keeps a tamper-evident log of sensitive operations, such as
+// charges, refunds, order cancellations and configuration changes, in a
+// service's Redis.
+//
+// Entries are appended to a single list and chained: each holds the SHA-256
+// of the one before it and its own, computed over its other fields, so that
+// an entry changed or removed after the fact no longer matches the hashes
+// that follow it. Verify checks a run of entries.
+//
+// The server element appends an entry for every call to the methods it is
+// given, before the handler runs, and refuses the call if the entry cannot
+// be written: an operation that is not in the log does not happen. Requests
+// are recorded with card data scrubbed, as the capture element records them.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/capture"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// key is the Redis list holding the log. It is shared by all tenants.
+const key = "audit-log"
+
+// appendRetries bounds the attempts at an append that raced with another.
+const appendRetries = 10
+
+// DefaultLimit is the page size of List when none is given, and MaxLimit
+// the largest.
+const (
+	DefaultLimit = 100
+	MaxLimit     = 1000
+)
+
+// Log is the audit log of a service.
+type Log struct {
+	rdb     redis.UniversalClient
+	service string
+}
+
+// New returns the audit log of service kept in rdb.
+func New(rdb redis.UniversalClient, service string) *Log {
+	return &Log{rdb: rdb, service: service}
+}
+
+// Append adds e to the log, filling in its position and hashes.
+func (l *Log) Append(ctx context.Context, e *pb.AuditEntry) error {
+	if e.Service == "" {
+		e.Service = l.service
+	}
+	if e.Time == 0 {
+		e.Time = time.Now().UnixNano()
+	}
+	var err error
+	for range appendRetries {
+		err = l.rdb.Watch(ctx, func(tx *redis.Tx) error {
+			last, err := tx.LRange(ctx, key, -1, -1).Result()
+			if err != nil {
+				return err
+			}
+			e.Seq, e.PrevHash = 1, ""
+			if len(last) > 0 {
+				var prev pb.AuditEntry
+				if err := json.Unmarshal([]byte(last[0]), &prev); err != nil {
+					return fmt.Errorf("reading last entry: %w", err)
+				}
+				e.Seq, e.PrevHash = prev.Seq+1, prev.Hash
+			}
+			e.Hash = Hash(e)
+			data, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.RPush(ctx, key, data)
+				return nil
+			})
+			return err
+		}, key)
+		if err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return err
+}
+
+// Record appends an entry for method with the given details, acting for the
+// user and tenant of ctx.
+func (l *Log) Record(ctx context.Context, method, details string) error {
+	return l.Append(ctx, &pb.AuditEntry{
+		Method:  method,
+		UserId:  usercontext.FromContext(ctx),
+		Tenant:  tenant.FromContext(ctx),
+		Details: details,
+	})
+}
+
+// RecordConfigChanges appends an entry whenever a configuration reload
+// changes settings, naming the keys but not their values, which may be
+// secrets.
+func (l *Log) RecordConfigChanges() {
+	config.OnChange(func(keys []string) {
+		details, _ := json.Marshal(map[string][]string{"changed": keys})
+		if err := l.Record(context.Background(), "ConfigReload", string(details)); err != nil {
+			log.Printf("audit: failed to record configuration change: %v", err)
+		}
+	})
+}
+
+// List returns up to limit entries from position from, checked with Verify
+// against the entry before them.
+func (l *Log) List(ctx context.Context, from int64, limit int) (*pb.AuditEntries, error) {
+	from = max(from, 1)
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, MaxLimit)
+
+	// The entry before the page is read along with it, to check the link.
+	start := from - 2
+	if from == 1 {
+		start = 0
+	}
+	raw, err := l.rdb.LRange(ctx, key, start, from-2+int64(limit)).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*pb.AuditEntry, len(raw))
+	for i, r := range raw {
+		var e pb.AuditEntry
+		if err := json.Unmarshal([]byte(r), &e); err != nil {
+			return nil, fmt.Errorf("reading entry %d: %w", start+int64(i)+1, err)
+		}
+		entries[i] = &e
+	}
+
+	prevHash := ""
+	if from > 1 && len(entries) > 0 {
+		prevHash, entries = entries[0].Hash, entries[1:]
+	}
+	res := &pb.AuditEntries{Entries: entries, Intact: true}
+	if seq, ok := Verify(prevHash, from, entries); !ok {
+		res.Intact, res.BrokenSeq = false, seq
+	}
+	return res, nil
+}
+
+// Verify checks that entries are the run of the log from position from,
+// following an entry whose hash is prevHash. It returns the position of the
+// first entry that is not.
+func Verify(prevHash string, from int64, entries []*pb.AuditEntry) (int64, bool) {
+	for i, e := range entries {
+		seq := from + int64(i)
+		if e.Seq != seq || e.PrevHash != prevHash || e.Hash != Hash(e) {
+			return seq, false
+		}
+		prevHash = e.Hash
+	}
+	return 0, true
+}
+
+// Hash returns the hash of e over all its fields but Hash.
+func Hash(e *pb.AuditEntry) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\n%d\n%q\n%q\n%q\n%q\n%q\n%q\n",
+		e.Seq, e.Time, e.Service, e.Method, e.UserId, e.Tenant, e.Details, e.PrevHash)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// ServerElement records calls to some of a server's methods in the log.
+type ServerElement struct {
+	log     *Log
+	methods map[string]bool
+}
+
+// NewServerElement creates a new server-side audit element recording calls
+// to methods, given by name, in l.
+func NewServerElement(l *Log, methods ...string) element.RPCElement {
+	e := &ServerElement{log: l, methods: make(map[string]bool)}
+	for _, m := range methods {
+		e.methods[m] = true
+	}
+	return e
+}
+
+func (e *ServerElement) Name() string {
+	return "server-audit"
+}
+
+func (e *ServerElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	if !e.methods[req.Method] {
+		return req, ctx, nil
+	}
+	var details string
+	if msg, ok := req.Payload.(proto.Message); ok {
+		if body, err := protojson.Marshal(capture.Scrub(msg)); err == nil {
+			details = string(body)
+		}
+	}
+	if err := e.log.Append(ctx, &pb.AuditEntry{
+		Service: req.ServiceName,
+		Method:  req.Method,
+		UserId:  usercontext.FromContext(ctx),
+		Tenant:  tenant.FromContext(ctx),
+		Details: details,
+	}); err != nil {
+		log.Printf("audit: refusing %s.%s, cannot record it: %v", req.ServiceName, req.Method, err)
+		return nil, ctx, errors.New("audit log unavailable")
+	}
+	return req, ctx, nil
+}
+
+func (e *ServerElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	return resp, ctx, nil
+}
+
+func (e *ServerElement) Close() error {
+	return nil
+}
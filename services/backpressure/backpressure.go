@@ -0,0 +1,250 @@
+// Package backpressure bounds the requests a server handles at once. Up to
+// MaxConcurrent requests are served; the next MaxQueue wait for a slot, in
+// arrival order, for at most QueueTimeout; the rest are rejected with
+// ResourceExhausted, like shed requests, so that a flood makes a service
+// slower and then refuse work rather than pile up goroutines and Redis
+// connections until it falls over.
+//
+// When the queue is full, Policy decides who is turned away: the request
+// that just arrived (PolicyReject), or the one that has waited longest
+// (PolicyDropOldest), whose caller is the likeliest to have given up on it.
+//
+// Saturation is exported through expvar as "server_backpressure".
+package backpressure
+
+import (
+	"container/list"
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policies for a full queue.
+const (
+	PolicyReject     = "reject"
+	PolicyDropOldest = "drop-oldest"
+)
+
+// staleAfter bounds how long a request holds its slot. aRPC does not run
+// ProcessResponse for calls whose handler failed; those slots are released
+// by recovery.Recover instead, and only reclaimed here for a handler that
+// does not defer it.
+const staleAfter = 30 * time.Second
+
+// Config holds the bounds of a server. A zero MaxConcurrent disables them.
+type Config struct {
+	MaxConcurrent int
+	MaxQueue      int
+	QueueTimeout  time.Duration
+	Policy        string
+}
+
+// stats counts the requests admitted, queued and rejected, along with the
+// time spent queued, in "queue_wait_ms_total".
+var stats = expvar.NewMap("server_backpressure")
+
+// ServerBackpressureElement implements RPC element interface for bounding the
+// requests served at once.
+type ServerBackpressureElement struct {
+	mu        sync.Mutex
+	cfg       Config
+	inFlight  map[uint64]time.Time
+	queue     *list.List // of *waiter, oldest first
+	lastSweep time.Time
+}
+
+// waiter is a queued request. Its slot, or the reason it was turned away, is
+// sent on ready, and settled set under mu once it was.
+type waiter struct {
+	id      uint64
+	queued  time.Time
+	ready   chan error
+	settled bool
+}
+
+type slotKey struct{}
+
+// NewServerBackpressureElement creates a new server-side backpressure element
+func NewServerBackpressureElement(cfg Config) *ServerBackpressureElement {
+	e := &ServerBackpressureElement{
+		cfg:      cfg,
+		inFlight: make(map[uint64]time.Time),
+		queue:    list.New(),
+	}
+	stats.Set("in_flight", expvar.Func(func() any { return e.gauge(func() int { return len(e.inFlight) }) }))
+	stats.Set("queued", expvar.Func(func() any { return e.gauge(e.queue.Len) }))
+	stats.Set("saturation", expvar.Func(e.saturation))
+	return e
+}
+
+// SetConfig replaces the bounds. Requests already admitted keep their slots,
+// and queued ones are admitted if the new bounds leave room for them.
+func (e *ServerBackpressureElement) SetConfig(cfg Config) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cfg = cfg
+	e.admit(time.Now())
+}
+
+func (e *ServerBackpressureElement) Name() string {
+	return "server-backpressure"
+}
+
+func (e *ServerBackpressureElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	now := time.Now()
+
+	e.mu.Lock()
+	if e.cfg.MaxConcurrent <= 0 {
+		e.mu.Unlock()
+		return req, ctx, nil
+	}
+	e.sweep(now)
+	if e.queue.Len() == 0 && len(e.inFlight) < e.cfg.MaxConcurrent {
+		e.inFlight[req.ID] = now
+		e.mu.Unlock()
+		stats.Add("admitted", 1)
+		return req, e.holdSlot(ctx, req.ID), nil
+	}
+	if e.queue.Len() >= e.cfg.MaxQueue {
+		if e.cfg.Policy != PolicyDropOldest || e.queue.Len() == 0 {
+			e.mu.Unlock()
+			stats.Add("rejected_queue_full", 1)
+			return nil, ctx, reject(req, fmt.Sprintf("%d requests in flight and %d queued", e.cfg.MaxConcurrent, e.cfg.MaxQueue))
+		}
+		oldest := e.queue.Remove(e.queue.Front()).(*waiter)
+		oldest.settled = true
+		oldest.ready <- fmt.Errorf("dropped from a full queue after %v", now.Sub(oldest.queued))
+		stats.Add("dropped_oldest", 1)
+	}
+	w := &waiter{id: req.ID, queued: now, ready: make(chan error, 1)}
+	elem := e.queue.PushBack(w)
+	timeout := e.cfg.QueueTimeout
+	e.mu.Unlock()
+	stats.Add("queued_total", 1)
+
+	var timer <-chan time.Time
+	if timeout > 0 {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		timer = t.C
+	}
+	var err error
+	select {
+	case err = <-w.ready:
+	case <-timer:
+		e.mu.Lock()
+		if !w.settled {
+			e.queue.Remove(elem)
+			w.settled = true
+			err = fmt.Errorf("no slot within %v", timeout)
+			stats.Add("rejected_timeout", 1)
+		}
+		e.mu.Unlock()
+		if err == nil {
+			// Admitted or dropped just as the wait ran out.
+			err = <-w.ready
+		}
+	}
+	stats.Add("queue_wait_ms_total", time.Since(now).Milliseconds())
+	if err != nil {
+		return nil, ctx, reject(req, err.Error())
+	}
+	stats.Add("admitted", 1)
+	return req, e.holdSlot(ctx, req.ID), nil
+}
+
+// holdSlot records in ctx the slot of request id, to be released when the
+// response goes out or, if the handler fails, by recovery.Recover.
+func (e *ServerBackpressureElement) holdSlot(ctx context.Context, id uint64) context.Context {
+	ctx = context.WithValue(ctx, slotKey{}, id)
+	return recovery.OnFailure(ctx, func() { e.release(id) })
+}
+
+func (e *ServerBackpressureElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	// Responses made up for requests a later element rejected carry no ID, so
+	// the slot is found through the context.
+	id, ok := ctx.Value(slotKey{}).(uint64)
+	if !ok {
+		return resp, ctx, nil
+	}
+
+	e.release(id)
+	return resp, ctx, nil
+}
+
+// release frees the slot of request id, if it still holds one, and gives it
+// to the next queued request.
+func (e *ServerBackpressureElement) release(id uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if _, ok := e.inFlight[id]; ok {
+		delete(e.inFlight, id)
+		e.admit(time.Now())
+	}
+}
+
+func (e *ServerBackpressureElement) Close() error {
+	return nil
+}
+
+// sweep reclaims the slots of requests that have held them too long. The
+// caller must hold mu.
+func (e *ServerBackpressureElement) sweep(now time.Time) {
+	if now.Sub(e.lastSweep) <= staleAfter {
+		return
+	}
+	for id, start := range e.inFlight {
+		if now.Sub(start) > staleAfter {
+			delete(e.inFlight, id)
+			stats.Add("reclaimed", 1)
+		}
+	}
+	e.lastSweep = now
+	e.admit(now)
+}
+
+// admit gives free slots to queued requests, oldest first. The caller must
+// hold mu.
+func (e *ServerBackpressureElement) admit(now time.Time) {
+	for e.queue.Len() > 0 && (e.cfg.MaxConcurrent <= 0 || len(e.inFlight) < e.cfg.MaxConcurrent) {
+		w := e.queue.Remove(e.queue.Front()).(*waiter)
+		w.settled = true
+		e.inFlight[w.id] = now
+		w.ready <- nil
+	}
+}
+
+// gauge returns f under mu.
+func (e *ServerBackpressureElement) gauge(f func() int) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return f()
+}
+
+// saturation returns the share of slots in use, above 1 once requests queue.
+func (e *ServerBackpressureElement) saturation() any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cfg.MaxConcurrent <= 0 {
+		return 0.0
+	}
+	return float64(len(e.inFlight)+e.queue.Len()) / float64(e.cfg.MaxConcurrent)
+}
+
+// reject builds the error returned to the client for a request turned away.
+func reject(req *element.RPCRequest, reason string) error {
+	log.Printf("backpressure: rejecting %s.%s: %s", req.ServiceName, req.Method, reason)
+	return &rpc.RPCError{
+		Type:   rpc.RPCFailError,
+		Reason: status.Errorf(codes.ResourceExhausted, "server overloaded: %s", reason).Error(),
+	}
+}
@@ -0,0 +1,63 @@
+package backpressure
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+)
+
+// failingHandler fails the way handlers do, with an error or a panic, and
+// like them defers recovery.Recover.
+func failingHandler(ctx context.Context, panics bool) (err error) {
+	defer recovery.Recover(ctx, &err)
+	if panics {
+		panic("boom")
+	}
+	return errors.New("redis unavailable")
+}
+
+func TestFailedCallsReleaseTheirSlots(t *testing.T) {
+	e := NewServerBackpressureElement(Config{MaxConcurrent: 2, Policy: PolicyReject})
+
+	// aRPC skips ProcessResponse for failed calls, so the slots of these must
+	// be released by the handler failing.
+	for id := uint64(1); id <= 20; id++ {
+		req := &element.RPCRequest{ID: id, ServiceName: "test", Method: "Call"}
+		_, ctx, err := e.ProcessRequest(context.Background(), req)
+		if err != nil {
+			t.Fatalf("request %d rejected after %d failed calls: %v", id, id-1, err)
+		}
+		if err := failingHandler(ctx, id%2 == 0); err == nil {
+			t.Fatal("handler did not fail")
+		}
+	}
+	if n := e.gauge(func() int { return len(e.inFlight) }); n != 0 {
+		t.Errorf("%d slots still held after every call failed", n)
+	}
+}
+
+func TestFailedCallAdmitsQueuedRequest(t *testing.T) {
+	e := NewServerBackpressureElement(Config{MaxConcurrent: 1, MaxQueue: 1, Policy: PolicyReject})
+
+	_, ctx, err := e.ProcessRequest(context.Background(), &element.RPCRequest{ID: 1, ServiceName: "test", Method: "Call"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	admitted := make(chan error, 1)
+	go func() {
+		_, _, err := e.ProcessRequest(context.Background(), &element.RPCRequest{ID: 2, ServiceName: "test", Method: "Call"})
+		admitted <- err
+	}()
+	for e.gauge(e.queue.Len) == 0 {
+		runtime.Gosched()
+	}
+
+	failingHandler(ctx, false)
+	if err := <-admitted; err != nil {
+		t.Fatalf("queued request rejected once the slot was released: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package bench
+
+import (
+	"context"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// arpcTransport calls the services over aRPC, negotiating the codec as the
+// services do, so that RPC_SERIALIZER settings can be compared as well.
+type arpcTransport struct {
+	catalog pb.ProductCatalogServiceClient
+	cart    pb.CartServiceClient
+}
+
+func newARPCTransport(addrs Addrs) (Transport, error) {
+	dial := func(addr string) (*rpc.Client, error) {
+		return rpc.NewClient(codec.NewClient(), addr, []element.RPCElement{usercontext.NewClientElement(), codec.NewClientElement()})
+	}
+	catalog, err := dial(addrs.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	cart, err := dial(addrs.Cart)
+	if err != nil {
+		return nil, err
+	}
+	return &arpcTransport{
+		catalog: pb.NewProductCatalogServiceClient(catalog),
+		cart:    pb.NewCartServiceClient(cart),
+	}, nil
+}
+
+func (t *arpcTransport) ListProducts(ctx context.Context) error {
+	_, err := t.catalog.ListProducts(ctx, &pb.EmptyUser{})
+	return err
+}
+
+func (t *arpcTransport) GetProduct(ctx context.Context, id string) error {
+	_, err := t.catalog.GetProduct(ctx, &pb.GetProductRequest{Id: id})
+	return err
+}
+
+func (t *arpcTransport) AddItem(ctx context.Context, user, productID string) error {
+	ctx = usercontext.NewContext(ctx, user)
+	_, err := t.cart.AddItem(ctx, &pb.AddItemRequest{Item: &pb.CartItem{ProductId: productID, Quantity: 1}})
+	return err
+}
+
+func (t *arpcTransport) GetCart(ctx context.Context, user string) error {
+	ctx = usercontext.NewContext(ctx, user)
+	_, err := t.cart.GetCart(ctx, &pb.GetCartRequest{})
+	return err
+}
+
+func (t *arpcTransport) EmptyCart(ctx context.Context, user string) error {
+	ctx = usercontext.NewContext(ctx, user)
+	_, err := t.cart.EmptyCart(ctx, &pb.EmptyCartRequest{})
+	return err
+}
+
+// Close does nothing: aRPC clients hold no connection.
+func (t *arpcTransport) Close() error {
+	return nil
+}
@@ -0,0 +1,263 @@
+// Package bench implements the bench command, which measures the throughput
+// and latency of the cart and catalog hot paths over each RPC transport
+// against running services, and writes the results as CSV or JSON.
+//
+// Transports register themselves in transports. Only aRPC does so far: the
+// services have no gRPC listeners yet, and the gRPC transport is meant to be
+// added alongside them so that the two can be compared run for run.
+package bench
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// Transport makes the calls of the benchmarked paths.
+type Transport interface {
+	ListProducts(ctx context.Context) error
+	GetProduct(ctx context.Context, id string) error
+	AddItem(ctx context.Context, user, productID string) error
+	GetCart(ctx context.Context, user string) error
+	EmptyCart(ctx context.Context, user string) error
+	Close() error
+}
+
+// Addrs are the addresses of the services under test.
+type Addrs struct {
+	Catalog string
+	Cart    string
+}
+
+// transports maps transport names to their constructors.
+var transports = map[string]func(Addrs) (Transport, error){
+	"arpc": newARPCTransport,
+}
+
+// workload is a benchmarked path. call makes one request as worker w.
+// prepare, if set, is run untimed before the i-th call of worker w, to keep
+// the state the calls depend on as it should be.
+type workload struct {
+	name    string
+	call    func(ctx context.Context, t Transport, w int) error
+	prepare func(ctx context.Context, t Transport, w, i int) error
+}
+
+// benchProduct is a product of the default catalog.
+const benchProduct = "OLJCESPC7Z"
+
+// benchCartQuantity is how many of benchProduct a worker adds to its cart
+// before emptying it, so that it stays within the default CART_MAX_QUANTITY
+// and AddItem is not measured failing on a full cart.
+const benchCartQuantity = 10
+
+var workloads = []workload{
+	{name: "catalog.ListProducts", call: func(ctx context.Context, t Transport, _ int) error {
+		return t.ListProducts(ctx)
+	}},
+	{name: "catalog.GetProduct", call: func(ctx context.Context, t Transport, _ int) error {
+		return t.GetProduct(ctx, benchProduct)
+	}},
+	{name: "cart.AddItem", call: func(ctx context.Context, t Transport, w int) error {
+		return t.AddItem(ctx, benchUser(w), benchProduct)
+	}, prepare: func(ctx context.Context, t Transport, w, i int) error {
+		if i%benchCartQuantity != 0 {
+			return nil
+		}
+		return t.EmptyCart(ctx, benchUser(w))
+	}},
+	{name: "cart.GetCart", call: func(ctx context.Context, t Transport, w int) error {
+		return t.GetCart(ctx, benchUser(w))
+	}},
+}
+
+// benchUser is the cart of worker w, so that workers do not contend on one
+// cart.
+func benchUser(w int) string {
+	return "bench-" + strconv.Itoa(w)
+}
+
+// Result is the outcome of one workload over one transport.
+type Result struct {
+	Transport   string  `json:"transport"`
+	Workload    string  `json:"workload"`
+	Concurrency int     `json:"concurrency"`
+	Requests    int     `json:"requests"`
+	Errors      int     `json:"errors"`
+	Seconds     float64 `json:"seconds"`
+	RPS         float64 `json:"rps"`
+	P50Ms       float64 `json:"p50_ms"`
+	P90Ms       float64 `json:"p90_ms"`
+	P99Ms       float64 `json:"p99_ms"`
+	MaxMs       float64 `json:"max_ms"`
+}
+
+// Main runs the bench command with the given arguments and returns the exit
+// status.
+func Main(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	var (
+		catalog     = fs.String("catalog", defaultString("PRODUCT_CATALOG_SERVICE_ADDR", "productcatalog:11002"), "product catalog service address")
+		cart        = fs.String("cart", defaultString("CART_SERVICE_ADDR", "cart:11001"), "cart service address")
+		names       = fs.String("transports", "arpc", "comma-separated transports to compare")
+		only        = fs.String("workloads", "", "comma-separated workloads to run, empty for all")
+		duration    = fs.Duration("duration", 10*time.Second, "measured time per workload")
+		warmup      = fs.Duration("warmup", 2*time.Second, "unmeasured time before each workload")
+		concurrency = fs.Int("concurrency", 8, "concurrent callers")
+		timeout     = fs.Duration("timeout", 2*time.Second, "time limit of a call")
+		format      = fs.String("format", "csv", "output format, csv or json")
+		out         = fs.String("out", "", "file to write the results to, empty for standard output")
+	)
+	fs.Parse(args)
+	if *format != "csv" && *format != "json" {
+		log.Printf("bench: unknown format %q", *format)
+		return 2
+	}
+
+	var selected []workload
+	for _, wl := range workloads {
+		if *only == "" || slices.Contains(strings.Split(*only, ","), wl.name) {
+			selected = append(selected, wl)
+		}
+	}
+
+	var results []Result
+	for _, name := range strings.Split(*names, ",") {
+		newTransport, ok := transports[name]
+		if !ok {
+			log.Printf("bench: unknown transport %q", name)
+			return 2
+		}
+		t, err := newTransport(Addrs{Catalog: *catalog, Cart: *cart})
+		if err != nil {
+			log.Printf("bench: %s: %v", name, err)
+			return 1
+		}
+		for _, wl := range selected {
+			run(t, wl, *concurrency, *warmup, *timeout)
+			r := run(t, wl, *concurrency, *duration, *timeout)
+			r.Transport = name
+			log.Printf("bench: %s %s: %.0f req/s, p50 %.2fms, p99 %.2fms, %d errors", name, wl.name, r.RPS, r.P50Ms, r.P99Ms, r.Errors)
+			results = append(results, r)
+		}
+		t.Close()
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Printf("bench: %v", err)
+			return 1
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := write(w, *format, results); err != nil {
+		log.Printf("bench: writing results: %v", err)
+		return 1
+	}
+	return 0
+}
+
+func defaultString(key, def string) string {
+	if v := config.Get(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// run calls wl from concurrency workers for d and summarizes the latencies.
+func run(t Transport, wl workload, concurrency int, d, timeout time.Duration) Result {
+	var (
+		mu        sync.Mutex
+		latencies []time.Duration
+		errs      int
+		wg        sync.WaitGroup
+	)
+	start := time.Now()
+	deadline := start.Add(d)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			var local []time.Duration
+			var localErrs int
+			for i := 0; time.Now().Before(deadline); i++ {
+				if wl.prepare != nil {
+					ctx, cancel := context.WithTimeout(context.Background(), timeout)
+					if err := wl.prepare(ctx, t, w, i); err != nil {
+						log.Printf("bench: %s: preparing call: %v", wl.name, err)
+					}
+					cancel()
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), timeout)
+				begin := time.Now()
+				err := wl.call(ctx, t, w)
+				elapsed := time.Since(begin)
+				cancel()
+				if err != nil {
+					localErrs++
+					continue
+				}
+				local = append(local, elapsed)
+			}
+			mu.Lock()
+			latencies = append(latencies, local...)
+			errs += localErrs
+			mu.Unlock()
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	r := Result{
+		Workload:    wl.name,
+		Concurrency: concurrency,
+		Requests:    len(latencies) + errs,
+		Errors:      errs,
+		Seconds:     elapsed.Seconds(),
+		RPS:         float64(len(latencies)) / elapsed.Seconds(),
+	}
+	if n := len(latencies); n > 0 {
+		r.P50Ms = ms(latencies[n*50/100])
+		r.P90Ms = ms(latencies[n*90/100])
+		r.P99Ms = ms(latencies[n*99/100])
+		r.MaxMs = ms(latencies[n-1])
+	}
+	return r
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func write(w io.Writer, format string, results []Result) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"transport", "workload", "concurrency", "requests", "errors", "seconds", "rps", "p50_ms", "p90_ms", "p99_ms", "max_ms"})
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 3, 64) }
+	for _, r := range results {
+		cw.Write([]string{r.Transport, r.Workload, strconv.Itoa(r.Concurrency), strconv.Itoa(r.Requests), strconv.Itoa(r.Errors),
+			f(r.Seconds), f(r.RPS), f(r.P50Ms), f(r.P90Ms), f(r.P99Ms), f(r.MaxMs)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
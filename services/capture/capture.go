@@ -0,0 +1,250 @@
+// Package capture samples the calls a server handles and records them, for
+// replaying later with the replay command.
+//
+// CAPTURE_SAMPLE_RATE is the fraction of calls recorded, 0 (the default)
+// disabling capture. Records are appended as JSON lines to a file named
+// after the host in CAPTURE_DIR, or posted one by one to CAPTURE_URL. Each
+// holds the call's metadata, request and response in protobuf JSON along
+// with the request's Symphony encoding, so that a replay can tell whether
+// the serializer still produces the same bytes. Card numbers and security
+// codes are scrubbed from the payloads before they are recorded, so captured
+// payment calls are declined on replay.
+//
+// Only calls that complete are recorded; the handler skips the response
+// elements of calls that fail.
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"github.com/appnet-org/arpc/pkg/serializer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// Record is a captured call.
+type Record struct {
+	Time         time.Time         `json:"time"`
+	Service      string            `json:"service"`
+	Method       string            `json:"method"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	RequestType  string            `json:"request_type"`
+	Request      json.RawMessage   `json:"request"`
+	RequestWire  []byte            `json:"request_wire,omitempty"`
+	ResponseType string            `json:"response_type,omitempty"`
+	Response     json.RawMessage   `json:"response,omitempty"`
+}
+
+// Read returns the records in r, a file written by the capture element.
+func Read(r *os.File) ([]Record, error) {
+	var records []Record
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 16<<20)
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		records = append(records, rec)
+	}
+	return records, sc.Err()
+}
+
+// settings are the capture configuration.
+type settings struct {
+	rate float64
+	dir  string
+	url  string
+}
+
+var current = config.NewValue(func() settings {
+	s := settings{dir: config.Get("CAPTURE_DIR"), url: config.Get("CAPTURE_URL")}
+	if v := config.Get("CAPTURE_SAMPLE_RATE"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			log.Printf("capture: ignoring invalid CAPTURE_SAMPLE_RATE=%q: %v", v, err)
+		}
+		s.rate = f
+	}
+	if s.dir == "" && s.url == "" {
+		s.rate = 0
+	}
+	return s
+})
+
+// ServerElement records a sample of the calls a server handles.
+type ServerElement struct {
+	once    sync.Once
+	records chan Record
+}
+
+// NewServerElement creates a new server-side capture element.
+func NewServerElement() element.RPCElement {
+	return &ServerElement{records: make(chan Record, 256)}
+}
+
+func (e *ServerElement) Name() string {
+	return "server-capture"
+}
+
+// pendingKey carries the record of a sampled call from its request to its
+// response.
+type pendingKey struct{}
+
+func (e *ServerElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	s := current.Get()
+	if s.rate <= 0 || rand.Float64() >= s.rate {
+		return req, ctx, nil
+	}
+	msg, ok := req.Payload.(proto.Message)
+	if !ok {
+		return req, ctx, nil
+	}
+	scrubbed := Scrub(msg)
+	body, err := protojson.Marshal(scrubbed)
+	if err != nil {
+		log.Printf("capture: cannot encode %s.%s request: %v", req.ServiceName, req.Method, err)
+		return req, ctx, nil
+	}
+	rec := &Record{
+		Time:        time.Now().UTC(),
+		Service:     req.ServiceName,
+		Method:      req.Method,
+		Metadata:    metadata.FromIncomingContext(ctx).Copy(),
+		RequestType: string(scrubbed.ProtoReflect().Descriptor().FullName()),
+		Request:     body,
+	}
+	if wire, err := (&serializer.SymphonySerializer{}).Marshal(scrubbed); err == nil {
+		rec.RequestWire = wire
+	}
+	return req, context.WithValue(ctx, pendingKey{}, rec), nil
+}
+
+func (e *ServerElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	rec, ok := ctx.Value(pendingKey{}).(*Record)
+	if !ok {
+		return resp, ctx, nil
+	}
+	if msg, ok := resp.Result.(proto.Message); ok && resp.Error == nil {
+		scrubbed := Scrub(msg)
+		if body, err := protojson.Marshal(scrubbed); err == nil {
+			rec.ResponseType = string(scrubbed.ProtoReflect().Descriptor().FullName())
+			rec.Response = body
+		}
+	}
+	e.once.Do(func() { go e.write() })
+	select {
+	case e.records <- *rec:
+	default:
+		log.Printf("capture: dropping %s.%s record, writer is behind", rec.Service, rec.Method)
+	}
+	return resp, ctx, nil
+}
+
+func (e *ServerElement) Close() error {
+	return nil
+}
+
+// write sends the records to their destination as they come.
+func (e *ServerElement) write() {
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "capture"
+	}
+	for rec := range e.records {
+		line, err := json.Marshal(rec)
+		if err != nil {
+			log.Printf("capture: cannot encode record: %v", err)
+			continue
+		}
+		s := current.Get()
+		if s.dir != "" {
+			err = appendLine(filepath.Join(s.dir, host+".jsonl"), line)
+		} else if s.url != "" {
+			err = post(s.url, line)
+		}
+		if err != nil {
+			log.Printf("capture: cannot write %s.%s record: %v", rec.Service, rec.Method, err)
+		}
+	}
+}
+
+func appendLine(path string, line []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func post(url string, line []byte) error {
+	resp, err := http.Post(url, "application/json", bytes.NewReader(line))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+// Scrub returns a copy of msg without card data: card numbers are masked
+// but for their last four digits, and security codes are cleared.
+func Scrub(msg proto.Message) proto.Message {
+	c := proto.Clone(msg)
+	scrub(c.ProtoReflect())
+	return c
+}
+
+func scrub(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.Name() == "credit_card_number" && fd.Kind() == protoreflect.StringKind:
+			n := v.String()
+			if len(n) > 4 {
+				n = strings.Repeat("*", len(n)-4) + n[len(n)-4:]
+			}
+			m.Set(fd, protoreflect.ValueOfString(n))
+		case fd.Name() == "credit_card_cvv":
+			m.Clear(fd)
+		case fd.IsList() && fd.Kind() == protoreflect.MessageKind:
+			for i, l := 0, v.List(); i < l.Len(); i++ {
+				scrub(l.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Kind() == protoreflect.MessageKind:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				scrub(mv.Message())
+				return true
+			})
+		case !fd.IsList() && !fd.IsMap() && fd.Kind() == protoreflect.MessageKind:
+			scrub(v.Message())
+		}
+		return true
+	})
+}
@@ -2,19 +2,23 @@ package services
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strconv"
+	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
 	"github.com/redis/go-redis/v9"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/privacy"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
 // NewCartService returns a new server for the CartService
@@ -28,8 +32,12 @@ func NewCartService(port int) *CartService {
 type CartService struct {
 	port int
 
-	cartRedisAddr string
-	rdb           *redis.Client // Redis client
+	rdb redis.UniversalClient // Redis client
+
+	// Abandoned carts are published on the event bus, and paid orders read
+	// from it for the address to remind shoppers at.
+	eventBusAddr string
+	bus          *eventbus.Bus
 }
 
 // Run starts the server
@@ -39,73 +47,94 @@ func (s *CartService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	mustMapEnv(&s.cartRedisAddr, "CART_REDIS_ADDR")
+	s.rdb = newRedisClient("CART")
+	s.rdb.AddHook(tracing.RedisHook{})
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
 
-	s.rdb = redis.NewClient(&redis.Options{
-		Addr: s.cartRedisAddr,
+	checker := newStartupChecker()
+	checker.Add("redis", func(ctx context.Context) error {
+		return s.rdb.Ping(ctx).Err()
 	})
-
-	serializer := &serializer.SymphonySerializer{}
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
+	mustCheckStartup(checker)
+
+	go s.bus.Subscribe(context.Background(), eventbus.TopicOrderStatusChanged, s.handleOrderStatusChanged)
+	go s.detectAbandonedCarts(envDuration("CART_ABANDONMENT_INTERVAL", 15*time.Minute))
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(
+		newLoadShedElement(),
+		tracing.NewServerTracingElement(),
+		recovery.NewServerRecoveryElement(),
+		usercontext.NewServerElement(),
+	)
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
 	}
 
 	pb.RegisterCartServiceServer(server, s)
+	pb.RegisterPrivacyServiceServer(server, s)
 	log.Printf("CartService running at port: %d", s.port)
 	server.Start()
 	return nil
 }
 
 // AddItem adds an item to the user's cart
-func (s *CartService) AddItem(ctx context.Context, req *pb.AddItemRequest) (*pb.Empty, context.Context, error) {
-	log.Printf("AddItem request for user_id = %v, product_id = %v, quantity = %v", req.GetUserId(), req.GetItem().GetProductId(), req.GetItem().GetQuantity())
+func (s *CartService) AddItem(ctx context.Context, req *pb.AddItemRequest) (_ *pb.Empty, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
-	userID := req.GetUserId()
+	userID := usercontext.UserID(ctx, req.GetUserId())
 	item := req.GetItem()
 
 	// Fetch the existing cart
-	data, err := s.rdb.Get(ctx, userID).Result()
-	var cart []*pb.CartItem
+	data, err := s.rdb.Get(ctx, tenant.Key(ctx, userID)).Bytes()
+	var cart *storedCart
 	if err == redis.Nil {
-		cart = []*pb.CartItem{} // Empty cart
+		cart = &storedCart{V: cartSchemaVersion} // Empty cart
 	} else if err != nil {
 		log.Printf("Failed to fetch cart for user_id = %v: %v", userID, err)
 		return nil, ctx, err
 	} else {
-		err = json.Unmarshal([]byte(data), &cart)
+		cart, err = decodeCart(data)
 		if err != nil {
 			log.Printf("Failed to unmarshal cart for user_id = %v: %v", userID, err)
 			return nil, ctx, err
 		}
 	}
 
+	if err := checkCartLimits(cart.Items, item); err != nil {
+		log.Printf("Rejecting item for user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+
 	// Add item to the cart
-	cart = append(cart, item)
+	cart.Items = append(cart.Items, item)
 
-	// Save the updated cart
-	cartData, err := json.Marshal(cart)
+	// Save the updated cart, migrating it to the current schema
+	cartData, err := encodeCart(cart)
 	if err != nil {
 		log.Printf("Failed to marshal cart for user_id = %v: %v", userID, err)
 		return nil, ctx, err
 	}
 
-	err = s.rdb.Set(ctx, userID, cartData, 0).Err()
+	err = s.rdb.Set(ctx, tenant.Key(ctx, userID), cartData, 0).Err()
 	if err != nil {
 		log.Printf("Failed to save cart for user_id = %v: %v", userID, err)
 		return nil, ctx, err
 	}
+	s.touchCart(ctx, userID)
 
 	return &pb.Empty{}, ctx, nil
 }
 
 // GetCart retrieves the cart for a user
-func (s *CartService) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.Cart, context.Context, error) {
-	log.Printf("GetCart request for user_id = %v", req.GetUserId())
+func (s *CartService) GetCart(ctx context.Context, req *pb.GetCartRequest) (_ *pb.Cart, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.UserID(ctx, req.GetUserId())
 
-	userID := req.GetUserId()
-	data, err := s.rdb.Get(ctx, userID).Result()
+	data, err := s.rdb.Get(ctx, tenant.Key(ctx, userID)).Bytes()
 	if err == redis.Nil {
 		return &pb.Cart{
 			UserId: userID,
@@ -116,8 +145,7 @@ func (s *CartService) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.
 		return nil, ctx, err
 	}
 
-	var cart []*pb.CartItem
-	err = json.Unmarshal([]byte(data), &cart)
+	cart, err := decodeCart(data)
 	if err != nil {
 		log.Printf("Failed to unmarshal cart for user_id = %v: %v", userID, err)
 		return nil, ctx, err
@@ -125,19 +153,91 @@ func (s *CartService) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.
 
 	return &pb.Cart{
 		UserId: userID,
-		Items:  cart,
+		Items:  cart.Items,
 	}, ctx, nil
 }
 
 // EmptyCart clears the cart for a user
-func (s *CartService) EmptyCart(ctx context.Context, req *pb.EmptyCartRequest) (*pb.Empty, context.Context, error) {
-	log.Printf("EmptyCart request for user_id = %v", req.GetUserId())
+func (s *CartService) EmptyCart(ctx context.Context, req *pb.EmptyCartRequest) (_ *pb.Empty, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
-	err := s.rdb.Del(ctx, req.GetUserId()).Err()
-	if err != nil {
-		log.Printf("Failed to delete cart for user_id = %v: %v", req.GetUserId(), err)
+	userID := usercontext.UserID(ctx, req.GetUserId())
+
+	// An emptied cart, such as one checked out, is not left behind. The
+	// cart and the activity index may be in different cluster slots, so
+	// they are not changed in one transaction: an entry left in the index
+	// is taken off by the abandonment detector, which finds the cart empty.
+	if err := s.rdb.Del(ctx, tenant.Key(ctx, userID)).Err(); err != nil {
+		log.Printf("Failed to delete cart for user_id = %v: %v", userID, err)
 		return nil, ctx, err
 	}
+	if err := s.rdb.ZRem(ctx, cartActivityKey(ctx), userID).Err(); err != nil {
+		log.Printf("Failed to remove activity of cart for user_id = %v: %v", userID, err)
+	}
 
 	return &pb.Empty{}, ctx, nil
 }
+
+// ExportUserData returns the cart of the user and the address they are
+// reminded of it at.
+func (s *CartService) ExportUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID, err := privacy.User(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+	cart, _, err := s.GetCart(ctx, &pb.GetCartRequest{})
+	if err != nil {
+		return nil, ctx, err
+	}
+	contact, err := s.rdb.HGetAll(ctx, cartContactKey(ctx, userID)).Result()
+	if err != nil {
+		log.Printf("Failed to fetch cart contact for user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+	rec, err := privacy.Record("cart", len(cart.GetItems()), cart.GetItems(), true)
+	if err != nil {
+		return nil, ctx, err
+	}
+	contactRec, err := privacy.Record("cart_reminder_contact", min(len(contact), 1), contact, true)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec, contactRec}}, ctx, nil
+}
+
+// DeleteUserData deletes the cart of the user. Its keys may be in different
+// cluster slots, so they are deleted one at a time; the request fails if any
+// is left, to be tried again.
+func (s *CartService) DeleteUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID, err := privacy.User(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+	carts, err := s.rdb.Del(ctx, tenant.Key(ctx, userID)).Result()
+	if err != nil {
+		log.Printf("Failed to delete cart for user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+	contacts, err := s.rdb.Del(ctx, cartContactKey(ctx, userID)).Result()
+	if err != nil {
+		log.Printf("Failed to delete cart contact for user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+	if err := s.rdb.ZRem(ctx, cartActivityKey(ctx), userID).Err(); err != nil {
+		log.Printf("Failed to remove activity of cart for user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+	rec, err := privacy.Record("cart", int(carts), nil, false)
+	if err != nil {
+		return nil, ctx, err
+	}
+	contactRec, err := privacy.Record("cart_reminder_contact", int(contacts), nil, false)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec, contactRec}}, ctx, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// cartAbandonedAfter is how long a cart is left untouched before it counts
+// as abandoned, CART_ABANDONED_AFTER. Zero turns detection off.
+var cartAbandonedAfter = config.NewValue(func() time.Duration {
+	return envDuration("CART_ABANDONED_AFTER", 24*time.Hour)
+})
+
+// cartContactTTL is how long the address of a shopper's last order is kept
+// to remind them of the carts they leave.
+const cartContactTTL = 90 * 24 * time.Hour
+
+// cartAbandonmentBatch is how many idle carts are read from the index at
+// once.
+const cartAbandonmentBatch = 100
+
+// cartAbandonmentStats counts the runs of the detector and the carts it
+// found abandoned, found empty, or failed to publish.
+var cartAbandonmentStats = expvar.NewMap("cart_abandonment")
+
+// touchCart notes that the cart of userID changed now. A cart is reported
+// abandoned once per time it is left: changing it again starts over.
+func (s *CartService) touchCart(ctx context.Context, userID string) {
+	err := s.rdb.ZAdd(ctx, cartActivityKey(ctx), redis.Z{Score: float64(time.Now().Unix()), Member: userID}).Err()
+	if err != nil {
+		log.Printf("Failed to record activity of cart for user_id = %v: %v", userID, err)
+	}
+}
+
+// handleOrderStatusChanged remembers the address and language a shopper
+// paid for an order with, the only way a cart is tied to an address.
+func (s *CartService) handleOrderStatusChanged(payload []byte) error {
+	var event pb.OrderStatusChanged
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	if event.GetStatus().GetStatus() != orderPaid || event.GetUserId() == "" || event.GetEmail() == "" {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+	key := cartContactKey(ctx, event.GetUserId())
+	pipe := s.rdb.TxPipeline()
+	pipe.HSet(ctx, key, "email", event.GetEmail(), "locale", event.GetLocale())
+	pipe.Expire(ctx, key, cartContactTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// detectAbandonedCarts publishes the carts left untouched for longer than
+// CART_ABANDONED_AFTER, every interval.
+func (s *CartService) detectAbandonedCarts(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		after := cartAbandonedAfter.Get()
+		if after <= 0 {
+			continue
+		}
+		cartAbandonmentStats.Add("runs", 1)
+		for _, t := range append([]string{""}, tenant.IDs()...) {
+			ctx := tenant.NewContext(context.Background(), t)
+			cutoff := time.Now().Add(-after)
+			for {
+				n, err := s.publishAbandonedCarts(ctx, cutoff)
+				if err != nil {
+					log.Printf("Failed to detect abandoned carts of tenant %q: %v", t, err)
+				}
+				if err != nil || n < cartAbandonmentBatch {
+					break
+				}
+			}
+		}
+	}
+}
+
+// publishAbandonedCarts publishes a CartAbandoned event for up to
+// cartAbandonmentBatch carts last changed before cutoff, taking them off
+// the index. It returns how many it took off; those whose event could not
+// be published stay for the next pass.
+func (s *CartService) publishAbandonedCarts(ctx context.Context, cutoff time.Time) (int, error) {
+	idle, err := s.rdb.ZRangeByScoreWithScores(ctx, cartActivityKey(ctx), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(cutoff.Unix(), 10),
+		Count: cartAbandonmentBatch,
+	}).Result()
+	if err != nil {
+		return 0, err
+	}
+	done := 0
+	for _, z := range idle {
+		userID, _ := z.Member.(string)
+		cart, _, err := s.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
+		if err != nil {
+			return done, err
+		}
+		if len(cart.GetItems()) == 0 {
+			// Emptied, or expired, without going through EmptyCart.
+			cartAbandonmentStats.Add("empty", 1)
+		} else {
+			contact, err := s.rdb.HGetAll(ctx, cartContactKey(ctx, userID)).Result()
+			if err != nil {
+				return done, err
+			}
+			err = s.bus.Publish(ctx, eventbus.TopicCartAbandoned, &pb.CartAbandoned{
+				Cart:      cart,
+				UpdatedAt: int64(z.Score),
+				Email:     contact["email"],
+				Locale:    contact["locale"],
+				Tenant:    tenant.FromContext(ctx),
+			})
+			if err != nil {
+				cartAbandonmentStats.Add("failed", 1)
+				log.Printf("Failed to publish abandoned cart for user_id = %v: %v", userID, err)
+				continue
+			}
+			cartAbandonmentStats.Add("abandoned", 1)
+		}
+		if err := s.rdb.ZRem(ctx, cartActivityKey(ctx), userID).Err(); err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+func cartActivityKey(ctx context.Context) string {
+	return tenant.Key(ctx, "cart-activity")
+}
+
+func cartContactKey(ctx context.Context, userID string) string {
+	return tenant.Key(ctx, "cart-contact:"+userID)
+}
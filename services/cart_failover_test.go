@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// fakeSentinel is a Redis Sentinel that knows one master, mymaster, and
+// fails it over to another on demand.
+type fakeSentinel struct {
+	*miniredis.Miniredis
+
+	mu     sync.Mutex
+	master *miniredis.Miniredis
+}
+
+func startFakeSentinel(t *testing.T, master *miniredis.Miniredis) *fakeSentinel {
+	s := &fakeSentinel{Miniredis: miniredis.RunT(t), master: master}
+	err := s.Server().Register("SENTINEL", func(c *server.Peer, cmd string, args []string) {
+		switch {
+		case len(args) == 2 && args[0] == "get-master-addr-by-name" && args[1] == "mymaster":
+			s.mu.Lock()
+			m := s.master
+			s.mu.Unlock()
+			c.WriteStrings([]string{m.Host(), m.Port()})
+		case len(args) == 2 && args[0] == "sentinels":
+			c.WriteLen(0)
+		default:
+			c.WriteError("ERR unsupported SENTINEL command")
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// failover promotes replica, which is given a copy of the data of the old
+// master, as the old master is demoted to a read-only replica.
+func (s *fakeSentinel) failover(t *testing.T, replica *miniredis.Miniredis) {
+	s.mu.Lock()
+	old := s.master
+	s.mu.Unlock()
+	for _, k := range old.Keys() {
+		switch old.Type(k) {
+		case "string":
+			v, _ := old.Get(k)
+			replica.Set(k, v)
+		case "zset":
+			members, _ := old.ZMembers(k)
+			for _, m := range members {
+				score, _ := old.ZScore(k, m)
+				replica.ZAdd(k, score, m)
+			}
+		default:
+			t.Fatalf("cannot copy key %q of type %s", k, old.Type(k))
+		}
+	}
+	old.SetError("READONLY You can't write against a read only replica.")
+
+	s.mu.Lock()
+	s.master = replica
+	s.mu.Unlock()
+	s.Publish("+switch-master", "mymaster "+old.Host()+" "+old.Port()+" "+replica.Host()+" "+replica.Port())
+}
+
+func addCartItem(ctx context.Context, s *CartService, productID string) error {
+	_, _, err := s.AddItem(ctx, &pb.AddItemRequest{Item: &pb.CartItem{ProductId: productID, Quantity: 1}})
+	return err
+}
+
+func cartProducts(t *testing.T, ctx context.Context, s *CartService) []string {
+	t.Helper()
+	cart, _, err := s.GetCart(ctx, &pb.GetCartRequest{})
+	if err != nil {
+		t.Fatalf("get cart: %v", err)
+	}
+	var ids []string
+	for _, item := range cart.GetItems() {
+		ids = append(ids, item.GetProductId())
+	}
+	return ids
+}
+
+func TestCartSurvivesSentinelFailover(t *testing.T) {
+	master, replica := miniredis.RunT(t), miniredis.RunT(t)
+	sentinel := startFakeSentinel(t, master)
+	t.Setenv("CART_REDIS_ADDR", sentinel.Addr())
+	t.Setenv("CART_REDIS_MODE", "sentinel")
+	t.Setenv("CART_REDIS_MASTER", "mymaster")
+
+	s := &CartService{rdb: newRedisClient("CART")}
+	defer s.rdb.Close()
+	ctx := usercontext.NewContext(context.Background(), "shopper")
+
+	if err := addCartItem(ctx, s, "OLJCESPC7Z"); err != nil {
+		t.Fatalf("add item before failover: %v", err)
+	}
+	sentinel.failover(t, replica)
+
+	// Writes go to the new master once the client has learned of it, which
+	// retries of READONLY errors leave it time to.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := addCartItem(ctx, s, "66VCHSJNUP")
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("writes did not recover after failover: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if got := cartProducts(t, ctx, s); len(got) != 2 || got[0] != "OLJCESPC7Z" || got[1] != "66VCHSJNUP" {
+		t.Errorf("cart after failover holds %v, want both items", got)
+	}
+	if len(replica.Keys()) == 0 {
+		t.Error("the new master was never written to")
+	}
+}
+
+func TestCartSurvivesRedisRestart(t *testing.T) {
+	rdb := miniredis.RunT(t)
+	t.Setenv("CART_REDIS_ADDR", rdb.Addr())
+	t.Setenv("CART_REDIS_MODE", "")
+
+	s := &CartService{rdb: newRedisClient("CART")}
+	defer s.rdb.Close()
+	ctx := usercontext.NewContext(context.Background(), "shopper")
+
+	if err := addCartItem(ctx, s, "OLJCESPC7Z"); err != nil {
+		t.Fatalf("add item before restart: %v", err)
+	}
+	rdb.Close()
+	if err := addCartItem(ctx, s, "66VCHSJNUP"); err == nil {
+		t.Fatal("add item succeeded while Redis was down")
+	}
+	if err := rdb.Restart(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := addCartItem(ctx, s, "66VCHSJNUP"); err != nil {
+		t.Fatalf("writes did not recover after restart: %v", err)
+	}
+	if got := cartProducts(t, ctx, s); len(got) != 2 {
+		t.Errorf("cart after restart holds %v, want both items", got)
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/rpcstatus"
+)
+
+// Cart limits, as named in pb.CartLimitExceeded.
+const (
+	cartLimitItems    = "items"
+	cartLimitQuantity = "quantity"
+)
+
+// cartLimitError returns the ResourceExhausted error of an AddItem call that
+// would take the cart past the limit e.
+func cartLimitError(e *pb.CartLimitExceeded) error {
+	st := status.Newf(codes.ResourceExhausted, "cart limit exceeded: %s limit of %d for product %s", e.Limit, e.Max, e.ProductId)
+	if detailed, err := st.WithDetails(e); err == nil {
+		st = detailed
+	}
+	return rpcstatus.Error(st)
+}
+
+// AsCartLimitExceeded reports whether err is the error of an AddItem call
+// that would take the cart past a limit, and returns the limit.
+func AsCartLimitExceeded(err error) (*pb.CartLimitExceeded, bool) {
+	st, ok := rpcstatus.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return nil, false
+	}
+	for _, d := range st.Details() {
+		if e, ok := d.(*pb.CartLimitExceeded); ok {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// checkCartLimits returns an error if adding item to cart would exceed the
+// configured limits. Items are distinct by product and variant; the quantity
+// limit counts all variants of a product together. A limit of 0 disables it.
+func checkCartLimits(cart []*pb.CartItem, item *pb.CartItem) error {
+	maxItems := envInt("CART_MAX_ITEMS", 50)
+	maxQuantity := envInt("CART_MAX_QUANTITY", 10)
+
+	type key struct{ product, variant string }
+	distinct := make(map[key]bool)
+	quantity := item.GetQuantity()
+	for _, it := range cart {
+		distinct[key{it.GetProductId(), it.GetVariantId()}] = true
+		if it.GetProductId() == item.GetProductId() {
+			quantity += it.GetQuantity()
+		}
+	}
+
+	switch {
+	case maxItems > 0 && !distinct[key{item.GetProductId(), item.GetVariantId()}] && len(distinct) >= maxItems:
+		return cartLimitError(&pb.CartLimitExceeded{Limit: cartLimitItems, Max: int32(maxItems), ProductId: item.GetProductId()})
+	case maxQuantity > 0 && int(quantity) > maxQuantity:
+		return cartLimitError(&pb.CartLimitExceeded{Limit: cartLimitQuantity, Max: int32(maxQuantity), ProductId: item.GetProductId()})
+	}
+	return nil
+}
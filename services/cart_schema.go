@@ -0,0 +1,105 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"strconv"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// cartSchemaVersion is the version of the stored cart schema written by this
+// code. Carts of older versions are read as they are and rewritten as the
+// current version the next time they change:
+//
+//   - Version 1 carts are bare JSON arrays of items.
+//   - Version 2 carts are JSON envelopes, {"v": 2, "items": [...]}.
+//   - Version 3 carts are pb.Cart messages in the Symphony encoding used on
+//     the wire, which is cheaper to decode and smaller than JSON. Symphony
+//     messages start with a zero byte, which sets them apart from JSON.
+//
+// Carts of later versions are expected to be JSON envelopes too.
+const cartSchemaVersion = 3
+
+// cartSchemaReads counts the carts read, by schema version, to tell when no
+// carts of older versions are left.
+var cartSchemaReads = expvar.NewMap("cart_schema_reads")
+
+// symphonyHeader starts every Symphony message.
+const symphonyHeader = 0x00
+
+// storedCart is a cart as kept in Redis.
+type storedCart struct {
+	// V is the version of the schema the cart was written with.
+	V     int
+	Items []*pb.CartItem
+
+	// extra holds the fields of a newer schema, kept so that rewriting the
+	// cart does not drop them.
+	extra map[string]json.RawMessage
+}
+
+// decodeCart reads a stored cart of any version. Carts written by a newer
+// version are read as far as this one understands them.
+func decodeCart(data []byte) (*storedCart, error) {
+	if len(data) > 0 && data[0] == symphonyHeader {
+		var msg pb.Cart
+		if err := msg.UnmarshalSymphony(data); err != nil {
+			return nil, fmt.Errorf("decoding version 3 cart: %w", err)
+		}
+		cartSchemaReads.Add("3", 1)
+		return &storedCart{V: 3, Items: msg.GetItems()}, nil
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		c := &storedCart{V: 1}
+		if err := json.Unmarshal(data, &c.Items); err != nil {
+			return nil, fmt.Errorf("decoding version 1 cart: %w", err)
+		}
+		cartSchemaReads.Add("1", 1)
+		return c, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("decoding cart: %w", err)
+	}
+	c := &storedCart{}
+	if err := json.Unmarshal(fields["v"], &c.V); err != nil || c.V < 2 {
+		return nil, fmt.Errorf("decoding cart: invalid schema version %s", fields["v"])
+	}
+	if items, ok := fields["items"]; ok {
+		if err := json.Unmarshal(items, &c.Items); err != nil {
+			return nil, fmt.Errorf("decoding version %d cart: %w", c.V, err)
+		}
+	}
+	delete(fields, "v")
+	delete(fields, "items")
+	if len(fields) > 0 {
+		c.extra = fields
+	}
+	cartSchemaReads.Add(strconv.Itoa(c.V), 1)
+	return c, nil
+}
+
+// encodeCart writes c in the current schema, or in the version it was read
+// with if that is newer, keeping that version's fields.
+func encodeCart(c *storedCart) ([]byte, error) {
+	if c.V <= cartSchemaVersion && c.extra == nil {
+		return (&pb.Cart{Items: c.Items}).MarshalSymphony()
+	}
+	fields := make(map[string]any, len(c.extra)+2)
+	for k, v := range c.extra {
+		fields[k] = v
+	}
+	fields["v"] = c.V
+	items := c.Items
+	if items == nil {
+		items = []*pb.CartItem{}
+	}
+	fields["items"] = items
+	return json.Marshal(fields)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// TestCartDeletesSpanSlots checks that emptying a cart and deleting the
+// data of its shopper work with keys in different cluster slots, which
+// cannot be changed in one transaction.
+func TestCartDeletesSpanSlots(t *testing.T) {
+	rdb := miniredis.RunT(t)
+	rdb.Server().SetPreHook(func(c *server.Peer, cmd string, args ...string) bool {
+		if cmd == "MULTI" {
+			c.WriteError("CROSSSLOT Keys in request don't hash to the same slot")
+			return true
+		}
+		return false
+	})
+	t.Setenv("CART_REDIS_ADDR", rdb.Addr())
+	t.Setenv("CART_REDIS_MODE", "")
+
+	s := &CartService{rdb: newRedisClient("CART")}
+	defer s.rdb.Close()
+	ctx := usercontext.NewContext(context.Background(), "shopper")
+
+	if err := addCartItem(ctx, s, "OLJCESPC7Z"); err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	if _, _, err := s.EmptyCart(ctx, &pb.EmptyCartRequest{}); err != nil {
+		t.Fatalf("empty cart: %v", err)
+	}
+	if got := cartProducts(t, ctx, s); len(got) != 0 {
+		t.Errorf("emptied cart holds %v", got)
+	}
+	if members, _ := rdb.ZMembers(cartActivityKey(ctx)); len(members) != 0 {
+		t.Errorf("emptied cart is still indexed as active: %v", members)
+	}
+
+	if err := addCartItem(ctx, s, "OLJCESPC7Z"); err != nil {
+		t.Fatalf("add item: %v", err)
+	}
+	rdb.HSet(cartContactKey(ctx, "shopper"), "email", "shopper@example.com")
+	deleted, _, err := s.DeleteUserData(ctx, &pb.UserDataRequest{})
+	if err != nil {
+		t.Fatalf("delete user data: %v", err)
+	}
+	for _, rec := range deleted.GetRecords() {
+		if rec.GetCount() != 1 {
+			t.Errorf("deleted %d %s records, want 1", rec.GetCount(), rec.GetKind())
+		}
+	}
+	if keys := rdb.Keys(); len(keys) != 0 {
+		t.Errorf("keys left after deleting the user's data: %v", keys)
+	}
+}
@@ -2,22 +2,44 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"expvar"
 	"fmt"
 	"log"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
-	"github.com/appnet-org/arpc/pkg/serializer"
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/audit"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/pricing"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/resolver"
+	"github.com/appnetorg/online-boutique-arpc/services/rpclog"
+	"github.com/appnetorg/online-boutique-arpc/services/rpcstatus"
+	"github.com/appnetorg/online-boutique-arpc/services/startup"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
 )
 
 const (
@@ -29,8 +51,51 @@ const (
 var (
 	ErrInvalidValue        = errors.New("one of the specified money values is invalid")
 	ErrMismatchingCurrency = errors.New("mismatching currency codes")
+	ErrOutOfRange          = errors.New("money value out of range")
 )
 
+// moneyErrors counts the money operations that failed, by operation and
+// error, e.g. "sum_mismatching_currency", so that bad amounts show up before
+// they fail requests.
+var moneyErrors = expvar.NewMap("money_errors")
+
+// MoneyOpErr is the error of a money operation on invalid amounts or on
+// amounts in different currencies, or on amounts too large to compute with.
+// Err is ErrInvalidValue, ErrMismatchingCurrency or ErrOutOfRange.
+type MoneyOpErr struct {
+	Op          string
+	Left, Right string
+	Err         error
+}
+
+func (e MoneyOpErr) Error() string {
+	return fmt.Sprintf("%s of %s and %s amounts: %v", e.Op, e.Left, e.Right, e.Err)
+}
+
+func (e MoneyOpErr) Unwrap() error {
+	return e.Err
+}
+
+func (e MoneyOpErr) GRPCStatus() *status.Status {
+	if e.Err == ErrOutOfRange {
+		return status.New(codes.OutOfRange, e.Error())
+	}
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// moneyOpErr counts and returns the error of op on l and r.
+func moneyOpErr(op string, l, r *pb.Money, err error) error {
+	kind := "invalid_value"
+	switch err {
+	case ErrMismatchingCurrency:
+		kind = "mismatching_currency"
+	case ErrOutOfRange:
+		kind = "out_of_range"
+	}
+	moneyErrors.Add(op+"_"+kind, 1)
+	return MoneyOpErr{Op: op, Left: l.GetCurrencyCode(), Right: r.GetCurrencyCode(), Err: err}
+}
+
 // NewCheckoutService returns a new server for the CheckoutService
 func NewCheckoutService(port int) *CheckoutService {
 	return &CheckoutService{
@@ -43,22 +108,35 @@ type CheckoutService struct {
 	port int
 
 	productCatalogSvcAddr string
-	productCatalogSvcConn *rpc.Client
+	productCatalogSvcConn *resolver.Pool
 
 	cartSvcAddr string
-	cartSvcConn *rpc.Client
+	cartSvcConn *resolver.Pool
 
 	currencySvcAddr string
-	currencySvcConn *rpc.Client
+	currencySvcConn *resolver.Pool
 
 	shippingSvcAddr string
-	shippingSvcConn *rpc.Client
+	shippingSvcConn *resolver.Pool
 
 	emailSvcAddr string
-	emailSvcConn *rpc.Client
+	emailSvcConn *resolver.Pool
 
 	paymentSvcAddr string
-	paymentSvcConn *rpc.Client
+	paymentSvcConn *resolver.Pool
+
+	addressSvcAddr string
+	addressSvcConn *resolver.Pool
+
+	walletSvcAddr string
+	walletSvcConn *resolver.Pool
+
+	// The status of orders, kept up to date from the payment and shipment
+	// events on the event bus. Cancellations are recorded in the audit log.
+	rdb          redis.UniversalClient
+	audit        *audit.Log
+	eventBusAddr string
+	bus          *eventbus.Bus
 }
 
 // Run starts the server
@@ -68,12 +146,14 @@ func (cs *CheckoutService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	mustMapEnv(&cs.shippingSvcAddr, "SHIPPING_SERVICE_ADDR")
-	mustMapEnv(&cs.productCatalogSvcAddr, "PRODUCT_CATALOG_SERVICE_ADDR")
-	mustMapEnv(&cs.cartSvcAddr, "CART_SERVICE_ADDR")
-	mustMapEnv(&cs.currencySvcAddr, "CURRENCY_SERVICE_ADDR")
-	mustMapEnv(&cs.emailSvcAddr, "EMAIL_SERVICE_ADDR")
-	mustMapEnv(&cs.paymentSvcAddr, "PAYMENT_SERVICE_ADDR")
+	mapServiceAddr(&cs.shippingSvcAddr, "SHIPPING_SERVICE_ADDR", "shipping")
+	mapServiceAddr(&cs.productCatalogSvcAddr, "PRODUCT_CATALOG_SERVICE_ADDR", "productcatalog")
+	mapServiceAddr(&cs.cartSvcAddr, "CART_SERVICE_ADDR", "cart")
+	mapServiceAddr(&cs.currencySvcAddr, "CURRENCY_SERVICE_ADDR", "currency")
+	mapServiceAddr(&cs.emailSvcAddr, "EMAIL_SERVICE_ADDR", "email")
+	mapServiceAddr(&cs.paymentSvcAddr, "PAYMENT_SERVICE_ADDR", "payment")
+	mapServiceAddr(&cs.addressSvcAddr, "ADDRESS_SERVICE_ADDR", "address")
+	mapServiceAddr(&cs.walletSvcAddr, "WALLET_SERVICE_ADDR", "wallet")
 
 	mustConnARPC(&cs.shippingSvcConn, cs.shippingSvcAddr)
 	mustConnARPC(&cs.productCatalogSvcConn, cs.productCatalogSvcAddr)
@@ -81,128 +161,457 @@ func (cs *CheckoutService) Run() error {
 	mustConnARPC(&cs.currencySvcConn, cs.currencySvcAddr)
 	mustConnARPC(&cs.emailSvcConn, cs.emailSvcAddr)
 	mustConnARPC(&cs.paymentSvcConn, cs.paymentSvcAddr)
+	mustConnARPC(&cs.addressSvcConn, cs.addressSvcAddr)
+	mustConnARPC(&cs.walletSvcConn, cs.walletSvcAddr)
+
+	checker := newStartupChecker()
+	checker.Add("shipping", startup.ARPC(cs.shippingSvcConn.Addrs))
+	checker.Add("productcatalog", startup.ARPC(cs.productCatalogSvcConn.Addrs))
+	checker.Add("cart", startup.ARPC(cs.cartSvcConn.Addrs))
+	checker.Add("currency", startup.ARPC(cs.currencySvcConn.Addrs))
+	checker.Add("email", startup.ARPC(cs.emailSvcConn.Addrs))
+	checker.Add("payment", startup.ARPC(cs.paymentSvcConn.Addrs))
+	checker.Add("address", startup.ARPC(cs.addressSvcConn.Addrs))
+	checker.Add("wallet", startup.ARPC(cs.walletSvcConn.Addrs))
+
+	cs.rdb = newRedisClient("CHECKOUT")
+	cs.audit = audit.New(cs.rdb, "CheckoutService")
+	checker.Add("redis", func(ctx context.Context) error {
+		return cs.rdb.Ping(ctx).Err()
+	})
+	mustCheckStartup(checker)
+
+	mustMapEnv(&cs.eventBusAddr, "EVENT_BUS_ADDR")
+	cs.bus = eventbus.New(cs.eventBusAddr)
+	go cs.bus.Subscribe(context.Background(), eventbus.TopicPaymentStatusChanged, cs.handlePaymentStatusChanged)
+	go cs.bus.Subscribe(context.Background(), eventbus.TopicShipmentStatusChanged, cs.handleShipmentStatusChanged)
+	go cs.retainOrders(envDuration("ORDER_RETENTION_INTERVAL", time.Hour))
 
 	// Create ARPC server
-	serializer := &serializer.SymphonySerializer{}
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(cs.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
 	}
 
 	pb.RegisterCheckoutServiceServer(server, cs)
+	pb.RegisterPrivacyServiceServer(server, cs)
 	log.Printf("CheckoutService running at port: %d", cs.port)
 	server.Start()
 	return nil
 }
 
 // PlaceOrder processes an order placement request
-func (cs *CheckoutService) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, context.Context, error) {
-	log.Printf("[PlaceOrder] user_id=%q user_currency=%q", req.UserId, req.UserCurrency)
+func (cs *CheckoutService) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (_ *pb.PlaceOrderResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+	// The cart, catalog, payment and shipping calls act for the same tenant.
+	ctx = tenant.Forward(ctx)
 
-	orderID, err := uuid.NewUUID()
-	if err != nil {
-		return nil, ctx, status.Errorf(codes.Internal, "failed to generate order uuid")
-	}
+	// Calls made for the order act for the same user, even if the caller
+	// only sent the deprecated user_id field.
+	userID := usercontext.UserID(ctx, req.UserId)
+	ctx = usercontext.NewContext(ctx, userID)
+	rpclog.Info(ctx, "placing order", zap.String("user_id", userID), zap.String("user_currency", req.UserCurrency))
+
+	// Order IDs are random: knowing one does not give away others.
+	orderID := uuid.New()
 
-	prep, err := cs.prepareOrderItemsAndShippingQuoteFromCart(ctx, req.UserId, req.UserCurrency, req.Address)
+	if err := checkConsent(req.GetConsent(), time.Now()); err != nil {
+		rpclog.Warn(ctx, "order refused", zap.String("user_id", userID), zap.Error(err))
+		return nil, ctx, err
+	}
+	priced, err := cs.priceOrder(ctx, userID, req)
 	if err != nil {
-		return nil, ctx, status.Error(codes.Internal, err.Error())
+		return nil, ctx, err
 	}
+	address, note, prep, breakdown := priced.address, priced.note, priced.prep, priced.breakdown
+	total, walletPaid, cardAmount := breakdown.GetTotal(), priced.walletPaid, priced.cardAmount
+
+	cs.createOrder(ctx, orderID.String(), userID, req)
 
-	total := pb.Money{CurrencyCode: req.UserCurrency,
-		Units: 0,
-		Nanos: 0}
-	total = *Must(Sum(&total, prep.shippingCostLocalized))
-	for _, it := range prep.orderItems {
-		multPrice := MultiplySlow(it.Cost, uint32(it.GetItem().GetQuantity()))
-		total = *Must(Sum(&total, multPrice))
+	// The stock of the order is held until it is placed. It goes back on
+	// sale if the order fails or, should it never complete, once the hold
+	// expires.
+	if err := cs.reserveStock(ctx, orderID.String(), prep.cartItems); err != nil {
+		cs.setOrderStatus(ctx, orderID.String(), orderCancelled, "stock reservation failed")
+		return nil, ctx, status.Errorf(codes.FailedPrecondition, "failed to reserve stock: %+v", err)
 	}
 
-	txID, err := cs.chargeCard(ctx, &total, req.CreditCard)
-	if err != nil {
-		return nil, ctx, status.Errorf(codes.Internal, "failed to charge card: %+v", err)
+	// The wallet is debited first and refunded if the card is then declined,
+	// so that neither leg is left paid for an order that was not placed.
+	if walletPaid != nil {
+		if err := cs.debitWallet(ctx, orderID.String(), walletPaid); err != nil {
+			cs.releaseStock(ctx, orderID.String())
+			cs.setOrderStatus(ctx, orderID.String(), orderCancelled, "wallet payment failed")
+			return nil, ctx, status.Errorf(codes.FailedPrecondition, "failed to pay from wallet: %+v", err)
+		}
+		rpclog.Info(ctx, "wallet debited", zap.String("debit_id", orderID.String()))
+	}
+	var txID string
+	var plan *pb.InstallmentPlan
+	if !IsZero(cardAmount) {
+		txID, plan, err = cs.chargeCard(ctx, userID, orderID.String(), cardAmount, req.CreditCard, req.GetInstallments())
+		if err != nil {
+			if walletPaid != nil {
+				cs.refundWallet(ctx, orderID.String())
+			}
+			cs.releaseStock(ctx, orderID.String())
+			cs.setOrderStatus(ctx, orderID.String(), orderCancelled, "card payment failed")
+			return nil, ctx, cardChargeError(err)
+		}
+		rpclog.Info(ctx, "card charged", zap.String("transaction_id", txID))
 	}
-	log.Printf("payment went through (transaction_id: %s)", txID)
+	cs.setOrderPayment(ctx, orderID.String(), txID)
 
-	shippingTrackingID, err := cs.shipOrder(ctx, req.Address, prep.cartItems)
+	shippingTrackingID, shipments, err := cs.shipOrder(ctx, &pb.ShipOrderRequest{
+		Address:        address,
+		Items:          prep.cartItems,
+		OrderId:        orderID.String(),
+		Email:          req.Email,
+		Locale:         req.Locale,
+		UserId:         userID,
+		GiftWrap:       req.GetGiftWrap(),
+		Note:           note,
+		DeliveryWindow: req.GetDeliveryWindow()})
 	if err != nil {
+		// Both legs of the payment are given back, as for a declined card.
+		if txID != "" {
+			cs.refundCharge(ctx, txID, "shipping failed")
+		}
+		if walletPaid != nil {
+			cs.refundWallet(ctx, orderID.String())
+		}
+		cs.releaseStock(ctx, orderID.String())
+		cs.setOrderStatus(ctx, orderID.String(), orderCancelled, "shipping failed")
 		return nil, ctx, status.Errorf(codes.Unavailable, "shipping error: %+v", err)
 	}
 
-	_ = cs.emptyUserCart(ctx, req.UserId)
+	// The stock is sold before the order is confirmed. If it cannot be, as
+	// when its hold expired and it went back on sale, the order is given up
+	// like one that could not be shipped.
+	if err := cs.commitStock(ctx, orderID.String()); err != nil {
+		trackingIDs := []string{shippingTrackingID}
+		if groups := shipments.GetGroups(); len(groups) > 0 {
+			trackingIDs = make([]string, len(groups))
+			for i, g := range groups {
+				trackingIDs[i] = g.TrackingId
+			}
+		}
+		cs.cancelShipments(ctx, orderID.String(), "stock not committed", trackingIDs...)
+		if txID != "" {
+			cs.refundCharge(ctx, txID, "stock not committed")
+		}
+		if walletPaid != nil {
+			cs.refundWallet(ctx, orderID.String())
+		}
+		cs.releaseStock(ctx, orderID.String())
+		cs.setOrderStatus(ctx, orderID.String(), orderCancelled, "stock not committed")
+		return nil, ctx, status.Errorf(codes.Aborted, "failed to commit stock: %+v", err)
+	}
+	cs.setOrderShipments(ctx, orderID.String(), max(len(shipments.GetGroups()), 1))
 
 	orderResult := &pb.OrderResult{
 		OrderId:            orderID.String(),
 		ShippingTrackingId: shippingTrackingID,
 		ShippingCost:       prep.shippingCostLocalized,
-		ShippingAddress:    req.Address,
+		ShippingAddress:    address,
 		Items:              prep.orderItems,
+		Breakdown:          breakdown,
+		GiftWrap:           req.GetGiftWrap(),
+		Note:               note,
+		Shipments:          shipments,
+		DeliveryWindow:     req.GetDeliveryWindow(),
+		InstallmentPlan:    plan,
+		WalletPaid:         walletPaid,
 	}
+	logBreakdown(ctx, orderResult.OrderId, txID, breakdown)
 
-	if err := cs.sendOrderConfirmation(ctx, req.Email, orderResult); err != nil {
-		log.Printf("failed to send order confirmation to %q: %+v", req.Email, err)
-	} else {
-		log.Printf("order confirmation email sent to %q", req.Email)
-	}
-	resp := &pb.PlaceOrderResponse{Order: orderResult}
+	// The order is placed: what remains cannot fail it, and the steps do
+	// not depend on each other.
+	confirmed := proto.Clone(orderResult).(*pb.OrderResult)
+	orderResult.PostOrderSteps = runPostOrderSteps(ctx, orderResult.OrderId, postOrderWait.Get(),
+		postOrderStep{"empty_cart", func(ctx context.Context) error {
+			return cs.emptyUserCart(ctx, userID)
+		}},
+		postOrderStep{"confirmation_email", func(ctx context.Context) error {
+			return cs.sendOrderConfirmation(ctx, req.Email, req.Locale, confirmed)
+		}},
+	)
+	resp := &pb.PlaceOrderResponse{Order: orderResult, Total: total}
 	return resp, ctx, nil
 }
 
+// pricedOrder is an order priced for a shopper, yet to be paid for.
+type pricedOrder struct {
+	address   *pb.Address
+	note      string
+	prep      orderPrep
+	breakdown *pb.OrderBreakdown
+	// walletPaid is the part of the total paid from the wallet, if any,
+	// and cardAmount the rest.
+	walletPaid, cardAmount *pb.Money
+}
+
+// priceOrder prices the cart of userID as ordered by req, checking that the
+// order can be placed as requested. Its errors are those of PlaceOrder.
+func (cs *CheckoutService) priceOrder(ctx context.Context, userID string, req *pb.PlaceOrderRequest) (*pricedOrder, error) {
+	// Every amount of the order is converted at the same rates.
+	rates := cs.newOrderRates()
+
+	address, err := cs.validateAddress(ctx, userID, req.Address)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid shipping address: %v", err)
+	}
+
+	note := strings.TrimSpace(req.GetNote())
+	if utf8.RuneCountInString(note) > maxOrderNote {
+		return nil, status.Errorf(codes.InvalidArgument, "note is longer than %d characters", maxOrderNote)
+	}
+
+	prep, err := cs.prepareOrderItemsAndShippingQuoteFromCart(ctx, rates, userID, req.UserCurrency, address)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if req.GetGiftWrap() {
+		feeUSD := giftWrapFeeUSD.Get()
+		fee, rate, err := rates.convert(ctx, feeUSD, req.UserCurrency)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert gift-wrap fee: %+v", err)
+		}
+		prep.giftWrap = fee
+		prep.conversions = appendConversion(prep.conversions, "gift_wrap", feeUSD, fee, rate)
+	}
+
+	// The shopper may have changed the cart, in another tab or on another
+	// device, since reviewing it; charge only for the cart they saw.
+	if want := req.GetExpectedCartHash(); want != "" && CartHash(prep.cartItems) != want {
+		rpclog.Warn(ctx, "cart changed since it was reviewed", zap.String("user_id", userID))
+		return nil, &rpc.RPCError{Type: rpc.RPCFailError, Reason: status.Error(codes.Aborted, errCartChanged).Error()}
+	}
+
+	// Windows are chosen before the address is known; check that the one
+	// chosen can still be met for this address.
+	if window := req.GetDeliveryWindow(); window != nil {
+		shippingClient := pb.NewShippingServiceClient(cs.shippingSvcConn.Pick())
+		options, err := shippingClient.GetDeliveryOptions(ctx, &pb.GetDeliveryOptionsRequest{Address: address, Items: prep.cartItems})
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to get delivery options: %+v", err)
+		}
+		if err := checkDeliveryWindow(window, options.GetWindows()); err != nil {
+			rpclog.Warn(ctx, "delivery window unavailable", zap.String("user_id", userID), zap.Error(err))
+			return nil, &rpc.RPCError{Type: rpc.RPCFailError, Reason: status.Error(codes.FailedPrecondition, err.Error()).Error()}
+		}
+	}
+
+	breakdown, err := orderBreakdown(req.UserCurrency, prep)
+	if err != nil {
+		rpclog.Warn(ctx, "failed to total order", zap.String("user_id", userID), zap.Error(err))
+		return nil, status.Errorf(codes.Internal, "failed to total order: %+v", err)
+	}
+
+	// The shopper may pay part of the total from their wallet and the rest
+	// by card, which is then what any installments spread.
+	walletPaid, cardAmount, err := splitPayment(breakdown.GetTotal(), req.GetWalletAmount())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if n := req.GetInstallments(); n > 1 && !IsZero(cardAmount) {
+		floor, _, err := rates.convert(ctx, installmentMinUSD.Get(), req.UserCurrency)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to convert installment minimum: %+v", err)
+		}
+		if !atLeast(cardAmount, floor) {
+			return nil, &rpc.RPCError{Type: rpc.RPCFailError, Reason: status.Error(codes.FailedPrecondition, InstallmentsNotAvailableErr{Installments: n}.Error()).Error()}
+		}
+	}
+
+	return &pricedOrder{
+		address:    address,
+		note:       note,
+		prep:       prep,
+		breakdown:  breakdown,
+		walletPaid: walletPaid,
+		cardAmount: cardAmount,
+	}, nil
+}
+
+// PreviewOrder prices an order as PlaceOrder would, for the shopper to
+// review before placing it. Nothing is charged, shipped or recorded.
+func (cs *CheckoutService) PreviewOrder(ctx context.Context, req *pb.PlaceOrderRequest) (_ *pb.OrderPreview, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+	ctx = tenant.Forward(ctx)
+	userID := usercontext.UserID(ctx, req.UserId)
+	ctx = usercontext.NewContext(ctx, userID)
+
+	priced, err := cs.priceOrder(ctx, userID, req)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.OrderPreview{
+		Items:           priced.prep.orderItems,
+		Breakdown:       priced.breakdown,
+		ShippingAddress: priced.address,
+		CartHash:        CartHash(priced.prep.cartItems),
+		WalletAmount:    priced.walletPaid,
+		CardAmount:      priced.cardAmount,
+	}, ctx, nil
+}
+
 type orderPrep struct {
 	orderItems            []*pb.OrderItem
 	cartItems             []*pb.CartItem
 	shippingCostLocalized *pb.Money
+	// giftWrap is the gift-wrap fee, if the order is gift-wrapped.
+	giftWrap *pb.Money
+	// adjustments are what the pricing rules take off the items.
+	adjustments []*pb.PriceAdjustment
+	// conversions are the currency conversions behind the item, shipping
+	// and gift-wrap prices, all made at rates.
+	conversions []*pb.AppliedConversion
+	rates       *orderRates
+}
+
+// maxOrderNote is the length limit of an order note, in characters.
+const maxOrderNote = 500
+
+// giftWrapFeeUSD is the fee for gift-wrapping an order, GIFT_WRAP_FEE_USD
+// dollars.
+var giftWrapFeeUSD = config.NewValue(func() *pb.Money {
+	cents := int64(math.Round(envFloat("GIFT_WRAP_FEE_USD", 4.99) * 100))
+	return &pb.Money{CurrencyCode: "USD", Units: cents / 100, Nanos: int32(cents%100) * 10000000}
+})
+
+// itemsTotal returns the cost of items, in currency.
+func itemsTotal(currency string, items []*pb.OrderItem) (*pb.Money, error) {
+	total := &pb.Money{CurrencyCode: currency}
+	for _, it := range items {
+		line, err := Multiply(it.GetCost(), uint32(it.GetItem().GetQuantity()))
+		if err != nil {
+			return nil, err
+		}
+		if total, err = Sum(total, line); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
+
+// orderBreakdown itemizes the total of an order in currency.
+func orderBreakdown(currency string, prep orderPrep) (*pb.OrderBreakdown, error) {
+	items, err := itemsTotal(currency, prep.orderItems)
+	if err != nil {
+		return nil, err
+	}
+	tax := &pb.Money{CurrencyCode: currency}
+	discount := pricing.Total(currency, prep.adjustments)
+	negDiscount := Negate(discount)
+	total := items
+	for _, m := range []*pb.Money{prep.shippingCostLocalized, prep.giftWrap, tax, &negDiscount} {
+		if m == nil {
+			continue
+		}
+		if total, err = Sum(total, m); err != nil {
+			return nil, err
+		}
+	}
+	return &pb.OrderBreakdown{
+		Items:       items,
+		Shipping:    prep.shippingCostLocalized,
+		Tax:         tax,
+		Discount:    discount,
+		Total:       total,
+		Conversions: prep.conversions,
+		GiftWrap:    prep.giftWrap,
+		PinnedRates: prep.rates.pinnedRates(),
+		Adjustments: &pb.PriceAdjustments{Adjustments: prep.adjustments},
+	}, nil
 }
 
-func (cs *CheckoutService) prepareOrderItemsAndShippingQuoteFromCart(ctx context.Context, userID, userCurrency string, address *pb.Address) (orderPrep, error) {
-	log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Start processing for userID=%s, userCurrency=%s", userID, userCurrency)
+// logBreakdown writes the breakdown of a placed order to the log as a single
+// JSON line, so that charges can be reconciled against orders.
+func logBreakdown(ctx context.Context, orderID, txID string, b *pb.OrderBreakdown) {
+	line, err := protojson.Marshal(b)
+	if err != nil {
+		rpclog.Warn(ctx, "failed to encode order breakdown", zap.String("order_id", orderID), zap.Error(err))
+		return
+	}
+	rpclog.Info(ctx, "order breakdown", zap.String("order_id", orderID), zap.String("transaction_id", txID), zap.String("breakdown", string(line)))
+}
 
-	var out orderPrep
+func (cs *CheckoutService) prepareOrderItemsAndShippingQuoteFromCart(ctx context.Context, rates *orderRates, userID, userCurrency string, address *pb.Address) (orderPrep, error) {
+	out := orderPrep{rates: rates}
 
 	// Get user cart
 	cartItems, err := cs.getUserCart(ctx, userID)
 	if err != nil {
-		log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Error fetching cart for userID=%s: %v", userID, err)
+		rpclog.Warn(ctx, "failed to fetch cart", zap.String("user_id", userID), zap.Error(err))
 		return out, fmt.Errorf("cart failure: %+v", err)
 	}
-	log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Retrieved %d items from cart for userID=%s", len(cartItems), userID)
+	rpclog.Debug(ctx, "fetched cart", zap.String("user_id", userID), zap.Int("items", len(cartItems)))
 
 	// Prepare order items
-	orderItems, err := cs.prepOrderItems(ctx, cartItems, userCurrency)
+	orderItems, lines, conversions, err := cs.prepOrderItems(ctx, rates, cartItems, userCurrency)
 	if err != nil {
-		log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Error preparing order items for userID=%s: %v", userID, err)
+		rpclog.Warn(ctx, "failed to prepare order items", zap.String("user_id", userID), zap.Error(err))
 		return out, fmt.Errorf("failed to prepare order: %+v", err)
 	}
-	log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Prepared %d order items for userID=%s", len(orderItems), userID)
 
-	// Quote shipping
-	shippingUSD, err := cs.quoteShipping(ctx, address, cartItems)
+	// Apply the promotions in effect
+	adjustments, err := cs.priceAdjustments(ctx, lines)
 	if err != nil {
-		log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Error quoting shipping for userID=%s: %v", userID, err)
+		rpclog.Warn(ctx, "failed to apply pricing rules", zap.String("user_id", userID), zap.Error(err))
+		return out, fmt.Errorf("failed to apply pricing rules: %+v", err)
+	}
+
+	// Quote shipping, which may be free above a subtotal, after discounts
+	subtotal, err := itemsTotal(userCurrency, orderItems)
+	if err == nil {
+		negDiscount := Negate(pricing.Total(userCurrency, adjustments))
+		subtotal, err = Sum(subtotal, &negDiscount)
+	}
+	if err != nil {
+		rpclog.Warn(ctx, "failed to total order items", zap.String("user_id", userID), zap.Error(err))
+		return out, fmt.Errorf("failed to total order: %+v", err)
+	}
+	shippingUSD, err := cs.quoteShipping(ctx, address, cartItems, subtotal)
+	if err != nil {
+		rpclog.Warn(ctx, "failed to quote shipping", zap.String("user_id", userID), zap.Error(err))
 		return out, fmt.Errorf("shipping quote failure: %+v", err)
 	}
-	log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Received shipping quote in USD for userID=%s", userID)
 
 	// Convert shipping cost
-	shippingPrice, err := cs.convertCurrency(shippingUSD, userCurrency)
+	shippingPrice, rate, err := rates.convert(ctx, shippingUSD, userCurrency)
 	if err != nil {
-		log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Error converting shipping cost to currency=%s for userID=%s: %v", userCurrency, userID, err)
+		rpclog.Warn(ctx, "failed to convert shipping cost", zap.String("user_id", userID), zap.String("currency", userCurrency), zap.Error(err))
 		return out, fmt.Errorf("failed to convert shipping cost to currency: %+v", err)
 	}
-	log.Printf("prepareOrderItemsAndShippingQuoteFromCart: Converted shipping cost to currency=%s for userID=%s", userCurrency, userID)
 
 	out.shippingCostLocalized = shippingPrice
+	out.conversions = appendConversion(conversions, "shipping", shippingUSD, shippingPrice, rate)
 	out.cartItems = cartItems
 	out.orderItems = orderItems
+	out.adjustments = adjustments
 	return out, nil
 }
 
-func (cs *CheckoutService) quoteShipping(ctx context.Context, address *pb.Address, items []*pb.CartItem) (*pb.Money, error) {
-	shippingClient := pb.NewShippingServiceClient(cs.shippingSvcConn)
+// priceAdjustments applies the pricing rules of the catalog to lines.
+func (cs *CheckoutService) priceAdjustments(ctx context.Context, lines []pricing.Line) ([]*pb.PriceAdjustment, error) {
+	cl := pb.NewProductCatalogServiceClient(cs.productCatalogSvcConn.Pick())
+	rules, err := cl.ListPricingRules(ctx, &pb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing rules: %+v", err)
+	}
+	return pricing.Apply(rules.GetRules(), lines, time.Now()), nil
+}
+
+func (cs *CheckoutService) quoteShipping(ctx context.Context, address *pb.Address, items []*pb.CartItem, subtotal *pb.Money) (*pb.Money, error) {
+	shippingClient := pb.NewShippingServiceClient(cs.shippingSvcConn.Pick())
 	shippingQuote, err := shippingClient.GetQuote(ctx, &pb.GetQuoteRequest{
-		Address: address,
-		Items:   items})
+		Address:  address,
+		Items:    items,
+		Subtotal: subtotal})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shipping quote: %+v", err)
 	}
@@ -210,7 +619,7 @@ func (cs *CheckoutService) quoteShipping(ctx context.Context, address *pb.Addres
 }
 
 func (cs *CheckoutService) getUserCart(ctx context.Context, userID string) ([]*pb.CartItem, error) {
-	cartClient := pb.NewCartServiceClient(cs.cartSvcConn)
+	cartClient := pb.NewCartServiceClient(cs.cartSvcConn.Pick())
 	cart, err := cartClient.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user cart during checkout: %+v", err)
@@ -218,73 +627,301 @@ func (cs *CheckoutService) getUserCart(ctx context.Context, userID string) ([]*p
 	return cart.GetItems(), nil
 }
 
+// errCartChanged is the error of an order whose cart differs from the one
+// the shopper reviewed. Errors reach clients as text, so IsCartChanged
+// recognizes it by this text.
+const errCartChanged = "cart changed since it was reviewed"
+
+// IsCartChanged reports whether err is the error of PlaceOrder for a cart
+// that changed since the shopper reviewed it.
+func IsCartChanged(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errCartChanged)
+}
+
+// CartHash returns a digest of the items of a cart, for PlaceOrderRequest's
+// expected_cart_hash. It does not depend on the order of the items.
+func CartHash(items []*pb.CartItem) string {
+	lines := make([]string, len(items))
+	for i, it := range items {
+		lines[i] = fmt.Sprintf("%s\x00%s\x00%d", it.GetProductId(), it.GetVariantId(), it.GetQuantity())
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:16])
+}
+
 func (cs *CheckoutService) emptyUserCart(ctx context.Context, userID string) error {
-	cartClient := pb.NewCartServiceClient(cs.cartSvcConn)
+	cartClient := pb.NewCartServiceClient(cs.cartSvcConn.Pick())
 	if _, err := cartClient.EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID}); err != nil {
 		return fmt.Errorf("failed to empty user cart during checkout: %+v", err)
 	}
 	return nil
 }
 
-func (cs *CheckoutService) prepOrderItems(ctx context.Context, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, error) {
+func (cs *CheckoutService) prepOrderItems(ctx context.Context, rates *orderRates, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, []pricing.Line, []*pb.AppliedConversion, error) {
 	out := make([]*pb.OrderItem, len(items))
-	cl := pb.NewProductCatalogServiceClient(cs.productCatalogSvcConn)
+	lines := make([]pricing.Line, len(items))
+	var conversions []*pb.AppliedConversion
+	cl := pb.NewProductCatalogServiceClient(cs.productCatalogSvcConn.Pick())
 
+	ids := make([]string, len(items))
 	for i, item := range items {
-		product, err := cl.GetProduct(ctx, &pb.GetProductRequest{Id: item.GetProductId()})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get product #%q", item.GetProductId())
+		ids[i] = item.GetProductId()
+	}
+	resp, err := cl.GetProducts(ctx, &pb.GetProductsRequest{Ids: ids})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get products %q: %+v", ids, err)
+	}
+	products := resp.GetProducts()
+	if len(products) != len(items) {
+		return nil, nil, nil, fmt.Errorf("expected %d products, got %d", len(items), len(products))
+	}
+
+	now := time.Now()
+	for i, item := range items {
+		priceUSD, _ := productPrice(products[i], now)
+		if item.GetVariantId() != "" {
+			if priceUSD, err = cs.variantPrice(ctx, cl, item, priceUSD); err != nil {
+				return nil, nil, nil, err
+			}
 		}
-		price, err := cs.convertCurrency(product.GetPriceUsd(), userCurrency)
+		price, rate, err := rates.convert(ctx, priceUSD, userCurrency)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert price of %q to %s", item.GetProductId(), userCurrency)
+			return nil, nil, nil, fmt.Errorf("failed to convert price of %q to %s", item.GetProductId(), userCurrency)
 		}
+		conversions = appendConversion(conversions, "item:"+item.GetProductId(), priceUSD, price, rate)
 		out[i] = &pb.OrderItem{
 			Item: item,
 			Cost: price}
+		lines[i] = pricing.Line{
+			ProductID:  item.GetProductId(),
+			Categories: products[i].GetCategories(),
+			Quantity:   item.GetQuantity(),
+			Unit:       price}
 	}
-	return out, nil
+	return out, lines, conversions, nil
 }
 
-func (cs *CheckoutService) convertCurrency(from *pb.Money, toCurrency string) (*pb.Money, error) {
-	currencyClient := pb.NewCurrencyServiceClient(cs.currencySvcConn)
-	result, err := currencyClient.Convert(context.TODO(), &pb.CurrencyConversionRequest{
-		From:   from,
-		ToCode: toCurrency})
+// variantPrice checks that the variant chosen for item is in stock and returns
+// its price, which is the product price plus the variant's price delta.
+func (cs *CheckoutService) variantPrice(ctx context.Context, cl pb.ProductCatalogServiceClient, item *pb.CartItem, productPrice *pb.Money) (*pb.Money, error) {
+	variant, err := cl.GetVariant(ctx, &pb.GetVariantRequest{ProductId: item.GetProductId(), VariantId: item.GetVariantId()})
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert currency: %+v", err)
+		return nil, fmt.Errorf("failed to get variant %q of product %q: %+v", item.GetVariantId(), item.GetProductId(), err)
+	}
+	if variant.GetStock() < item.GetQuantity() {
+		return nil, status.Errorf(codes.FailedPrecondition, "only %d of variant %q in stock", variant.GetStock(), item.GetVariantId())
+	}
+	if variant.GetPriceDeltaUsd() == nil {
+		return productPrice, nil
 	}
-	return result, err
+	return Sum(productPrice, variant.GetPriceDeltaUsd())
 }
 
-func (cs *CheckoutService) chargeCard(ctx context.Context, amount *pb.Money, paymentInfo *pb.CreditCardInfo) (string, error) {
-	paymentClient := pb.NewPaymentServiceClient(cs.paymentSvcConn)
+// appendConversion records the conversion of component from one currency to
+// another. Amounts that were already in the target currency are skipped.
+func appendConversion(conversions []*pb.AppliedConversion, component string, from, to *pb.Money, rate string) []*pb.AppliedConversion {
+	if from.GetCurrencyCode() == to.GetCurrencyCode() {
+		return conversions
+	}
+	return append(conversions, &pb.AppliedConversion{Component: component, From: from, To: to, Rate: rate})
+}
+
+// chargeCard charges amount to the card, in the given number of monthly
+// installments if more than one, and returns the transaction ID along with
+// the installment plan, if any.
+func (cs *CheckoutService) chargeCard(ctx context.Context, userID, orderID string, amount *pb.Money, paymentInfo *pb.CreditCardInfo, installments int32) (string, *pb.InstallmentPlan, error) {
+	paymentClient := pb.NewPaymentServiceClient(cs.paymentSvcConn.Pick())
 	paymentResp, err := paymentClient.Charge(ctx, &pb.ChargeRequest{
-		Amount:     amount,
-		CreditCard: paymentInfo})
+		Amount:       amount,
+		CreditCard:   paymentInfo,
+		UserId:       userID,
+		Installments: installments,
+		OrderId:      orderID})
+	if err != nil {
+		return "", nil, fmt.Errorf("could not charge the card: %+v", err)
+	}
+	return paymentResp.GetTransactionId(), paymentResp.GetInstallmentPlan(), nil
+}
+
+// cardChargeError returns the error of PlaceOrder for err, the error of
+// chargeCard. A card or amount that payment refused keeps its code and
+// details, so that the shopper is told; any other failure is Internal.
+func cardChargeError(err error) error {
+	st, ok := rpcstatus.FromError(err)
+	if !ok || (st.Code() != codes.InvalidArgument && st.Code() != codes.FailedPrecondition && st.Code() != codes.OutOfRange) {
+		return status.Errorf(codes.Internal, "failed to charge card: %+v", err)
+	}
+	p := st.Proto()
+	p.Message = "failed to charge card: " + p.GetMessage()
+	return rpcstatus.Error(status.FromProto(p))
+}
+
+// refundCharge gives the card charge txID back. Failures are logged, as the
+// order is failing already.
+func (cs *CheckoutService) refundCharge(ctx context.Context, txID, reason string) {
+	paymentClient := pb.NewPaymentServiceClient(cs.paymentSvcConn.Pick())
+	if _, err := paymentClient.Refund(ctx, &pb.RefundRequest{TransactionId: txID, Reason: reason}); err != nil {
+		rpclog.Warn(ctx, "failed to refund transaction", zap.String("transaction_id", txID), zap.Error(err))
+		return
+	}
+	rpclog.Info(ctx, "transaction refunded", zap.String("transaction_id", txID), zap.String("reason", reason))
+}
+
+// splitPayment splits total into the part paid from the wallet, at most
+// walletAmount, and the part charged to the card. The wallet part is nil if
+// walletAmount is unset or zero.
+func splitPayment(total, walletAmount *pb.Money) (wallet, card *pb.Money, err error) {
+	if walletAmount == nil || IsZero(walletAmount) {
+		return nil, total, nil
+	}
+	if !IsValid(walletAmount) || IsNegative(walletAmount) || !AreSameCurrency(walletAmount, total) {
+		return nil, nil, fmt.Errorf("wallet amount must be a positive amount of %s", total.GetCurrencyCode())
+	}
+	wallet = walletAmount
+	if atLeast(wallet, total) {
+		wallet = total
+	}
+	totalNanos, err := moneyToNanos(total)
+	if err != nil {
+		return nil, nil, moneyOpErr("split", total, wallet, err)
+	}
+	walletNanos, err := moneyToNanos(wallet)
 	if err != nil {
-		return "", fmt.Errorf("could not charge the card: %+v", err)
+		return nil, nil, moneyOpErr("split", total, wallet, err)
+	}
+	card = nanosToMoney(total.GetCurrencyCode(), totalNanos-walletNanos)
+	return wallet, card, nil
+}
+
+// debitWallet takes amount from the user's wallet, as the debit debitID.
+func (cs *CheckoutService) debitWallet(ctx context.Context, debitID string, amount *pb.Money) error {
+	walletClient := pb.NewWalletServiceClient(cs.walletSvcConn.Pick())
+	_, err := walletClient.Debit(ctx, &pb.WalletDebitRequest{Amount: amount, DebitId: debitID})
+	return err
+}
+
+// refundWallet gives the debit debitID back. Failures are logged, as the
+// order is failing already.
+func (cs *CheckoutService) refundWallet(ctx context.Context, debitID string) {
+	walletClient := pb.NewWalletServiceClient(cs.walletSvcConn.Pick())
+	if _, err := walletClient.Refund(ctx, &pb.WalletRefundRequest{DebitId: debitID}); err != nil {
+		rpclog.Warn(ctx, "failed to refund wallet debit", zap.String("debit_id", debitID), zap.Error(err))
+		return
 	}
-	return paymentResp.GetTransactionId(), nil
+	rpclog.Info(ctx, "wallet debit refunded", zap.String("debit_id", debitID))
 }
 
-func (cs *CheckoutService) sendOrderConfirmation(ctx context.Context, email string, order *pb.OrderResult) error {
-	emailClient := pb.NewEmailServiceClient(cs.emailSvcConn)
+// reserveStock holds the stock of items for the order orderID.
+func (cs *CheckoutService) reserveStock(ctx context.Context, orderID string, items []*pb.CartItem) error {
+	catalogClient := pb.NewProductCatalogServiceClient(cs.productCatalogSvcConn.Pick())
+	_, err := catalogClient.ReserveStock(ctx, &pb.ReserveStockRequest{OrderId: orderID, Items: items})
+	return err
+}
+
+// releaseStock puts the stock held for the order orderID back on sale.
+// Failures are logged, as the order is failing already and the hold
+// expires anyway.
+func (cs *CheckoutService) releaseStock(ctx context.Context, orderID string) {
+	catalogClient := pb.NewProductCatalogServiceClient(cs.productCatalogSvcConn.Pick())
+	if _, err := catalogClient.ReleaseReservation(ctx, &pb.ReservationRequest{OrderId: orderID}); err != nil {
+		rpclog.Warn(ctx, "failed to release stock", zap.String("order_id", orderID), zap.Error(err))
+	}
+}
+
+// commitStock marks the stock held for the placed order orderID as sold.
+func (cs *CheckoutService) commitStock(ctx context.Context, orderID string) error {
+	catalogClient := pb.NewProductCatalogServiceClient(cs.productCatalogSvcConn.Pick())
+	_, err := catalogClient.CommitReservation(ctx, &pb.ReservationRequest{OrderId: orderID})
+	return err
+}
+
+func (cs *CheckoutService) sendOrderConfirmation(ctx context.Context, email, locale string, order *pb.OrderResult) error {
+	emailClient := pb.NewEmailServiceClient(cs.emailSvcConn.Pick())
 	_, err := emailClient.SendOrderConfirmation(ctx, &pb.SendOrderConfirmationRequest{
-		Email: email,
-		Order: order})
+		Email:     email,
+		Order:     order,
+		Locale:    locale,
+		DedupeKey: dedupeKey(order.GetOrderId(), emailConfirmation)})
 	return err
 }
 
-func (cs *CheckoutService) shipOrder(ctx context.Context, address *pb.Address, items []*pb.CartItem) (string, error) {
-	shippingClient := pb.NewShippingServiceClient(cs.shippingSvcConn)
-	resp, err := shippingClient.ShipOrder(ctx, &pb.ShipOrderRequest{
+// validateAddress checks the address with the AddressService and returns its
+// normalized form, or an error listing the problems found.
+func (cs *CheckoutService) validateAddress(ctx context.Context, userID string, address *pb.Address) (*pb.Address, error) {
+	addressClient := pb.NewAddressServiceClient(cs.addressSvcConn.Pick())
+	resp, err := addressClient.ValidateAddress(ctx, &pb.ValidateAddressRequest{
 		Address: address,
-		Items:   items})
+		UserId:  userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate address: %+v", err)
+	}
+	if problems := resp.GetProblems(); len(problems) > 0 {
+		msgs := make([]string, len(problems))
+		for i, p := range problems {
+			msgs[i] = fmt.Sprintf("%s: %s", p.GetField(), p.GetMessage())
+		}
+		return nil, fmt.Errorf("%s", strings.Join(msgs, "; "))
+	}
+	return resp.GetNormalized(), nil
+}
+
+// shipOrder ships the order, as several shipments when the shipping service
+// plans more than one. It returns the tracking ID of the first shipment and,
+// for a split order, every shipment with its tracking ID.
+func (cs *CheckoutService) shipOrder(ctx context.Context, req *pb.ShipOrderRequest) (string, *pb.ShipmentGroups, error) {
+	shippingClient := pb.NewShippingServiceClient(cs.shippingSvcConn.Pick())
+	plan, err := shippingClient.PlanShipments(ctx, &pb.PlanShipmentsRequest{Address: req.Address, Items: req.Items})
 	if err != nil {
-		return "", fmt.Errorf("shipment failed: %+v", err)
+		rpclog.Warn(ctx, "failed to plan shipments, shipping as one", zap.String("order_id", req.OrderId), zap.Error(err))
+	}
+	groups := plan.GetGroups()
+	if len(groups) < 2 {
+		resp, err := shippingClient.ShipOrder(ctx, req)
+		if err != nil {
+			return "", nil, fmt.Errorf("shipment failed: %+v", err)
+		}
+		return resp.GetTrackingId(), nil, nil
+	}
+
+	for i, g := range groups {
+		resp, err := shippingClient.ShipOrder(ctx, &pb.ShipOrderRequest{
+			Address:        req.Address,
+			Items:          g.Items,
+			OrderId:        req.OrderId,
+			Email:          req.Email,
+			Locale:         req.Locale,
+			UserId:         req.UserId,
+			GiftWrap:       req.GiftWrap,
+			Note:           req.Note,
+			DeliveryWindow: req.DeliveryWindow,
+			WarehouseId:    g.WarehouseId,
+			Part:           int32(i + 1)})
+		if err != nil {
+			// The order is not shipped in part: the shipments already
+			// created are cancelled.
+			created := make([]string, i)
+			for j := range created {
+				created[j] = groups[j].TrackingId
+			}
+			cs.cancelShipments(ctx, req.OrderId, fmt.Sprintf("shipment %d failed", i+1), created...)
+			return "", nil, fmt.Errorf("shipment %d of %d failed: %+v", i+1, len(groups), err)
+		}
+		g.TrackingId = resp.GetTrackingId()
+	}
+	rpclog.Info(ctx, "order split into shipments", zap.String("order_id", req.OrderId), zap.Int("shipments", len(groups)))
+	return groups[0].TrackingId, &pb.ShipmentGroups{Groups: groups}, nil
+}
+
+// cancelShipments cancels the shipments of the order orderID, given by their
+// tracking IDs. Failures are logged, as the order is failing already.
+func (cs *CheckoutService) cancelShipments(ctx context.Context, orderID, reason string, trackingIDs ...string) {
+	shippingClient := pb.NewShippingServiceClient(cs.shippingSvcConn.Pick())
+	for _, id := range trackingIDs {
+		if _, err := shippingClient.CancelShipment(ctx, &pb.CancelShipmentRequest{TrackingId: id, Reason: reason}); err != nil {
+			rpclog.Warn(ctx, "failed to cancel shipment", zap.String("order_id", orderID), zap.String("tracking_id", id), zap.Error(err))
+		}
 	}
-	return resp.GetTrackingId(), nil
 }
 
 // IsValid checks if specified value has a valid units/nanos signs and ranges.
@@ -348,9 +985,9 @@ func Must(v *pb.Money, err error) *pb.Money {
 // both).
 func Sum(l, r *pb.Money) (*pb.Money, error) {
 	if !IsValid(l) || !IsValid(r) {
-		return &pb.Money{}, ErrInvalidValue
+		return &pb.Money{}, moneyOpErr("sum", l, r, ErrInvalidValue)
 	} else if l.GetCurrencyCode() != r.GetCurrencyCode() {
-		return &pb.Money{}, ErrMismatchingCurrency
+		return &pb.Money{}, moneyOpErr("sum", l, r, ErrMismatchingCurrency)
 	}
 	units := l.GetUnits() + r.GetUnits()
 	nanos := l.GetNanos() + r.GetNanos()
@@ -376,17 +1013,77 @@ func Sum(l, r *pb.Money) (*pb.Money, error) {
 		CurrencyCode: l.GetCurrencyCode()}, nil
 }
 
-// MultiplySlow is a slow multiplication operation done through adding the value
-// to itself n-1 times.
-func MultiplySlow(m *pb.Money, n uint32) *pb.Money {
+// Multiply is a slow multiplication operation done through adding the value
+// to itself n-1 times. Returns an error if the value is invalid.
+func Multiply(m *pb.Money, n uint32) (*pb.Money, error) {
+	if !IsValid(m) {
+		return &pb.Money{}, moneyOpErr("multiply", m, m, ErrInvalidValue)
+	}
 	out := &pb.Money{
 		Units:        m.GetUnits(),
 		Nanos:        m.GetNanos(),
 		CurrencyCode: m.GetCurrencyCode(),
 	}
-	for n > 1 {
-		out = Must(Sum(out, m))
-		n--
+	for ; n > 1; n-- {
+		var err error
+		if out, err = Sum(out, m); err != nil {
+			return &pb.Money{}, err
+		}
+	}
+	return out, nil
+}
+
+// MultiplySlow is Multiply for values known to be valid. It panics if m is
+// invalid.
+func MultiplySlow(m *pb.Money, n uint32) *pb.Money {
+	return Must(Multiply(m, n))
+}
+
+// Divide splits a positive value into n parts that add up to it exactly. The
+// parts are whole minor units of the currency (cents, units for currencies
+// without decimals, or as MinorUnits gives them) and differ by at most one of them, the larger
+// ones first. A remainder smaller than a minor unit goes to the first part.
+func Divide(m *pb.Money, n int) ([]*pb.Money, error) {
+	if !IsPositive(m) || n < 1 {
+		return nil, ErrInvalidValue
+	}
+	minor := minorUnitNanos(m.GetCurrencyCode())
+	total, err := moneyToNanos(m)
+	if err != nil {
+		return nil, moneyOpErr("divide", m, m, err)
+	}
+	minors, rest := total/minor, total%minor
+	base, extra := minors/int64(n), minors%int64(n)
+
+	parts := make([]*pb.Money, n)
+	for i := range parts {
+		nanos := base * minor
+		if int64(i) < extra {
+			nanos += minor
+		}
+		if i == 0 {
+			nanos += rest
+		}
+		parts[i] = nanosToMoney(m.GetCurrencyCode(), nanos)
+	}
+	return parts, nil
+}
+
+// moneyToNanos returns m as a count of nano units, or ErrOutOfRange if an
+// int64 cannot hold that many.
+func moneyToNanos(m *pb.Money) (int64, error) {
+	units, nanos := m.GetUnits(), int64(m.GetNanos())
+	if units > math.MaxInt64/nanosMod || units < math.MinInt64/nanosMod {
+		return 0, ErrOutOfRange
+	}
+	n := units * nanosMod
+	if (nanos > 0 && n > math.MaxInt64-nanos) || (nanos < 0 && n < math.MinInt64-nanos) {
+		return 0, ErrOutOfRange
 	}
-	return out
+	return n + nanos, nil
+}
+
+// nanosToMoney returns n nano units of currency as Money.
+func nanosToMoney(currency string, n int64) *pb.Money {
+	return &pb.Money{CurrencyCode: currency, Units: n / nanosMod, Nanos: int32(n % nanosMod)}
 }
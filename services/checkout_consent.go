@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// termsVersion is the version of the terms of sale shoppers accept at
+// checkout, TERMS_VERSION. Orders accepting an older version are refused
+// until the shopper accepts the current one.
+var termsVersion = config.NewValue(func() string {
+	if v := config.Get("TERMS_VERSION"); v != "" {
+		return v
+	}
+	return "2024-01"
+})
+
+// TermsVersion returns the version of the terms of sale that orders must
+// accept, as the checkout form shows it.
+func TermsVersion() string {
+	return termsVersion.Get()
+}
+
+// minimumAge is the age shoppers confirm they have reached, MINIMUM_AGE.
+var minimumAge = config.NewValue(func() int {
+	return envInt("MINIMUM_AGE", 18)
+})
+
+// consentMaxAge bounds how long before an order its consent may have been
+// given, and consentClockSkew how far after, allowing for the clocks of the
+// frontend and of checkout to differ.
+const (
+	consentMaxAge    = 24 * time.Hour
+	consentClockSkew = 5 * time.Minute
+)
+
+// ConsentRequiredErr is the error of an order placed without a consent it
+// requires.
+type ConsentRequiredErr struct {
+	What string
+}
+
+func (e ConsentRequiredErr) Error() string {
+	return fmt.Sprintf("%s: %s", errConsentRequired, e.What)
+}
+
+// errConsentRequired starts the text of ConsentRequiredErr, by which
+// IsConsentRequired recognizes it once it has reached a client as text.
+const errConsentRequired = "consent required"
+
+// IsConsentRequired reports whether err is the error of PlaceOrder for an
+// order missing the shopper's consent.
+func IsConsentRequired(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errConsentRequired)
+}
+
+// checkConsent checks that c accepts the current terms of sale and confirms
+// the shopper's age, at times that are plausible at now.
+func checkConsent(c *pb.Consent, now time.Time) error {
+	if c.GetTermsAcceptedAt() == 0 {
+		return &rpc.RPCError{Type: rpc.RPCFailError, Reason: status.Error(codes.FailedPrecondition, ConsentRequiredErr{"terms of sale"}.Error()).Error()}
+	}
+	if v := termsVersion.Get(); c.GetTermsVersion() != v {
+		return &rpc.RPCError{Type: rpc.RPCFailError, Reason: status.Error(codes.FailedPrecondition, ConsentRequiredErr{"terms of sale version " + v}.Error()).Error()}
+	}
+	if c.GetAgeConfirmedAt() == 0 {
+		return &rpc.RPCError{Type: rpc.RPCFailError, Reason: status.Error(codes.FailedPrecondition, ConsentRequiredErr{"age confirmation"}.Error()).Error()}
+	}
+	for _, at := range []int64{c.GetTermsAcceptedAt(), c.GetAgeConfirmedAt()} {
+		t := time.Unix(at, 0)
+		if t.Before(now.Add(-consentMaxAge)) || t.After(now.Add(consentClockSkew)) {
+			return status.Errorf(codes.InvalidArgument, "consent given at %s is out of range", t.UTC().Format(time.RFC3339))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,301 @@
+package services_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/testsupport"
+)
+
+// cancellations returns the details of the order cancellations in the audit
+// log of the checkout service.
+func cancellations(t *testing.T, shop *testsupport.Shop) []map[string]string {
+	t.Helper()
+	entries, err := shop.Redis["CHECKOUT"].List("audit-log")
+	if err != nil && err != miniredis.ErrKeyNotFound {
+		t.Fatal(err)
+	}
+	var cancelled []map[string]string
+	for _, data := range entries {
+		var e pb.AuditEntry
+		if err := json.Unmarshal([]byte(data), &e); err != nil {
+			t.Fatal(err)
+		}
+		if e.GetMethod() != "CancelOrder" {
+			continue
+		}
+		var details map[string]string
+		if err := json.Unmarshal([]byte(e.GetDetails()), &details); err != nil {
+			t.Fatal(err)
+		}
+		cancelled = append(cancelled, details)
+	}
+	return cancelled
+}
+
+func TestCheckoutEndToEnd(t *testing.T) {
+	shop := testsupport.Start(t)
+	c := shop.NewClient(t)
+
+	if code, body := c.PostForm("/cart", url.Values{"product_id": {"OLJCESPC7Z"}, "variant_id": {"OLJCESPC7Z-BLK"}, "quantity": {"2"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+	code, cart := c.Get("/cart")
+	if code != http.StatusOK {
+		t.Fatalf("cart: %d %s", code, cart)
+	}
+	before := len(shop.Payments.Charges())
+
+	form := testsupport.CheckoutForm(cart)
+	code, body := c.PostForm("/cart/checkout", form)
+	if code != http.StatusOK {
+		t.Fatalf("checkout: %d %s", code, body)
+	}
+	if !strings.Contains(body, "order-complete-section") {
+		t.Errorf("checkout did not render the order confirmation:\n%s", body)
+	}
+
+	charges := shop.Payments.Charges()
+	if len(charges) != before+1 {
+		t.Fatalf("got %d new charges, want 1", len(charges)-before)
+	}
+	if got := charges[len(charges)-1].GetCreditCard().GetCreditCardNumber(); got != form.Get("credit_card_number") {
+		t.Errorf("charged card %q, want %q", got, form.Get("credit_card_number"))
+	}
+
+	// The cart is emptied once the order is placed.
+	if _, cart := c.Get("/cart"); strings.Contains(cart, `name="checkout_nonce"`) {
+		t.Error("cart still offers checkout after the order was placed")
+	}
+
+	// The nonce is spent: submitting the form again places no second order.
+	if code, body := c.PostForm("/cart/checkout", form); code != http.StatusConflict {
+		t.Fatalf("resubmitted checkout: got %d, want %d: %s", code, http.StatusConflict, body)
+	}
+	if n := len(shop.Payments.Charges()) - before; n != 1 {
+		t.Errorf("resubmitting the form charged %d times in all, want once", n)
+	}
+}
+
+func TestCheckoutRefundsWhenShippingFails(t *testing.T) {
+	shop := testsupport.Start(t)
+	c := shop.NewClient(t)
+
+	if code, body := c.PostForm("/cart", url.Values{"product_id": {"OLJCESPC7Z"}, "variant_id": {"OLJCESPC7Z-BLK"}, "quantity": {"1"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+	_, cart := c.Get("/cart")
+	before := len(shop.Payments.Charges())
+	cancelledBefore := len(cancellations(t, shop))
+
+	// Shipping cannot record the shipment once the card is charged.
+	shop.Redis["SHIPPING"].SetError("READONLY You can't write against a read only replica.")
+	code, body := c.PostForm("/cart/checkout", testsupport.CheckoutForm(cart))
+	shop.Redis["SHIPPING"].SetError("")
+	if code == http.StatusOK {
+		t.Fatalf("checkout succeeded while shipping was failing:\n%s", body)
+	}
+
+	if n := len(shop.Payments.Charges()) - before; n != 1 {
+		t.Fatalf("got %d new charges, want 1", n)
+	}
+	refunds := shop.Payments.Refunds()
+	if len(refunds) != 1 {
+		t.Fatalf("got %d refunds, want 1", len(refunds))
+	}
+	if refunds[0].GetTransactionId() == "" || refunds[0].GetReason() != "shipping failed" {
+		t.Errorf("got refund %v, want the charge given back as shipping failed", refunds[0])
+	}
+
+	cancelled := cancellations(t, shop)
+	if len(cancelled) != cancelledBefore+1 {
+		t.Fatalf("got %d cancellations audited, want 1", len(cancelled)-cancelledBefore)
+	}
+	if got := cancelled[len(cancelled)-1]; got["reason"] != "shipping failed" || got["from"] != "PAID" || got["transaction_id"] != refunds[0].GetTransactionId() {
+		t.Errorf("audited cancellation %v, want the paid order cancelled as shipping failed", got)
+	}
+}
+
+func TestCheckoutAuditsDeclinedOrder(t *testing.T) {
+	shop := testsupport.Start(t)
+	c := shop.NewClient(t)
+
+	if code, body := c.PostForm("/cart", url.Values{"product_id": {"1YMWWN1N4O"}, "quantity": {"1"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+	_, cart := c.Get("/cart")
+	form := testsupport.CheckoutForm(cart)
+	form.Set("credit_card_number", "4111111111111111")
+	shop.Payments.Decline("4111111111111111")
+	before := len(cancellations(t, shop))
+
+	if code, body := c.PostForm("/cart/checkout", form); code == http.StatusOK {
+		t.Fatalf("checkout succeeded with a declined card:\n%s", body)
+	}
+	cancelled := cancellations(t, shop)
+	if len(cancelled) != before+1 {
+		t.Fatalf("got %d cancellations audited, want 1", len(cancelled)-before)
+	}
+	if got := cancelled[len(cancelled)-1]; got["reason"] != "card payment failed" || got["from"] != "PENDING" || got["order_id"] == "" {
+		t.Errorf("audited cancellation %v, want the pending order cancelled as the card failed", got)
+	}
+}
+
+func TestCheckoutTellsShopperTheCardWasDeclined(t *testing.T) {
+	t.Cleanup(func() { config.Reload() })
+	t.Setenv("FRONTEND_SHOW_ERRORS", "true")
+	config.Reload()
+	shop := testsupport.Start(t)
+	c := shop.NewClient(t)
+
+	if code, body := c.PostForm("/cart", url.Values{"product_id": {"1YMWWN1N4O"}, "quantity": {"1"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+	_, cart := c.Get("/cart")
+	form := testsupport.CheckoutForm(cart)
+	form.Set("credit_card_number", "5555555555554444")
+	shop.Payments.Decline("5555555555554444")
+
+	code, body := c.PostForm("/cart/checkout", form)
+	if code != http.StatusUnprocessableEntity {
+		t.Fatalf("checkout with a declined card: got %d, want %d: %s", code, http.StatusUnprocessableEntity, body)
+	}
+	if want := "Your card could not be charged."; !strings.Contains(body, want) {
+		t.Errorf("error page does not say %q:\n%s", want, body)
+	}
+}
+
+func TestCheckoutCancelsShipmentsWhenSplitFails(t *testing.T) {
+	// Every unit ships on its own.
+	t.Cleanup(func() { config.Reload() })
+	t.Setenv("SHIPMENT_MAX_UNITS", "1")
+	config.Reload()
+
+	shop := testsupport.Start(t)
+	c := shop.NewClient(t)
+	if code, body := c.PostForm("/cart", url.Values{"product_id": {"1YMWWN1N4O"}, "quantity": {"2"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+	_, cart := c.Get("/cart")
+	rdb := shop.Redis["SHIPPING"]
+	existing := make(map[string]bool)
+	for _, key := range rdb.Keys() {
+		existing[key] = true
+	}
+	shippedBefore := rdb.HGet("warehouse-shipped:us-west", "1YMWWN1N4O")
+	refundsBefore := len(shop.Payments.Refunds())
+
+	// The second shipment cannot be recorded.
+	rdb.Server().SetPreHook(func(c *server.Peer, cmd string, args ...string) bool {
+		if strings.EqualFold(cmd, "SETNX") && len(args) > 0 && strings.HasSuffix(args[0], ":2") {
+			c.WriteError("READONLY You can't write against a read only replica.")
+			return true
+		}
+		return false
+	})
+	code, body := c.PostForm("/cart/checkout", testsupport.CheckoutForm(cart))
+	rdb.Server().SetPreHook(nil)
+	if code == http.StatusOK {
+		t.Fatalf("checkout succeeded while the second shipment was failing:\n%s", body)
+	}
+	if n := len(shop.Payments.Refunds()) - refundsBefore; n != 1 {
+		t.Errorf("got %d refunds, want 1", n)
+	}
+
+	// The first shipment was created, then cancelled.
+	var created []string
+	for _, key := range rdb.Keys() {
+		if strings.HasPrefix(key, "tracking:") && !existing[key] {
+			created = append(created, key)
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("got %d shipments created, want 1", len(created))
+	}
+	data, err := rdb.Get(created[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rec struct {
+		Shipment *pb.Shipment `json:"shipment"`
+	}
+	if err := json.Unmarshal([]byte(data), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Shipment.GetStatus(); got != "CANCELLED" {
+		t.Errorf("shipment of the failed order is %s, want CANCELLED", got)
+	}
+	if ok, _ := rdb.SIsMember("shipments:active", rec.Shipment.GetTrackingId()); ok {
+		t.Error("cancelled shipment is still advanced by the carrier")
+	}
+	if got := rdb.HGet("warehouse-shipped:us-west", "1YMWWN1N4O"); got != shippedBefore && !(shippedBefore == "" && got == "0") {
+		t.Errorf("warehouse shipped count went from %q to %q, want it given back", shippedBefore, got)
+	}
+}
+
+func TestCheckoutIsCancelledWhenStockCannotBeCommitted(t *testing.T) {
+	shop := testsupport.Start(t)
+	c := shop.NewClient(t)
+	if code, body := c.PostForm("/cart", url.Values{"product_id": {"1YMWWN1N4O"}, "quantity": {"1"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+	_, cart := c.Get("/cart")
+	rdb := shop.Redis["SHIPPING"]
+	existing := make(map[string]bool)
+	for _, key := range rdb.Keys() {
+		existing[key] = true
+	}
+	refundsBefore := len(shop.Payments.Refunds())
+
+	// The hold on the stock expires as the order ships.
+	var releaseErr error
+	rdb.Server().SetPreHook(func(c *server.Peer, cmd string, args ...string) bool {
+		if strings.EqualFold(cmd, "SETNX") && len(args) > 0 && strings.HasPrefix(args[0], "shipment:") {
+			releaseErr = shop.ReleaseStock(strings.TrimPrefix(args[0], "shipment:"))
+		}
+		return false
+	})
+	code, body := c.PostForm("/cart/checkout", testsupport.CheckoutForm(cart))
+	rdb.Server().SetPreHook(nil)
+	if releaseErr != nil {
+		t.Fatalf("release stock: %v", releaseErr)
+	}
+	if code == http.StatusOK {
+		t.Fatalf("checkout succeeded with stock that was no longer held:\n%s", body)
+	}
+	if n := len(shop.Payments.Refunds()) - refundsBefore; n != 1 {
+		t.Errorf("got %d refunds, want 1", n)
+	}
+
+	// The shipment was created, then cancelled.
+	var created []string
+	for _, key := range rdb.Keys() {
+		if strings.HasPrefix(key, "tracking:") && !existing[key] {
+			created = append(created, key)
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("got %d shipments created, want 1", len(created))
+	}
+	data, err := rdb.Get(created[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rec struct {
+		Shipment *pb.Shipment `json:"shipment"`
+	}
+	if err := json.Unmarshal([]byte(data), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if got := rec.Shipment.GetStatus(); got != "CANCELLED" {
+		t.Errorf("shipment of the failed order is %s, want CANCELLED", got)
+	}
+}
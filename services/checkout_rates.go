@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// orderRates converts the amounts of one order, fetching each exchange rate
+// the first time it is needed and reusing it after, so that prices converted
+// moments apart can never be at different rates.
+type orderRates struct {
+	cs *CheckoutService
+
+	mu     sync.Mutex
+	rates  map[[2]string]*big.Rat
+	pinned []*pb.PinnedRate
+}
+
+func (cs *CheckoutService) newOrderRates() *orderRates {
+	return &orderRates{cs: cs, rates: make(map[[2]string]*big.Rat)}
+}
+
+// convert converts from to toCurrency at the order's rate and returns the
+// result along with that rate.
+func (r *orderRates) convert(ctx context.Context, from *pb.Money, toCurrency string) (*pb.Money, string, error) {
+	if from.GetCurrencyCode() == toCurrency {
+		return from, "1", nil
+	}
+	rate, err := r.rate(ctx, from.GetCurrencyCode(), toCurrency)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to convert currency: %+v", err)
+	}
+	to, err := convertMoney(from, rate, toCurrency)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to convert currency: %+v", err)
+	}
+	return to, formatRate(rate), nil
+}
+
+// rate returns the from -> to rate of the order, fetching it if it is not
+// pinned yet.
+func (r *orderRates) rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rate, ok := r.rates[[2]string{from, to}]; ok {
+		return rate, nil
+	}
+
+	currencyClient := pb.NewCurrencyServiceClient(r.cs.currencySvcConn.Pick())
+	resp, err := currencyClient.GetExchangeRate(ctx, &pb.ExchangeRateRequest{FromCode: from, ToCode: to})
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := new(big.Rat).SetString(resp.GetRate())
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid %s -> %s rate %q", from, to, resp.GetRate())
+	}
+	r.rates[[2]string{from, to}] = rate
+	r.pinned = append(r.pinned, &pb.PinnedRate{
+		FromCode: from,
+		ToCode:   to,
+		Rate:     resp.GetRate(),
+		PinnedAt: time.Now().Unix(),
+	})
+	return rate, nil
+}
+
+// pinnedRates returns the rates fetched for the order, nil if none were.
+func (r *orderRates) pinnedRates() *pb.PinnedRates {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pinned) == 0 {
+		return nil
+	}
+	return &pb.PinnedRates{Rates: append([]*pb.PinnedRate(nil), r.pinned...)}
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// orderRetention is how long orders keep the personal data of the shopper,
+// ORDER_RETENTION, after which it is erased from them. Zero keeps it for as
+// long as the order is on record.
+var orderRetention = config.NewValue(func() time.Duration {
+	return envDuration("ORDER_RETENTION", 30*24*time.Hour)
+})
+
+// orderRetentionBatch is how many orders are read from the index at once.
+const orderRetentionBatch = 100
+
+// orderRetentionStats counts the orders the retention worker went through:
+// anonymized, already gone from the record, or failed.
+var orderRetentionStats = expvar.NewMap("order_retention")
+
+// anonymizeOrder erases the shopper from rec, keeping what analytics needs:
+// the status and its history, the times and the number of shipments. It is
+// marked rather than deleted, so its status can still be looked up.
+func anonymizeOrder(rec *orderRecord, now time.Time) {
+	rec.Email = ""
+	rec.Locale = ""
+	rec.UserID = ""
+	rec.Delivered = nil
+	rec.Status.TransactionId = ""
+	rec.AnonymizedAt = now.Unix()
+}
+
+// retainOrders anonymizes the orders placed longer than ORDER_RETENTION ago,
+// every interval.
+func (cs *CheckoutService) retainOrders(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		retention := orderRetention.Get()
+		if retention <= 0 {
+			continue
+		}
+		orderRetentionStats.Add("runs", 1)
+		// Each tenant keeps its own orders.
+		for _, t := range append([]string{""}, tenant.IDs()...) {
+			ctx := tenant.NewContext(context.Background(), t)
+			cutoff := time.Now().Add(-retention)
+			// Batches go on while they come full, and through, so that a
+			// backlog clears but an order that keeps failing does not hold
+			// the worker.
+			for {
+				n, err := cs.anonymizeOrdersBefore(ctx, cutoff)
+				if err != nil {
+					log.Printf("failed to anonymize orders of tenant %q: %+v", t, err)
+				}
+				if err != nil || n < orderRetentionBatch {
+					break
+				}
+			}
+		}
+	}
+}
+
+// anonymizeOrdersBefore anonymizes up to orderRetentionBatch orders placed
+// before cutoff, taking them off the index of orders by time. It returns
+// how many it took off; those that failed stay for the next pass.
+func (cs *CheckoutService) anonymizeOrdersBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	ids, err := cs.rdb.ZRangeByScore(ctx, ordersByTimeKey(ctx), &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(cutoff.Unix(), 10),
+		Count: orderRetentionBatch,
+	}).Result()
+	if err != nil {
+		return 0, err
+	}
+	done := 0
+	for _, id := range ids {
+		var userID string
+		err := cs.updateOrder(ctx, id, func(rec *orderRecord) (bool, error) {
+			userID = rec.UserID
+			anonymizeOrder(rec, time.Now())
+			return false, nil
+		})
+		var notFound OrderNotFoundErr
+		switch {
+		case errors.As(err, &notFound):
+			// Deleted, or expired, since it was indexed.
+			orderRetentionStats.Add("missing", 1)
+		case err != nil:
+			orderRetentionStats.Add("failed", 1)
+			log.Printf("failed to anonymize order %s: %+v", id, err)
+			continue
+		default:
+			orderRetentionStats.Add("anonymized", 1)
+		}
+		pipe := cs.rdb.TxPipeline()
+		pipe.ZRem(ctx, ordersByTimeKey(ctx), id)
+		if userID != "" {
+			pipe.SRem(ctx, userOrdersKey(ctx, userID), id)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return done, err
+		}
+		done++
+	}
+	if done > 0 {
+		log.Printf("anonymized %d order(s) placed before %s", done, cutoff.UTC().Format(time.RFC3339))
+	}
+	return done, nil
+}
+
+func ordersByTimeKey(ctx context.Context) string {
+	return tenant.Key(ctx, "orders-by-time")
+}
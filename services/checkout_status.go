@@ -0,0 +1,486 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/privacy"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// Statuses of an order.
+const (
+	orderPending   = "PENDING"
+	orderPaid      = "PAID"
+	orderShipped   = "SHIPPED"
+	orderDelivered = "DELIVERED"
+	orderCancelled = "CANCELLED"
+	orderRefunded  = "REFUNDED"
+)
+
+// orderTransitions lists the statuses an order can move to from each status.
+// Cancelled and refunded orders stay so.
+var orderTransitions = map[string][]string{
+	orderPending:   {orderPaid, orderCancelled},
+	orderPaid:      {orderShipped, orderCancelled, orderRefunded},
+	orderShipped:   {orderDelivered, orderRefunded},
+	orderDelivered: {orderRefunded},
+}
+
+// orderStatusByTransaction gives the order status that follows from each
+// transaction status the processor reports.
+var orderStatusByTransaction = map[string]string{
+	transactionCaptured:      orderPaid,
+	transactionCaptureFailed: orderCancelled,
+	transactionChargedBack:   orderRefunded,
+}
+
+type InvalidOrderTransitionErr struct {
+	From, To string
+}
+
+func (e InvalidOrderTransitionErr) Error() string {
+	return fmt.Sprintf("order cannot go from %s to %s", e.From, e.To)
+}
+
+// OrderNotFoundErr is the error of an update to an order that is not on
+// record.
+type OrderNotFoundErr struct {
+	OrderID string
+}
+
+func (e OrderNotFoundErr) Error() string {
+	return fmt.Sprintf("order %s not found", e.OrderID)
+}
+
+// orderStatusTTL is how long the status of an order is kept.
+const orderStatusTTL = 90 * 24 * time.Hour
+
+// orderRetries bounds the attempts at an update that lost a race with
+// another one on the same order.
+const orderRetries = 5
+
+// orderRecord is what is kept of an order to track its status.
+type orderRecord struct {
+	Status *pb.OrderStatus `json:"status"`
+	Email  string          `json:"email"`
+	Locale string          `json:"locale"`
+	UserID string          `json:"user_id"`
+
+	// Shipments is how many shipments the order went out in, and Delivered
+	// the tracking IDs of those delivered.
+	Shipments int      `json:"shipments"`
+	Delivered []string `json:"delivered,omitempty"`
+
+	// Consent is what the shopper agreed to in placing the order, kept as
+	// proof of it.
+	Consent *pb.Consent `json:"consent,omitempty"`
+
+	// ReferralCode is the referral code the order is attributed to, if the
+	// shopper arrived with one.
+	ReferralCode string `json:"referral_code,omitempty"`
+
+	// AnonymizedAt is when the personal data of the shopper was erased from
+	// the record, in Unix seconds, if it was.
+	AnonymizedAt int64 `json:"anonymized_at,omitempty"`
+
+	// PlacedAt is when the order was placed, in Unix seconds, and Currency
+	// the currency it was priced in. Amounts given back later are converted
+	// at the rates of that time.
+	PlacedAt int64  `json:"placed_at,omitempty"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// GetOrderStatus returns the status of an order of the shopper. Other
+// shoppers' orders are not found.
+func (cs *CheckoutService) GetOrderStatus(ctx context.Context, req *pb.GetOrderStatusRequest) (_ *pb.OrderStatus, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.FromContext(ctx)
+	rec, err := cs.loadOrder(ctx, req.GetOrderId())
+	if err == redis.Nil || (err == nil && (userID == "" || rec.UserID != userID)) {
+		return nil, ctx, status.Errorf(codes.NotFound, "order %q not found", req.GetOrderId())
+	} else if err != nil {
+		log.Printf("failed to fetch status of order %s: %+v", req.GetOrderId(), err)
+		return nil, ctx, err
+	}
+	return rec.Status, ctx, nil
+}
+
+// loadOrder reads the record of an order. It returns redis.Nil if there is
+// none.
+func (cs *CheckoutService) loadOrder(ctx context.Context, orderID string) (*orderRecord, error) {
+	data, err := cs.rdb.Get(ctx, orderStatusKey(ctx, orderID)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var rec orderRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// createOrder records a new order as pending, along with the consent it was
+// placed with and the referral code it is attributed to. The order can be
+// placed without it, so failures are only logged.
+func (cs *CheckoutService) createOrder(ctx context.Context, orderID, userID string, req *pb.PlaceOrderRequest) {
+	now := time.Now().Unix()
+	rec := &orderRecord{
+		Status: &pb.OrderStatus{
+			OrderId:   orderID,
+			Status:    orderPending,
+			UpdatedAt: now,
+		},
+		Email:        req.GetEmail(),
+		Locale:       req.GetLocale(),
+		UserID:       userID,
+		Consent:      req.GetConsent(),
+		ReferralCode: req.GetReferralCode(),
+		PlacedAt:     now,
+		Currency:     req.GetUserCurrency(),
+	}
+	data, err := json.Marshal(rec)
+	if err == nil {
+		err = cs.rdb.SetNX(ctx, orderStatusKey(ctx, orderID), data, orderStatusTTL).Err()
+	}
+	if err == nil {
+		// Orders are indexed by time for the retention worker and, by
+		// user, for ExportUserData and DeleteUserData; the list of a user
+		// outlives none of their orders.
+		pipe := cs.rdb.TxPipeline()
+		pipe.ZAdd(ctx, ordersByTimeKey(ctx), redis.Z{Score: float64(rec.Status.UpdatedAt), Member: orderID})
+		if userID != "" {
+			pipe.SAdd(ctx, userOrdersKey(ctx, userID), orderID)
+			pipe.Expire(ctx, userOrdersKey(ctx, userID), orderStatusTTL)
+		}
+		_, err = pipe.Exec(ctx)
+	}
+	if err != nil {
+		log.Printf("failed to record order %s: %+v", orderID, err)
+	}
+}
+
+// setOrderStatus moves an order to status to, for reason, and publishes the
+// change. Moving it to the status it has already is not a change, as events
+// may be delivered more than once. Failures are logged, as the order has
+// moved on whether or not its status follows.
+func (cs *CheckoutService) setOrderStatus(ctx context.Context, orderID, to, reason string) {
+	err := cs.updateOrder(ctx, orderID, func(rec *orderRecord) (bool, error) {
+		return transitionOrder(rec, to, reason)
+	})
+	if err != nil {
+		log.Printf("failed to move order %s to %s: %+v", orderID, to, err)
+	}
+}
+
+// transitionOrder moves rec to status to, reporting whether that changed it.
+func transitionOrder(rec *orderRecord, to, reason string) (bool, error) {
+	from := rec.Status.GetStatus()
+	if from == to {
+		return false, nil
+	}
+	if !slices.Contains(orderTransitions[from], to) {
+		return false, InvalidOrderTransitionErr{From: from, To: to}
+	}
+	rec.Status.Status = to
+	rec.Status.Reason = reason
+	rec.Status.UpdatedAt = time.Now().Unix()
+	return true, nil
+}
+
+// updateOrder applies fn to the record of an order and, if fn reports that
+// the status changed, publishes the change.
+func (cs *CheckoutService) updateOrder(ctx context.Context, orderID string, fn func(*orderRecord) (bool, error)) error {
+	key := orderStatusKey(ctx, orderID)
+	var rec orderRecord
+	var previous string
+	var changed bool
+	var err error
+	for range orderRetries {
+		err = cs.rdb.Watch(ctx, func(tx *redis.Tx) error {
+			data, err := tx.Get(ctx, key).Bytes()
+			if err != nil {
+				return err
+			}
+			rec = orderRecord{}
+			if err := json.Unmarshal(data, &rec); err != nil {
+				return err
+			}
+			previous = rec.Status.GetStatus()
+			if changed, err = fn(&rec); err != nil {
+				return err
+			}
+			if data, err = json.Marshal(&rec); err != nil {
+				return err
+			}
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, data, orderStatusTTL)
+				return nil
+			})
+			return err
+		}, key)
+		if err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err == redis.Nil {
+		return OrderNotFoundErr{OrderID: orderID}
+	} else if err != nil || !changed {
+		return err
+	}
+
+	log.Printf("order %s: %s -> %s", orderID, previous, rec.Status.Status)
+	if rec.Status.Status == orderCancelled {
+		cs.recordCancellation(ctx, previous, rec.Status)
+	}
+	event := &pb.OrderStatusChanged{
+		Status:         rec.Status,
+		PreviousStatus: previous,
+		Email:          rec.Email,
+		Locale:         rec.Locale,
+		UserId:         rec.UserID,
+		Tenant:         tenant.FromContext(ctx),
+		ReferralCode:   rec.ReferralCode,
+	}
+	if err := cs.bus.Publish(ctx, eventbus.TopicOrderStatusChanged, event); err != nil {
+		log.Printf("failed to publish status of order %s: %+v", orderID, err)
+	}
+	return nil
+}
+
+// recordCancellation appends the cancellation of an order, from status
+// previous, to the audit log, whichever way it came about. Failures are
+// logged, as the order is cancelled already.
+func (cs *CheckoutService) recordCancellation(ctx context.Context, previous string, st *pb.OrderStatus) {
+	details, _ := json.Marshal(map[string]string{
+		"order_id":       st.GetOrderId(),
+		"from":           previous,
+		"reason":         st.GetReason(),
+		"transaction_id": st.GetTransactionId(),
+	})
+	if err := cs.audit.Record(ctx, "CancelOrder", string(details)); err != nil {
+		log.Printf("failed to record cancellation of order %s: %+v", st.GetOrderId(), err)
+	}
+}
+
+// setOrderPayment records the transaction that paid for an order.
+func (cs *CheckoutService) setOrderPayment(ctx context.Context, orderID, txID string) {
+	err := cs.updateOrder(ctx, orderID, func(rec *orderRecord) (bool, error) {
+		rec.Status.TransactionId = txID
+		return transitionOrder(rec, orderPaid, "")
+	})
+	if err != nil {
+		log.Printf("failed to move order %s to %s: %+v", orderID, orderPaid, err)
+	}
+}
+
+// setOrderShipments records how many shipments an order went out in, which
+// must all be delivered for the order to be.
+func (cs *CheckoutService) setOrderShipments(ctx context.Context, orderID string, n int) {
+	err := cs.updateOrder(ctx, orderID, func(rec *orderRecord) (bool, error) {
+		rec.Shipments = n
+		return false, nil
+	})
+	if err != nil {
+		log.Printf("failed to record shipments of order %s: %+v", orderID, err)
+	}
+}
+
+// handlePaymentStatusChanged is the event bus handler for payments. It moves
+// the order a transaction pays for to the status that follows.
+func (cs *CheckoutService) handlePaymentStatusChanged(payload []byte) error {
+	var event pb.PaymentStatusChanged
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	txn := event.GetTransaction()
+	next, ok := orderStatusByTransaction[txn.GetStatus()]
+	if !ok || txn.GetOrderId() == "" {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+
+	// What the shopper gets back is reported in the currency they paid in,
+	// as converted when they did.
+	var refunded *pb.Money
+	if next == orderRefunded {
+		var err error
+		if refunded, err = cs.refundedAmount(ctx, txn); err != nil {
+			log.Printf("failed to convert refund of order %s: %+v", txn.GetOrderId(), err)
+		}
+	}
+	return cs.updateOrder(ctx, txn.GetOrderId(), func(rec *orderRecord) (bool, error) {
+		changed, err := transitionOrder(rec, next, txn.GetFailureReason())
+		if changed && refunded != nil {
+			rec.Status.RefundedAmount = refunded
+		}
+		return changed, err
+	})
+}
+
+// refundedAmount returns the amount of txn in the currency of the order it
+// paid for, at the rate in effect when the order was placed rather than
+// today's.
+func (cs *CheckoutService) refundedAmount(ctx context.Context, txn *pb.Transaction) (*pb.Money, error) {
+	rec, err := cs.loadOrder(ctx, txn.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	amount := txn.GetAmount()
+	if rec.Currency == "" || rec.Currency == amount.GetCurrencyCode() {
+		return amount, nil
+	}
+	currencyClient := pb.NewCurrencyServiceClient(cs.currencySvcConn.Pick())
+	resp, err := currencyClient.RateAt(ctx, &pb.RateAtRequest{
+		At:       rec.PlacedAt,
+		FromCode: amount.GetCurrencyCode(),
+		ToCode:   rec.Currency})
+	if err != nil {
+		return nil, err
+	}
+	rate, ok := new(big.Rat).SetString(resp.GetRate())
+	if !ok || rate.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid %s -> %s rate %q", amount.GetCurrencyCode(), rec.Currency, resp.GetRate())
+	}
+	return convertMoney(amount, rate, rec.Currency)
+}
+
+// handleShipmentStatusChanged is the event bus handler for shipments. An
+// order ships when the carrier picks up its first shipment and is delivered
+// when its last one is.
+func (cs *CheckoutService) handleShipmentStatusChanged(payload []byte) error {
+	var event pb.ShipmentStatusChanged
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	shipment := event.GetShipment()
+	if shipment.GetOrderId() == "" {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+	switch shipment.GetStatus() {
+	case "PICKED_UP":
+		return cs.updateOrder(ctx, shipment.GetOrderId(), func(rec *orderRecord) (bool, error) {
+			return transitionOrder(rec, orderShipped, "")
+		})
+	case "DELIVERED":
+		return cs.updateOrder(ctx, shipment.GetOrderId(), func(rec *orderRecord) (bool, error) {
+			if !slices.Contains(rec.Delivered, shipment.GetTrackingId()) {
+				rec.Delivered = append(rec.Delivered, shipment.GetTrackingId())
+			}
+			if len(rec.Delivered) < max(rec.Shipments, 1) {
+				return false, nil
+			}
+			return transitionOrder(rec, orderDelivered, "")
+		})
+	}
+	return nil
+}
+
+func orderStatusKey(ctx context.Context, orderID string) string {
+	return tenant.Key(ctx, "order-status:"+orderID)
+}
+
+func userOrdersKey(ctx context.Context, userID string) string {
+	return tenant.Key(ctx, "user-orders:"+userID)
+}
+
+// userOrders returns the orders of userID still on record, by ID.
+func (cs *CheckoutService) userOrders(ctx context.Context, userID string) (map[string]*orderRecord, error) {
+	ids, err := cs.rdb.SMembers(ctx, userOrdersKey(ctx, userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	orders := make(map[string]*orderRecord, len(ids))
+	for _, id := range ids {
+		rec, err := cs.loadOrder(ctx, id)
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		orders[id] = rec
+	}
+	return orders, nil
+}
+
+// orderEmails returns the addresses orders were placed with.
+func orderEmails(orders map[string]*orderRecord) *pb.EmailAddresses {
+	var emails []string
+	for _, rec := range orders {
+		if email := strings.ToLower(strings.TrimSpace(rec.Email)); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	slices.Sort(emails)
+	return &pb.EmailAddresses{Addresses: slices.Compact(emails)}
+}
+
+// ExportUserData returns the orders of the user, along with the addresses
+// they were placed with.
+func (cs *CheckoutService) ExportUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID, err := privacy.User(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+	orders, err := cs.userOrders(ctx, userID)
+	if err != nil {
+		log.Printf("failed to list orders of user_id=%q: %+v", userID, err)
+		return nil, ctx, err
+	}
+	rec, err := privacy.Record("orders", len(orders), orders, true)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec}, Emails: orderEmails(orders)}, ctx, nil
+}
+
+// DeleteUserData deletes the records of the orders of the user, returning
+// the addresses they were placed with. The orders themselves stand; only
+// their status can no longer be tracked.
+func (cs *CheckoutService) DeleteUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID, err := privacy.User(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+	orders, err := cs.userOrders(ctx, userID)
+	if err != nil {
+		log.Printf("failed to list orders of user_id=%q: %+v", userID, err)
+		return nil, ctx, err
+	}
+	pipe := cs.rdb.TxPipeline()
+	pipe.Del(ctx, userOrdersKey(ctx, userID))
+	for id := range orders {
+		pipe.Del(ctx, orderStatusKey(ctx, id))
+		pipe.ZRem(ctx, ordersByTimeKey(ctx), id)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("failed to delete orders of user_id=%q: %+v", userID, err)
+		return nil, ctx, err
+	}
+	log.Printf("deleted %d order(s) of user_id=%q", len(orders), userID)
+	rec, err := privacy.Record("orders", len(orders), nil, false)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec}, Emails: orderEmails(orders)}, ctx, nil
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// Outcomes of a post-order step.
+const (
+	stepDone    = "DONE"
+	stepFailed  = "FAILED"
+	stepPending = "PENDING"
+)
+
+// postOrderWait is how long PlaceOrder waits for the steps after an order is
+// placed, POST_ORDER_WAIT. Steps still running then go on without it.
+var postOrderWait = config.NewValue(func() time.Duration {
+	return envDuration("POST_ORDER_WAIT", 2*time.Second)
+})
+
+// postOrderStep is a step of an order that runs once it is placed and does
+// not decide it: the order stands whether it fails or not.
+type postOrderStep struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// runPostOrderSteps runs steps concurrently for order orderID and waits for
+// them up to wait. Steps that outlast the wait are reported pending and their
+// outcome is only logged. They run on a context that is not canceled with
+// ctx, so the caller may return before they finish.
+func runPostOrderSteps(ctx context.Context, orderID string, wait time.Duration, steps ...postOrderStep) *pb.PostOrderSteps {
+	ctx = context.WithoutCancel(ctx)
+	type result struct {
+		i   int
+		err error
+	}
+	results := make(chan result, len(steps))
+	for i, step := range steps {
+		go func() {
+			start := time.Now()
+			err := step.run(ctx)
+			if err != nil {
+				log.Printf("order %s: %s failed after %v: %+v", orderID, step.name, time.Since(start), err)
+			} else {
+				log.Printf("order %s: %s done in %v", orderID, step.name, time.Since(start))
+			}
+			results <- result{i, err}
+		}()
+	}
+
+	out := &pb.PostOrderSteps{Steps: make([]*pb.PostOrderStep, len(steps))}
+	for i, step := range steps {
+		out.Steps[i] = &pb.PostOrderStep{Name: step.name, Outcome: stepPending}
+	}
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	for range steps {
+		select {
+		case r := <-results:
+			out.Steps[r.i].Outcome = stepDone
+			if r.err != nil {
+				out.Steps[r.i].Outcome = stepFailed
+				out.Steps[r.i].Error = r.err.Error()
+			}
+		case <-timeout.C:
+			return out
+		}
+	}
+	return out
+}
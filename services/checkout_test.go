@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+func TestMoneyToNanosOutOfRange(t *testing.T) {
+	for _, tc := range []struct {
+		m    *pb.Money
+		want int64
+		err  error
+	}{
+		{&pb.Money{Units: 12, Nanos: 340000000}, 12340000000, nil},
+		{&pb.Money{Units: -12, Nanos: -340000000}, -12340000000, nil},
+		{&pb.Money{Units: math.MaxInt64 / nanosMod, Nanos: 854775807}, math.MaxInt64, nil},
+		{&pb.Money{Units: math.MaxInt64 / nanosMod, Nanos: 854775808}, 0, ErrOutOfRange},
+		{&pb.Money{Units: math.MaxInt64 / nanosMod * 2}, 0, ErrOutOfRange},
+		{&pb.Money{Units: math.MinInt64 / nanosMod, Nanos: -854775809}, 0, ErrOutOfRange},
+	} {
+		got, err := moneyToNanos(tc.m)
+		if got != tc.want || err != tc.err {
+			t.Errorf("moneyToNanos(%v) = %d, %v; want %d, %v", tc.m, got, err, tc.want, tc.err)
+		}
+	}
+}
+
+func TestSplitPaymentAndDivideRejectHugeAmounts(t *testing.T) {
+	huge := &pb.Money{CurrencyCode: "USD", Units: math.MaxInt64}
+	if _, _, err := splitPayment(huge, &pb.Money{CurrencyCode: "USD", Units: 1}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("splitPayment of %v: got %v, want %v", huge, err, ErrOutOfRange)
+	}
+	_, err := Divide(huge, 3)
+	if !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Divide of %v: got %v, want %v", huge, err, ErrOutOfRange)
+	}
+	if st, _ := status.FromError(err); st.Code() != codes.OutOfRange {
+		t.Errorf("Divide of %v: got code %v, want %v", huge, st.Code(), codes.OutOfRange)
+	}
+
+	// Amounts in range are still split exactly.
+	wallet, card, err := splitPayment(&pb.Money{CurrencyCode: "USD", Units: 10, Nanos: 500000000}, &pb.Money{CurrencyCode: "USD", Units: 3, Nanos: 750000000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.GetUnits() != 3 || card.GetUnits() != 6 || card.GetNanos() != 750000000 {
+		t.Errorf("split 10.50 with 3.75 from the wallet: got wallet %v, card %v", wallet, card)
+	}
+}
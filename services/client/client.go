@@ -0,0 +1,328 @@
+// Package client implements the client command, which calls a method of a
+// service from the terminal and prints the response, e.g.
+//
+//	client cart get --user u1
+//	client currency convert --from.currency_code USD --from.units 10 --to_code EUR
+//	client productcatalog getproduct --id OLJCESPC7Z
+//
+// Services and methods are named case-insensitively, without the Service
+// suffix, and a method may be shortened to a prefix that names it alone.
+// Without a method, the methods of the service are listed, and without a
+// service, the services.
+//
+// The fields of the request are set with --field value, by proto or JSON
+// name, with dots for the fields of nested messages and once per value for
+// repeated fields, or all at once as JSON with -d. --user sets the user the
+// call is made for, as the frontend does for a shopper.
+//
+// Calls go to -target or else the address the frontend would use, such as
+// CART_SERVICE_ADDR, through the codec and user context elements of the
+// services.
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/discovery"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// Main runs the client command with the given arguments and returns the
+// exit status.
+func Main(args []string) int {
+	fs := flag.NewFlagSet("client", flag.ExitOnError)
+	var (
+		target  = fs.String("target", "", "aRPC address of the service, by default its <SERVICE>_SERVICE_ADDR")
+		data    = fs.String("d", "", "request as JSON, to which --field flags are applied")
+		timeout = fs.Duration("timeout", 5*time.Second, "time limit of the call")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: client [flags] [service [method [--field value]...]]\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	files := (&pb.Empty{}).ProtoReflect().Descriptor().ParentFile()
+	if fs.NArg() == 0 {
+		for i := 0; i < files.Services().Len(); i++ {
+			fmt.Println(shortName(files.Services().Get(i)))
+		}
+		return 0
+	}
+	sd, err := findService(files.Services(), fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "client: %v\n", err)
+		return 2
+	}
+	if fs.NArg() == 1 {
+		for i := 0; i < sd.Methods().Len(); i++ {
+			md := sd.Methods().Get(i)
+			fmt.Printf("%s(%s) returns (%s)\n", md.Name(), md.Input().Name(), md.Output().Name())
+		}
+		return 0
+	}
+	md, err := findMethod(sd.Methods(), fs.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "client: %v\n", err)
+		return 2
+	}
+
+	req, err := newMessage(md.Input())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "client: %v\n", err)
+		return 2
+	}
+	resp, err := newMessage(md.Output())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "client: %v\n", err)
+		return 2
+	}
+	if *data != "" {
+		if err := protojson.Unmarshal([]byte(*data), req); err != nil {
+			fmt.Fprintf(os.Stderr, "client: -d: %v\n", err)
+			return 2
+		}
+	}
+	user, err := setFields(req.ProtoReflect(), fs.Args()[2:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "client: %v\n", err)
+		return 2
+	}
+
+	addr := *target
+	if addr == "" {
+		if addr, err = serviceAddr(sd); err != nil {
+			fmt.Fprintf(os.Stderr, "client: %v\n", err)
+			return 2
+		}
+	}
+	c, err := rpc.NewClient(codec.NewClient(), addr, []element.RPCElement{usercontext.NewClientElement(), codec.NewClientElement()})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "client: cannot connect to %s: %v\n", addr, err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if user != "" {
+		ctx = usercontext.NewContext(ctx, user)
+	}
+	start := time.Now()
+	if err := c.Call(ctx, string(sd.Name()), string(md.Name()), req, resp); err != nil {
+		fmt.Fprintf(os.Stderr, "client: %s.%s failed after %v: %v\n", sd.Name(), md.Name(), time.Since(start), err)
+		return 1
+	}
+	fmt.Println(protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Format(resp))
+	fmt.Fprintf(os.Stderr, "client: %s.%s took %v\n", sd.Name(), md.Name(), time.Since(start))
+	return 0
+}
+
+// shortName returns the name of a service as given on the command line, e.g.
+// productcatalog for ProductCatalogService.
+func shortName(sd protoreflect.ServiceDescriptor) string {
+	return strings.ToLower(strings.TrimSuffix(string(sd.Name()), "Service"))
+}
+
+func findService(services protoreflect.ServiceDescriptors, name string) (protoreflect.ServiceDescriptor, error) {
+	name = strings.ToLower(name)
+	for i := 0; i < services.Len(); i++ {
+		sd := services.Get(i)
+		if shortName(sd) == name || strings.ToLower(string(sd.Name())) == name {
+			return sd, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown service %q", name)
+}
+
+// findMethod returns the method called name or, failing that, the only one
+// whose name starts with it.
+func findMethod(methods protoreflect.MethodDescriptors, name string) (protoreflect.MethodDescriptor, error) {
+	name = strings.ToLower(name)
+	var matches []protoreflect.MethodDescriptor
+	for i := 0; i < methods.Len(); i++ {
+		md := methods.Get(i)
+		method := strings.ToLower(string(md.Name()))
+		if method == name {
+			return md, nil
+		}
+		if strings.HasPrefix(method, name) {
+			matches = append(matches, md)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("unknown method %q", name)
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, md := range matches {
+		names[i] = string(md.Name())
+	}
+	return nil, fmt.Errorf("method %q is ambiguous: %s", name, strings.Join(names, ", "))
+}
+
+// setFields sets the fields of msg given as --field value or --field=value
+// in args. It returns the user given with --user, unless msg has a field of
+// that name, which is also its user_id if it has one.
+func setFields(msg protoreflect.Message, args []string) (user string, err error) {
+	for len(args) > 0 {
+		arg := args[0]
+		args = args[1:]
+		if !strings.HasPrefix(arg, "-") {
+			return "", fmt.Errorf("expected --field, got %q", arg)
+		}
+		name, value, ok := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !ok {
+			if len(args) == 0 {
+				return "", fmt.Errorf("missing value of --%s", name)
+			}
+			value, args = args[0], args[1:]
+		}
+		if name == "user" && findField(msg.Descriptor(), name) == nil {
+			// Requests that name their user say so as well.
+			if fd := findField(msg.Descriptor(), "user_id"); fd != nil && fd.Kind() == protoreflect.StringKind && !msg.Has(fd) {
+				msg.Set(fd, protoreflect.ValueOfString(value))
+			}
+			user = value
+			continue
+		}
+		if err := setField(msg, name, value); err != nil {
+			return "", fmt.Errorf("--%s: %w", name, err)
+		}
+	}
+	return user, nil
+}
+
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := md.Fields().ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	return md.Fields().ByJSONName(name)
+}
+
+// setField sets the field at path, a dotted list of field names, to value.
+// Repeated fields are appended to.
+func setField(msg protoreflect.Message, path, value string) error {
+	names := strings.Split(path, ".")
+	for _, name := range names[:len(names)-1] {
+		fd := findField(msg.Descriptor(), name)
+		if fd == nil {
+			return fmt.Errorf("%s has no field %q", msg.Descriptor().Name(), name)
+		}
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("field %q is not a message", name)
+		}
+		msg = msg.Mutable(fd).Message()
+	}
+	name := names[len(names)-1]
+	fd := findField(msg.Descriptor(), name)
+	if fd == nil {
+		return fmt.Errorf("%s has no field %q", msg.Descriptor().Name(), name)
+	}
+	if fd.IsMap() || fd.Message() != nil {
+		return fmt.Errorf("field %q is a message; set its fields or use -d", name)
+	}
+	v, err := parseScalar(fd, value)
+	if err != nil {
+		return err
+	}
+	if fd.IsList() {
+		msg.Mutable(fd).List().Append(v)
+	} else {
+		msg.Set(fd, v)
+	}
+	return nil
+}
+
+func parseScalar(fd protoreflect.FieldDescriptor, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(s)), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	}
+	return protoreflect.Value{}, fmt.Errorf("fields of kind %s are not supported", fd.Kind())
+}
+
+// newMessage returns an empty message of the generated type of md, which is
+// what the serializers know how to encode.
+func newMessage(md protoreflect.MessageDescriptor) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+	if err != nil {
+		return nil, fmt.Errorf("unknown message type %s: %w", md.FullName(), err)
+	}
+	return mt.New().Interface(), nil
+}
+
+// serviceAddr returns the address the frontend would call sd at: the first
+// of the addresses in <SERVICE>_SERVICE_ADDR, which may be a discovery
+// target.
+func serviceAddr(sd protoreflect.ServiceDescriptor) (string, error) {
+	var key strings.Builder
+	for i, r := range strings.TrimSuffix(string(sd.Name()), "Service") {
+		if i > 0 && unicode.IsUpper(r) {
+			key.WriteByte('_')
+		}
+		key.WriteRune(unicode.ToUpper(r))
+	}
+	env := key.String() + "_SERVICE_ADDR"
+	target := config.Get(env)
+	if target == "" {
+		return "", fmt.Errorf("%s is not set; give the address with -target", env)
+	}
+	if !discovery.IsTarget(target) {
+		addr, _, _ := strings.Cut(target, ",")
+		return strings.TrimSpace(addr), nil
+	}
+	lookup, err := discovery.Lookup(target)
+	if err != nil {
+		return "", err
+	}
+	addrs, err := lookup(context.Background())
+	if err != nil {
+		return "", err
+	}
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("no replica of %s found", target)
+	}
+	return addrs[0], nil
+}
@@ -0,0 +1,258 @@
+// Package codec lets services choose how aRPC payloads are serialized, among
+// Symphony, protobuf and JSON, and lets peers of different versions agree on
+// one.
+//
+// RPC_SERIALIZER names the codec a service prefers, "symphony" by default.
+// Payloads in any codec but legacy Symphony start with a tag byte naming
+// their codec; Symphony payloads always start with 0x00, so untagged ones
+// are still understood. Negotiation goes as follows:
+//
+//   - Clients list the codecs they decode in the x-arpc-accept metadata of
+//     each call (ClientElement) and send Symphony without a tag until the
+//     server has shown that it reads tags.
+//   - A server that receives the list replies, tagged, in its preferred
+//     codec if the client accepts it and in Symphony otherwise
+//     (ServerElement). Clients that send no list get untagged Symphony.
+//   - Once a client has received a tagged reply, it sends its preferred
+//     codec.
+//
+// So old and new services interoperate, and a deployment switches codecs
+// by setting RPC_SERIALIZER. The bytes and time spent encoding each codec
+// are published with expvar, under rpc_codec_bytes and rpc_codec_encode_ns,
+// for comparing their overheads.
+package codec
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"github.com/appnet-org/arpc/pkg/serializer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// AcceptKey lists, comma-separated, the codecs a client decodes.
+const AcceptKey = "x-arpc-accept"
+
+// Symphony is the codec of peers that do not negotiate.
+const Symphony = "symphony"
+
+// legacyHeader starts every Symphony payload.
+const legacyHeader = 0x00
+
+// codec is a registered serializer with the tag marking its payloads.
+type codec struct {
+	name string
+	tag  byte
+	s    serializer.Serializer
+}
+
+var (
+	byName = map[string]codec{}
+	byTag  = map[byte]codec{}
+	names  []string
+)
+
+// Register adds a codec. tag must not be 0x00, which starts legacy Symphony
+// payloads, nor be used by another codec. Codecs must be registered before
+// any call is made.
+func Register(name string, tag byte, s serializer.Serializer) {
+	if tag == legacyHeader {
+		panic("codec: tag 0x00 is reserved for legacy Symphony payloads")
+	}
+	if _, ok := byTag[tag]; ok {
+		panic(fmt.Sprintf("codec: tag %#x registered twice", tag))
+	}
+	c := codec{name: name, tag: tag, s: s}
+	byName[name] = c
+	byTag[tag] = c
+	names = append(names, name)
+}
+
+func init() {
+	Register(Symphony, 0xa0, &serializer.SymphonySerializer{})
+	Register("proto", 0xa1, &serializer.ProtoSerializer{})
+	Register("json", 0xa2, jsonSerializer{})
+	preferred = config.NewValue(readPreferred)
+}
+
+// jsonSerializer encodes messages in protobuf JSON.
+type jsonSerializer struct{}
+
+func (jsonSerializer) Marshal(msg any) ([]byte, error) {
+	return protojson.Marshal(msg.(proto.Message))
+}
+
+func (jsonSerializer) Unmarshal(data []byte, out any) error {
+	return protojson.Unmarshal(data, out.(proto.Message))
+}
+
+// preferred is the codec the service sends when its peer allows. It is read
+// once the built-in codecs are registered.
+var preferred *config.Value[string]
+
+func readPreferred() string {
+	name := config.Get("RPC_SERIALIZER")
+	if name == "" {
+		return Symphony
+	}
+	if _, ok := byName[name]; !ok {
+		log.Printf("codec: unknown RPC_SERIALIZER %q, using %s", name, Symphony)
+		return Symphony
+	}
+	return name
+}
+
+var (
+	encodedBytes = expvar.NewMap("rpc_codec_bytes")
+	encodeTime   = expvar.NewMap("rpc_codec_encode_ns")
+)
+
+// Serializer is an aRPC serializer speaking every registered codec. A client
+// needs its own for each server it calls, since it remembers whether that
+// server negotiates.
+type Serializer struct {
+	server     bool
+	negotiates atomic.Bool
+}
+
+// NewClient returns the serializer of a client.
+func NewClient() *Serializer {
+	return &Serializer{}
+}
+
+// NewServer returns the serializer of a server, used with ServerElement.
+func NewServer() *Serializer {
+	return &Serializer{server: true}
+}
+
+// reply is a server's response to be sent in a given codec, as chosen by
+// ServerElement.
+type reply struct {
+	msg   any
+	codec string
+}
+
+func (s *Serializer) Marshal(msg any) ([]byte, error) {
+	if r, ok := msg.(reply); ok {
+		return encode(byName[r.codec], r.msg, true)
+	}
+	c, tagged := byName[Symphony], false
+	if !s.server && s.negotiates.Load() {
+		c, tagged = byName[preferred.Get()], true
+	}
+	start := time.Now()
+	data, err := encode(c, msg, tagged)
+	if err == nil && !s.server {
+		recordStats(msg, Stats{Codec: c.name, Bytes: len(data), Duration: time.Since(start)})
+	}
+	return data, err
+}
+
+func encode(c codec, msg any, tagged bool) ([]byte, error) {
+	start := time.Now()
+	data, err := c.s.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	encodeTime.Add(c.name, int64(time.Since(start)))
+	encodedBytes.Add(c.name, int64(len(data)))
+	if !tagged {
+		return data, nil
+	}
+	return append([]byte{c.tag}, data...), nil
+}
+
+func (s *Serializer) Unmarshal(data []byte, out any) error {
+	c, payload := byName[Symphony], data
+	if len(data) > 0 && data[0] != legacyHeader {
+		var ok bool
+		if c, ok = byTag[data[0]]; !ok {
+			return fmt.Errorf("codec: unknown payload tag %#x", data[0])
+		}
+		s.negotiates.Store(true)
+		payload = data[1:]
+	}
+	start := time.Now()
+	if err := c.s.Unmarshal(payload, out); err != nil {
+		return err
+	}
+	recordStats(out, Stats{Codec: c.name, Bytes: len(data), Duration: time.Since(start)})
+	return nil
+}
+
+// ClientElement lists the codecs the client decodes on its calls.
+type ClientElement struct{}
+
+// NewClientElement creates a new client-side codec negotiation element.
+func NewClientElement() element.RPCElement {
+	return &ClientElement{}
+}
+
+func (e *ClientElement) Name() string {
+	return "client-codec"
+}
+
+func (e *ClientElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	if metadata.FromOutgoingContext(ctx).Get(AcceptKey) == "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, AcceptKey, strings.Join(names, ","))
+	}
+	return req, ctx, nil
+}
+
+func (e *ClientElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	return resp, ctx, nil
+}
+
+func (e *ClientElement) Close() error {
+	return nil
+}
+
+// ServerElement picks the codec of each reply from the client's list. It
+// must come first in the server's chain, so that the other elements see the
+// response before it is marked for encoding.
+type ServerElement struct{}
+
+// NewServerElement creates a new server-side codec negotiation element.
+func NewServerElement() element.RPCElement {
+	return &ServerElement{}
+}
+
+func (e *ServerElement) Name() string {
+	return "server-codec"
+}
+
+type replyCodecKey struct{}
+
+func (e *ServerElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	accept := metadata.FromIncomingContext(ctx).Get(AcceptKey)
+	if accept == "" {
+		return req, ctx, nil
+	}
+	name := Symphony
+	if p := preferred.Get(); slices.Contains(strings.Split(accept, ","), p) {
+		name = p
+	}
+	return req, context.WithValue(ctx, replyCodecKey{}, name), nil
+}
+
+func (e *ServerElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	if name, ok := ctx.Value(replyCodecKey{}).(string); ok && resp.Result != nil {
+		resp.Result = reply{msg: resp.Result, codec: name}
+	}
+	return resp, ctx, nil
+}
+
+func (e *ServerElement) Close() error {
+	return nil
+}
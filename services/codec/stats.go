@@ -0,0 +1,60 @@
+package codec
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats describe the encoding or decoding of one payload.
+type Stats struct {
+	Codec    string
+	Bytes    int
+	Duration time.Duration
+}
+
+// statsTTL bounds how long the stats of a payload are kept for TakeStats.
+// Payloads whose call failed before their stats were taken are dropped
+// after it.
+const statsTTL = time.Minute
+
+type statsEntry struct {
+	Stats
+	at time.Time
+}
+
+// payloadStats holds the stats of the payloads a client encoded and
+// decoded and of the requests a server decoded, by message, until the
+// tracing elements take them. Responses a server encodes are not kept: they
+// are encoded once the elements are done with the call.
+var payloadStats = struct {
+	sync.Mutex
+	m     map[any]statsEntry
+	swept time.Time
+}{m: make(map[any]statsEntry)}
+
+func recordStats(msg any, s Stats) {
+	now := time.Now()
+	payloadStats.Lock()
+	defer payloadStats.Unlock()
+	payloadStats.m[msg] = statsEntry{s, now}
+	if now.Sub(payloadStats.swept) > statsTTL {
+		for k, e := range payloadStats.m {
+			if now.Sub(e.at) > statsTTL {
+				delete(payloadStats.m, k)
+			}
+		}
+		payloadStats.swept = now
+	}
+}
+
+// TakeStats returns the stats of the last encoding or decoding of msg, which
+// must be the very value passed to the serializer, and forgets them.
+func TakeStats(msg any) (Stats, bool) {
+	payloadStats.Lock()
+	defer payloadStats.Unlock()
+	e, ok := payloadStats.m[msg]
+	if ok {
+		delete(payloadStats.m, msg)
+	}
+	return e.Stats, ok
+}
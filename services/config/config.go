@@ -0,0 +1,190 @@
+// Package config reads settings from an optional configuration file layered
+// over the environment, and reloads the file on SIGHUP so settings can change
+// without restarting the servers.
+//
+// The file is named by CONFIG_FILE and holds KEY=VALUE lines, the format of
+// an env file or of a ConfigMap rendered to one. Blank lines and lines
+// starting with # are ignored. Keys in the file take precedence over the
+// environment.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+)
+
+var (
+	loadOnce sync.Once
+
+	mu          sync.RWMutex
+	values      map[string]string
+	hooks       []func()
+	changeHooks []func(keys []string)
+)
+
+// Lookup returns the value of key from the configuration file or, if the
+// file does not set it, from the environment.
+func Lookup(key string) (string, bool) {
+	loadOnce.Do(func() {
+		if err := load(); err != nil {
+			log.Printf("config: ignoring configuration file: %v", err)
+		}
+	})
+	mu.RLock()
+	v, ok := values[key]
+	mu.RUnlock()
+	if ok {
+		return v, true
+	}
+	return os.LookupEnv(key)
+}
+
+// Get returns the value of key, or "" if it is not set.
+func Get(key string) string {
+	v, _ := Lookup(key)
+	return v
+}
+
+// OnReload registers fn to be called after every reload. Settings that are
+// read once at startup use it to pick up new values.
+func OnReload(fn func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	hooks = append(hooks, fn)
+}
+
+// OnChange registers fn to be called after every reload that changes the
+// file, with the keys set, changed or removed, sorted.
+func OnChange(fn func(keys []string)) {
+	mu.Lock()
+	defer mu.Unlock()
+	changeHooks = append(changeHooks, fn)
+}
+
+// WatchSignals reloads the configuration whenever the process receives
+// SIGHUP.
+func WatchSignals() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGHUP)
+	go func() {
+		for range sigs {
+			if err := Reload(); err != nil {
+				log.Printf("config: reload failed, keeping previous settings: %v", err)
+			}
+		}
+	}()
+}
+
+// Reload re-reads the configuration file and runs the reload hooks. If the
+// file cannot be read, the previous settings stay in effect.
+func Reload() error {
+	loadOnce.Do(func() {})
+	mu.RLock()
+	previous := values
+	mu.RUnlock()
+	if err := load(); err != nil {
+		return err
+	}
+	mu.RLock()
+	fns, changeFns := hooks, changeHooks
+	keys := changedKeys(previous, values)
+	mu.RUnlock()
+	for _, fn := range fns {
+		fn()
+	}
+	if len(keys) > 0 {
+		for _, fn := range changeFns {
+			fn(keys)
+		}
+	}
+	log.Printf("config: reloaded")
+	return nil
+}
+
+// changedKeys returns the keys whose values differ between a and b, sorted.
+func changedKeys(a, b map[string]string) []string {
+	var keys []string
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			keys = append(keys, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// load replaces the file values with the contents of CONFIG_FILE.
+func load() error {
+	name := os.Getenv("CONFIG_FILE")
+	if name == "" {
+		return nil
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	parsed := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", name, n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+		parsed[strings.TrimSpace(key)] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	mu.Lock()
+	values = parsed
+	mu.Unlock()
+	return nil
+}
+
+// Value is a setting that is loaded again on every reload. It is safe for
+// concurrent use.
+type Value[T any] struct {
+	v atomic.Pointer[T]
+}
+
+// NewValue returns a Value holding the result of load, which is called again
+// after every reload.
+func NewValue[T any](load func() T) *Value[T] {
+	v := &Value[T]{}
+	v.store(load())
+	OnReload(func() { v.store(load()) })
+	return v
+}
+
+// Get returns the current value.
+func (v *Value[T]) Get() T {
+	return *v.v.Load()
+}
+
+func (v *Value[T]) store(x T) {
+	v.v.Store(&x)
+}
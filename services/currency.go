@@ -1,31 +1,92 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
-	"math"
+	"math/big"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
 const (
-	filePath = "data/currency_conversion.json"
+	filePath           = "data/currency_conversion.json"
+	defaultSnapshotDir = "data/rate_snapshots"
+	snapshotDateLayout = "2006-01-02"
+	// snapshotLayout names a snapshot file after the time it was taken.
+	// Files named by snapshotDateLayout alone are read as taken at the
+	// start of that day.
+	snapshotLayout = "20060102T150405Z"
 )
 
 // CurrencyService implements the CurrencyService
 type CurrencyService struct {
-	port          int
-	conversionMap map[string]float64
+	port        int
+	ratesSource string
+
+	// ratesMu guards the rates and the supported currencies, which are
+	// replaced on a configuration reload.
+	ratesMu       sync.RWMutex
+	conversionMap map[string]*big.Rat
+	ratesUpdated  time.Time
+	ratesData     []byte
+
+	snapshotDir   string
+	snapshotMu    sync.Mutex
+	snapshotCache map[string]*rateSnapshot
+
+	// supported is the curated list returned by GetSupportedCurrencies.
+	supported []string
+
+	eventBusAddr string
+	bus          *eventbus.Bus
+}
+
+// defaultPopularCurrencies is the order the most used currencies are listed in.
+var defaultPopularCurrencies = []string{"USD", "EUR", "GBP", "JPY", "CAD"}
+
+// supportedCurrencies returns the codes of conversionMap that are in
+// allowlist, or all of them if allowlist is empty. Codes in popular come
+// first, in that order, and the rest follow alphabetically.
+func supportedCurrencies(conversionMap map[string]*big.Rat, allowlist, popular []string) []string {
+	codes := make([]string, 0, len(conversionMap))
+	for code := range conversionMap {
+		if len(allowlist) == 0 || slices.Contains(allowlist, code) {
+			codes = append(codes, code)
+		}
+	}
+	rank := func(code string) int {
+		if i := slices.Index(popular, code); i >= 0 {
+			return i
+		}
+		return len(popular)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if ri, rj := rank(codes[i]), rank(codes[j]); ri != rj {
+			return ri < rj
+		}
+		return codes[i] < codes[j]
+	})
+	return codes
 }
 
 // NewCurrencyService returns a new server for the CurrencyService
@@ -40,9 +101,23 @@ func NewCurrencyService(port int) *CurrencyService {
 	if err != nil {
 		return nil
 	}
+
+	snapshotDir := config.Get("CURRENCY_SNAPSHOT_DIR")
+	if snapshotDir == "" {
+		snapshotDir = defaultSnapshotDir
+	}
+
 	return &CurrencyService{
 		port:          port,
 		conversionMap: conversionMap,
+		ratesSource:   filePath,
+		ratesUpdated:  ratesModTime(),
+		ratesData:     currencyData,
+		snapshotDir:   snapshotDir,
+		snapshotCache: make(map[string]*rateSnapshot),
+		supported: supportedCurrencies(conversionMap,
+			envList("CURRENCY_ALLOWLIST", nil),
+			envList("CURRENCY_POPULAR", defaultPopularCurrencies)),
 	}
 }
 
@@ -53,85 +128,370 @@ func (s *CurrencyService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
-	serializer := &serializer.SymphonySerializer{}
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
+	serializer := codec.NewServer()
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
 	}
 
+	if err := s.writeSnapshot(time.Now()); err != nil {
+		log.Printf("failed to write rate snapshot: %v", err)
+	}
+
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
+	config.OnReload(s.reloadRates)
+
 	pb.RegisterCurrencyServiceServer(server, s)
 	log.Printf("CurrencyService running at port: %d", s.port)
 	server.Start()
 	return nil
 }
 
-// GetSupportedCurrencies returns a list of supported currency codes
-func (s *CurrencyService) GetSupportedCurrencies(ctx context.Context, req *pb.EmptyUser) (*pb.GetSupportedCurrenciesResponse, context.Context, error) {
-	log.Printf("GetSupportedCurrencies request received")
-	keys := make([]string, 0, len(s.conversionMap))
-	for k := range s.conversionMap {
-		keys = append(keys, k)
-	}
+// GetSupportedCurrencies returns the supported currency codes, popular ones first
+func (s *CurrencyService) GetSupportedCurrencies(ctx context.Context, req *pb.EmptyUser) (_ *pb.GetSupportedCurrenciesResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	s.ratesMu.RLock()
+	defer s.ratesMu.RUnlock()
 	return &pb.GetSupportedCurrenciesResponse{
-		CurrencyCodes: keys,
+		CurrencyCodes: s.supported,
 	}, ctx, nil
 }
 
+// ratesModTime returns when the rates file was last changed. The file
+// carries no timestamp of its own, so its mtime stands in.
+func ratesModTime() time.Time {
+	if fi, err := os.Stat(filePath); err == nil {
+		return fi.ModTime()
+	}
+	return time.Now()
+}
+
+// reloadRates re-reads the rates and the supported currencies after a
+// configuration reload. When they changed, the new rates are snapshotted
+// and a CurrencyUpdated event tells the caches of them to drop what they
+// hold.
+func (s *CurrencyService) reloadRates() {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		log.Printf("Failed to reload rates, keeping current ones: %v", err)
+		return
+	}
+	conversionMap, err := createConversionMap(data)
+	if err != nil {
+		log.Printf("Failed to reload rates, keeping current ones: %v", err)
+		return
+	}
+	supported := supportedCurrencies(conversionMap,
+		envList("CURRENCY_ALLOWLIST", nil),
+		envList("CURRENCY_POPULAR", defaultPopularCurrencies))
+
+	s.ratesMu.Lock()
+	changed := !bytes.Equal(data, s.ratesData) || !slices.Equal(supported, s.supported)
+	if changed {
+		s.conversionMap = conversionMap
+		s.ratesData = data
+		s.ratesUpdated = ratesModTime()
+		s.supported = supported
+	}
+	s.ratesMu.Unlock()
+	if !changed {
+		return
+	}
+
+	log.Printf("Reloaded rates for %d currencies, %d supported", len(conversionMap), len(supported))
+	if err := s.writeSnapshot(time.Now()); err != nil {
+		log.Printf("failed to write rate snapshot: %v", err)
+	}
+	err = s.bus.Publish(context.Background(), eventbus.TopicCurrencyUpdated, &pb.CurrencyUpdated{
+		CurrencyCodes: supported,
+		Reason:        "rates reloaded",
+	})
+	if err != nil {
+		log.Printf("Failed to publish currency update: %v", err)
+	}
+}
+
 // Convert converts an amount of money from one currency to another
-func (s *CurrencyService) Convert(ctx context.Context, req *pb.CurrencyConversionRequest) (*pb.Money, context.Context, error) {
-	log.Printf("Convert request: from = %v %v, to = %v", req.GetFrom().GetUnits(), req.GetFrom().GetCurrencyCode(), req.GetToCode())
+func (s *CurrencyService) Convert(ctx context.Context, req *pb.CurrencyConversionRequest) (_ *pb.CurrencyConversionResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
 	from := req.GetFrom()
 	toCode := req.GetToCode()
 
-	// Convert: from -> EUR
-	fromRate, ok := s.conversionMap[from.GetCurrencyCode()]
-	if !ok {
-		return nil, ctx, fmt.Errorf("unsupported currency code: %v", from.GetCurrencyCode())
+	s.ratesMu.RLock()
+	rate, err := lookupRate(s.conversionMap, from.GetCurrencyCode(), toCode)
+	s.ratesMu.RUnlock()
+	if err != nil {
+		return nil, ctx, err
+	}
+	to, err := convertMoney(from, rate, toCode)
+	if err != nil {
+		return nil, ctx, err
+	}
+
+	return &pb.CurrencyConversionResponse{
+		Money:       to,
+		AppliedRate: formatRate(rate),
+	}, ctx, nil
+}
+
+// GetExchangeRate returns the from -> to rate along with its source and age
+func (s *CurrencyService) GetExchangeRate(ctx context.Context, req *pb.ExchangeRateRequest) (_ *pb.ExchangeRateResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	s.ratesMu.RLock()
+	defer s.ratesMu.RUnlock()
+	rate, err := lookupRate(s.conversionMap, req.GetFromCode(), req.GetToCode())
+	if err != nil {
+		return nil, ctx, err
 	}
-	euros := carry(float64(from.GetUnits())/fromRate, float64(from.GetNanos())/fromRate)
 
-	// Convert: EUR -> toCode
-	toRate, ok := s.conversionMap[toCode]
+	return &pb.ExchangeRateResponse{
+		FromCode:    req.GetFromCode(),
+		ToCode:      req.GetToCode(),
+		Rate:        formatRate(rate),
+		Source:      s.ratesSource,
+		LastUpdated: s.ratesUpdated.Unix(),
+	}, ctx, nil
+}
+
+// RateAt returns the from -> to rate that was in effect at a given time, or
+// at the end of a given date: that of the last snapshot taken by then.
+func (s *CurrencyService) RateAt(ctx context.Context, req *pb.RateAtRequest) (_ *pb.ExchangeRateResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	at := time.Unix(req.GetAt(), 0)
+	if req.GetAt() == 0 {
+		date, err := time.Parse(snapshotDateLayout, req.GetDate())
+		if err != nil {
+			return nil, ctx, fmt.Errorf("invalid date %q: %v", req.GetDate(), err)
+		}
+		at = date.Add(24*time.Hour - time.Second)
+	}
+	snap, err := s.snapshotAt(at)
+	if err != nil {
+		return nil, ctx, err
+	}
+	rate, err := lookupRate(snap.rates, req.GetFromCode(), req.GetToCode())
+	if err != nil {
+		return nil, ctx, err
+	}
+
+	return &pb.ExchangeRateResponse{
+		FromCode:    req.GetFromCode(),
+		ToCode:      req.GetToCode(),
+		Rate:        formatRate(rate),
+		Source:      snap.path,
+		LastUpdated: snap.taken.Unix(),
+	}, ctx, nil
+}
+
+// lookupRate returns the from -> to rate from a EUR based conversion map.
+func lookupRate(conv map[string]*big.Rat, fromCode, toCode string) (*big.Rat, error) {
+	fromRate, ok := conv[fromCode]
+	if !ok {
+		return nil, fmt.Errorf("unsupported currency code: %v", fromCode)
+	}
+	toRate, ok := conv[toCode]
 	if !ok {
-		return nil, ctx, fmt.Errorf("unsupported currency code: %v", toCode)
+		return nil, fmt.Errorf("unsupported currency code: %v", toCode)
 	}
-	to := carry(float64(euros.Units)*toRate, float64(euros.Nanos)*toRate)
-	to.CurrencyCode = toCode
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+// MinorUnits lists the ISO 4217 exponent for currencies that do not use two
+// decimal places. Every service that rounds or formats money goes by it.
+var MinorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0,
+	"KMF": 0, "KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0,
+	"VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+
+	"CLF": 4, "UYW": 4,
+}
+
+// MinorUnitsOf returns the number of decimal places used by a currency.
+func MinorUnitsOf(currencyCode string) int {
+	if n, ok := MinorUnits[currencyCode]; ok {
+		return n
+	}
+	return 2
+}
+
+// minorUnitNanos returns the nanos in one minor unit of a currency.
+func minorUnitNanos(currencyCode string) int64 {
+	n := int64(1)
+	for range 9 - MinorUnitsOf(currencyCode) {
+		n *= 10
+	}
+	return n
+}
 
+// convertMoney applies rate to m and rounds the result half away from zero
+// to the minor unit of toCode. All arithmetic is exact; it fails if the
+// result does not fit in a Money.
+func convertMoney(m *pb.Money, rate *big.Rat, toCode string) (*pb.Money, error) {
+	const nanosPerUnit = 1000000000
+
+	nanos := new(big.Int).Mul(big.NewInt(m.GetUnits()), big.NewInt(nanosPerUnit))
+	nanos.Add(nanos, big.NewInt(int64(m.GetNanos())))
+	amount := new(big.Rat).SetInt(nanos)
+	amount.Mul(amount, rate)
+
+	// Scale to minor units, round, then scale back to nanos.
+	step := big.NewInt(minorUnitNanos(toCode))
+	amount.Quo(amount, new(big.Rat).SetInt(step))
+	minor := roundHalfAway(amount)
+	minor.Mul(minor, step)
+
+	units, rem := new(big.Int).QuoRem(minor, big.NewInt(nanosPerUnit), new(big.Int))
+	if !units.IsInt64() {
+		return nil, fmt.Errorf("%d %s is out of range once converted to %s", m.GetUnits(), m.GetCurrencyCode(), toCode)
+	}
 	return &pb.Money{
-		CurrencyCode: to.CurrencyCode,
-		Units:        to.Units,
-		Nanos:        to.Nanos,
-	}, ctx, nil
+		CurrencyCode: toCode,
+		Units:        units.Int64(),
+		Nanos:        int32(rem.Int64()),
+	}, nil
 }
 
-// carry handles decimal/fractional carrying for currency conversions.
-func carry(units float64, nanos float64) pb.Money {
-	const fractionSize = 1000000000 // 1B
-	nanos += math.Mod(units, 1.0) * fractionSize
-	units = math.Floor(units) + math.Floor(nanos/fractionSize)
-	nanos = math.Mod(nanos, fractionSize)
-	return pb.Money{
-		Units: int64(units),
-		Nanos: int32(nanos),
+// roundHalfAway rounds r to the nearest integer, with ties away from zero.
+func roundHalfAway(r *big.Rat) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	den := r.Denom()
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(den) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
 	}
+	return q
+}
+
+// formatRate renders a rate as a decimal string with trailing zeros removed.
+func formatRate(rate *big.Rat) string {
+	s := rate.FloatString(9)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
 }
 
-// createConversionMap parses the currency conversion JSON data.
-func createConversionMap(currencyData []byte) (map[string]float64, error) {
+// createConversionMap parses the currency conversion JSON data. Rates are
+// kept as exact rationals so conversions never pass through float64.
+func createConversionMap(currencyData []byte) (map[string]*big.Rat, error) {
 	m := map[string]string{}
 	if err := json.Unmarshal(currencyData, &m); err != nil {
 		return nil, err
 	}
-	conv := make(map[string]float64, len(m))
+	conv := make(map[string]*big.Rat, len(m))
 	for k, v := range m {
-		f, err := strconv.ParseFloat(v, 64)
-		if err != nil {
-			return nil, err
+		r, ok := new(big.Rat).SetString(v)
+		if !ok || r.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate for %s: %q", k, v)
 		}
-		conv[k] = f
+		conv[k] = r
 	}
 	return conv, nil
 }
+
+// rateSnapshot is the set of conversion rates in effect from the time it was
+// taken until the next one.
+type rateSnapshot struct {
+	taken time.Time
+	path  string
+	rates map[string]*big.Rat
+}
+
+// snapshotTime returns when the snapshot in the file name was taken.
+func snapshotTime(name string) (time.Time, bool) {
+	stem, ok := strings.CutSuffix(name, ".json")
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{snapshotLayout, snapshotDateLayout} {
+		if t, err := time.Parse(layout, stem); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// latestSnapshot returns the name of the last snapshot taken at or before
+// at, if any.
+func (s *CurrencyService) latestSnapshot(at time.Time) (string, time.Time, error) {
+	entries, err := os.ReadDir(s.snapshotDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", time.Time{}, fmt.Errorf("failed to read rate snapshots: %v", err)
+	}
+	var name string
+	var taken time.Time
+	for _, e := range entries {
+		t, ok := snapshotTime(e.Name())
+		if e.IsDir() || !ok || t.After(at) || t.Before(taken) {
+			continue
+		}
+		name, taken = e.Name(), t
+	}
+	return name, taken, nil
+}
+
+// writeSnapshot records the current rates as in effect from now on, unless
+// they are those of the last snapshot already.
+func (s *CurrencyService) writeSnapshot(now time.Time) error {
+	if err := os.MkdirAll(s.snapshotDir, 0o755); err != nil {
+		return err
+	}
+	s.ratesMu.RLock()
+	data := s.ratesData
+	s.ratesMu.RUnlock()
+
+	last, _, err := s.latestSnapshot(now)
+	if err != nil {
+		return err
+	}
+	if last != "" {
+		if prev, err := os.ReadFile(filepath.Join(s.snapshotDir, last)); err == nil && bytes.Equal(prev, data) {
+			return nil
+		}
+	}
+	path := filepath.Join(s.snapshotDir, now.UTC().Format(snapshotLayout)+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	log.Printf("Writing rate snapshot %s", path)
+	return os.Rename(tmp, path)
+}
+
+// snapshotAt loads the last snapshot taken at or before at.
+func (s *CurrencyService) snapshotAt(at time.Time) (*rateSnapshot, error) {
+	name, taken, err := s.latestSnapshot(at)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no rate snapshot at or before %s", at.UTC().Format(time.RFC3339))
+	}
+
+	s.snapshotMu.Lock()
+	defer s.snapshotMu.Unlock()
+	if snap, ok := s.snapshotCache[name]; ok {
+		return snap, nil
+	}
+	path := filepath.Join(s.snapshotDir, name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := createConversionMap(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	snap := &rateSnapshot{taken: taken, path: path, rates: rates}
+	s.snapshotCache[name] = snap
+	return snap, nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"os"
+	"testing"
+	"testing/quick"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// testCurrencies covers every exponent in MinorUnits, and the default.
+var testCurrencies = []string{"USD", "EUR", "JPY", "KRW", "BHD", "KWD", "JOD", "OMR", "TND", "CLF"}
+
+// exactNanos returns m as a number of nanos.
+func exactNanos(m *pb.Money) *big.Rat {
+	n := new(big.Int).Mul(big.NewInt(m.GetUnits()), big.NewInt(nanosMod))
+	return new(big.Rat).SetInt(n.Add(n, big.NewInt(int64(m.GetNanos()))))
+}
+
+// halfStep returns half a minor unit of currencyCode, in nanos.
+func halfStep(currencyCode string) *big.Rat {
+	return big.NewRat(minorUnitNanos(currencyCode), 2)
+}
+
+// testAmount builds a valid Money from arbitrary values.
+func testAmount(code string, units int32, nanos uint32) *pb.Money {
+	m := &pb.Money{CurrencyCode: code, Units: int64(units), Nanos: int32(nanos % nanosMod)}
+	if m.Units < 0 {
+		m.Nanos = -m.Nanos
+	}
+	return m
+}
+
+// testRate builds a positive rate from arbitrary values.
+func testRate(num, den uint16) *big.Rat {
+	return big.NewRat(int64(num)+1, int64(den)+1)
+}
+
+func TestConvertMoneyPrecision(t *testing.T) {
+	f := func(units int32, nanos uint32, num, den uint16, from, to uint8) bool {
+		m := testAmount(testCurrencies[int(from)%len(testCurrencies)], units, nanos)
+		rate := testRate(num, den)
+		toCode := testCurrencies[int(to)%len(testCurrencies)]
+
+		got, err := convertMoney(m, rate, toCode)
+		if err != nil {
+			t.Logf("convert %v at %v: %v", m, rate, err)
+			return false
+		}
+		if !IsValid(got) || got.GetCurrencyCode() != toCode {
+			t.Logf("convert %v at %v: invalid result %v", m, rate, got)
+			return false
+		}
+		// The result is whole minor units, within half of one of the exact
+		// amount.
+		if int64(got.GetNanos())%minorUnitNanos(toCode) != 0 {
+			t.Logf("convert %v at %v: %v is not in minor units of %s", m, rate, got, toCode)
+			return false
+		}
+		exact := exactNanos(m)
+		exact.Mul(exact, rate)
+		diff := new(big.Rat).Sub(exactNanos(got), exact)
+		if diff.Abs(diff).Cmp(halfStep(toCode)) > 0 {
+			t.Logf("convert %v at %v: %v is off by %s nanos", m, rate, got, diff.FloatString(3))
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConvertMoneyRoundTrip(t *testing.T) {
+	f := func(units int32, nanos uint32, num, den uint16, from, to uint8) bool {
+		fromCode := testCurrencies[int(from)%len(testCurrencies)]
+		toCode := testCurrencies[int(to)%len(testCurrencies)]
+		m, err := convertMoney(testAmount(fromCode, units, nanos), big.NewRat(1, 1), fromCode)
+		if err != nil {
+			return false
+		}
+		rate := testRate(num, den)
+
+		there, err := convertMoney(m, rate, toCode)
+		if err != nil {
+			return false
+		}
+		back, err := convertMoney(there, new(big.Rat).Inv(rate), fromCode)
+		if err != nil {
+			return false
+		}
+		// Each way rounds by at most half a minor unit, the first one scaled
+		// back by the rate.
+		bound := new(big.Rat).Quo(halfStep(toCode), rate)
+		bound.Add(bound, halfStep(fromCode))
+		diff := new(big.Rat).Sub(exactNanos(back), exactNanos(m))
+		if diff.Abs(diff).Cmp(bound) > 0 {
+			t.Logf("%v -> %v -> %v at %v is off by %s nanos", m, there, back, rate, diff.FloatString(3))
+			return false
+		}
+		// An amount in minor units is kept as is at a rate of one.
+		if same, err := convertMoney(m, big.NewRat(1, 1), fromCode); err != nil || !sameMoney(same, m) {
+			t.Logf("%v -> %v at a rate of one", m, same)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: 5000}); err != nil {
+		t.Error(err)
+	}
+}
+
+func sameMoney(a, b *pb.Money) bool {
+	return a.GetCurrencyCode() == b.GetCurrencyCode() && a.GetUnits() == b.GetUnits() && a.GetNanos() == b.GetNanos()
+}
+
+func TestConvertMoneyMinorUnits(t *testing.T) {
+	for _, tc := range []struct {
+		from *pb.Money
+		rate *big.Rat
+		to   string
+		want *pb.Money
+	}{
+		{&pb.Money{CurrencyCode: "USD", Units: 1, Nanos: 234500000}, big.NewRat(1, 1), "KWD", &pb.Money{CurrencyCode: "KWD", Units: 1, Nanos: 235000000}},
+		{&pb.Money{CurrencyCode: "USD", Units: 10}, big.NewRat(3, 8), "BHD", &pb.Money{CurrencyCode: "BHD", Units: 3, Nanos: 750000000}},
+		{&pb.Money{CurrencyCode: "USD", Units: -1, Nanos: -5}, big.NewRat(1, 3), "TND", &pb.Money{CurrencyCode: "TND", Units: 0, Nanos: -333000000}},
+		{&pb.Money{CurrencyCode: "USD", Units: 1, Nanos: 500000000}, big.NewRat(1, 1), "JPY", &pb.Money{CurrencyCode: "JPY", Units: 2}},
+		{&pb.Money{CurrencyCode: "USD", Units: 1, Nanos: 234560000}, big.NewRat(1, 1), "CLF", &pb.Money{CurrencyCode: "CLF", Units: 1, Nanos: 234600000}},
+	} {
+		got, err := convertMoney(tc.from, tc.rate, tc.to)
+		if err != nil {
+			t.Errorf("convert %v at %v to %s: %v", tc.from, tc.rate, tc.to, err)
+			continue
+		}
+		if !sameMoney(got, tc.want) {
+			t.Errorf("convert %v at %v to %s: got %v, want %v", tc.from, tc.rate, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestConvertMoneyOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		from *pb.Money
+		rate *big.Rat
+	}{
+		{&pb.Money{CurrencyCode: "USD", Units: math.MaxInt64}, big.NewRat(2, 1)},
+		{&pb.Money{CurrencyCode: "USD", Units: math.MinInt64}, big.NewRat(2, 1)},
+		{&pb.Money{CurrencyCode: "USD", Units: math.MaxInt64 / 100}, big.NewRat(1000, 1)},
+	} {
+		if got, err := convertMoney(tc.from, tc.rate, "JPY"); err == nil {
+			t.Errorf("convert %v at %v: got %v, want an error", tc.from, tc.rate, got)
+		}
+	}
+	if _, err := convertMoney(&pb.Money{CurrencyCode: "USD", Units: math.MaxInt64}, big.NewRat(1, 1), "JPY"); err != nil {
+		t.Errorf("convert the largest amount at a rate of one: %v", err)
+	}
+}
+
+func TestRateAtFollowsReloads(t *testing.T) {
+	s := &CurrencyService{
+		snapshotDir:   t.TempDir(),
+		snapshotCache: make(map[string]*rateSnapshot),
+		ratesData:     []byte(`{"EUR": "1.0", "USD": "1.1"}`),
+	}
+	placed := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	reloaded := placed.Add(3 * time.Hour)
+	for _, step := range []struct {
+		at    time.Time
+		rates string
+	}{
+		{placed, `{"EUR": "1.0", "USD": "1.1"}`},
+		// A reload that changes nothing takes no snapshot.
+		{placed.Add(time.Hour), `{"EUR": "1.0", "USD": "1.1"}`},
+		{reloaded, `{"EUR": "1.0", "USD": "1.2"}`},
+	} {
+		s.ratesData = []byte(step.rates)
+		if err := s.writeSnapshot(step.at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if entries, _ := os.ReadDir(s.snapshotDir); len(entries) != 2 {
+		t.Errorf("got %d snapshots, want 2", len(entries))
+	}
+
+	for _, tc := range []struct {
+		req  *pb.RateAtRequest
+		want string
+	}{
+		{&pb.RateAtRequest{At: placed.Unix()}, "1.1"},
+		{&pb.RateAtRequest{At: reloaded.Add(-time.Second).Unix()}, "1.1"},
+		{&pb.RateAtRequest{At: reloaded.Unix()}, "1.2"},
+		// A date gives the last rate of the day.
+		{&pb.RateAtRequest{Date: "2026-03-02"}, "1.2"},
+		{&pb.RateAtRequest{At: placed.Add(-time.Second).Unix()}, ""},
+	} {
+		tc.req.FromCode, tc.req.ToCode = "EUR", "USD"
+		resp, _, err := s.RateAt(context.Background(), tc.req)
+		if tc.want == "" {
+			if err == nil {
+				t.Errorf("RateAt(%v) = %s, want no rate before the first snapshot", tc.req, resp.GetRate())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("RateAt(%v): %v", tc.req, err)
+			continue
+		}
+		if resp.GetRate() != tc.want {
+			t.Errorf("RateAt(%v) = %s, want %s", tc.req, resp.GetRate(), tc.want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Package discovery resolves logical service names, such as "cart", to the
+// addresses of their ready replicas using the Kubernetes API or Consul.
+package discovery
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appnetorg/online-boutique-arpc/services/resolver"
+)
+
+// Target schemes understood by Lookup.
+const (
+	SchemeKubernetes = "k8s"
+	SchemeConsul     = "consul"
+)
+
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+var httpClient = &http.Client{Timeout: 5 * time.Second}
+
+// Target returns the discovery target for service under scheme, e.g.
+// "k8s:///cart".
+func Target(scheme, service string) string {
+	return scheme + ":///" + service
+}
+
+// IsTarget reports whether target is handled by this package.
+func IsTarget(target string) bool {
+	return strings.HasPrefix(target, SchemeKubernetes+":///") || strings.HasPrefix(target, SchemeConsul+":///")
+}
+
+// Lookup returns a function that resolves target, which must satisfy IsTarget.
+// A Kubernetes target may name a port, as in "k8s:///cart:arpc-cart";
+// otherwise the first port of the endpoints is used.
+func Lookup(target string) (resolver.LookupFunc, error) {
+	scheme, name, ok := strings.Cut(target, ":///")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid discovery target %q", target)
+	}
+	switch scheme {
+	case SchemeKubernetes:
+		service, port, _ := strings.Cut(name, ":")
+		k, err := newKubernetes()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) ([]string, error) {
+			return k.endpoints(ctx, service, port)
+		}, nil
+	case SchemeConsul:
+		addr := os.Getenv("CONSUL_HTTP_ADDR")
+		if addr == "" {
+			addr = "consul:8500"
+		}
+		return func(ctx context.Context) ([]string, error) {
+			return consulEndpoints(ctx, addr, name)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown discovery scheme %q", scheme)
+	}
+}
+
+// kubernetes reads Endpoints objects through the in-cluster API server.
+type kubernetes struct {
+	host      string
+	namespace string
+	token     string
+	client    *http.Client
+}
+
+func newKubernetes() (*kubernetes, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("kubernetes discovery: not running in a cluster")
+	}
+	token, err := os.ReadFile(serviceAccountDir + "/token")
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes discovery: %w", err)
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes discovery: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("kubernetes discovery: no certificates in ca.crt")
+	}
+	namespace := os.Getenv("POD_NAMESPACE")
+	if namespace == "" {
+		ns, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("kubernetes discovery: %w", err)
+		}
+		namespace = strings.TrimSpace(string(ns))
+	}
+	return &kubernetes{
+		host:      net.JoinHostPort(host, port),
+		namespace: namespace,
+		token:     strings.TrimSpace(string(token)),
+		client: &http.Client{
+			Timeout:   httpClient.Timeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+	}, nil
+}
+
+// endpointsObject is the subset of a v1 Endpoints object that is needed.
+type endpointsObject struct {
+	Subsets []struct {
+		Addresses []struct {
+			IP string `json:"ip"`
+		} `json:"addresses"`
+		Ports []struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		} `json:"ports"`
+	} `json:"subsets"`
+}
+
+// endpoints returns the ready addresses of service on the named port.
+func (k *kubernetes) endpoints(ctx context.Context, service, portName string) ([]string, error) {
+	u := fmt.Sprintf("https://%s/api/v1/namespaces/%s/endpoints/%s", k.host, url.PathEscape(k.namespace), url.PathEscape(service))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+k.token)
+
+	var obj endpointsObject
+	if err := getJSON(k.client, req, &obj); err != nil {
+		return nil, fmt.Errorf("kubernetes endpoints %s/%s: %w", k.namespace, service, err)
+	}
+
+	var addrs []string
+	for _, subset := range obj.Subsets {
+		port := 0
+		for _, p := range subset.Ports {
+			if portName == "" || p.Name == portName {
+				port = p.Port
+				break
+			}
+		}
+		if port == 0 {
+			continue
+		}
+		for _, a := range subset.Addresses {
+			addrs = append(addrs, net.JoinHostPort(a.IP, strconv.Itoa(port)))
+		}
+	}
+	return addrs, nil
+}
+
+// consulEntry is the subset of a Consul health API entry that is needed.
+type consulEntry struct {
+	Node struct {
+		Address string `json:"Address"`
+	} `json:"Node"`
+	Service struct {
+		Address string `json:"Address"`
+		Port    int    `json:"Port"`
+	} `json:"Service"`
+}
+
+// consulEndpoints returns the addresses of the instances of service that
+// pass their health checks.
+func consulEndpoints(ctx context.Context, consulAddr, service string) ([]string, error) {
+	u := fmt.Sprintf("http://%s/v1/health/service/%s?passing=true", consulAddr, url.PathEscape(service))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := os.Getenv("CONSUL_HTTP_TOKEN"); token != "" {
+		req.Header.Set("X-Consul-Token", token)
+	}
+
+	var entries []consulEntry
+	if err := getJSON(httpClient, req, &entries); err != nil {
+		return nil, fmt.Errorf("consul service %s: %w", service, err)
+	}
+
+	addrs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		host := e.Service.Address
+		if host == "" {
+			host = e.Node.Address
+		}
+		addrs = append(addrs, net.JoinHostPort(host, strconv.Itoa(e.Service.Port)))
+	}
+	return addrs, nil
+}
+
+func getJSON(client *http.Client, req *http.Request, v any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
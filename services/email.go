@@ -3,27 +3,43 @@ package services
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"log"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/receipt"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
-// Embed the HTML template for the email
+// HTML templates for the emails, one file per kind of email
 var (
 	tmpl = template.Must(template.New("email").
 		Funcs(template.FuncMap{
-			"div": func(x, y int32) int32 { return x / y },
+			"add":               func(x, y int) int { return x + y },
+			"div":               func(x, y int32) int32 { return x / y },
+			"renderMoney":       renderMoney,
+			"renderMoneyOrFree": renderMoneyOrFree,
+			"renderDiscount":    renderDiscount,
+			"T":                 translations.T,
 		}).
-		Parse("./templates/email.html"))
+		ParseGlob("templates/email/*.html"))
 )
 
 // NewEmailService returns a new server for the EmailService
@@ -36,6 +52,30 @@ func NewEmailService(port int) *EmailService {
 // EmailService implements the EmailService
 type EmailService struct {
 	port int
+
+	eventBusAddr string
+	bus          *eventbus.Bus
+
+	// Confirmed orders are kept in Redis so their receipts can be
+	// downloaded later.
+	rdb        redis.UniversalClient
+	receiptTTL time.Duration
+
+	// Emails are queued and sent in the background, see emailQueue.
+	queue *emailQueue
+
+	// Dedupe keys of sent emails are kept for dedupeTTL, so that an email
+	// requested or triggered more than once in that time is sent once.
+	dedupeTTL time.Duration
+}
+
+// receiptRecord is what is kept of a confirmed order to render its receipt.
+type receiptRecord struct {
+	Order  *pb.OrderResult `json:"order"`
+	Locale string          `json:"locale"`
+	// UserID is the shopper who placed the order; only they may download
+	// the receipt.
+	UserID string `json:"user_id"`
 }
 
 // Run starts the server
@@ -45,36 +85,327 @@ func (s *EmailService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
-	serializer := &serializer.SymphonySerializer{}
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
+	s.rdb = newRedisClient("EMAIL")
+	s.receiptTTL = envDuration("RECEIPT_TTL", 90*24*time.Hour)
+	s.dedupeTTL = envDuration("EMAIL_DEDUPE_TTL", 7*24*time.Hour)
+
+	checker := newStartupChecker()
+	checker.Add("redis", func(ctx context.Context) error {
+		return s.rdb.Ping(ctx).Err()
+	})
+	mustCheckStartup(checker)
+
+	if s.queue, err = newEmailQueue(); err != nil {
+		log.Fatalf("Failed to start email queue: %v", err)
+	}
+	go s.bus.Subscribe(context.Background(), eventbus.TopicShipmentStatusChanged, s.handleShipmentStatusChanged)
+	go s.bus.Subscribe(context.Background(), eventbus.TopicProductRestocked, s.handleProductRestocked)
+	go s.bus.Subscribe(context.Background(), eventbus.TopicOrderStatusChanged, s.handleOrderStatusChanged)
+	go s.bus.Subscribe(context.Background(), eventbus.TopicCartAbandoned, s.handleCartAbandoned)
+
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
+	serializer := codec.NewServer()
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
 	}
 
 	pb.RegisterEmailServiceServer(server, s)
+	pb.RegisterPrivacyServiceServer(server, s)
 	log.Printf("EmailService running at port: %d", s.port)
 	server.Start()
 	return nil
 }
 
-// SendOrderConfirmation sends an order confirmation email
-func (s *EmailService) SendOrderConfirmation(ctx context.Context, req *pb.SendOrderConfirmationRequest) (*pb.Empty, context.Context, error) {
-	log.Printf("SendOrderConfirmation request received for email = %v", req.GetEmail())
+// SendOrderConfirmation sends an order confirmation email. A request with
+// the dedupe key of one already sent is acknowledged and dropped.
+func (s *EmailService) SendOrderConfirmation(ctx context.Context, req *pb.SendOrderConfirmationRequest) (_ *pb.Empty, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if !s.claimSend(ctx, req.GetDedupeKey()) {
+		return &pb.Empty{}, ctx, nil
+	}
+	defer func() {
+		if err != nil {
+			s.releaseSend(ctx, req.GetDedupeKey())
+		}
+	}()
+
+	lang := emailLanguage(req.GetLocale())
 
 	// Generate email content using the template
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, req.GetOrder()); err != nil {
+	err = tmpl.ExecuteTemplate(&buf, "confirmation.html", struct {
+		Lang       string
+		Order      *pb.OrderResult
+		ReceiptURL string
+	}{lang, req.GetOrder(), receiptURL(req.GetOrder().GetOrderId())})
+	if err != nil {
 		log.Printf("Error executing template: %v", err)
 		return nil, ctx, err
 	}
 	confirmation := buf.String()
 
-	// Simulate sending the email
-	log.Printf("Order confirmation email content for %v:\n%s", req.GetEmail(), confirmation)
+	// Keep the order for later receipt downloads. The email goes out even
+	// if it cannot be kept, with the receipt attached.
+	record, err := json.Marshal(receiptRecord{Order: req.GetOrder(), Locale: lang, UserID: usercontext.FromContext(ctx)})
+	if err == nil {
+		err = s.saveReceipt(ctx, req.GetEmail(), req.GetOrder().GetOrderId(), record)
+	}
+	if err != nil {
+		log.Printf("Failed to save receipt of order %v: %v", req.GetOrder().GetOrderId(), err)
+	}
+	pdf := renderReceipt(lang, req.GetOrder())
+	s.recordContact(ctx, req.GetEmail(), lang)
 
-	// Replace this with actual email-sending logic if needed
-	log.Printf("Order confirmation email sent to %v", req.GetEmail())
+	err = s.queue.enqueue(&outgoingEmail{
+		priority:       emailTransactional,
+		desc:           "Order confirmation",
+		to:             req.GetEmail(),
+		subject:        translations.T(lang, "email.subject"),
+		body:           confirmation,
+		attachment:     receiptFilename(req.GetOrder().GetOrderId()),
+		attachmentSize: len(pdf),
+	})
+	if err != nil {
+		return nil, ctx, status.Errorf(codes.ResourceExhausted, "failed to queue confirmation email: %v", err)
+	}
 
 	return &pb.Empty{}, ctx, nil
 }
+
+// GetReceipt renders the receipt of an order confirmed by this service, in
+// the language of its confirmation email. The receipts of other shoppers'
+// orders are not found.
+func (s *EmailService) GetReceipt(ctx context.Context, req *pb.GetReceiptRequest) (_ *pb.GetReceiptResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	data, err := s.rdb.Get(ctx, receiptKey(ctx, req.GetOrderId())).Bytes()
+	if err == redis.Nil {
+		return nil, ctx, status.Errorf(codes.NotFound, "no receipt for order %q", req.GetOrderId())
+	}
+	if err != nil {
+		return nil, ctx, err
+	}
+	var record receiptRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, ctx, err
+	}
+	if userID := usercontext.FromContext(ctx); userID == "" || record.UserID != userID {
+		return nil, ctx, status.Errorf(codes.NotFound, "no receipt for order %q", req.GetOrderId())
+	}
+	return &pb.GetReceiptResponse{Pdf: renderReceipt(record.Locale, record.Order)}, ctx, nil
+}
+
+func receiptKey(ctx context.Context, orderID string) string {
+	return tenant.Key(ctx, "receipt:"+orderID)
+}
+
+// receiptURL returns where the receipt of an order can be downloaded from
+// the storefront at STOREFRONT_URL.
+func receiptURL(orderID string) string {
+	return strings.TrimSuffix(config.Get("STOREFRONT_URL"), "/") + "/orders/" + orderID + "/receipt"
+}
+
+func receiptFilename(orderID string) string {
+	return "receipt-" + orderID + ".pdf"
+}
+
+// renderReceipt returns the receipt of order as a PDF. Languages whose text
+// the receipt font cannot show fall back to the default language.
+func renderReceipt(lang string, order *pb.OrderResult) string {
+	if !receipt.Encodable(translations.T(lang, "receipt.title")) {
+		lang = defaultLanguage
+	}
+	amount := func(m *pb.Money) string {
+		return formatAmount(m) + " " + m.GetCurrencyCode()
+	}
+	row := func(label, value string) string {
+		return fmt.Sprintf("%-44s %26s", label, value)
+	}
+
+	title := translations.T(lang, "receipt.title")
+	lines := []string{
+		title,
+		"",
+		row(translations.T(lang, "email.order_id"), order.GetOrderId()),
+		row(translations.T(lang, "email.tracking"), order.GetShippingTrackingId()),
+		"",
+		row(translations.T(lang, "email.items"), translations.T(lang, "email.cost")),
+		strings.Repeat("-", 71),
+	}
+	for _, it := range order.GetItems() {
+		item := it.GetItem()
+		label := fmt.Sprintf("%d x %s", item.GetQuantity(), item.GetProductId())
+		if item.GetVariantId() != "" {
+			label += " (" + item.GetVariantId() + ")"
+		}
+		lines = append(lines, row(label, amount(MultiplySlow(it.GetCost(), uint32(item.GetQuantity())))))
+	}
+	lines = append(lines, strings.Repeat("-", 71))
+	amountOrFree := func(m *pb.Money) string {
+		if m != nil && IsZero(m) {
+			return translations.T(lang, "money.free")
+		}
+		return amount(m)
+	}
+	if b := order.GetBreakdown(); b != nil {
+		discount := b.GetDiscount()
+		if !IsZero(discount) {
+			neg := Negate(discount)
+			discount = &neg
+		}
+		lines = append(lines,
+			row(translations.T(lang, "email.items_subtotal"), amount(b.GetItems())),
+			row(translations.T(lang, "email.shipping_cost"), amountOrFree(b.GetShipping())),
+		)
+		if b.GetGiftWrap() != nil {
+			lines = append(lines, row(translations.T(lang, "email.gift_wrap"), amount(b.GetGiftWrap())))
+		}
+		lines = append(lines,
+			row(translations.T(lang, "email.tax"), amount(b.GetTax())),
+			row(translations.T(lang, "email.discount"), amount(discount)),
+		)
+		for _, adj := range b.GetAdjustments().GetAdjustments() {
+			off := Negate(adj.GetAmount())
+			lines = append(lines, row("  "+adj.GetDescription(), amount(&off)))
+		}
+		lines = append(lines, row(translations.T(lang, "email.total"), amount(b.GetTotal())))
+	} else {
+		lines = append(lines, row(translations.T(lang, "email.shipping_cost"), amountOrFree(order.GetShippingCost())))
+	}
+	return receipt.PDF(title, lines)
+}
+
+// handleShipmentStatusChanged sends a "your order shipped" email when the
+// carrier picks up a shipment.
+func (s *EmailService) handleShipmentStatusChanged(payload []byte) error {
+	var event pb.ShipmentStatusChanged
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	shipment := event.GetShipment()
+	if shipment.GetStatus() != "PICKED_UP" || event.GetEmail() == "" {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+	key := dedupeKey(shipment.GetTrackingId(), emailShipped)
+	if !s.claimSend(ctx, key) {
+		return nil
+	}
+
+	lang := emailLanguage(event.GetLocale())
+	var buf bytes.Buffer
+	err := tmpl.ExecuteTemplate(&buf, "shipped.html", struct {
+		Lang     string
+		Shipment *pb.Shipment
+	}{lang, shipment})
+	if err != nil {
+		s.releaseSend(ctx, key)
+		return err
+	}
+
+	err = s.queue.enqueue(&outgoingEmail{
+		priority: emailTransactional,
+		desc:     "Shipping notification",
+		to:       event.GetEmail(),
+		subject:  translations.T(lang, "email.shipped_subject"),
+		body:     buf.String(),
+	})
+	if err != nil {
+		s.releaseSend(ctx, key)
+	}
+	return err
+}
+
+// orderStatusEmails gives the subject and body keys of the email sent when
+// an order moves to a status, for those that send one.
+var orderStatusEmails = map[string][2]string{
+	orderCancelled: {"email.cancelled_subject", "email.cancelled_body"},
+	orderRefunded:  {"email.refunded_subject", "email.refunded_body"},
+}
+
+// handleOrderStatusChanged lets the customer know when their order is
+// cancelled or refunded. Orders cancelled while still pending were never
+// confirmed to them, so those are left alone.
+func (s *EmailService) handleOrderStatusChanged(payload []byte) error {
+	var event pb.OrderStatusChanged
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	keys, ok := orderStatusEmails[event.GetStatus().GetStatus()]
+	if !ok || event.GetPreviousStatus() == orderPending || event.GetEmail() == "" {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+	key := dedupeKey(event.GetStatus().GetOrderId(), emailOrderStatus+":"+event.GetStatus().GetStatus())
+	if !s.claimSend(ctx, key) {
+		return nil
+	}
+
+	lang := emailLanguage(event.GetLocale())
+	var buf bytes.Buffer
+	err := tmpl.ExecuteTemplate(&buf, "order_status.html", struct {
+		Lang    string
+		Subject string
+		Body    string
+		Status  *pb.OrderStatus
+	}{lang, keys[0], keys[1], event.GetStatus()})
+	if err != nil {
+		s.releaseSend(ctx, key)
+		return err
+	}
+
+	err = s.queue.enqueue(&outgoingEmail{
+		priority: emailTransactional,
+		desc:     "Order status",
+		to:       event.GetEmail(),
+		subject:  translations.T(lang, keys[0]),
+		body:     buf.String(),
+	})
+	if err != nil {
+		s.releaseSend(ctx, key)
+	}
+	return err
+}
+
+// handleProductRestocked sends a "back in stock" email to a subscriber of a
+// sold out product.
+func (s *EmailService) handleProductRestocked(payload []byte) error {
+	var event pb.ProductRestocked
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	if event.GetEmail() == "" {
+		return nil
+	}
+
+	lang := emailLanguage(event.GetLocale())
+	var buf bytes.Buffer
+	err := tmpl.ExecuteTemplate(&buf, "restocked.html", struct {
+		Lang    string
+		Product *pb.Product
+		Variant string
+	}{lang, event.GetProduct(), variantLabel(event.GetVariant())})
+	if err != nil {
+		return err
+	}
+
+	return s.queue.enqueue(&outgoingEmail{
+		priority: emailMarketing,
+		desc:     "Back in stock",
+		to:       event.GetEmail(),
+		subject:  translations.T(lang, "email.restocked_subject"),
+		body:     buf.String(),
+	})
+}
+
+// emailLanguage returns locale if there is a catalog for it, otherwise the default language.
+func emailLanguage(locale string) string {
+	if translations.Supports(locale) {
+		return strings.ToLower(locale)
+	}
+	return defaultLanguage
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// emailCampaign is the kind of campaign emails in dedupe keys.
+const emailCampaign = "campaign"
+
+// emailContact is what is known of a customer to target campaigns: the
+// language and time of their last order and how many they placed.
+type emailContact struct {
+	email     string
+	locale    string
+	orders    int
+	lastOrder time.Time
+}
+
+// recordContact notes an order confirmed to email, in locale, for campaign
+// segments. The confirmation goes out whether or not it is recorded.
+func (s *EmailService) recordContact(ctx context.Context, email, locale string) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return
+	}
+	key := contactKey(ctx, email)
+	pipe := s.rdb.TxPipeline()
+	pipe.HSet(ctx, key, "locale", locale, "last_order", time.Now().Unix())
+	pipe.HIncrBy(ctx, key, "orders", 1)
+	pipe.SAdd(ctx, contactsKey(ctx), email)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to record contact %v: %v", email, err)
+	}
+}
+
+// Unsubscribe adds an address to the suppression list, which campaigns skip.
+// Transactional emails still go to it.
+func (s *EmailService) Unsubscribe(ctx context.Context, req *pb.UnsubscribeRequest) (_ *pb.Empty, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	email := strings.ToLower(strings.TrimSpace(req.GetEmail()))
+	if email == "" {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "email is required")
+	}
+	if err := s.rdb.SAdd(ctx, suppressedKey(ctx), email).Err(); err != nil {
+		log.Printf("Failed to unsubscribe %v: %v", email, err)
+		return nil, ctx, err
+	}
+	log.Printf("Unsubscribed %v from marketing emails", email)
+	return &pb.Empty{}, ctx, nil
+}
+
+// SendCampaign sends the campaign template to the customers in the segment
+// who have not unsubscribed, each in the language they last ordered in. The
+// emails are queued in the background, so the call returns once recipients
+// are chosen. In test-send mode only the test address gets the email.
+func (s *EmailService) SendCampaign(ctx context.Context, req *pb.SendCampaignRequest) (_ *pb.CampaignResult, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if req.GetCampaignId() == "" {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "campaign_id is required")
+	}
+	name := campaignTemplate(req.GetTemplate())
+	if tmpl.Lookup(name) == nil {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "unknown campaign template %q", req.GetTemplate())
+	}
+
+	contacts, err := s.segmentContacts(ctx, req.GetSegment())
+	if err != nil {
+		log.Printf("Failed to select campaign %v recipients: %v", req.GetCampaignId(), err)
+		return nil, ctx, err
+	}
+	suppressed, err := s.rdb.SMembers(ctx, suppressedKey(ctx)).Result()
+	if err != nil {
+		log.Printf("Failed to read the suppression list: %v", err)
+		return nil, ctx, err
+	}
+	res := &pb.CampaignResult{CampaignId: req.GetCampaignId(), Matched: int32(len(contacts))}
+	recipients := contacts[:0]
+	for _, c := range contacts {
+		if slices.Contains(suppressed, c.email) {
+			res.Suppressed++
+			continue
+		}
+		recipients = append(recipients, c)
+	}
+
+	if req.GetTestEmail() != "" {
+		log.Printf("Test-sending campaign %v to %v, segment of %d", req.GetCampaignId(), req.GetTestEmail(), len(recipients))
+		e, err := s.campaignEmail(req.GetTemplate(), emailLanguage(req.GetTestLocale()), req.GetTestEmail())
+		if err != nil {
+			return nil, ctx, err
+		}
+		if err := s.queue.enqueue(e); err != nil {
+			return nil, ctx, status.Errorf(codes.ResourceExhausted, "failed to queue test email: %v", err)
+		}
+		res.Queued = 1
+		return res, ctx, nil
+	}
+
+	log.Printf("Sending campaign %v to %d customers (%d unsubscribed)", req.GetCampaignId(), len(recipients), res.Suppressed)
+	go s.sendCampaign(context.WithoutCancel(ctx), req.GetCampaignId(), req.GetTemplate(), recipients)
+	res.Queued = int32(len(recipients))
+	return res, ctx, nil
+}
+
+// sendCampaign queues the campaign email for each recipient, waiting for
+// room in the queue rather than dropping any.
+func (s *EmailService) sendCampaign(ctx context.Context, campaignID, template string, recipients []emailContact) {
+	sent, skipped := 0, 0
+	for _, c := range recipients {
+		key := dedupeKey(campaignID+":"+c.email, emailCampaign)
+		if !s.claimSend(ctx, key) {
+			skipped++
+			continue
+		}
+		e, err := s.campaignEmail(template, emailLanguage(c.locale), c.email)
+		if err == nil {
+			err = s.queue.enqueueWait(ctx, e)
+		}
+		if err != nil {
+			s.releaseSend(ctx, key)
+			log.Printf("Campaign %v stopped after %d emails: %v", campaignID, sent, err)
+			return
+		}
+		sent++
+	}
+	log.Printf("Campaign %v queued: %d emails, %d already sent", campaignID, sent, skipped)
+}
+
+// campaignEmail renders the campaign template in lang for to.
+func (s *EmailService) campaignEmail(template, lang, to string) (*outgoingEmail, error) {
+	var buf bytes.Buffer
+	err := tmpl.ExecuteTemplate(&buf, campaignTemplate(template), struct {
+		Lang          string
+		StorefrontURL string
+	}{lang, strings.TrimSuffix(config.Get("STOREFRONT_URL"), "/")})
+	if err != nil {
+		return nil, err
+	}
+	return &outgoingEmail{
+		priority: emailMarketing,
+		desc:     "Campaign",
+		to:       to,
+		subject:  translations.T(lang, "campaign."+template+".subject"),
+		body:     buf.String(),
+	}, nil
+}
+
+// segmentContacts returns the customers in segment.
+func (s *EmailService) segmentContacts(ctx context.Context, segment *pb.CampaignSegment) ([]emailContact, error) {
+	emails, err := s.rdb.SMembers(ctx, contactsKey(ctx)).Result()
+	if err != nil {
+		return nil, err
+	}
+	var since time.Time
+	if days := segment.GetOrderedWithinDays(); days > 0 {
+		since = time.Now().AddDate(0, 0, -int(days))
+	}
+	var contacts []emailContact
+	for _, email := range emails {
+		fields, err := s.rdb.HGetAll(ctx, contactKey(ctx, email)).Result()
+		if err != nil {
+			return nil, err
+		}
+		c := emailContact{email: email, locale: fields["locale"]}
+		c.orders, _ = strconv.Atoi(fields["orders"])
+		lastOrder, _ := strconv.ParseInt(fields["last_order"], 10, 64)
+		c.lastOrder = time.Unix(lastOrder, 0)
+
+		if segment.GetLocale() != "" && !strings.EqualFold(c.locale, segment.GetLocale()) {
+			continue
+		}
+		if c.orders < int(segment.GetMinOrders()) || c.lastOrder.Before(since) {
+			continue
+		}
+		contacts = append(contacts, c)
+	}
+	return contacts, nil
+}
+
+func campaignTemplate(name string) string {
+	return "campaign_" + name + ".html"
+}
+
+func contactKey(ctx context.Context, email string) string {
+	return tenant.Key(ctx, "email-contact:"+email)
+}
+
+func contactsKey(ctx context.Context) string {
+	return tenant.Key(ctx, "email-contacts")
+}
+
+func suppressedKey(ctx context.Context) string {
+	return tenant.Key(ctx, "email-suppressed")
+}
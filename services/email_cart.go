@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// emailCartReminder is the kind of cart reminder emails in dedupe keys.
+const emailCartReminder = "cart_reminder"
+
+// handleCartAbandoned reminds a shopper of the cart they left, with a link
+// back to it. Reminders are marketing emails, so addresses on the
+// suppression list do not get them, and a cart is reminded of once each
+// time it is left.
+func (s *EmailService) handleCartAbandoned(payload []byte) error {
+	var event pb.CartAbandoned
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	email := strings.ToLower(strings.TrimSpace(event.GetEmail()))
+	if email == "" || len(event.GetCart().GetItems()) == 0 {
+		return nil
+	}
+	ctx := tenant.NewContext(context.Background(), event.GetTenant())
+	suppressed, err := s.rdb.SIsMember(ctx, suppressedKey(ctx), email).Result()
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		log.Printf("Not reminding unsubscribed %v of their cart", email)
+		return nil
+	}
+	key := dedupeKey(event.GetCart().GetUserId()+":"+strconv.FormatInt(event.GetUpdatedAt(), 10), emailCartReminder)
+	if !s.claimSend(ctx, key) {
+		return nil
+	}
+
+	lang := emailLanguage(event.GetLocale())
+	var buf bytes.Buffer
+	err = tmpl.ExecuteTemplate(&buf, "cart_reminder.html", struct {
+		Lang    string
+		Items   []*pb.CartItem
+		CartURL string
+	}{lang, event.GetCart().GetItems(), cartURL()})
+	if err != nil {
+		s.releaseSend(ctx, key)
+		return err
+	}
+
+	err = s.queue.enqueue(&outgoingEmail{
+		priority: emailMarketing,
+		desc:     "Cart reminder",
+		to:       email,
+		subject:  translations.T(lang, "email.cart_reminder_subject"),
+		body:     buf.String(),
+	})
+	if err != nil {
+		s.releaseSend(ctx, key)
+	}
+	return err
+}
+
+// cartURL returns the cart page of the storefront at STOREFRONT_URL. Carts
+// belong to the shopper's session, so the link finds theirs in the browser
+// they left it in.
+func cartURL() string {
+	return strings.TrimSuffix(config.Get("STOREFRONT_URL"), "/") + "/cart"
+}
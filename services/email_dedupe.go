@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// Kinds of email, which make up dedupe keys along with what the email is
+// about.
+const (
+	emailConfirmation = "confirmation"
+	emailShipped      = "shipped"
+	emailOrderStatus  = "order_status"
+)
+
+// dedupeKey returns the dedupe key of the email of the given kind about
+// subject, such as an order ID.
+func dedupeKey(subject, kind string) string {
+	return subject + ":" + kind
+}
+
+// claimSend marks the email with dedupe key key as sent and reports whether
+// it was not already, in which case the caller sends it. Keys are kept for
+// EMAIL_DEDUPE_TTL. An empty key is never a duplicate, and neither is any
+// key when Redis cannot be reached: a duplicate email beats a lost one.
+func (s *EmailService) claimSend(ctx context.Context, key string) bool {
+	if key == "" {
+		return true
+	}
+	claimed, err := s.rdb.SetNX(ctx, sentKey(ctx, key), 1, s.dedupeTTL).Result()
+	if err != nil {
+		log.Printf("Failed to check email %v for duplicates: %v", key, err)
+		return true
+	}
+	if !claimed {
+		log.Printf("Email %v already sent, skipping", key)
+	}
+	return claimed
+}
+
+// releaseSend forgets that the email with dedupe key key was sent, for when
+// sending it failed after it was claimed, so that a retry sends it.
+func (s *EmailService) releaseSend(ctx context.Context, key string) {
+	if key == "" {
+		return
+	}
+	if err := s.rdb.Del(ctx, sentKey(ctx, key)).Err(); err != nil {
+		log.Printf("Failed to release email %v: %v", key, err)
+	}
+}
+
+func sentKey(ctx context.Context, key string) string {
+	return tenant.Key(ctx, "email-sent:"+key)
+}
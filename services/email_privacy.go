@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/privacy"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// saveReceipt keeps the receipt record of an order confirmed to email,
+// listing it under the address so that it can be exported or deleted with
+// the rest of the data kept about the address.
+func (s *EmailService) saveReceipt(ctx context.Context, email, orderID string, record []byte) error {
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, receiptKey(ctx, orderID), record, s.receiptTTL)
+	if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+		pipe.SAdd(ctx, emailReceiptsKey(ctx, email), orderID)
+		pipe.Expire(ctx, emailReceiptsKey(ctx, email), s.receiptTTL)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// emailData is what is kept about an address.
+type emailData struct {
+	email      string
+	contact    map[string]string
+	suppressed bool
+	receipts   map[string]*receiptRecord
+}
+
+// loadEmailData reads what is kept about each of emails.
+func (s *EmailService) loadEmailData(ctx context.Context, emails []string) ([]*emailData, error) {
+	var data []*emailData
+	for _, email := range emails {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" {
+			continue
+		}
+		d := &emailData{email: email, receipts: make(map[string]*receiptRecord)}
+		var err error
+		if d.contact, err = s.rdb.HGetAll(ctx, contactKey(ctx, email)).Result(); err != nil {
+			return nil, err
+		}
+		if d.suppressed, err = s.rdb.SIsMember(ctx, suppressedKey(ctx), email).Result(); err != nil {
+			return nil, err
+		}
+		orderIDs, err := s.rdb.SMembers(ctx, emailReceiptsKey(ctx, email)).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range orderIDs {
+			raw, err := s.rdb.Get(ctx, receiptKey(ctx, id)).Bytes()
+			if err == redis.Nil {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			var rec receiptRecord
+			if err := json.Unmarshal(raw, &rec); err != nil {
+				return nil, err
+			}
+			d.receipts[id] = &rec
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// emailDataRecords returns the records of data, with the data itself if
+// withData is set.
+func emailDataRecords(data []*emailData, withData bool) ([]*pb.UserDataRecord, error) {
+	contacts := make(map[string]map[string]string)
+	var suppressed []string
+	receipts := make(map[string]*receiptRecord)
+	for _, d := range data {
+		if len(d.contact) > 0 {
+			contacts[d.email] = d.contact
+		}
+		if d.suppressed {
+			suppressed = append(suppressed, d.email)
+		}
+		for id, rec := range d.receipts {
+			receipts[id] = rec
+		}
+	}
+	var records []*pb.UserDataRecord
+	for _, r := range []struct {
+		kind    string
+		count   int
+		entries any
+	}{
+		{"email_contacts", len(contacts), contacts},
+		{"email_suppressions", len(suppressed), suppressed},
+		{"receipts", len(receipts), receipts},
+	} {
+		rec, err := privacy.Record(r.kind, r.count, r.entries, withData)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// ExportUserData returns what is kept about the addresses of the user: the
+// campaign contact, the suppression entry and the receipts of orders.
+func (s *EmailService) ExportUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if _, err := privacy.User(ctx); err != nil {
+		return nil, ctx, err
+	}
+	data, err := s.loadEmailData(ctx, req.GetEmails())
+	if err != nil {
+		log.Printf("Failed to read data of %d address(es): %v", len(req.GetEmails()), err)
+		return nil, ctx, err
+	}
+	records, err := emailDataRecords(data, true)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: records}, ctx, nil
+}
+
+// DeleteUserData deletes what is kept about the addresses of the user. With
+// its contact gone, an address is out of every campaign segment, so its
+// suppression entry goes too, until it places an order again.
+func (s *EmailService) DeleteUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if _, err := privacy.User(ctx); err != nil {
+		return nil, ctx, err
+	}
+	data, err := s.loadEmailData(ctx, req.GetEmails())
+	if err != nil {
+		log.Printf("Failed to read data of %d address(es): %v", len(req.GetEmails()), err)
+		return nil, ctx, err
+	}
+	pipe := s.rdb.TxPipeline()
+	for _, d := range data {
+		pipe.Del(ctx, contactKey(ctx, d.email), emailReceiptsKey(ctx, d.email))
+		pipe.SRem(ctx, contactsKey(ctx), d.email)
+		pipe.SRem(ctx, suppressedKey(ctx), d.email)
+		for id := range d.receipts {
+			pipe.Del(ctx, receiptKey(ctx, id))
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to delete data of %d address(es): %v", len(data), err)
+		return nil, ctx, err
+	}
+	log.Printf("Deleted the data of %d address(es)", len(data))
+	records, err := emailDataRecords(data, false)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: records}, ctx, nil
+}
+
+func emailReceiptsKey(ctx context.Context, email string) string {
+	return tenant.Key(ctx, "email-receipts:"+email)
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// Priorities of an email. Transactional emails, which customers wait for,
+// are always sent before marketing ones.
+const (
+	emailTransactional = "transactional"
+	emailMarketing     = "marketing"
+)
+
+var errEmailQueueFull = errors.New("email queue is full")
+
+// emailQueueStats exposes the depth of the email queue and what went
+// through it, by priority.
+var emailQueueStats = expvar.NewMap("email_queue")
+
+// outgoingEmail is an email waiting in the queue.
+type outgoingEmail struct {
+	priority string
+	// desc names the kind of email in logs, e.g. "Order confirmation".
+	desc    string
+	to      string
+	subject string
+	body    string
+
+	// attachment is the file name of the attachment, if any, and
+	// attachmentSize its size in bytes.
+	attachment     string
+	attachmentSize int
+
+	enqueued time.Time
+}
+
+// emailProvider is a backend emails are sent through, at no more than its
+// rate per second.
+type emailProvider struct {
+	name     string
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until the provider may send the next email.
+func (p *emailProvider) wait() {
+	if p.interval <= 0 {
+		return
+	}
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(p.interval)
+	p.mu.Unlock()
+	time.Sleep(time.Until(at))
+}
+
+// emailQueue sends emails with a pool of workers, transactional ones first,
+// through the provider configured for their priority.
+type emailQueue struct {
+	queues    map[string]chan *outgoingEmail
+	providers map[string]*emailProvider
+}
+
+// newEmailQueue reads the queue configuration and starts its workers:
+//
+//   - EMAIL_WORKERS, the number of workers, 4 by default;
+//   - EMAIL_QUEUE_SIZE, the emails of each priority that may wait, 1000 by
+//     default;
+//   - EMAIL_PROVIDERS, the providers as name=max-per-second, "smtp=50" by
+//     default, 0 meaning unlimited;
+//   - EMAIL_TRANSACTIONAL_PROVIDER and EMAIL_MARKETING_PROVIDER, the provider
+//     of each priority, the first provider by default.
+func newEmailQueue() (*emailQueue, error) {
+	size := envInt("EMAIL_QUEUE_SIZE", 1000)
+	q := &emailQueue{
+		queues: map[string]chan *outgoingEmail{
+			emailTransactional: make(chan *outgoingEmail, size),
+			emailMarketing:     make(chan *outgoingEmail, size),
+		},
+		providers: make(map[string]*emailProvider),
+	}
+
+	var first *emailProvider
+	for _, item := range envList("EMAIL_PROVIDERS", []string{"smtp=50"}) {
+		name, limit, _ := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		perSecond, err := strconv.ParseFloat(strings.TrimSpace(limit), 64)
+		if err != nil || perSecond < 0 {
+			return nil, fmt.Errorf("invalid EMAIL_PROVIDERS entry %q", item)
+		}
+		p := &emailProvider{name: name}
+		if perSecond > 0 {
+			p.interval = time.Duration(float64(time.Second) / perSecond)
+		}
+		if first == nil {
+			first = p
+		}
+		for _, priority := range []string{emailTransactional, emailMarketing} {
+			if q.providers[priority] == nil && providerSetting(priority) == name {
+				q.providers[priority] = p
+			}
+		}
+	}
+	if first == nil {
+		return nil, errors.New("no email providers configured")
+	}
+	for _, priority := range []string{emailTransactional, emailMarketing} {
+		if want := providerSetting(priority); want != "" && q.providers[priority] == nil {
+			return nil, fmt.Errorf("unknown %s email provider %q", priority, want)
+		}
+		if q.providers[priority] == nil {
+			q.providers[priority] = first
+		}
+		queue := q.queues[priority]
+		emailQueueStats.Set("depth_"+priority, expvar.Func(func() any { return len(queue) }))
+	}
+
+	workers := max(envInt("EMAIL_WORKERS", 4), 1)
+	for range workers {
+		go q.work()
+	}
+	log.Printf("Email queue: %d workers, transactional via %s, marketing via %s",
+		workers, q.providers[emailTransactional].name, q.providers[emailMarketing].name)
+	return q, nil
+}
+
+// providerSetting returns the provider configured for priority, if any.
+func providerSetting(priority string) string {
+	return strings.TrimSpace(config.Get("EMAIL_" + strings.ToUpper(priority) + "_PROVIDER"))
+}
+
+// enqueue queues e for sending. It fails rather than wait if the queue of its
+// priority is full.
+func (q *emailQueue) enqueue(e *outgoingEmail) error {
+	e.enqueued = time.Now()
+	select {
+	case q.queues[e.priority] <- e:
+		emailQueueStats.Add("enqueued_"+e.priority, 1)
+		return nil
+	default:
+		emailQueueStats.Add("rejected_"+e.priority, 1)
+		return errEmailQueueFull
+	}
+}
+
+// enqueueWait queues e for sending, waiting for room in the queue of its
+// priority until ctx is done.
+func (q *emailQueue) enqueueWait(ctx context.Context, e *outgoingEmail) error {
+	e.enqueued = time.Now()
+	select {
+	case q.queues[e.priority] <- e:
+		emailQueueStats.Add("enqueued_"+e.priority, 1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work sends queued emails, taking a marketing email only when no
+// transactional one is waiting.
+func (q *emailQueue) work() {
+	transactional, marketing := q.queues[emailTransactional], q.queues[emailMarketing]
+	for {
+		var e *outgoingEmail
+		select {
+		case e = <-transactional:
+		default:
+			select {
+			case e = <-transactional:
+			case e = <-marketing:
+			}
+		}
+		q.send(e)
+	}
+}
+
+// send sends e through the provider of its priority, once the provider's
+// rate allows.
+func (q *emailQueue) send(e *outgoingEmail) {
+	p := q.providers[e.priority]
+	p.wait()
+	emailQueueStats.Add("wait_ns_"+e.priority, int64(time.Since(e.enqueued)))
+
+	// Simulate sending the email
+	log.Printf("%s email %q for %v via %s:\n%s", e.desc, e.subject, e.to, p.name, e.body)
+	if e.attachment != "" {
+		log.Printf("Attached %s (%d bytes)", e.attachment, e.attachmentSize)
+	}
+	log.Printf("%s email sent to %v", e.desc, e.to)
+	emailQueueStats.Add("sent_"+e.priority, 1)
+}
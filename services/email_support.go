@@ -0,0 +1,67 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+)
+
+// emailTicketAcknowledgement is the kind of ticket acknowledgements in dedupe
+// keys.
+const emailTicketAcknowledgement = "ticket_acknowledgement"
+
+// SendTicketAcknowledgement tells a shopper their support ticket was
+// received, quoting it back to them. It is a reply to the shopper, so it
+// goes out even to addresses that unsubscribed from marketing.
+func (s *EmailService) SendTicketAcknowledgement(ctx context.Context, req *pb.SendTicketAcknowledgementRequest) (_ *pb.Empty, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	ticket := req.GetTicket()
+	if ticket.GetEmail() == "" {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "ticket %s has no email address", ticket.GetId())
+	}
+	if !s.claimSend(ctx, req.GetDedupeKey()) {
+		return &pb.Empty{}, ctx, nil
+	}
+	defer func() {
+		if err != nil {
+			s.releaseSend(ctx, req.GetDedupeKey())
+		}
+	}()
+
+	lang := emailLanguage(ticket.GetLocale())
+	var buf bytes.Buffer
+	err = tmpl.ExecuteTemplate(&buf, "ticket_acknowledgement.html", struct {
+		Lang      string
+		Ticket    *pb.Ticket
+		TicketURL string
+	}{lang, ticket, ticketURL(ticket.GetId())})
+	if err != nil {
+		return nil, ctx, err
+	}
+
+	err = s.queue.enqueue(&outgoingEmail{
+		priority: emailTransactional,
+		desc:     "Ticket acknowledgement",
+		to:       ticket.GetEmail(),
+		subject:  translations.T(lang, "email.ticket_subject", ticket.GetId()),
+		body:     buf.String(),
+	})
+	if err != nil {
+		return nil, ctx, status.Errorf(codes.ResourceExhausted, "failed to queue ticket acknowledgement: %v", err)
+	}
+	return &pb.Empty{}, ctx, nil
+}
+
+// ticketURL returns the page of a support ticket on the storefront at
+// STOREFRONT_URL. Like orders, tickets belong to the shopper's session.
+func ticketURL(ticketID string) string {
+	return strings.TrimSuffix(config.Get("STOREFRONT_URL"), "/") + "/contact/" + ticketID
+}
@@ -0,0 +1,90 @@
+package eventbus
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// BatchConfig controls how a Batcher groups events.
+type BatchConfig struct {
+	// Size is the number of events that triggers a publish.
+	Size int
+	// Interval is the longest an event waits before it is published.
+	Interval time.Duration
+	// Buffer is how many events may wait to be batched. Events added while
+	// it is full are dropped.
+	Buffer int
+}
+
+// Batcher collects events in memory and publishes them on a topic as JSON
+// arrays. Adding an event never waits on the bus: if publishing falls behind
+// and the buffer fills up, new events are dropped and counted instead.
+type Batcher[T any] struct {
+	bus    *Bus
+	topic  string
+	cfg    BatchConfig
+	events chan T
+
+	dropped atomic.Int64
+}
+
+// NewBatcher starts a Batcher publishing on topic.
+func NewBatcher[T any](bus *Bus, topic string, cfg BatchConfig) *Batcher[T] {
+	b := &Batcher[T]{
+		bus:    bus,
+		topic:  topic,
+		cfg:    cfg,
+		events: make(chan T, cfg.Buffer),
+	}
+	go b.run()
+	return b
+}
+
+// Add queues event for the next batch. It reports false if the event was
+// dropped because the buffer is full.
+func (b *Batcher[T]) Add(event T) bool {
+	select {
+	case b.events <- event:
+		return true
+	default:
+		b.dropped.Add(1)
+		return false
+	}
+}
+
+func (b *Batcher[T]) run() {
+	ticker := time.NewTicker(b.cfg.Interval)
+	defer ticker.Stop()
+
+	batch := make([]T, 0, b.cfg.Size)
+	for {
+		select {
+		case event := <-b.events:
+			batch = append(batch, event)
+			if len(batch) < b.cfg.Size {
+				continue
+			}
+		case <-ticker.C:
+		}
+		b.flush(batch)
+		batch = batch[:0]
+	}
+}
+
+// flush publishes batch. A batch that fails to publish is logged and
+// discarded rather than retried, so a slow bus cannot stall the pipeline.
+func (b *Batcher[T]) flush(batch []T) {
+	if dropped := b.dropped.Swap(0); dropped > 0 {
+		log.Printf("eventbus: dropped %d events for %s, buffer full", dropped, b.topic)
+	}
+	if len(batch) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := b.bus.Publish(ctx, b.topic, batch); err != nil {
+		log.Printf("eventbus: failed to publish %d events on %s: %v", len(batch), b.topic, err)
+	}
+}
@@ -0,0 +1,70 @@
+// Package eventbus publishes and consumes JSON encoded events over Redis
+// pub/sub.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Topics used by the services.
+const (
+	TopicShipmentStatusChanged = "shipment.status_changed"
+	TopicProductRestocked      = "product.restocked"
+	TopicAdEvents              = "ad.events"
+	TopicPaymentStatusChanged  = "payment.status_changed"
+	TopicOrderStatusChanged    = "order.status_changed"
+	TopicCartAbandoned         = "cart.abandoned"
+	TopicReferralVisited       = "referral.visited"
+	TopicCatalogUpdated        = "catalog.updated"
+	TopicCurrencyUpdated       = "currency.updated"
+)
+
+// Bus is a connection to the event bus.
+type Bus struct {
+	rdb *redis.Client
+}
+
+// New returns a Bus backed by the Redis server at addr.
+func New(addr string) *Bus {
+	return &Bus{
+		rdb: redis.NewClient(&redis.Options{
+			Addr: addr,
+		}),
+	}
+}
+
+// Publish JSON encodes event and publishes it on topic.
+func (b *Bus) Publish(ctx context.Context, topic string, event interface{}) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return b.rdb.Publish(ctx, topic, data).Err()
+}
+
+// Subscribe calls handle with the payload of every event published on topic
+// until ctx is done. Handler errors are logged and do not stop the
+// subscription.
+func (b *Bus) Subscribe(ctx context.Context, topic string, handle func(payload []byte) error) {
+	sub := b.rdb.Subscribe(ctx, topic)
+	defer sub.Close()
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := handle([]byte(msg.Payload)); err != nil {
+				log.Printf("eventbus: failed to handle event on %s: %v", topic, err)
+			}
+		}
+	}
+}
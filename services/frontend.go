@@ -2,12 +2,15 @@ package services
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"html/template"
+	"io"
 	"log"
-	"math/rand"
 	"net/http"
-	"os"
+	"net/url"
+	"runtime/debug"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,18 +18,45 @@ import (
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/graphql"
+	"github.com/appnetorg/online-boutique-arpc/services/hedge"
+	"github.com/appnetorg/online-boutique-arpc/services/i18n"
+	"github.com/appnetorg/online-boutique-arpc/services/privacy"
+	"github.com/appnetorg/online-boutique-arpc/services/resolver"
+	"github.com/appnetorg/online-boutique-arpc/services/rpclog"
+	"github.com/appnetorg/online-boutique-arpc/services/startup"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 	"github.com/appnetorg/online-boutique-arpc/services/validator"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
 
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 )
 
 const (
 	defaultCurrency = "CNY"
 
+	defaultLanguage = "en"
+
 	cookiePrefix   = "shop_"
 	cookieCurrency = cookiePrefix + "currency"
+	cookieLanguage = cookiePrefix + "lang"
+	cookieMaxAge   = 60 * 60 * 48
+
+	cookieAdExperiment     = cookiePrefix + "ad_experiment"
+	cookieRecentCategories = cookiePrefix + "recent_categories"
+	cookieAdSession        = cookiePrefix + "ad_session"
+	cookieSessionID        = cookiePrefix + "session-id"
+
+	// maxFormBytes caps the size of POST bodies; the largest form is checkout.
+	maxFormBytes = 64 << 10
 )
 
 type ctxKeySessionID struct{}
@@ -38,24 +68,22 @@ type platformDetails struct {
 }
 
 var (
-	frontendMessage  = strings.TrimSpace(os.Getenv("FRONTEND_MESSAGE"))
-	isCymbalBrand    = strings.ToLower(os.Getenv("CYMBAL_BRANDING")) == "true"
-	assistantEnabled = strings.ToLower(os.Getenv("ENABLE_ASSISTANT")) == "true"
+	frontendMessage  = config.NewValue(func() string { return strings.TrimSpace(config.Get("FRONTEND_MESSAGE")) })
+	isCymbalBrand    = config.NewValue(func() bool { return strings.ToLower(config.Get("CYMBAL_BRANDING")) == "true" })
+	assistantEnabled = config.NewValue(func() bool { return strings.ToLower(config.Get("ENABLE_ASSISTANT")) == "true" })
+	showErrors       = config.NewValue(func() bool { return strings.ToLower(config.Get("FRONTEND_SHOW_ERRORS")) == "true" })
+	translations     = i18n.MustLoad("data/i18n", defaultLanguage)
 	templates        = template.Must(template.New("").
 				Funcs(template.FuncMap{
+			"add":                func(x, y int) int { return x + y },
+			"formatDate":         func(unix int64) string { return time.Unix(unix, 0).UTC().Format("2006-01-02") },
 			"renderMoney":        renderMoney,
+			"renderMoneyOrFree":  renderMoneyOrFree,
+			"renderDiscount":     renderDiscount,
 			"renderCurrencyLogo": renderCurrencyLogo,
+			"T":                  translations.T,
 		}).ParseGlob("templates/*.html"))
 	plat platformDetails
-
-	whitelistedCurrencies = map[string]bool{
-		"USD": true,
-		"EUR": true,
-		"CAD": true,
-		"JPY": true,
-		"GBP": true,
-		"TRY": true,
-	}
 )
 
 // frontendServer implements frontendServer service
@@ -63,27 +91,55 @@ type frontendServer struct {
 	port int
 
 	productCatalogSvcAddr string
-	productCatalogSvcConn *rpc.Client
+	productCatalogSvcConn *resolver.Pool
 
 	currencySvcAddr string
-	currencySvcConn *rpc.Client
+	currencySvcConn *resolver.Pool
 
 	cartSvcAddr string
-	cartSvcConn *rpc.Client
+	cartSvcConn *resolver.Pool
 
 	recommendationSvcAddr string
-	recommendationSvcConn *rpc.Client
+	recommendationSvcConn *resolver.Pool
 
 	checkoutSvcAddr string
-	checkoutSvcConn *rpc.Client
+	checkoutSvcConn *resolver.Pool
 
 	shippingSvcAddr string
-	shippingSvcConn *rpc.Client
+	shippingSvcConn *resolver.Pool
 
 	adSvcAddr string
-	adSvcConn *rpc.Client
+	adSvcConn *resolver.Pool
+
+	addressSvcAddr string
+	addressSvcConn *resolver.Pool
+
+	emailSvcAddr string
+	emailSvcConn *resolver.Pool
+
+	walletSvcAddr string
+	walletSvcConn *resolver.Pool
+
+	paymentSvcAddr string
+	paymentSvcConn *resolver.Pool
+
+	supportSvcAddr string
+	supportSvcConn *resolver.Pool
+
+	eventBusAddr string
+	bus          *eventbus.Bus
+	orderEvents  *orderEvents
 
 	shoppingAssistantSvcAddr string
+
+	fragments    *fragmentCache
+	productCache *productCache
+	suggestions  *suggestCache
+	stock        *stockCache
+	graphSchema  *graphql.Schema
+	privacy      *privacy.Coordinator
+	nonces       *checkoutNonces
+	hedger       *hedge.Hedger
 }
 
 func NewFrontendServer(port int) *frontendServer {
@@ -99,13 +155,18 @@ func (fe *frontendServer) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	mustMapEnv(&fe.productCatalogSvcAddr, "PRODUCT_CATALOG_SERVICE_ADDR")
-	mustMapEnv(&fe.currencySvcAddr, "CURRENCY_SERVICE_ADDR")
-	mustMapEnv(&fe.cartSvcAddr, "CART_SERVICE_ADDR")
-	mustMapEnv(&fe.recommendationSvcAddr, "RECOMMENDATION_SERVICE_ADDR")
-	mustMapEnv(&fe.checkoutSvcAddr, "CHECKOUT_SERVICE_ADDR")
-	mustMapEnv(&fe.shippingSvcAddr, "SHIPPING_SERVICE_ADDR")
-	mustMapEnv(&fe.adSvcAddr, "AD_SERVICE_ADDR")
+	mapServiceAddr(&fe.productCatalogSvcAddr, "PRODUCT_CATALOG_SERVICE_ADDR", "productcatalog")
+	mapServiceAddr(&fe.currencySvcAddr, "CURRENCY_SERVICE_ADDR", "currency")
+	mapServiceAddr(&fe.cartSvcAddr, "CART_SERVICE_ADDR", "cart")
+	mapServiceAddr(&fe.recommendationSvcAddr, "RECOMMENDATION_SERVICE_ADDR", "recommendation")
+	mapServiceAddr(&fe.checkoutSvcAddr, "CHECKOUT_SERVICE_ADDR", "checkout")
+	mapServiceAddr(&fe.shippingSvcAddr, "SHIPPING_SERVICE_ADDR", "shipping")
+	mapServiceAddr(&fe.adSvcAddr, "AD_SERVICE_ADDR", "ad")
+	mapServiceAddr(&fe.addressSvcAddr, "ADDRESS_SERVICE_ADDR", "address")
+	mapServiceAddr(&fe.emailSvcAddr, "EMAIL_SERVICE_ADDR", "email")
+	mapServiceAddr(&fe.walletSvcAddr, "WALLET_SERVICE_ADDR", "wallet")
+	mapServiceAddr(&fe.paymentSvcAddr, "PAYMENT_SERVICE_ADDR", "payment")
+	mapServiceAddr(&fe.supportSvcAddr, "SUPPORT_SERVICE_ADDR", "support")
 	mustMapEnv(&fe.shoppingAssistantSvcAddr, "SHOPPING_ASSISTANT_SERVICE_ADDR")
 
 	mustConnARPC(&fe.currencySvcConn, fe.currencySvcAddr)
@@ -115,13 +176,132 @@ func (fe *frontendServer) Run() error {
 	mustConnARPC(&fe.shippingSvcConn, fe.shippingSvcAddr)
 	mustConnARPC(&fe.checkoutSvcConn, fe.checkoutSvcAddr)
 	mustConnARPC(&fe.adSvcConn, fe.adSvcAddr)
+	mustConnARPC(&fe.addressSvcConn, fe.addressSvcAddr)
+	mustConnARPC(&fe.emailSvcConn, fe.emailSvcAddr)
+	mustConnARPC(&fe.walletSvcConn, fe.walletSvcAddr)
+	mustConnARPC(&fe.paymentSvcConn, fe.paymentSvcAddr)
+	mustConnARPC(&fe.supportSvcConn, fe.supportSvcAddr)
+
+	checker := newStartupChecker()
+	checker.Add("currency", startup.ARPC(fe.currencySvcConn.Addrs))
+	checker.Add("productcatalog", startup.ARPC(fe.productCatalogSvcConn.Addrs))
+	checker.Add("cart", startup.ARPC(fe.cartSvcConn.Addrs))
+	checker.Add("recommendation", startup.ARPC(fe.recommendationSvcConn.Addrs))
+	checker.Add("shipping", startup.ARPC(fe.shippingSvcConn.Addrs))
+	checker.Add("checkout", startup.ARPC(fe.checkoutSvcConn.Addrs))
+	checker.Add("ad", startup.ARPC(fe.adSvcConn.Addrs))
+	checker.Add("address", startup.ARPC(fe.addressSvcConn.Addrs))
+	checker.Add("email", startup.ARPC(fe.emailSvcConn.Addrs))
+	checker.Add("wallet", startup.ARPC(fe.walletSvcConn.Addrs))
+	checker.Add("payment", startup.ARPC(fe.paymentSvcConn.Addrs))
+	checker.Add("support", startup.ARPC(fe.supportSvcConn.Addrs))
+	mustCheckStartup(checker)
+
+	// Shipment progress is pushed to the shopper's open pages.
+	mustMapEnv(&fe.eventBusAddr, "EVENT_BUS_ADDR")
+	fe.bus = eventbus.New(fe.eventBusAddr)
+	fe.orderEvents = newOrderEvents()
+	go fe.bus.Subscribe(context.Background(), eventbus.TopicOrderStatusChanged, fe.orderEvents.handleOrderStatusChanged)
+
+	// Read-only catalog and currency calls may be hedged to cut tail latency.
+	fe.hedger = hedge.New(hedgeConfig())
+	config.OnReload(func() {
+		fe.hedger.SetConfig(hedgeConfig())
+	})
 
-	http.HandleFunc("/", fe.tracingMiddleware(fe.homeHandler))
-	http.HandleFunc("/cart/checkout", fe.tracingMiddleware(fe.placeOrderHandler))
-	http.HandleFunc("/cart", fe.tracingMiddleware(fe.addToCartHandler))
+	fe.productCache = newProductCache(
+		envDuration("FRONTEND_PRODUCT_TTL", 30*time.Second),
+		envDuration("FRONTEND_PRODUCT_STALE", 5*time.Minute),
+		fe.fetchProduct,
+		fe.fetchProducts)
+	fe.fragments = newFragmentCache(map[string]time.Duration{
+		fragmentCurrencies: envDuration("FRONTEND_CURRENCY_CACHE_TTL", 30*time.Second),
+		fragmentProducts:   envDuration("FRONTEND_PRODUCT_CACHE_TTL", 10*time.Second),
+	})
+
+	fe.suggestions = newSuggestCache(envDuration("FRONTEND_SUGGEST_CACHE_TTL", 5*time.Minute))
+	fe.stock = newStockCache(envDuration("FRONTEND_STOCK_CACHE_TTL", 5*time.Second))
+	// Caches are dropped as soon as the catalog or the rates change, rather
+	// than serving stale data until they expire.
+	go fe.bus.Subscribe(context.Background(), eventbus.TopicCatalogUpdated, fe.handleCatalogUpdated)
+	go fe.bus.Subscribe(context.Background(), eventbus.TopicCurrencyUpdated, fe.handleCurrencyUpdated)
+	fe.graphSchema = fe.newGraphQLSchema()
+	fe.privacy = fe.newPrivacyCoordinator()
+
+	fe.nonces = newCheckoutNonces(envDuration("CHECKOUT_NONCE_TTL", time.Hour))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", fe.tracingMiddleware(recoverMiddleware(fe.homeHandler)))
+	mux.HandleFunc("/product/", fe.tracingMiddleware(recoverMiddleware(fe.productHandler)))
+	mux.HandleFunc("POST /cart/review", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.reviewOrderHandler))))
+	mux.HandleFunc("/cart/checkout", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.placeOrderHandler))))
+	mux.HandleFunc("GET /cart", fe.tracingMiddleware(recoverMiddleware(fe.viewCartHandler)))
+	mux.HandleFunc("POST /cart", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.addToCartHandler))))
+	mux.HandleFunc("/cart/empty", fe.tracingMiddleware(recoverMiddleware(fe.emptyCartHandler)))
+	mux.HandleFunc("POST /wallet/redeem", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.redeemGiftCardHandler))))
+	mux.HandleFunc("GET /privacy", fe.tracingMiddleware(recoverMiddleware(fe.privacyHandler)))
+	mux.HandleFunc("POST /privacy/export", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.exportUserDataHandler))))
+	mux.HandleFunc("POST /privacy/delete", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.deleteUserDataHandler))))
+	mux.HandleFunc("GET /contact", fe.tracingMiddleware(recoverMiddleware(fe.contactHandler)))
+	mux.HandleFunc("POST /contact", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.createTicketHandler))))
+	mux.HandleFunc("GET /contact/{id}", fe.tracingMiddleware(recoverMiddleware(fe.ticketHandler)))
+	mux.HandleFunc("/ad/click", fe.tracingMiddleware(recoverMiddleware(fe.adClickHandler)))
+	mux.HandleFunc("/notify", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.notifyWhenAvailableHandler))))
+	mux.HandleFunc("/setCurrency", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.setCurrencyHandler))))
+	mux.HandleFunc("/setLanguage", fe.tracingMiddleware(recoverMiddleware(limitBody(fe.setLanguageHandler))))
+	mux.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
+	mux.HandleFunc("/images/", imagesHandler)
+	mux.Handle("GET /_ready", checker)
+	mux.HandleFunc("GET /search", fe.tracingMiddleware(recoverMiddleware(fe.searchHandler)))
+	api := fe.apiEndpoints()
+	for _, e := range api {
+		mux.HandleFunc(e.method+" "+e.path, fe.tracingMiddleware(recoverMiddleware(e.handler)))
+	}
+	mux.HandleFunc("GET /api/openapi.json", openAPIHandler(api))
+	mux.HandleFunc("/graphql", fe.tracingMiddleware(recoverMiddleware(fe.graphqlHandler)))
+	mux.HandleFunc("/track", fe.tracingMiddleware(recoverMiddleware(fe.trackingHandler)))
+	// Event streams are long-lived, so they are not traced.
+	mux.HandleFunc("GET /events", recoverMiddleware(fe.eventsHandler))
+	mux.HandleFunc("GET /orders/{id}/receipt", fe.tracingMiddleware(recoverMiddleware(fe.receiptHandler)))
+	mux.HandleFunc("GET /robots.txt", fe.tracingMiddleware(recoverMiddleware(fe.robotsHandler)))
+	mux.HandleFunc("GET /sitemap.xml", fe.tracingMiddleware(recoverMiddleware(fe.sitemapHandler)))
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", fe.port),
+		Handler:           securityMiddleware(requestIDMiddleware(tenantMiddleware(sessionMiddleware(classifyMiddleware(fe.referralMiddleware(mux)))))),
+		ReadTimeout:       envDuration("FRONTEND_READ_TIMEOUT", 10*time.Second),
+		ReadHeaderTimeout: envDuration("FRONTEND_READ_HEADER_TIMEOUT", 5*time.Second),
+		WriteTimeout:      envDuration("FRONTEND_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:       envDuration("FRONTEND_IDLE_TIMEOUT", 120*time.Second),
+		MaxHeaderBytes:    1 << 20,
+	}
+	if v := config.Get("FRONTEND_MAX_HEADER_BYTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			srv.MaxHeaderBytes = n
+		}
+	}
+
+	// TLS is terminated here only if a certificate is configured. HTTP/2 is
+	// negotiated over TLS unless explicitly disabled.
+	certFile, keyFile := config.Get("FRONTEND_TLS_CERT_FILE"), config.Get("FRONTEND_TLS_KEY_FILE")
+	if certFile != "" && keyFile != "" {
+		if strings.ToLower(config.Get("FRONTEND_ENABLE_HTTP2")) == "false" {
+			srv.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+		}
+		log.Printf("frontendServer server running with TLS at port: %d", fe.port)
+		return srv.ListenAndServeTLS(certFile, keyFile)
+	}
 
 	log.Printf("frontendServer server running at port: %d", fe.port)
-	return http.ListenAndServe(fmt.Sprintf(":%d", fe.port), nil)
+	return srv.ListenAndServe()
+}
+
+// hedgeConfig reads when read-only calls are hedged.
+func hedgeConfig() hedge.Config {
+	return hedge.Config{
+		Delay:  envDuration("HEDGE_DELAY", 0),
+		Budget: envFloat("HEDGE_BUDGET", 0.1),
+	}
 }
 
 func (fe *frontendServer) tracingMiddleware(next http.HandlerFunc) http.HandlerFunc {
@@ -140,24 +320,212 @@ func (fe *frontendServer) tracingMiddleware(next http.HandlerFunc) http.HandlerF
 		// Explicitly set service name
 		span.SetTag("service.name", "frontend")
 		span.SetTag("span.kind", "server")
+		if id, ok := r.Context().Value(ctxKeyRequestID{}).(string); ok {
+			span.SetTag("request.id", id)
+		}
+
+		tc := requestTrafficClass(r)
+		span.SetTag("traffic.class", tc.class)
+		if tc.reason != "" {
+			span.SetTag("traffic.bot_reason", tc.reason)
+		}
 
 		log.Printf("Created span: %s for service: frontend", spanName)
 
 		// Add span to request context
 		ctx := opentracing.ContextWithSpan(r.Context(), span)
 		r = r.WithContext(ctx)
+		setRequestBaggage(r)
 
 		// Call the next handler
 		next(w, r)
 	}
 }
 
+// User tiers. Premium shoppers get free shipping.
+const (
+	userTierStandard = "standard"
+	userTierPremium  = "premium"
+)
+
+// trustTierHeader makes the frontend take the shopper's tier from the
+// X-User-Tier header, as set by a gateway in front of it, when
+// FRONTEND_TRUST_TIER_HEADER is "true". Otherwise every shopper is standard.
+var trustTierHeader = config.NewValue(func() bool {
+	return strings.ToLower(config.Get("FRONTEND_TRUST_TIER_HEADER")) == "true"
+})
+
+func userTier(r *http.Request) string {
+	if trustTierHeader.Get() && strings.ToLower(r.Header.Get("X-User-Tier")) == userTierPremium {
+		return userTierPremium
+	}
+	return userTierStandard
+}
+
+// setRequestBaggage puts the shopper's tier, ad experiment arm and tenant in
+// the baggage of the request's trace, for the backends to see.
+func setRequestBaggage(r *http.Request) {
+	ctx := r.Context()
+	tracing.SetBaggage(ctx, tracing.BaggageUserTier, userTier(r))
+	if c, _ := r.Cookie(cookieAdExperiment); c != nil && (c.Value == adExperimentControl || c.Value == adExperimentRanked) {
+		tracing.SetBaggage(ctx, tracing.BaggageExperiment, c.Value)
+	}
+	tracing.SetBaggage(ctx, tracing.BaggageTenant, tenant.FromContext(ctx))
+}
+
+// requestIDMiddleware gives every request an ID, returned in the X-Request-Id
+// header and shown on its page, so that what a shopper reports can be found
+// in the logs.
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := uuid.NewString()
+		w.Header().Set("X-Request-Id", id)
+		ctx := context.WithValue(r.Context(), ctxKeyRequestID{}, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// requestIDField is the field naming the request of ctx in the lines logged
+// about it.
+func requestIDField(ctx context.Context) zap.Field {
+	id, _ := ctx.Value(ctxKeyRequestID{}).(string)
+	return zap.String("request_id", id)
+}
+
+// tenantMiddleware attaches the tenant serving the request's host to its
+// context, so that the backends it calls act for that tenant.
+func tenantMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := tenant.NewContext(r.Context(), tenant.ForHost(r.Host))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// sessionMiddleware identifies the shopper by a session cookie, issued on
+// their first visit. The session is the user ID the backends see: it is put
+// into the request context once here and passed along as call metadata.
+func sessionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var id string
+		if c, _ := r.Cookie(cookieSessionID); c != nil && c.Value != "" {
+			id = c.Value
+		} else {
+			id = uuid.NewString()
+			http.SetCookie(w, &http.Cookie{
+				Name:   cookieSessionID,
+				Value:  id,
+				MaxAge: cookieMaxAge,
+			})
+		}
+		ctx := context.WithValue(r.Context(), ctxKeySessionID{}, id)
+		ctx = usercontext.NewContext(ctx, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// recoverMiddleware turns a panic in next into a 500 error page, logging the
+// stack and recording it on the request span instead of dropping the
+// connection.
+func recoverMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			if p == http.ErrAbortHandler {
+				// Deliberate abort; let net/http close the connection quietly.
+				panic(p)
+			}
+			stack := debug.Stack()
+			log.Printf("panic serving %s %s: %v\n%s", r.Method, r.URL.Path, p, stack)
+			if span := opentracing.SpanFromContext(r.Context()); span != nil {
+				ext.Error.Set(span, true)
+				span.LogFields(
+					otlog.String("event", "panic"),
+					otlog.String("panic", fmt.Sprint(p)),
+					otlog.String("stack", string(stack)),
+				)
+			}
+			renderHTTPError(r, w, errors.Errorf("internal error: %v", p), http.StatusInternalServerError)
+		}()
+		next(w, r)
+	}
+}
+
+// limitBody caps the request body at maxFormBytes and parses the form up
+// front, so handlers see an explicit error instead of silently empty values.
+func limitBody(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxFormBytes)
+		if err := r.ParseForm(); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				renderHTTPError(r, w, errors.Errorf("request body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+				return
+			}
+			renderHTTPError(r, w, errors.Wrap(err, "malformed form data"), http.StatusBadRequest)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// formParser reads numeric form fields, collecting an error for each value
+// that is present but not a valid number. Empty values parse as 0 and are
+// left for the payload validator to reject.
+type formParser struct {
+	r    *http.Request
+	errs []string
+}
+
+func (p *formParser) int(field string, bitSize int) int64 {
+	v := strings.TrimSpace(p.r.FormValue(field))
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.ParseInt(v, 10, bitSize)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Sprintf("Field '%s' is invalid: %q is not a valid number", field, v))
+	}
+	return n
+}
+
+func (p *formParser) uint(field string, bitSize int) uint64 {
+	v := strings.TrimSpace(p.r.FormValue(field))
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.ParseUint(v, 10, bitSize)
+	if err != nil {
+		p.errs = append(p.errs, fmt.Sprintf("Field '%s' is invalid: %q is not a valid number", field, v))
+	}
+	return n
+}
+
+// err returns the collected parse errors, or nil if there were none.
+func (p *formParser) err() error {
+	if len(p.errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(p.errs, "\n"))
+}
+
 // homeHandler handles requests to the home page with detailed timing instrumentation
 func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
-	userId := r.FormValue("user_id")
+	userId := sessionID(r)
 
 	log.Printf("homeHandler: Received request. UserID: %s, Currency: %s", userId, currentCurrency(r))
 
+	// The ad is optional, so it is fetched alongside the required data and
+	// dropped if it is not ready within the page budget.
+	deadline := time.Now().Add(pageBudget.Get())
+	adCall := skipOptional[*pb.Ad]()
+	if personalized(r) {
+		adCtx := shopperContext(w, r)
+		adCall = startOptional(func() *pb.Ad { return fe.chooseAd(adCtx, []string{}, userId) })
+	}
+
 	// 1. Retrieve currencies
 	currencies, err := fe.getCurrencies(r.Context(), userId)
 
@@ -189,45 +557,31 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 	log.Printf("homeHandler: Retrieved cart with %d items", cartSize(cart))
 
 	// 4. Process products for display with currency conversion
-	type productView struct {
-		Item  *pb.Product
-		Price *pb.Money
-	}
-	ps := make([]productView, len(products))
-
-	currencyConversionCount := 0
-	currencyConversionErrors := 0
-
-	for i, p := range products {
-		price, err := fe.convertCurrency(r.Context(), p.GetPriceUsd(), currentCurrency(r), userId)
-
-		if err != nil {
-			currencyConversionErrors++
-			log.Printf("homeHandler: Error converting currency for product %s: %v", p.GetId(), err)
-			renderHTTPError(r, w, errors.Wrapf(err, "failed to do currency conversion for product %s", p.GetId()), http.StatusInternalServerError)
-			return
-		}
-
-		currencyConversionCount++
-		ps[i] = productView{p, price}
+	ps, err := fe.productViews(r.Context(), products, currentCurrency(r), userId)
+	if err != nil {
+		log.Printf("homeHandler: Error converting currency: %v", err)
+		renderHTTPError(r, w, err, http.StatusInternalServerError)
+		return
 	}
+	defer releaseProductViews(ps)
 
-	log.Printf("homeHandler: Processed %d products with currency conversion", len(ps))
+	log.Printf("homeHandler: Processed %d products with currency conversion", len(*ps))
 
 	// 5. Get advertisement
-	ad := fe.chooseAd(r.Context(), []string{}, userId)
-
-	if ad != nil {
+	ad, ok := adCall.wait(deadline)
+	if !ok {
+		recordDegraded(r, "ad")
+	} else if ad != nil {
 		log.Printf("homeHandler: Retrieved ad: %s", ad.GetRedirectUrl())
 	}
 
 	// 6. Render template
-	err = templates.ExecuteTemplate(w, "home", injectCommonTemplateData(r, map[string]interface{}{
+	err = renderTemplate(w, "home", injectCommonTemplateData(r, map[string]interface{}{
 		"show_currency": true,
 		"currencies":    currencies,
-		"products":      ps,
+		"products":      *ps,
 		"cart_size":     cartSize(cart),
-		"banner_color":  os.Getenv("BANNER_COLOR"), // illustrates canary deployments
+		"banner_color":  config.Get("BANNER_COLOR"), // illustrates canary deployments
 		"ad":            ad,
 	}))
 
@@ -241,70 +595,78 @@ func (fe *frontendServer) homeHandler(w http.ResponseWriter, r *http.Request) {
 // placeOrderHandler handles placing an order
 func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Request) {
 	// log.Println("placeOrderHandler: placing order")
+	deadline := time.Now().Add(pageBudget.Get())
 
-	var (
-		email         = r.FormValue("email")
-		userId        = r.FormValue("user_id")
-		streetAddress = r.FormValue("street_address")
-		zipCode, _    = strconv.ParseInt(r.FormValue("zip_code"), 10, 32)
-		city          = r.FormValue("city")
-		state         = r.FormValue("state")
-		country       = r.FormValue("country")
-		ccNumber      = r.FormValue("credit_card_number")
-		ccMonth, _    = strconv.ParseInt(r.FormValue("credit_card_expiration_month"), 10, 32)
-		ccYear, _     = strconv.ParseInt(r.FormValue("credit_card_expiration_year"), 10, 32)
-		ccCVV, _      = strconv.ParseInt(r.FormValue("credit_card_cvv"), 10, 32)
-	)
-
+	userId := sessionID(r)
+	f, err := parseCheckoutForm(r)
+	if err != nil {
+		log.Printf("placeOrderHandler: invalid input: %v", err)
+		renderHTTPError(r, w, err, http.StatusUnprocessableEntity)
+		return
+	}
+	payload := f.payload
 	log.Printf("placeOrderHandler: received input - user_id: %s, email: %s, address: %s, city: %s, state: %s, country: %s, zip code: %d",
-		userId, email, streetAddress, city, state, country, zipCode)
-
-	payload := validator.PlaceOrderPayload{
-		Email:         email,
-		StreetAddress: streetAddress,
-		ZipCode:       zipCode,
-		City:          city,
-		State:         state,
-		Country:       country,
-		CcNumber:      ccNumber,
-		CcMonth:       ccMonth,
-		CcYear:        ccYear,
-		CcCVV:         ccCVV,
+		userId, payload.Email, payload.StreetAddress, payload.City, payload.State, payload.Country, payload.ZipCode)
+
+	// The form carries a one-time nonce so that submitting it twice, by
+	// double-clicking or going back and resubmitting, places a single order.
+	nonce := r.FormValue("checkout_nonce")
+	switch state, placed := fe.nonces.claim(nonce, sessionID(r)); state {
+	case nonceUsed, noncePending:
+		log.Printf("placeOrderHandler: checkout form resubmitted")
+		w.WriteHeader(http.StatusConflict)
+		if err := renderTemplate(w, "order_placed", injectCommonTemplateData(r, map[string]interface{}{
+			"show_currency": false,
+			"meta_robots":   "noindex",
+			"order":         placed,
+		})); err != nil {
+			log.Printf("placeOrderHandler: error rendering template: %v", err)
+		}
+		return
+	case nonceInvalid:
+		renderHTTPError(r, w, errors.New("the checkout form has expired, please go back to the cart and try again"), http.StatusUnprocessableEntity)
+		return
 	}
-	if err := payload.Validate(); err != nil {
-		log.Printf("placeOrderHandler: validation error: %v", err)
-		renderHTTPError(r, w, validator.ValidationErrorResponse(err), http.StatusUnprocessableEntity)
+
+	req, code, err := fe.orderRequest(r, f)
+	if err != nil {
+		fe.nonces.release(nonce)
+		renderHTTPError(r, w, err, code)
 		return
 	}
-	log.Println("placeOrderHandler: input validation successful")
-
-	checkoutClient := pb.NewCheckoutServiceClient(fe.checkoutSvcConn)
-	order, err := checkoutClient.
-		PlaceOrder(r.Context(), &pb.PlaceOrderRequest{
-			Email: payload.Email,
-			CreditCard: &pb.CreditCardInfo{
-				CreditCardNumber:          payload.CcNumber,
-				CreditCardExpirationMonth: int32(payload.CcMonth),
-				CreditCardExpirationYear:  int32(payload.CcYear),
-				CreditCardCvv:             int32(payload.CcCVV)},
-			UserId:       sessionID(r),
-			UserCurrency: currentCurrency(r),
-			Address: &pb.Address{
-				StreetAddress: payload.StreetAddress,
-				City:          payload.City,
-				State:         payload.State,
-				ZipCode:       int32(payload.ZipCode),
-				Country:       payload.Country},
-		})
+
+	// Recommendations don't depend on the order, so they are fetched while it
+	// is placed and left out if they miss the page budget. Without products
+	// to go by, they are seeded with the categories the shopper browsed.
+	categories := recentCategories(r)
+	recsCall := startOptional(func() []recommendationView {
+		recs, _ := fe.getRecommendations(r.Context(), sessionID(r), nil, categories)
+		return recs
+	})
+
+	req.Email = payload.Email
+	req.CreditCard = &pb.CreditCardInfo{
+		CreditCardNumber:          payload.CcNumber,
+		CreditCardExpirationMonth: int32(payload.CcMonth),
+		CreditCardExpirationYear:  int32(payload.CcYear),
+		CreditCardCvv:             int32(payload.CcCVV)}
+	checkoutClient := pb.NewCheckoutServiceClient(fe.checkoutSvcConn.Pick())
+	order, err := checkoutClient.PlaceOrder(r.Context(), req)
 	if err != nil {
 		log.Printf("placeOrderHandler: error placing order: %v", err)
-		renderHTTPError(r, w, errors.Wrap(err, "failed to complete the order"), http.StatusInternalServerError)
+		fe.nonces.release(nonce)
+		renderCheckoutError(r, w, errors.Wrap(err, "failed to complete the order"), f.installments)
 		return
 	}
 	log.Printf("placeOrderHandler: order placed successfully, Order ID: %s", order.GetOrder().GetOrderId())
+	fe.nonces.complete(nonce, order.GetOrder())
 
-	recommendations, _ := fe.getRecommendations(r.Context(), sessionID(r), nil)
-	log.Println("placeOrderHandler: retrieved recommendations")
+	recommendations, ok := recsCall.wait(deadline)
+	if !ok {
+		recordDegraded(r, "recommendations")
+	} else {
+		log.Println("placeOrderHandler: retrieved recommendations")
+	}
 
 	if len(recommendations) == 0 {
 		log.Println("placeOrderHandler: No recommendations available")
@@ -315,12 +677,8 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
-	totalPaid := *order.GetOrder().GetShippingCost()
-	for _, v := range order.GetOrder().GetItems() {
-		multPrice := MultiplySlow(v.GetCost(), uint32(v.GetItem().GetQuantity()))
-		totalPaid = *Must(Sum(&totalPaid, multPrice))
-	}
-	log.Printf("placeOrderHandler: total paid calculated: %d.%02d %s", totalPaid.GetUnits(), totalPaid.GetNanos()/10000000, totalPaid.GetCurrencyCode())
+	totalPaid := order.GetTotal()
+	log.Printf("placeOrderHandler: total paid: %s %s", formatAmount(totalPaid), totalPaid.GetCurrencyCode())
 
 	currencies, err := fe.getCurrencies(r.Context(), userId)
 	if err != nil {
@@ -330,11 +688,12 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 	}
 	log.Println("placeOrderHandler: retrieved currencies successfully")
 
-	err = templates.ExecuteTemplate(w, "order", injectCommonTemplateData(r, map[string]interface{}{
+	err = renderTemplate(w, "order", injectCommonTemplateData(r, map[string]interface{}{
 		"show_currency":   false,
+		"meta_robots":     "noindex",
 		"currencies":      currencies,
 		"order":           order.GetOrder(),
-		"total_paid":      &totalPaid,
+		"total_paid":      totalPaid,
 		"recommendations": recommendations,
 	}))
 	if err != nil {
@@ -344,11 +703,136 @@ func (fe *frontendServer) placeOrderHandler(w http.ResponseWriter, r *http.Reque
 	log.Println("placeOrderHandler: order page rendered successfully")
 }
 
+// variantView is a variant as shown on the product page.
+type variantView struct {
+	ID      string
+	Label   string
+	Price   *pb.Money
+	InStock bool
+}
+
+// variantLabel joins the attribute values of v, e.g. "M" for "size=M".
+func variantLabel(v *pb.ProductVariant) string {
+	values := make([]string, 0, len(v.GetAttributes()))
+	for _, attr := range v.GetAttributes() {
+		_, value, _ := strings.Cut(attr, "=")
+		values = append(values, value)
+	}
+	return strings.Join(values, " / ")
+}
+
+func (fe *frontendServer) productHandler(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/product/")
+	if id == "" {
+		renderHTTPError(r, w, errors.New("product id not specified"), http.StatusBadRequest)
+		return
+	}
+	log.Printf("productHandler: serving product page for id=%s, currency=%s", id, currentCurrency(r))
+
+	deadline := time.Now().Add(pageBudget.Get())
+	recsCall := skipOptional[[]recommendationView]()
+	adCall := skipOptional[*pb.Ad]()
+	if personalized(r) {
+		recsCall = startOptional(func() []recommendationView {
+			recs, _ := fe.getRecommendations(r.Context(), sessionID(r), []string{id}, nil)
+			return recs
+		})
+		adCtx := shopperContext(w, r)
+		adCall = startOptional(func() *pb.Ad { return fe.chooseAd(adCtx, []string{}, sessionID(r)) })
+	}
+	stockCall := startOptional(func() *stockBadge { return fe.getStockBadge(r.Context(), id) })
+
+	p, err := fe.getProduct(r.Context(), id)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve product"), http.StatusInternalServerError)
+		return
+	}
+	recordViewedCategories(w, r, p.GetCategories())
+	currencies, err := fe.getCurrencies(r.Context(), sessionID(r))
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	cart, err := fe.getCart(r.Context(), sessionID(r))
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		return
+	}
+	now := time.Now()
+	product, err := fe.productView(r.Context(), p, now, currentCurrency(r), sessionID(r))
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to convert currency"), http.StatusInternalServerError)
+		return
+	}
+	priceUSD, _ := productPrice(p, now)
+
+	variants, err := fe.getVariants(r.Context(), id)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve product variants"), http.StatusInternalServerError)
+		return
+	}
+	vs := make([]variantView, len(variants))
+	for i, v := range variants {
+		vPrice := product.Price
+		if delta := v.GetPriceDeltaUsd(); delta != nil {
+			vPriceUSD, err := Sum(priceUSD, delta)
+			if err == nil {
+				vPrice, err = fe.convertCurrency(r.Context(), vPriceUSD, currentCurrency(r), sessionID(r))
+			}
+			if err != nil {
+				renderHTTPError(r, w, errors.Wrapf(err, "failed to price variant %s", v.GetId()), http.StatusInternalServerError)
+				return
+			}
+		}
+		vs[i] = variantView{ID: v.GetId(), Label: variantLabel(v), Price: vPrice, InStock: v.GetStock() > 0}
+	}
+	soldOut := slices.DeleteFunc(slices.Clone(vs), func(v variantView) bool { return v.InStock })
+
+	recommendations, ok := recsCall.wait(deadline)
+	if !ok {
+		recordDegraded(r, "recommendations")
+	}
+	ad, ok := adCall.wait(deadline)
+	if !ok {
+		recordDegraded(r, "ad")
+	}
+	stock, ok := stockCall.wait(deadline)
+	if !ok {
+		recordDegraded(r, "stock")
+	}
+
+	err = renderTemplate(w, "product", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency":    true,
+		"currencies":       currencies,
+		"product":          product,
+		"page_title":       p.GetName(),
+		"meta_description": p.GetDescription(),
+		"og_type":          "product",
+		"og_image":         productImageURL(siteURL(r), p),
+		"variants":         vs,
+		"sold_out":         soldOut,
+		"stock":            stock,
+		"notify_ok":        r.URL.Query().Get("notify") == "ok",
+		"recommendations":  recommendations,
+		"cart_size":        cartSize(cart),
+		"ad":               ad,
+	}))
+	if err != nil {
+		log.Printf("productHandler: error rendering template: %v", err)
+	}
+}
+
 func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Request) {
 	log.Println("addToCartHandler: Start processing request")
 
-	quantity, _ := strconv.ParseUint(r.FormValue("quantity"), 10, 32)
+	form := formParser{r: r}
+	quantity := form.uint("quantity", 32)
 	productID := r.FormValue("product_id")
+	if err := form.err(); err != nil {
+		log.Printf("addToCartHandler: malformed input: %v", err)
+		renderHTTPError(r, w, err, http.StatusUnprocessableEntity)
+		return
+	}
 	log.Printf("addToCartHandler: Received product_id=%s, quantity=%d", productID, quantity)
 
 	payload := validator.AddToCartPayload{
@@ -374,10 +858,33 @@ func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Reques
 	}
 	log.Printf("addToCartHandler: Retrieved product details for product_id=%s", productID)
 
+	// Products sold in variants need an in-stock variant to be chosen
+	variants, err := fe.getVariants(r.Context(), p.GetId())
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve product variants"), http.StatusInternalServerError)
+		return
+	}
+	variantID := r.FormValue("variant_id")
+	if len(variants) > 0 || variantID != "" {
+		i := slices.IndexFunc(variants, func(v *pb.ProductVariant) bool { return v.GetId() == variantID })
+		if i < 0 {
+			renderHTTPError(r, w, errors.New(translations.T(currentLanguage(r), "product.choose_variant")), http.StatusUnprocessableEntity)
+			return
+		}
+		if stock := variants[i].GetStock(); stock < int32(payload.Quantity) {
+			renderHTTPError(r, w, errors.Errorf("only %d of %s in stock", stock, variantID), http.StatusUnprocessableEntity)
+			return
+		}
+	}
+
 	// Add product to cart
-	log.Printf("addToCartHandler: Adding product_id=%s, quantity=%d to cart", productID, payload.Quantity)
-	if err := fe.insertCart(r.Context(), sessionID(r), p.GetId(), int32(payload.Quantity)); err != nil {
+	log.Printf("addToCartHandler: Adding product_id=%s, variant_id=%s, quantity=%d to cart", productID, variantID, payload.Quantity)
+	if err := fe.insertCart(r.Context(), sessionID(r), p.GetId(), variantID, int32(payload.Quantity)); err != nil {
 		log.Printf("addToCartHandler: Error adding product_id=%s to cart: %v", productID, err)
+		if limit, ok := AsCartLimitExceeded(err); ok {
+			renderHTTPError(r, w, errors.New(cartLimitMessage(currentLanguage(r), limit)), http.StatusUnprocessableEntity)
+			return
+		}
 		renderHTTPError(r, w, errors.Wrap(err, "failed to add to cart"), http.StatusInternalServerError)
 		return
 	}
@@ -389,8 +896,163 @@ func (fe *frontendServer) addToCartHandler(w http.ResponseWriter, r *http.Reques
 	log.Println("addToCartHandler: Redirected to /cart")
 }
 
+// notifyWhenAvailableHandler subscribes the shopper to a sold out variant and
+// sends them back to the product page.
+func (fe *frontendServer) notifyWhenAvailableHandler(w http.ResponseWriter, r *http.Request) {
+	payload := validator.NotifyWhenAvailablePayload{
+		Email:     strings.TrimSpace(r.FormValue("email")),
+		ProductID: r.FormValue("product_id"),
+	}
+	if err := payload.Validate(); err != nil {
+		renderHTTPError(r, w, validator.ValidationErrorResponse(err), http.StatusUnprocessableEntity)
+		return
+	}
+	variantID := r.FormValue("variant_id")
+	log.Printf("notifyWhenAvailableHandler: subscribing to product_id=%s, variant_id=%s", payload.ProductID, variantID)
+
+	productCatalogClient := pb.NewProductCatalogServiceClient(fe.productCatalogSvcConn.Pick())
+	_, err := productCatalogClient.NotifyWhenAvailable(r.Context(), &pb.NotifyWhenAvailableRequest{
+		Email:     payload.Email,
+		ProductId: payload.ProductID,
+		VariantId: variantID,
+		Locale:    currentLanguage(r),
+	})
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not subscribe to product availability"), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("location", "/product/"+url.PathEscape(payload.ProductID)+"?notify=ok")
+	w.WriteHeader(http.StatusFound)
+}
+
+// trackingHandler shows the current status of a shipment
+func (fe *frontendServer) trackingHandler(w http.ResponseWriter, r *http.Request) {
+	trackingID := strings.TrimSpace(r.FormValue("tracking_id"))
+	if trackingID == "" {
+		renderHTTPError(r, w, errors.New("tracking_id is required"), http.StatusBadRequest)
+		return
+	}
+
+	shippingClient := pb.NewShippingServiceClient(fe.shippingSvcConn.Pick())
+	shipment, err := shippingClient.GetShipment(r.Context(), &pb.GetShipmentRequest{TrackingId: trackingID})
+	if err != nil {
+		log.Printf("trackingHandler: error retrieving shipment %s: %v", trackingID, err)
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve shipment"), http.StatusNotFound)
+		return
+	}
+
+	err = renderTemplate(w, "tracking", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency": false,
+		"meta_robots":   "noindex",
+		"shipment":      shipment,
+		"updated_at":    time.Unix(shipment.GetUpdatedAt(), 0).UTC().Format(time.RFC1123),
+	}))
+	if err != nil {
+		log.Printf("trackingHandler: error rendering template: %v", err)
+	}
+}
+
+// receiptHandler downloads the PDF receipt of an order of the shopper.
+func (fe *frontendServer) receiptHandler(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+	emailClient := pb.NewEmailServiceClient(fe.emailSvcConn.Pick())
+	resp, err := emailClient.GetReceipt(r.Context(), &pb.GetReceiptRequest{OrderId: orderID})
+	if err != nil {
+		log.Printf("receiptHandler: error retrieving receipt of order %s: %v", orderID, err)
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve receipt"), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "receipt-"+orderID+".pdf"))
+	if _, err := io.WriteString(w, resp.GetPdf()); err != nil {
+		log.Printf("receiptHandler: error writing receipt: %v", err)
+	}
+}
+
+// setCurrencyHandler stores the chosen currency in a cookie and sends the
+// user back to the page they came from. A currency missing from the cached
+// list may mean the list is stale, so it is refetched before rejecting.
+func (fe *frontendServer) setCurrencyHandler(w http.ResponseWriter, r *http.Request) {
+	cur := strings.ToUpper(r.FormValue("currency_code"))
+	currencies, err := fe.getCurrencies(r.Context(), sessionID(r))
+	if err == nil && !slices.Contains(currencies, cur) {
+		fe.fragments.invalidate(fragmentCurrencies)
+		currencies, err = fe.getCurrencies(r.Context(), sessionID(r))
+	}
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	if !slices.Contains(currencies, cur) {
+		renderHTTPError(r, w, errors.Errorf("unsupported currency %q", cur), http.StatusUnprocessableEntity)
+		return
+	}
+	log.Printf("setCurrencyHandler: setting currency to %s", cur)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:   cookieCurrency,
+		Value:  cur,
+		MaxAge: cookieMaxAge,
+	})
+	referer := r.Header.Get("referer")
+	if referer == "" {
+		referer = "/"
+	}
+	w.Header().Set("Location", referer)
+	w.WriteHeader(http.StatusFound)
+}
+
+// setLanguageHandler stores the chosen language in a cookie and sends the
+// user back to the page they came from.
+func (fe *frontendServer) setLanguageHandler(w http.ResponseWriter, r *http.Request) {
+	lang := strings.ToLower(r.FormValue("language"))
+	if !translations.Supports(lang) {
+		renderHTTPError(r, w, errors.Errorf("unsupported language %q", lang), http.StatusUnprocessableEntity)
+		return
+	}
+	log.Printf("setLanguageHandler: setting language to %s", lang)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:   cookieLanguage,
+		Value:  lang,
+		MaxAge: cookieMaxAge,
+	})
+	referer := r.Header.Get("referer")
+	if referer == "" {
+		referer = "/"
+	}
+	w.Header().Set("Location", referer)
+	w.WriteHeader(http.StatusFound)
+}
+
+// validateAddress returns the normalized address and any per-field problems.
+func (fe *frontendServer) validateAddress(ctx context.Context, userID string, address *pb.Address) (*pb.Address, []*pb.AddressProblem, error) {
+	addressClient := pb.NewAddressServiceClient(fe.addressSvcConn.Pick())
+	resp, err := addressClient.ValidateAddress(ctx, &pb.ValidateAddressRequest{
+		Address: address,
+		UserId:  userID})
+	if err != nil {
+		log.Printf("validateAddress RPC failed: %v", err)
+		return nil, nil, err
+	}
+	return resp.GetNormalized(), resp.GetProblems(), nil
+}
+
+// addressProblemsError formats address problems like validator.ValidationErrorResponse.
+func addressProblemsError(problems []*pb.AddressProblem) error {
+	var msg string
+	for _, p := range problems {
+		msg += fmt.Sprintf("Field '%s' is invalid: %s\n", p.GetField(), p.GetMessage())
+	}
+	return errors.New(msg)
+}
+
 func (fe *frontendServer) getCurrencies(ctx context.Context, userID string) ([]string, error) {
-	currencyClient := pb.NewCurrencyServiceClient(fe.currencySvcConn)
+	if v, ok := fe.fragments.get(ctx, fragmentCurrencies); ok {
+		return v.([]string), nil
+	}
+	currencyClient := pb.NewCurrencyServiceClient(fe.currencySvcConn.Pick())
 	currs, err := currencyClient.
 		GetSupportedCurrencies(ctx, &pb.EmptyUser{UserId: userID})
 
@@ -399,41 +1061,66 @@ func (fe *frontendServer) getCurrencies(ctx context.Context, userID string) ([]s
 		return nil, err
 	}
 
-	var out []string
-	for _, c := range currs.CurrencyCodes {
-		if _, ok := whitelistedCurrencies[c]; ok {
-			out = append(out, c)
-		}
-	}
+	// The currency service curates and orders the list.
+	out := currs.GetCurrencyCodes()
 
 	log.Printf("getCurrencies RPC completed, returned %d currencies", len(out))
+	fe.fragments.set(ctx, fragmentCurrencies, out)
 	return out, nil
 }
 
 func (fe *frontendServer) getProducts(ctx context.Context, userID string) ([]*pb.Product, error) {
-	productCatalogClient := pb.NewProductCatalogServiceClient(fe.productCatalogSvcConn)
-	resp, err := productCatalogClient.
-		ListProducts(ctx, &pb.EmptyUser{UserId: userID})
+	if v, ok := fe.fragments.get(ctx, fragmentProducts); ok {
+		return v.([]*pb.Product), nil
+	}
+	resp, err := hedge.Do(ctx, fe.hedger, fe.productCatalogSvcConn.Pick,
+		func(ctx context.Context, c *rpc.Client) (*pb.ListProductsResponse, error) {
+			return pb.NewProductCatalogServiceClient(c).ListProducts(ctx, &pb.EmptyUser{UserId: userID})
+		})
 
 	if err != nil {
 		log.Printf("getProducts RPC failed: %v", err)
 		return nil, err
 	}
 
-	products := resp.GetProducts()
+	products := make([]*pb.Product, len(resp.GetProducts()))
+	for i, p := range resp.GetProducts() {
+		products[i] = sanitizeProduct(p)
+	}
 	log.Printf("getProducts RPC completed, returned %d products", len(products))
+	fe.fragments.set(ctx, fragmentProducts, products)
 	return products, err
 }
 
 func (fe *frontendServer) getProduct(ctx context.Context, id string) (*pb.Product, error) {
-	productCatalogClient := pb.NewProductCatalogServiceClient(fe.productCatalogSvcConn)
-	resp, err := productCatalogClient.
-		GetProduct(ctx, &pb.GetProductRequest{Id: id})
-	return resp, err
+	return fe.productCache.get(ctx, id)
+}
+
+func (fe *frontendServer) fetchProduct(ctx context.Context, id string) (*pb.Product, error) {
+	return hedge.Do(ctx, fe.hedger, fe.productCatalogSvcConn.Pick,
+		func(ctx context.Context, c *rpc.Client) (*pb.Product, error) {
+			p, err := pb.NewProductCatalogServiceClient(c).GetProduct(ctx, &pb.GetProductRequest{Id: id})
+			return sanitizeProduct(p), err
+		})
+}
+
+func (fe *frontendServer) fetchProducts(ctx context.Context, ids []string) ([]*pb.Product, error) {
+	resp, err := hedge.Do(ctx, fe.hedger, fe.productCatalogSvcConn.Pick,
+		func(ctx context.Context, c *rpc.Client) (*pb.ListProductsResponse, error) {
+			return pb.NewProductCatalogServiceClient(c).GetProducts(ctx, &pb.GetProductsRequest{Ids: ids})
+		})
+	if err != nil {
+		return nil, err
+	}
+	products := make([]*pb.Product, len(resp.GetProducts()))
+	for i, p := range resp.GetProducts() {
+		products[i] = sanitizeProduct(p)
+	}
+	return products, nil
 }
 
 func (fe *frontendServer) getCart(ctx context.Context, userID string) ([]*pb.CartItem, error) {
-	cartClient := pb.NewCartServiceClient(fe.cartSvcConn)
+	cartClient := pb.NewCartServiceClient(fe.cartSvcConn.Pick())
 	resp, err := cartClient.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
 
 	if err != nil {
@@ -446,64 +1133,111 @@ func (fe *frontendServer) getCart(ctx context.Context, userID string) ([]*pb.Car
 	return items, err
 }
 
-func (fe *frontendServer) insertCart(ctx context.Context, userID, productID string, quantity int32) error {
-	cartClient := pb.NewCartServiceClient(fe.cartSvcConn)
+func (fe *frontendServer) insertCart(ctx context.Context, userID, productID, variantID string, quantity int32) error {
+	cartClient := pb.NewCartServiceClient(fe.cartSvcConn.Pick())
 	_, err := cartClient.AddItem(ctx, &pb.AddItemRequest{
 		UserId: userID,
 		Item: &pb.CartItem{
 			ProductId: productID,
+			VariantId: variantID,
 			Quantity:  quantity},
 	})
 	return err
 }
 
+func (fe *frontendServer) getVariants(ctx context.Context, productID string) ([]*pb.ProductVariant, error) {
+	productCatalogClient := pb.NewProductCatalogServiceClient(fe.productCatalogSvcConn.Pick())
+	resp, err := productCatalogClient.ListVariants(ctx, &pb.ListVariantsRequest{ProductId: productID})
+	if err != nil {
+		log.Printf("getVariants RPC failed: %v", err)
+		return nil, err
+	}
+	return resp.GetVariants(), nil
+}
+
 func (fe *frontendServer) convertCurrency(ctx context.Context, money *pb.Money, currency string, userID string) (*pb.Money, error) {
 	if money.GetCurrencyCode() == currency {
 		return money, nil
 	}
 
-	currencyClient := pb.NewCurrencyServiceClient(fe.currencySvcConn)
-	result, err := currencyClient.
-		Convert(ctx, &pb.CurrencyConversionRequest{
-			From:   money,
-			ToCode: currency,
-			UserId: userID})
+	result, err := hedge.Do(ctx, fe.hedger, fe.currencySvcConn.Pick,
+		func(ctx context.Context, c *rpc.Client) (*pb.CurrencyConversionResponse, error) {
+			return pb.NewCurrencyServiceClient(c).Convert(ctx, &pb.CurrencyConversionRequest{
+				From:   money,
+				ToCode: currency,
+				UserId: userID})
+		})
 
 	if err != nil {
 		log.Printf("convertCurrency RPC failed: %v", err)
 		return nil, err
 	}
 
-	log.Printf("convertCurrency RPC completed: %s -> %s", money.GetCurrencyCode(), currency)
-	return result, err
+	log.Printf("convertCurrency RPC completed: %s -> %s at rate %s", money.GetCurrencyCode(), currency, result.GetAppliedRate())
+	return result.GetMoney(), err
 }
 
-func (fe *frontendServer) getRecommendations(ctx context.Context, userID string, productIDs []string) ([]*pb.Product, error) {
-	recommendationClient := pb.NewRecommendationServiceClient(fe.recommendationSvcConn)
-	resp, err := recommendationClient.ListRecommendations(ctx,
-		&pb.ListRecommendationsRequest{UserId: userID, ProductIds: productIDs})
+// getExchangeRate returns the from -> to rate with the time it dates from,
+// to show next to converted prices. It returns nil if the currencies are the
+// same.
+func (fe *frontendServer) getExchangeRate(ctx context.Context, from, to string) (*pb.ExchangeRateResponse, error) {
+	if from == to {
+		return nil, nil
+	}
+	currencyClient := pb.NewCurrencyServiceClient(fe.currencySvcConn.Pick())
+	resp, err := currencyClient.GetExchangeRate(ctx, &pb.ExchangeRateRequest{FromCode: from, ToCode: to})
 	if err != nil {
+		log.Printf("getExchangeRate RPC failed: %v", err)
 		return nil, err
 	}
-	out := make([]*pb.Product, len(resp.GetProductIds()))
-	for i, v := range resp.GetProductIds() {
-		p, err := fe.getProduct(ctx, v)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get recommended product info (#%s)", v)
-		}
-		out[i] = p
+	return resp, nil
+}
+
+// recommendationView is a recommended product with the i18n key and argument
+// of the reason it was recommended.
+type recommendationView struct {
+	*pb.Product
+	ReasonKey    string
+	ReasonDetail string
+}
+
+func (fe *frontendServer) getRecommendations(ctx context.Context, userID string, productIDs, categories []string) ([]recommendationView, error) {
+	recommendationClient := pb.NewRecommendationServiceClient(fe.recommendationSvcConn.Pick())
+	resp, err := recommendationClient.ListRecommendations(ctx, &pb.ListRecommendationsRequest{
+		UserId:      userID,
+		ProductIds:  productIDs,
+		PageContext: &pb.PageContext{Categories: categories},
+	})
+	if err != nil {
+		return nil, err
+	}
+	recs := resp.GetRecommendations()
+	if len(recs) > 4 {
+		recs = recs[:4] // take only first four to fit the UI
 	}
-	if len(out) > 4 {
-		out = out[:4] // take only first four to fit the UI
+	ids := make([]string, len(recs))
+	for i, rec := range recs {
+		ids[i] = rec.GetProductId()
 	}
-	return out, err
+	products, err := fe.productCache.getMany(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get recommended product info (%q)", ids)
+	}
+	out := make([]recommendationView, len(recs))
+	for i, rec := range recs {
+		out[i] = recommendationView{Product: products[i], ReasonDetail: rec.GetReasonDetail()}
+		if reason := rec.GetReason(); reason != "" {
+			out[i].ReasonKey = "recommendation." + strings.ToLower(reason)
+		}
+	}
+	return out, nil
 }
 
 func (fe *frontendServer) getAd(ctx context.Context, ctxKeys []string, userID string) ([]*pb.Ad, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*100)
 	defer cancel()
 
-	adClient := pb.NewAdServiceClient(fe.adSvcConn)
+	adClient := pb.NewAdServiceClient(fe.adSvcConn.Pick())
 	resp, err := adClient.GetAds(ctx, &pb.AdRequest{
 		ContextKeys: ctxKeys,
 		UserId:      userID,
@@ -514,7 +1248,7 @@ func (fe *frontendServer) getAd(ctx context.Context, ctxKeys []string, userID st
 		return nil, errors.Wrap(err, "failed to get ads")
 	}
 
-	ads := resp.GetAds()
+	ads := sanitizeAds(resp.GetAds())
 	log.Printf("getAd RPC completed, returned %d ads", len(ads))
 	return ads, nil
 }
@@ -527,6 +1261,15 @@ func currentCurrency(r *http.Request) string {
 	return defaultCurrency
 }
 
+// currentLanguage returns the language from the cookie if set, otherwise the
+// best match for the Accept-Language header.
+func currentLanguage(r *http.Request) string {
+	if c, _ := r.Cookie(cookieLanguage); c != nil && translations.Supports(c.Value) {
+		return strings.ToLower(c.Value)
+	}
+	return translations.Match(r.Header.Get("Accept-Language"))
+}
+
 func sessionID(r *http.Request) string {
 	v := r.Context().Value(ctxKeySessionID{})
 	if v != nil {
@@ -537,13 +1280,19 @@ func sessionID(r *http.Request) string {
 
 // renderHTTPError renders an error page and logs the error
 func renderHTTPError(r *http.Request, w http.ResponseWriter, err error, code int) {
-	log.Printf("renderHTTPError: request error: %v", err)
+	rpclog.Warn(r.Context(), "request failed", requestIDField(r.Context()), zap.String("path", r.URL.Path), zap.Int("code", code), zap.Error(err))
 
-	errMsg := fmt.Sprintf("%+v", err)
+	// Errors carry text from the backends, so shoppers are only shown the
+	// request ID to quote, unless FRONTEND_SHOW_ERRORS is "true".
+	errMsg := ""
+	if showErrors.Get() {
+		errMsg = fmt.Sprintf("%+v", err)
+	}
 	w.WriteHeader(code)
 
 	// Attempt to render the error page
-	templateErr := templates.ExecuteTemplate(w, "error", injectCommonTemplateData(r, map[string]interface{}{
+	templateErr := renderTemplate(w, "error", injectCommonTemplateData(r, map[string]interface{}{
+		"meta_robots": "noindex",
 		"error":       errMsg,
 		"status_code": code,
 		"status":      http.StatusText(code),
@@ -553,9 +1302,59 @@ func renderHTTPError(r *http.Request, w http.ResponseWriter, err error, code int
 	}
 }
 
+// renderMoney formats an amount with the symbol of its currency and as many
+// decimals as the currency has, e.g. "$12.50", "¥1250" or, for a refund,
+// "-€3.00".
 func renderMoney(money *pb.Money) string {
-	currencyLogo := renderCurrencyLogo(money.GetCurrencyCode())
-	return fmt.Sprintf("%s%d.%02d", currencyLogo, money.GetUnits(), money.GetNanos()/10000000)
+	neg, digits := amountDigits(money)
+	if neg {
+		return "-" + renderCurrencyLogo(money.GetCurrencyCode()) + digits
+	}
+	return renderCurrencyLogo(money.GetCurrencyCode()) + digits
+}
+
+// renderMoneyOrFree formats an amount like renderMoney, or as "FREE" in lang
+// if it is zero, for free items and shipping.
+func renderMoneyOrFree(lang string, money *pb.Money) string {
+	if money != nil && IsZero(money) {
+		return translations.T(lang, "money.free")
+	}
+	return renderMoney(money)
+}
+
+// renderDiscount formats an amount taken off a price, which the order
+// breakdown holds as a positive amount, with a minus sign.
+func renderDiscount(money *pb.Money) string {
+	if IsZero(money) {
+		return renderMoney(money)
+	}
+	neg := Negate(money)
+	return renderMoney(&neg)
+}
+
+// formatAmount formats the amount of money, without a currency, to the
+// decimals of its currency, e.g. "-3.05".
+func formatAmount(money *pb.Money) string {
+	neg, digits := amountDigits(money)
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// amountDigits returns whether money is negative and its absolute amount to
+// the decimals of its currency. Fractions beyond them are dropped.
+func amountDigits(money *pb.Money) (bool, string) {
+	units, nanos := money.GetUnits(), money.GetNanos()
+	neg := units < 0 || nanos < 0
+	if neg {
+		units, nanos = -units, -nanos
+	}
+	decimals := MinorUnitsOf(money.GetCurrencyCode())
+	if decimals == 0 {
+		return neg, strconv.FormatInt(units, 10)
+	}
+	return neg, fmt.Sprintf("%d.%0*d", units, decimals, int64(nanos)/minorUnitNanos(money.GetCurrencyCode()))
 }
 
 func renderCurrencyLogo(currencyCode string) string {
@@ -576,16 +1375,23 @@ func renderCurrencyLogo(currencyCode string) string {
 }
 
 func injectCommonTemplateData(r *http.Request, payload map[string]interface{}) map[string]interface{} {
+	cymbal := isCymbalBrand.Get()
+	if t := tenant.Get(tenant.FromContext(r.Context())); t != nil && t.Branding != "" {
+		cymbal = t.Branding == tenant.BrandingCymbal
+	}
 	data := map[string]interface{}{
 		"session_id":        sessionID(r),
 		"request_id":        r.Context().Value(ctxKeyRequestID{}),
 		"user_currency":     currentCurrency(r),
+		"lang":              currentLanguage(r),
+		"languages":         translations.Languages(),
 		"platform_css":      plat.css,
 		"platform_name":     plat.provider,
-		"is_cymbal_brand":   isCymbalBrand,
-		"assistant_enabled": assistantEnabled,
-		"frontendMessage":   frontendMessage,
+		"is_cymbal_brand":   cymbal,
+		"assistant_enabled": assistantEnabled.Get(),
+		"frontendMessage":   frontendMessage.Get(),
 		"currentYear":       time.Now().Year(),
+		"canonical_url":     siteURL(r) + r.URL.Path,
 	}
 
 	for k, v := range payload {
@@ -604,15 +1410,18 @@ func cartSize(c []*pb.CartItem) int {
 	return cartSize
 }
 
-// chooseAd queries for advertisements available and randomly chooses one, if
-// available. It ignores the error retrieving the ad since it is not critical.
+// chooseAd queries for advertisements available and chooses the first one,
+// which the ad service ranks best, if available. It ignores the error
+// retrieving the ad since it is not critical.
 func (fe *frontendServer) chooseAd(ctx context.Context, ctxKeys []string, userId string) *pb.Ad {
 	ads, err := fe.getAd(ctx, ctxKeys, userId)
 	if err != nil {
 		log.Printf("chooseAd: failed to retrieve ads: %v", err)
 		return nil
 	}
+	if len(ads) == 0 {
+		return nil
+	}
 
-	// Choose a random ad from the retrieved ads
-	return ads[rand.Intn(len(ads))]
+	return ads[0]
 }
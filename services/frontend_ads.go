@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// maxRecentCategories caps the viewed categories remembered per shopper.
+const maxRecentCategories = 5
+
+// shopperContext returns the request context carrying the shopper's currency,
+// ad experiment arm, recently viewed categories and ad session as outgoing
+// metadata, for the ad service to rank and cap ads with. Shoppers new to the
+// experiment are assigned an arm at random, which sticks through a cookie,
+// as does the ad session.
+func shopperContext(w http.ResponseWriter, r *http.Request) context.Context {
+	arm := adExperimentControl
+	if c, _ := r.Cookie(cookieAdExperiment); c != nil && (c.Value == adExperimentControl || c.Value == adExperimentRanked) {
+		arm = c.Value
+	} else {
+		if rand.Intn(2) == 0 {
+			arm = adExperimentRanked
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:   cookieAdExperiment,
+			Value:  arm,
+			MaxAge: cookieMaxAge,
+		})
+	}
+
+	var session string
+	if c, _ := r.Cookie(cookieAdSession); c != nil {
+		session = c.Value
+	} else {
+		session = uuid.NewString()
+		http.SetCookie(w, &http.Cookie{
+			Name:   cookieAdSession,
+			Value:  session,
+			MaxAge: cookieMaxAge,
+		})
+	}
+
+	return metadata.AppendToOutgoingContext(r.Context(),
+		mdShopCurrency, currentCurrency(r),
+		mdShopExperiment, arm,
+		mdShopRecentCategories, strings.Join(recentCategories(r), ","),
+		mdShopSession, session,
+	)
+}
+
+// recentCategories returns the categories of the products the shopper viewed
+// last, most recent first.
+func recentCategories(r *http.Request) []string {
+	c, _ := r.Cookie(cookieRecentCategories)
+	if c == nil || c.Value == "" {
+		return nil
+	}
+	return strings.Split(c.Value, "|")
+}
+
+// recordViewedCategories moves categories to the front of the shopper's
+// recently viewed categories.
+func recordViewedCategories(w http.ResponseWriter, r *http.Request, categories []string) {
+	recent := slices.Clone(categories)
+	for _, c := range recentCategories(r) {
+		if !slices.Contains(recent, c) {
+			recent = append(recent, c)
+		}
+	}
+	recent = recent[:min(len(recent), maxRecentCategories)]
+	http.SetCookie(w, &http.Cookie{
+		Name:   cookieRecentCategories,
+		Value:  strings.Join(recent, "|"),
+		MaxAge: cookieMaxAge,
+	})
+}
+
+// adClickHandler records a click on an ad and redirects to the ad's page.
+// Only product pages are accepted as targets, so the handler cannot be used
+// to redirect elsewhere.
+func (fe *frontendServer) adClickHandler(w http.ResponseWriter, r *http.Request) {
+	to := r.FormValue("to")
+	if !strings.HasPrefix(to, "/product/") {
+		renderHTTPError(r, w, errors.Errorf("invalid ad target %q", to), http.StatusBadRequest)
+		return
+	}
+
+	adClient := pb.NewAdServiceClient(fe.adSvcConn.Pick())
+	if _, err := adClient.RecordAdClick(shopperContext(w, r), &pb.AdClickRequest{RedirectUrl: to}); err != nil {
+		log.Printf("adClickHandler: failed to record click on %s: %v", to, err)
+	}
+
+	w.Header().Set("location", to)
+	w.WriteHeader(http.StatusFound)
+}
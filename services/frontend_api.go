@@ -0,0 +1,186 @@
+package services
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// apiEndpoint is an operation of the REST API under /api/v1. The routes of
+// the API and its OpenAPI document are both made from the endpoints, so the
+// document describes what is actually served.
+type apiEndpoint struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	params      []apiParam
+	// response is a value of the type of the JSON body of a successful
+	// response, from which its schema is derived.
+	response any
+	// errors describes the error responses by status code.
+	errors  map[int]string
+	handler http.HandlerFunc
+}
+
+// apiParam is a query parameter of an endpoint.
+type apiParam struct {
+	name        string
+	description string
+	required    bool
+}
+
+// apiEndpoints returns the endpoints of the REST API.
+func (fe *frontendServer) apiEndpoints() []apiEndpoint {
+	return []apiEndpoint{
+		{
+			method:      http.MethodGet,
+			path:        "/api/v1/suggest",
+			operationID: "suggestProducts",
+			summary:     "Suggest products whose name completes a search query as it is typed.",
+			params: []apiParam{
+				{name: "q", description: "The query typed so far. No products are suggested for an empty query."},
+			},
+			response: suggestResponse{},
+			errors: map[int]string{
+				http.StatusServiceUnavailable: "The catalog could not be reached.",
+			},
+			handler: fe.suggestHandler,
+		},
+	}
+}
+
+// openAPIDocument returns the OpenAPI 3 description of endpoints.
+func openAPIDocument(endpoints []apiEndpoint) map[string]any {
+	schemas := make(map[string]any)
+	paths := make(map[string]any)
+	for _, e := range endpoints {
+		var params []any
+		for _, p := range e.params {
+			params = append(params, map[string]any{
+				"name":        p.name,
+				"in":          "query",
+				"description": p.description,
+				"required":    p.required,
+				"schema":      map[string]any{"type": "string"},
+			})
+		}
+		responses := map[string]any{
+			"200": map[string]any{
+				"description": "OK",
+				"content": map[string]any{
+					"application/json": map[string]any{"schema": jsonSchema(reflect.TypeOf(e.response), schemas)},
+				},
+			},
+		}
+		for code, description := range e.errors {
+			responses[strconv.Itoa(code)] = map[string]any{"description": description}
+		}
+		op := map[string]any{
+			"operationId": e.operationID,
+			"summary":     e.summary,
+			"responses":   responses,
+		}
+		if params != nil {
+			op["parameters"] = params
+		}
+		item, _ := paths[e.path].(map[string]any)
+		if item == nil {
+			item = make(map[string]any)
+			paths[e.path] = item
+		}
+		item[strings.ToLower(e.method)] = op
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "Online Boutique API",
+			"version": "v1",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": schemas},
+	}
+}
+
+// jsonSchema returns the schema of the JSON encoding of t. Named structs are
+// added to schemas, under their name capitalized, and referred to.
+func jsonSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return jsonSchema(t.Elem(), schemas)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int32, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchema(t.Elem(), schemas)}
+	case reflect.Struct:
+		name := t.Name()
+		if name == "" {
+			return structSchema(t, schemas)
+		}
+		r, size := utf8.DecodeRuneInString(name)
+		name = string(unicode.ToUpper(r)) + name[size:]
+		if _, ok := schemas[name]; !ok {
+			// Set first, for types that refer to themselves.
+			schemas[name] = nil
+			schemas[name] = structSchema(t, schemas)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]any{}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	props := make(map[string]any)
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = jsonSchema(f.Type, schemas)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	s := map[string]any{"type": "object", "properties": props}
+	if required != nil {
+		s["required"] = required
+	}
+	return s
+}
+
+// openAPIHandler serves the OpenAPI document of the REST API, encoded once.
+func openAPIHandler(endpoints []apiEndpoint) http.HandlerFunc {
+	doc, err := json.MarshalIndent(openAPIDocument(endpoints), "", "  ")
+	if err != nil {
+		log.Fatalf("failed to encode the OpenAPI document: %v", err)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(doc); err != nil {
+			log.Printf("openAPIHandler: error writing response: %v", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"strings"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// Traffic classes.
+const (
+	trafficHuman = "human"
+	trafficBot   = "bot"
+)
+
+// botUserAgents are user agent substrings, in lower case, of crawlers,
+// scripts and headless browsers. BOT_USER_AGENTS adds to them.
+var botUserAgents = []string{
+	"bot", "crawl", "spider", "slurp", "curl", "wget", "python-requests",
+	"go-http-client", "okhttp", "java/", "libwww", "httpclient", "scrapy",
+	"headless", "phantomjs", "lighthouse",
+}
+
+var extraBotUserAgents = config.NewValue(func() []string {
+	var uas []string
+	for _, ua := range envList("BOT_USER_AGENTS", nil) {
+		uas = append(uas, strings.ToLower(ua))
+	}
+	return uas
+})
+
+// botLitePages serves bots pages without personalization, skipping the ad
+// and recommendation calls, when FRONTEND_BOT_LITE is "true".
+var botLitePages = config.NewValue(func() bool {
+	return strings.ToLower(config.Get("FRONTEND_BOT_LITE")) == "true"
+})
+
+// trafficByClass counts requests by traffic class, and botsByReason counts
+// bot requests by the heuristic that flagged them.
+var (
+	trafficByClass = expvar.NewMap("frontend_traffic_class")
+	botsByReason   = expvar.NewMap("frontend_bot_reasons")
+)
+
+// trafficClass is how a request was classified, with the reason for bots.
+type trafficClass struct {
+	class  string
+	reason string
+}
+
+type ctxKeyTrafficClass struct{}
+
+// classifyTraffic guesses whether r comes from a person's browser. Besides
+// known user agents, it flags requests lacking the headers every browser
+// sends.
+func classifyTraffic(r *http.Request) trafficClass {
+	ua := strings.ToLower(r.UserAgent())
+	if ua == "" {
+		return trafficClass{trafficBot, "no-user-agent"}
+	}
+	for _, list := range [][]string{botUserAgents, extraBotUserAgents.Get()} {
+		for _, s := range list {
+			if strings.Contains(ua, s) {
+				return trafficClass{trafficBot, "user-agent"}
+			}
+		}
+	}
+	if r.Header.Get("Accept") == "" {
+		return trafficClass{trafficBot, "no-accept"}
+	}
+	if r.Header.Get("Accept-Language") == "" {
+		return trafficClass{trafficBot, "no-accept-language"}
+	}
+	return trafficClass{class: trafficHuman}
+}
+
+// classifyMiddleware classifies each request and counts it. The class is
+// tagged on the request span by tracingMiddleware.
+func classifyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tc := classifyTraffic(r)
+		trafficByClass.Add(tc.class, 1)
+		if tc.class == trafficBot {
+			botsByReason.Add(tc.reason, 1)
+		}
+		ctx := context.WithValue(r.Context(), ctxKeyTrafficClass{}, tc)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func requestTrafficClass(r *http.Request) trafficClass {
+	if tc, ok := r.Context().Value(ctxKeyTrafficClass{}).(trafficClass); ok {
+		return tc
+	}
+	return trafficClass{class: trafficHuman}
+}
+
+// personalized reports whether r gets ads and recommendations.
+func personalized(r *http.Request) bool {
+	return !botLitePages.Get() || requestTrafficClass(r).class != trafficBot
+}
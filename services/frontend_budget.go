@@ -0,0 +1,72 @@
+package services
+
+import (
+	"expvar"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/opentracing/opentracing-go"
+)
+
+// pageBudget is how long a page may wait for its optional sections, such as
+// ads and recommendations, counted from the start of the request.
+var pageBudget = config.NewValue(func() time.Duration {
+	return envDuration("FRONTEND_PAGE_BUDGET", 500*time.Millisecond)
+})
+
+// degradedSections counts pages rendered without a section, keyed by section.
+// Like the other counters, it is served at /debug/vars on the status server,
+// not the storefront.
+var degradedSections = expvar.NewMap("frontend_degraded_sections")
+
+// optionalCall is a page section fetched in the background while the
+// required data is loaded.
+type optionalCall[T any] struct {
+	done chan T
+}
+
+func startOptional[T any](fetch func() T) *optionalCall[T] {
+	o := &optionalCall[T]{done: make(chan T, 1)}
+	go func() { o.done <- fetch() }()
+	return o
+}
+
+// skipOptional returns a section left out on purpose, which is ready at once
+// with no result.
+func skipOptional[T any]() *optionalCall[T] {
+	o := &optionalCall[T]{done: make(chan T, 1)}
+	var zero T
+	o.done <- zero
+	return o
+}
+
+// wait returns the result if it is ready by deadline. A result that is
+// already available is returned even if the deadline has passed.
+func (o *optionalCall[T]) wait(deadline time.Time) (T, bool) {
+	select {
+	case v := <-o.done:
+		return v, true
+	default:
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case v := <-o.done:
+		return v, true
+	case <-timer.C:
+		var zero T
+		return zero, false
+	}
+}
+
+// recordDegraded notes that section was left out of the page for r.
+func recordDegraded(r *http.Request, section string) {
+	log.Printf("%s: rendering without %s, page budget of %v exceeded", r.URL.Path, section, pageBudget.Get())
+	degradedSections.Add(section, 1)
+	if span := opentracing.SpanFromContext(r.Context()); span != nil {
+		span.SetTag("degraded", true)
+		span.LogKV("event", "degraded", "section", section)
+	}
+}
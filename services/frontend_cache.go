@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// Keys of the fragments cached by the frontend.
+const (
+	fragmentCurrencies = "currencies"
+	fragmentProducts   = "products"
+)
+
+// fragmentCache holds rarely changing RPC results that every page render
+// needs, each for a short TTL. Entries are kept per tenant.
+type fragmentCache struct {
+	mu      sync.Mutex
+	ttls    map[string]time.Duration
+	entries map[cacheKey]fragmentEntry
+}
+
+// cacheKey identifies a cache entry within a tenant.
+type cacheKey struct {
+	tenant string
+	key    string
+}
+
+type fragmentEntry struct {
+	value   any
+	expires time.Time
+}
+
+func newFragmentCache(ttls map[string]time.Duration) *fragmentCache {
+	return &fragmentCache{
+		ttls:    ttls,
+		entries: make(map[cacheKey]fragmentEntry),
+	}
+}
+
+// get returns the cached value for key if present and not expired.
+func (c *fragmentCache) get(ctx context.Context, key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[cacheKey{tenant.FromContext(ctx), key}]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+// set stores value under key. Keys with a zero TTL are not cached.
+func (c *fragmentCache) set(ctx context.Context, key string, value any) {
+	ttl := c.ttls[key]
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[cacheKey{tenant.FromContext(ctx), key}] = fragmentEntry{value: value, expires: time.Now().Add(ttl)}
+}
+
+// invalidate drops key for every tenant so the next read goes to the
+// backing service.
+func (c *fragmentCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if k.key == key {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// productCacheStats exposes how often GetProduct results are served from the
+// cache, and how stale the stale ones were.
+var productCacheStats = expvar.NewMap("frontend_product_cache")
+
+func init() {
+	productCacheStats.Set("hit_ratio", expvar.Func(func() any {
+		get := func(key string) float64 {
+			if v, ok := productCacheStats.Get(key).(*expvar.Int); ok {
+				return float64(v.Value())
+			}
+			return 0
+		}
+		hits := get("hits") + get("stale_hits")
+		if total := hits + get("misses"); total > 0 {
+			return hits / total
+		}
+		return 0.0
+	}))
+}
+
+// productCache caches products by tenant and ID. Entries older than ttl are still
+// served for up to stale more while they are refreshed in the background.
+//
+// Invalidations are counted per product, and fetches that were under way
+// when their product was invalidated are not stored, so that a product read
+// before a catalog change is not cached as if read after it.
+type productCache struct {
+	ttl       time.Duration
+	stale     time.Duration
+	fetch     func(ctx context.Context, id string) (*pb.Product, error)
+	fetchMany func(ctx context.Context, ids []string) ([]*pb.Product, error)
+
+	mu         sync.Mutex
+	entries    map[cacheKey]productEntry
+	refreshing map[cacheKey]bool
+
+	// invalidated counts the invalidations of each product, and cleared
+	// those of every product.
+	invalidated map[string]uint64
+	cleared     uint64
+}
+
+type productEntry struct {
+	product   *pb.Product
+	fetchedAt time.Time
+}
+
+func newProductCache(ttl, stale time.Duration,
+	fetch func(ctx context.Context, id string) (*pb.Product, error),
+	fetchMany func(ctx context.Context, ids []string) ([]*pb.Product, error)) *productCache {
+	return &productCache{
+		ttl:         ttl,
+		stale:       stale,
+		fetch:       fetch,
+		fetchMany:   fetchMany,
+		entries:     make(map[cacheKey]productEntry),
+		refreshing:  make(map[cacheKey]bool),
+		invalidated: make(map[string]uint64),
+	}
+}
+
+func (c *productCache) get(ctx context.Context, id string) (*pb.Product, error) {
+	if p, ok := c.cached(ctx, id); ok {
+		return p, nil
+	}
+	gen := c.generation(id)
+	p, err := c.fetch(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	c.store(ctx, id, p, gen)
+	return p, nil
+}
+
+// getMany returns the products for ids in order, fetching all misses in a
+// single batch call.
+func (c *productCache) getMany(ctx context.Context, ids []string) ([]*pb.Product, error) {
+	out := make([]*pb.Product, len(ids))
+	var missing []string
+	var missingAt []int
+	for i, id := range ids {
+		if p, ok := c.cached(ctx, id); ok {
+			out[i] = p
+			continue
+		}
+		missing = append(missing, id)
+		missingAt = append(missingAt, i)
+	}
+	if len(missing) == 0 {
+		return out, nil
+	}
+
+	gens := make([]uint64, len(missing))
+	for i, id := range missing {
+		gens[i] = c.generation(id)
+	}
+	products, err := c.fetchMany(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) != len(missing) {
+		return nil, fmt.Errorf("expected %d products, got %d", len(missing), len(products))
+	}
+	for i, p := range products {
+		c.store(ctx, missing[i], p, gens[i])
+		out[missingAt[i]] = p
+	}
+	return out, nil
+}
+
+// cached returns id from the cache, starting a background refresh if the
+// entry is stale. Lookups are counted in productCacheStats.
+func (c *productCache) cached(ctx context.Context, id string) (*pb.Product, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	k := cacheKey{tenant.FromContext(ctx), id}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[k]
+	age := time.Since(e.fetchedAt)
+	switch {
+	case ok && age <= c.ttl:
+		productCacheStats.Add("hits", 1)
+		return e.product, true
+	case ok && age <= c.ttl+c.stale:
+		if !c.refreshing[k] {
+			c.refreshing[k] = true
+			go c.refresh(context.WithoutCancel(ctx), id, c.generationLocked(id))
+		}
+		productCacheStats.Add("stale_hits", 1)
+		productCacheStats.Add("stale_age_ms_total", (age - c.ttl).Milliseconds())
+		return e.product, true
+	}
+	productCacheStats.Add("misses", 1)
+	return nil, false
+}
+
+// refresh fetches id again for an entry gone stale at generation gen.
+func (c *productCache) refresh(ctx context.Context, id string, gen uint64) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	p, err := c.fetch(ctx, id)
+	if err != nil {
+		productCacheStats.Add("refresh_errors", 1)
+		log.Printf("productCache: failed to refresh product %s: %v", id, err)
+	} else {
+		c.store(ctx, id, p, gen)
+	}
+
+	c.mu.Lock()
+	delete(c.refreshing, cacheKey{tenant.FromContext(ctx), id})
+	c.mu.Unlock()
+}
+
+// generation returns the invalidation count of id, to be handed to store
+// along with what is fetched from then on.
+func (c *productCache) generation(id string) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generationLocked(id)
+}
+
+// generationLocked is generation for callers holding mu.
+func (c *productCache) generationLocked(id string) uint64 {
+	return c.cleared + c.invalidated[id]
+}
+
+// store caches p, fetched for id at generation gen, unless id was
+// invalidated since.
+func (c *productCache) store(ctx context.Context, id string, p *pb.Product, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generationLocked(id) != gen {
+		productCacheStats.Add("invalidated_fetches", 1)
+		return
+	}
+	c.entries[cacheKey{tenant.FromContext(ctx), id}] = productEntry{product: p, fetchedAt: time.Now()}
+}
+
+// invalidate drops ids for every tenant, or every product if ids is empty.
+// Fetches of them already under way are not stored.
+func (c *productCache) invalidate(ids []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(ids) == 0 {
+		c.cleared++
+		clear(c.entries)
+		return
+	}
+	for _, id := range ids {
+		c.invalidated[id]++
+	}
+	for k := range c.entries {
+		if slices.Contains(ids, k.key) {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// cacheInvalidations counts the events that dropped cached data, by topic.
+var cacheInvalidations = expvar.NewMap("frontend_cache_invalidations")
+
+// handleCatalogUpdated is the event bus handler for catalog changes, such as
+// imports and scheduled price changes. It drops the changed products, the
+// product list and the suggestions, so that pages show the catalog as it is
+// now rather than as cached.
+func (fe *frontendServer) handleCatalogUpdated(payload []byte) error {
+	var event pb.CatalogUpdated
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	fe.productCache.invalidate(event.GetProductIds())
+	fe.fragments.invalidate(fragmentProducts)
+	fe.suggestions.invalidate()
+	cacheInvalidations.Add(eventbus.TopicCatalogUpdated, 1)
+	return nil
+}
+
+// handleCurrencyUpdated is the event bus handler for changes to the rates or
+// the supported currencies. It drops the cached currency list.
+func (fe *frontendServer) handleCurrencyUpdated(payload []byte) error {
+	var event pb.CurrencyUpdated
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	fe.fragments.invalidate(fragmentCurrencies)
+	cacheInvalidations.Add(eventbus.TopicCurrencyUpdated, 1)
+	return nil
+}
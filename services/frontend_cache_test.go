@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// blockingFetch returns a fetch that signals on started and then waits for
+// release before answering with product.
+func blockingFetch(product *pb.Product, started, release chan struct{}) func(context.Context, string) (*pb.Product, error) {
+	return func(ctx context.Context, id string) (*pb.Product, error) {
+		started <- struct{}{}
+		<-release
+		return product, nil
+	}
+}
+
+func TestProductCacheRefreshSkipsInvalidated(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ids  []string
+	}{
+		{"product", []string{"OLJCESPC7Z"}},
+		{"catalog", nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			started, release := make(chan struct{}), make(chan struct{})
+			old := &pb.Product{Id: "OLJCESPC7Z", Name: "before the update"}
+			c := newProductCache(time.Minute, time.Hour, blockingFetch(old, started, release), nil)
+			ctx := context.Background()
+
+			// A stale entry is served while it is refreshed in the background.
+			k := cacheKey{"", "OLJCESPC7Z"}
+			c.entries[k] = productEntry{product: old, fetchedAt: time.Now().Add(-2 * time.Minute)}
+			if _, ok := c.cached(ctx, "OLJCESPC7Z"); !ok {
+				t.Fatal("stale entry not served")
+			}
+
+			// The catalog changes while the refresh is reading it.
+			<-started
+			c.invalidate(tc.ids)
+			close(release)
+			for c.isRefreshing(k) {
+				runtime.Gosched()
+			}
+
+			if e, ok := c.entries[k]; ok {
+				t.Errorf("refresh stored %q read before the invalidation", e.product.GetName())
+			}
+		})
+	}
+}
+
+func TestProductCacheGetSkipsInvalidated(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	old := &pb.Product{Id: "OLJCESPC7Z", Name: "before the update"}
+	c := newProductCache(time.Minute, time.Hour, blockingFetch(old, started, release), nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := c.get(context.Background(), "OLJCESPC7Z"); err != nil {
+			t.Error(err)
+		}
+	}()
+	<-started
+	c.invalidate([]string{"OLJCESPC7Z"})
+	close(release)
+	<-done
+
+	if e, ok := c.entries[cacheKey{"", "OLJCESPC7Z"}]; ok {
+		t.Errorf("get stored %q read before the invalidation", e.product.GetName())
+	}
+}
+
+func (c *productCache) isRefreshing(k cacheKey) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshing[k]
+}
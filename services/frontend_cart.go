@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/pkg/errors"
+)
+
+// cartItemView is a cart line with its product and its price in the
+// shopper's currency for the whole quantity, and its regular price while the
+// product is on sale.
+type cartItemView struct {
+	Item     *pb.Product
+	Quantity int32
+	Price    *pb.Money
+	Regular  *pb.Money
+}
+
+// viewCartHandler shows the cart with its shipping estimate and, if the
+// shopper's currency has a free shipping threshold, how far the cart is from
+// it.
+func (fe *frontendServer) viewCartHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID := sessionID(r)
+	currency := currentCurrency(r)
+
+	currencies, err := fe.getCurrencies(ctx, userID)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	cart, err := fe.getCart(ctx, userID)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		return
+	}
+
+	items, subtotal, err := fe.cartItemViews(ctx, cart, currency, userID)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not price cart"), http.StatusInternalServerError)
+		return
+	}
+
+	quote, err := fe.getShippingQuote(ctx, cart, subtotal)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not get shipping quote"), http.StatusInternalServerError)
+		return
+	}
+	shippingCost, err := fe.convertCurrency(ctx, quote.GetCostUsd(), currency, userID)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not convert shipping cost"), http.StatusInternalServerError)
+		return
+	}
+	total, err := Sum(subtotal, shippingCost)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not total cart"), http.StatusInternalServerError)
+		return
+	}
+	giftWrapFee, err := fe.convertCurrency(ctx, giftWrapFeeUSD.Get(), currency, userID)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not convert gift-wrap fee"), http.StatusInternalServerError)
+		return
+	}
+
+	// Without delivery options the cart is checked out with no window chosen.
+	deliveryWindows, _ := fe.getDeliveryOptions(ctx, cart)
+
+	// Without a wallet balance the cart is paid by card only.
+	walletBalance, _ := fe.getWalletBalance(ctx, currency)
+
+	// Prices are set in USD; without the rate they are shown on their own.
+	exchangeRate, _ := fe.getExchangeRate(ctx, "USD", currency)
+
+	var installments []int
+	if options := installmentOptions.Get(); len(options) > 0 {
+		floor, err := fe.convertCurrency(ctx, installmentMinUSD.Get(), currency, userID)
+		if err != nil {
+			renderHTTPError(r, w, errors.Wrap(err, "could not convert installment minimum"), http.StatusInternalServerError)
+			return
+		}
+		if atLeast(total, floor) {
+			installments = options
+		}
+	}
+
+	year := time.Now().Year()
+	err = renderTemplate(w, "cart", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency":           true,
+		"meta_robots":             "noindex",
+		"currencies":              currencies,
+		"items":                   items,
+		"cart_size":               cartSize(cart),
+		"shipping_cost":           shippingCost,
+		"total_cost":              total,
+		"free_shipping":           quote.GetFreeShipping(),
+		"free_shipping_remaining": quote.GetFreeShippingRemaining(),
+		"expiration_years":        []int{year, year + 1, year + 2, year + 3, year + 4},
+		"checkout_nonce":          fe.nonces.issue(userID),
+		"cart_hash":               CartHash(cart),
+		"gift_wrap_fee":           giftWrapFee,
+		"delivery_windows":        deliveryWindows,
+		"installment_options":     installments,
+		"wallet_balance":          walletBalance,
+		"exchange_rate":           exchangeRate,
+		"terms_version":           termsVersion.Get(),
+		"minimum_age":             minimumAge.Get(),
+	}))
+	if err != nil {
+		log.Printf("viewCartHandler: error rendering template: %v", err)
+	}
+}
+
+// cartItemViews prices each cart line in currency, including the price delta
+// of its variant, and returns the lines with their subtotal.
+func (fe *frontendServer) cartItemViews(ctx context.Context, cart []*pb.CartItem, currency, userID string) ([]cartItemView, *pb.Money, error) {
+	ids := make([]string, len(cart))
+	for i, item := range cart {
+		ids[i] = item.GetProductId()
+	}
+	products, err := fe.productCache.getMany(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	subtotal := &pb.Money{CurrencyCode: currency}
+	items := make([]cartItemView, len(cart))
+	for i, item := range cart {
+		priceUSD, regularUSD := productPrice(products[i], now)
+		if item.GetVariantId() != "" {
+			variants, err := fe.getVariants(ctx, item.GetProductId())
+			if err != nil {
+				return nil, nil, err
+			}
+			j := slices.IndexFunc(variants, func(v *pb.ProductVariant) bool { return v.GetId() == item.GetVariantId() })
+			if j >= 0 && variants[j].GetPriceDeltaUsd() != nil {
+				delta := variants[j].GetPriceDeltaUsd()
+				if priceUSD, err = Sum(priceUSD, delta); err != nil {
+					return nil, nil, err
+				}
+				if regularUSD != nil {
+					if regularUSD, err = Sum(regularUSD, delta); err != nil {
+						return nil, nil, err
+					}
+				}
+			}
+		}
+		price, err := fe.convertCurrency(ctx, priceUSD, currency, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		linePrice, err := Multiply(price, uint32(item.GetQuantity()))
+		if err != nil {
+			return nil, nil, err
+		}
+		if subtotal, err = Sum(subtotal, linePrice); err != nil {
+			return nil, nil, err
+		}
+		items[i] = cartItemView{Item: products[i], Quantity: item.GetQuantity(), Price: linePrice}
+		if regularUSD != nil {
+			regular, err := fe.convertCurrency(ctx, regularUSD, currency, userID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if items[i].Regular, err = Multiply(regular, uint32(item.GetQuantity())); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return items, subtotal, nil
+}
+
+// emptyCartHandler removes every item from the cart and goes back to the
+// home page.
+func (fe *frontendServer) emptyCartHandler(w http.ResponseWriter, r *http.Request) {
+	cartClient := pb.NewCartServiceClient(fe.cartSvcConn.Pick())
+	if _, err := cartClient.EmptyCart(r.Context(), &pb.EmptyCartRequest{UserId: sessionID(r)}); err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "failed to empty cart"), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("location", "/")
+	w.WriteHeader(http.StatusFound)
+}
+
+// getShippingQuote estimates shipping for the cart before an address is
+// known, passing the subtotal so the free shipping threshold applies.
+func (fe *frontendServer) getShippingQuote(ctx context.Context, items []*pb.CartItem, subtotal *pb.Money) (*pb.GetQuoteResponse, error) {
+	shippingClient := pb.NewShippingServiceClient(fe.shippingSvcConn.Pick())
+	resp, err := shippingClient.GetQuote(ctx, &pb.GetQuoteRequest{
+		Items:    items,
+		Subtotal: subtotal,
+	})
+	if err != nil {
+		log.Printf("getShippingQuote RPC failed: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// getDeliveryOptions returns the delivery windows offered for the cart before
+// an address is known.
+func (fe *frontendServer) getDeliveryOptions(ctx context.Context, items []*pb.CartItem) ([]*pb.DeliveryWindow, error) {
+	shippingClient := pb.NewShippingServiceClient(fe.shippingSvcConn.Pick())
+	resp, err := shippingClient.GetDeliveryOptions(ctx, &pb.GetDeliveryOptionsRequest{Items: items})
+	if err != nil {
+		log.Printf("getDeliveryOptions RPC failed: %v", err)
+		return nil, err
+	}
+	return resp.GetWindows(), nil
+}
+
+// parseDeliveryWindow parses a delivery window as submitted by the cart form,
+// its start and end dates separated by a slash. An empty value is no window.
+func parseDeliveryWindow(v string) (*pb.DeliveryWindow, error) {
+	if v == "" {
+		return nil, nil
+	}
+	start, end, ok := strings.Cut(v, "/")
+	if !ok {
+		return nil, fmt.Errorf("malformed delivery window %q", v)
+	}
+	for _, d := range []string{start, end} {
+		if _, err := time.Parse(deliveryDateLayout, d); err != nil {
+			return nil, fmt.Errorf("malformed delivery window %q", v)
+		}
+	}
+	return &pb.DeliveryWindow{StartDate: start, EndDate: end}, nil
+}
+
+// cartLimitMessage returns the message shown to the shopper for e.
+func cartLimitMessage(lang string, e *pb.CartLimitExceeded) string {
+	switch e.Limit {
+	case cartLimitItems:
+		return translations.T(lang, "cart.limit_items", e.Max)
+	case cartLimitQuantity:
+		return translations.T(lang, "cart.limit_quantity", e.Max)
+	}
+	return fmt.Sprintf("cart limit exceeded: %s", e.Limit)
+}
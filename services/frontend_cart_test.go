@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/testsupport"
+)
+
+func TestCartShowsExchangeRate(t *testing.T) {
+	c := testsupport.Start(t).NewClient(t)
+	if code, body := c.PostForm("/cart", url.Values{"product_id": {"1YMWWN1N4O"}, "quantity": {"1"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+
+	// Prices are converted from USD to the default currency.
+	rate := regexp.MustCompile(`1 USD = [0-9.]+ CNY, rates as of \d{4}-\d{2}-\d{2}`)
+	code, cart := c.Get("/cart")
+	if code != http.StatusOK {
+		t.Fatalf("cart: %d %s", code, cart)
+	}
+	if !rate.MatchString(cart) {
+		t.Errorf("cart does not show the exchange rate:\n%s", cart)
+	}
+
+	code, review := c.PostForm("/cart/review", testsupport.CheckoutForm(cart))
+	if code != http.StatusOK {
+		t.Fatalf("review: %d %s", code, review)
+	}
+	if !rate.MatchString(review) {
+		t.Errorf("order review does not show the exchange rate:\n%s", review)
+	}
+}
+
+func TestCartLimitIsShownToTheShopper(t *testing.T) {
+	t.Cleanup(func() { config.Reload() })
+	t.Setenv("CART_MAX_QUANTITY", "2")
+	t.Setenv("FRONTEND_SHOW_ERRORS", "true")
+	config.Reload()
+	c := testsupport.Start(t).NewClient(t)
+
+	code, body := c.PostForm("/cart", url.Values{"product_id": {"1YMWWN1N4O"}, "quantity": {"3"}})
+	if code != http.StatusUnprocessableEntity {
+		t.Fatalf("add past the limit: got %d, want %d: %s", code, http.StatusUnprocessableEntity, body)
+	}
+	if want := "You can add at most 2 of this product to your cart."; !strings.Contains(body, want) {
+		t.Errorf("error page does not say %q:\n%s", want, body)
+	}
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/validator"
+)
+
+// Outcomes of claiming a checkout nonce.
+const (
+	// nonceClaimed means the nonce was fresh and the order may be placed.
+	nonceClaimed = iota
+	// nonceUsed means an order was already placed with the nonce.
+	nonceUsed
+	// noncePending means an order is being placed with the nonce.
+	noncePending
+	// nonceInvalid means the nonce is unknown, expired or belongs to
+	// another session.
+	nonceInvalid
+)
+
+// checkoutNonces issues the one-time nonces embedded in the checkout form, so
+// that a form submitted twice places a single order. A nonce is bound to the
+// session it was issued to and, once used, remembers the order it placed.
+type checkoutNonces struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*nonceEntry
+}
+
+type nonceEntry struct {
+	session string
+	expires time.Time
+	pending bool
+	order   *pb.OrderResult
+}
+
+func newCheckoutNonces(ttl time.Duration) *checkoutNonces {
+	return &checkoutNonces{
+		ttl:     ttl,
+		entries: make(map[string]*nonceEntry),
+	}
+}
+
+// issue returns a new nonce for session. Expired nonces are dropped.
+func (n *checkoutNonces) issue(session string) string {
+	nonce := uuid.New().String()
+
+	now := time.Now()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for k, e := range n.entries {
+		if now.After(e.expires) {
+			delete(n.entries, k)
+		}
+	}
+	n.entries[nonce] = &nonceEntry{session: session, expires: now.Add(n.ttl)}
+	return nonce
+}
+
+// claim marks nonce as in use by session. For a nonce that was already used,
+// it also returns the order placed with it.
+func (n *checkoutNonces) claim(nonce, session string) (int, *pb.OrderResult) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	e, ok := n.entries[nonce]
+	switch {
+	case !ok || e.session != session || time.Now().After(e.expires):
+		return nonceInvalid, nil
+	case e.order != nil:
+		return nonceUsed, e.order
+	case e.pending:
+		return noncePending, nil
+	}
+	e.pending = true
+	return nonceClaimed, nil
+}
+
+// release makes a claimed nonce usable again after the order failed, so the
+// shopper can correct the form and resubmit it.
+func (n *checkoutNonces) release(nonce string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if e, ok := n.entries[nonce]; ok {
+		e.pending = false
+	}
+}
+
+// complete records the order placed with a claimed nonce. The nonce is kept
+// for another TTL so resubmissions can still be pointed to the order.
+func (n *checkoutNonces) complete(nonce string, order *pb.OrderResult) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if e, ok := n.entries[nonce]; ok {
+		e.pending = false
+		e.order = order
+		e.expires = time.Now().Add(n.ttl)
+	}
+}
+
+// checkoutForm is the checkout form of the cart page, as submitted.
+type checkoutForm struct {
+	payload        validator.PlaceOrderPayload
+	giftWrap       bool
+	installments   int64
+	deliveryWindow *pb.DeliveryWindow
+	useWallet      bool
+	cartHash       string
+	// termsVersion is the version of the terms of sale the form showed,
+	// which the shopper accepted along with confirming their age.
+	termsVersion string
+}
+
+// parseCheckoutForm parses and validates the checkout form of r. Its errors
+// are meant for the shopper.
+func parseCheckoutForm(r *http.Request) (checkoutForm, error) {
+	form := formParser{r: r}
+	f := checkoutForm{
+		payload: validator.PlaceOrderPayload{
+			Email:         r.FormValue("email"),
+			StreetAddress: r.FormValue("street_address"),
+			ZipCode:       form.int("zip_code", 32),
+			City:          r.FormValue("city"),
+			State:         r.FormValue("state"),
+			Country:       r.FormValue("country"),
+			CcNumber:      r.FormValue("credit_card_number"),
+			CcMonth:       form.int("credit_card_expiration_month", 32),
+			CcYear:        form.int("credit_card_expiration_year", 32),
+			CcCVV:         form.int("credit_card_cvv", 32),
+			Note:          strings.TrimSpace(r.FormValue("note")),
+		},
+		giftWrap:     r.FormValue("gift_wrap") == "true",
+		installments: form.int("installments", 32),
+		useWallet:    r.FormValue("use_wallet") == "true",
+		cartHash:     r.FormValue("cart_hash"),
+		termsVersion: r.FormValue("terms_version"),
+	}
+	if err := form.err(); err != nil {
+		return f, err
+	}
+	if r.FormValue("accept_terms") != "true" || r.FormValue("confirm_age") != "true" {
+		return f, errors.New(translations.T(currentLanguage(r), "cart.consent_required"))
+	}
+	var err error
+	if f.deliveryWindow, err = parseDeliveryWindow(r.FormValue("delivery_window")); err != nil {
+		return f, err
+	}
+	if err := f.payload.Validate(); err != nil {
+		return f, validator.ValidationErrorResponse(err)
+	}
+	return f, nil
+}
+
+// orderRequest returns the order f asks for, without its payment details,
+// with the address validated. Errors come with the status to render them
+// with.
+func (fe *frontendServer) orderRequest(r *http.Request, f checkoutForm) (*pb.PlaceOrderRequest, int, error) {
+	// Paying from the wallet uses as much of the balance as the order needs.
+	var walletAmount *pb.Money
+	if f.useWallet {
+		var err error
+		if walletAmount, err = fe.getWalletBalance(r.Context(), currentCurrency(r)); err != nil {
+			return nil, http.StatusInternalServerError, errors.Wrap(err, "could not retrieve wallet balance")
+		}
+	}
+
+	address, problems, err := fe.validateAddress(r.Context(), sessionID(r), &pb.Address{
+		StreetAddress: f.payload.StreetAddress,
+		City:          f.payload.City,
+		State:         f.payload.State,
+		ZipCode:       int32(f.payload.ZipCode),
+		Country:       f.payload.Country})
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "could not validate address")
+	}
+	if len(problems) > 0 {
+		log.Printf("orderRequest: address has %d problem(s)", len(problems))
+		return nil, http.StatusUnprocessableEntity, addressProblemsError(problems)
+	}
+
+	// The consent is given as the form is submitted.
+	now := time.Now().Unix()
+	return &pb.PlaceOrderRequest{
+		UserId:           sessionID(r),
+		UserCurrency:     currentCurrency(r),
+		Locale:           currentLanguage(r),
+		Address:          address,
+		ExpectedCartHash: f.cartHash,
+		GiftWrap:         f.giftWrap,
+		Note:             f.payload.Note,
+		DeliveryWindow:   f.deliveryWindow,
+		Installments:     int32(f.installments),
+		WalletAmount:     walletAmount,
+		ReferralCode:     referralCode(r),
+		Consent: &pb.Consent{
+			TermsVersion:    f.termsVersion,
+			TermsAcceptedAt: now,
+			AgeConfirmedAt:  now,
+		},
+	}, http.StatusOK, nil
+}
+
+// renderCheckoutError renders an error of PlaceOrder or PreviewOrder,
+// explaining to the shopper those they can do something about.
+func renderCheckoutError(r *http.Request, w http.ResponseWriter, err error, installments int64) {
+	switch {
+	case IsCartChanged(err):
+		renderHTTPError(r, w, errors.New(translations.T(currentLanguage(r), "cart.changed")), http.StatusConflict)
+	case IsDeliveryWindowUnavailable(err):
+		renderHTTPError(r, w, errors.New(translations.T(currentLanguage(r), "cart.delivery_unavailable")), http.StatusConflict)
+	case IsConsentRequired(err):
+		renderHTTPError(r, w, errors.New(translations.T(currentLanguage(r), "cart.consent_required")), http.StatusUnprocessableEntity)
+	case IsInstallmentsNotAvailable(err):
+		renderHTTPError(r, w, errors.New(translations.T(currentLanguage(r), "cart.installments_unavailable", installments)), http.StatusUnprocessableEntity)
+	case IsCardRejected(err):
+		renderHTTPError(r, w, errors.New(translations.T(currentLanguage(r), "cart.card_rejected")), http.StatusUnprocessableEntity)
+	default:
+		renderHTTPError(r, w, err, http.StatusInternalServerError)
+	}
+}
+
+// hiddenField is a form field carried over from one page to the next.
+type hiddenField struct {
+	Name, Value string
+}
+
+// reviewItem is an item of an order under review.
+type reviewItem struct {
+	Product  *pb.Product
+	Item     *pb.CartItem
+	LineCost *pb.Money
+}
+
+// reviewOrderHandler shows the order the checkout form asks for, priced
+// down to the last cent, before it is placed. Confirming posts the same form
+// on to placeOrderHandler, pinned to the cart that was priced.
+func (fe *frontendServer) reviewOrderHandler(w http.ResponseWriter, r *http.Request) {
+	f, err := parseCheckoutForm(r)
+	if err != nil {
+		log.Printf("reviewOrderHandler: invalid input: %v", err)
+		renderHTTPError(r, w, err, http.StatusUnprocessableEntity)
+		return
+	}
+	req, code, err := fe.orderRequest(r, f)
+	if err != nil {
+		renderHTTPError(r, w, err, code)
+		return
+	}
+	checkoutClient := pb.NewCheckoutServiceClient(fe.checkoutSvcConn.Pick())
+	preview, err := checkoutClient.PreviewOrder(r.Context(), req)
+	if err != nil {
+		log.Printf("reviewOrderHandler: error previewing order: %v", err)
+		renderCheckoutError(r, w, errors.Wrap(err, "could not price the order"), f.installments)
+		return
+	}
+
+	ids := make([]string, len(preview.GetItems()))
+	for i, it := range preview.GetItems() {
+		ids[i] = it.GetItem().GetProductId()
+	}
+	products, err := fe.productCache.getMany(r.Context(), ids)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve products"), http.StatusInternalServerError)
+		return
+	}
+	items := make([]reviewItem, len(products))
+	for i, it := range preview.GetItems() {
+		line, err := Multiply(it.GetCost(), uint32(it.GetItem().GetQuantity()))
+		if err != nil {
+			renderHTTPError(r, w, errors.Wrap(err, "could not price the order"), http.StatusInternalServerError)
+			return
+		}
+		items[i] = reviewItem{Product: products[i], Item: it.GetItem(), LineCost: line}
+	}
+
+	var fields []hiddenField
+	for _, name := range slices.Sorted(maps.Keys(r.PostForm)) {
+		if name == "cart_hash" {
+			continue
+		}
+		for _, v := range r.PostForm[name] {
+			fields = append(fields, hiddenField{name, v})
+		}
+	}
+	fields = append(fields, hiddenField{"cart_hash", preview.GetCartHash()})
+
+	// Without the rate the order is reviewed without one.
+	exchangeRate, _ := fe.getExchangeRate(r.Context(), "USD", preview.GetBreakdown().GetTotal().GetCurrencyCode())
+
+	// The page carries the card details on to the order.
+	w.Header().Set("Cache-Control", "no-store")
+	err = renderTemplate(w, "order_review", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency":   false,
+		"meta_robots":     "noindex",
+		"preview":         preview,
+		"items":           items,
+		"fields":          fields,
+		"delivery_window": f.deliveryWindow,
+		"note":            f.payload.Note,
+		"exchange_rate":   exchangeRate,
+	}))
+	if err != nil {
+		log.Printf("reviewOrderHandler: error rendering template: %v", err)
+	}
+}
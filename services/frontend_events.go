@@ -0,0 +1,131 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// Order notifications pushed to the storefront, by the order status that
+// triggers them.
+var orderEventNames = map[string]string{
+	orderShipped:   "order-shipped",
+	orderDelivered: "order-delivered",
+	orderCancelled: "order-cancelled",
+	orderRefunded:  "order-refunded",
+}
+
+// orderEvent is an order notification for one session.
+type orderEvent struct {
+	name string
+	data []byte
+}
+
+// orderEvents relays order status changes from the event bus to the
+// storefront sessions the orders belong to. Each open /events stream
+// subscribes for its session.
+type orderEvents struct {
+	mu   sync.Mutex
+	subs map[string]map[chan orderEvent]struct{}
+}
+
+func newOrderEvents() *orderEvents {
+	return &orderEvents{subs: make(map[string]map[chan orderEvent]struct{})}
+}
+
+// subscribe returns a channel receiving the notifications for session and a
+// function to stop receiving them.
+func (e *orderEvents) subscribe(session string) (<-chan orderEvent, func()) {
+	ch := make(chan orderEvent, 8)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.subs[session] == nil {
+		e.subs[session] = make(map[chan orderEvent]struct{})
+	}
+	e.subs[session][ch] = struct{}{}
+	return ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		delete(e.subs[session], ch)
+		if len(e.subs[session]) == 0 {
+			delete(e.subs, session)
+		}
+	}
+}
+
+// handleOrderStatusChanged is the event bus handler. Notifications for
+// sessions without an open stream are dropped, as are those for streams too
+// slow to keep up.
+func (e *orderEvents) handleOrderStatusChanged(payload []byte) error {
+	var event pb.OrderStatusChanged
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return err
+	}
+	st := event.GetStatus()
+	name, ok := orderEventNames[st.GetStatus()]
+	if !ok || event.GetUserId() == "" {
+		return nil
+	}
+	data, err := json.Marshal(map[string]string{
+		"order_id": st.GetOrderId(),
+		"status":   st.GetStatus(),
+	})
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for ch := range e.subs[event.GetUserId()] {
+		select {
+		case ch <- orderEvent{name: name, data: data}:
+		default:
+		}
+	}
+	return nil
+}
+
+// eventsHandler streams the order notifications of the session as
+// server-sent events until the client goes away. A comment is sent
+// periodically so proxies keep the connection open.
+func (fe *frontendServer) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	// The stream outlives the server's write timeout.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		log.Printf("eventsHandler: cannot stream: %v", err)
+		renderHTTPError(r, w, err, http.StatusInternalServerError)
+		return
+	}
+	events, unsubscribe := fe.orderEvents.subscribe(sessionID(r))
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	heartbeat := time.NewTicker(envDuration("FRONTEND_EVENTS_HEARTBEAT", 20*time.Second))
+	defer heartbeat.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-events:
+			_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.name, ev.data)
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": ping\n\n")
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
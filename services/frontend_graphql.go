@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/graphql"
+	"github.com/appnetorg/online-boutique-arpc/services/rpclog"
+)
+
+// graphqlEnabled serves the GraphQL endpoint, FRONTEND_GRAPHQL, off by
+// default.
+var graphqlEnabled = config.NewValue(func() bool {
+	return strings.ToLower(config.Get("FRONTEND_GRAPHQL")) == "true"
+})
+
+// graphqlRequest is what the resolvers of a query share.
+type graphqlRequest struct {
+	userID   string
+	currency string
+	// products batches the products referred to by the fields at a depth,
+	// such as those of the items of a cart, into a single catalog call.
+	products *graphql.Loader[string, *pb.Product]
+}
+
+type ctxKeyGraphQL struct{}
+
+func graphqlRequestOf(ctx context.Context) *graphqlRequest {
+	return ctx.Value(ctxKeyGraphQL{}).(*graphqlRequest)
+}
+
+// graphqlCart is the cart of the shopper. The items are wrapped since a
+// slice would be resolved as a list of carts.
+type graphqlCart struct {
+	items []*pb.CartItem
+}
+
+// fieldOf returns a scalar field computed from its parent alone.
+func fieldOf[T any](f func(T) any) *graphql.Field {
+	return &graphql.Field{Resolve: func(_ context.Context, parent any, _ graphql.Args) (any, error) {
+		return f(parent.(T)), nil
+	}}
+}
+
+// linkOf returns a field of object type typ computed from its parent alone.
+func linkOf[T any](typ string, f func(T) any) *graphql.Field {
+	field := fieldOf(f)
+	field.Type = typ
+	return field
+}
+
+// newGraphQLSchema returns the graph of the products, the cart, the orders
+// and the recommendations of a shopper.
+func (fe *frontendServer) newGraphQLSchema() *graphql.Schema {
+	query := graphql.Object{
+		"product": {Type: "Product", Args: []string{"id"}, Resolve: func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+			id, err := args.String("id")
+			if err != nil {
+				return nil, err
+			}
+			return graphqlRequestOf(ctx).products.Load(ctx, id), nil
+		}},
+		"products": {Type: "Product", Args: []string{"ids"}, Resolve: func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+			ids, err := args.Strings("ids")
+			if err != nil {
+				return nil, err
+			}
+			if ids == nil {
+				return fe.getProducts(ctx, graphqlRequestOf(ctx).userID)
+			}
+			return fe.productCache.getMany(ctx, ids)
+		}},
+		"search": {Type: "Product", Args: []string{"query", "category", "priceRange"}, Resolve: func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+			var f searchFilters
+			var err error
+			if f.query, err = args.String("query"); err != nil {
+				return nil, err
+			}
+			if f.category, err = args.String("category"); err != nil {
+				return nil, err
+			}
+			if f.price, err = args.String("priceRange"); err != nil {
+				return nil, err
+			}
+			f.query = sanitizeText(strings.TrimSpace(f.query), maxSearchQueryLength)
+			resp, err := fe.searchProducts(ctx, f)
+			if err != nil {
+				return nil, err
+			}
+			return resp.GetResults(), nil
+		}},
+		"cart": {Type: "Cart", Resolve: func(ctx context.Context, _ any, _ graphql.Args) (any, error) {
+			items, err := fe.getCart(ctx, graphqlRequestOf(ctx).userID)
+			if err != nil {
+				return nil, err
+			}
+			return &graphqlCart{items: items}, nil
+		}},
+		"order": {Type: "Order", Args: []string{"id"}, Resolve: func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+			id, err := args.String("id")
+			if err != nil {
+				return nil, err
+			}
+			checkoutClient := pb.NewCheckoutServiceClient(fe.checkoutSvcConn.Pick())
+			return checkoutClient.GetOrderStatus(ctx, &pb.GetOrderStatusRequest{OrderId: id})
+		}},
+		"recommendations": {Type: "Recommendation", Args: []string{"productIds"}, Resolve: func(ctx context.Context, _ any, args graphql.Args) (any, error) {
+			ids, err := args.Strings("productIds")
+			if err != nil {
+				return nil, err
+			}
+			return fe.getRecommendations(ctx, graphqlRequestOf(ctx).userID, ids, nil)
+		}},
+	}
+
+	product := graphql.Object{
+		"id":          fieldOf(func(p *pb.Product) any { return p.GetId() }),
+		"name":        fieldOf(func(p *pb.Product) any { return p.GetName() }),
+		"description": fieldOf(func(p *pb.Product) any { return p.GetDescription() }),
+		"picture":     fieldOf(func(p *pb.Product) any { return p.GetPicture() }),
+		"categories":  fieldOf(func(p *pb.Product) any { return p.GetCategories() }),
+		"priceUsd":    linkOf("Money", func(p *pb.Product) any { return p.GetPriceUsd() }),
+		// price is what the product sells for now, in currency or else the
+		// shopper's currency.
+		"price": {Type: "Money", Args: []string{"currency"}, Resolve: func(ctx context.Context, parent any, args graphql.Args) (any, error) {
+			currency, err := args.String("currency")
+			if err != nil {
+				return nil, err
+			}
+			req := graphqlRequestOf(ctx)
+			if currency == "" {
+				currency = req.currency
+			}
+			p := parent.(*pb.Product)
+			return graphql.Go(func() (any, error) {
+				v, err := fe.productView(ctx, p, time.Now(), currency, req.userID)
+				return v.Price, err
+			}), nil
+		}},
+		"recommendations": {Type: "Recommendation", Resolve: func(ctx context.Context, parent any, _ graphql.Args) (any, error) {
+			p := parent.(*pb.Product)
+			return graphql.Go(func() (any, error) {
+				return fe.getRecommendations(ctx, graphqlRequestOf(ctx).userID, []string{p.GetId()}, p.GetCategories())
+			}), nil
+		}},
+	}
+
+	money := graphql.Object{
+		"currencyCode": fieldOf(func(m *pb.Money) any { return m.GetCurrencyCode() }),
+		"units":        fieldOf(func(m *pb.Money) any { return m.GetUnits() }),
+		"nanos":        fieldOf(func(m *pb.Money) any { return m.GetNanos() }),
+		"formatted":    fieldOf(func(m *pb.Money) any { return renderMoney(m) }),
+	}
+
+	cart := graphql.Object{
+		"items":         linkOf("CartItem", func(c *graphqlCart) any { return c.items }),
+		"totalQuantity": fieldOf(func(c *graphqlCart) any { return cartSize(c.items) }),
+	}
+
+	cartItem := graphql.Object{
+		"productId": fieldOf(func(i *pb.CartItem) any { return i.GetProductId() }),
+		"variantId": fieldOf(func(i *pb.CartItem) any { return i.GetVariantId() }),
+		"quantity":  fieldOf(func(i *pb.CartItem) any { return i.GetQuantity() }),
+		"product": {Type: "Product", Resolve: func(ctx context.Context, parent any, _ graphql.Args) (any, error) {
+			return graphqlRequestOf(ctx).products.Load(ctx, parent.(*pb.CartItem).GetProductId()), nil
+		}},
+	}
+
+	order := graphql.Object{
+		"id":     fieldOf(func(o *pb.OrderStatus) any { return o.GetOrderId() }),
+		"status": fieldOf(func(o *pb.OrderStatus) any { return o.GetStatus() }),
+		"reason": fieldOf(func(o *pb.OrderStatus) any { return o.GetReason() }),
+		"updatedAt": fieldOf(func(o *pb.OrderStatus) any {
+			return time.Unix(o.GetUpdatedAt(), 0).UTC().Format(time.RFC3339)
+		}),
+	}
+
+	recommendation := graphql.Object{
+		"product": linkOf("Product", func(r recommendationView) any { return r.Product }),
+		"reason": fieldOf(func(r recommendationView) any {
+			return strings.TrimPrefix(r.ReasonKey, "recommendation.")
+		}),
+		"reasonDetail": fieldOf(func(r recommendationView) any { return r.ReasonDetail }),
+	}
+
+	return &graphql.Schema{
+		Types: map[string]graphql.Object{
+			"Query":          query,
+			"Product":        product,
+			"Money":          money,
+			"Cart":           cart,
+			"CartItem":       cartItem,
+			"Order":          order,
+			"Recommendation": recommendation,
+		},
+		ErrorMessage: graphqlErrorMessage,
+	}
+}
+
+// graphqlErrorMessage hides the errors of the backends from clients, as
+// renderHTTPError does, leaving the request ID to quote.
+func graphqlErrorMessage(ctx context.Context, err error) string {
+	if showErrors.Get() {
+		return err.Error()
+	}
+	requestID, _ := ctx.Value(ctxKeyRequestID{}).(string)
+	rpclog.Warn(ctx, "graphql request failed", requestIDField(ctx), zap.Error(err))
+	return "internal error, request ID " + requestID
+}
+
+// graphqlHandler answers GraphQL queries, posted as JSON or given in the
+// query parameter of a GET. It is only served if FRONTEND_GRAPHQL is "true".
+func (fe *frontendServer) graphqlHandler(w http.ResponseWriter, r *http.Request) {
+	if !graphqlEnabled.Get() {
+		http.NotFound(w, r)
+		return
+	}
+	var req graphql.Request
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query, req.OperationName = q.Get("query"), q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				writeGraphQLError(w, http.StatusBadRequest, "variables must be a JSON object")
+				return
+			}
+		}
+	case http.MethodPost:
+		r.Body = http.MaxBytesReader(w, r.Body, maxFormBytes)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeGraphQLError(w, http.StatusRequestEntityTooLarge, "request body is too large")
+				return
+			}
+			writeGraphQLError(w, http.StatusBadRequest, "request body must be a JSON object with a query")
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeGraphQLError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if req.Query == "" {
+		writeGraphQLError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), ctxKeyGraphQL{}, &graphqlRequest{
+		userID:   sessionID(r),
+		currency: currentCurrency(r),
+		products: graphql.NewLoader(fe.productCache.getMany),
+	})
+	resp := fe.graphSchema.Execute(ctx, req)
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Data == nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		rpclog.Warn(ctx, "failed to write graphql response", requestIDField(ctx), zap.Error(err))
+	}
+}
+
+func writeGraphQLError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(graphql.Response{Errors: []graphql.Error{{Message: msg}}})
+}
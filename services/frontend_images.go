@@ -0,0 +1,132 @@
+package services
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Widths of the product image variants advertised by the catalog. The
+// /images handler only produces these, so the resize cache stays bounded.
+const (
+	thumbnailWidth = 240
+	mediumWidth    = 600
+)
+
+// imagesDir is the root that /images/ paths are resolved against.
+const imagesDir = "static/img"
+
+var imageWidths = map[int]bool{thumbnailWidth: true, mediumWidth: true}
+
+// resizedImages caches encoded variants by path and width.
+var resizedImages sync.Map
+
+type resizedImage struct {
+	contentType string
+	data        []byte
+}
+
+// imagesHandler serves a picture under static/img scaled down to the width
+// given by the w query parameter, keeping its aspect ratio.
+func imagesHandler(w http.ResponseWriter, r *http.Request) {
+	rel := path.Clean("/" + strings.TrimPrefix(r.URL.Path, "/images/"))
+	ext := strings.ToLower(path.Ext(rel))
+	if ext != ".jpg" && ext != ".jpeg" && ext != ".png" {
+		http.NotFound(w, r)
+		return
+	}
+	width, err := strconv.Atoi(r.URL.Query().Get("w"))
+	if err != nil || !imageWidths[width] {
+		http.Error(w, "unsupported image width", http.StatusBadRequest)
+		return
+	}
+
+	key := rel + "?w=" + strconv.Itoa(width)
+	v, ok := resizedImages.Load(key)
+	if !ok {
+		img, err := resizeImageFile(imagesDir+rel, width)
+		if os.IsNotExist(err) {
+			http.NotFound(w, r)
+			return
+		}
+		if err != nil {
+			log.Printf("imagesHandler: failed to resize %s: %v", rel, err)
+			http.Error(w, "could not resize image", http.StatusInternalServerError)
+			return
+		}
+		v, _ = resizedImages.LoadOrStore(key, img)
+	}
+	img := v.(*resizedImage)
+
+	w.Header().Set("Content-Type", img.contentType)
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Write(img.data)
+}
+
+// resizeImageFile decodes the image at name and re-encodes it at most width
+// pixels wide.
+func resizeImageFile(name string, width int) (*resizedImage, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	src, format, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	dst := scaleToWidth(src, width)
+
+	var buf bytes.Buffer
+	if format == "png" {
+		err = png.Encode(&buf, dst)
+		return &resizedImage{contentType: "image/png", data: buf.Bytes()}, err
+	}
+	err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80})
+	return &resizedImage{contentType: "image/jpeg", data: buf.Bytes()}, err
+}
+
+// scaleToWidth shrinks src to width by averaging the source pixels that fall
+// into each destination pixel. Images that are already narrow enough are
+// returned as they are.
+func scaleToWidth(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= width {
+		return src
+	}
+	height := max(1, b.Dy()*width/b.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		sy0 := b.Min.Y + y*b.Dy()/height
+		sy1 := max(sy0+1, b.Min.Y+(y+1)*b.Dy()/height)
+		for x := 0; x < width; x++ {
+			sx0 := b.Min.X + x*b.Dx()/width
+			sx1 := max(sx0+1, b.Min.X+(x+1)*b.Dx()/width)
+
+			var r, g, bl, a, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+pr, g+pg, bl+pb, a+pa
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(bl / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/appnetorg/online-boutique-arpc/services/privacy"
+	"github.com/appnetorg/online-boutique-arpc/services/rpclog"
+)
+
+// privacyReport is what a service keeps, or erased, of a shopper's data,
+// as exported and shown on the privacy page.
+type privacyReport struct {
+	Service string          `json:"service"`
+	Records []privacyRecord `json:"records"`
+	// Error is set if the service could not be reached, in which case its
+	// data was not exported or erased.
+	Error string `json:"error,omitempty"`
+}
+
+type privacyRecord struct {
+	Kind  string          `json:"kind"`
+	Count int64           `json:"count"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// privacyCookies are the cookies kept about the shopper's browsing: the
+// categories they viewed, which ads and recommendations are seeded with,
+// their ad experiment and session, and the referral code they arrived with.
+var privacyCookies = []string{cookieRecentCategories, cookieAdExperiment, cookieAdSession, cookieReferral}
+
+// newPrivacyCoordinator returns the coordinator of the services that keep
+// data about shoppers. Recommendations are ranked from each request alone,
+// from the categories cookie, so the recommendation service keeps none.
+func (fe *frontendServer) newPrivacyCoordinator() *privacy.Coordinator {
+	return privacy.NewCoordinator(
+		privacy.Participant{Name: "cart", Pick: fe.cartSvcConn.Pick},
+		privacy.Participant{Name: "checkout", Pick: fe.checkoutSvcConn.Pick},
+		privacy.Participant{Name: "payment", Pick: fe.paymentSvcConn.Pick},
+		privacy.Participant{Name: "support", Pick: fe.supportSvcConn.Pick},
+		privacy.Participant{Name: "email", Pick: fe.emailSvcConn.Pick, ByEmail: true},
+	)
+}
+
+// privacyReports returns the reports of the services along with that of
+// the frontend's own cookies. The errors of services are logged, and only
+// shown if FRONTEND_SHOW_ERRORS is "true".
+func privacyReports(r *http.Request, reports []privacy.Report, withData bool) []privacyReport {
+	recent := recentCategories(r)
+	browsing := privacyRecord{Kind: "recent_categories", Count: int64(len(recent))}
+	if withData {
+		browsing.Data, _ = json.Marshal(recent)
+	}
+	out := []privacyReport{{Service: "frontend", Records: []privacyRecord{browsing}}}
+
+	for _, rep := range reports {
+		p := privacyReport{Service: rep.Service, Records: []privacyRecord{}}
+		if rep.Err != nil {
+			rpclog.Warn(r.Context(), "privacy request failed", requestIDField(r.Context()), zap.String("service", rep.Service), zap.Error(rep.Err))
+			p.Error = "unavailable"
+			if showErrors.Get() {
+				p.Error = rep.Err.Error()
+			}
+		}
+		for _, rec := range rep.Records {
+			pr := privacyRecord{Kind: rec.GetKind(), Count: rec.GetCount()}
+			if rec.GetJson() != "" {
+				pr.Data = json.RawMessage(rec.GetJson())
+			}
+			p.Records = append(p.Records, pr)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// privacyHandler shows the privacy page, from which shoppers export or
+// erase the data kept about them.
+func (fe *frontendServer) privacyHandler(w http.ResponseWriter, r *http.Request) {
+	err := renderTemplate(w, "privacy", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency": false,
+		"meta_robots":   "noindex",
+	}))
+	if err != nil {
+		rpclog.Warn(r.Context(), "failed to render privacy page", requestIDField(r.Context()), zap.Error(err))
+	}
+}
+
+// exportUserDataHandler downloads the data kept about the shopper, as JSON.
+func (fe *frontendServer) exportUserDataHandler(w http.ResponseWriter, r *http.Request) {
+	doc := struct {
+		ExportedAt string          `json:"exported_at"`
+		Services   []privacyReport `json:"services"`
+	}{
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Services:   privacyReports(r, fe.privacy.Export(r.Context()), true),
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not encode the export"), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="online-boutique-data.json"`)
+	w.Header().Set("Cache-Control", "no-store")
+	if _, err := w.Write(data); err != nil {
+		rpclog.Warn(r.Context(), "failed to write data export", requestIDField(r.Context()), zap.Error(err))
+	}
+}
+
+// deleteUserDataHandler erases the data kept about the shopper, once they
+// confirmed it, and shows what was erased. The browsing cookies go with it
+// and, if every service erased its data, so does the session, so the
+// shopper starts afresh; otherwise the session is kept to try again.
+func (fe *frontendServer) deleteUserDataHandler(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue("confirm") != "true" {
+		renderHTTPError(r, w, errors.New(translations.T(currentLanguage(r), "privacy.confirm_required")), http.StatusUnprocessableEntity)
+		return
+	}
+	reports := privacyReports(r, fe.privacy.Delete(r.Context()), false)
+
+	cookies := privacyCookies
+	failed := false
+	for _, rep := range reports {
+		failed = failed || rep.Error != ""
+	}
+	if !failed {
+		cookies = append(cookies[:len(cookies):len(cookies)], cookieSessionID)
+	}
+	for _, name := range cookies {
+		http.SetCookie(w, &http.Cookie{Name: name, MaxAge: -1})
+	}
+	rpclog.Info(r.Context(), "erased user data", requestIDField(r.Context()), zap.String("session_id", sessionID(r)), zap.Bool("failed", failed))
+
+	err := renderTemplate(w, "privacy", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency": false,
+		"meta_robots":   "noindex",
+		"deleted":       reports,
+		"failed":        failed,
+	}))
+	if err != nil {
+		rpclog.Warn(r.Context(), "failed to render privacy page", requestIDField(r.Context()), zap.Error(err))
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"expvar"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// cookieReferral holds the referral code a shopper first arrived with, for
+// referralMaxAge seconds. The first code wins: a later link does not take
+// the credit for an order from the one that brought the shopper in.
+const (
+	cookieReferral = cookiePrefix + "referral"
+	referralMaxAge = 60 * 60 * 24 * 30
+)
+
+// validReferralCode matches the referral codes accepted from links.
+var validReferralCode = regexp.MustCompile(`^[A-Z0-9_-]{1,32}$`)
+
+// referralVisits counts the visits with a referral code, by whether the code
+// was recorded, ignored as invalid, or already held by an earlier one.
+var referralVisits = expvar.NewMap("frontend_referral_visits")
+
+// referralMiddleware records the referral code of links with ?ref=CODE in a
+// cookie and publishes the visit for the analytics service. Bots are
+// ignored, so crawlers following referral links do not count as visits.
+func (fe *frontendServer) referralMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ref := r.URL.Query().Get("ref")
+		if ref != "" && requestTrafficClass(r).class != trafficBot {
+			fe.recordReferral(w, r, strings.ToUpper(strings.TrimSpace(ref)))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (fe *frontendServer) recordReferral(w http.ResponseWriter, r *http.Request, code string) {
+	if !validReferralCode.MatchString(code) {
+		referralVisits.Add("invalid", 1)
+		return
+	}
+	if referralCode(r) != "" {
+		referralVisits.Add("held", 1)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:   cookieReferral,
+		Value:  code,
+		MaxAge: referralMaxAge,
+	})
+	referralVisits.Add("recorded", 1)
+
+	ctx := r.Context()
+	err := fe.bus.Publish(ctx, eventbus.TopicReferralVisited, &pb.ReferralVisited{
+		Code:   code,
+		Tenant: tenant.FromContext(ctx),
+	})
+	if err != nil {
+		log.Printf("failed to publish visit with referral code %s: %+v", code, err)
+	}
+}
+
+// referralCode returns the referral code the shopper arrived with, if any.
+func referralCode(r *http.Request) string {
+	c, _ := r.Cookie(cookieReferral)
+	if c == nil || !validReferralCode.MatchString(c.Value) {
+		return ""
+	}
+	return c.Value
+}
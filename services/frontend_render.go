@@ -0,0 +1,92 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/pkg/errors"
+)
+
+// streamTemplates writes templates straight to the response instead of
+// buffering them. Streaming saves a copy per page but a template error then
+// leaves a partial page behind.
+var streamTemplates = config.NewValue(func() bool {
+	return strings.ToLower(config.Get("FRONTEND_STREAM_TEMPLATES")) == "true"
+})
+
+// productView is a product together with its price in the user's currency
+// and, while it is on sale, the regular price, shown struck through.
+type productView struct {
+	Item    *pb.Product
+	Price   *pb.Money
+	Regular *pb.Money
+}
+
+var (
+	productViewPool = sync.Pool{New: func() any { return new([]productView) }}
+	renderBufPool   = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+)
+
+// productViews converts the price of each product to currency. The returned
+// slice comes from a pool and must be handed back to releaseProductViews once
+// the page has been rendered.
+func (fe *frontendServer) productViews(ctx context.Context, products []*pb.Product, currency, userID string) (*[]productView, error) {
+	ps := productViewPool.Get().(*[]productView)
+	*ps = (*ps)[:0]
+	now := time.Now()
+	for _, p := range products {
+		v, err := fe.productView(ctx, p, now, currency, userID)
+		if err != nil {
+			releaseProductViews(ps)
+			return nil, err
+		}
+		*ps = append(*ps, v)
+	}
+	return ps, nil
+}
+
+// productView converts the price of p at now, and its regular price if it is
+// on sale, to currency.
+func (fe *frontendServer) productView(ctx context.Context, p *pb.Product, now time.Time, currency, userID string) (productView, error) {
+	priceUSD, regularUSD := productPrice(p, now)
+	price, err := fe.convertCurrency(ctx, priceUSD, currency, userID)
+	if err != nil {
+		return productView{}, errors.Wrapf(err, "failed to do currency conversion for product %s", p.GetId())
+	}
+	v := productView{Item: p, Price: price}
+	if regularUSD != nil {
+		if v.Regular, err = fe.convertCurrency(ctx, regularUSD, currency, userID); err != nil {
+			return productView{}, errors.Wrapf(err, "failed to do currency conversion for product %s", p.GetId())
+		}
+	}
+	return v, nil
+}
+
+func releaseProductViews(ps *[]productView) {
+	clear(*ps)
+	productViewPool.Put(ps)
+}
+
+// renderTemplate executes the named template into w, buffering the output
+// unless streamTemplates is set.
+func renderTemplate(w io.Writer, name string, data any) error {
+	if streamTemplates.Get() {
+		return templates.ExecuteTemplate(w, name, data)
+	}
+	buf := renderBufPool.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		renderBufPool.Put(buf)
+	}()
+	if err := templates.ExecuteTemplate(buf, name, data); err != nil {
+		return err
+	}
+	_, err := buf.WriteTo(w)
+	return err
+}
@@ -0,0 +1,43 @@
+package services_test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/appnetorg/online-boutique-arpc/services/testsupport"
+)
+
+// benchmarkPage requests path as one shopper b.N times. The backends run in
+// process, so the time is that of the whole request, rendering included.
+func benchmarkPage(b *testing.B, c *testsupport.Client, path string) {
+	b.Helper()
+	if code, body := c.Get(path); code != http.StatusOK {
+		b.Fatalf("GET %s: %d %s", path, code, body)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		c.Get(path)
+	}
+}
+
+func BenchmarkRenderHome(b *testing.B) {
+	c := testsupport.Start(b).NewClient(b)
+	benchmarkPage(b, c, "/")
+}
+
+func BenchmarkRenderProduct(b *testing.B) {
+	c := testsupport.Start(b).NewClient(b)
+	benchmarkPage(b, c, "/product/1YMWWN1N4O")
+}
+
+func BenchmarkRenderCart(b *testing.B) {
+	c := testsupport.Start(b).NewClient(b)
+	for _, id := range []string{"1YMWWN1N4O", "2ZYFJ3GM2N", "0PUK6V6EV0"} {
+		if code, body := c.PostForm("/cart", url.Values{"product_id": {id}, "quantity": {"1"}}); code != http.StatusOK {
+			b.Fatalf("add %s to cart: %d %s", id, code, body)
+		}
+	}
+	benchmarkPage(b, c, "/cart")
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// Longest text, in characters, shown from the backends. Longer text is cut.
+const (
+	maxNameLength        = 200
+	maxDescriptionLength = 2000
+	maxAdTextLength      = 300
+)
+
+// sanitizeText returns s as valid UTF-8 without control characters, other
+// than newlines and tabs, or the bidirectional overrides that make text read
+// differently from how it is stored, cut to max characters.
+func sanitizeText(s string, max int) string {
+	s = strings.ToValidUTF8(s, "")
+	var b strings.Builder
+	n := 0
+	for _, r := range s {
+		if n == max {
+			b.WriteString("…")
+			break
+		}
+		if (unicode.IsControl(r) && r != '\n' && r != '\t') || unicode.Is(unicode.Bidi_Control, r) {
+			continue
+		}
+		b.WriteRune(r)
+		n++
+	}
+	return b.String()
+}
+
+// sanitizeProduct returns a copy of p with its text cleaned up. The
+// templates escape it, but the catalog is data anyone running the demo can
+// edit, and products are also cached and served to the assistant's script.
+func sanitizeProduct(p *pb.Product) *pb.Product {
+	if p == nil {
+		return nil
+	}
+	p = proto.Clone(p).(*pb.Product)
+	p.Name = sanitizeText(p.Name, maxNameLength)
+	p.Description = sanitizeText(p.Description, maxDescriptionLength)
+	for i, c := range p.Categories {
+		p.Categories[i] = sanitizeText(c, maxNameLength)
+	}
+	return p
+}
+
+// sanitizeAds returns the ads that link to a page of the storefront, with
+// their text cleaned up.
+func sanitizeAds(ads []*pb.Ad) []*pb.Ad {
+	var out []*pb.Ad
+	for _, ad := range ads {
+		url := ad.GetRedirectUrl()
+		if !strings.HasPrefix(url, "/") || strings.HasPrefix(url, "//") || strings.HasPrefix(url, "/\\") {
+			continue
+		}
+		out = append(out, &pb.Ad{RedirectUrl: url, Text: sanitizeText(ad.GetText(), maxAdTextLength)})
+	}
+	return out
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/pkg/errors"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/hedge"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// maxSearchQueryLength is the longest query, in characters, sent to the
+// catalog. Longer queries are cut.
+const maxSearchQueryLength = 100
+
+// facetView is a value of a search facet as shown in the filters: selecting
+// it goes to URL, which drops the filter again if Selected.
+type facetView struct {
+	Value    string
+	Count    int32
+	Selected bool
+	URL      string
+}
+
+// searchFilters are the parameters of a search page.
+type searchFilters struct {
+	query, category, price string
+}
+
+// url returns the address of the search page for f.
+func (f searchFilters) url() string {
+	v := url.Values{}
+	v.Set("q", f.query)
+	if f.category != "" {
+		v.Set("category", f.category)
+	}
+	if f.price != "" {
+		v.Set("price", f.price)
+	}
+	return "/search?" + v.Encode()
+}
+
+func (fe *frontendServer) searchProducts(ctx context.Context, f searchFilters) (*pb.SearchProductsResponse, error) {
+	resp, err := hedge.Do(ctx, fe.hedger, fe.productCatalogSvcConn.Pick,
+		func(ctx context.Context, c *rpc.Client) (*pb.SearchProductsResponse, error) {
+			return pb.NewProductCatalogServiceClient(c).SearchProducts(ctx, &pb.SearchProductsRequest{
+				Query:      f.query,
+				Category:   f.category,
+				PriceRange: f.price,
+			})
+		})
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range resp.GetResults() {
+		resp.Results[i] = sanitizeProduct(p)
+	}
+	return resp, nil
+}
+
+// facetViews returns the values of facet in facets, each linking to the
+// search narrowed down to it or, if already selected, widened back.
+func facetViews(f searchFilters, facets *pb.SearchFacets, facet string) []facetView {
+	var out []facetView
+	for _, c := range facets.GetCounts() {
+		if c.GetFacet() != facet {
+			continue
+		}
+		value := sanitizeText(c.GetValue(), maxNameLength)
+		next := f
+		selected := false
+		switch facet {
+		case facetCategory:
+			selected = f.category == value
+			next.category = value
+			if selected {
+				next.category = ""
+			}
+		case facetPrice:
+			selected = f.price == value
+			next.price = value
+			if selected {
+				next.price = ""
+			}
+		}
+		out = append(out, facetView{Value: value, Count: c.GetCount(), Selected: selected, URL: next.url()})
+	}
+	return out
+}
+
+// searchHandler shows the products matching the q parameter, narrowed down
+// by the category and price parameters, along with the filters to narrow
+// them down further.
+func (fe *frontendServer) searchHandler(w http.ResponseWriter, r *http.Request) {
+	userID := sessionID(r)
+	f := searchFilters{
+		query:    sanitizeText(strings.TrimSpace(r.FormValue("q")), maxSearchQueryLength),
+		category: r.FormValue("category"),
+		price:    r.FormValue("price"),
+	}
+
+	currencies, err := fe.getCurrencies(r.Context(), userID)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve currencies"), http.StatusInternalServerError)
+		return
+	}
+	resp, err := fe.searchProducts(r.Context(), f)
+	if err != nil {
+		log.Printf("searchHandler: search for %q failed: %v", f.query, err)
+		renderHTTPError(r, w, errors.Wrap(err, "could not search products"), http.StatusInternalServerError)
+		return
+	}
+	cart, err := fe.getCart(r.Context(), userID)
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve cart"), http.StatusInternalServerError)
+		return
+	}
+	ps, err := fe.productViews(r.Context(), resp.GetResults(), currentCurrency(r), userID)
+	if err != nil {
+		renderHTTPError(r, w, err, http.StatusInternalServerError)
+		return
+	}
+	defer releaseProductViews(ps)
+
+	err = renderTemplate(w, "search", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency":    true,
+		"currencies":       currencies,
+		"cart_size":        cartSize(cart),
+		"meta_robots":      "noindex, follow",
+		"query":            f.query,
+		"products":         *ps,
+		"category_facets":  facetViews(f, resp.GetFacets(), facetCategory),
+		"price_facets":     facetViews(f, resp.GetFacets(), facetPrice),
+		"filtered":         f.category != "" || f.price != "",
+		"clear_filter_url": searchFilters{query: f.query}.url(),
+	}))
+	if err != nil {
+		log.Printf("searchHandler: error rendering template: %v", err)
+	}
+}
+
+// maxSuggestCacheEntries bounds the prefixes whose suggestions are cached.
+// The cache is emptied when it is full.
+const maxSuggestCacheEntries = 10000
+
+// suggestion is a completion of a search query as served to the search box.
+type suggestion struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// suggestResponse is the body of the answer to a request for suggestions.
+type suggestResponse struct {
+	Suggestions []suggestion `json:"suggestions"`
+}
+
+// suggestCache holds the suggestions for each prefix typed, by tenant. They
+// only change with the catalog, and a search box asks for them on every key
+// stroke.
+type suggestCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]suggestEntry
+}
+
+type suggestEntry struct {
+	suggestions []suggestion
+	expires     time.Time
+}
+
+func newSuggestCache(ttl time.Duration) *suggestCache {
+	return &suggestCache{ttl: ttl, entries: make(map[cacheKey]suggestEntry)}
+}
+
+func (c *suggestCache) get(ctx context.Context, prefix string) ([]suggestion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[cacheKey{tenant.FromContext(ctx), prefix}]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.suggestions, true
+}
+
+func (c *suggestCache) set(ctx context.Context, prefix string, suggestions []suggestion) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxSuggestCacheEntries {
+		clear(c.entries)
+	}
+	c.entries[cacheKey{tenant.FromContext(ctx), prefix}] = suggestEntry{suggestions, time.Now().Add(c.ttl)}
+}
+
+// invalidate drops every cached suggestion.
+func (c *suggestCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+func (fe *frontendServer) suggestProducts(ctx context.Context, prefix string) ([]suggestion, error) {
+	if s, ok := fe.suggestions.get(ctx, prefix); ok {
+		return s, nil
+	}
+	resp, err := hedge.Do(ctx, fe.hedger, fe.productCatalogSvcConn.Pick,
+		func(ctx context.Context, c *rpc.Client) (*pb.SuggestProductsResponse, error) {
+			return pb.NewProductCatalogServiceClient(c).SuggestProducts(ctx, &pb.SuggestProductsRequest{Prefix: prefix})
+		})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]suggestion, 0, len(resp.GetSuggestions()))
+	for _, s := range resp.GetSuggestions() {
+		out = append(out, suggestion{
+			ID:   s.GetId(),
+			Name: sanitizeText(s.GetName(), maxNameLength),
+			URL:  "/product/" + url.PathEscape(s.GetId()),
+		})
+	}
+	fe.suggestions.set(ctx, prefix, out)
+	return out, nil
+}
+
+// suggestHandler serves the completions of the q parameter for the search
+// box as JSON. Browsers may reuse them for as long as the frontend caches
+// them.
+func (fe *frontendServer) suggestHandler(w http.ResponseWriter, r *http.Request) {
+	prefix := strings.ToLower(sanitizeText(strings.TrimSpace(r.FormValue("q")), maxSearchQueryLength))
+	suggestions := []suggestion{}
+	if prefix != "" {
+		var err error
+		if suggestions, err = fe.suggestProducts(r.Context(), prefix); err != nil {
+			log.Printf("suggestHandler: suggestions for %q failed: %v", prefix, err)
+			http.Error(w, "suggestions unavailable", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if ttl := fe.suggestions.ttl; ttl > 0 {
+		w.Header().Set("Cache-Control", "private, max-age="+strconv.Itoa(int(ttl.Seconds())))
+	}
+	if err := json.NewEncoder(w).Encode(suggestResponse{Suggestions: suggestions}); err != nil {
+		log.Printf("suggestHandler: error writing response: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// defaultCSP allows the pages' own scripts and styles and the Bootstrap and
+// Google Fonts CDNs they load. Inline scripts and event handlers are still
+// used by the templates, hence 'unsafe-inline'.
+const defaultCSP = "default-src 'self'; " +
+	"script-src 'self' 'unsafe-inline' https://stackpath.bootstrapcdn.com; " +
+	"style-src 'self' 'unsafe-inline' https://stackpath.bootstrapcdn.com https://fonts.googleapis.com; " +
+	"font-src 'self' https://fonts.gstatic.com; " +
+	"img-src 'self' data:; " +
+	"connect-src 'self'; " +
+	"frame-ancestors 'none'; base-uri 'self'; form-action 'self'"
+
+// securityConfig is what securityMiddleware adds to responses.
+type securityConfig struct {
+	// csp is the Content-Security-Policy, FRONTEND_CSP, and frameOptions the
+	// X-Frame-Options, FRONTEND_FRAME_OPTIONS. Either is left out if "off".
+	csp          string
+	frameOptions string
+
+	// hstsMaxAge is the max-age of Strict-Transport-Security on TLS
+	// responses, FRONTEND_HSTS_MAX_AGE. Zero leaves the header out.
+	hstsMaxAge time.Duration
+
+	// secureCookies is FRONTEND_SECURE_COOKIES: "auto" marks cookies Secure
+	// on TLS responses, "true" always and "false" never. sameSite is
+	// FRONTEND_COOKIE_SAMESITE, "lax", "strict" or "none".
+	secureCookies string
+	sameSite      http.SameSite
+}
+
+var securitySettings = config.NewValue(func() securityConfig {
+	c := securityConfig{
+		csp:           defaultCSP,
+		frameOptions:  "DENY",
+		hstsMaxAge:    envDuration("FRONTEND_HSTS_MAX_AGE", 180*24*time.Hour),
+		secureCookies: "auto",
+		sameSite:      http.SameSiteLaxMode,
+	}
+	if v := strings.TrimSpace(config.Get("FRONTEND_CSP")); v != "" {
+		c.csp = v
+	}
+	if v := strings.TrimSpace(config.Get("FRONTEND_FRAME_OPTIONS")); v != "" {
+		c.frameOptions = v
+	}
+	if v := strings.ToLower(config.Get("FRONTEND_SECURE_COOKIES")); v == "true" || v == "false" {
+		c.secureCookies = v
+	}
+	switch strings.ToLower(config.Get("FRONTEND_COOKIE_SAMESITE")) {
+	case "strict":
+		c.sameSite = http.SameSiteStrictMode
+	case "none":
+		c.sameSite = http.SameSiteNoneMode
+	}
+	return c
+})
+
+// securityMiddleware sets the security headers of every response and marks
+// the storefront's cookies HttpOnly, SameSite and, over TLS, Secure. Nothing
+// in the pages reads the cookies from script.
+func securityMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := securitySettings.Get()
+		h := w.Header()
+		if c.csp != "off" {
+			h.Set("Content-Security-Policy", c.csp)
+		}
+		if c.frameOptions != "off" {
+			h.Set("X-Frame-Options", c.frameOptions)
+		}
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		if r.TLS != nil && c.hstsMaxAge > 0 {
+			h.Set("Strict-Transport-Security", "max-age="+strconv.Itoa(int(c.hstsMaxAge.Seconds())))
+		}
+
+		secure := c.secureCookies == "true" || (c.secureCookies == "auto" && r.TLS != nil)
+		sw := &cookieWriter{ResponseWriter: w, secure: secure, sameSite: c.sameSite}
+		next.ServeHTTP(sw, r)
+		// Handlers that write nothing leave the headers to the server.
+		sw.secureCookies()
+	})
+}
+
+// cookieWriter rewrites the storefront's cookies just before the headers
+// are sent.
+type cookieWriter struct {
+	http.ResponseWriter
+	secure   bool
+	sameSite http.SameSite
+	done     bool
+}
+
+func (w *cookieWriter) WriteHeader(code int) {
+	w.secureCookies()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cookieWriter) Write(b []byte) (int, error) {
+	w.secureCookies()
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush is used by the event streams, which may flush before writing.
+func (w *cookieWriter) Flush() {
+	w.secureCookies()
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *cookieWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *cookieWriter) secureCookies() {
+	if w.done {
+		return
+	}
+	w.done = true
+	h := w.Header()
+	for i, line := range h["Set-Cookie"] {
+		c, err := http.ParseSetCookie(line)
+		if err != nil || !strings.HasPrefix(c.Name, cookiePrefix) {
+			continue
+		}
+		c.HttpOnly = true
+		c.SameSite = w.sameSite
+		// Browsers reject SameSite=None cookies that are not Secure.
+		c.Secure = w.secure || w.sameSite == http.SameSiteNoneMode
+		h["Set-Cookie"][i] = c.String()
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// robotsDisallow lists the paths crawlers are kept out of: per-shopper pages
+// and endpoints that change state.
+var robotsDisallow = []string{
+	"/cart",
+	"/orders/",
+	"/track",
+	"/events",
+	"/ad/click",
+	"/notify",
+	"/setCurrency",
+	"/setLanguage",
+	"/debug/",
+}
+
+// siteURL returns the storefront's public base URL, STOREFRONT_URL if set or
+// else the one r was made to. Sitemaps and canonical links need absolute
+// URLs.
+func siteURL(r *http.Request) string {
+	if u := config.Get("STOREFRONT_URL"); u != "" {
+		return strings.TrimSuffix(u, "/")
+	}
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+func (fe *frontendServer) robotsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "User-agent: *")
+	for _, p := range robotsDisallow {
+		fmt.Fprintf(w, "Disallow: %s\n", p)
+	}
+	fmt.Fprintf(w, "\nSitemap: %s/sitemap.xml\n", siteURL(r))
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapLoc `xml:"url"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+// sitemapHandler serves the sitemap of the home page and the product pages.
+// The catalog is split into pages of SITEMAP_PAGE_SIZE products, served with
+// ?page=N and listed by the sitemap index served without it.
+func (fe *frontendServer) sitemapHandler(w http.ResponseWriter, r *http.Request) {
+	products, err := fe.getProducts(r.Context(), sessionID(r))
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve products"), http.StatusInternalServerError)
+		return
+	}
+	base := siteURL(r)
+	size := max(envInt("SITEMAP_PAGE_SIZE", 1000), 1)
+	pages := max((len(products)+size-1)/size, 1)
+
+	var doc interface{}
+	if p := r.URL.Query().Get("page"); p == "" {
+		index := sitemapIndex{}
+		for i := 1; i <= pages; i++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapLoc{Loc: fmt.Sprintf("%s/sitemap.xml?page=%d", base, i)})
+		}
+		doc = index
+	} else {
+		page, err := strconv.Atoi(p)
+		if err != nil || page < 1 || page > pages {
+			renderHTTPError(r, w, errors.Errorf("no sitemap page %q", p), http.StatusNotFound)
+			return
+		}
+		set := sitemapURLSet{}
+		if page == 1 {
+			set.URLs = append(set.URLs, sitemapLoc{Loc: base + "/"})
+		}
+		for _, p := range products[min((page-1)*size, len(products)):min(page*size, len(products))] {
+			set.URLs = append(set.URLs, sitemapLoc{Loc: base + "/product/" + p.GetId()})
+		}
+		doc = set
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	if err := xml.NewEncoder(w).Encode(doc); err != nil {
+		log.Printf("sitemapHandler: error writing sitemap: %v", err)
+	}
+}
+
+// productImageURL returns the absolute URL of p's picture, for link previews.
+func productImageURL(base string, p *pb.Product) string {
+	if strings.HasPrefix(p.GetPicture(), "/") {
+		return base + p.GetPicture()
+	}
+	return p.GetPicture()
+}
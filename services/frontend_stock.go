@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/hedge"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// Kinds of stock badge.
+const (
+	stockBadgeLow = "low"
+	stockBadgeIn  = "in"
+	stockBadgeOut = "out"
+)
+
+// lowStockThreshold is the stock at or below which a product page says how
+// many are left, LOW_STOCK_THRESHOLD.
+var lowStockThreshold = config.NewValue(func() int {
+	return envInt("LOW_STOCK_THRESHOLD", 5)
+})
+
+// maxStockCacheEntries bounds the products whose stock is cached.
+const maxStockCacheEntries = 10000
+
+// stockBadges counts the badges shown by kind, and the pages shown without
+// one because the stock could not be had in time.
+var stockBadges = expvar.NewMap("frontend_stock_badges")
+
+// stockBadge is the stock of a product as shown on its page.
+type stockBadge struct {
+	Kind     string
+	Quantity int32
+}
+
+// newStockBadge returns the badge for level, or nil for a product whose stock
+// is not tracked.
+func newStockBadge(level *pb.StockLevel) *stockBadge {
+	switch {
+	case !level.GetTracked():
+		return nil
+	case level.GetQuantity() <= 0:
+		return &stockBadge{Kind: stockBadgeOut}
+	case level.GetQuantity() <= int32(lowStockThreshold.Get()):
+		return &stockBadge{Kind: stockBadgeLow, Quantity: level.GetQuantity()}
+	}
+	return &stockBadge{Kind: stockBadgeIn}
+}
+
+// stockCache holds the stock of each product, by tenant, for a few seconds:
+// long enough to spare the catalog a call per page view of a popular
+// product, short enough that the badge keeps up with sales.
+type stockCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]stockEntry
+}
+
+type stockEntry struct {
+	level   *pb.StockLevel
+	expires time.Time
+}
+
+func newStockCache(ttl time.Duration) *stockCache {
+	return &stockCache{ttl: ttl, entries: make(map[cacheKey]stockEntry)}
+}
+
+func (c *stockCache) get(ctx context.Context, productID string) (*pb.StockLevel, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[cacheKey{tenant.FromContext(ctx), productID}]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.level, true
+}
+
+func (c *stockCache) set(ctx context.Context, productID string, level *pb.StockLevel) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxStockCacheEntries {
+		clear(c.entries)
+	}
+	c.entries[cacheKey{tenant.FromContext(ctx), productID}] = stockEntry{level, time.Now().Add(c.ttl)}
+}
+
+// getStockBadge returns the stock badge of a product. Badges are a nicety:
+// if the stock cannot be had, the page goes without one.
+func (fe *frontendServer) getStockBadge(ctx context.Context, productID string) *stockBadge {
+	level, ok := fe.stock.get(ctx, productID)
+	if !ok {
+		var err error
+		level, err = hedge.Do(ctx, fe.hedger, fe.productCatalogSvcConn.Pick,
+			func(ctx context.Context, c *rpc.Client) (*pb.StockLevel, error) {
+				return pb.NewProductCatalogServiceClient(c).GetStock(ctx, &pb.GetStockRequest{ProductId: productID})
+			})
+		if err != nil {
+			log.Printf("getStockBadge: GetStock of %s failed: %v", productID, err)
+			stockBadges.Add("unavailable", 1)
+			return nil
+		}
+		fe.stock.set(ctx, productID, level)
+	}
+	badge := newStockBadge(level)
+	if badge != nil {
+		stockBadges.Add(badge.Kind, 1)
+	}
+	return badge
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/validator"
+)
+
+// contactHandler shows the contact form. Links from an order page fill in
+// the order the shopper writes about.
+func (fe *frontendServer) contactHandler(w http.ResponseWriter, r *http.Request) {
+	err := renderTemplate(w, "contact", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency": false,
+		"meta_robots":   "noindex",
+		"order_id":      r.FormValue("order_id"),
+	}))
+	if err != nil {
+		log.Printf("contactHandler: error rendering template: %v", err)
+	}
+}
+
+// createTicketHandler opens a support ticket from the contact form and goes
+// to its page. Bots cannot open tickets.
+func (fe *frontendServer) createTicketHandler(w http.ResponseWriter, r *http.Request) {
+	if requestTrafficClass(r).class == trafficBot {
+		renderHTTPError(r, w, errors.New("automated clients cannot contact support"), http.StatusForbidden)
+		return
+	}
+	payload := validator.ContactPayload{
+		Name:    strings.TrimSpace(r.FormValue("name")),
+		Email:   strings.TrimSpace(r.FormValue("email")),
+		Subject: strings.TrimSpace(r.FormValue("subject")),
+		Message: strings.TrimSpace(r.FormValue("message")),
+		OrderID: strings.TrimSpace(r.FormValue("order_id")),
+	}
+	if err := payload.Validate(); err != nil {
+		renderHTTPError(r, w, validator.ValidationErrorResponse(err), http.StatusUnprocessableEntity)
+		return
+	}
+
+	supportClient := pb.NewSupportServiceClient(fe.supportSvcConn.Pick())
+	ticket, err := supportClient.CreateTicket(r.Context(), &pb.CreateTicketRequest{
+		Name:    payload.Name,
+		Email:   payload.Email,
+		Subject: payload.Subject,
+		Message: payload.Message,
+		OrderId: payload.OrderID,
+		Locale:  currentLanguage(r),
+	})
+	if err != nil {
+		code := http.StatusInternalServerError
+		if strings.Contains(err.Error(), TooManyTicketsErr{}.Error()) {
+			code = http.StatusTooManyRequests
+		}
+		renderHTTPError(r, w, errors.Wrap(err, "could not contact support"), code)
+		return
+	}
+	log.Printf("createTicketHandler: opened ticket %s", ticket.GetId())
+
+	w.Header().Set("location", "/contact/"+url.PathEscape(ticket.GetId()))
+	w.WriteHeader(http.StatusFound)
+}
+
+// ticketHandler shows a support ticket of the shopper.
+func (fe *frontendServer) ticketHandler(w http.ResponseWriter, r *http.Request) {
+	supportClient := pb.NewSupportServiceClient(fe.supportSvcConn.Pick())
+	ticket, err := supportClient.GetTicket(r.Context(), &pb.GetTicketRequest{TicketId: r.PathValue("id")})
+	if err != nil {
+		renderHTTPError(r, w, errors.Wrap(err, "could not retrieve ticket"), http.StatusNotFound)
+		return
+	}
+
+	err = renderTemplate(w, "contact", injectCommonTemplateData(r, map[string]interface{}{
+		"show_currency": false,
+		"meta_robots":   "noindex",
+		"ticket":        ticket,
+		"created_at":    time.Unix(ticket.GetCreatedAt(), 0).UTC().Format(time.RFC1123),
+	}))
+	if err != nil {
+		log.Printf("ticketHandler: error rendering template: %v", err)
+	}
+}
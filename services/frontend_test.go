@@ -0,0 +1,58 @@
+package services_test
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/testsupport"
+)
+
+func TestStorefrontHidesDebugVars(t *testing.T) {
+	c := testsupport.Start(t).NewClient(t)
+
+	// The counters are served on the status server only.
+	if _, body := c.Get("/debug/vars"); strings.Contains(body, `"memstats"`) {
+		t.Errorf("storefront serves expvar:\n%s", body)
+	}
+}
+
+func TestOrdersAreOnlyShownToTheirShopper(t *testing.T) {
+	t.Cleanup(func() { config.Reload() })
+	t.Setenv("FRONTEND_GRAPHQL", "true")
+	config.Reload()
+
+	shop := testsupport.Start(t)
+	owner := shop.NewClient(t)
+	if code, body := owner.PostForm("/cart", url.Values{"product_id": {"1YMWWN1N4O"}, "quantity": {"1"}}); code != http.StatusOK {
+		t.Fatalf("add to cart: %d %s", code, body)
+	}
+	_, cart := owner.Get("/cart")
+	code, order := owner.PostForm("/cart/checkout", testsupport.CheckoutForm(cart))
+	if code != http.StatusOK {
+		t.Fatalf("checkout: %d %s", code, order)
+	}
+	orderID := regexp.MustCompile(`/orders/([^/"]+)/receipt`).FindStringSubmatch(order)
+	trackingID := regexp.MustCompile(`/track\?tracking_id=([^"&]+)`).FindStringSubmatch(order)
+	if orderID == nil || trackingID == nil {
+		t.Fatalf("order confirmation shows no order or tracking ID:\n%s", order)
+	}
+
+	pages := []string{
+		"/orders/" + orderID[1] + "/receipt",
+		"/track?tracking_id=" + trackingID[1],
+		"/graphql?query=" + url.QueryEscape(`{ order(id: "`+orderID[1]+`") { status } }`),
+	}
+	other := shop.NewClient(t)
+	for _, page := range pages {
+		if code, body := owner.Get(page); code != http.StatusOK || strings.Contains(body, `"errors"`) {
+			t.Errorf("GET %s as the shopper: %d %s", page, code, body)
+		}
+		if code, body := other.Get(page); code == http.StatusOK && !strings.Contains(body, `"errors"`) {
+			t.Errorf("GET %s as another shopper: %d %s", page, code, body)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/pkg/errors"
+)
+
+// getWalletBalance returns the shopper's wallet balance in currency.
+func (fe *frontendServer) getWalletBalance(ctx context.Context, currency string) (*pb.Money, error) {
+	walletClient := pb.NewWalletServiceClient(fe.walletSvcConn.Pick())
+	resp, err := walletClient.GetBalance(ctx, &pb.GetWalletBalanceRequest{CurrencyCode: currency})
+	if err != nil {
+		log.Printf("getWalletBalance RPC failed: %v", err)
+		return nil, err
+	}
+	return resp.GetBalance(), nil
+}
+
+// redeemGiftCardHandler adds a gift card to the shopper's wallet and goes
+// back to the cart.
+func (fe *frontendServer) redeemGiftCardHandler(w http.ResponseWriter, r *http.Request) {
+	code := strings.TrimSpace(r.FormValue("code"))
+	if code == "" {
+		renderHTTPError(r, w, errors.New("code is required"), http.StatusUnprocessableEntity)
+		return
+	}
+
+	walletClient := pb.NewWalletServiceClient(fe.walletSvcConn.Pick())
+	resp, err := walletClient.RedeemGiftCard(r.Context(), &pb.RedeemGiftCardRequest{Code: code})
+	if err != nil {
+		log.Printf("redeemGiftCardHandler: %v", err)
+		status := http.StatusInternalServerError
+		if isGiftCardRejected(err) {
+			status = http.StatusUnprocessableEntity
+		}
+		renderHTTPError(r, w, errors.Wrap(err, "could not redeem gift card"), status)
+		return
+	}
+	log.Printf("redeemGiftCardHandler: balance is now %d %s", resp.GetBalance().GetUnits(), resp.GetBalance().GetCurrencyCode())
+
+	w.Header().Set("location", "/cart")
+	w.WriteHeader(http.StatusFound)
+}
+
+// isGiftCardRejected reports whether err is the error of RedeemGiftCard for
+// a code that cannot be redeemed, rather than a failure to reach the wallet.
+func isGiftCardRejected(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, UnknownGiftCardErr{}.Error()) || strings.Contains(msg, GiftCardRedeemedErr{}.Error())
+}
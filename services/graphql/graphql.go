@@ -0,0 +1,428 @@
+// Package graphql serves read-only GraphQL queries over a schema of Go
+// resolvers.
+//
+// It supports the subset of GraphQL that clients of a read-only graph use:
+// queries with variables, arguments, aliases and __typename. Mutations,
+// subscriptions, fragments, directives and introspection are rejected.
+//
+// Queries are executed breadth first: every field at a depth is resolved
+// before any field below it. A resolver may return a Thunk instead of a
+// value, which is only forced once all the fields at its depth have been
+// resolved, so a Loader can fetch the keys asked for by all of them in one
+// call.
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// MaxDepth is how deeply the selections of a query may be nested.
+const MaxDepth = 8
+
+// ResolveFunc resolves a field of parent, the value its object was resolved
+// to, with the arguments given in the query.
+type ResolveFunc func(ctx context.Context, parent any, args Args) (any, error)
+
+// Field is a field of an object type.
+type Field struct {
+	// Type is the name of the object type of the field, whose fields must
+	// then be selected, or "" for a scalar, which is encoded as JSON. The
+	// field is a list if it resolves to a slice.
+	Type string
+	// Args are the names of the arguments the field takes.
+	Args    []string
+	Resolve ResolveFunc
+}
+
+// Object is an object type, made of fields.
+type Object map[string]*Field
+
+// Schema is the object types of a graph, of which Query is the root.
+type Schema struct {
+	Types map[string]Object
+	// ErrorMessage returns what clients are told of an error a resolver
+	// returned while executing the query of ctx. The error itself is
+	// reported if nil.
+	ErrorMessage func(ctx context.Context, err error) string
+}
+
+// Thunk is a value that is yet to be computed.
+type Thunk func() (any, error)
+
+// Go starts computing a value on its own goroutine, so resolvers whose values
+// cannot be batched still make their calls concurrently.
+func Go(f func() (any, error)) Thunk {
+	var v any
+	var err error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		v, err = f()
+	}()
+	return func() (any, error) {
+		<-done
+		return v, err
+	}
+}
+
+// Args are the arguments of a field, with the variables they refer to
+// replaced by their values.
+type Args map[string]any
+
+// String returns the string argument name, or "" if it was not given.
+func (a Args) String(name string) (string, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	}
+	return "", &QueryError{Message: fmt.Sprintf("argument %q must be a string", name)}
+}
+
+// Strings returns the list of strings argument name. A single string is a
+// list of one.
+func (a Args) Strings(name string) ([]string, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		out := make([]string, len(v))
+		for i, e := range v {
+			s, ok := e.(string)
+			if !ok {
+				return nil, &QueryError{Message: fmt.Sprintf("argument %q must be a list of strings", name)}
+			}
+			out[i] = s
+		}
+		return out, nil
+	}
+	return nil, &QueryError{Message: fmt.Sprintf("argument %q must be a list of strings", name)}
+}
+
+// Int returns the integer argument name, or def if it was not given.
+func (a Args) Int(name string, def int) (int, error) {
+	switch v := a[name].(type) {
+	case nil:
+		return def, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		// Variables are decoded from JSON as numbers.
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+	return 0, &QueryError{Message: fmt.Sprintf("argument %q must be an integer", name)}
+}
+
+// QueryError is an error in a query, reported to the client as is.
+type QueryError struct {
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	return e.Message
+}
+
+// Error is an error reported in a response, at the path of the field that
+// failed, if any.
+type Error struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Response is the result of a query.
+type Response struct {
+	Data   *Map    `json:"data,omitempty"`
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Request is a query as posted by a client.
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Map is a JSON object whose fields are encoded in the order they were set,
+// which is the order they were selected in.
+type Map struct {
+	keys   []string
+	values map[string]any
+}
+
+func newMap() *Map {
+	return &Map{values: make(map[string]any)}
+}
+
+func (m *Map) set(key string, v any) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = v
+}
+
+func (m *Map) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(k)
+		b.Write(key)
+		b.WriteByte(':')
+		v, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return []byte(b.String()), nil
+}
+
+// Execute runs a query against s. Errors in the query fail it as a whole;
+// errors of resolvers null the field that failed and are reported along
+// with the rest of the data.
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	vars, err := op.coerceVariables(req.Variables)
+	if err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	if err := s.validate("Query", op.selection, 1); err != nil {
+		return &Response{Errors: []Error{{Message: err.Error()}}}
+	}
+	return s.execute(ctx, op.selection, vars)
+}
+
+func (d *document) operation(name string) (*operation, error) {
+	if name == "" {
+		if len(d.operations) > 1 {
+			return nil, &QueryError{Message: "operationName is required for documents with several operations"}
+		}
+		return d.operations[0], nil
+	}
+	for _, op := range d.operations {
+		if op.name == name {
+			return op, nil
+		}
+	}
+	return nil, &QueryError{Message: fmt.Sprintf("unknown operation %q", name)}
+}
+
+// coerceVariables returns the values of the variables of op given values,
+// falling back to their defaults.
+func (op *operation) coerceVariables(values map[string]any) (map[string]any, error) {
+	out := make(map[string]any)
+	for _, v := range op.variables {
+		val, ok := values[v.name]
+		if !ok && v.hasDef {
+			val, ok = v.def, true
+		}
+		if v.required && (!ok || val == nil) {
+			return nil, &QueryError{Message: fmt.Sprintf("variable $%s is required", v.name)}
+		}
+		out[v.name] = val
+	}
+	return out, nil
+}
+
+// validate checks that sels selects fields of typ that exist, with
+// arguments they take, and that the fields of objects are selected.
+func (s *Schema) validate(typ string, sels []*selection, depth int) error {
+	if depth > MaxDepth {
+		return &QueryError{Message: fmt.Sprintf("query is nested more than %d deep", MaxDepth)}
+	}
+	obj := s.Types[typ]
+	seen := make(map[string]bool)
+	for _, sel := range sels {
+		name := sel.responseName()
+		if seen[name] {
+			return &QueryError{Message: fmt.Sprintf("field %q of %s is selected twice", name, typ)}
+		}
+		seen[name] = true
+		if sel.name == "__typename" {
+			if sel.hasSub || len(sel.args) > 0 {
+				return &QueryError{Message: "__typename takes no arguments or selections"}
+			}
+			continue
+		}
+		f, ok := obj[sel.name]
+		if !ok {
+			return &QueryError{Message: fmt.Sprintf("type %s has no field %q", typ, sel.name)}
+		}
+		for _, a := range sel.args {
+			if !slices.Contains(f.Args, a.name) {
+				return &QueryError{Message: fmt.Sprintf("field %q of %s has no argument %q", sel.name, typ, a.name)}
+			}
+		}
+		switch {
+		case f.Type == "" && sel.hasSub:
+			return &QueryError{Message: fmt.Sprintf("field %q of %s is a scalar and has no fields", sel.name, typ)}
+		case f.Type != "" && !sel.hasSub:
+			return &QueryError{Message: fmt.Sprintf("field %q of %s must have a selection of fields", sel.name, typ)}
+		case f.Type != "":
+			if err := s.validate(f.Type, sel.sub, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// object is an object whose fields are yet to be resolved.
+type object struct {
+	typ   string
+	value any
+	sels  []*selection
+	out   *Map
+	path  []any
+}
+
+// resolved is a field resolved, or being resolved, to val.
+type resolved struct {
+	obj   *object
+	sel   *selection
+	field *Field
+	val   any
+	err   error
+}
+
+func (s *Schema) execute(ctx context.Context, sels []*selection, vars map[string]any) *Response {
+	resp := &Response{Data: newMap()}
+	level := []*object{{typ: "Query", sels: sels, out: resp.Data}}
+	for len(level) > 0 {
+		var fields []resolved
+		for _, o := range level {
+			for _, sel := range o.sels {
+				if sel.name == "__typename" {
+					o.out.set(sel.responseName(), o.typ)
+					continue
+				}
+				f := s.Types[o.typ][sel.name]
+				// Keep the place of the field in the response.
+				o.out.set(sel.responseName(), nil)
+				args := make(Args, len(sel.args))
+				for _, a := range sel.args {
+					args[a.name] = substitute(a.value, vars)
+				}
+				val, err := f.Resolve(ctx, o.value, args)
+				fields = append(fields, resolved{obj: o, sel: sel, field: f, val: val, err: err})
+			}
+		}
+		for i := range fields {
+			if t, ok := fields[i].val.(Thunk); ok && fields[i].err == nil {
+				fields[i].val, fields[i].err = t()
+			}
+		}
+
+		var next []*object
+		for _, r := range fields {
+			name := r.sel.responseName()
+			path := append(append([]any(nil), r.obj.path...), name)
+			if r.err != nil {
+				resp.Errors = append(resp.Errors, Error{Message: s.errorMessage(ctx, r.err), Path: path})
+				continue
+			}
+			if r.field.Type == "" {
+				r.obj.out.set(name, r.val)
+				continue
+			}
+			val, objs := complete(r.field.Type, r.val, r.sel.sub, path)
+			r.obj.out.set(name, val)
+			next = append(next, objs...)
+		}
+		level = next
+	}
+	return resp
+}
+
+func (s *Schema) errorMessage(ctx context.Context, err error) string {
+	var qe *QueryError
+	if s.ErrorMessage == nil || errors.As(err, &qe) {
+		return err.Error()
+	}
+	return s.ErrorMessage(ctx, err)
+}
+
+// complete returns the response value of a field of type typ resolved to
+// val, a value or a slice of them, along with the objects whose fields are
+// to be resolved next.
+func complete(typ string, val any, sels []*selection, path []any) (any, []*object) {
+	if val == nil {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		if isNil(val) {
+			return nil, nil
+		}
+		o := &object{typ: typ, value: val, sels: sels, out: newMap(), path: path}
+		return o.out, []*object{o}
+	}
+	list := make([]any, rv.Len())
+	var objs []*object
+	for i := range list {
+		elem := rv.Index(i).Interface()
+		if isNil(elem) {
+			continue
+		}
+		o := &object{typ: typ, value: elem, sels: sels, out: newMap(), path: append(append([]any(nil), path...), i)}
+		list[i] = o.out
+		objs = append(objs, o)
+	}
+	return list, objs
+}
+
+func isNil(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// substitute replaces the variables in an argument value by their values.
+func substitute(v any, vars map[string]any) any {
+	switch v := v.(type) {
+	case variable:
+		return vars[string(v)]
+	case enumValue:
+		return string(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = substitute(e, vars)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = substitute(e, vars)
+		}
+		return out
+	}
+	return v
+}
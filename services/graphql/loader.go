@@ -0,0 +1,68 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// Loader batches the loads of values by key within a query. The keys asked
+// for while resolving the fields at a depth are fetched in one call when the
+// first of their values is needed, and every value is fetched once per
+// query, so a Loader must not outlive the query it was made for.
+type Loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) ([]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]loaded[V]
+}
+
+type loaded[V any] struct {
+	value V
+	err   error
+}
+
+// NewLoader returns a Loader fetching values with fetch, which returns the
+// values of keys in order.
+func NewLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) ([]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, results: make(map[K]loaded[V])}
+}
+
+// Load returns a Thunk of the value of key.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) Thunk {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok && !slices.Contains(l.pending, key) {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+	return func() (any, error) {
+		r := l.get(ctx, key)
+		return r.value, r.err
+	}
+}
+
+// get returns the result of key, fetching it along with all the pending keys
+// if it has yet to be.
+func (l *Loader[K, V]) get(ctx context.Context, key K) loaded[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+	keys := l.pending
+	l.pending = nil
+	values, err := l.fetch(ctx, keys)
+	if err == nil && len(values) != len(keys) {
+		err = fmt.Errorf("expected %d values, got %d", len(keys), len(values))
+	}
+	for i, k := range keys {
+		if err != nil {
+			l.results[k] = loaded[V]{err: err}
+		} else {
+			l.results[k] = loaded[V]{value: values[i]}
+		}
+	}
+	return l.results[key]
+}
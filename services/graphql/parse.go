@@ -0,0 +1,427 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// document is a parsed query document.
+type document struct {
+	operations []*operation
+}
+
+type operation struct {
+	name      string
+	variables []*variableDef
+	selection []*selection
+}
+
+type variableDef struct {
+	name     string
+	required bool
+	def      any
+	hasDef   bool
+}
+
+// selection is a field selected in a query, under its response name.
+type selection struct {
+	alias string
+	name  string
+	args  []*argument
+	sub   []*selection
+	// hasSub tells an empty selection set from none, both of which are
+	// errors on the fields they are wrong for.
+	hasSub bool
+}
+
+// responseName is the key of the field in the response.
+func (s *selection) responseName() string {
+	if s.alias != "" {
+		return s.alias
+	}
+	return s.name
+}
+
+type argument struct {
+	name  string
+	value any
+}
+
+// variable is a reference to a variable in an argument value.
+type variable string
+
+// enumValue is an enum value in an argument value. The schema has no enum
+// types, so resolvers see it as a string.
+type enumValue string
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokPunct
+	tokName
+	tokInt
+	tokFloat
+	tokString
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type parser struct {
+	src string
+	pos int
+	tok token
+}
+
+// parse parses a query document, of which this package supports queries
+// without fragments or directives.
+func parse(src string) (doc *document, err error) {
+	p := &parser{src: strings.TrimPrefix(src, "\ufeff")}
+	defer func() {
+		if r := recover(); r != nil {
+			qe, ok := r.(*QueryError)
+			if !ok {
+				panic(r)
+			}
+			err = qe
+		}
+	}()
+	p.next()
+	doc = &document{}
+	for p.tok.kind != tokEOF {
+		doc.operations = append(doc.operations, p.parseOperation())
+	}
+	if len(doc.operations) == 0 {
+		p.fail("no operation")
+	}
+	return doc, nil
+}
+
+func (p *parser) fail(format string, args ...any) {
+	panic(&QueryError{Message: fmt.Sprintf("syntax error at %d: ", p.tok.pos) + fmt.Sprintf(format, args...)})
+}
+
+func (p *parser) parseOperation() *operation {
+	op := &operation{}
+	if p.peek("{") {
+		op.selection = p.parseSelectionSet()
+		return op
+	}
+	if p.tok.kind != tokName {
+		p.fail("expected an operation, got %q", p.tok.text)
+	}
+	switch p.tok.text {
+	case "query":
+	case "mutation", "subscription":
+		p.fail("%ss are not supported", p.tok.text)
+	case "fragment":
+		p.fail("fragments are not supported")
+	default:
+		p.fail("unknown operation %q", p.tok.text)
+	}
+	p.next()
+	if p.tok.kind == tokName {
+		op.name = p.tok.text
+		p.next()
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			op.variables = append(op.variables, p.parseVariableDef())
+		}
+	}
+	p.noDirectives()
+	op.selection = p.parseSelectionSet()
+	return op
+}
+
+func (p *parser) parseVariableDef() *variableDef {
+	p.expect("$")
+	v := &variableDef{name: p.expectName()}
+	p.expect(":")
+	v.required = p.parseType()
+	if p.skip("=") {
+		v.def, v.hasDef = p.parseValue(true), true
+	}
+	return v
+}
+
+// parseType skips a variable type, returning whether it is non-null. The
+// types of variables are not checked; resolvers check their arguments.
+func (p *parser) parseType() bool {
+	if p.skip("[") {
+		p.parseType()
+		p.expect("]")
+	} else {
+		p.expectName()
+	}
+	return p.skip("!")
+}
+
+func (p *parser) parseSelectionSet() []*selection {
+	p.expect("{")
+	var sels []*selection
+	for !p.skip("}") {
+		if p.peek("...") {
+			p.fail("fragments are not supported")
+		}
+		sels = append(sels, p.parseField())
+	}
+	if len(sels) == 0 {
+		p.fail("empty selection set")
+	}
+	return sels
+}
+
+func (p *parser) parseField() *selection {
+	s := &selection{name: p.expectName()}
+	if p.skip(":") {
+		s.alias, s.name = s.name, p.expectName()
+	}
+	if p.skip("(") {
+		for !p.skip(")") {
+			name := p.expectName()
+			p.expect(":")
+			s.args = append(s.args, &argument{name: name, value: p.parseValue(false)})
+		}
+	}
+	p.noDirectives()
+	if p.peek("{") {
+		s.sub, s.hasSub = p.parseSelectionSet(), true
+	}
+	return s
+}
+
+func (p *parser) noDirectives() {
+	if p.peek("@") {
+		p.fail("directives are not supported")
+	}
+}
+
+// parseValue parses an argument value. Constant values, such as the
+// defaults of variables, may not refer to variables.
+func (p *parser) parseValue(constant bool) any {
+	t := p.tok
+	switch t.kind {
+	case tokInt:
+		p.next()
+		n, err := strconv.ParseInt(t.text, 10, 64)
+		if err != nil {
+			p.fail("invalid integer %s", t.text)
+		}
+		return n
+	case tokFloat:
+		p.next()
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			p.fail("invalid number %s", t.text)
+		}
+		return f
+	case tokString:
+		p.next()
+		return t.text
+	case tokName:
+		p.next()
+		switch t.text {
+		case "true":
+			return true
+		case "false":
+			return false
+		case "null":
+			return nil
+		}
+		return enumValue(t.text)
+	}
+	switch {
+	case p.skip("$"):
+		if constant {
+			p.fail("variables are not allowed here")
+		}
+		return variable(p.expectName())
+	case p.skip("["):
+		list := []any{}
+		for !p.skip("]") {
+			list = append(list, p.parseValue(constant))
+		}
+		return list
+	case p.skip("{"):
+		obj := map[string]any{}
+		for !p.skip("}") {
+			name := p.expectName()
+			p.expect(":")
+			obj[name] = p.parseValue(constant)
+		}
+		return obj
+	}
+	p.fail("expected a value, got %q", t.text)
+	return nil
+}
+
+func (p *parser) peek(punct string) bool {
+	return p.tok.kind == tokPunct && p.tok.text == punct
+}
+
+func (p *parser) skip(punct string) bool {
+	if p.peek(punct) {
+		p.next()
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) {
+	if !p.skip(punct) {
+		p.fail("expected %q, got %q", punct, p.tok.text)
+	}
+}
+
+func (p *parser) expectName() string {
+	if p.tok.kind != tokName {
+		p.fail("expected a name, got %q", p.tok.text)
+	}
+	name := p.tok.text
+	p.next()
+	return name
+}
+
+// next reads the next token, skipping white space, commas and comments.
+func (p *parser) next() {
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' {
+			break
+		}
+		p.pos++
+	}
+	start := p.pos
+	p.tok = token{pos: start}
+	if p.pos >= len(p.src) {
+		p.tok.kind = tokEOF
+		return
+	}
+	c := p.src[p.pos]
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], "..."):
+		p.pos += 3
+		p.tok.kind, p.tok.text = tokPunct, "..."
+	case strings.IndexByte("!$()[]{}:=@|&", c) >= 0:
+		p.pos++
+		p.tok.kind, p.tok.text = tokPunct, string(c)
+	case c == '_' || isLetter(c):
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || isLetter(p.src[p.pos]) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok.kind, p.tok.text = tokName, p.src[start:p.pos]
+	case c == '-' || isDigit(c):
+		p.lexNumber()
+	case c == '"':
+		p.lexString()
+	default:
+		r, _ := utf8.DecodeRuneInString(p.src[p.pos:])
+		p.tok.text = string(r)
+		p.fail("unexpected character %q", r)
+	}
+}
+
+func (p *parser) lexNumber() {
+	start := p.pos
+	p.tok.kind = tokInt
+	if p.src[p.pos] == '-' {
+		p.pos++
+	}
+	digits := func() {
+		for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+			p.pos++
+		}
+	}
+	digits()
+	if p.pos < len(p.src) && p.src[p.pos] == '.' {
+		p.tok.kind = tokFloat
+		p.pos++
+		digits()
+	}
+	if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+		p.tok.kind = tokFloat
+		p.pos++
+		if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	p.tok.text = p.src[start:p.pos]
+}
+
+// lexString reads a quoted string. Block strings are not supported.
+func (p *parser) lexString() {
+	if strings.HasPrefix(p.src[p.pos:], `"""`) {
+		p.fail("block strings are not supported")
+	}
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.pos >= len(p.src) || p.src[p.pos] == '\n' {
+			p.fail("unterminated string")
+		}
+		c := p.src[p.pos]
+		if c == '"' {
+			p.pos++
+			break
+		}
+		if c != '\\' {
+			b.WriteByte(c)
+			p.pos++
+			continue
+		}
+		if p.pos+1 >= len(p.src) {
+			p.fail("unterminated string")
+		}
+		esc := p.src[p.pos+1]
+		p.pos += 2
+		switch esc {
+		case '"', '\\', '/':
+			b.WriteByte(esc)
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case 'u':
+			if p.pos+4 > len(p.src) {
+				p.fail("invalid escape")
+			}
+			n, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 16)
+			if err != nil {
+				p.fail("invalid escape")
+			}
+			b.WriteRune(rune(n))
+			p.pos += 4
+		default:
+			p.fail("invalid escape \\%c", esc)
+		}
+	}
+	p.tok.kind, p.tok.text = tokString, b.String()
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,135 @@
+// Package hedge sends a second attempt of a read-only RPC to another replica
+// when the first one is slow, and returns whichever answers first.
+//
+// aRPC elements transform a call but cannot issue one, so hedging wraps the
+// call itself rather than living in the element chain.
+package hedge
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+)
+
+// Config controls when calls are hedged.
+type Config struct {
+	// Delay is how long to wait for the first attempt before hedging. Zero
+	// disables hedging.
+	Delay time.Duration
+	// Budget is the fraction of calls that may be hedged, e.g. 0.1 for 10%.
+	Budget float64
+}
+
+// maxTokens caps the hedges that can be saved up during quiet periods.
+const maxTokens = 10
+
+// Hedger hedges calls within a budget. Every call earns Budget tokens and each
+// hedge spends one, so extra load stays at roughly Budget of the call rate.
+type Hedger struct {
+	mu     sync.Mutex
+	cfg    Config
+	tokens float64
+}
+
+// New returns a Hedger for cfg.
+func New(cfg Config) *Hedger {
+	return &Hedger{cfg: cfg}
+}
+
+// SetConfig replaces the delay and budget used for subsequent calls.
+func (h *Hedger) SetConfig(cfg Config) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cfg = cfg
+}
+
+// earn adds the call's share of the budget and returns the current delay.
+func (h *Hedger) earn() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cfg.Delay > 0 {
+		h.tokens = min(h.tokens+h.cfg.Budget, maxTokens)
+	}
+	return h.cfg.Delay
+}
+
+func (h *Hedger) spend() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.tokens < 1 {
+		return false
+	}
+	h.tokens--
+	return true
+}
+
+type result[T any] struct {
+	v   T
+	err error
+}
+
+// Do calls call on the client returned by pick and, if it has not returned
+// after the configured delay, calls it again on a second pick. The second
+// attempt is skipped when pick returns the same client, since a client
+// cannot carry two calls at once, or when the budget is spent.
+//
+// Only idempotent calls may be hedged. The slower attempt is left to finish
+// in the background.
+func Do[T any](ctx context.Context, h *Hedger, pick func() *rpc.Client, call func(context.Context, *rpc.Client) (T, error)) (T, error) {
+	first := pick()
+	if h == nil {
+		return call(ctx, first)
+	}
+	delay := h.earn()
+	if delay <= 0 {
+		return call(ctx, first)
+	}
+
+	results := make(chan result[T], 2)
+	attempt := func(c *rpc.Client) {
+		v, err := call(ctx, c)
+		results <- result[T]{v, err}
+	}
+	go attempt(first)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case r := <-results:
+		return r.v, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case <-timer.C:
+	}
+
+	second := pick()
+	if second == first || !h.spend() {
+		select {
+		case r := <-results:
+			return r.v, r.err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+	go attempt(second)
+
+	// Prefer the first success; report an error only if both attempts fail.
+	var r result[T]
+	for range 2 {
+		select {
+		case r = <-results:
+			if r.err == nil {
+				return r.v, nil
+			}
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+	return r.v, r.err
+}
@@ -0,0 +1,126 @@
+// Package i18n loads message catalogs and negotiates the language to render
+// templates in.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Bundle holds one message catalog per language.
+type Bundle struct {
+	fallback  string
+	catalogs  map[string]map[string]string
+	languages []string
+}
+
+// Load reads every <lang>.json file in dir. Keys missing from a catalog are
+// looked up in the fallback language.
+func Load(dir, fallback string) (*Bundle, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	b := &Bundle{
+		fallback: fallback,
+		catalogs: make(map[string]map[string]string, len(files)),
+	}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		catalog := map[string]string{}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %v", f, err)
+		}
+		lang := strings.ToLower(strings.TrimSuffix(filepath.Base(f), ".json"))
+		b.catalogs[lang] = catalog
+		b.languages = append(b.languages, lang)
+	}
+	if _, ok := b.catalogs[fallback]; !ok {
+		return nil, fmt.Errorf("no catalog for fallback language %q in %s", fallback, dir)
+	}
+	sort.Strings(b.languages)
+	return b, nil
+}
+
+// MustLoad is like Load but panics on error.
+func MustLoad(dir, fallback string) *Bundle {
+	b, err := Load(dir, fallback)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Languages returns the supported language tags in sorted order.
+func (b *Bundle) Languages() []string {
+	return b.languages
+}
+
+// Supports reports whether there is a catalog for lang.
+func (b *Bundle) Supports(lang string) bool {
+	_, ok := b.catalogs[strings.ToLower(lang)]
+	return ok
+}
+
+// T translates key into lang, formatting args into the message if given. If
+// no catalog has the key, the key itself is returned.
+func (b *Bundle) T(lang, key string, args ...interface{}) string {
+	msg, ok := b.catalogs[strings.ToLower(lang)][key]
+	if !ok {
+		if msg, ok = b.catalogs[b.fallback][key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Match picks the best supported language for an Accept-Language header,
+// falling back to the base language of a regional tag (fr-CH -> fr) and
+// finally to the bundle's fallback.
+func (b *Bundle) Match(acceptLanguage string) string {
+	type pref struct {
+		tag string
+		q   float64
+	}
+	var prefs []pref
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		if q > 0 {
+			prefs = append(prefs, pref{tag, q})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].q > prefs[j].q })
+
+	for _, p := range prefs {
+		if b.Supports(p.tag) {
+			return p.tag
+		}
+		if base, _, found := strings.Cut(p.tag, "-"); found && b.Supports(base) {
+			return base
+		}
+	}
+	return b.fallback
+}
@@ -0,0 +1,179 @@
+package loadshed
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// latencyWindow is how far back the latencies the p99 is taken over go.
+	// Older ones are dropped, so that a burst of slow requests stops counting
+	// once it is over even if shedding keeps new samples from coming in.
+	latencyWindow = 10 * time.Second
+	// bucketSpan is the granularity at which latencies expire.
+	bucketSpan = time.Second
+	// p99Interval is how often the p99 is recomputed.
+	p99Interval = time.Second
+	// probeEvery lets one in that many requests through while the p99 is
+	// over MaxP99, so that recovery shows up in the latencies.
+	probeEvery = 20
+	// staleAfter bounds how long a request counts as in flight. aRPC does not
+	// run ProcessResponse for failed calls, so those are only dropped here.
+	staleAfter = 30 * time.Second
+)
+
+// Config holds the thresholds above which new requests are rejected. A zero
+// value disables that check.
+type Config struct {
+	MaxInFlight int
+	MaxP99      time.Duration
+}
+
+// ServerLoadShedElement implements RPC element interface for server-side load shedding
+type ServerLoadShedElement struct {
+	mu        sync.Mutex
+	cfg       Config
+	inFlight  map[uint64]time.Time
+	lastSweep time.Time
+
+	buckets []bucket // oldest first
+	p99     time.Duration
+	p99At   time.Time
+	shed    int // requests shed since the last probe
+
+	now func() time.Time
+}
+
+// bucket holds the latencies of the responses sent within bucketSpan of start.
+type bucket struct {
+	start     time.Time
+	latencies []time.Duration
+}
+
+// NewServerLoadShedElement creates a new server-side load-shedding element
+func NewServerLoadShedElement(cfg Config) *ServerLoadShedElement {
+	return &ServerLoadShedElement{
+		cfg:      cfg,
+		inFlight: make(map[uint64]time.Time),
+		now:      time.Now,
+	}
+}
+
+// SetConfig replaces the thresholds. Requests already in flight are kept, but
+// the latencies are dropped so that the new MaxP99 is judged afresh.
+func (e *ServerLoadShedElement) SetConfig(cfg Config) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cfg = cfg
+	e.buckets = nil
+	e.p99 = 0
+	e.p99At = time.Time{}
+	e.shed = 0
+}
+
+func (e *ServerLoadShedElement) Name() string {
+	return "server-loadshed"
+}
+
+func (e *ServerLoadShedElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	now := e.now()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if now.Sub(e.lastSweep) > staleAfter {
+		for id, start := range e.inFlight {
+			if now.Sub(start) > staleAfter {
+				delete(e.inFlight, id)
+			}
+		}
+		e.lastSweep = now
+	}
+
+	if e.cfg.MaxInFlight > 0 && len(e.inFlight) >= e.cfg.MaxInFlight {
+		return nil, ctx, e.reject(req, fmt.Sprintf("%d requests in flight", len(e.inFlight)))
+	}
+	if e.cfg.MaxP99 > 0 {
+		if now.Sub(e.p99At) > p99Interval {
+			e.expire(now)
+			var latencies []time.Duration
+			for _, b := range e.buckets {
+				latencies = append(latencies, b.latencies...)
+			}
+			e.p99 = percentile(latencies, 0.99)
+			e.p99At = now
+		}
+		if e.p99 > e.cfg.MaxP99 {
+			if e.shed++; e.shed < probeEvery {
+				return nil, ctx, e.reject(req, fmt.Sprintf("p99 latency %v", e.p99))
+			}
+			e.shed = 0
+		}
+	}
+
+	e.inFlight[req.ID] = now
+	return req, ctx, nil
+}
+
+func (e *ServerLoadShedElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	start, ok := e.inFlight[resp.ID]
+	if !ok {
+		return resp, ctx, nil
+	}
+	delete(e.inFlight, resp.ID)
+
+	now := e.now()
+	if n := len(e.buckets); n == 0 || now.Sub(e.buckets[n-1].start) >= bucketSpan {
+		e.expire(now)
+		e.buckets = append(e.buckets, bucket{start: now})
+	}
+	last := &e.buckets[len(e.buckets)-1]
+	last.latencies = append(last.latencies, now.Sub(start))
+
+	return resp, ctx, nil
+}
+
+func (e *ServerLoadShedElement) Close() error {
+	return nil
+}
+
+// expire drops the buckets that have fallen out of the latency window. The
+// caller must hold mu.
+func (e *ServerLoadShedElement) expire(now time.Time) {
+	i := 0
+	for i < len(e.buckets) && now.Sub(e.buckets[i].start) >= latencyWindow {
+		i++
+	}
+	e.buckets = slices.Delete(e.buckets, 0, i)
+}
+
+// reject builds the error returned to the client for a shed request.
+func (e *ServerLoadShedElement) reject(req *element.RPCRequest, reason string) error {
+	log.Printf("loadshed: rejecting %s.%s: %s", req.ServiceName, req.Method, reason)
+	return &rpc.RPCError{
+		Type:   rpc.RPCFailError,
+		Reason: status.Errorf(codes.ResourceExhausted, "server overloaded: %s", reason).Error(),
+	}
+}
+
+// percentile returns the p-th percentile of samples, or 0 if there are none.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(samples)
+	slices.Sort(sorted)
+	return sorted[int(p*float64(len(sorted)-1))]
+}
@@ -0,0 +1,102 @@
+package loadshed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+)
+
+// clock is a settable time source for the element.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestElement(cfg Config) (*ServerLoadShedElement, *clock) {
+	c := &clock{t: time.Unix(1700000000, 0)}
+	e := NewServerLoadShedElement(cfg)
+	e.now = c.now
+	return e, c
+}
+
+// call sends request id through e and, if it is admitted, answers it after d.
+func call(e *ServerLoadShedElement, c *clock, id uint64, d time.Duration) error {
+	ctx := context.Background()
+	if _, _, err := e.ProcessRequest(ctx, &element.RPCRequest{ID: id, ServiceName: "test", Method: "Call"}); err != nil {
+		return err
+	}
+	c.t = c.t.Add(d)
+	_, _, err := e.ProcessResponse(ctx, &element.RPCResponse{ID: id})
+	return err
+}
+
+// burst sends n requests through e at once and answers them all after d.
+func burst(t *testing.T, e *ServerLoadShedElement, c *clock, id *uint64, n int, d time.Duration) {
+	t.Helper()
+	ctx := context.Background()
+	first := *id + 1
+	for range n {
+		*id++
+		if _, _, err := e.ProcessRequest(ctx, &element.RPCRequest{ID: *id, ServiceName: "test", Method: "Call"}); err != nil {
+			t.Fatalf("request %d rejected: %v", *id, err)
+		}
+	}
+	c.t = c.t.Add(d)
+	for i := first; i <= *id; i++ {
+		if _, _, err := e.ProcessResponse(ctx, &element.RPCResponse{ID: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestShedsWhileSlowAndRecovers(t *testing.T) {
+	e, c := newTestElement(Config{MaxP99: 100 * time.Millisecond})
+
+	var id uint64
+	burst(t, e, c, &id, 50, 500*time.Millisecond)
+	c.t = c.t.Add(2 * p99Interval)
+	id++
+	if err := call(e, c, id, time.Millisecond); err == nil {
+		t.Fatal("request admitted with a p99 of 500ms")
+	}
+
+	// Latency is back to normal: the few probes let through while shedding
+	// are fast, and the slow burst falls out of the window.
+	probes := 0
+	for range 200 {
+		c.t = c.t.Add(100 * time.Millisecond)
+		id++
+		if err := call(e, c, id, time.Millisecond); err == nil && e.p99 > e.cfg.MaxP99 {
+			probes++
+		}
+		if e.p99 <= e.cfg.MaxP99 {
+			break
+		}
+	}
+	if probes == 0 {
+		t.Error("no probe was let through while shedding")
+	}
+	c.t = c.t.Add(2 * p99Interval)
+	for range 10 {
+		id++
+		if err := call(e, c, id, time.Millisecond); err != nil {
+			t.Fatalf("still shedding after latency recovered: %v", err)
+		}
+	}
+}
+
+func TestSetConfigResetsLatencies(t *testing.T) {
+	e, c := newTestElement(Config{MaxP99: 100 * time.Millisecond})
+	var id uint64
+	burst(t, e, c, &id, 10, time.Second)
+	c.t = c.t.Add(2 * p99Interval)
+	if err := call(e, c, id+1, time.Millisecond); err == nil {
+		t.Fatal("request admitted with a p99 of 1s")
+	}
+
+	e.SetConfig(Config{MaxP99: 100 * time.Millisecond})
+	if err := call(e, c, id+2, time.Millisecond); err != nil {
+		t.Fatalf("request rejected after SetConfig: %v", err)
+	}
+}
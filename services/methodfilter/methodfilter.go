@@ -0,0 +1,118 @@
+// Package methodfilter limits RPC elements to some of a server's methods, so
+// that, for example, load shedding applies to ListProducts but never to
+// GetCart.
+//
+// The methods an element runs for are read from a configuration key derived
+// from its name: SERVER_LOADSHED_METHODS for "server-loadshed". The value is
+// a comma-separated list of patterns, each "Method", "Service.Method" or
+// "Service.*". A leading "!" excludes the methods a pattern matches. An
+// element runs for a method that no exclusion matches and that some
+// inclusion matches, or for every method not excluded if there are no
+// inclusions. Unset, it runs for every method. The lists are reloaded with
+// the configuration.
+package methodfilter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// ConfigKey returns the configuration key listing the methods of the
+// element with the given name.
+func ConfigKey(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_METHODS"
+}
+
+// rules are the parsed patterns of a configuration value.
+type rules struct {
+	include []string
+	exclude []string
+}
+
+func parse(v string) rules {
+	var r rules
+	for _, p := range strings.Split(v, ",") {
+		p = strings.TrimSpace(p)
+		switch {
+		case p == "":
+		case strings.HasPrefix(p, "!"):
+			r.exclude = append(r.exclude, strings.TrimSpace(p[1:]))
+		default:
+			r.include = append(r.include, p)
+		}
+	}
+	return r
+}
+
+// match reports whether pattern matches method of service.
+func match(pattern, service, method string) bool {
+	svc, m, ok := strings.Cut(pattern, ".")
+	if !ok {
+		svc, m = "*", pattern
+	}
+	return (svc == "*" || svc == service) && (m == "*" || m == method)
+}
+
+func (r rules) allows(service, method string) bool {
+	for _, p := range r.exclude {
+		if match(p, service, method) {
+			return false
+		}
+	}
+	if len(r.include) == 0 {
+		return true
+	}
+	for _, p := range r.include {
+		if match(p, service, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// Element runs the element it wraps only for the configured methods.
+type Element struct {
+	inner element.RPCElement
+	rules *config.Value[rules]
+}
+
+// skippedKey marks a context whose request was not passed to the inner
+// element, so that its response is not either.
+type skippedKey struct{ e *Element }
+
+// Wrap returns e limited to the methods configured for it.
+func Wrap(e element.RPCElement) *Element {
+	key := ConfigKey(e.Name())
+	return &Element{
+		inner: e,
+		rules: config.NewValue(func() rules { return parse(config.Get(key)) }),
+	}
+}
+
+func (e *Element) Name() string {
+	return e.inner.Name()
+}
+
+func (e *Element) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	if !e.rules.Get().allows(req.ServiceName, req.Method) {
+		return req, context.WithValue(ctx, skippedKey{e}, true), nil
+	}
+	return e.inner.ProcessRequest(ctx, req)
+}
+
+func (e *Element) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	if skipped, _ := ctx.Value(skippedKey{e}).(bool); skipped {
+		return resp, ctx, nil
+	}
+	return e.inner.ProcessResponse(ctx, resp)
+}
+
+func (e *Element) Close() error {
+	if c, ok := e.inner.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -2,41 +2,154 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"math/rand"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/audit"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/privacy"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/resolver"
+	"github.com/appnetorg/online-boutique-arpc/services/rpclog"
+	"github.com/appnetorg/online-boutique-arpc/services/rpcstatus"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
+// The errors of a charge reach clients with the status their GRPCStatus
+// method returns: InvalidArgument for a request that cannot be charged as
+// it is, FailedPrecondition for a card the issuer turned down. Those of a
+// card the shopper should check or replace carry a card-rejected detail,
+// which IsCardRejected tells.
+
 type InvalidCreditCardErr struct{}
 
 func (e InvalidCreditCardErr) Error() string {
 	return "invalid credit card"
 }
 
+func (e InvalidCreditCardErr) GRPCStatus() *status.Status {
+	return cardRejectedStatus(codes.InvalidArgument, e)
+}
+
 type UnacceptedCreditCardErr struct{}
 
 func (e UnacceptedCreditCardErr) Error() string {
 	return "credit card not accepted; only VISA or MasterCard are accepted"
 }
 
+func (e UnacceptedCreditCardErr) GRPCStatus() *status.Status {
+	return cardRejectedStatus(codes.InvalidArgument, e)
+}
+
 type ExpiredCreditCardErr struct{}
 
 func (e ExpiredCreditCardErr) Error() string {
 	return "credit card expired"
 }
 
-func validateAndCharge(amount *pb.Money, card *pb.CreditCardInfo) (string, error) {
+func (e ExpiredCreditCardErr) GRPCStatus() *status.Status {
+	return cardRejectedStatus(codes.InvalidArgument, e)
+}
+
+type CardDeclinedErr struct{}
+
+func (e CardDeclinedErr) Error() string {
+	return "credit card declined by issuer"
+}
+
+func (e CardDeclinedErr) GRPCStatus() *status.Status {
+	return cardRejectedStatus(codes.FailedPrecondition, e)
+}
+
+type InvalidAmountErr struct{}
+
+func (e InvalidAmountErr) Error() string {
+	return "charge amount must be a valid, positive amount of money"
+}
+
+func (e InvalidAmountErr) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+type UnsupportedCurrencyErr struct {
+	CurrencyCode string
+}
+
+func (e UnsupportedCurrencyErr) Error() string {
+	return fmt.Sprintf("currency %q cannot be charged", e.CurrencyCode)
+}
+
+func (e UnsupportedCurrencyErr) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+type ThreeDSChallengeFailedErr struct{}
+
+func (e ThreeDSChallengeFailedErr) Error() string {
+	return "3-D Secure challenge failed"
+}
+
+func (e ThreeDSChallengeFailedErr) GRPCStatus() *status.Status {
+	return cardRejectedStatus(codes.FailedPrecondition, e)
+}
+
+// errCardRejected is the reason of the detail of a card-rejected status.
+const errCardRejected = "CARD_REJECTED"
+
+// cardRejectedStatus returns the status of err, a charge the card cannot
+// be used for.
+func cardRejectedStatus(code codes.Code, err error) *status.Status {
+	st := status.New(code, err.Error())
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: errCardRejected, Domain: "payment"}); err == nil {
+		st = detailed
+	}
+	return st
+}
+
+// IsCardRejected reports whether err is the error of PlaceOrder or Charge
+// for a card that cannot be charged, invalid or declined.
+func IsCardRejected(err error) bool {
+	st, ok := rpcstatus.FromError(err)
+	if !ok {
+		return false
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok && info.GetReason() == errCardRejected {
+			return true
+		}
+	}
+	return false
+}
+
+// Transaction statuses recorded in the ledger.
+const (
+	transactionCharged  = "CHARGED"
+	transactionDeclined = "DECLINED"
+	transactionRefunded = "REFUNDED"
+)
+
+// validateAndCharge validates the card and charges it under the given
+// simulation profile, returning the card company.
+func validateAndCharge(amount *pb.Money, card *pb.CreditCardInfo, profile *paymentProfile) (string, error) {
 	// Perform some rudimentary validation.
 	number := strings.ReplaceAll(card.CreditCardNumber, "-", "")
 	var company string
@@ -59,30 +172,207 @@ func validateAndCharge(amount *pb.Money, card *pb.CreditCardInfo) (string, error
 		return "", ExpiredCreditCardErr{}
 	}
 
-	// Card is valid: process the transaction.
-	log.Printf(
-		"Transaction processed: company=%s, last_four=%s, currency=%s, amount=%d.%d",
-		company,
-		number[len(number)-4:],
-		amount.CurrencyCode,
-		amount.Units,
-		amount.Nanos,
-	)
+	// Card is valid: simulate the issuer.
+	if err := profile.authorize(); err != nil {
+		return company, err
+	}
+
+	return company, nil
+}
+
+// lastFour returns the last four digits of a card number, or "" if it is too short.
+func lastFour(cardNumber string) string {
+	number := strings.ReplaceAll(cardNumber, "-", "")
+	if len(number) < 4 {
+		return ""
+	}
+	return number[len(number)-4:]
+}
+
+// paymentProfile describes how the simulated issuer behaves for a card.
+type paymentProfile struct {
+	// DeclineRate is the fraction of valid charges that are declined.
+	DeclineRate float64 `json:"decline_rate"`
+	// ChallengeRate is the fraction of charges that trigger a 3-D Secure challenge.
+	ChallengeRate float64 `json:"challenge_rate"`
+	// ChallengePassRate is the fraction of challenges the cardholder completes.
+	ChallengePassRate float64 `json:"challenge_pass_rate"`
+	// ChallengeLatency is added to charges that are challenged.
+	ChallengeLatency string `json:"challenge_latency"`
+	// Latency is a distribution spec, see parseLatency.
+	Latency string `json:"latency"`
+
+	challengeLatency time.Duration
+	latency          latencyDist
+}
+
+// authorize simulates the issuer's decision for a valid card.
+func (p *paymentProfile) authorize() error {
+	if rand.Float64() < p.ChallengeRate {
+		time.Sleep(p.challengeLatency)
+		if rand.Float64() >= p.ChallengePassRate {
+			return ThreeDSChallengeFailedErr{}
+		}
+	}
+	if rand.Float64() < p.DeclineRate {
+		return CardDeclinedErr{}
+	}
+	return nil
+}
+
+// latencyDist samples a simulated processing delay.
+type latencyDist func() time.Duration
+
+// parseLatency parses a latency spec. Supported forms are a plain duration
+// ("50ms"), "fixed:50ms", "uniform:20ms,200ms", "normal:100ms,25ms" (mean,
+// stddev) and "exponential:80ms" (mean).
+func parseLatency(spec string) (latencyDist, error) {
+	if spec == "" {
+		return func() time.Duration { return 0 }, nil
+	}
+	kind, args, found := strings.Cut(spec, ":")
+	if !found {
+		kind, args = "fixed", spec
+	}
+	var ds []time.Duration
+	for _, a := range strings.Split(args, ",") {
+		d, err := time.ParseDuration(strings.TrimSpace(a))
+		if err != nil {
+			return nil, fmt.Errorf("invalid latency %q: %v", spec, err)
+		}
+		ds = append(ds, d)
+	}
+
+	switch {
+	case kind == "fixed" && len(ds) == 1:
+		return func() time.Duration { return ds[0] }, nil
+	case kind == "uniform" && len(ds) == 2 && ds[1] >= ds[0]:
+		return func() time.Duration {
+			return ds[0] + time.Duration(rand.Int63n(int64(ds[1]-ds[0])+1))
+		}, nil
+	case kind == "normal" && len(ds) == 2:
+		return func() time.Duration {
+			return max(0, ds[0]+time.Duration(rand.NormFloat64()*float64(ds[1])))
+		}, nil
+	case kind == "exponential" && len(ds) == 1:
+		return func() time.Duration {
+			return time.Duration(rand.ExpFloat64() * float64(ds[0]))
+		}, nil
+	}
+	return nil, fmt.Errorf("invalid latency %q", spec)
+}
+
+// compile parses the profile's duration fields.
+func (p *paymentProfile) compile() error {
+	var err error
+	if p.latency, err = parseLatency(p.Latency); err != nil {
+		return err
+	}
+	if p.ChallengeLatency != "" {
+		if p.challengeLatency, err = time.ParseDuration(p.ChallengeLatency); err != nil {
+			return fmt.Errorf("invalid challenge latency %q: %v", p.ChallengeLatency, err)
+		}
+	}
+	return nil
+}
+
+// envFloat reads a float from the environment, keeping def if unset or invalid.
+func envFloat(key string, def float64) float64 {
+	v := config.Get(key)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q: %v", key, v, err)
+		return def
+	}
+	return f
+}
+
+// loadPaymentProfiles builds the default profile from PAYMENT_* variables and
+// per card prefix overrides from PAYMENT_PROFILES, a JSON object keyed by
+// card number prefix.
+func loadPaymentProfiles() (*paymentProfile, map[string]*paymentProfile) {
+	def := &paymentProfile{
+		DeclineRate:       envFloat("PAYMENT_DECLINE_RATE", 0),
+		ChallengeRate:     envFloat("PAYMENT_3DS_RATE", 0),
+		ChallengePassRate: envFloat("PAYMENT_3DS_PASS_RATE", 1),
+		ChallengeLatency:  config.Get("PAYMENT_3DS_LATENCY"),
+		Latency:           config.Get("PAYMENT_LATENCY"),
+	}
+	if err := def.compile(); err != nil {
+		log.Printf("Ignoring invalid payment profile: %v", err)
+		def = &paymentProfile{ChallengePassRate: 1}
+		def.compile()
+	}
 
-	// Generate a transaction ID.
-	return uuid.New().String(), nil
+	byPrefix := map[string]*paymentProfile{}
+	if v := config.Get("PAYMENT_PROFILES"); v != "" {
+		if err := json.Unmarshal([]byte(v), &byPrefix); err != nil {
+			log.Printf("Ignoring invalid PAYMENT_PROFILES: %v", err)
+			return def, nil
+		}
+		for prefix, p := range byPrefix {
+			if err := p.compile(); err != nil {
+				log.Printf("Ignoring payment profile for prefix %q: %v", prefix, err)
+				delete(byPrefix, prefix)
+			}
+		}
+	}
+	return def, byPrefix
 }
 
 // NewPaymentService returns a new server for the PaymentService
 func NewPaymentService(port int) *PaymentService {
 	return &PaymentService{
 		port: port,
+		profiles: config.NewValue(func() paymentProfiles {
+			def, byPrefix := loadPaymentProfiles()
+			return paymentProfiles{def: def, byPrefix: byPrefix}
+		}),
 	}
 }
 
+// paymentProfiles is the set of profiles in effect, replaced on reload.
+type paymentProfiles struct {
+	def      *paymentProfile
+	byPrefix map[string]*paymentProfile
+}
+
+// profileFor returns the profile with the longest prefix matching the card number.
+func (s *PaymentService) profileFor(cardNumber string) *paymentProfile {
+	number := strings.ReplaceAll(cardNumber, "-", "")
+	profiles := s.profiles.Get()
+	best, bestLen := profiles.def, 0
+	for prefix, p := range profiles.byPrefix {
+		if len(prefix) > bestLen && strings.HasPrefix(number, prefix) {
+			best, bestLen = p, len(prefix)
+		}
+	}
+	return best
+}
+
 // PaymentService implements the PaymentService
 type PaymentService struct {
 	port int
+
+	rdb   redis.UniversalClient // Transaction ledger
+	audit *audit.Log
+
+	profiles *config.Value[paymentProfiles]
+
+	// Currencies that can be charged directly; empty means any.
+	chargeableCurrencies map[string]bool
+	// If set, other currencies are converted to this one before charging.
+	settlementCurrency string
+
+	currencySvcAddr string
+	currencySvcConn *resolver.Pool
+
+	// Status changes reported by processor webhooks are published here.
+	eventBusAddr string
+	bus          *eventbus.Bus
 }
 
 // Run starts the server
@@ -92,36 +382,327 @@ func (s *PaymentService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	serializer := &serializer.SymphonySerializer{}
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
+	s.rdb = newRedisClient("PAYMENT")
+
+	s.chargeableCurrencies = map[string]bool{}
+	for _, code := range strings.Split(config.Get("PAYMENT_CURRENCIES"), ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			s.chargeableCurrencies[code] = true
+		}
+	}
+	s.settlementCurrency = strings.TrimSpace(config.Get("PAYMENT_SETTLEMENT_CURRENCY"))
+	if s.settlementCurrency != "" {
+		mapServiceAddr(&s.currencySvcAddr, "CURRENCY_SERVICE_ADDR", "currency")
+		mustConnARPC(&s.currencySvcConn, s.currencySvcAddr)
+	}
+
+	s.audit = audit.New(s.rdb, "PaymentService")
+	s.audit.RecordConfigChanges()
+
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
+	if addr := config.Get("PAYMENT_WEBHOOK_ADDR"); addr != "" {
+		s.serveWebhooks(addr)
+	}
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement(), audit.NewServerElement(s.audit, "Charge", "Refund"))
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
 	}
 
 	pb.RegisterPaymentServiceServer(server, s)
+	pb.RegisterPrivacyServiceServer(server, s)
 	log.Printf("PaymentService running at port: %d", s.port)
 	server.Start()
 	return nil
 }
 
 // Charge processes a payment charge request
-func (s *PaymentService) Charge(ctx context.Context, req *pb.ChargeRequest) (*pb.ChargeResponse, context.Context, error) {
-	log.Printf("Charge request received for amount: %v %v", req.GetAmount().GetCurrencyCode(), req.GetAmount().GetUnits())
-	log.Printf("Credit Card Info: Number ending in ****%s, Expiry: %02d/%04d",
-		req.GetCreditCard().GetCreditCardNumber()[len(req.GetCreditCard().GetCreditCardNumber())-4:],
-		req.GetCreditCard().GetCreditCardExpirationMonth(),
-		req.GetCreditCard().GetCreditCardExpirationYear())
-
-	transactionID, err := validateAndCharge(req.GetAmount(), req.GetCreditCard())
+func (s *PaymentService) Charge(ctx context.Context, req *pb.ChargeRequest) (_ *pb.ChargeResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	rpclog.Debug(ctx, "charging card",
+		zap.String("last_four", lastFour(req.GetCreditCard().GetCreditCardNumber())),
+		zap.Int32("expiration_month", req.GetCreditCard().GetCreditCardExpirationMonth()),
+		zap.Int32("expiration_year", req.GetCreditCard().GetCreditCardExpirationYear()))
+
+	userID := usercontext.UserID(ctx, req.GetUserId())
+	amount, err := s.normalizeAmount(ctx, req.GetAmount(), userID)
 	if err != nil {
-		log.Printf("Transaction failed: %v", err)
+		rpclog.Warn(ctx, "charge rejected", zap.Error(err))
+		return nil, ctx, rpcstatus.Convert(err)
+	}
+
+	// The card is authorized for the whole amount; the plan records when
+	// each part of it falls due.
+	var plan *pb.InstallmentPlan
+	if n := req.GetInstallments(); n > 1 {
+		if !installmentsOffered(n) {
+			err := InstallmentsNotAvailableErr{Installments: n}
+			rpclog.Warn(ctx, "charge rejected", zap.Error(err))
+			return nil, ctx, rpcstatus.Convert(err)
+		}
+		if plan, err = installmentPlan(amount, n, time.Now()); err != nil {
+			return nil, ctx, rpcstatus.Convert(err)
+		}
+		rpclog.Debug(ctx, "charging in installments", zap.Int32("installments", n))
+	}
+
+	now := time.Now().Unix()
+	txn := &pb.Transaction{
+		TransactionId:   uuid.New().String(),
+		UserId:          userID,
+		Amount:          amount,
+		CardLastFour:    lastFour(req.GetCreditCard().GetCreditCardNumber()),
+		Status:          transactionCharged,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		InstallmentPlan: plan,
+		OrderId:         req.GetOrderId(),
+	}
+
+	profile := s.profileFor(req.GetCreditCard().GetCreditCardNumber())
+	time.Sleep(profile.latency())
+
+	company, chargeErr := validateAndCharge(amount, req.GetCreditCard(), profile)
+	if chargeErr != nil {
+		txn.Status = transactionDeclined
+		txn.FailureReason = chargeErr.Error()
+	}
+	txn.CardBrand = company
+
+	if err := s.saveTransaction(ctx, txn); err != nil {
+		rpclog.Warn(ctx, "failed to record transaction", zap.String("transaction_id", txn.TransactionId), zap.Error(err))
 		return nil, ctx, err
 	}
 
-	log.Printf("Transaction successful: %v", transactionID)
+	if chargeErr != nil {
+		rpclog.Warn(ctx, "charge declined", zap.String("transaction_id", txn.TransactionId), zap.Error(chargeErr))
+		return nil, ctx, rpcstatus.Convert(chargeErr)
+	}
+
+	rpclog.Info(ctx, "card charged",
+		zap.String("transaction_id", txn.TransactionId),
+		zap.String("company", company),
+		zap.String("last_four", txn.CardLastFour),
+		zap.String("amount", fmt.Sprintf("%d.%09d %s", amount.GetUnits(), amount.GetNanos(), amount.GetCurrencyCode())))
 
 	return &pb.ChargeResponse{
-		TransactionId: transactionID,
+		TransactionId:   txn.TransactionId,
+		InstallmentPlan: plan,
+	}, ctx, nil
+}
+
+// normalizeAmount validates a charge amount and, if its currency cannot be
+// charged directly, converts it to the settlement currency.
+func (s *PaymentService) normalizeAmount(ctx context.Context, amount *pb.Money, userID string) (*pb.Money, error) {
+	if !IsValid(amount) || !IsPositive(amount) {
+		return nil, InvalidAmountErr{}
+	}
+	if len(s.chargeableCurrencies) == 0 || s.chargeableCurrencies[amount.GetCurrencyCode()] {
+		return amount, nil
+	}
+	if s.settlementCurrency == "" {
+		return nil, UnsupportedCurrencyErr{CurrencyCode: amount.GetCurrencyCode()}
+	}
+
+	currencyClient := pb.NewCurrencyServiceClient(s.currencySvcConn.Pick())
+	result, err := currencyClient.Convert(ctx, &pb.CurrencyConversionRequest{
+		From:   amount,
+		ToCode: s.settlementCurrency,
+		UserId: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s to %s: %v", amount.GetCurrencyCode(), s.settlementCurrency, err)
+	}
+	rpclog.Info(ctx, "charge converted", zap.String("from", amount.GetCurrencyCode()), zap.String("to", s.settlementCurrency), zap.String("rate", result.GetAppliedRate()))
+	return result.GetMoney(), nil
+}
+
+// GetTransaction returns a single ledger entry by ID
+func (s *PaymentService) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (_ *pb.Transaction, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	txn, err := s.loadTransaction(ctx, req.GetTransactionId())
+	if err == redis.Nil {
+		return nil, ctx, fmt.Errorf("transaction %q not found", req.GetTransactionId())
+	} else if err != nil {
+		rpclog.Warn(ctx, "failed to fetch transaction", zap.String("transaction_id", req.GetTransactionId()), zap.Error(err))
+		return nil, ctx, err
+	}
+	return txn, ctx, nil
+}
+
+// Refund gives a charge back in full, as when the order it paid for
+// cannot be shipped. Refunding a refunded charge does nothing.
+func (s *PaymentService) Refund(ctx context.Context, req *pb.RefundRequest) (_ *pb.Transaction, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	var previous string
+	txn, err := s.transitionTransaction(ctx, req.GetTransactionId(), func(txn *pb.Transaction) (bool, error) {
+		previous = txn.Status
+		switch previous {
+		case transactionRefunded:
+			return false, nil
+		case transactionCharged, transactionCaptured:
+		default:
+			return false, errTransitionNotAllowed
+		}
+		txn.Status = transactionRefunded
+		txn.FailureReason = req.GetReason()
+		txn.UpdatedAt = time.Now().Unix()
+		return true, nil
+	})
+	switch {
+	case err == redis.Nil:
+		return nil, ctx, fmt.Errorf("transaction %q not found", req.GetTransactionId())
+	case err == errTransitionNotAllowed:
+		return nil, ctx, fmt.Errorf("cannot refund a %s transaction", previous)
+	case err != nil:
+		rpclog.Warn(ctx, "failed to update transaction", zap.String("transaction_id", req.GetTransactionId()), zap.Error(err))
+		return nil, ctx, err
+	case previous == transactionRefunded:
+		return txn, ctx, nil
+	}
+	rpclog.Info(ctx, "transaction status changed", zap.String("transaction_id", txn.TransactionId), zap.String("from", previous), zap.String("to", txn.Status), zap.String("event", "refund"))
+
+	s.publishPaymentStatus(ctx, &pb.PaymentStatusChanged{
+		Transaction:    txn,
+		PreviousStatus: previous,
+		Event:          "refund",
+		Tenant:         tenant.FromContext(ctx),
+	})
+	return txn, ctx, nil
+}
+
+// ListTransactionsByUser returns every ledger entry for a user, oldest first
+func (s *PaymentService) ListTransactionsByUser(ctx context.Context, req *pb.ListTransactionsByUserRequest) (_ *pb.ListTransactionsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.UserID(ctx, req.GetUserId())
+
+	ids, err := s.rdb.LRange(ctx, tenant.Key(ctx, userTransactionsKey(userID)), 0, -1).Result()
+	if err != nil {
+		rpclog.Warn(ctx, "failed to list transactions", zap.String("user_id", userID), zap.Error(err))
+		return nil, ctx, err
+	}
+
+	txns := make([]*pb.Transaction, 0, len(ids))
+	for _, id := range ids {
+		txn, err := s.loadTransaction(ctx, id)
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			rpclog.Warn(ctx, "failed to fetch transaction", zap.String("transaction_id", id), zap.Error(err))
+			return nil, ctx, err
+		}
+		txns = append(txns, txn)
+	}
+	return &pb.ListTransactionsResponse{
+		Transactions: txns,
 	}, ctx, nil
 }
+
+// ListAuditEntries returns a page of the audit log of charges and
+// processor webhooks
+func (s *PaymentService) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (_ *pb.AuditEntries, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	entries, err := s.audit.List(ctx, req.GetFromSeq(), int(req.GetLimit()))
+	if err != nil {
+		rpclog.Warn(ctx, "failed to list audit entries", zap.Error(err))
+		return nil, ctx, err
+	}
+	if !entries.GetIntact() {
+		rpclog.Warn(ctx, "audit log is broken", zap.Int64("entry", entries.GetBrokenSeq()))
+	}
+	return entries, ctx, nil
+}
+
+// ExportUserData returns the ledger entries of the user, with the cards
+// they were charged to as far as they are kept.
+func (s *PaymentService) ExportUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if _, err := privacy.User(ctx); err != nil {
+		return nil, ctx, err
+	}
+	txns, _, err := s.ListTransactionsByUser(ctx, &pb.ListTransactionsByUserRequest{})
+	if err != nil {
+		return nil, ctx, err
+	}
+	rec, err := privacy.Record("transactions", len(txns.GetTransactions()), txns.GetTransactions(), true)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec}}, ctx, nil
+}
+
+// DeleteUserData anonymizes the ledger entries of the user: the ledger must
+// keep its transactions, but no longer ties them to the user or to a card.
+// The audit log, which cannot be altered, is kept as is.
+func (s *PaymentService) DeleteUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID, err := privacy.User(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+	txns, _, err := s.ListTransactionsByUser(ctx, &pb.ListTransactionsByUserRequest{})
+	if err != nil {
+		return nil, ctx, err
+	}
+	for _, txn := range txns.GetTransactions() {
+		txn.UserId, txn.CardBrand, txn.CardLastFour = "", "", ""
+		if err := s.saveTransaction(ctx, txn); err != nil {
+			rpclog.Warn(ctx, "failed to anonymize transaction", zap.String("transaction_id", txn.GetTransactionId()), zap.Error(err))
+			return nil, ctx, err
+		}
+	}
+	if err := s.rdb.Del(ctx, tenant.Key(ctx, userTransactionsKey(userID))).Err(); err != nil {
+		rpclog.Warn(ctx, "failed to delete transactions", zap.String("user_id", userID), zap.Error(err))
+		return nil, ctx, err
+	}
+	rec, err := privacy.Record("transactions", len(txns.GetTransactions()), nil, false)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec}}, ctx, nil
+}
+
+func transactionKey(id string) string {
+	return "transaction:" + id
+}
+
+func userTransactionsKey(userID string) string {
+	return "user-transactions:" + userID
+}
+
+// saveTransaction writes a ledger entry and indexes it under its user.
+func (s *PaymentService) saveTransaction(ctx context.Context, txn *pb.Transaction) error {
+	data, err := json.Marshal(txn)
+	if err != nil {
+		return err
+	}
+	if err := s.rdb.Set(ctx, tenant.Key(ctx, transactionKey(txn.TransactionId)), data, 0).Err(); err != nil {
+		return err
+	}
+	if txn.UserId == "" {
+		return nil
+	}
+	return s.rdb.RPush(ctx, tenant.Key(ctx, userTransactionsKey(txn.UserId)), txn.TransactionId).Err()
+}
+
+// loadTransaction reads a ledger entry. It returns redis.Nil if the entry does not exist.
+func (s *PaymentService) loadTransaction(ctx context.Context, id string) (*pb.Transaction, error) {
+	data, err := s.rdb.Get(ctx, tenant.Key(ctx, transactionKey(id))).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var txn pb.Transaction
+	if err := json.Unmarshal(data, &txn); err != nil {
+		return nil, err
+	}
+	return &txn, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// InstallmentsNotAvailableErr is the error of a charge in a number of
+// installments that is not offered, or for too small an amount.
+type InstallmentsNotAvailableErr struct {
+	Installments int32
+}
+
+func (e InstallmentsNotAvailableErr) Error() string {
+	return fmt.Sprintf("payment in %d %s", e.Installments, errInstallmentsNotAvailable)
+}
+
+func (e InstallmentsNotAvailableErr) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// errInstallmentsNotAvailable ends the text of InstallmentsNotAvailableErr,
+// by which IsInstallmentsNotAvailable recognizes it once it has reached a
+// client as text.
+const errInstallmentsNotAvailable = "installments is not available for this amount"
+
+// IsInstallmentsNotAvailable reports whether err is the error of PlaceOrder
+// or Charge for a number of installments that is not available.
+func IsInstallmentsNotAvailable(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errInstallmentsNotAvailable)
+}
+
+// installmentOptions are the numbers of monthly payments a shopper can
+// choose from, INSTALLMENT_OPTIONS as a comma-separated list. An empty list
+// turns installments off.
+var installmentOptions = config.NewValue(func() []int {
+	var options []int
+	for _, v := range envList("INSTALLMENT_OPTIONS", []string{"3", "6", "12"}) {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 2 {
+			log.Printf("Ignoring invalid installment option %q", v)
+			continue
+		}
+		options = append(options, n)
+	}
+	slices.Sort(options)
+	return slices.Compact(options)
+})
+
+// installmentMinUSD is the smallest total that can be paid in installments,
+// INSTALLMENT_MIN_USD dollars. Checkout enforces it, converting it to the
+// shopper's currency.
+var installmentMinUSD = config.NewValue(func() *pb.Money {
+	cents := int64(math.Round(envFloat("INSTALLMENT_MIN_USD", 100) * 100))
+	return &pb.Money{CurrencyCode: "USD", Units: cents / 100, Nanos: int32(cents%100) * 10000000}
+})
+
+// atLeast reports whether amount is at least floor, both in the same
+// currency.
+func atLeast(amount, floor *pb.Money) bool {
+	if !AreSameCurrency(amount, floor) {
+		return false
+	}
+	if amount.GetUnits() != floor.GetUnits() {
+		return amount.GetUnits() > floor.GetUnits()
+	}
+	return amount.GetNanos() >= floor.GetNanos()
+}
+
+// installmentsOffered reports whether n is one of installmentOptions.
+func installmentsOffered(n int32) bool {
+	return slices.Contains(installmentOptions.Get(), int(n))
+}
+
+// installmentPlan schedules amount in n monthly installments, the first due
+// at now.
+func installmentPlan(amount *pb.Money, n int32, now time.Time) (*pb.InstallmentPlan, error) {
+	parts, err := Divide(amount, int(n))
+	if err != nil {
+		return nil, err
+	}
+	plan := &pb.InstallmentPlan{Installments: make([]*pb.Installment, n)}
+	for i, part := range parts {
+		plan.Installments[i] = &pb.Installment{
+			Number: int32(i + 1),
+			Amount: part,
+			DueAt:  addMonths(now, i).Unix(),
+		}
+	}
+	return plan, nil
+}
+
+// addMonths returns t n months later, on the same day of the month or the
+// last day of a shorter month, rather than overflowing into the next.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/appnetorg/online-boutique-arpc/services/rpcstatus"
+)
+
+func TestCardChargeErrorKeepsPaymentCodes(t *testing.T) {
+	for _, tc := range []struct {
+		err      error
+		code     codes.Code
+		rejected bool
+	}{
+		{CardDeclinedErr{}, codes.FailedPrecondition, true},
+		{ExpiredCreditCardErr{}, codes.InvalidArgument, true},
+		{InvalidCreditCardErr{}, codes.InvalidArgument, true},
+		{UnsupportedCurrencyErr{CurrencyCode: "XXX"}, codes.InvalidArgument, false},
+		{InstallmentsNotAvailableErr{Installments: 7}, codes.FailedPrecondition, false},
+		{MoneyOpErr{Op: "divide", Left: "USD", Right: "USD", Err: ErrOutOfRange}, codes.OutOfRange, false},
+		{errors.New("connection refused"), codes.Internal, false},
+	} {
+		// The error of Charge as chargeCard wraps it.
+		err := cardChargeError(fmt.Errorf("could not charge the card: %+v", rpcstatus.Convert(tc.err)))
+		st, _ := rpcstatus.FromError(err)
+		if st.Code() != tc.code {
+			t.Errorf("%v: got code %v, want %v", tc.err, st.Code(), tc.code)
+		}
+		if got := IsCardRejected(err); got != tc.rejected {
+			t.Errorf("%v: IsCardRejected = %v, want %v", tc.err, got, tc.rejected)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/rpclog"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+)
+
+// Statuses a charge moves to as the processor reports back on it.
+const (
+	transactionCaptured      = "CAPTURED"
+	transactionCaptureFailed = "CAPTURE_FAILED"
+	transactionChargedBack   = "CHARGED_BACK"
+)
+
+// webhookTransitions gives, for each webhook the processor can send, the
+// status it moves a transaction to and the statuses it may move it from.
+var webhookTransitions = map[string]struct {
+	to   string
+	from []string
+}{
+	"capture.succeeded": {transactionCaptured, []string{transactionCharged}},
+	"capture.failed":    {transactionCaptureFailed, []string{transactionCharged}},
+	"chargeback":        {transactionChargedBack, []string{transactionCharged, transactionCaptured}},
+}
+
+// paymentWebhook is the body of a processor webhook. Tenant is echoed back
+// by the processor as it was given the charge, and is only trusted as the
+// body is signed.
+type paymentWebhook struct {
+	Type          string `json:"type"`
+	TransactionID string `json:"transaction_id"`
+	Reason        string `json:"reason"`
+	Tenant        string `json:"tenant"`
+}
+
+// maxWebhookBytes bounds the size of a webhook body.
+const maxWebhookBytes = 16 << 10
+
+// webhookSignatureHeader carries the hex HMAC-SHA256 of a webhook body,
+// keyed with PAYMENT_WEBHOOK_SECRET, the secret shared with the processor.
+const webhookSignatureHeader = "X-Webhook-Signature"
+
+// errTransitionNotAllowed is the error of a webhook or refund that does not
+// apply to the status the transaction is in.
+var errTransitionNotAllowed = errors.New("transition not allowed")
+
+// serveWebhooks serves POST /webhooks at addr, where the processor, or
+// whoever simulates it, reports on charges after the fact. Webhooks are
+// rejected as long as PAYMENT_WEBHOOK_SECRET is not set.
+func (s *PaymentService) serveWebhooks(addr string) {
+	if config.Get("PAYMENT_WEBHOOK_SECRET") == "" {
+		log.Printf("PAYMENT_WEBHOOK_SECRET is not set: payment webhooks will be rejected")
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /webhooks", s.webhookHandler)
+	go func() {
+		log.Printf("Serving payment webhooks at %s", addr)
+		log.Printf("Payment webhook server stopped: %v", http.ListenAndServe(addr, mux))
+	}()
+}
+
+// webhookHandler applies a webhook to the transaction it is about and
+// publishes the change. It answers with the updated transaction.
+func (s *PaymentService) webhookHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBytes))
+	if err != nil {
+		http.Error(w, "invalid webhook: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !validWebhookSignature(config.Get("PAYMENT_WEBHOOK_SECRET"), body, r.Header.Get(webhookSignatureHeader)) {
+		http.Error(w, "invalid webhook signature", http.StatusUnauthorized)
+		return
+	}
+	var hook paymentWebhook
+	if err := json.Unmarshal(body, &hook); err != nil {
+		http.Error(w, "invalid webhook: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	transition, ok := webhookTransitions[hook.Type]
+	if !ok {
+		http.Error(w, "unknown webhook type "+hook.Type, http.StatusBadRequest)
+		return
+	}
+
+	ctx := tenant.NewContext(r.Context(), hook.Tenant)
+	var previous string
+	var auditErr error
+	recorded := false
+	txn, err := s.transitionTransaction(ctx, hook.TransactionID, func(txn *pb.Transaction) (bool, error) {
+		previous = txn.Status
+		if previous == transition.to {
+			// Processors deliver webhooks at least once.
+			return false, nil
+		}
+		if !slices.Contains(transition.from, previous) {
+			return false, errTransitionNotAllowed
+		}
+		// The webhook is recorded before it is applied, and not applied if
+		// it cannot be. A retry after a lost race does not record it again.
+		if !recorded {
+			details, _ := json.Marshal(hook)
+			if auditErr = s.audit.Record(ctx, "Webhook", string(details)); auditErr != nil {
+				return false, auditErr
+			}
+			recorded = true
+		}
+		txn.Status = transition.to
+		txn.FailureReason = hook.Reason
+		txn.UpdatedAt = time.Now().Unix()
+		return true, nil
+	})
+	switch {
+	case err == redis.Nil:
+		http.Error(w, "unknown transaction", http.StatusNotFound)
+		return
+	case err == errTransitionNotAllowed:
+		http.Error(w, "cannot apply "+hook.Type+" to a "+previous+" transaction", http.StatusConflict)
+		return
+	case auditErr != nil:
+		rpclog.Warn(ctx, "failed to record webhook", zap.String("transaction_id", hook.TransactionID), zap.String("type", hook.Type), zap.Error(auditErr))
+		http.Error(w, "failed to record webhook", http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		rpclog.Warn(ctx, "failed to update transaction", zap.String("transaction_id", hook.TransactionID), zap.String("type", hook.Type), zap.Error(err))
+		http.Error(w, "failed to update transaction", http.StatusInternalServerError)
+		return
+	case previous == txn.Status:
+		writeJSON(w, txn)
+		return
+	}
+	rpclog.Info(ctx, "transaction status changed", zap.String("transaction_id", txn.TransactionId), zap.String("from", previous), zap.String("to", txn.Status), zap.String("event", hook.Type))
+
+	s.publishPaymentStatus(ctx, &pb.PaymentStatusChanged{
+		Transaction:    txn,
+		PreviousStatus: previous,
+		Event:          hook.Type,
+		Tenant:         hook.Tenant,
+	})
+	writeJSON(w, txn)
+}
+
+// validWebhookSignature reports whether signature is the hex HMAC-SHA256 of
+// body keyed with secret. Nothing is valid without a secret.
+func validWebhookSignature(secret string, body []byte, signature string) bool {
+	if secret == "" {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// transactionRetries bounds the attempts at a transition that lost a race
+// with another one on the same transaction.
+const transactionRetries = 5
+
+// transitionTransaction reads the ledger entry id, lets fn change it, and
+// saves it if fn returns true, unless the entry changed in between, in which
+// case it starts over. Webhooks and refunds thus cannot both apply to the
+// status they read. It returns the entry as saved or left, or redis.Nil if
+// there is none.
+func (s *PaymentService) transitionTransaction(ctx context.Context, id string, fn func(*pb.Transaction) (bool, error)) (*pb.Transaction, error) {
+	key := tenant.Key(ctx, transactionKey(id))
+	var txn *pb.Transaction
+	apply := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err != nil {
+			return err
+		}
+		txn = new(pb.Transaction)
+		if err := json.Unmarshal(data, txn); err != nil {
+			return err
+		}
+		save, err := fn(txn)
+		if err != nil || !save {
+			return err
+		}
+		if data, err = json.Marshal(txn); err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, 0)
+			return nil
+		})
+		return err
+	}
+	var err error
+	for range transactionRetries {
+		if err = s.rdb.Watch(ctx, apply, key); err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return txn, nil
+}
+
+// publishPaymentStatus publishes a status change. The ledger is already
+// updated, so failures are only logged.
+func (s *PaymentService) publishPaymentStatus(ctx context.Context, event *pb.PaymentStatusChanged) {
+	if err := s.bus.Publish(ctx, eventbus.TopicPaymentStatusChanged, event); err != nil {
+		rpclog.Warn(ctx, "failed to publish transaction status", zap.String("transaction_id", event.GetTransaction().GetTransactionId()), zap.Error(err))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/alicebob/miniredis/v2/server"
+	"github.com/redis/go-redis/v9"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/audit"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+// newTestPaymentService returns a payment service whose ledger holds a
+// CHARGED transaction with the given ID.
+func newTestPaymentService(t *testing.T, txnID string) (*PaymentService, *miniredis.Miniredis) {
+	t.Cleanup(func() { config.Reload() })
+	t.Setenv("PAYMENT_WEBHOOK_SECRET", testWebhookSecret)
+	config.Reload()
+
+	m := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: m.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	s := &PaymentService{rdb: rdb, audit: audit.New(rdb, "PaymentService"), bus: eventbus.New(m.Addr())}
+
+	data, _ := json.Marshal(&pb.Transaction{TransactionId: txnID, Status: transactionCharged})
+	m.Set(transactionKey(txnID), string(data))
+	return s, m
+}
+
+func sendWebhook(s *PaymentService, hook paymentWebhook, secret string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(hook)
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(string(body)))
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		r.Header.Set(webhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+	w := httptest.NewRecorder()
+	s.webhookHandler(w, r)
+	return w
+}
+
+func TestWebhooksMustBeSigned(t *testing.T) {
+	s, m := newTestPaymentService(t, "t1")
+	hook := paymentWebhook{Type: "capture.succeeded", TransactionID: "t1"}
+
+	for name, secret := range map[string]string{"unsigned": "", "wrong secret": "guess"} {
+		if w := sendWebhook(s, hook, secret); w.Code != http.StatusUnauthorized {
+			t.Errorf("%s webhook: got %d, want %d", name, w.Code, http.StatusUnauthorized)
+		}
+	}
+	if data, _ := m.Get(transactionKey("t1")); !strings.Contains(data, transactionCharged) {
+		t.Fatalf("rejected webhooks changed the transaction: %s", data)
+	}
+
+	if w := sendWebhook(s, hook, testWebhookSecret); w.Code != http.StatusOK {
+		t.Fatalf("signed webhook: got %d: %s", w.Code, w.Body)
+	}
+	if data, _ := m.Get(transactionKey("t1")); !strings.Contains(data, transactionCaptured) {
+		t.Errorf("signed webhook did not capture the transaction: %s", data)
+	}
+}
+
+func TestChargebackRacingRefundDoesNotReverseTwice(t *testing.T) {
+	s, m := newTestPaymentService(t, "t1")
+
+	// A refund lands between the chargeback reading the transaction and
+	// saving it.
+	var raced atomic.Bool
+	var refundErr error
+	m.Server().SetPreHook(func(c *server.Peer, cmd string, args ...string) bool {
+		if cmd == "SET" && len(args) > 0 && args[0] == transactionKey("t1") && raced.CompareAndSwap(false, true) {
+			_, _, refundErr = s.Refund(context.Background(), &pb.RefundRequest{TransactionId: "t1", Reason: "order cancelled"})
+		}
+		return false
+	})
+
+	w := sendWebhook(s, paymentWebhook{Type: "chargeback", TransactionID: "t1"}, testWebhookSecret)
+	if refundErr != nil {
+		t.Fatalf("refund: %v", refundErr)
+	}
+	if w.Code != http.StatusConflict {
+		t.Errorf("chargeback of a refunded transaction: got %d, want %d: %s", w.Code, http.StatusConflict, w.Body)
+	}
+	if data, _ := m.Get(transactionKey("t1")); !strings.Contains(data, transactionRefunded) {
+		t.Errorf("transaction is not left refunded: %s", data)
+	}
+}
@@ -0,0 +1,154 @@
+// Package pricing applies the promotions of the shop to the lines of an
+// order: bulk discounts, category-wide sales and buy-one-get-one offers.
+//
+// The rules are kept by the catalog, which checks them with Validate, and
+// applied by checkout with Apply. Every amount a rule takes off is returned
+// as an adjustment naming the rule and the line, so that the discount on an
+// order can be explained to the shopper and reconciled.
+package pricing
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// Kinds of rule.
+const (
+	KindBulk         = "BULK"
+	KindCategorySale = "CATEGORY_SALE"
+	KindBOGO         = "BOGO"
+)
+
+const (
+	nanosMod = 1000000000
+	// centNanos is a hundredth of a unit, the smallest amount taken off.
+	centNanos = nanosMod / 100
+)
+
+// Line is a line of an order, priced in the order's currency.
+type Line struct {
+	ProductID  string
+	Categories []string
+	Quantity   int32
+	Unit       *pb.Money
+}
+
+// Validate returns what is wrong with rules, if anything.
+func Validate(rules []*pb.PricingRule) []string {
+	var msgs []string
+	seen := make(map[string]bool, len(rules))
+	for i, r := range rules {
+		problem := func(format string, args ...any) {
+			msgs = append(msgs, fmt.Sprintf("rule %d (%q): ", i, r.GetId())+fmt.Sprintf(format, args...))
+		}
+		if r.GetId() == "" {
+			problem("id is required")
+		} else if seen[r.GetId()] {
+			problem("duplicate id")
+		}
+		seen[r.GetId()] = true
+
+		switch r.GetKind() {
+		case KindBulk:
+			if r.GetMinQuantity() < 2 {
+				problem("min_quantity must be at least 2")
+			}
+		case KindCategorySale:
+			if r.GetCategory() == "" {
+				problem("category is required")
+			}
+		case KindBOGO:
+		default:
+			problem("unknown kind %q", r.GetKind())
+		}
+		if r.GetKind() != KindBOGO && (r.GetPercentOff() < 1 || r.GetPercentOff() > 100) {
+			problem("percent_off must be between 1 and 100")
+		}
+		if r.GetStart() != 0 && r.GetEnd() != 0 && r.GetEnd() <= r.GetStart() {
+			problem("end is not after start")
+		}
+	}
+	return msgs
+}
+
+// active reports whether r is in effect at now.
+func active(r *pb.PricingRule, now time.Time) bool {
+	return (r.GetStart() == 0 || now.Unix() >= r.GetStart()) &&
+		(r.GetEnd() == 0 || now.Unix() < r.GetEnd())
+}
+
+// applies reports whether r covers the product of l.
+func applies(r *pb.PricingRule, l Line) bool {
+	switch {
+	case len(r.GetProductIds()) > 0:
+		return slices.Contains(r.GetProductIds(), l.ProductID)
+	case r.GetCategory() != "":
+		return slices.Contains(l.Categories, r.GetCategory())
+	}
+	return true
+}
+
+// Apply returns the adjustments rules make to lines at now. Rules apply in
+// order, each to what those before it left of a line, so that no line is
+// taken below zero. Amounts are rounded down to the cent.
+func Apply(rules []*pb.PricingRule, lines []Line, now time.Time) []*pb.PriceAdjustment {
+	left := make([]int64, len(lines))
+	for i, l := range lines {
+		left[i] = toNanos(l.Unit) * int64(l.Quantity)
+	}
+	var adjs []*pb.PriceAdjustment
+	for _, r := range rules {
+		if !active(r, now) {
+			continue
+		}
+		for i, l := range lines {
+			if l.Quantity <= 0 || !applies(r, l) {
+				continue
+			}
+			var off int64
+			switch r.GetKind() {
+			case KindBulk:
+				if l.Quantity >= r.GetMinQuantity() {
+					off = left[i] * int64(r.GetPercentOff()) / 100
+				}
+			case KindCategorySale:
+				off = left[i] * int64(r.GetPercentOff()) / 100
+			case KindBOGO:
+				off = left[i] * int64(l.Quantity/2) / int64(l.Quantity)
+			}
+			off -= off % centNanos
+			if off <= 0 {
+				continue
+			}
+			left[i] -= off
+			adjs = append(adjs, &pb.PriceAdjustment{
+				RuleId:      r.GetId(),
+				Kind:        r.GetKind(),
+				Description: r.GetDescription(),
+				ProductId:   l.ProductID,
+				Amount:      fromNanos(l.Unit.GetCurrencyCode(), off),
+			})
+		}
+	}
+	return adjs
+}
+
+// Total returns the sum of adjs, in currency.
+func Total(currency string, adjs []*pb.PriceAdjustment) *pb.Money {
+	var n int64
+	for _, a := range adjs {
+		n += toNanos(a.GetAmount())
+	}
+	return fromNanos(currency, n)
+}
+
+func toNanos(m *pb.Money) int64 {
+	return m.GetUnits()*nanosMod + int64(m.GetNanos())
+}
+
+func fromNanos(currency string, n int64) *pb.Money {
+	return &pb.Money{CurrencyCode: currency, Units: n / nanosMod, Nanos: int32(n % nanosMod)}
+}
@@ -0,0 +1,127 @@
+// Package privacy exports and erases the data the services keep about a
+// shopper, on the shopper's request.
+//
+// Each service that keeps data about shoppers serves PrivacyService next to
+// its own service. A Coordinator asks all of them about the user of the
+// call: first those that keep data by user, which report the email
+// addresses they know the shopper by, and then those that keep data by
+// address, with those addresses. The addresses are never taken from the
+// shopper, so that no one can reach the data of another address.
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+// Participant is a service that keeps data about shoppers.
+type Participant struct {
+	// Name names the service in reports.
+	Name string
+	// Pick returns a client of the service.
+	Pick func() *rpc.Client
+	// ByEmail marks a service that keeps data by email address rather than
+	// by user.
+	ByEmail bool
+}
+
+// Report is what a participant returned, or the error it failed with.
+type Report struct {
+	Service string
+	Records []*pb.UserDataRecord
+	Err     error
+}
+
+// Coordinator asks the participants for, or to erase, the data of a
+// shopper.
+type Coordinator struct {
+	participants []Participant
+}
+
+// NewCoordinator returns a coordinator of participants.
+func NewCoordinator(participants ...Participant) *Coordinator {
+	return &Coordinator{participants: participants}
+}
+
+// Export gathers the data kept about the user of ctx, reporting for each
+// participant in order.
+func (c *Coordinator) Export(ctx context.Context) []Report {
+	return c.run(ctx, pb.PrivacyServiceClient.ExportUserData)
+}
+
+// Delete erases the data kept about the user of ctx, reporting what each
+// participant erased. A participant that failed keeps its data, and
+// without the addresses it would have reported, so may those keeping data
+// by address; deleting again erases what remains.
+func (c *Coordinator) Delete(ctx context.Context) []Report {
+	return c.run(ctx, pb.PrivacyServiceClient.DeleteUserData)
+}
+
+type call func(pb.PrivacyServiceClient, context.Context, *pb.UserDataRequest) (*pb.UserData, error)
+
+// run makes call to the participants keeping data by user, concurrently,
+// and then to those keeping it by address.
+func (c *Coordinator) run(ctx context.Context, call call) []Report {
+	reports := make([]Report, len(c.participants))
+	var emails []string
+	for _, byEmail := range []bool{false, true} {
+		var (
+			wg    sync.WaitGroup
+			mu    sync.Mutex
+			found []string
+		)
+		req := &pb.UserDataRequest{Emails: emails}
+		for i, p := range c.participants {
+			if p.ByEmail != byEmail {
+				continue
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				data, err := call(pb.NewPrivacyServiceClient(p.Pick()), ctx, req)
+				reports[i] = Report{Service: p.Name, Records: data.GetRecords(), Err: err}
+				mu.Lock()
+				found = append(found, data.GetEmails().GetAddresses()...)
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+		emails = append(emails, found...)
+		slices.Sort(emails)
+		emails = slices.Compact(emails)
+	}
+	return reports
+}
+
+// User returns the user of a PrivacyService call. Unlike other calls, those
+// without a user are refused rather than taken for an anonymous one.
+func User(ctx context.Context) (string, error) {
+	userID := usercontext.FromContext(ctx)
+	if userID == "" {
+		return "", status.Error(codes.InvalidArgument, "the user of the call is required")
+	}
+	return userID, nil
+}
+
+// Record returns the record of count entries of kind, with the entries
+// encoded as JSON if withData is set, as for ExportUserData.
+func Record(kind string, count int, entries any, withData bool) (*pb.UserDataRecord, error) {
+	rec := &pb.UserDataRecord{Kind: kind, Count: int64(count)}
+	if withData {
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		rec.Json = string(data)
+	}
+	return rec, nil
+}
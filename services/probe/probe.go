@@ -0,0 +1,263 @@
+// Package probe implements the probe command, a synthetic monitor that goes
+// through a purchase on a live storefront: it opens the home page, adds the
+// first product to the cart and checks out with the form's default details.
+//
+// Each run's outcome and latency are exposed in the Prometheus text format
+// on -metrics-addr and, for short-lived runs such as a Kubernetes CronJob,
+// pushed to a Pushgateway with -pushgateway. The command exits with status 1
+// once -max-failures runs in a row have failed, and with status 0 after -runs
+// runs otherwise.
+package probe
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+var (
+	productLinkRe   = regexp.MustCompile(`/product/([A-Za-z0-9_-]+)`)
+	checkoutNonceRe = regexp.MustCompile(`name="checkout_nonce" value="([^"]*)"`)
+	termsVersionRe  = regexp.MustCompile(`name="terms_version" value="([^"]*)"`)
+	receiptLinkRe   = regexp.MustCompile(`/orders/([A-Za-z0-9-]+)/receipt`)
+)
+
+// checkoutForm holds the checkout details submitted by the probe, the
+// defaults of the storefront's form.
+var checkoutForm = url.Values{
+	"email":                        {"probe@example.com"},
+	"street_address":               {"1600 Amphitheatre Parkway"},
+	"zip_code":                     {"94043"},
+	"city":                         {"Mountain View"},
+	"state":                        {"CA"},
+	"country":                      {"United States"},
+	"credit_card_number":           {"4432801561520454"},
+	"credit_card_expiration_month": {"1"},
+	"credit_card_cvv":              {"672"},
+	"accept_terms":                 {"true"},
+	"confirm_age":                  {"true"},
+}
+
+// Main runs the probe command with the given arguments and returns the exit
+// status.
+func Main(args []string) int {
+	fs := flag.NewFlagSet("probe", flag.ExitOnError)
+	var (
+		target      = fs.String("target", defaultString("PROBE_TARGET", "http://frontend:80"), "storefront base URL")
+		interval    = fs.Duration("interval", 30*time.Second, "time between runs")
+		timeout     = fs.Duration("timeout", 20*time.Second, "time limit of a run")
+		runs        = fs.Int("runs", 0, "number of runs, 0 for no limit")
+		maxFailures = fs.Int("max-failures", 3, "consecutive failed runs after which the probe exits with status 1")
+		metricsAddr = fs.String("metrics-addr", "", "address to serve /metrics on, empty to disable")
+		pushgateway = fs.String("pushgateway", config.Get("PROBE_PUSHGATEWAY"), "Pushgateway URL to push metrics to after every run, empty to disable")
+	)
+	fs.Parse(args)
+
+	m := &metrics{}
+	if *metricsAddr != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", m)
+		go func() {
+			log.Printf("probe: serving metrics at %s", *metricsAddr)
+			log.Printf("probe: metrics server stopped: %v", http.ListenAndServe(*metricsAddr, mux))
+		}()
+	}
+
+	failures := 0
+	for i := 0; *runs == 0 || i < *runs; i++ {
+		if i > 0 {
+			time.Sleep(*interval)
+		}
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		orderID, err := purchase(ctx, *target)
+		cancel()
+		elapsed := time.Since(start)
+
+		m.record(err == nil, elapsed)
+		if err != nil {
+			failures++
+			log.Printf("probe: run failed after %v (%d in a row): %v", elapsed, failures, err)
+		} else {
+			failures = 0
+			log.Printf("probe: placed order %s in %v", orderID, elapsed)
+		}
+		if *pushgateway != "" {
+			if err := m.push(*pushgateway); err != nil {
+				log.Printf("probe: failed to push metrics: %v", err)
+			}
+		}
+		if failures >= *maxFailures {
+			log.Printf("probe: giving up after %d failed runs in a row", failures)
+			return 1
+		}
+	}
+	return 0
+}
+
+func defaultString(key, def string) string {
+	if v := config.Get(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// purchase goes through a purchase on the storefront at base as a new
+// shopper and returns the ID of the order placed.
+func purchase(ctx context.Context, base string) (string, error) {
+	jar, _ := cookiejar.New(nil)
+	c := &client{base: strings.TrimSuffix(base, "/"), http: &http.Client{Jar: jar}}
+
+	home, err := c.do(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		return "", err
+	}
+	m := productLinkRe.FindStringSubmatch(home)
+	if m == nil {
+		return "", fmt.Errorf("no product on the home page")
+	}
+	if _, err := c.do(ctx, http.MethodGet, "/product/"+m[1], nil); err != nil {
+		return "", err
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/cart", url.Values{"product_id": {m[1]}, "quantity": {"1"}}); err != nil {
+		return "", err
+	}
+	cart, err := c.do(ctx, http.MethodGet, "/cart", nil)
+	if err != nil {
+		return "", err
+	}
+	n := checkoutNonceRe.FindStringSubmatch(cart)
+	if n == nil {
+		return "", fmt.Errorf("no checkout form on the cart page")
+	}
+
+	form := url.Values{"checkout_nonce": {n[1]}, "credit_card_expiration_year": {fmt.Sprint(time.Now().Year() + 1)}}
+	if v := termsVersionRe.FindStringSubmatch(cart); v != nil {
+		form.Set("terms_version", v[1])
+	}
+	for k, v := range checkoutForm {
+		form[k] = v
+	}
+	order, err := c.do(ctx, http.MethodPost, "/cart/checkout", form)
+	if err != nil {
+		return "", err
+	}
+	o := receiptLinkRe.FindStringSubmatch(order)
+	if o == nil {
+		return "", fmt.Errorf("no order on the confirmation page")
+	}
+	return o[1], nil
+}
+
+// client makes the requests of one probe run, keeping its session cookie.
+type client struct {
+	base string
+	http *http.Client
+}
+
+// do requests path, with form as the body if not nil, and returns the
+// response body. Statuses other than 200 are errors.
+func (c *client) do(ctx context.Context, method, path string, form url.Values) (string, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.base+path, body)
+	if err != nil {
+		return "", err
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s %s: %s", method, path, resp.Status)
+	}
+	return string(data), nil
+}
+
+// metrics are the probe's results, written in the Prometheus text format.
+type metrics struct {
+	mu          sync.Mutex
+	successes   int
+	failures    int
+	lastSuccess time.Time
+	lastOK      bool
+	lastLatency time.Duration
+}
+
+func (m *metrics) record(ok bool, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if ok {
+		m.successes++
+		m.lastSuccess = time.Now()
+	} else {
+		m.failures++
+	}
+	m.lastOK = ok
+	m.lastLatency = latency
+}
+
+func (m *metrics) write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	up := 0
+	if m.lastOK {
+		up = 1
+	}
+	var lastSuccess int64
+	if !m.lastSuccess.IsZero() {
+		lastSuccess = m.lastSuccess.Unix()
+	}
+	fmt.Fprintf(w, "# HELP probe_runs_total Purchase probe runs by result.\n# TYPE probe_runs_total counter\n")
+	fmt.Fprintf(w, "probe_runs_total{result=\"success\"} %d\nprobe_runs_total{result=\"failure\"} %d\n", m.successes, m.failures)
+	fmt.Fprintf(w, "# HELP probe_success Whether the last purchase probe run succeeded.\n# TYPE probe_success gauge\nprobe_success %d\n", up)
+	fmt.Fprintf(w, "# HELP probe_duration_seconds Duration of the last purchase probe run.\n# TYPE probe_duration_seconds gauge\nprobe_duration_seconds %g\n", m.lastLatency.Seconds())
+	fmt.Fprintf(w, "# HELP probe_last_success_timestamp_seconds Time of the last successful purchase probe run.\n# TYPE probe_last_success_timestamp_seconds gauge\nprobe_last_success_timestamp_seconds %d\n", lastSuccess)
+}
+
+func (m *metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.write(w)
+}
+
+// push replaces the probe's metrics on the Pushgateway at gateway.
+func (m *metrics) push(gateway string) error {
+	var buf bytes.Buffer
+	m.write(&buf)
+	req, err := http.NewRequest(http.MethodPut, strings.TrimSuffix(gateway, "/")+"/metrics/job/onlineboutique-probe", &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("pushgateway: %s", resp.Status)
+	}
+	return nil
+}
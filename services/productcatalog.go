@@ -6,22 +6,28 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
 // ProductCatalogService implements the ProductCatalogService
@@ -29,23 +35,107 @@ type ProductCatalogService struct {
 	port    int
 	catalog pb.ListProductsResponse
 
+	extraLatency *config.Value[time.Duration]
+
 	mu            sync.RWMutex
-	extraLatency  time.Duration
 	reloadCatalog bool
+
+	// searchIdx indexes the catalog for SearchProducts, and views counts
+	// product page views to rank its results.
+	searchIdx atomic.Pointer[searchIndex]
+	views     productViews
+
+	importsMu sync.Mutex
+	imports   map[string]*pendingImport
+
+	// variants maps product IDs to their SKUs; products without an entry
+	// are sold as is. Restocks replace variants rather than modifying them.
+	variantsMu sync.RWMutex
+	variants   map[string][]*pb.ProductVariant
+	// backorders holds the emails, with their locales, waiting for a sold
+	// out variant to come back.
+	backorders map[backorderKey]map[string]string
+	// reservations holds the stock taken off sale for orders being placed,
+	// by order ID.
+	reservations map[string]*stockReservation
+
+	// pricingRules are the promotions checkout applies to orders, in the
+	// order they apply.
+	pricingMu    sync.RWMutex
+	pricingRules []*pb.PricingRule
+
+	// schedule holds the price changes to apply to the catalog in their
+	// window, and appliedChanges the sales each applied change replaced, by
+	// product ID. It is locked before mu.
+	scheduleMu     sync.Mutex
+	schedule       []*pb.PriceChange
+	appliedChanges map[string]map[string]savedSale
+
+	eventBusAddr string
+	bus          *eventbus.Bus
+}
+
+// backorderKey identifies what a subscriber waits for. An empty variantID
+// stands for any variant of the product.
+type backorderKey struct {
+	productID string
+	variantID string
+}
+
+// Catalog import modes.
+const (
+	importModeReplace = "REPLACE"
+	importModeMerge   = "MERGE"
+)
+
+// Catalog import statuses.
+const (
+	importStatusStaged    = "STAGED"
+	importStatusValidated = "VALIDATED"
+	importStatusApplied   = "APPLIED"
+	importStatusRejected  = "REJECTED"
+)
+
+const (
+	// maxImportChunks bounds the chunks of a single import.
+	maxImportChunks = 10000
+	// importTTL is how long an incomplete import is kept.
+	importTTL = 10 * time.Minute
+)
+
+// inventoryTracking is whether GetStock reports the stock of products,
+// INVENTORY_TRACKING, on unless "false". Variants still cannot be sold past
+// their stock when it is off: only the badges of the storefront go.
+var inventoryTracking = config.NewValue(func() bool {
+	return strings.ToLower(config.Get("INVENTORY_TRACKING")) != "false"
+})
+
+// pendingImport collects the chunks of an import until all have arrived.
+type pendingImport struct {
+	mode       string
+	dryRun     bool
+	chunkCount int32
+	chunks     map[int32][]*pb.Product
+	updated    time.Time
 }
 
 // NewProductCatalogService creates a new ProductCatalogService
 func NewProductCatalogService(port int) *ProductCatalogService {
 	svc := &ProductCatalogService{
-		port: port,
+		port:           port,
+		imports:        make(map[string]*pendingImport),
+		backorders:     make(map[backorderKey]map[string]string),
+		reservations:   make(map[string]*stockReservation),
+		appliedChanges: make(map[string]map[string]savedSale),
 	}
 
 	// Initialize extra latency from environment variable
-	if extra := os.Getenv("EXTRA_LATENCY"); extra != "" {
-		if duration, err := time.ParseDuration(extra); err == nil {
-			svc.extraLatency = duration
+	svc.extraLatency = config.NewValue(func() time.Duration {
+		if duration, err := time.ParseDuration(config.Get("EXTRA_LATENCY")); err == nil {
+			return duration
 		}
-	}
+		return 0
+	})
 
 	// Signal handling for reloading
 	sigs := make(chan os.Signal, 1)
@@ -72,10 +162,64 @@ func NewProductCatalogService(port int) *ProductCatalogService {
 	if err := svc.loadCatalog(&svc.catalog); err != nil {
 		log.Fatalf("Failed to load catalog: %v", err)
 	}
+	variants, err := loadVariants("data/variants.json")
+	if err != nil {
+		log.Fatalf("Failed to load variants: %v", err)
+	}
+	svc.variants = variants
+	if svc.pricingRules, err = loadPricingRules("data/pricing_rules.json"); err != nil {
+		log.Fatalf("Failed to load pricing rules: %v", err)
+	}
+	if svc.schedule, err = loadPriceSchedule("data/price_schedule.json"); err != nil {
+		log.Fatalf("Failed to load price schedule: %v", err)
+	}
+	config.OnReload(svc.reloadData)
 
 	return svc
 }
 
+// reloadData re-reads the catalog, variants, pricing rules and price
+// schedule after a configuration reload. Stock levels are reset to the
+// file's values, and products imported and rules set since startup are
+// replaced. Reservations are dropped with the stock they held, which the
+// file's values do not account for.
+func (s *ProductCatalogService) reloadData() {
+	if err := s.loadCatalog(&s.catalog); err != nil {
+		log.Printf("Failed to reload catalog, keeping current one: %v", err)
+	} else {
+		s.publishCatalogUpdated(nil, "catalog reloaded")
+	}
+	changes, err := loadPriceSchedule("data/price_schedule.json")
+	if err != nil {
+		log.Printf("Failed to reload price schedule, keeping current one: %v", err)
+		s.scheduleMu.Lock()
+		changes = s.schedule
+		s.scheduleMu.Unlock()
+	}
+	s.setPriceSchedule(changes)
+	if rules, err := loadPricingRules("data/pricing_rules.json"); err != nil {
+		log.Printf("Failed to reload pricing rules, keeping current ones: %v", err)
+	} else {
+		s.pricingMu.Lock()
+		s.pricingRules = rules
+		s.pricingMu.Unlock()
+	}
+	variants, err := loadVariants("data/variants.json")
+	if err != nil {
+		log.Printf("Failed to reload variants, keeping current ones: %v", err)
+		return
+	}
+	s.variantsMu.Lock()
+	s.variants = variants
+	dropped := len(s.reservations)
+	s.reservations = make(map[string]*stockReservation)
+	s.variantsMu.Unlock()
+	if dropped > 0 {
+		log.Printf("Dropped %d stock reservations with the reloaded variants", dropped)
+		reservationStats.Add("held", -int64(dropped))
+	}
+}
+
 // loadCatalog loads the product catalog from a file.
 func (s *ProductCatalogService) loadCatalog(catalog *pb.ListProductsResponse) error {
 	s.mu.Lock()
@@ -91,20 +235,99 @@ func (s *ProductCatalogService) loadCatalog(catalog *pb.ListProductsResponse) er
 	if err := protojson.Unmarshal(catalogJSON, catalog); err != nil {
 		return err
 	}
+	for _, p := range catalog.Products {
+		addImageVariants(p)
+	}
+	s.rebuildSearchIndex(catalog.Products)
 
 	return nil
 }
 
-// parseCatalog parses the current catalog state
+// loadVariants reads product variants from a file, grouped by product ID. A
+// missing file means no product has variants.
+func loadVariants(name string) (map[string][]*pb.ProductVariant, error) {
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return map[string][]*pb.ProductVariant{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list pb.ListVariantsResponse
+	if err := protojson.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	variants := make(map[string][]*pb.ProductVariant)
+	for _, v := range list.Variants {
+		variants[v.ProductId] = append(variants[v.ProductId], v)
+	}
+	return variants, nil
+}
+
+// addImageVariants fills in the thumbnail and medium images of p from its
+// picture, unless they are already set. Only pictures under /static/img/ can
+// be resized by the frontend.
+func addImageVariants(p *pb.Product) {
+	rel, ok := strings.CutPrefix(p.GetPicture(), "/static/img/")
+	if !ok {
+		return
+	}
+	variant := func(width int) *pb.ProductImage {
+		return &pb.ProductImage{Url: fmt.Sprintf("/images/%s?w=%d", rel, width), Width: int32(width)}
+	}
+	if p.Thumbnail == nil {
+		p.Thumbnail = variant(thumbnailWidth)
+	}
+	if p.Medium == nil {
+		p.Medium = variant(mediumWidth)
+	}
+}
+
+// parseCatalog parses the current catalog state. The returned slice is never
+// modified in place, so callers may use it without holding the lock.
 func (s *ProductCatalogService) parseCatalog() []*pb.Product {
-	if s.reloadCatalog || len(s.catalog.Products) == 0 {
+	s.mu.RLock()
+	reload := s.reloadCatalog || len(s.catalog.Products) == 0
+	products := s.catalog.Products
+	s.mu.RUnlock()
+
+	if reload {
 		err := s.loadCatalog(&s.catalog)
 		if err != nil {
 			return []*pb.Product{}
 		}
+		s.mu.RLock()
+		products = s.catalog.Products
+		s.mu.RUnlock()
 	}
 
-	return s.catalog.Products
+	return products
+}
+
+// tenantCatalog returns the products the caller's tenant sells.
+func (s *ProductCatalogService) tenantCatalog(ctx context.Context) []*pb.Product {
+	products := s.parseCatalog()
+	t := tenant.Get(tenant.FromContext(ctx))
+	if t == nil || len(t.Products) == 0 {
+		return products
+	}
+	var sold []*pb.Product
+	for _, p := range products {
+		if t.HasProduct(p.Id) {
+			sold = append(sold, p)
+		}
+	}
+	return sold
+}
+
+// tenantSells returns a function reporting whether the caller's tenant
+// sells a product.
+func tenantSells(ctx context.Context) func(*pb.Product) bool {
+	t := tenant.Get(tenant.FromContext(ctx))
+	return func(p *pb.Product) bool {
+		return t == nil || len(t.Products) == 0 || t.HasProduct(p.Id)
+	}
 }
 
 // Run starts the ARPC server
@@ -114,13 +337,26 @@ func (s *ProductCatalogService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	serializer := &serializer.SymphonySerializer{}
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
+	go s.sweepReservations(envDuration("STOCK_RESERVATION_SWEEP_INTERVAL", 30*time.Second))
+	go s.schedulePrices(envDuration("PRICE_SCHEDULE_INTERVAL", 15*time.Second))
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(
+		newLoadShedElement(),
+		tracing.NewServerTracingElement(),
+		recovery.NewServerRecoveryElement(),
+		usercontext.NewServerElement(),
+	)
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
 	}
 
+	statusMux.HandleFunc("/admin/search-index", s.serveRebuildSearchIndex)
+	mustCheckStartup(newStartupChecker())
+
 	pb.RegisterProductCatalogServiceServer(server, s)
 	log.Printf("ProductCatalogService running at port: %d", s.port)
 	server.Start()
@@ -128,13 +364,15 @@ func (s *ProductCatalogService) Run() error {
 }
 
 // ListProducts lists all available products
-func (s *ProductCatalogService) ListProducts(ctx context.Context, req *pb.EmptyUser) (*pb.ListProductsResponse, context.Context, error) {
+func (s *ProductCatalogService) ListProducts(ctx context.Context, req *pb.EmptyUser) (_ *pb.ListProductsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
 	log.Println("ListProducts: Received request")
 
-	time.Sleep(s.extraLatency)
+	time.Sleep(s.extraLatency.Get())
 
 	response := &pb.ListProductsResponse{
-		Products: s.parseCatalog(),
+		Products: s.tenantCatalog(ctx),
 	}
 
 	log.Printf("ListProducts: Responding with %d products\n", len(response.Products))
@@ -143,15 +381,15 @@ func (s *ProductCatalogService) ListProducts(ctx context.Context, req *pb.EmptyU
 }
 
 // GetProduct retrieves a product by its ID
-func (s *ProductCatalogService) GetProduct(ctx context.Context, req *pb.GetProductRequest) (*pb.Product, context.Context, error) {
-	log.Printf("GetProduct: Received request for product ID %s\n", req.Id)
+func (s *ProductCatalogService) GetProduct(ctx context.Context, req *pb.GetProductRequest) (_ *pb.Product, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
-	time.Sleep(s.extraLatency)
+	time.Sleep(s.extraLatency.Get())
 
 	var found *pb.Product
-	for i := 0; i < len(s.parseCatalog()); i++ {
-		if req.Id == s.parseCatalog()[i].Id {
-			found = s.parseCatalog()[i]
+	for _, p := range s.tenantCatalog(ctx) {
+		if req.Id == p.Id {
+			found = p
 			break
 		}
 	}
@@ -161,25 +399,422 @@ func (s *ProductCatalogService) GetProduct(ctx context.Context, req *pb.GetProdu
 		return nil, ctx, status.Errorf(codes.NotFound, "no product with ID %s", req.Id)
 	}
 
+	s.views.add(found.Id)
 	log.Printf("GetProduct: Found product with ID %s\n", found.Id)
 	return found, ctx, nil
 }
 
-// SearchProducts searches for products matching a query
-func (s *ProductCatalogService) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, context.Context, error) {
-	log.Printf("SearchProducts: Received request with query: %s\n", req.Query)
+// GetProducts retrieves several products by ID in a single call
+func (s *ProductCatalogService) GetProducts(ctx context.Context, req *pb.GetProductsRequest) (_ *pb.ListProductsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
-	time.Sleep(s.extraLatency)
+	time.Sleep(s.extraLatency.Get())
 
-	var ps []*pb.Product
-	for _, product := range s.parseCatalog() {
-		if strings.Contains(strings.ToLower(product.Name), strings.ToLower(req.Query)) ||
-			strings.Contains(strings.ToLower(product.Description), strings.ToLower(req.Query)) {
-			ps = append(ps, product)
+	catalog := s.tenantCatalog(ctx)
+	byID := make(map[string]*pb.Product, len(catalog))
+	for _, p := range catalog {
+		byID[p.Id] = p
+	}
+
+	products := make([]*pb.Product, len(req.Ids))
+	for i, id := range req.Ids {
+		p, ok := byID[id]
+		if !ok {
+			log.Printf("GetProducts: Product with ID %s not found\n", id)
+			return nil, ctx, status.Errorf(codes.NotFound, "no product with ID %s", id)
 		}
+		products[i] = p
+	}
+
+	log.Printf("GetProducts: Found %d products\n", len(products))
+	return &pb.ListProductsResponse{Products: products}, ctx, nil
+}
+
+// SearchProducts searches for products matching every word of a query, with
+// some tolerance for typos, best matches first
+func (s *ProductCatalogService) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (_ *pb.SearchProductsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	time.Sleep(s.extraLatency.Get())
+
+	// Loading the catalog builds the index.
+	s.parseCatalog()
+	idx := s.searchIdx.Load()
+	if idx == nil {
+		return nil, ctx, status.Errorf(codes.Unavailable, "catalog not loaded")
 	}
+	ps := idx.search(req.Query, s.views.get, tenantSells(ctx))
+	ps, facets := filterResults(ps, req.Category, req.PriceRange, time.Now())
 
 	log.Printf("SearchProducts: Search completed. Query: %s, Results: %d\n", req.Query, len(ps))
 
-	return &pb.SearchProductsResponse{Results: ps}, ctx, nil
+	return &pb.SearchProductsResponse{Results: ps, Facets: facets}, ctx, nil
+}
+
+// SuggestProducts completes a search query from the names of the products,
+// the most popular first
+func (s *ProductCatalogService) SuggestProducts(ctx context.Context, req *pb.SuggestProductsRequest) (_ *pb.SuggestProductsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultSuggestions
+	}
+	limit = min(limit, maxSuggestions)
+
+	s.parseCatalog()
+	idx := s.searchIdx.Load()
+	if idx == nil {
+		return nil, ctx, status.Errorf(codes.Unavailable, "catalog not loaded")
+	}
+	resp := &pb.SuggestProductsResponse{}
+	for _, p := range idx.suggest(req.Prefix, limit, s.views.get, tenantSells(ctx)) {
+		resp.Suggestions = append(resp.Suggestions, &pb.ProductSuggestion{Id: p.Id, Name: p.Name})
+	}
+	return resp, ctx, nil
+}
+
+// ImportProducts stages a chunk of a catalog import and, once every chunk has
+// arrived, validates the import and applies it unless it is a dry run.
+// Imported products live in memory only; enabling catalog reload (SIGUSR1)
+// goes back to data/products.json.
+func (s *ProductCatalogService) ImportProducts(ctx context.Context, req *pb.ImportProductsRequest) (_ *pb.ImportProductsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	log.Printf("ImportProducts: Received chunk %d/%d of import %s with %d products\n",
+		req.ChunkIndex+1, req.ChunkCount, req.ImportId, len(req.Products))
+
+	mode := strings.ToUpper(req.Mode)
+	if mode == "" {
+		mode = importModeMerge
+	}
+	switch {
+	case req.ImportId == "":
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "import_id is required")
+	case mode != importModeReplace && mode != importModeMerge:
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "unknown import mode %q", req.Mode)
+	case req.ChunkCount < 1 || req.ChunkCount > maxImportChunks:
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "chunk_count must be between 1 and %d", maxImportChunks)
+	case req.ChunkIndex < 0 || req.ChunkIndex >= req.ChunkCount:
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "chunk_index %d out of range", req.ChunkIndex)
+	}
+
+	s.importsMu.Lock()
+	now := time.Now()
+	for id, imp := range s.imports {
+		if now.Sub(imp.updated) > importTTL {
+			log.Printf("ImportProducts: Dropping incomplete import %s\n", id)
+			delete(s.imports, id)
+		}
+	}
+	imp, ok := s.imports[req.ImportId]
+	if !ok {
+		imp = &pendingImport{
+			mode:       mode,
+			dryRun:     req.DryRun,
+			chunkCount: req.ChunkCount,
+			chunks:     make(map[int32][]*pb.Product),
+		}
+		s.imports[req.ImportId] = imp
+	}
+	if imp.mode != mode || imp.dryRun != req.DryRun || imp.chunkCount != req.ChunkCount {
+		s.importsMu.Unlock()
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "chunk does not match mode, dry_run or chunk_count of import %s", req.ImportId)
+	}
+	imp.chunks[req.ChunkIndex] = req.Products
+	imp.updated = now
+	received := int32(len(imp.chunks))
+	if received < imp.chunkCount {
+		s.importsMu.Unlock()
+		return &pb.ImportProductsResponse{
+			ImportId:       req.ImportId,
+			ChunksReceived: received,
+			Status:         importStatusStaged,
+		}, ctx, nil
+	}
+	delete(s.imports, req.ImportId)
+	s.importsMu.Unlock()
+
+	var products []*pb.Product
+	for i := int32(0); i < imp.chunkCount; i++ {
+		products = append(products, imp.chunks[i]...)
+	}
+
+	// Hold the lock from building the result to storing it, so a concurrent
+	// merge is not lost.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	catalog, problems := buildCatalog(s.catalog.Products, products, imp.mode)
+
+	resp := &pb.ImportProductsResponse{
+		ImportId:       req.ImportId,
+		ChunksReceived: received,
+		ProductCount:   int32(len(catalog)),
+		Problems:       problems,
+	}
+	switch {
+	case len(problems) > 0:
+		resp.Status = importStatusRejected
+	case imp.dryRun:
+		resp.Status = importStatusValidated
+	default:
+		s.catalog.Products = catalog
+		s.rebuildSearchIndex(catalog)
+		resp.Status = importStatusApplied
+
+		// A replace may have dropped any product, a merge only touched
+		// those imported. The event goes out without holding up the lock.
+		var changed []string
+		if imp.mode == importModeMerge {
+			for _, p := range products {
+				changed = append(changed, p.Id)
+			}
+		}
+		go s.publishCatalogUpdated(changed, "import "+req.ImportId+" applied")
+	}
+	log.Printf("ImportProducts: Import %s %s with %d problems, catalog has %d products\n",
+		req.ImportId, resp.Status, len(problems), resp.ProductCount)
+	return resp, ctx, nil
+}
+
+// buildCatalog returns the catalog that results from importing products into
+// current with mode, along with any problems that prevent the import.
+func buildCatalog(current, products []*pb.Product, mode string) ([]*pb.Product, []*pb.ImportProblem) {
+	var problems []*pb.ImportProblem
+	seen := make(map[string]bool, len(products))
+	for _, p := range products {
+		for _, msg := range validateProduct(p) {
+			problems = append(problems, &pb.ImportProblem{ProductId: p.GetId(), Message: msg})
+		}
+		if seen[p.GetId()] {
+			problems = append(problems, &pb.ImportProblem{ProductId: p.GetId(), Message: "duplicate product ID in import"})
+		}
+		seen[p.GetId()] = true
+	}
+
+	var catalog []*pb.Product
+	if mode == importModeMerge {
+		catalog = make([]*pb.Product, 0, len(current)+len(products))
+		for _, p := range current {
+			if !seen[p.GetId()] {
+				catalog = append(catalog, p)
+			}
+		}
+	}
+	for _, p := range products {
+		addImageVariants(p)
+	}
+	catalog = append(catalog, products...)
+	if len(catalog) == 0 {
+		problems = append(problems, &pb.ImportProblem{Message: "import would leave the catalog empty"})
+	}
+	return catalog, problems
+}
+
+// validateProduct returns what is wrong with p, if anything.
+func validateProduct(p *pb.Product) []string {
+	var msgs []string
+	if p.GetId() == "" {
+		msgs = append(msgs, "id is required")
+	}
+	if p.GetName() == "" {
+		msgs = append(msgs, "name is required")
+	}
+	price := p.GetPriceUsd()
+	switch {
+	case price == nil:
+		msgs = append(msgs, "price_usd is required")
+	case price.GetCurrencyCode() != "USD":
+		msgs = append(msgs, fmt.Sprintf("price_usd has currency %q, want USD", price.GetCurrencyCode()))
+	case !IsValid(price) || IsNegative(price):
+		msgs = append(msgs, "price_usd is not a valid non-negative amount")
+	}
+	if sale := p.GetSalePriceUsd(); sale != nil {
+		switch {
+		case sale.GetCurrencyCode() != "USD":
+			msgs = append(msgs, fmt.Sprintf("sale_price_usd has currency %q, want USD", sale.GetCurrencyCode()))
+		case !IsValid(sale) || IsNegative(sale):
+			msgs = append(msgs, "sale_price_usd is not a valid non-negative amount")
+		}
+		if p.GetSaleEnd() != 0 && p.GetSaleEnd() <= p.GetSaleStart() {
+			msgs = append(msgs, "sale_end is not after sale_start")
+		}
+	}
+	return msgs
+}
+
+// productPrice returns the price of p in USD at now and, while p is on sale,
+// the regular price that the sale price replaces.
+func productPrice(p *pb.Product, now time.Time) (price, regular *pb.Money) {
+	sale := p.GetSalePriceUsd()
+	if sale == nil ||
+		(p.GetSaleStart() != 0 && now.Unix() < p.GetSaleStart()) ||
+		(p.GetSaleEnd() != 0 && now.Unix() >= p.GetSaleEnd()) {
+		return p.GetPriceUsd(), nil
+	}
+	return sale, p.GetPriceUsd()
+}
+
+// ExportProducts returns a page of the current catalog
+func (s *ProductCatalogService) ExportProducts(ctx context.Context, req *pb.ExportProductsRequest) (_ *pb.ExportProductsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if req.Offset < 0 || req.Limit < 0 {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "offset and limit must not be negative")
+	}
+	catalog := s.parseCatalog()
+	start := min(int(req.Offset), len(catalog))
+	end := len(catalog)
+	if req.Limit > 0 {
+		end = min(start+int(req.Limit), end)
+	}
+
+	return &pb.ExportProductsResponse{
+		Products: catalog[start:end],
+		Total:    int32(len(catalog)),
+	}, ctx, nil
+}
+
+// ListVariants lists the SKUs of a product
+func (s *ProductCatalogService) ListVariants(ctx context.Context, req *pb.ListVariantsRequest) (_ *pb.ListVariantsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	time.Sleep(s.extraLatency.Get())
+
+	s.variantsMu.RLock()
+	variants := slices.Clone(s.variants[req.ProductId])
+	s.variantsMu.RUnlock()
+
+	return &pb.ListVariantsResponse{Variants: variants}, ctx, nil
+}
+
+// GetVariant retrieves one SKU of a product
+func (s *ProductCatalogService) GetVariant(ctx context.Context, req *pb.GetVariantRequest) (_ *pb.ProductVariant, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	time.Sleep(s.extraLatency.Get())
+
+	s.variantsMu.RLock()
+	defer s.variantsMu.RUnlock()
+	if i := s.variantIndex(req.ProductId, req.VariantId); i >= 0 {
+		return s.variants[req.ProductId][i], ctx, nil
+	}
+	return nil, ctx, status.Errorf(codes.NotFound, "no variant %s of product %s", req.VariantId, req.ProductId)
+}
+
+// GetStock returns the units left of a product over all its variants.
+// Products not sold in variants have no stock to report.
+func (s *ProductCatalogService) GetStock(ctx context.Context, req *pb.GetStockRequest) (_ *pb.StockLevel, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	level := &pb.StockLevel{ProductId: req.ProductId}
+	if !inventoryTracking.Get() {
+		return level, ctx, nil
+	}
+	s.variantsMu.RLock()
+	defer s.variantsMu.RUnlock()
+	for _, v := range s.variants[req.ProductId] {
+		level.Tracked = true
+		level.Quantity += max(v.Stock, 0)
+	}
+	return level, ctx, nil
+}
+
+// variantIndex returns the position of a variant in s.variants, or -1. The
+// caller must hold variantsMu.
+func (s *ProductCatalogService) variantIndex(productID, variantID string) int {
+	return slices.IndexFunc(s.variants[productID], func(v *pb.ProductVariant) bool {
+		return v.Id == variantID
+	})
+}
+
+// RestockVariant adds stock to a variant. Subscribers waiting for a sold out
+// variant are sent a back in stock email through the event bus.
+func (s *ProductCatalogService) RestockVariant(ctx context.Context, req *pb.RestockVariantRequest) (_ *pb.ProductVariant, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	log.Printf("RestockVariant: Received %d units of product ID %s, variant ID %s\n", req.Quantity, req.ProductId, req.VariantId)
+
+	if req.Quantity <= 0 {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "quantity must be positive, got %d", req.Quantity)
+	}
+
+	s.variantsMu.Lock()
+	i := s.variantIndex(req.ProductId, req.VariantId)
+	if i < 0 {
+		s.variantsMu.Unlock()
+		return nil, ctx, status.Errorf(codes.NotFound, "no variant %s of product %s", req.VariantId, req.ProductId)
+	}
+	old, restocked := s.adjustStock(req.ProductId, i, req.Quantity)
+
+	var waiting map[string]string
+	if old.Stock <= 0 {
+		waiting = s.takeBackorders(req.ProductId, req.VariantId)
+	}
+	s.variantsMu.Unlock()
+
+	if len(waiting) > 0 {
+		s.publishRestocked(ctx, req.ProductId, restocked, waiting)
+	}
+	return restocked, ctx, nil
+}
+
+// publishRestocked announces a restock to every waiting subscriber. Failures
+// are logged; the subscriptions are not kept for another attempt.
+func (s *ProductCatalogService) publishRestocked(ctx context.Context, productID string, variant *pb.ProductVariant, waiting map[string]string) {
+	i := slices.IndexFunc(s.parseCatalog(), func(p *pb.Product) bool { return p.Id == productID })
+	if i < 0 {
+		log.Printf("RestockVariant: product ID %s left the catalog, dropping %d notifications\n", productID, len(waiting))
+		return
+	}
+	product := s.parseCatalog()[i]
+	for email, locale := range waiting {
+		err := s.bus.Publish(ctx, eventbus.TopicProductRestocked, &pb.ProductRestocked{
+			Product: product,
+			Variant: variant,
+			Email:   email,
+			Locale:  locale,
+		})
+		if err != nil {
+			log.Printf("RestockVariant: failed to publish restock of %s for %s: %v\n", variant.Id, email, err)
+		}
+	}
+	log.Printf("RestockVariant: notified %d subscribers of variant ID %s\n", len(waiting), variant.Id)
+}
+
+// NotifyWhenAvailable subscribes an email to a sold out product or variant.
+// Products that can be bought right now are rejected, as there would be
+// nothing to wait for.
+func (s *ProductCatalogService) NotifyWhenAvailable(ctx context.Context, req *pb.NotifyWhenAvailableRequest) (_ *pb.Empty, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if !strings.Contains(req.Email, "@") {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "invalid email %q", req.Email)
+	}
+	if !slices.ContainsFunc(s.tenantCatalog(ctx), func(p *pb.Product) bool { return p.Id == req.ProductId }) {
+		return nil, ctx, status.Errorf(codes.NotFound, "no product with ID %s", req.ProductId)
+	}
+
+	s.variantsMu.Lock()
+	defer s.variantsMu.Unlock()
+
+	variants := s.variants[req.ProductId]
+	if req.VariantId != "" {
+		i := s.variantIndex(req.ProductId, req.VariantId)
+		if i < 0 {
+			return nil, ctx, status.Errorf(codes.NotFound, "no variant %s of product %s", req.VariantId, req.ProductId)
+		}
+		variants = variants[i : i+1]
+	}
+	if len(variants) == 0 || slices.ContainsFunc(variants, func(v *pb.ProductVariant) bool { return v.Stock > 0 }) {
+		return nil, ctx, status.Errorf(codes.FailedPrecondition, "product %s is in stock", req.ProductId)
+	}
+
+	key := backorderKey{req.ProductId, req.VariantId}
+	if s.backorders[key] == nil {
+		s.backorders[key] = make(map[string]string)
+	}
+	s.backorders[key][req.Email] = req.Locale
+
+	return &pb.Empty{}, ctx, nil
 }
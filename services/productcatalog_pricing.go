@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/pricing"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+)
+
+// loadPricingRules reads the pricing rules from a file. A missing file means
+// there are no promotions.
+func loadPricingRules(name string) ([]*pb.PricingRule, error) {
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rules pb.PricingRules
+	if err := protojson.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+	if problems := pricing.Validate(rules.Rules); len(problems) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid pricing rules: %s", strings.Join(problems, "; "))
+	}
+	return rules.Rules, nil
+}
+
+// ListPricingRules lists the pricing rules, including those not in effect
+// yet or any more, in the order they apply.
+func (s *ProductCatalogService) ListPricingRules(ctx context.Context, req *pb.Empty) (_ *pb.PricingRules, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	s.pricingMu.RLock()
+	rules := slices.Clone(s.pricingRules)
+	s.pricingMu.RUnlock()
+
+	return &pb.PricingRules{Rules: rules}, ctx, nil
+}
+
+// SetPricingRules replaces the pricing rules, unless any is invalid. Like
+// imported products, they live in memory only, until the data is reloaded
+// from data/pricing_rules.json.
+func (s *ProductCatalogService) SetPricingRules(ctx context.Context, req *pb.PricingRules) (_ *pb.PricingRules, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if problems := pricing.Validate(req.Rules); len(problems) > 0 {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "invalid pricing rules: %s", strings.Join(problems, "; "))
+	}
+	s.pricingMu.Lock()
+	s.pricingRules = req.Rules
+	s.pricingMu.Unlock()
+
+	log.Printf("SetPricingRules: %d pricing rules set\n", len(req.Rules))
+	return &pb.PricingRules{Rules: req.Rules}, ctx, nil
+}
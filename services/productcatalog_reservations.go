@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"maps"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+)
+
+// Statuses of a stock reservation.
+const (
+	reservationHeld      = "HELD"
+	reservationCommitted = "COMMITTED"
+	reservationReleased  = "RELEASED"
+)
+
+// stockReservationTTL is how long stock is held for an order that is neither
+// committed nor released, STOCK_RESERVATION_TTL, after which it goes back on
+// sale.
+var stockReservationTTL = config.NewValue(func() time.Duration {
+	return envDuration("STOCK_RESERVATION_TTL", 10*time.Minute)
+})
+
+// reservationStats counts reservations by how they ended, along with those
+// held right now. Expired ones were leaked by orders that never completed,
+// and expired_units counts the stock they held.
+var reservationStats = expvar.NewMap("stock_reservations")
+
+// stockReservation is the stock held for an order.
+type stockReservation struct {
+	// items are the variants held, one per variant.
+	items   []*pb.CartItem
+	expires time.Time
+}
+
+// restockedVariant is a variant that came back in stock, with the
+// subscribers waiting for it.
+type restockedVariant struct {
+	variant *pb.ProductVariant
+	waiting map[string]string
+}
+
+// adjustStock adds delta to the stock of the i-th variant of a product and
+// returns the variant before and after. The variant is replaced, and the
+// slice copied, so responses already holding them are not affected. The
+// caller must hold variantsMu.
+func (s *ProductCatalogService) adjustStock(productID string, i int, delta int32) (old, updated *pb.ProductVariant) {
+	old = s.variants[productID][i]
+	updated = &pb.ProductVariant{
+		Id:            old.Id,
+		ProductId:     old.ProductId,
+		Attributes:    old.Attributes,
+		Stock:         old.Stock + delta,
+		PriceDeltaUsd: old.PriceDeltaUsd,
+	}
+	variants := slices.Clone(s.variants[productID])
+	variants[i] = updated
+	s.variants[productID] = variants
+	return old, updated
+}
+
+// takeBackorders removes and returns the subscribers waiting for a variant,
+// including those waiting for any variant of its product. The caller must
+// hold variantsMu.
+func (s *ProductCatalogService) takeBackorders(productID, variantID string) map[string]string {
+	waiting := make(map[string]string)
+	for _, key := range []backorderKey{{productID, variantID}, {productID, ""}} {
+		maps.Copy(waiting, s.backorders[key])
+		delete(s.backorders, key)
+	}
+	return waiting
+}
+
+// returnStock puts the stock of a reservation back on sale and returns the
+// variants that were sold out until then. Variants that left the catalog
+// since are skipped. The caller must hold variantsMu.
+func (s *ProductCatalogService) returnStock(items []*pb.CartItem) []restockedVariant {
+	var restocked []restockedVariant
+	for _, item := range items {
+		i := s.variantIndex(item.ProductId, item.VariantId)
+		if i < 0 {
+			continue
+		}
+		old, updated := s.adjustStock(item.ProductId, i, item.Quantity)
+		if old.Stock <= 0 && updated.Stock > 0 {
+			restocked = append(restocked, restockedVariant{updated, s.takeBackorders(item.ProductId, item.VariantId)})
+		}
+	}
+	return restocked
+}
+
+// ReserveStock holds the stock of an order's variants for
+// STOCK_RESERVATION_TTL, taking it off sale. Either every variant is held or
+// none is. Reserving again for the same order returns the reservation
+// already held.
+func (s *ProductCatalogService) ReserveStock(ctx context.Context, req *pb.ReserveStockRequest) (_ *pb.StockReservation, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	if req.OrderId == "" {
+		return nil, ctx, status.Errorf(codes.InvalidArgument, "order_id is required")
+	}
+
+	// The same variant may be in the cart more than once.
+	var items []*pb.CartItem
+	for _, it := range req.Items {
+		if it.GetVariantId() == "" {
+			continue
+		}
+		if it.GetQuantity() <= 0 {
+			return nil, ctx, status.Errorf(codes.InvalidArgument, "quantity must be positive, got %d", it.GetQuantity())
+		}
+		j := slices.IndexFunc(items, func(held *pb.CartItem) bool {
+			return held.ProductId == it.GetProductId() && held.VariantId == it.GetVariantId()
+		})
+		if j < 0 {
+			items = append(items, &pb.CartItem{ProductId: it.GetProductId(), VariantId: it.GetVariantId(), Quantity: it.GetQuantity()})
+			continue
+		}
+		items[j].Quantity += it.GetQuantity()
+	}
+
+	s.variantsMu.Lock()
+	defer s.variantsMu.Unlock()
+
+	if r, ok := s.reservations[req.OrderId]; ok {
+		return reservationResponse(req.OrderId, r, reservationHeld), ctx, nil
+	}
+	indexes := make([]int, len(items))
+	for k, item := range items {
+		i := s.variantIndex(item.ProductId, item.VariantId)
+		if i < 0 {
+			return nil, ctx, status.Errorf(codes.NotFound, "no variant %s of product %s", item.VariantId, item.ProductId)
+		}
+		if stock := s.variants[item.ProductId][i].Stock; stock < item.Quantity {
+			return nil, ctx, status.Errorf(codes.FailedPrecondition, "only %d of variant %q in stock", stock, item.VariantId)
+		}
+		indexes[k] = i
+	}
+	for k, item := range items {
+		s.adjustStock(item.ProductId, indexes[k], -item.Quantity)
+	}
+	r := &stockReservation{items: items, expires: time.Now().Add(stockReservationTTL.Get())}
+	s.reservations[req.OrderId] = r
+	reservationStats.Add("reserved", 1)
+	reservationStats.Add("held", 1)
+
+	log.Printf("ReserveStock: Holding %d variants for order %s\n", len(items), req.OrderId)
+	return reservationResponse(req.OrderId, r, reservationHeld), ctx, nil
+}
+
+// CommitReservation marks the stock held for an order as sold, once the
+// order is placed.
+func (s *ProductCatalogService) CommitReservation(ctx context.Context, req *pb.ReservationRequest) (_ *pb.StockReservation, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	s.variantsMu.Lock()
+	r, ok := s.reservations[req.OrderId]
+	delete(s.reservations, req.OrderId)
+	s.variantsMu.Unlock()
+	if !ok {
+		return nil, ctx, status.Errorf(codes.NotFound, "no stock held for order %s", req.OrderId)
+	}
+	reservationStats.Add("committed", 1)
+	reservationStats.Add("held", -1)
+	return reservationResponse(req.OrderId, r, reservationCommitted), ctx, nil
+}
+
+// ReleaseReservation puts the stock held for an order that failed back on
+// sale. Releasing stock that is no longer held does nothing.
+func (s *ProductCatalogService) ReleaseReservation(ctx context.Context, req *pb.ReservationRequest) (_ *pb.StockReservation, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	s.variantsMu.Lock()
+	r, ok := s.reservations[req.OrderId]
+	var restocked []restockedVariant
+	if ok {
+		delete(s.reservations, req.OrderId)
+		restocked = s.returnStock(r.items)
+	}
+	s.variantsMu.Unlock()
+	if !ok {
+		return &pb.StockReservation{OrderId: req.OrderId, Status: reservationReleased}, ctx, nil
+	}
+
+	reservationStats.Add("released", 1)
+	reservationStats.Add("held", -1)
+	for _, rv := range restocked {
+		if len(rv.waiting) > 0 {
+			s.publishRestocked(ctx, rv.variant.ProductId, rv.variant, rv.waiting)
+		}
+	}
+	return reservationResponse(req.OrderId, r, reservationReleased), ctx, nil
+}
+
+// sweepReservations expires the reservations past their time, every
+// interval.
+func (s *ProductCatalogService) sweepReservations(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		s.expireReservations(now)
+	}
+}
+
+// expireReservations puts the stock of the reservations expired at now back
+// on sale.
+func (s *ProductCatalogService) expireReservations(now time.Time) {
+	var restocked []restockedVariant
+	s.variantsMu.Lock()
+	for orderID, r := range s.reservations {
+		if now.Before(r.expires) {
+			continue
+		}
+		delete(s.reservations, orderID)
+		restocked = append(restocked, s.returnStock(r.items)...)
+
+		var units int64
+		for _, item := range r.items {
+			units += int64(item.Quantity)
+		}
+		reservationStats.Add("expired", 1)
+		reservationStats.Add("expired_units", units)
+		reservationStats.Add("held", -1)
+		log.Printf("expireReservations: Stock held for order %s expired, returning %d units\n", orderID, units)
+	}
+	s.variantsMu.Unlock()
+
+	for _, rv := range restocked {
+		if len(rv.waiting) > 0 {
+			s.publishRestocked(context.Background(), rv.variant.ProductId, rv.variant, rv.waiting)
+		}
+	}
+}
+
+func reservationResponse(orderID string, r *stockReservation, state string) *pb.StockReservation {
+	return &pb.StockReservation{
+		OrderId:   orderID,
+		Items:     r.items,
+		ExpiresAt: r.expires.Unix(),
+		Status:    state,
+	}
+}
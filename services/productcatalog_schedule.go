@@ -0,0 +1,245 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+)
+
+// Statuses of a scheduled price change.
+const (
+	priceChangePending = "PENDING"
+	priceChangeActive  = "ACTIVE"
+	priceChangeEnded   = "ENDED"
+)
+
+// savedSale is the sale a product had before a price change replaced it.
+type savedSale struct {
+	price      *pb.Money
+	start, end int64
+}
+
+// loadPriceSchedule reads the scheduled price changes from a file. A missing
+// file means none are scheduled.
+func loadPriceSchedule(name string) ([]*pb.PriceChange, error) {
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var changes pb.PriceChanges
+	if err := protojson.Unmarshal(data, &changes); err != nil {
+		return nil, err
+	}
+	if problems := validatePriceChanges(changes.Changes); len(problems) > 0 {
+		return nil, fmt.Errorf("invalid price schedule: %s", strings.Join(problems, "; "))
+	}
+	return changes.Changes, nil
+}
+
+// validatePriceChanges returns what is wrong with changes, if anything.
+func validatePriceChanges(changes []*pb.PriceChange) []string {
+	var msgs []string
+	seen := make(map[string]bool, len(changes))
+	for i, c := range changes {
+		problem := func(format string, args ...any) {
+			msgs = append(msgs, fmt.Sprintf("change %d (%q): ", i, c.GetId())+fmt.Sprintf(format, args...))
+		}
+		if c.GetId() == "" {
+			problem("id is required")
+		} else if seen[c.GetId()] {
+			problem("duplicate id")
+		}
+		seen[c.GetId()] = true
+
+		if len(c.GetProductIds()) == 0 {
+			problem("product_ids is required")
+		}
+		if sale := c.GetSalePriceUsd(); sale != nil {
+			if sale.GetCurrencyCode() != "USD" || !IsValid(sale) || IsNegative(sale) {
+				problem("sale_price_usd is not a valid non-negative USD amount")
+			}
+		} else if c.GetPercentOff() < 1 || c.GetPercentOff() > 99 {
+			problem("percent_off must be between 1 and 99 without sale_price_usd")
+		}
+		if c.GetStart() == 0 || c.GetEnd() <= c.GetStart() {
+			problem("start is required and end must be after it")
+		}
+	}
+	return msgs
+}
+
+// priceChangeStatus returns the status of c at now.
+func priceChangeStatus(c *pb.PriceChange, now time.Time) string {
+	switch {
+	case now.Unix() < c.GetStart():
+		return priceChangePending
+	case now.Unix() < c.GetEnd():
+		return priceChangeActive
+	}
+	return priceChangeEnded
+}
+
+// ListPriceChanges lists the scheduled price changes with their status,
+// those that ended only when asked for by status.
+func (s *ProductCatalogService) ListPriceChanges(ctx context.Context, req *pb.ListPriceChangesRequest) (_ *pb.PriceChanges, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	now := time.Now()
+	s.scheduleMu.Lock()
+	var changes []*pb.PriceChange
+	for _, c := range s.schedule {
+		state := priceChangeStatus(c, now)
+		if req.Status == "" && state == priceChangeEnded || req.Status != "" && state != req.Status {
+			continue
+		}
+		listed := proto.Clone(c).(*pb.PriceChange)
+		listed.Status = state
+		changes = append(changes, listed)
+	}
+	s.scheduleMu.Unlock()
+
+	slices.SortStableFunc(changes, func(a, b *pb.PriceChange) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+	return &pb.PriceChanges{Changes: changes}, ctx, nil
+}
+
+// schedulePrices applies the price changes due, every interval.
+func (s *ProductCatalogService) schedulePrices(interval time.Duration) {
+	s.applyPriceSchedule(time.Now())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		s.applyPriceSchedule(now)
+	}
+}
+
+// applyPriceSchedule starts the price changes whose window began by now and
+// ends those whose window is over, publishing a CatalogUpdated event for
+// each.
+func (s *ProductCatalogService) applyPriceSchedule(now time.Time) {
+	s.scheduleMu.Lock()
+	var starting, ending []*pb.PriceChange
+	for _, c := range s.schedule {
+		_, applied := s.appliedChanges[c.Id]
+		switch active := priceChangeStatus(c, now) == priceChangeActive; {
+		case active && !applied:
+			starting = append(starting, c)
+		case !active && applied:
+			ending = append(ending, c)
+		}
+	}
+	if len(starting) == 0 && len(ending) == 0 {
+		s.scheduleMu.Unlock()
+		return
+	}
+
+	s.mu.Lock()
+	catalog := slices.Clone(s.catalog.Products)
+	for _, c := range ending {
+		s.endPriceChange(catalog, c)
+	}
+	for _, c := range starting {
+		s.startPriceChange(catalog, c)
+	}
+	s.catalog.Products = catalog
+	s.rebuildSearchIndex(catalog)
+	s.mu.Unlock()
+	s.scheduleMu.Unlock()
+
+	for _, c := range ending {
+		log.Printf("applyPriceSchedule: Price change %s ended\n", c.Id)
+		s.publishCatalogUpdated(c.ProductIds, "price change "+c.Id+" ended")
+	}
+	for _, c := range starting {
+		log.Printf("applyPriceSchedule: Price change %s started for %d products\n", c.Id, len(c.ProductIds))
+		s.publishCatalogUpdated(c.ProductIds, "price change "+c.Id+" started")
+	}
+}
+
+// startPriceChange puts the products of c in catalog on sale, saving the
+// sales they had. Products are replaced rather than modified, since
+// responses may still hold them. The caller must hold mu and scheduleMu.
+func (s *ProductCatalogService) startPriceChange(catalog []*pb.Product, c *pb.PriceChange) {
+	saved := make(map[string]savedSale)
+	for i, p := range catalog {
+		if !slices.Contains(c.ProductIds, p.Id) {
+			continue
+		}
+		saved[p.Id] = savedSale{p.SalePriceUsd, p.SaleStart, p.SaleEnd}
+		sale := c.SalePriceUsd
+		if sale == nil {
+			price, err := moneyToNanos(p.PriceUsd)
+			if err != nil {
+				log.Printf("startPriceChange: Skipping product %s of price change %s: %v\n", p.Id, c.Id, err)
+				continue
+			}
+			// Rounded down to the cent, like pricing rules. The price is
+			// split so that multiplying by the percentage cannot overflow.
+			percent := int64(c.PercentOff)
+			off := price/100*percent + price%100*percent/100
+			off -= off % (nanosMod / 100)
+			sale = nanosToMoney(p.PriceUsd.GetCurrencyCode(), price-off)
+		}
+		updated := proto.Clone(p).(*pb.Product)
+		updated.SalePriceUsd = sale
+		updated.SaleStart = c.Start
+		updated.SaleEnd = c.End
+		catalog[i] = updated
+	}
+	s.appliedChanges[c.Id] = saved
+}
+
+// endPriceChange gives the products of c in catalog back the sales they had
+// before it, unless their sale changed since. The caller must hold mu and
+// scheduleMu.
+func (s *ProductCatalogService) endPriceChange(catalog []*pb.Product, c *pb.PriceChange) {
+	for i, p := range catalog {
+		prev, ok := s.appliedChanges[c.Id][p.Id]
+		if !ok || p.SaleStart != c.Start || p.SaleEnd != c.End {
+			continue
+		}
+		updated := proto.Clone(p).(*pb.Product)
+		updated.SalePriceUsd = prev.price
+		updated.SaleStart = prev.start
+		updated.SaleEnd = prev.end
+		catalog[i] = updated
+	}
+	delete(s.appliedChanges, c.Id)
+}
+
+// publishCatalogUpdated tells the caches of products that they changed.
+func (s *ProductCatalogService) publishCatalogUpdated(productIDs []string, reason string) {
+	err := s.bus.Publish(context.Background(), eventbus.TopicCatalogUpdated, &pb.CatalogUpdated{
+		ProductIds: productIDs,
+		Reason:     reason,
+	})
+	if err != nil {
+		log.Printf("Failed to publish catalog update (%s): %v", reason, err)
+	}
+}
+
+// setPriceSchedule replaces the scheduled price changes. The catalog is
+// reloaded along with them, so no change is applied to it any more: those in
+// their window are applied afresh on the next pass of the scheduler.
+func (s *ProductCatalogService) setPriceSchedule(changes []*pb.PriceChange) {
+	s.scheduleMu.Lock()
+	defer s.scheduleMu.Unlock()
+	s.schedule = changes
+	s.appliedChanges = make(map[string]map[string]savedSale)
+}
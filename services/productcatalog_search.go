@@ -0,0 +1,342 @@
+package services
+
+import (
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// Weights of a term by the field it appears in.
+const (
+	searchWeightName        = 3
+	searchWeightCategory    = 2
+	searchWeightDescription = 1
+)
+
+const (
+	// minFuzzySimilarity is how alike, by shared trigrams, a query word and
+	// a catalog word must be to match: 1 for the same word, around 0.5 for
+	// words a typo apart.
+	minFuzzySimilarity = 0.45
+	// prefixSimilarity is how well a query word matches the words it is a
+	// prefix of, e.g. "sun" and "sunglasses".
+	prefixSimilarity = 0.8
+	// minPrefixLength is the shortest query word matched as a prefix.
+	minPrefixLength = 3
+)
+
+// searchIndex is an inverted index of the catalog. It is built whenever the
+// catalog changes and never modified after.
+type searchIndex struct {
+	products []*pb.Product
+	// postings maps each word to the products it appears in, with the
+	// weight of the most important field it appears in.
+	postings map[string]map[int]int
+	// trigrams maps the trigrams of the words to the words, for finding
+	// the words a misspelled query word may stand for.
+	trigrams map[string][]string
+}
+
+// searchTokens splits text into lower-case words.
+func searchTokens(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// wordTrigrams returns the distinct trigrams of word, padded so that its
+// first and last letters count as much as the others.
+func wordTrigrams(word string) []string {
+	r := []rune(" " + word + " ")
+	seen := make(map[string]bool)
+	var out []string
+	for i := 0; i+3 <= len(r); i++ {
+		t := string(r[i : i+3])
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func newSearchIndex(products []*pb.Product) *searchIndex {
+	idx := &searchIndex{
+		products: products,
+		postings: make(map[string]map[int]int),
+		trigrams: make(map[string][]string),
+	}
+	add := func(i int, text string, weight int) {
+		for _, w := range searchTokens(text) {
+			p, ok := idx.postings[w]
+			if !ok {
+				p = make(map[int]int)
+				idx.postings[w] = p
+				for _, t := range wordTrigrams(w) {
+					idx.trigrams[t] = append(idx.trigrams[t], w)
+				}
+			}
+			p[i] = max(p[i], weight)
+		}
+	}
+	for i, p := range products {
+		add(i, p.GetName(), searchWeightName)
+		for _, c := range p.GetCategories() {
+			add(i, c, searchWeightCategory)
+		}
+		add(i, p.GetDescription(), searchWeightDescription)
+	}
+	return idx
+}
+
+// matches returns the words of the index that word may stand for, with how
+// alike they are.
+func (idx *searchIndex) matches(word string) map[string]float64 {
+	out := make(map[string]float64)
+	if _, ok := idx.postings[word]; ok {
+		out[word] = 1
+	}
+	grams := wordTrigrams(word)
+	shared := make(map[string]int)
+	for _, t := range grams {
+		for _, w := range idx.trigrams[t] {
+			shared[w]++
+		}
+	}
+	for w, n := range shared {
+		if w == word {
+			continue
+		}
+		// Dice coefficient of the two trigram sets.
+		sim := 2 * float64(n) / float64(len(grams)+len(wordTrigrams(w)))
+		if len([]rune(word)) >= minPrefixLength && strings.HasPrefix(w, word) {
+			sim = max(sim, prefixSimilarity)
+		}
+		if sim >= minFuzzySimilarity {
+			out[w] = sim
+		}
+	}
+	return out
+}
+
+// search returns the products that match every word of query, best first:
+// by relevance, then popularity, then name. Products for which keep returns
+// false are left out. An empty query matches every product.
+func (idx *searchIndex) search(query string, popularity func(id string) int64, keep func(*pb.Product) bool) []*pb.Product {
+	words := searchTokens(query)
+	scores := make(map[int]float64)
+	if len(words) == 0 {
+		for i := range idx.products {
+			scores[i] = 0
+		}
+	}
+	for n, word := range words {
+		best := make(map[int]float64)
+		for w, sim := range idx.matches(word) {
+			for i, weight := range idx.postings[w] {
+				best[i] = max(best[i], sim*float64(weight))
+			}
+		}
+		if n == 0 {
+			scores = best
+			continue
+		}
+		for i := range scores {
+			if s, ok := best[i]; ok {
+				scores[i] += s
+			} else {
+				delete(scores, i)
+			}
+		}
+	}
+
+	type hit struct {
+		p     *pb.Product
+		score float64
+		pop   int64
+	}
+	hits := make([]hit, 0, len(scores))
+	for i, score := range scores {
+		p := idx.products[i]
+		if keep(p) {
+			hits = append(hits, hit{p, score, popularity(p.GetId())})
+		}
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if math.Abs(hits[a].score-hits[b].score) > 1e-9 {
+			return hits[a].score > hits[b].score
+		}
+		if hits[a].pop != hits[b].pop {
+			return hits[a].pop > hits[b].pop
+		}
+		return hits[a].p.GetName() < hits[b].p.GetName()
+	})
+	out := make([]*pb.Product, len(hits))
+	for i, h := range hits {
+		out[i] = h.p
+	}
+	return out
+}
+
+const (
+	defaultSuggestions = 5
+	maxSuggestions     = 10
+)
+
+// suggest returns up to limit products whose name starts with prefix, or
+// has a word that does, for completing a query as it is typed. Names that
+// start with prefix come first, then the most popular products.
+func (idx *searchIndex) suggest(prefix string, limit int, popularity func(id string) int64, keep func(*pb.Product) bool) []*pb.Product {
+	prefix = strings.ToLower(strings.TrimLeftFunc(prefix, unicode.IsSpace))
+	if prefix == "" {
+		return nil
+	}
+	type hit struct {
+		p     *pb.Product
+		whole bool
+		pop   int64
+	}
+	var hits []hit
+	for _, p := range idx.products {
+		name := strings.ToLower(p.GetName())
+		whole := strings.HasPrefix(name, prefix)
+		if !whole && !slices.ContainsFunc(searchTokens(name), func(w string) bool { return strings.HasPrefix(w, prefix) }) {
+			continue
+		}
+		if keep(p) {
+			hits = append(hits, hit{p, whole, popularity(p.GetId())})
+		}
+	}
+	sort.Slice(hits, func(a, b int) bool {
+		if hits[a].whole != hits[b].whole {
+			return hits[a].whole
+		}
+		if hits[a].pop != hits[b].pop {
+			return hits[a].pop > hits[b].pop
+		}
+		return hits[a].p.GetName() < hits[b].p.GetName()
+	})
+	out := make([]*pb.Product, 0, min(limit, len(hits)))
+	for _, h := range hits[:min(limit, len(hits))] {
+		out = append(out, h.p)
+	}
+	return out
+}
+
+// Search facets.
+const (
+	facetCategory = "category"
+	facetPrice    = "price"
+)
+
+// priceRanges are the values of the price facet, cheapest first. Each covers
+// prices from min dollars up to the min of the next.
+var priceRanges = []struct {
+	value string
+	min   int64
+}{
+	{"0-25", 0},
+	{"25-50", 25},
+	{"50-100", 50},
+	{"100+", 100},
+}
+
+// priceRangeOf returns the price facet value of p at now.
+func priceRangeOf(p *pb.Product, now time.Time) string {
+	price, _ := productPrice(p, now)
+	value := priceRanges[0].value
+	for _, r := range priceRanges {
+		if price.GetUnits() >= r.min {
+			value = r.value
+		}
+	}
+	return value
+}
+
+// filterResults returns the results in category and priceRange, in order,
+// along with the facet counts. Categories are counted most common first.
+func filterResults(results []*pb.Product, category, priceRange string, now time.Time) ([]*pb.Product, *pb.SearchFacets) {
+	categories := make(map[string]int32)
+	prices := make(map[string]int32)
+	var kept []*pb.Product
+	for _, p := range results {
+		inCategory := category == "" || slices.Contains(p.GetCategories(), category)
+		r := priceRangeOf(p, now)
+		inRange := priceRange == "" || r == priceRange
+		if inRange {
+			for _, c := range p.GetCategories() {
+				categories[c]++
+			}
+		}
+		if inCategory {
+			prices[r]++
+		}
+		if inCategory && inRange {
+			kept = append(kept, p)
+		}
+	}
+
+	facets := &pb.SearchFacets{}
+	for _, c := range slices.Sorted(maps.Keys(categories)) {
+		facets.Counts = append(facets.Counts, &pb.FacetCount{Facet: facetCategory, Value: c, Count: categories[c]})
+	}
+	slices.SortStableFunc(facets.Counts, func(a, b *pb.FacetCount) int {
+		return int(b.Count - a.Count)
+	})
+	for _, r := range priceRanges {
+		if n := prices[r.value]; n > 0 {
+			facets.Counts = append(facets.Counts, &pb.FacetCount{Facet: facetPrice, Value: r.value, Count: n})
+		}
+	}
+	return kept, facets
+}
+
+// productViews counts how often each product was fetched on its own, which
+// is how search ranks products that match equally well.
+type productViews struct {
+	mu sync.Mutex
+	n  map[string]int64
+}
+
+func (v *productViews) add(id string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.n == nil {
+		v.n = make(map[string]int64)
+	}
+	v.n[id]++
+}
+
+func (v *productViews) get(id string) int64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.n[id]
+}
+
+// rebuildSearchIndex indexes products, which must be the current catalog.
+// The caller must hold s.mu.
+func (s *ProductCatalogService) rebuildSearchIndex(products []*pb.Product) {
+	s.searchIdx.Store(newSearchIndex(products))
+}
+
+// serveRebuildSearchIndex rebuilds the search index from the current
+// catalog. It answers POST /admin/search-index on the status server.
+func (s *ProductCatalogService) serveRebuildSearchIndex(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.mu.Lock()
+	s.rebuildSearchIndex(s.catalog.Products)
+	s.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
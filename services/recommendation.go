@@ -1,34 +1,108 @@
 package services
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"maps"
 	"math/rand"
+	"os"
+	"slices"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/resolver"
+	"github.com/appnetorg/online-boutique-arpc/services/startup"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
+// Reasons a product is recommended, strongest first.
+const (
+	reasonBoughtTogether    = "BOUGHT_TOGETHER"
+	reasonSimilar           = "SIMILAR"
+	reasonInPageCategory    = "IN_PAGE_CATEGORY"
+	reasonPopularInCategory = "POPULAR_IN_CATEGORY"
+)
+
+// maxRecommendations is the number of products recommended per request.
+const maxRecommendations = 5
+
 // NewRecommendationService returns a new server for the RecommendationService
 func NewRecommendationService(port int) *RecommendationService {
+	signals, err := loadRecommendationSignals("data/recommendations.json")
+	if err != nil {
+		log.Fatalf("Failed to load recommendation signals: %v", err)
+	}
 	return &RecommendationService{
-		port: port,
+		port:       port,
+		signals:    signals,
+		catalogTTL: envDuration("RECOMMENDATION_CATALOG_TTL", 5*time.Minute),
+		catalogs:   make(map[string]cachedCatalog),
+	}
+}
+
+// recommendationSignals is what the ranking knows about products beyond the
+// catalog.
+type recommendationSignals struct {
+	// BoughtTogether lists, per product, the products often ordered with it.
+	BoughtTogether []struct {
+		ProductID string   `json:"product_id"`
+		With      []string `json:"with"`
+	} `json:"bought_together"`
+	// Popular lists the best sellers, most popular first.
+	Popular []string `json:"popular"`
+}
+
+// loadRecommendationSignals reads the ranking signals from a file. A missing
+// file leaves the ranking to the catalog categories alone.
+func loadRecommendationSignals(name string) (*recommendationSignals, error) {
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return &recommendationSignals{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var signals recommendationSignals
+	if err := json.Unmarshal(data, &signals); err != nil {
+		return nil, err
 	}
+	return &signals, nil
 }
 
 // RecommendationService implements the RecommendationService
 type RecommendationService struct {
-	port int
+	port    int
+	signals *recommendationSignals
+
+	// catalogs holds the catalog of each tenant for catalogTTL, or until a
+	// CatalogUpdated event says it changed.
+	catalogTTL time.Duration
+	catalogMu  sync.Mutex
+	catalogs   map[string]cachedCatalog
 
 	productCatalogSvcAddr string
-	productCatalogSvcConn *rpc.Client
+	productCatalogSvcConn *resolver.Pool
+
+	eventBusAddr string
+	bus          *eventbus.Bus
+}
+
+type cachedCatalog struct {
+	products []*pb.Product
+	expires  time.Time
 }
 
 // Run starts the server
@@ -38,13 +112,21 @@ func (s *RecommendationService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	mustMapEnv(&s.productCatalogSvcAddr, "PRODUCT_CATALOG_SERVICE_ADDR")
+	mapServiceAddr(&s.productCatalogSvcAddr, "PRODUCT_CATALOG_SERVICE_ADDR", "productcatalog")
 
 	mustConnARPC(&s.productCatalogSvcConn, s.productCatalogSvcAddr)
 
+	checker := newStartupChecker()
+	checker.Add("productcatalog", startup.ARPC(s.productCatalogSvcConn.Addrs))
+	mustCheckStartup(checker)
+
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
+	go s.bus.Subscribe(context.Background(), eventbus.TopicCatalogUpdated, s.handleCatalogUpdated)
+
 	// Create ARPC server
-	serializer := &serializer.SymphonySerializer{}
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
@@ -56,42 +138,125 @@ func (s *RecommendationService) Run() error {
 	return nil
 }
 
-// ListRecommendations provides a list of recommended product IDs based on user and product history
-func (s *RecommendationService) ListRecommendations(ctx context.Context, req *pb.ListRecommendationsRequest) (*pb.ListRecommendationsResponse, context.Context, error) {
-	log.Printf("ListRecommendations request received for user_id = %v, product_ids = %v", req.GetUserId(), req.GetProductIds())
+// ListRecommendations recommends products, with the reason for each, based on the products the user is looking at
+func (s *RecommendationService) ListRecommendations(ctx context.Context, req *pb.ListRecommendationsRequest) (_ *pb.ListRecommendationsResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
-	// Fetch a list of products from the product catalog.
-	productCatalogClient := pb.NewProductCatalogServiceClient(s.productCatalogSvcConn)
-	catalogProducts, err := productCatalogClient.ListProducts(ctx, &pb.EmptyUser{UserId: req.GetUserId()})
+	userID := usercontext.UserID(ctx, req.GetUserId())
+
+	catalog, err := s.listProducts(ctx, userID)
 	if err != nil {
 		log.Printf("Error fetching catalog products: %v", err)
 		return nil, ctx, err
 	}
 
-	// Remove user-provided products from the catalog to avoid recommending them.
-	userProductIDs := req.GetProductIds()
-	userIDs := make(map[string]struct{}, len(userProductIDs))
-	for _, id := range userProductIDs {
-		userIDs[id] = struct{}{}
+	return &pb.ListRecommendationsResponse{
+		Recommendations: s.rank(catalog, req.GetProductIds(), req.GetPageContext().GetCategories()),
+	}, ctx, nil
+}
+
+// listProducts returns the catalog of the tenant of ctx, from the cache if it
+// holds it.
+func (s *RecommendationService) listProducts(ctx context.Context, userID string) ([]*pb.Product, error) {
+	key := tenant.FromContext(ctx)
+	s.catalogMu.Lock()
+	c, ok := s.catalogs[key]
+	s.catalogMu.Unlock()
+	if ok && time.Now().Before(c.expires) {
+		return c.products, nil
 	}
 
-	filtered := make([]string, 0, len(catalogProducts.Products))
-	for _, product := range catalogProducts.Products {
-		if _, ok := userIDs[product.Id]; !ok {
-			filtered = append(filtered, product.Id)
-		}
+	productCatalogClient := pb.NewProductCatalogServiceClient(s.productCatalogSvcConn.Pick())
+	resp, err := productCatalogClient.ListProducts(tenant.Forward(ctx), &pb.EmptyUser{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	if s.catalogTTL > 0 {
+		s.catalogMu.Lock()
+		s.catalogs[key] = cachedCatalog{resp.Products, time.Now().Add(s.catalogTTL)}
+		s.catalogMu.Unlock()
 	}
+	return resp.Products, nil
+}
 
-	// Sample from filtered products and return them.
-	rand.Shuffle(len(filtered), func(i, j int) { filtered[i], filtered[j] = filtered[j], filtered[i] })
+// handleCatalogUpdated is the event bus handler for catalog changes. Any
+// change may add or drop a product, so every cached catalog is dropped.
+func (s *RecommendationService) handleCatalogUpdated(payload []byte) error {
+	s.catalogMu.Lock()
+	defer s.catalogMu.Unlock()
+	clear(s.catalogs)
+	return nil
+}
 
-	const maxResponses = 5
-	recommended := filtered
-	if len(filtered) > maxResponses {
-		recommended = filtered[:maxResponses]
+// rank scores every catalog product the user has not picked against the
+// products they have and the categories of the page, and returns the best
+// with the strongest reason for each. Products with no reason fill the
+// remaining slots at random.
+func (s *RecommendationService) rank(catalog []*pb.Product, productIDs, categories []string) []*pb.Recommendation {
+	byID := make(map[string]*pb.Product, len(catalog))
+	for _, p := range catalog {
+		byID[p.Id] = p
+	}
+	picked := make(map[string]bool, len(productIDs))
+	for _, id := range productIDs {
+		picked[id] = true
 	}
 
-	return &pb.ListRecommendationsResponse{
-		ProductIds: recommended,
-	}, ctx, nil
+	type candidate struct {
+		rec   *pb.Recommendation
+		score float64
+	}
+	candidates := make(map[string]*candidate, len(catalog))
+	for _, p := range catalog {
+		if !picked[p.Id] {
+			candidates[p.Id] = &candidate{rec: &pb.Recommendation{ProductId: p.Id}}
+		}
+	}
+	consider := func(id, reason, detail string, score float64) {
+		if c, ok := candidates[id]; ok && score > c.score {
+			c.rec.Reason, c.rec.ReasonDetail, c.score = reason, detail, score
+		}
+	}
+
+	for _, id := range productIDs {
+		p, ok := byID[id]
+		if !ok {
+			continue
+		}
+		for _, bt := range s.signals.BoughtTogether {
+			if bt.ProductID != id {
+				continue
+			}
+			for _, other := range bt.With {
+				consider(other, reasonBoughtTogether, p.Name, 3)
+			}
+		}
+		for _, other := range catalog {
+			if slices.ContainsFunc(other.Categories, func(c string) bool { return slices.Contains(p.Categories, c) }) {
+				consider(other.Id, reasonSimilar, p.Name, 2)
+			}
+		}
+	}
+	// Page categories matter most when there is no product to go by; they
+	// rank below anything derived from the user's own products.
+	for _, p := range catalog {
+		if i := slices.IndexFunc(categories, func(c string) bool { return slices.Contains(p.Categories, c) }); i >= 0 {
+			consider(p.Id, reasonInPageCategory, categories[i], 1.5+1/float64(i+2))
+		}
+	}
+	for i, id := range s.signals.Popular {
+		if p, ok := byID[id]; ok && len(p.Categories) > 0 {
+			consider(id, reasonPopularInCategory, p.Categories[0], 1+1/float64(i+2))
+		}
+	}
+
+	ranked := slices.Collect(maps.Values(candidates))
+	rand.Shuffle(len(ranked), func(i, j int) { ranked[i], ranked[j] = ranked[j], ranked[i] })
+	slices.SortStableFunc(ranked, func(a, b *candidate) int { return cmp.Compare(b.score, a.score) })
+
+	recs := make([]*pb.Recommendation, 0, maxRecommendations)
+	for _, c := range ranked[:min(maxRecommendations, len(ranked))] {
+		recs = append(recs, c.rec)
+	}
+	return recs
 }
@@ -0,0 +1,109 @@
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServerRecoveryElement implements RPC element interface for server-side panic recovery
+type ServerRecoveryElement struct {
+}
+
+type rpcInfoKey struct{}
+
+// rpcInfo identifies the RPC being served, for panic reports.
+type rpcInfo struct {
+	id      uint64
+	service string
+	method  string
+}
+
+// NewServerRecoveryElement creates a new server-side recovery element.
+// aRPC runs elements before and after the handler rather than around it, so
+// the element only records which RPC is in flight; handlers still need to
+// defer Recover to turn a panic into an error.
+func NewServerRecoveryElement() element.RPCElement {
+	return &ServerRecoveryElement{}
+}
+
+func (e *ServerRecoveryElement) Name() string {
+	return "server-recovery"
+}
+
+func (e *ServerRecoveryElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	ctx = context.WithValue(ctx, rpcInfoKey{}, rpcInfo{id: req.ID, service: req.ServiceName, method: req.Method})
+	return req, ctx, nil
+}
+
+func (e *ServerRecoveryElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	return resp, ctx, nil
+}
+
+func (e *ServerRecoveryElement) Close() error {
+	return nil
+}
+
+type failureHooksKey struct{}
+
+// OnFailure returns a context under which f is run by Recover if the
+// handler fails, by returning an error or by panicking. Elements use it to
+// undo what they did in ProcessRequest, since the element chain does not
+// process responses for failed calls.
+func OnFailure(ctx context.Context, f func()) context.Context {
+	hooks, _ := ctx.Value(failureHooksKey{}).([]func())
+	return context.WithValue(ctx, failureHooksKey{}, append(hooks[:len(hooks):len(hooks)], f))
+}
+
+// Recover converts a panic in an RPC handler into an Internal error stored in
+// *err. It must be deferred directly by the handler:
+//
+//	defer recovery.Recover(ctx, &err)
+//
+// The panic value and stack are logged and, if the request is traced, recorded
+// on the server span. The span is finished here since the element chain does
+// not process responses for failed calls. If the handler failed, the hooks
+// registered with OnFailure are run last.
+func Recover(ctx context.Context, err *error) {
+	if p := recover(); p != nil {
+		recoverPanic(ctx, err, p)
+	}
+	if *err == nil {
+		return
+	}
+	hooks, _ := ctx.Value(failureHooksKey{}).([]func())
+	for _, f := range hooks {
+		f()
+	}
+}
+
+// recoverPanic logs the panic p and stores it in *err as an Internal error.
+func recoverPanic(ctx context.Context, err *error, p any) {
+	stack := debug.Stack()
+
+	name := "unknown RPC"
+	if info, ok := ctx.Value(rpcInfoKey{}).(rpcInfo); ok {
+		name = fmt.Sprintf("%s.%s (id %d)", info.service, info.method, info.id)
+	}
+	log.Printf("panic serving %s: %v\n%s", name, p, stack)
+
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		ext.Error.Set(span, true)
+		span.LogFields(
+			otlog.String("event", "panic"),
+			otlog.String("panic", fmt.Sprint(p)),
+			otlog.String("stack", string(stack)),
+		)
+		span.Finish()
+	}
+
+	*err = status.Errorf(codes.Internal, "internal error: %v", p)
+}
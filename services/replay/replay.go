@@ -0,0 +1,195 @@
+// Package replay implements the replay command, which sends calls recorded
+// by the capture element (see package capture) to a server again.
+//
+// Each call is sent with its recorded metadata and its response compared to
+// the recorded one. Before sending, the request is encoded again and
+// compared to its recorded Symphony encoding, which catches serializer
+// changes even when the server accepts both. Responses may legitimately
+// differ, for example in generated IDs, so differences are only reported;
+// the command exits with status 1 if a call fails.
+package replay
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/serializer"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	_ "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/capture"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// Main runs the replay command with the given arguments and returns the
+// exit status.
+func Main(args []string) int {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	var (
+		target  = fs.String("target", config.Get("REPLAY_TARGET"), "aRPC address to send the calls to")
+		service = fs.String("service", "", "replay only the calls to this service")
+		method  = fs.String("method", "", "replay only the calls to this method")
+		speed   = fs.Float64("speed", 0, "replay at this multiple of the recorded pace, 0 to send calls back to back")
+		timeout = fs.Duration("timeout", 5*time.Second, "time limit of a call")
+		verbose = fs.Bool("v", false, "print the responses that differ")
+	)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: replay -target host:port [flags] capture.jsonl...\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if *target == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var records []capture.Record
+	for _, name := range fs.Args() {
+		f, err := os.Open(name)
+		if err != nil {
+			log.Printf("replay: %v", err)
+			return 1
+		}
+		recs, err := capture.Read(f)
+		f.Close()
+		if err != nil {
+			log.Printf("replay: %s: %v", name, err)
+			return 1
+		}
+		records = append(records, recs...)
+	}
+
+	client, err := rpc.NewClient(&serializer.SymphonySerializer{}, *target, nil)
+	if err != nil {
+		log.Printf("replay: cannot connect to %s: %v", *target, err)
+		return 1
+	}
+
+	var sent, failed, wireDiffs, respDiffs int
+	var last time.Time
+	for _, rec := range records {
+		if (*service != "" && rec.Service != *service) || (*method != "" && rec.Method != *method) {
+			continue
+		}
+		if *speed > 0 && !last.IsZero() {
+			time.Sleep(time.Duration(float64(rec.Time.Sub(last)) / *speed))
+		}
+		last = rec.Time
+		sent++
+
+		res, err := send(client, rec, *timeout)
+		if err != nil {
+			failed++
+			log.Printf("replay: %s.%s at %s: %v", rec.Service, rec.Method, rec.Time.Format(time.RFC3339Nano), err)
+			continue
+		}
+		if res.wireDiffers {
+			wireDiffs++
+			log.Printf("replay: %s.%s at %s: request encodes differently than when captured", rec.Service, rec.Method, rec.Time.Format(time.RFC3339Nano))
+		}
+		if res.response != "" {
+			respDiffs++
+			if *verbose {
+				log.Printf("replay: %s.%s at %s: response differs\n  captured: %s\n  replayed: %s", rec.Service, rec.Method, rec.Time.Format(time.RFC3339Nano), rec.Response, res.response)
+			}
+		}
+	}
+	log.Printf("replay: sent %d calls: %d failed, %d encoded differently, %d with different responses", sent, failed, wireDiffs, respDiffs)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// result is how a replayed call compares to its record. response is the
+// replayed response if it differs from the recorded one.
+type result struct {
+	wireDiffers bool
+	response    string
+}
+
+func send(client *rpc.Client, rec capture.Record, timeout time.Duration) (result, error) {
+	var res result
+	req, err := newMessage(rec.RequestType)
+	if err != nil {
+		return res, err
+	}
+	if err := protojson.Unmarshal(rec.Request, req); err != nil {
+		return res, fmt.Errorf("decoding request: %w", err)
+	}
+	if len(rec.RequestWire) > 0 {
+		wire, err := (&serializer.SymphonySerializer{}).Marshal(req)
+		if err != nil {
+			return res, fmt.Errorf("encoding request: %w", err)
+		}
+		res.wireDiffers = !bytes.Equal(wire, rec.RequestWire)
+	}
+
+	respType, err := responseType(req, rec.Service, rec.Method)
+	if err != nil {
+		return res, err
+	}
+	resp, err := newMessage(respType)
+	if err != nil {
+		return res, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(rec.Metadata))
+	if err := client.Call(ctx, rec.Service, rec.Method, req, resp); err != nil {
+		return res, err
+	}
+
+	if len(rec.Response) > 0 {
+		want, err := newMessage(respType)
+		if err != nil {
+			return res, err
+		}
+		if err := protojson.Unmarshal(rec.Response, want); err != nil {
+			return res, fmt.Errorf("decoding recorded response: %w", err)
+		}
+		if !proto.Equal(capture.Scrub(resp), want) {
+			got, _ := protojson.Marshal(resp)
+			res.response = string(got)
+		}
+	}
+	return res, nil
+}
+
+// responseType returns the name of the response message of the method,
+// looked up in the package of its request.
+func responseType(req proto.Message, service, method string) (string, error) {
+	pkg := req.ProtoReflect().Descriptor().ParentFile().Package()
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(pkg.Append(protoreflect.Name(service)))
+	if err != nil {
+		return "", fmt.Errorf("unknown service %s: %w", service, err)
+	}
+	sd, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return "", fmt.Errorf("%s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return "", fmt.Errorf("unknown method %s.%s", service, method)
+	}
+	return string(md.Output().FullName()), nil
+}
+
+func newMessage(name string) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown message type %s: %w", name, err)
+	}
+	return mt.New().Interface(), nil
+}
@@ -0,0 +1,322 @@
+// Package resolver spreads aRPC calls over the replicas of a service.
+//
+// A target is one of:
+//
+//	host:port                      a single address
+//	host1:port,host2:port          a static list
+//	dns:///name:port               A/AAAA records of name, e.g. a headless service
+//	srv:///_service._proto.name    SRV records of name
+//
+// DNS targets are re-resolved periodically. Replicas that keep failing are
+// ejected for a while, and each caller may be limited to a stable subset of
+// the replicas so that large fleets don't fan out to every backend.
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math/rand"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+)
+
+// LookupFunc returns the current addresses (host:port) of a target.
+type LookupFunc func(ctx context.Context) ([]string, error)
+
+// DialFunc creates a client for a single address. The elements must be
+// appended to the client's own so the pool can observe call outcomes.
+type DialFunc func(addr string, elements ...element.RPCElement) (*rpc.Client, error)
+
+// Config tunes a Pool.
+type Config struct {
+	// RefreshInterval is how often dynamic targets are re-resolved.
+	RefreshInterval time.Duration
+	// SubsetSize caps the number of replicas used; 0 uses all of them.
+	SubsetSize int
+	// MaxFailures is the number of consecutive failed calls that ejects a replica.
+	MaxFailures int
+	// EjectFor is how long an ejected replica is skipped.
+	EjectFor time.Duration
+}
+
+// DefaultConfig is used for settings left at zero.
+var DefaultConfig = Config{
+	RefreshInterval: 30 * time.Second,
+	MaxFailures:     5,
+	EjectFor:        10 * time.Second,
+}
+
+// closeGrace delays closing a removed replica's client so in-flight calls can finish.
+const closeGrace = time.Minute
+
+type endpoint struct {
+	addr   string
+	client *rpc.Client
+
+	mu           sync.Mutex
+	failures     int
+	ejectedUntil time.Time
+}
+
+func (e *endpoint) healthy(now time.Time) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return now.After(e.ejectedUntil)
+}
+
+// Pool holds one client per replica of a target and picks among them round-robin.
+type Pool struct {
+	target string
+	cfg    Config
+	dial   DialFunc
+	lookup LookupFunc
+	subset uint64
+
+	mu        sync.RWMutex
+	endpoints []*endpoint
+	next      atomic.Uint64
+}
+
+// New resolves target and dials every replica in it. Targets that can change
+// are refreshed in the background.
+func New(target string, cfg Config, dial DialFunc) (*Pool, error) {
+	lookup, dynamic, err := parseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithLookup(target, lookup, dynamic, cfg, dial)
+}
+
+// NewWithLookup builds a pool over the addresses returned by lookup. If
+// refresh is set, lookup is called again every cfg.RefreshInterval.
+func NewWithLookup(target string, lookup LookupFunc, refresh bool, cfg Config, dial DialFunc) (*Pool, error) {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = DefaultConfig.RefreshInterval
+	}
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = DefaultConfig.MaxFailures
+	}
+	if cfg.EjectFor <= 0 {
+		cfg.EjectFor = DefaultConfig.EjectFor
+	}
+	p := &Pool{
+		target: target,
+		cfg:    cfg,
+		dial:   dial,
+		lookup: lookup,
+		subset: subsetSeed(),
+	}
+	if err := p.refresh(); err != nil {
+		return nil, err
+	}
+	if refresh {
+		go p.refreshLoop()
+	}
+	return p, nil
+}
+
+// Pick returns the client of the next healthy replica. If every replica is
+// ejected, it falls back to plain round-robin rather than failing the call.
+func (p *Pool) Pick() *rpc.Client {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	n := uint64(len(p.endpoints))
+	start := p.next.Add(1)
+	now := time.Now()
+	for i := uint64(0); i < n; i++ {
+		if e := p.endpoints[(start+i)%n]; e.healthy(now) {
+			return e.client
+		}
+	}
+	return p.endpoints[start%n].client
+}
+
+// Addrs returns the addresses currently in use.
+func (p *Pool) Addrs() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	addrs := make([]string, len(p.endpoints))
+	for i, e := range p.endpoints {
+		addrs[i] = e.addr
+	}
+	return addrs
+}
+
+func (p *Pool) refreshLoop() {
+	for range time.Tick(p.cfg.RefreshInterval) {
+		if err := p.refresh(); err != nil {
+			log.Printf("resolver: keeping previous endpoints for %s: %v", p.target, err)
+		}
+	}
+}
+
+// refresh resolves the target and swaps in the new endpoint set, reusing
+// clients for addresses that are still present.
+func (p *Pool) refresh() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addrs, err := p.lookup(ctx)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", p.target, err)
+	}
+	addrs = p.pickSubset(addrs)
+	if len(addrs) == 0 {
+		return fmt.Errorf("resolve %s: no endpoints", p.target)
+	}
+
+	p.mu.RLock()
+	current := make(map[string]*endpoint, len(p.endpoints))
+	for _, e := range p.endpoints {
+		current[e.addr] = e
+	}
+	p.mu.RUnlock()
+
+	endpoints := make([]*endpoint, 0, len(addrs))
+	changed := false
+	for _, addr := range addrs {
+		if e, ok := current[addr]; ok {
+			endpoints = append(endpoints, e)
+			delete(current, addr)
+			continue
+		}
+		e := &endpoint{addr: addr}
+		e.client, err = p.dial(addr, &healthElement{pool: p, endpoint: e})
+		if err != nil {
+			return fmt.Errorf("dial %s: %w", addr, err)
+		}
+		endpoints = append(endpoints, e)
+		changed = true
+	}
+	if !changed && len(current) == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	p.endpoints = endpoints
+	p.mu.Unlock()
+	log.Printf("resolver: %s -> %v", p.target, addrs)
+
+	for _, e := range current {
+		client := e.client
+		time.AfterFunc(closeGrace, func() { client.Transport().Close() })
+	}
+	return nil
+}
+
+// pickSubset returns a subset of addrs that stays the same for this process
+// as long as the set of replicas does not change.
+func (p *Pool) pickSubset(addrs []string) []string {
+	addrs = slices.Clone(addrs)
+	slices.Sort(addrs)
+	addrs = slices.Compact(addrs)
+	if p.cfg.SubsetSize <= 0 || len(addrs) <= p.cfg.SubsetSize {
+		return addrs
+	}
+	r := rand.New(rand.NewSource(int64(p.subset)))
+	r.Shuffle(len(addrs), func(i, j int) { addrs[i], addrs[j] = addrs[j], addrs[i] })
+	return addrs[:p.cfg.SubsetSize]
+}
+
+// subsetSeed derives the subset from the host name so each replica of the
+// caller gets a different, but stable, slice of the backends.
+func subsetSeed() uint64 {
+	host, _ := os.Hostname()
+	h := fnv.New64a()
+	h.Write([]byte(host))
+	return h.Sum64()
+}
+
+// parseTarget returns how to look up target and whether it can change.
+func parseTarget(target string) (LookupFunc, bool, error) {
+	switch {
+	case strings.HasPrefix(target, "dns:///"):
+		host, port, err := net.SplitHostPort(strings.TrimPrefix(target, "dns:///"))
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid dns target %q: %w", target, err)
+		}
+		return func(ctx context.Context) ([]string, error) {
+			ips, err := net.DefaultResolver.LookupHost(ctx, host)
+			if err != nil {
+				return nil, err
+			}
+			addrs := make([]string, len(ips))
+			for i, ip := range ips {
+				addrs[i] = net.JoinHostPort(ip, port)
+			}
+			return addrs, nil
+		}, true, nil
+
+	case strings.HasPrefix(target, "srv:///"):
+		name := strings.TrimPrefix(target, "srv:///")
+		return func(ctx context.Context) ([]string, error) {
+			_, srvs, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+			if err != nil {
+				return nil, err
+			}
+			addrs := make([]string, len(srvs))
+			for i, srv := range srvs {
+				addrs[i] = net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port)))
+			}
+			return addrs, nil
+		}, true, nil
+
+	default:
+		var addrs []string
+		for _, a := range strings.Split(target, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				addrs = append(addrs, a)
+			}
+		}
+		if len(addrs) == 0 {
+			return nil, false, fmt.Errorf("empty target")
+		}
+		return func(context.Context) ([]string, error) { return addrs, nil }, false, nil
+	}
+}
+
+// healthElement is a client element that records call outcomes for one replica.
+type healthElement struct {
+	pool     *Pool
+	endpoint *endpoint
+}
+
+func (h *healthElement) Name() string {
+	return "resolver-health"
+}
+
+func (h *healthElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	return req, ctx, nil
+}
+
+func (h *healthElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	e := h.endpoint
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if resp.Error == nil {
+		e.failures = 0
+		return resp, ctx, nil
+	}
+	e.failures++
+	if e.failures >= h.pool.cfg.MaxFailures {
+		log.Printf("resolver: ejecting %s for %v after %d failures", e.addr, h.pool.cfg.EjectFor, e.failures)
+		e.ejectedUntil = time.Now().Add(h.pool.cfg.EjectFor)
+		e.failures = 0
+	}
+	return resp, ctx, nil
+}
+
+func (h *healthElement) Close() error {
+	return nil
+}
@@ -0,0 +1,198 @@
+// Package rpclog provides RPC elements that log every call a service makes
+// or serves as one structured line: the method, the peer, how long the call
+// took, its outcome and the size of its payloads.
+//
+// The lines are written through the aRPC logger at the level set by
+// RPC_LOG_LEVEL: "info" (the default), "debug", or "off" to log nothing.
+// Failed calls are logged as warnings whatever the level, unless it is "off".
+//
+// aRPC does not run the server's response elements for calls whose handler
+// failed, so servers only log the calls they served; failures are logged by
+// the client element of the caller.
+//
+// Handlers log what the line of a call cannot tell, such as why it was
+// rejected, with Debug, Info and Warn, which add the method and peer of the
+// call to the fields given. RPC_LOG_LEVEL does not apply to those lines.
+package rpclog
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/logging"
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// peerKey carries the host name of the client, which servers log as the
+// peer of the call.
+const peerKey = "x-arpc-peer"
+
+var level = config.NewValue(func() string {
+	switch l := strings.ToLower(config.Get("RPC_LOG_LEVEL")); l {
+	case "debug", "off":
+		return l
+	default:
+		return "info"
+	}
+})
+
+var hostname, _ = os.Hostname()
+
+// call is what the elements know of a call in flight.
+type call struct {
+	method   string
+	peer     string
+	start    time.Time
+	reqBytes int
+}
+
+type callKey struct{}
+
+func startCall(ctx context.Context, req *element.RPCRequest, peer string) context.Context {
+	return context.WithValue(ctx, callKey{}, &call{
+		method:   req.ServiceName + "." + req.Method,
+		peer:     peer,
+		start:    time.Now(),
+		reqBytes: payloadSize(req.Payload),
+	})
+}
+
+// finishCall logs the call of ctx, if any, as ended with resp.
+func finishCall(ctx context.Context, side string, resp *element.RPCResponse) {
+	c, ok := ctx.Value(callKey{}).(*call)
+	if !ok {
+		return
+	}
+	l := level.Get()
+	if l == "off" {
+		return
+	}
+	fields := []zap.Field{
+		zap.String("side", side),
+		zap.String("method", c.method),
+		zap.String("peer", c.peer),
+		zap.Duration("duration", time.Since(c.start)),
+		zap.Int("request_bytes", c.reqBytes),
+	}
+	if resp.Error != nil {
+		fields = append(fields,
+			zap.String("code", status.Code(resp.Error).String()),
+			zap.Error(resp.Error))
+		logging.Warn("rpc failed", fields...)
+		return
+	}
+	fields = append(fields,
+		zap.String("code", "OK"),
+		zap.Int("response_bytes", payloadSize(resp.Result)))
+	if l == "debug" {
+		logging.Debug("rpc", fields...)
+	} else {
+		logging.Info("rpc", fields...)
+	}
+}
+
+// Debug logs msg from the handler of the call of ctx.
+func Debug(ctx context.Context, msg string, fields ...zap.Field) {
+	logging.Debug(msg, callFields(ctx, fields)...)
+}
+
+// Info logs msg from the handler of the call of ctx.
+func Info(ctx context.Context, msg string, fields ...zap.Field) {
+	logging.Info(msg, callFields(ctx, fields)...)
+}
+
+// Warn logs msg from the handler of the call of ctx, for a failure.
+func Warn(ctx context.Context, msg string, fields ...zap.Field) {
+	logging.Warn(msg, callFields(ctx, fields)...)
+}
+
+// callFields returns fields followed by the method and peer of the call of
+// ctx, if any.
+func callFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	if c, ok := ctx.Value(callKey{}).(*call); ok {
+		fields = append(fields, zap.String("method", c.method), zap.String("peer", c.peer))
+	}
+	return fields
+}
+
+// payloadSize returns the size of the protobuf encoding of a payload. The
+// codec actually used may encode it in more or fewer bytes.
+func payloadSize(payload any) int {
+	if m, ok := payload.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
+
+// ClientElement logs the calls of a client.
+type ClientElement struct {
+	addr string
+}
+
+// NewClientElement returns an element logging the calls of a client of the
+// server at addr.
+func NewClientElement(addr string) *ClientElement {
+	return &ClientElement{addr: addr}
+}
+
+func (e *ClientElement) Name() string {
+	return "client-log"
+}
+
+func (e *ClientElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	if hostname != "" {
+		md := metadata.FromOutgoingContext(ctx)
+		if md == nil {
+			md = metadata.New(map[string]string{})
+		}
+		md.Set(peerKey, hostname)
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	return req, startCall(ctx, req, e.addr), nil
+}
+
+func (e *ClientElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	finishCall(ctx, "client", resp)
+	return resp, ctx, nil
+}
+
+func (e *ClientElement) Close() error {
+	return nil
+}
+
+// ServerElement logs the calls a server serves.
+type ServerElement struct{}
+
+// NewServerElement returns an element logging the calls a server serves.
+func NewServerElement() *ServerElement {
+	return &ServerElement{}
+}
+
+func (e *ServerElement) Name() string {
+	return "server-log"
+}
+
+func (e *ServerElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	peer := metadata.FromIncomingContext(ctx).Get(peerKey)
+	if peer == "" {
+		peer = "unknown"
+	}
+	return req, startCall(ctx, req, peer), nil
+}
+
+func (e *ServerElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	finishCall(ctx, "server", resp)
+	return resp, ctx, nil
+}
+
+func (e *ServerElement) Close() error {
+	return nil
+}
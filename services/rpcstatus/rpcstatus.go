@@ -0,0 +1,73 @@
+// Package rpcstatus carries gRPC statuses, details included, across aRPC.
+// aRPC hands the client only the text of a failed call's error, so Error
+// appends the encoded status to that text and FromError decodes it back.
+package rpcstatus
+
+import (
+	"encoding/base64"
+	"strings"
+	"unicode"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// marker precedes the encoded status in the text of an error.
+const marker = " grpc-status-details-bin="
+
+// Error returns the error of a call that failed with st. Its text starts
+// with that of st.Err(), so it still reads as usual in logs.
+func Error(st *status.Status) error {
+	data, err := proto.Marshal(st.Proto())
+	if err != nil {
+		return st.Err()
+	}
+	// A failed call, rather than an unexpected error, to the aRPC server.
+	return &rpc.RPCError{
+		Type:   rpc.RPCFailError,
+		Reason: st.Err().Error() + marker + base64.RawStdEncoding.EncodeToString(data),
+	}
+}
+
+// Convert returns the error of a call whose handler failed with err. An err
+// that carries a status, such as one with a GRPCStatus method, is returned
+// as Error does; others are returned as they are.
+func Convert(err error) error {
+	if st, ok := status.FromError(err); ok && st.Code() != codes.OK {
+		return Error(st)
+	}
+	return err
+}
+
+// FromError returns the status err was built from by Error, or the status
+// err carries itself. Errors that wrap the text of one returned by Error,
+// as the frontend's do, are decoded too.
+func FromError(err error) (*status.Status, bool) {
+	if err == nil {
+		return nil, false
+	}
+	text := err.Error()
+	i := strings.LastIndex(text, marker)
+	if i < 0 {
+		if st, ok := status.FromError(err); ok {
+			return st, true
+		}
+		return nil, false
+	}
+	enc := text[i+len(marker):]
+	if j := strings.IndexFunc(enc, unicode.IsSpace); j >= 0 {
+		enc = enc[:j]
+	}
+	data, err := base64.RawStdEncoding.DecodeString(enc)
+	if err != nil {
+		return nil, false
+	}
+	var p spb.Status
+	if err := proto.Unmarshal(data, &p); err != nil {
+		return nil, false
+	}
+	return status.FromProto(&p), true
+}
@@ -0,0 +1,47 @@
+package rpcstatus
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFromErrorDecodesError(t *testing.T) {
+	detail := &errdetails.ErrorInfo{Reason: "LIMIT", Metadata: map[string]string{"max": "10"}}
+	st, err := status.New(codes.ResourceExhausted, "limit reached").WithDetails(detail)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, err := range map[string]error{
+		"as returned": Error(st),
+		// Clients wrap the text of what the server returned.
+		"wrapped": fmt.Errorf("failed to add to cart: server error: %v", Error(st)),
+	} {
+		got, ok := FromError(err)
+		if !ok {
+			t.Errorf("%s: no status in %q", name, err)
+			continue
+		}
+		if got.Code() != codes.ResourceExhausted || got.Message() != "limit reached" {
+			t.Errorf("%s: got %v, want %v", name, got, st)
+		}
+		if d := got.Details(); len(d) != 1 || !proto.Equal(d[0].(proto.Message), detail) {
+			t.Errorf("%s: got details %v, want %v", name, d, detail)
+		}
+	}
+}
+
+func TestFromErrorWithoutStatus(t *testing.T) {
+	if _, ok := FromError(fmt.Errorf("connection refused")); ok {
+		t.Error("found a status in a plain error")
+	}
+	st, ok := FromError(status.Error(codes.NotFound, "no such order"))
+	if !ok || st.Code() != codes.NotFound {
+		t.Errorf("got %v, %v for a status error", st, ok)
+	}
+}
@@ -2,33 +2,96 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
 	"math/rand"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
-	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/eventbus"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
 )
 
+// Shipment statuses, in the order a shipment moves through them.
+var shipmentStatuses = []string{
+	"LABEL_CREATED",
+	"PICKED_UP",
+	"IN_TRANSIT",
+	"OUT_FOR_DELIVERY",
+	"DELIVERED",
+}
+
+// shipmentCancelled is the status of a shipment cancelled before pick-up.
+const shipmentCancelled = "CANCELLED"
+
+const (
+	defaultShipmentStepInterval = time.Minute
+	activeShipmentsKey          = "shipments:active"
+)
+
+// shipmentRecord is a shipment along with the private details needed to
+// notify the customer.
+type shipmentRecord struct {
+	Shipment *pb.Shipment `json:"shipment"`
+	Email    string       `json:"email"`
+	Locale   string       `json:"locale"`
+	UserID   string       `json:"user_id"`
+
+	// GiftWrap and Note are for the packers.
+	GiftWrap bool   `json:"gift_wrap,omitempty"`
+	Note     string `json:"note,omitempty"`
+
+	// DeliveryWindow is when the shopper asked for delivery.
+	DeliveryWindow *pb.DeliveryWindow `json:"delivery_window,omitempty"`
+
+	// Items are what the shipment holds, given back to the stock of its
+	// warehouse if it is cancelled.
+	Items []*pb.CartItem `json:"items,omitempty"`
+}
+
 // NewShippingService returns a new server for the ShippingService
 func NewShippingService(port int) *ShippingService {
-	return &ShippingService{
-		name: "shipping-service",
-		port: port,
+	svc := &ShippingService{
+		name:         "shipping-service",
+		port:         port,
+		stepInterval: defaultShipmentStepInterval,
+	}
+	if v := config.Get("SHIPMENT_STEP_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			svc.stepInterval = d
+		}
 	}
+	return svc
 }
 
 // ShippingService implements the ShippingService
 type ShippingService struct {
 	name string
 	port int
+
+	rdb redis.UniversalClient // Order ID -> tracking ID, and shipment records
+
+	eventBusAddr string
+	bus          *eventbus.Bus
+
+	// How long a shipment stays in each status before the simulated carrier
+	// advances it.
+	stepInterval time.Duration
 }
 
 // Run starts the server
@@ -38,8 +101,14 @@ func (s *ShippingService) Run() error {
 		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
 	}
 
-	serializer := &serializer.SymphonySerializer{}
-	rpcElements := []element.RPCElement{tracing.NewServerTracingElement()}
+	s.rdb = newRedisClient("SHIPPING")
+
+	mustMapEnv(&s.eventBusAddr, "EVENT_BUS_ADDR")
+	s.bus = eventbus.New(s.eventBusAddr)
+	go s.advanceShipments()
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
 	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
 	if err != nil {
 		log.Fatalf("Failed to start aRPC server: %v", err)
@@ -52,18 +121,22 @@ func (s *ShippingService) Run() error {
 }
 
 // GetQuote calculates a shipping quote for a given address and items
-func (s *ShippingService) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.GetQuoteResponse, context.Context, error) {
-	log.Printf("GetQuote request received for address: %v, %v, %v, %v, %v",
-		req.GetAddress().GetStreetAddress(),
-		req.GetAddress().GetCity(),
-		req.GetAddress().GetState(),
-		req.GetAddress().GetCountry(),
-		req.GetAddress().GetZipCode())
+func (s *ShippingService) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (_ *pb.GetQuoteResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
 	log.Printf("Calculating quote for %d items", len(req.GetItems()))
 
-	// Generate a quote based on item count
-	quote := createQuoteFromCount(len(req.GetItems()))
+	// Price the order from the closest warehouse that has it in stock.
+	origin, zone := s.chooseWarehouse(ctx, req.GetAddress(), req.GetItems())
+	quote := createQuote(zone, req.GetItems())
+
+	remaining, free := freeShippingRemaining(req.GetSubtotal())
+	if tracing.Baggage(ctx, tracing.BaggageUserTier) == userTierPremium {
+		remaining, free = nil, true
+	}
+	if free {
+		quote.Dollars, quote.Cents = 0, 0
+	}
 
 	response := &pb.GetQuoteResponse{
 		CostUsd: &pb.Money{
@@ -71,53 +144,332 @@ func (s *ShippingService) GetQuote(ctx context.Context, req *pb.GetQuoteRequest)
 			Units:        int64(quote.Dollars),
 			Nanos:        int32(quote.Cents * 10000000),
 		},
+		FreeShipping:          free,
+		FreeShippingRemaining: remaining,
+	}
+	if origin != nil {
+		response.Origin = origin.proto()
 	}
 
 	return response, ctx, nil
 }
 
-// ShipOrder processes a shipping order and returns a tracking ID
-func (s *ShippingService) ShipOrder(ctx context.Context, req *pb.ShipOrderRequest) (*pb.ShipOrderResponse, context.Context, error) {
-	log.Printf("ShipOrder request received for address: %v, %v, %v, %v, %v",
-		req.GetAddress().GetStreetAddress(),
-		req.GetAddress().GetCity(),
-		req.GetAddress().GetState(),
-		req.GetAddress().GetCountry(),
-		req.GetAddress().GetZipCode())
+// ShipOrder processes a shipping order and returns a tracking ID. Calls that
+// repeat an order ID, and part for split orders, get the tracking ID of the
+// first shipment.
+func (s *ShippingService) ShipOrder(ctx context.Context, req *pb.ShipOrderRequest) (_ *pb.ShipOrderResponse, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
 
 	log.Printf("Shipping %d items", len(req.GetItems()))
+	if req.GetGiftWrap() {
+		log.Printf("Gift-wrapping order %v", req.GetOrderId())
+	}
+	if w := req.GetDeliveryWindow(); w != nil {
+		log.Printf("Order %v to be delivered between %v and %v", req.GetOrderId(), w.GetStartDate(), w.GetEndDate())
+	}
 
 	// Generate tracking ID
 	baseAddress := fmt.Sprintf("%s, %s, %s", req.GetAddress().GetStreetAddress(), req.GetAddress().GetCity(), req.GetAddress().GetState())
 	trackingID := createTrackingID(baseAddress)
 
+	if orderID := req.GetOrderId(); orderID != "" {
+		key := tenant.Key(ctx, shipmentKey(orderID, req.GetPart()))
+		created, err := s.rdb.SetNX(ctx, key, trackingID, 0).Result()
+		if err != nil {
+			log.Printf("Failed to record shipment for order_id = %v: %v", orderID, err)
+			return nil, ctx, err
+		}
+		if !created {
+			trackingID, err = s.rdb.Get(ctx, key).Result()
+			if err != nil {
+				log.Printf("Failed to fetch shipment for order_id = %v: %v", orderID, err)
+				return nil, ctx, err
+			}
+			log.Printf("Order %v already shipped, reusing tracking ID: %v", orderID, trackingID)
+			response := &pb.ShipOrderResponse{TrackingId: trackingID}
+			if rec, err := s.loadShipment(ctx, trackingID); err == nil {
+				response.Origin = rec.Shipment.GetOrigin()
+			}
+			return response, ctx, nil
+		}
+	}
+
+	var origin *warehouse
+	if id := req.GetWarehouseId(); id != "" {
+		origin = findWarehouse(id)
+		if origin == nil {
+			log.Printf("Unknown warehouse %v for order_id = %v, choosing another", id, req.GetOrderId())
+		}
+	}
+	if origin == nil {
+		origin, _ = s.chooseWarehouse(ctx, req.GetAddress(), req.GetItems())
+	}
+
+	now := time.Now().Unix()
+	rec := &shipmentRecord{
+		Shipment: &pb.Shipment{
+			TrackingId: trackingID,
+			OrderId:    req.GetOrderId(),
+			Status:     shipmentStatuses[0],
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		},
+		Email:          req.GetEmail(),
+		Locale:         req.GetLocale(),
+		UserID:         usercontext.UserID(ctx, req.GetUserId()),
+		GiftWrap:       req.GetGiftWrap(),
+		Note:           req.GetNote(),
+		DeliveryWindow: req.GetDeliveryWindow(),
+		Items:          req.GetItems(),
+	}
+	if origin != nil {
+		rec.Shipment.Origin = origin.proto()
+	}
+	if err := s.saveShipment(ctx, rec); err != nil {
+		log.Printf("Failed to save shipment %v: %v", trackingID, err)
+		return nil, ctx, err
+	}
+	if err := s.rdb.SAdd(ctx, tenant.Key(ctx, activeShipmentsKey), trackingID).Err(); err != nil {
+		log.Printf("Failed to track shipment %v: %v", trackingID, err)
+		return nil, ctx, err
+	}
+	s.publishStatusChange(ctx, rec, "")
+
 	response := &pb.ShipOrderResponse{
 		TrackingId: trackingID,
 	}
+	if origin != nil {
+		s.reserveStock(ctx, origin, req.GetItems())
+		response.Origin = rec.Shipment.GetOrigin()
+	}
 
-	log.Printf("Order shipped with tracking ID: %v", trackingID)
+	log.Printf("Order shipped with tracking ID: %v from warehouse: %v", trackingID, response.GetOrigin().GetId())
 
 	return response, ctx, nil
 }
 
-// Quote represents a currency value.
-type quote struct {
-	Dollars uint32
-	Cents   uint32
+// PlanShipments groups the items of an order into the shipments they would
+// leave in (see planShipments).
+func (s *ShippingService) PlanShipments(ctx context.Context, req *pb.PlanShipmentsRequest) (_ *pb.ShipmentGroups, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	groups := s.planShipments(ctx, req.GetAddress(), req.GetItems())
+	log.Printf("PlanShipments: %d items ship in %d shipment(s)", len(req.GetItems()), len(groups))
+	return &pb.ShipmentGroups{Groups: groups}, ctx, nil
+}
+
+// GetDeliveryOptions returns the delivery windows a shopper can choose from
+// for the items.
+func (s *ShippingService) GetDeliveryOptions(ctx context.Context, req *pb.GetDeliveryOptionsRequest) (_ *pb.DeliveryOptions, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	windows := s.deliveryOptions(ctx, req.GetAddress(), req.GetItems(), time.Now())
+	log.Printf("GetDeliveryOptions: earliest delivery on %v", windows[0].GetStartDate())
+	return &pb.DeliveryOptions{Windows: windows}, ctx, nil
 }
 
-// createQuoteFromCount generates a shipping quote based on item count.
-func createQuoteFromCount(_ int) quote {
-	return createQuoteFromFloat(8.99) // Example static rate
+// GetShipment returns the current status of a shipment of the shopper.
+// Other shoppers' shipments are not found.
+func (s *ShippingService) GetShipment(ctx context.Context, req *pb.GetShipmentRequest) (_ *pb.Shipment, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.FromContext(ctx)
+	rec, err := s.loadShipment(ctx, req.GetTrackingId())
+	if err == redis.Nil || (err == nil && (userID == "" || rec.UserID != userID)) {
+		return nil, ctx, status.Errorf(codes.NotFound, "no shipment with tracking ID %s", req.GetTrackingId())
+	} else if err != nil {
+		log.Printf("Failed to fetch shipment %v: %v", req.GetTrackingId(), err)
+		return nil, ctx, err
+	}
+	return rec.Shipment, ctx, nil
 }
 
-// createQuoteFromFloat generates a quote from a float value.
-func createQuoteFromFloat(value float64) quote {
-	units, fraction := math.Modf(value)
-	return quote{
-		uint32(units),
-		uint32(math.Trunc(fraction * 100)),
+// CancelShipment cancels a shipment the carrier has not picked up yet and
+// gives its items back to the stock of its warehouse. A cancelled shipment
+// is returned as is.
+func (s *ShippingService) CancelShipment(ctx context.Context, req *pb.CancelShipmentRequest) (_ *pb.Shipment, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	rec, err := s.loadShipment(ctx, req.GetTrackingId())
+	if err == redis.Nil {
+		return nil, ctx, status.Errorf(codes.NotFound, "no shipment with tracking ID %s", req.GetTrackingId())
+	} else if err != nil {
+		log.Printf("Failed to fetch shipment %v: %v", req.GetTrackingId(), err)
+		return nil, ctx, err
+	}
+	previous := rec.Shipment.Status
+	switch previous {
+	case shipmentCancelled:
+		return rec.Shipment, ctx, nil
+	case shipmentStatuses[0]:
+	default:
+		return nil, ctx, status.Errorf(codes.FailedPrecondition, "shipment %s is %s already", req.GetTrackingId(), previous)
+	}
+
+	rec.Shipment.Status = shipmentCancelled
+	rec.Shipment.UpdatedAt = time.Now().Unix()
+	if err := s.saveShipment(ctx, rec); err != nil {
+		return nil, ctx, err
 	}
+	if err := s.rdb.SRem(ctx, tenant.Key(ctx, activeShipmentsKey), req.GetTrackingId()).Err(); err != nil {
+		log.Printf("Failed to stop tracking shipment %v: %v", req.GetTrackingId(), err)
+	}
+	if origin := findWarehouse(rec.Shipment.GetOrigin().GetId()); origin != nil {
+		s.releaseStock(ctx, origin, rec.Items)
+	}
+	log.Printf("Shipment %v of order %v cancelled: %v", req.GetTrackingId(), rec.Shipment.OrderId, req.GetReason())
+	s.publishStatusChange(ctx, rec, previous)
+	return rec.Shipment, ctx, nil
+}
+
+// advanceShipments simulates carrier webhooks by moving every active
+// shipment to its next status once it has spent stepInterval in the current one.
+func (s *ShippingService) advanceShipments() {
+	ticker := time.NewTicker(s.stepInterval / 4)
+	defer ticker.Stop()
+	for range ticker.C {
+		// Each tenant keeps its own set of active shipments.
+		for _, t := range append([]string{""}, tenant.IDs()...) {
+			ctx := tenant.NewContext(context.Background(), t)
+			ids, err := s.rdb.SMembers(ctx, tenant.Key(ctx, activeShipmentsKey)).Result()
+			if err != nil {
+				log.Printf("Failed to list active shipments: %v", err)
+				continue
+			}
+			for _, id := range ids {
+				if err := s.advanceShipment(ctx, id); err != nil {
+					log.Printf("Failed to advance shipment %v: %v", id, err)
+				}
+			}
+		}
+	}
+}
+
+// advanceShipment moves a single shipment forward if it is due.
+func (s *ShippingService) advanceShipment(ctx context.Context, trackingID string) error {
+	rec, err := s.loadShipment(ctx, trackingID)
+	if err == redis.Nil {
+		return s.rdb.SRem(ctx, tenant.Key(ctx, activeShipmentsKey), trackingID).Err()
+	} else if err != nil {
+		return err
+	}
+	now := time.Now()
+	if now.Sub(time.Unix(rec.Shipment.UpdatedAt, 0)) < s.stepInterval {
+		return nil
+	}
+
+	previous := rec.Shipment.Status
+	next := len(shipmentStatuses) - 1
+	for i, status := range shipmentStatuses[:next] {
+		if status == previous {
+			next = i + 1
+			break
+		}
+	}
+	rec.Shipment.Status = shipmentStatuses[next]
+	rec.Shipment.UpdatedAt = now.Unix()
+	if err := s.saveShipment(ctx, rec); err != nil {
+		return err
+	}
+	log.Printf("Shipment %v: %v -> %v", trackingID, previous, rec.Shipment.Status)
+	s.publishStatusChange(ctx, rec, previous)
+
+	if next == len(shipmentStatuses)-1 {
+		return s.rdb.SRem(ctx, tenant.Key(ctx, activeShipmentsKey), trackingID).Err()
+	}
+	return nil
+}
+
+// publishStatusChange announces a status change on the event bus. Failures
+// are logged, since the shipment itself has already been updated.
+func (s *ShippingService) publishStatusChange(ctx context.Context, rec *shipmentRecord, previous string) {
+	err := s.bus.Publish(ctx, eventbus.TopicShipmentStatusChanged, &pb.ShipmentStatusChanged{
+		Shipment:       rec.Shipment,
+		PreviousStatus: previous,
+		Email:          rec.Email,
+		Locale:         rec.Locale,
+		UserId:         rec.UserID,
+		Tenant:         tenant.FromContext(ctx),
+	})
+	if err != nil {
+		log.Printf("Failed to publish status change for shipment %v: %v", rec.Shipment.TrackingId, err)
+	}
+}
+
+func (s *ShippingService) saveShipment(ctx context.Context, rec *shipmentRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.rdb.Set(ctx, tenant.Key(ctx, trackingKey(rec.Shipment.TrackingId)), data, 0).Err()
+}
+
+// loadShipment reads a shipment record. It returns redis.Nil if there is none.
+func (s *ShippingService) loadShipment(ctx context.Context, trackingID string) (*shipmentRecord, error) {
+	data, err := s.rdb.Get(ctx, tenant.Key(ctx, trackingKey(trackingID))).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var rec shipmentRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func shipmentKey(orderID string, part int32) string {
+	if part > 0 {
+		return fmt.Sprintf("shipment:%s:%d", orderID, part)
+	}
+	return "shipment:" + orderID
+}
+
+func trackingKey(trackingID string) string {
+	return "tracking:" + trackingID
+}
+
+// freeShippingThresholds maps currency codes to the subtotal at or above which
+// shipping is free, read from FREE_SHIPPING_THRESHOLDS as a list of
+// CODE=AMOUNT pairs, e.g. "USD=75,EUR=70".
+var freeShippingThresholds = config.NewValue(func() map[string]*pb.Money {
+	thresholds := make(map[string]*pb.Money)
+	for _, pair := range envList("FREE_SHIPPING_THRESHOLDS", nil) {
+		code, amount, _ := strings.Cut(pair, "=")
+		code = strings.ToUpper(strings.TrimSpace(code))
+		v, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+		if err != nil || v < 0 {
+			log.Printf("Ignoring invalid free shipping threshold %q", pair)
+			continue
+		}
+		cents := int64(math.Round(v * 100))
+		thresholds[code] = &pb.Money{CurrencyCode: code, Units: cents / 100, Nanos: int32(cents%100) * 10000000}
+	}
+	return thresholds
+})
+
+// freeShippingRemaining reports whether subtotal qualifies for free shipping
+// and, if not, how much more would. It returns nil when the subtotal's
+// currency has no threshold.
+func freeShippingRemaining(subtotal *pb.Money) (*pb.Money, bool) {
+	threshold, ok := freeShippingThresholds.Get()[subtotal.GetCurrencyCode()]
+	if !ok {
+		return nil, false
+	}
+	negated := Negate(subtotal)
+	remaining, err := Sum(threshold, &negated)
+	if err != nil {
+		return nil, false
+	}
+	if !IsPositive(remaining) {
+		return nil, true
+	}
+	return remaining, false
+}
+
+// Quote represents a currency value.
+type quote struct {
+	Dollars uint32
+	Cents   uint32
 }
 
 // createTrackingID generates a tracking ID.
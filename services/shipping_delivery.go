@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// deliveryDateLayout is how the dates of a delivery window are written.
+const deliveryDateLayout = "2006-01-02"
+
+// Days in transit by zone, counted from the day after the order.
+var zoneTransitDays = [...]int{
+	zoneRegional:      1,
+	zoneDomestic:      3,
+	zoneNearby:        5,
+	zoneInternational: 8,
+}
+
+// backorderDays is added to the transit time of orders that no warehouse has
+// in stock.
+const backorderDays = 7
+
+// deliveryWindows is how many windows a shopper is offered, from
+// DELIVERY_WINDOWS, and deliveryWindowDays how many days each spans, from
+// DELIVERY_WINDOW_DAYS.
+var (
+	deliveryWindows = config.NewValue(func() int {
+		return max(envInt("DELIVERY_WINDOWS", 4), 1)
+	})
+	deliveryWindowDays = config.NewValue(func() int {
+		return max(envInt("DELIVERY_WINDOW_DAYS", 2), 1)
+	})
+)
+
+// deliveryOptions returns the windows items can be delivered in to addr for
+// an order placed at now. The first starts on the earliest delivery date and
+// the others follow it back to back.
+func (s *ShippingService) deliveryOptions(ctx context.Context, addr *pb.Address, items []*pb.CartItem, now time.Time) []*pb.DeliveryWindow {
+	w, zone := s.chooseWarehouse(ctx, addr, items)
+	days := zoneTransitDays[zone]
+	if w != nil && !s.inStock(ctx, w, items) {
+		days += backorderDays
+	}
+
+	n, span := deliveryWindows.Get(), deliveryWindowDays.Get()
+	start := now.UTC().Truncate(24*time.Hour).AddDate(0, 0, days)
+	windows := make([]*pb.DeliveryWindow, n)
+	for i := range windows {
+		windows[i] = &pb.DeliveryWindow{
+			StartDate: start.Format(deliveryDateLayout),
+			EndDate:   start.AddDate(0, 0, span-1).Format(deliveryDateLayout),
+		}
+		start = start.AddDate(0, 0, span)
+	}
+	return windows
+}
+
+// checkDeliveryWindow returns an error unless w falls within the windows
+// offered, from the start of the first to the end of the last. Windows are
+// offered before the address is known, so the one chosen need not be among
+// those offered for the address as long as it can still be met.
+func checkDeliveryWindow(w *pb.DeliveryWindow, offered []*pb.DeliveryWindow) error {
+	start, err := time.Parse(deliveryDateLayout, w.GetStartDate())
+	if err != nil {
+		return fmt.Errorf("invalid delivery window start %q", w.GetStartDate())
+	}
+	end, err := time.Parse(deliveryDateLayout, w.GetEndDate())
+	if err != nil {
+		return fmt.Errorf("invalid delivery window end %q", w.GetEndDate())
+	}
+	if end.Before(start) {
+		return errors.New("delivery window ends before it starts")
+	}
+	if len(offered) == 0 {
+		return errors.New("no delivery windows are offered")
+	}
+	first, _ := time.Parse(deliveryDateLayout, offered[0].GetStartDate())
+	last, _ := time.Parse(deliveryDateLayout, offered[len(offered)-1].GetEndDate())
+	if start.Before(first) || end.After(last) {
+		return fmt.Errorf("%s: %s to %s, the earliest is %s",
+			errDeliveryWindowUnavailable, w.GetStartDate(), w.GetEndDate(), offered[0].GetStartDate())
+	}
+	return nil
+}
+
+// errDeliveryWindowUnavailable is the error of an order for a delivery window
+// that can no longer be met, recognized by IsDeliveryWindowUnavailable.
+const errDeliveryWindowUnavailable = "delivery window not available"
+
+// IsDeliveryWindowUnavailable reports whether err is the error of PlaceOrder
+// for a delivery window that can no longer be met.
+func IsDeliveryWindowUnavailable(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errDeliveryWindowUnavailable)
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// Shipping zones, from the closest to the farthest. A warehouse ships within
+// its own region, to the rest of its country, to nearby countries or abroad.
+const (
+	zoneRegional = iota
+	zoneDomestic
+	zoneNearby
+	zoneInternational
+)
+
+// Base shipping rates in USD by zone, for a single item.
+var zoneRates = [...]float64{
+	zoneRegional:      5.99,
+	zoneDomestic:      8.99,
+	zoneNearby:        14.99,
+	zoneInternational: 24.99,
+}
+
+// extraItemRate is added to the base rate for every item after the first.
+const extraItemRate = 0.99
+
+// warehouse is a fulfillment location and the stock it started with.
+type warehouse struct {
+	ID      string      `json:"id"`
+	Name    string      `json:"name"`
+	Address *pb.Address `json:"address"`
+	// Regions are the states the warehouse serves at the regional rate.
+	// Empty means its whole country.
+	Regions []string `json:"regions"`
+	// Nearby are the countries served at the nearby rate.
+	Nearby []string `json:"nearby"`
+	// Stock holds the units on hand by product ID when the data was loaded.
+	Stock map[string]int `json:"stock"`
+}
+
+// proto returns the warehouse as exposed in responses.
+func (w *warehouse) proto() *pb.Warehouse {
+	return &pb.Warehouse{Id: w.ID, Name: w.Name, Address: w.Address}
+}
+
+// zone returns how far addr is from the warehouse.
+func (w *warehouse) zone(addr *pb.Address) int {
+	country := addr.GetCountry()
+	switch {
+	case country == "":
+		// Estimates made before an address is known are priced as domestic.
+		return zoneDomestic
+	case !strings.EqualFold(country, w.Address.GetCountry()):
+		if slices.ContainsFunc(w.Nearby, func(c string) bool { return strings.EqualFold(c, country) }) {
+			return zoneNearby
+		}
+		return zoneInternational
+	case len(w.Regions) == 0:
+		return zoneRegional
+	case slices.ContainsFunc(w.Regions, func(r string) bool { return strings.EqualFold(r, addr.GetState()) }):
+		return zoneRegional
+	}
+	return zoneDomestic
+}
+
+// warehouses are read from data/warehouses.json and reloaded with the
+// configuration. A file that cannot be read leaves no warehouses, and orders
+// then ship from nowhere in particular at the domestic rate.
+var warehouses = config.NewValue(func() []*warehouse {
+	data, err := os.ReadFile("data/warehouses.json")
+	var ws []*warehouse
+	if err == nil {
+		err = json.Unmarshal(data, &ws)
+	}
+	if err != nil {
+		log.Printf("Failed to load warehouses: %v", err)
+		return nil
+	}
+	return ws
+})
+
+// findWarehouse returns the warehouse with the given ID, or nil.
+func findWarehouse(id string) *warehouse {
+	for _, w := range warehouses.Get() {
+		if w.ID == id {
+			return w
+		}
+	}
+	return nil
+}
+
+// warehouseShippedKey is the Redis hash counting the units a warehouse has
+// shipped by product ID. Stock is shared by every tenant.
+func warehouseShippedKey(id string) string {
+	return "warehouse-shipped:" + id
+}
+
+// chooseWarehouse returns the closest warehouse that can ship every item to
+// addr, along with its zone. If none has everything in stock, the closest
+// one is used and the order is backordered there. It returns nil if there
+// are no warehouses.
+func (s *ShippingService) chooseWarehouse(ctx context.Context, addr *pb.Address, items []*pb.CartItem) (*warehouse, int) {
+	var best, fallback *warehouse
+	bestZone, fallbackZone := zoneInternational+1, zoneInternational+1
+	for _, w := range warehouses.Get() {
+		zone := w.zone(addr)
+		if zone < fallbackZone {
+			fallback, fallbackZone = w, zone
+		}
+		if zone < bestZone && s.inStock(ctx, w, items) {
+			best, bestZone = w, zone
+		}
+	}
+	switch {
+	case best != nil:
+		return best, bestZone
+	case fallback != nil:
+		log.Printf("No warehouse has every item in stock, backordering from %v", fallback.ID)
+		return fallback, fallbackZone
+	}
+	return nil, zoneDomestic
+}
+
+// inStock reports whether w still has enough units of every item. If the
+// shipped counts cannot be read, the starting stock is assumed.
+func (s *ShippingService) inStock(ctx context.Context, w *warehouse, items []*pb.CartItem) bool {
+	if len(items) == 0 {
+		return true
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.GetProductId()
+	}
+	shipped, err := s.rdb.HMGet(ctx, warehouseShippedKey(w.ID), ids...).Result()
+	if err != nil {
+		log.Printf("Failed to read stock of warehouse %v: %v", w.ID, err)
+		shipped = make([]any, len(ids))
+	}
+	for i, item := range items {
+		var n int
+		if v, _ := shipped[i].(string); v != "" {
+			n, _ = strconv.Atoi(v)
+		}
+		if w.Stock[item.GetProductId()]-n < int(item.GetQuantity()) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitShipments is whether orders that no single warehouse has in stock are
+// split between warehouses rather than backordered, from SPLIT_SHIPMENTS.
+var splitShipments = config.NewValue(func() bool {
+	return strings.ToLower(config.Get("SPLIT_SHIPMENTS")) == "true"
+})
+
+// shipmentMaxUnits caps the units in one shipment, from SHIPMENT_MAX_UNITS.
+// Products carry no weights, so the cap stands in for a weight limit. Zero
+// means no cap.
+var shipmentMaxUnits = config.NewValue(func() int {
+	return envInt("SHIPMENT_MAX_UNITS", 0)
+})
+
+// planShipments groups items into the shipments they leave in. Orders ship
+// whole from the warehouse chooseWarehouse picks unless splitShipments is set
+// and it cannot fill them; then each item ships from the closest warehouse
+// that has it, or is backordered at the closest one. Shipments are then cut
+// down to shipmentMaxUnits.
+func (s *ShippingService) planShipments(ctx context.Context, addr *pb.Address, items []*pb.CartItem) []*pb.ShipmentGroup {
+	var groups []*pb.ShipmentGroup
+	w, _ := s.chooseWarehouse(ctx, addr, items)
+	switch {
+	case w == nil:
+		groups = []*pb.ShipmentGroup{{Items: items}}
+	case !splitShipments.Get() || s.inStock(ctx, w, items):
+		groups = []*pb.ShipmentGroup{{WarehouseId: w.ID, Items: items}}
+	default:
+		groups = s.splitByWarehouse(ctx, addr, items)
+	}
+	return splitByUnits(groups, shipmentMaxUnits.Get())
+}
+
+// splitByWarehouse assigns each item to the closest warehouse that has it in
+// stock, counting the units of the same product already assigned there.
+func (s *ShippingService) splitByWarehouse(ctx context.Context, addr *pb.Address, items []*pb.CartItem) []*pb.ShipmentGroup {
+	ws := slices.Clone(warehouses.Get())
+	slices.SortStableFunc(ws, func(a, b *warehouse) int { return a.zone(addr) - b.zone(addr) })
+
+	var groups []*pb.ShipmentGroup
+	byWarehouse := make(map[string]*pb.ShipmentGroup)
+	planned := make(map[string]map[string]int32)
+	for _, item := range items {
+		from := ws[0]
+		for _, w := range ws {
+			need := &pb.CartItem{ProductId: item.GetProductId(), Quantity: planned[w.ID][item.GetProductId()] + item.GetQuantity()}
+			if s.inStock(ctx, w, []*pb.CartItem{need}) {
+				from = w
+				break
+			}
+		}
+		if planned[from.ID] == nil {
+			planned[from.ID] = make(map[string]int32)
+		}
+		planned[from.ID][item.GetProductId()] += item.GetQuantity()
+		g, ok := byWarehouse[from.ID]
+		if !ok {
+			g = &pb.ShipmentGroup{WarehouseId: from.ID}
+			byWarehouse[from.ID] = g
+			groups = append(groups, g)
+		}
+		g.Items = append(g.Items, item)
+	}
+	return groups
+}
+
+// splitByUnits cuts groups into shipments of at most maxUnits units, splitting
+// the quantity of an item if needed. A max of zero leaves groups as they are.
+func splitByUnits(groups []*pb.ShipmentGroup, maxUnits int) []*pb.ShipmentGroup {
+	if maxUnits <= 0 {
+		return groups
+	}
+	var out []*pb.ShipmentGroup
+	for _, g := range groups {
+		cur := &pb.ShipmentGroup{WarehouseId: g.GetWarehouseId()}
+		units := 0
+		for _, item := range g.GetItems() {
+			for left := int(item.GetQuantity()); left > 0; {
+				if units == maxUnits {
+					out = append(out, cur)
+					cur = &pb.ShipmentGroup{WarehouseId: g.GetWarehouseId()}
+					units = 0
+				}
+				n := min(left, maxUnits-units)
+				cur.Items = append(cur.Items, &pb.CartItem{ProductId: item.GetProductId(), VariantId: item.GetVariantId(), Quantity: int32(n)})
+				units += n
+				left -= n
+			}
+		}
+		if len(cur.Items) > 0 {
+			out = append(out, cur)
+		}
+	}
+	return out
+}
+
+// reserveStock counts items as shipped from w. Failures are logged, as the
+// shipment has already been created.
+func (s *ShippingService) reserveStock(ctx context.Context, w *warehouse, items []*pb.CartItem) {
+	pipe := s.rdb.TxPipeline()
+	for _, item := range items {
+		pipe.HIncrBy(ctx, warehouseShippedKey(w.ID), item.GetProductId(), int64(item.GetQuantity()))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to update stock of warehouse %v: %v", w.ID, err)
+	}
+}
+
+// releaseStock gives back to w the items of a shipment that was cancelled.
+// Failures are logged, as the shipment has already been cancelled.
+func (s *ShippingService) releaseStock(ctx context.Context, w *warehouse, items []*pb.CartItem) {
+	pipe := s.rdb.TxPipeline()
+	for _, item := range items {
+		pipe.HIncrBy(ctx, warehouseShippedKey(w.ID), item.GetProductId(), -int64(item.GetQuantity()))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to update stock of warehouse %v: %v", w.ID, err)
+	}
+}
+
+// createQuote prices shipping items to a zone.
+func createQuote(zone int, items []*pb.CartItem) quote {
+	var units int
+	for _, item := range items {
+		units += int(item.GetQuantity())
+	}
+	usd := zoneRates[zone] + extraItemRate*float64(max(units-1, 0))
+	cents := uint32(math.Round(usd * 100))
+	return quote{Dollars: cents / 100, Cents: cents % 100}
+}
@@ -0,0 +1,287 @@
+// Package startup checks, as a service starts, that the services and stores
+// it depends on answer, retrying with backoff until a deadline, and reports
+// how far each check got.
+//
+// In strict mode the service waits for every dependency before serving and
+// gives up when one is still unreachable at the deadline. In lazy mode it
+// serves at once and the checks go on in the background, so the readiness
+// status tells an orchestrator when to send traffic. With checks off, the
+// default, dependencies are only reached on the first call that needs them.
+package startup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/serializer"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+)
+
+// Modes of the checks.
+const (
+	Off    = "off"
+	Strict = "strict"
+	Lazy   = "lazy"
+)
+
+// Config controls the checks.
+type Config struct {
+	// Mode is Off, Strict or Lazy.
+	Mode string
+	// Deadline bounds the time spent checking a dependency.
+	Deadline time.Duration
+	// Timeout bounds one attempt.
+	Timeout time.Duration
+	// MinBackoff and MaxBackoff bound the wait between attempts, which
+	// doubles after each failure.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// Check returns nil once a dependency answers.
+type Check func(ctx context.Context) error
+
+// States of a dependency.
+const (
+	StatePending = "pending"
+	StateReady   = "ready"
+	StateFailed  = "failed"
+)
+
+// Status is how far the check of a dependency got.
+type Status struct {
+	Name      string    `json:"name"`
+	State     string    `json:"state"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	ReadyAt   time.Time `json:"ready_at,omitzero"`
+}
+
+type dependency struct {
+	check  Check
+	status Status
+}
+
+// Checker checks the dependencies of a service.
+type Checker struct {
+	cfg Config
+
+	mu      sync.Mutex
+	deps    []*dependency
+	started time.Time
+}
+
+// New returns a Checker for cfg.
+func New(cfg Config) *Checker {
+	return &Checker{cfg: cfg}
+}
+
+// Add registers a dependency to be checked by Run.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deps = append(c.deps, &dependency{check: check, status: Status{Name: name, State: StatePending}})
+}
+
+// Run checks every dependency concurrently. In strict mode it returns once
+// all of them are ready, or with an error naming those that were not by the
+// deadline. In lazy mode it returns at once.
+func (c *Checker) Run() error {
+	c.mu.Lock()
+	c.started = time.Now()
+	deps := c.deps
+	c.mu.Unlock()
+
+	switch c.cfg.Mode {
+	case Off, "":
+		return nil
+	case Strict, Lazy:
+	default:
+		return fmt.Errorf("startup: unknown mode %q", c.cfg.Mode)
+	}
+
+	var wg sync.WaitGroup
+	for _, d := range deps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.run(d)
+		}()
+	}
+	if c.cfg.Mode == Lazy {
+		return nil
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, s := range c.Status() {
+		if s.State != StateReady {
+			errs = append(errs, fmt.Errorf("%s: %s", s.Name, s.LastError))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("startup: dependencies not ready after %v: %w", c.cfg.Deadline, errors.Join(errs...))
+	}
+	return nil
+}
+
+// run retries the check of d until it succeeds or the deadline passes.
+func (c *Checker) run(d *dependency) {
+	deadline := time.Now().Add(c.cfg.Deadline)
+	backoff := c.cfg.MinBackoff
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), c.cfg.Timeout)
+		err := d.check(ctx)
+		cancel()
+
+		c.mu.Lock()
+		d.status.Attempts++
+		if err == nil {
+			d.status.State = StateReady
+			d.status.LastError = ""
+			d.status.ReadyAt = time.Now()
+			c.mu.Unlock()
+			log.Printf("startup: %s is ready after %d attempt(s)", d.status.Name, d.status.Attempts)
+			return
+		}
+		d.status.LastError = err.Error()
+		if time.Now().Add(backoff).After(deadline) {
+			d.status.State = StateFailed
+			c.mu.Unlock()
+			log.Printf("startup: giving up on %s after %d attempt(s): %v", d.status.Name, d.status.Attempts, err)
+			return
+		}
+		c.mu.Unlock()
+
+		log.Printf("startup: %s is not ready, retrying in %v: %v", d.status.Name, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, c.cfg.MaxBackoff)
+	}
+}
+
+// Ready reports whether the service may take traffic: with checks on, once
+// every dependency is ready.
+func (c *Checker) Ready() bool {
+	if c.cfg.Mode == Off || c.cfg.Mode == "" {
+		return true
+	}
+	for _, s := range c.Status() {
+		if s.State != StateReady {
+			return false
+		}
+	}
+	return true
+}
+
+// Status returns the status of each dependency, in the order they were
+// added.
+func (c *Checker) Status() []Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]Status, len(c.deps))
+	for i, d := range c.deps {
+		out[i] = d.status
+	}
+	return out
+}
+
+// report is the readiness status as served.
+type report struct {
+	Ready        bool     `json:"ready"`
+	Mode         string   `json:"mode"`
+	Elapsed      string   `json:"elapsed"`
+	Dependencies []Status `json:"dependencies"`
+}
+
+func (c *Checker) report() report {
+	c.mu.Lock()
+	started := c.started
+	c.mu.Unlock()
+	mode := c.cfg.Mode
+	if mode == "" {
+		mode = Off
+	}
+	return report{
+		Ready:        c.Ready(),
+		Mode:         mode,
+		Elapsed:      time.Since(started).Round(time.Millisecond).String(),
+		Dependencies: c.Status(),
+	}
+}
+
+// Var returns the readiness status, for publishing with expvar.
+func (c *Checker) Var() any {
+	return c.report()
+}
+
+// ServeHTTP serves the readiness status as JSON, with status 503 until the
+// service is ready.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rep := c.report()
+	w.Header().Set("Content-Type", "application/json")
+	if !rep.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(rep)
+}
+
+// pingService is a service no server registers. Servers answer calls to it
+// with an error, which is enough to tell that they are up.
+const pingService = "startup.Ping"
+
+// ARPC returns a check that an aRPC server answers at one of the addresses
+// returned by addrs. Each attempt uses clients of its own, so that a lost
+// ping cannot take the response of a real call.
+func ARPC(addrs func() []string) Check {
+	return func(ctx context.Context) error {
+		list := addrs()
+		if len(list) == 0 {
+			return errors.New("no addresses")
+		}
+		errs := make(chan error, len(list))
+		for _, addr := range list {
+			go func() {
+				errs <- pingARPC(ctx, addr)
+			}()
+		}
+		var err error
+		for range list {
+			if err = <-errs; err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+}
+
+func pingARPC(ctx context.Context, addr string) error {
+	client, err := rpc.NewClient(&serializer.SymphonySerializer{}, addr, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", addr, err)
+	}
+	// Closing the transport ends a call still waiting for its response.
+	defer client.GetTransport().Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Call(ctx, pingService, "Ping", &pb.Empty{}, &pb.Empty{})
+	}()
+	select {
+	case err := <-done:
+		var rpcErr *rpc.RPCError
+		if err == nil || errors.As(err, &rpcErr) {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", addr, err)
+	case <-ctx.Done():
+		return fmt.Errorf("%s: no answer: %w", addr, ctx.Err())
+	}
+}
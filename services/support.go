@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/appnet-org/arpc/pkg/logging"
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/privacy"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/resolver"
+	"github.com/appnetorg/online-boutique-arpc/services/startup"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+type TooManyTicketsErr struct{}
+
+func (e TooManyTicketsErr) Error() string {
+	return "too many support tickets today"
+}
+
+// ticketOpen is the status of new tickets. The support team closes them,
+// outside of the shop.
+const ticketOpen = "OPEN"
+
+// Limits on the fields of a ticket, in characters.
+const (
+	maxTicketName    = 128
+	maxTicketEmail   = 254
+	maxTicketSubject = 200
+	maxTicketMessage = 5000
+)
+
+// supportTicketTTL is how long a ticket is kept.
+const supportTicketTTL = 180 * 24 * time.Hour
+
+// supportTicketsPerDay is how many tickets a shopper may open a day,
+// SUPPORT_TICKETS_PER_DAY, so that the form cannot flood the support team.
+// Zero lifts the limit.
+var supportTicketsPerDay = config.NewValue(func() int {
+	return envInt("SUPPORT_TICKETS_PER_DAY", 5)
+})
+
+// ticketStats counts the tickets created, those refused for going over the
+// daily limit, and those whose acknowledgement could not be sent.
+var ticketStats = expvar.NewMap("support_tickets")
+
+// NewSupportService returns a new server for the SupportService
+func NewSupportService(port int) *SupportService {
+	return &SupportService{
+		port: port,
+	}
+}
+
+// SupportService implements the SupportService
+type SupportService struct {
+	port int
+
+	rdb redis.UniversalClient // Tickets, by ID and by user
+
+	// Shoppers are emailed that their ticket was received.
+	emailSvcAddr string
+	emailSvcConn *resolver.Pool
+}
+
+// Run starts the server
+func (s *SupportService) Run() error {
+	err := logging.Init(getLoggingConfig())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
+	}
+
+	mapServiceAddr(&s.emailSvcAddr, "EMAIL_SERVICE_ADDR", "email")
+	mustConnARPC(&s.emailSvcConn, s.emailSvcAddr)
+	s.rdb = newRedisClient("SUPPORT")
+	s.rdb.AddHook(tracing.RedisHook{})
+
+	checker := newStartupChecker()
+	checker.Add("email", startup.ARPC(s.emailSvcConn.Addrs))
+	checker.Add("redis", func(ctx context.Context) error {
+		return s.rdb.Ping(ctx).Err()
+	})
+	mustCheckStartup(checker)
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement())
+	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
+	if err != nil {
+		log.Fatalf("Failed to start aRPC server: %v", err)
+	}
+
+	pb.RegisterSupportServiceServer(server, s)
+	pb.RegisterPrivacyServiceServer(server, s)
+	log.Printf("SupportService running at port: %d", s.port)
+	server.Start()
+	return nil
+}
+
+// CreateTicket opens a support ticket and emails the shopper that it was
+// received. The ticket is opened even if the email cannot be sent, which
+// the ticket records.
+func (s *SupportService) CreateTicket(ctx context.Context, req *pb.CreateTicketRequest) (_ *pb.Ticket, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+	// The acknowledgement is sent for the same tenant.
+	ctx = tenant.Forward(ctx)
+
+	userID := usercontext.UserID(ctx, req.UserId)
+	ticket := &pb.Ticket{
+		Id:        uuid.NewString(),
+		UserId:    userID,
+		Name:      strings.TrimSpace(req.GetName()),
+		Email:     strings.TrimSpace(req.GetEmail()),
+		Subject:   strings.TrimSpace(req.GetSubject()),
+		Message:   strings.TrimSpace(req.GetMessage()),
+		OrderId:   strings.TrimSpace(req.GetOrderId()),
+		Locale:    req.GetLocale(),
+		Status:    ticketOpen,
+		CreatedAt: time.Now().Unix(),
+	}
+	if err := validateTicket(ticket); err != nil {
+		return nil, ctx, err
+	}
+	if err := s.takeTicketQuota(ctx, userID); err != nil {
+		return nil, ctx, err
+	}
+
+	if err := s.saveTicket(ctx, ticket); err != nil {
+		log.Printf("Failed to save ticket for user_id = %v: %v", userID, err)
+		return nil, ctx, status.Errorf(codes.Unavailable, "failed to save ticket: %v", err)
+	}
+	ticketStats.Add("created", 1)
+	log.Printf("CreateTicket: Opened ticket %s for user_id = %v", ticket.Id, userID)
+
+	emailClient := pb.NewEmailServiceClient(s.emailSvcConn.Pick())
+	_, err = emailClient.SendTicketAcknowledgement(ctx, &pb.SendTicketAcknowledgementRequest{
+		Ticket:    ticket,
+		DedupeKey: dedupeKey(ticket.Id, emailTicketAcknowledgement),
+	})
+	if err != nil {
+		ticketStats.Add("unacknowledged", 1)
+		log.Printf("Failed to acknowledge ticket %s: %v", ticket.Id, err)
+		return ticket, ctx, nil
+	}
+	ticket.Acknowledged = true
+	if err := s.saveTicket(ctx, ticket); err != nil {
+		log.Printf("Failed to mark ticket %s acknowledged: %v", ticket.Id, err)
+	}
+	return ticket, ctx, nil
+}
+
+// GetTicket returns a ticket of the shopper.
+func (s *SupportService) GetTicket(ctx context.Context, req *pb.GetTicketRequest) (_ *pb.Ticket, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.UserID(ctx, req.UserId)
+	ticket, err := s.loadTicket(ctx, req.GetTicketId())
+	if err == redis.Nil || (err == nil && ticket.UserId != userID) {
+		return nil, ctx, status.Errorf(codes.NotFound, "ticket %s not found", req.GetTicketId())
+	}
+	if err != nil {
+		return nil, ctx, err
+	}
+	return ticket, ctx, nil
+}
+
+// validateTicket returns an InvalidArgument error for the first field of
+// ticket that is missing or too long.
+func validateTicket(ticket *pb.Ticket) error {
+	fields := []struct {
+		name, value string
+		max         int
+	}{
+		{"name", ticket.Name, maxTicketName},
+		{"email", ticket.Email, maxTicketEmail},
+		{"subject", ticket.Subject, maxTicketSubject},
+		{"message", ticket.Message, maxTicketMessage},
+	}
+	for _, f := range fields {
+		if f.value == "" {
+			return status.Errorf(codes.InvalidArgument, "%s is required", f.name)
+		}
+		if utf8.RuneCountInString(f.value) > f.max {
+			return status.Errorf(codes.InvalidArgument, "%s is longer than %d characters", f.name, f.max)
+		}
+	}
+	if !strings.Contains(ticket.Email, "@") {
+		return status.Errorf(codes.InvalidArgument, "invalid email address %q", ticket.Email)
+	}
+	return nil
+}
+
+// takeTicketQuota counts a ticket against the daily limit of userID, or
+// returns a ResourceExhausted error if the limit is reached. The day starts
+// with the first ticket of the shopper.
+func (s *SupportService) takeTicketQuota(ctx context.Context, userID string) error {
+	limit := supportTicketsPerDay.Get()
+	if limit <= 0 {
+		return nil
+	}
+	key := ticketQuotaKey(ctx, userID)
+	n, err := s.rdb.Incr(ctx, key).Result()
+	if err == nil && n == 1 {
+		err = s.rdb.Expire(ctx, key, 24*time.Hour).Err()
+	}
+	if err != nil {
+		// The form stays open if the count cannot be kept.
+		log.Printf("Failed to count tickets of user_id = %v: %v", userID, err)
+		return nil
+	}
+	if n > int64(limit) {
+		ticketStats.Add("refused", 1)
+		return status.Error(codes.ResourceExhausted, TooManyTicketsErr{}.Error())
+	}
+	return nil
+}
+
+func (s *SupportService) saveTicket(ctx context.Context, ticket *pb.Ticket) error {
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return err
+	}
+	pipe := s.rdb.TxPipeline()
+	pipe.Set(ctx, ticketKey(ctx, ticket.Id), data, supportTicketTTL)
+	if ticket.UserId != "" {
+		pipe.SAdd(ctx, userTicketsKey(ctx, ticket.UserId), ticket.Id)
+		pipe.Expire(ctx, userTicketsKey(ctx, ticket.UserId), supportTicketTTL)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// loadTicket returns the ticket with ID id, or redis.Nil if there is none.
+func (s *SupportService) loadTicket(ctx context.Context, id string) (*pb.Ticket, error) {
+	data, err := s.rdb.Get(ctx, ticketKey(ctx, id)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var ticket pb.Ticket
+	if err := json.Unmarshal(data, &ticket); err != nil {
+		return nil, err
+	}
+	return &ticket, nil
+}
+
+// userTickets returns the tickets of userID still kept, oldest first.
+func (s *SupportService) userTickets(ctx context.Context, userID string) ([]*pb.Ticket, error) {
+	ids, err := s.rdb.SMembers(ctx, userTicketsKey(ctx, userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	tickets := make([]*pb.Ticket, 0, len(ids))
+	for _, id := range ids {
+		ticket, err := s.loadTicket(ctx, id)
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, ticket)
+	}
+	slices.SortFunc(tickets, func(a, b *pb.Ticket) int {
+		return int(a.CreatedAt - b.CreatedAt)
+	})
+	return tickets, nil
+}
+
+// ExportUserData returns the tickets of the user.
+func (s *SupportService) ExportUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID, err := privacy.User(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+	tickets, err := s.userTickets(ctx, userID)
+	if err != nil {
+		return nil, ctx, err
+	}
+	rec, err := privacy.Record("support_tickets", len(tickets), tickets, true)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec}}, ctx, nil
+}
+
+// DeleteUserData deletes the tickets of the user.
+func (s *SupportService) DeleteUserData(ctx context.Context, req *pb.UserDataRequest) (_ *pb.UserData, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID, err := privacy.User(ctx)
+	if err != nil {
+		return nil, ctx, err
+	}
+	ids, err := s.rdb.SMembers(ctx, userTicketsKey(ctx, userID)).Result()
+	if err != nil {
+		return nil, ctx, err
+	}
+	keys := []string{userTicketsKey(ctx, userID), ticketQuotaKey(ctx, userID)}
+	for _, id := range ids {
+		keys = append(keys, ticketKey(ctx, id))
+	}
+	if err := s.rdb.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("Failed to delete tickets for user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+	rec, err := privacy.Record("support_tickets", len(ids), nil, false)
+	if err != nil {
+		return nil, ctx, err
+	}
+	return &pb.UserData{Records: []*pb.UserDataRecord{rec}}, ctx, nil
+}
+
+func ticketKey(ctx context.Context, id string) string {
+	return tenant.Key(ctx, "ticket:"+id)
+}
+
+func userTicketsKey(ctx context.Context, userID string) string {
+	return tenant.Key(ctx, "user-tickets:"+userID)
+}
+
+func ticketQuotaKey(ctx context.Context, userID string) string {
+	return tenant.Key(ctx, "ticket-quota:"+userID)
+}
@@ -0,0 +1,145 @@
+// Package tenant partitions one deployment into several storefronts. The
+// frontend picks a tenant from the Host header and passes its ID to the
+// backends as metadata; each backend then narrows the catalog and ads to the
+// tenant's and prefixes its Redis keys with the tenant's.
+//
+// Tenants are defined in the JSON file named by TENANTS_FILE, keyed by ID.
+// Requests for hosts no tenant claims go to DEFAULT_TENANT. The empty ID is
+// the base storefront: the full catalog and ads, and unprefixed keys.
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// MetadataKey carries the tenant ID between services.
+const MetadataKey = "x-shop-tenant"
+
+// Branding values.
+const (
+	BrandingBoutique = "boutique"
+	BrandingCymbal   = "cymbal"
+)
+
+// Tenant describes one storefront.
+type Tenant struct {
+	// Hosts are the Host header values served as this tenant, without port.
+	Hosts []string `json:"hosts"`
+	// Branding selects the storefront look; empty keeps the deployment's.
+	Branding string `json:"branding"`
+	// Products lists the product IDs on sale; empty means all of them.
+	Products []string `json:"products"`
+	// AdCategories lists the ad campaigns that run; empty means all of them.
+	AdCategories []string `json:"ad_categories"`
+	// RedisPrefix is prepended to every Redis key; it defaults to "<id>:".
+	RedisPrefix string `json:"redis_prefix"`
+}
+
+// HasProduct reports whether id is on sale for t. A nil tenant sells
+// everything.
+func (t *Tenant) HasProduct(id string) bool {
+	return t == nil || len(t.Products) == 0 || slices.Contains(t.Products, id)
+}
+
+// HasAdCategory reports whether ads of category run for t. A nil tenant runs
+// every campaign.
+func (t *Tenant) HasAdCategory(category string) bool {
+	return t == nil || len(t.AdCategories) == 0 || slices.Contains(t.AdCategories, category)
+}
+
+var tenants = config.NewValue(func() map[string]*Tenant {
+	name := config.Get("TENANTS_FILE")
+	if name == "" {
+		name = "data/tenants.json"
+	}
+	data, err := os.ReadFile(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	var m map[string]*Tenant
+	if err == nil {
+		err = json.Unmarshal(data, &m)
+	}
+	if err != nil {
+		log.Printf("tenant: ignoring %s: %v", name, err)
+		return nil
+	}
+	return m
+})
+
+// Get returns the tenant with the given ID, or nil for the base storefront
+// and unknown IDs.
+func Get(id string) *Tenant {
+	return tenants.Get()[id]
+}
+
+// IDs returns the IDs of the configured tenants, sorted.
+func IDs() []string {
+	ids := make([]string, 0, len(tenants.Get()))
+	for id := range tenants.Get() {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// ForHost returns the ID of the tenant serving host, which may carry a port.
+func ForHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+	for id, t := range tenants.Get() {
+		if slices.Contains(t.Hosts, host) {
+			return id
+		}
+	}
+	return config.Get("DEFAULT_TENANT")
+}
+
+type ctxKey struct{}
+
+// NewContext returns ctx acting for tenant id: FromContext reports it, and
+// calls made with the context carry it to the callee.
+func NewContext(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, ctxKey{}, id)
+	if id == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+}
+
+// FromContext returns the tenant ctx acts for, set by NewContext or received
+// from the caller.
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(ctxKey{}).(string); ok {
+		return id
+	}
+	return metadata.FromIncomingContext(ctx).Get(MetadataKey)
+}
+
+// Forward returns ctx with the caller's tenant passed on to further calls.
+func Forward(ctx context.Context) context.Context {
+	return NewContext(ctx, FromContext(ctx))
+}
+
+// Key returns the Redis key for key in the tenant ctx acts for.
+func Key(ctx context.Context, key string) string {
+	id := FromContext(ctx)
+	if id == "" {
+		return key
+	}
+	if t := Get(id); t != nil && t.RedisPrefix != "" {
+		return t.RedisPrefix + key
+	}
+	return id + ":" + key
+}
@@ -0,0 +1,386 @@
+// Package testsupport runs the whole shop in-process for end-to-end tests of
+// flows that cross services, such as checkout.
+//
+// Start brings up every service on loopback ports, with the payment service
+// replaced by a stub that approves every charge and records it. Each store
+// and the event bus get an in-memory Redis server of their own, which tests
+// may inspect or make fail through Shop.Redis.
+//
+// The services load their data and templates relative to the working
+// directory, so tests using this package must live in the services
+// directory. They cannot be stopped either: the first Start brings them up
+// for the rest of the test binary and later calls share them.
+package testsupport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services"
+	"github.com/appnetorg/online-boutique-arpc/services/rpcstatus"
+)
+
+// Shop is a running shop.
+type Shop struct {
+	// URL is the base URL of the storefront.
+	URL string
+	// Payments is the stub standing in for the payment service.
+	Payments *StubPayment
+	// Redis holds the Redis server of each store by the prefix of its
+	// variable, such as "CART" for CART_REDIS_ADDR, and that of the event
+	// bus as "EVENT_BUS".
+	Redis map[string]*miniredis.Miniredis
+}
+
+var (
+	startOnce sync.Once
+	shop      *Shop
+	startErr  error
+)
+
+// Start returns the shop, starting it on first use.
+func Start(tb testing.TB) *Shop {
+	tb.Helper()
+	startOnce.Do(func() { shop, startErr = start() })
+	if startErr != nil {
+		tb.Fatalf("testsupport: %v", startErr)
+	}
+	return shop
+}
+
+// stores lists the prefixes of the Redis servers the services use.
+var stores = []string{"CART", "SHIPPING", "AD", "EMAIL", "WALLET", "ANALYTICS", "SUPPORT", "CHECKOUT", "EVENT_BUS"}
+
+// readyTimeout bounds the wait for the shop to come up.
+const readyTimeout = 10 * time.Second
+
+// backends lists the services behind the frontend with the variable holding
+// their address and the constructor of their server. A nil constructor
+// marks the payment service, which is stubbed.
+var backends = []struct {
+	addrKey string
+	newSrv  func(port int) interface{ Run() error }
+}{
+	{"CART_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewCartService(p) }},
+	{"PRODUCT_CATALOG_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewProductCatalogService(p) }},
+	{"CURRENCY_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewCurrencyService(p) }},
+	{"PAYMENT_SERVICE_ADDR", nil},
+	{"SHIPPING_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewShippingService(p) }},
+	{"EMAIL_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewEmailService(p) }},
+	{"CHECKOUT_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewCheckoutService(p) }},
+	{"RECOMMENDATION_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewRecommendationService(p) }},
+	{"AD_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewAdService(p) }},
+	{"ADDRESS_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewAddressService(p) }},
+	{"WALLET_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewWalletService(p) }},
+	{"ANALYTICS_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewAnalyticsService(p) }},
+	{"SUPPORT_SERVICE_ADDR", func(p int) interface{ Run() error } { return services.NewSupportService(p) }},
+}
+
+func start() (*Shop, error) {
+	s := &Shop{
+		Payments: &StubPayment{},
+		Redis:    make(map[string]*miniredis.Miniredis, len(stores)),
+	}
+	for _, prefix := range stores {
+		r, err := miniredis.Run()
+		if err != nil {
+			return nil, err
+		}
+		s.Redis[prefix] = r
+		key := prefix + "_REDIS_ADDR"
+		if prefix == "EVENT_BUS" {
+			key = "EVENT_BUS_ADDR"
+		}
+		os.Setenv(key, r.Addr())
+	}
+	// The assistant is an external HTTP service the frontend only links to.
+	os.Setenv("SHOPPING_ASSISTANT_SERVICE_ADDR", "127.0.0.1:1")
+	// The services come up in any order and report when their dependencies
+	// answer, which the storefront's readiness status sums up.
+	os.Setenv("STARTUP_CHECKS", "lazy")
+	os.Setenv("STARTUP_CHECK_MIN_BACKOFF", "20ms")
+	os.Setenv("STARTUP_CHECK_MAX_BACKOFF", "200ms")
+	// The currency service snapshots its rates as it starts; they are kept
+	// out of the source tree.
+	snapshots, err := os.MkdirTemp("", "rate_snapshots")
+	if err != nil {
+		return nil, err
+	}
+	os.Setenv("CURRENCY_SNAPSHOT_DIR", snapshots)
+
+	ports := make([]int, len(backends))
+	for i, b := range backends {
+		port, err := freeUDPPort()
+		if err != nil {
+			return nil, err
+		}
+		ports[i] = port
+		os.Setenv(b.addrKey, fmt.Sprintf("127.0.0.1:%d", port))
+	}
+	frontendPort, err := freeTCPPort()
+	if err != nil {
+		return nil, err
+	}
+	s.URL = fmt.Sprintf("http://127.0.0.1:%d", frontendPort)
+
+	for i, b := range backends {
+		if b.newSrv == nil {
+			if err := s.Payments.serve(ports[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		go run(b.newSrv(ports[i]))
+	}
+	go run(services.NewFrontendServer(frontendPort))
+
+	if err := waitReady(s.URL, readyTimeout); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func run(srv interface{ Run() error }) {
+	if err := srv.Run(); err != nil {
+		panic(fmt.Sprintf("testsupport: %T stopped: %v", srv, err))
+	}
+}
+
+func freeUDPPort() (int, error) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port, nil
+}
+
+func freeTCPPort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// waitReady polls the storefront's readiness status until every service
+// behind it answers.
+func waitReady(base string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := http.Get(base + "/_ready")
+		if err == nil {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			err = fmt.Errorf("readiness: %s: %s", resp.Status, body)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("storefront not ready: %w", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// StubPayment is a payment service that approves every charge, except those
+// of a card number it was told to decline, and remembers them and the
+// refunds asked of it.
+type StubPayment struct {
+	mu      sync.Mutex
+	charges []*pb.ChargeRequest
+	refunds []*pb.RefundRequest
+	decline map[string]bool
+}
+
+func (p *StubPayment) serve(port int) error {
+	server, err := rpc.NewServer(fmt.Sprintf("127.0.0.1:%d", port), &serializer.SymphonySerializer{}, nil)
+	if err != nil {
+		return err
+	}
+	pb.RegisterPaymentServiceServer(server, p)
+	pb.RegisterPrivacyServiceServer(server, p)
+	go server.Start()
+	return nil
+}
+
+// ReleaseStock puts the stock held for the order orderID back on sale, as if
+// its hold had expired.
+func (s *Shop) ReleaseStock(orderID string) error {
+	client, err := rpc.NewClient(&serializer.SymphonySerializer{}, os.Getenv("PRODUCT_CATALOG_SERVICE_ADDR"), nil)
+	if err != nil {
+		return err
+	}
+	_, err = pb.NewProductCatalogServiceClient(client).ReleaseReservation(context.Background(), &pb.ReservationRequest{OrderId: orderID})
+	return err
+}
+
+// Decline makes charges to the card number fail.
+func (p *StubPayment) Decline(cardNumber string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.decline == nil {
+		p.decline = make(map[string]bool)
+	}
+	p.decline[cardNumber] = true
+}
+
+// Charges returns the charges approved so far.
+func (p *StubPayment) Charges() []*pb.ChargeRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*pb.ChargeRequest(nil), p.charges...)
+}
+
+// Refunds returns the refunds asked for so far.
+func (p *StubPayment) Refunds() []*pb.RefundRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*pb.RefundRequest(nil), p.refunds...)
+}
+
+func (p *StubPayment) Charge(ctx context.Context, req *pb.ChargeRequest) (*pb.ChargeResponse, context.Context, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.decline[req.GetCreditCard().GetCreditCardNumber()] {
+		return nil, ctx, rpcstatus.Convert(services.CardDeclinedErr{})
+	}
+	p.charges = append(p.charges, req)
+	return &pb.ChargeResponse{TransactionId: uuid.NewString()}, ctx, nil
+}
+
+func (p *StubPayment) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.Transaction, context.Context, error) {
+	return nil, ctx, status.Errorf(codes.NotFound, "the stub keeps no ledger")
+}
+
+func (p *StubPayment) ListTransactionsByUser(ctx context.Context, req *pb.ListTransactionsByUserRequest) (*pb.ListTransactionsResponse, context.Context, error) {
+	return &pb.ListTransactionsResponse{}, ctx, nil
+}
+
+func (p *StubPayment) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (*pb.AuditEntries, context.Context, error) {
+	return &pb.AuditEntries{Intact: true}, ctx, nil
+}
+
+func (p *StubPayment) Refund(ctx context.Context, req *pb.RefundRequest) (*pb.Transaction, context.Context, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.refunds = append(p.refunds, req)
+	return &pb.Transaction{TransactionId: req.GetTransactionId(), Status: "REFUNDED"}, ctx, nil
+}
+
+func (p *StubPayment) ExportUserData(ctx context.Context, req *pb.UserDataRequest) (*pb.UserData, context.Context, error) {
+	return &pb.UserData{}, ctx, nil
+}
+
+func (p *StubPayment) DeleteUserData(ctx context.Context, req *pb.UserDataRequest) (*pb.UserData, context.Context, error) {
+	return &pb.UserData{}, ctx, nil
+}
+
+// Client browses the storefront as one shopper, keeping their session
+// cookie between requests.
+type Client struct {
+	tb   testing.TB
+	base string
+	http *http.Client
+}
+
+// NewClient returns a client for a new shopper.
+func (s *Shop) NewClient(tb testing.TB) *Client {
+	jar, _ := cookiejar.New(nil)
+	return &Client{tb: tb, base: s.URL, http: &http.Client{Jar: jar}}
+}
+
+// Get requests path and returns the status code and body. Transport errors
+// fail the test.
+func (c *Client) Get(path string) (int, string) {
+	c.tb.Helper()
+	return c.do(http.MethodGet, path, nil)
+}
+
+// PostForm posts form to path and returns the status code and body.
+// Transport errors fail the test.
+func (c *Client) PostForm(path string, form url.Values) (int, string) {
+	c.tb.Helper()
+	return c.do(http.MethodPost, path, form)
+}
+
+func (c *Client) do(method, path string, form url.Values) (int, string) {
+	c.tb.Helper()
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, c.base+path, body)
+	if err != nil {
+		c.tb.Fatalf("%s %s: %v", method, path, err)
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		c.tb.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.tb.Fatalf("%s %s: %v", method, path, err)
+	}
+	return resp.StatusCode, string(data)
+}
+
+// CheckoutForm returns the checkout details the storefront's form is
+// filled with, along with the one-time nonce and cart hash from cartPage,
+// the body of the cart page, and the shopper's consent to the current terms
+// of sale.
+func CheckoutForm(cartPage string) url.Values {
+	return url.Values{
+		"checkout_nonce":               {hiddenValue(cartPage, "checkout_nonce")},
+		"cart_hash":                    {hiddenValue(cartPage, "cart_hash")},
+		"terms_version":                {services.TermsVersion()},
+		"accept_terms":                 {"true"},
+		"confirm_age":                  {"true"},
+		"email":                        {"someone@example.com"},
+		"street_address":               {"1600 Amphitheatre Parkway"},
+		"zip_code":                     {"94043"},
+		"city":                         {"Mountain View"},
+		"state":                        {"CA"},
+		"country":                      {"United States"},
+		"credit_card_number":           {"4432801561520454"},
+		"credit_card_expiration_month": {"1"},
+		"credit_card_expiration_year":  {fmt.Sprint(time.Now().Year() + 1)},
+		"credit_card_cvv":              {"672"},
+	}
+}
+
+// hiddenValue returns the value of the hidden field name in page, or "" if
+// it has none.
+func hiddenValue(page, name string) string {
+	marker := `name="` + name + `" value="`
+	i := strings.Index(page, marker)
+	if i < 0 {
+		return ""
+	}
+	v, _, _ := strings.Cut(page[i+len(marker):], `"`)
+	return v
+}
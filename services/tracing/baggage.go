@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"context"
+
+	opentracing "github.com/opentracing/opentracing-go"
+)
+
+// Baggage items the frontend sets on every request. They travel with the
+// trace to every service the request reaches, where they tag the spans and
+// can be read with Baggage.
+const (
+	BaggageUserTier   = "user-tier"
+	BaggageExperiment = "experiment"
+	BaggageTenant     = "tenant"
+)
+
+// SetBaggage sets baggage item key of the trace of ctx to value. Items are
+// carried by spans, so nothing is set if ctx has none.
+func SetBaggage(ctx context.Context, key, value string) {
+	if span := opentracing.SpanFromContext(ctx); span != nil && value != "" {
+		span.SetBaggageItem(key, value)
+		span.SetTag("baggage."+key, value)
+	}
+}
+
+// Baggage returns baggage item key of the trace of ctx, or "" if it has
+// none.
+func Baggage(ctx context.Context, key string) string {
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		return span.BaggageItem(key)
+	}
+	return ""
+}
+
+// tagBaggage tags span with the baggage it carries, so that traces can be
+// searched by it.
+func tagBaggage(span opentracing.Span) {
+	span.Context().ForeachBaggageItem(func(k, v string) bool {
+		span.SetTag("baggage."+k, v)
+		return true
+	})
+}
@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisHook traces the commands of a Redis client, each as a child span of
+// the span of its context named after the command, e.g. redis.get. Commands
+// issued outside of a traced call, such as health checks, are not traced.
+//
+//	rdb.AddHook(tracing.RedisHook{})
+type RedisHook struct{}
+
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		span, ctx := startRedisSpan(ctx, "redis."+strings.ToLower(cmd.Name()))
+		if span == nil {
+			return next(ctx, cmd)
+		}
+		err := next(ctx, cmd)
+		finishRedisSpan(span, err)
+		return err
+	}
+}
+
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		span, ctx := startRedisSpan(ctx, "redis.pipeline")
+		if span == nil {
+			return next(ctx, cmds)
+		}
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = strings.ToLower(cmd.Name())
+		}
+		span.SetTag("db.statement", strings.Join(names, " "))
+		err := next(ctx, cmds)
+		finishRedisSpan(span, err)
+		return err
+	}
+}
+
+func startRedisSpan(ctx context.Context, name string) (opentracing.Span, context.Context) {
+	if opentracing.SpanFromContext(ctx) == nil {
+		return nil, ctx
+	}
+	span, ctx := opentracing.StartSpanFromContext(ctx, name, ext.SpanKindRPCClient)
+	ext.Component.Set(span, "go-redis")
+	ext.DBType.Set(span, "redis")
+	return span, ctx
+}
+
+// finishRedisSpan finishes span, marking it failed if err is an error other
+// than a missing key.
+func finishRedisSpan(span opentracing.Span, err error) {
+	if err != nil && err != redis.Nil {
+		ext.Error.Set(span, true)
+		span.LogFields(otlog.Error(err))
+	}
+	span.Finish()
+}
@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+)
+
+// The tracer samples a small share of traces up front, which misses almost
+// all failures. The tracing elements make up for it once a call is over:
+// calls that failed or were slow have their span sampled before it is
+// reported, whatever the tracer decided.
+
+// samplingPolicy decides which calls are always sampled.
+type samplingPolicy struct {
+	// errors samples failed calls, TRACE_SAMPLE_ERRORS, true by default.
+	errors bool
+	// slow is the latency past which calls are sampled,
+	// TRACE_SLOW_THRESHOLD, zero for none. slowByService overrides it for
+	// the calls to or of some services, TRACE_SLOW_THRESHOLDS, e.g.
+	// "CartService=100ms,CheckoutService=2s".
+	slow          time.Duration
+	slowByService map[string]time.Duration
+}
+
+var policy = config.NewValue(func() samplingPolicy {
+	p := samplingPolicy{
+		errors:        strings.ToLower(config.Get("TRACE_SAMPLE_ERRORS")) != "false",
+		slow:          parseDuration("TRACE_SLOW_THRESHOLD", config.Get("TRACE_SLOW_THRESHOLD")),
+		slowByService: make(map[string]time.Duration),
+	}
+	for _, item := range strings.Split(config.Get("TRACE_SLOW_THRESHOLDS"), ",") {
+		service, d, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			continue
+		}
+		p.slowByService[strings.TrimSpace(service)] = parseDuration("TRACE_SLOW_THRESHOLDS", d)
+	}
+	return p
+})
+
+func parseDuration(key, v string) time.Duration {
+	if v = strings.TrimSpace(v); v == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("tracing: ignoring invalid %s value %q: %v", key, v, err)
+		return 0
+	}
+	return d
+}
+
+// callStart is when a call to or of service started.
+type callStart struct {
+	service string
+	at      time.Time
+}
+
+type callStartKey struct{}
+
+// withCallStart records in ctx that a call of service starts now.
+func withCallStart(ctx context.Context, service string) context.Context {
+	return context.WithValue(ctx, callStartKey{}, callStart{service, time.Now()})
+}
+
+// sampleTail marks span sampled if the call of ctx failed or was slow, and
+// tags it with why.
+func sampleTail(ctx context.Context, span opentracing.Span, failed bool) {
+	p := policy.Get()
+	reason := ""
+	if failed && p.errors {
+		reason = "error"
+	} else if start, ok := ctx.Value(callStartKey{}).(callStart); ok {
+		slow, ok := p.slowByService[start.service]
+		if !ok {
+			slow = p.slow
+		}
+		if slow > 0 && time.Since(start.at) > slow {
+			reason = "slow"
+		}
+	}
+	if reason != "" {
+		ext.SamplingPriority.Set(span, 1)
+		span.SetTag("sampling.reason", reason)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/appnet-org/arpc/pkg/metadata"
@@ -13,6 +14,8 @@ import (
 	"github.com/opentracing/opentracing-go/ext"
 	jaegercfg "github.com/uber/jaeger-client-go/config"
 	jaegerlog "github.com/uber/jaeger-client-go/log"
+
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
 )
 
 // ClientTracingElement implements RPC element interface for client-side distributed tracing
@@ -39,6 +42,23 @@ func NewServerTracingElement() element.RPCElement {
 	return &ServerTracingElement{}
 }
 
+// sentAtKey carries the time, in Unix nanoseconds, at which the client
+// issued a call. The server tags its span with how long ago that was as
+// rpc.queue_wait_us: the time the call spent being encoded, in transit and
+// waiting for the server, give or take the skew between their clocks.
+const sentAtKey = "x-arpc-sent-at"
+
+type requestPayloadKey struct{}
+
+// tagPayload tags span with the size of a request or response payload and
+// the time spent encoding or decoding it, e.g. rpc.request.bytes and
+// rpc.request.encode_us.
+func tagPayload(span opentracing.Span, payload, op string, s codec.Stats) {
+	span.SetTag("rpc.codec", s.Codec)
+	span.SetTag("rpc."+payload+".bytes", s.Bytes)
+	span.SetTag("rpc."+payload+"."+op+"_us", s.Duration.Microseconds())
+}
+
 // ClientTracingElement methods
 func (t *ClientTracingElement) Name() string {
 	return "client-tracing"
@@ -105,13 +125,17 @@ func (t *ClientTracingElement) ProcessRequest(ctx context.Context, req *element.
 	span.SetTag("rpc.id", req.ID)
 	span.SetTag("rpc.service", req.ServiceName)
 	span.SetTag("rpc.method", req.Method)
+	tagBaggage(span)
 
 	md := metadata.FromOutgoingContext(ctx)
 	if md == nil {
 		md = metadata.New(map[string]string{})
 	}
 	_ = opentracing.GlobalTracer().Inject(span.Context(), opentracing.TextMap, mdCarrier{md})
+	md.Set(sentAtKey, strconv.FormatInt(time.Now().UnixNano(), 10))
 	ctx = metadata.NewOutgoingContext(ctx, md)
+	ctx = context.WithValue(ctx, requestPayloadKey{}, req.Payload)
+	ctx = withCallStart(ctx, req.ServiceName)
 
 	return req, ctx, nil
 }
@@ -119,12 +143,19 @@ func (t *ClientTracingElement) ProcessRequest(ctx context.Context, req *element.
 func (t *ClientTracingElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
 	span := opentracing.SpanFromContext(ctx)
 	if span != nil {
+		if s, ok := codec.TakeStats(ctx.Value(requestPayloadKey{})); ok {
+			tagPayload(span, "request", "encode", s)
+		}
+		if s, ok := codec.TakeStats(resp.Result); ok {
+			tagPayload(span, "response", "decode", s)
+		}
 		if resp.Error != nil {
 			ext.Error.Set(span, true)
 			span.SetTag("error", resp.Error.Error())
 		} else {
 			span.SetTag("rpc.success", true)
 		}
+		sampleTail(ctx, span, resp.Error != nil)
 		span.Finish()
 		log.Printf("Finished client tracing span for response")
 	}
@@ -165,6 +196,17 @@ func (t *ServerTracingElement) ProcessRequest(ctx context.Context, req *element.
 	span.SetTag("rpc.id", req.ID)
 	span.SetTag("rpc.service", req.ServiceName)
 	span.SetTag("rpc.method", req.Method)
+	tagBaggage(span)
+
+	// The server decodes the request before the elements run and encodes
+	// the response after, so only the request's payload is known here.
+	if s, ok := codec.TakeStats(req.Payload); ok {
+		tagPayload(span, "request", "decode", s)
+	}
+	if sentAt, err := strconv.ParseInt(md.Get(sentAtKey), 10, 64); err == nil {
+		span.SetTag("rpc.queue_wait_us", time.Since(time.Unix(0, sentAt)).Microseconds())
+	}
+	ctx = withCallStart(ctx, req.ServiceName)
 
 	return req, ctx, nil
 }
@@ -178,6 +220,7 @@ func (t *ServerTracingElement) ProcessResponse(ctx context.Context, resp *elemen
 		} else {
 			span.SetTag("rpc.success", true)
 		}
+		sampleTail(ctx, span, resp.Error != nil)
 		span.Finish()
 		// log.Printf("Finished server tracing span for response")
 	}
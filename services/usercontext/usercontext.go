@@ -0,0 +1,100 @@
+// Package usercontext carries the shopper's identity between services as
+// aRPC metadata. The frontend sets it once per request with NewContext; the
+// client element then attaches it to every call, and the server element
+// makes it available to handlers through FromContext, including to the
+// calls they make in turn.
+//
+// Requests still have their own user_id fields, which are deprecated. UserID
+// prefers the metadata and falls back to them for callers that do not send
+// it yet.
+package usercontext
+
+import (
+	"context"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/rpc/element"
+)
+
+// MetadataKey carries the user ID between services.
+const MetadataKey = "x-shop-user"
+
+type ctxKey struct{}
+
+// NewContext returns ctx acting for user id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// FromContext returns the user ctx acts for, set by NewContext or received
+// from the caller.
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(ctxKey{}).(string); ok {
+		return id
+	}
+	return metadata.FromIncomingContext(ctx).Get(MetadataKey)
+}
+
+// UserID returns the user ctx acts for, or field, the request's deprecated
+// user_id, if the caller did not send one.
+func UserID(ctx context.Context, field string) string {
+	if id := FromContext(ctx); id != "" {
+		return id
+	}
+	return field
+}
+
+// ClientElement attaches the user to outgoing calls.
+type ClientElement struct{}
+
+// NewClientElement creates a new client-side user context element.
+func NewClientElement() element.RPCElement {
+	return &ClientElement{}
+}
+
+func (e *ClientElement) Name() string {
+	return "client-user-context"
+}
+
+func (e *ClientElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	id := FromContext(ctx)
+	if id != "" && metadata.FromOutgoingContext(ctx).Get(MetadataKey) == "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, id)
+	}
+	return req, ctx, nil
+}
+
+func (e *ClientElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	return resp, ctx, nil
+}
+
+func (e *ClientElement) Close() error {
+	return nil
+}
+
+// ServerElement makes the caller's user available to handlers.
+type ServerElement struct{}
+
+// NewServerElement creates a new server-side user context element.
+func NewServerElement() element.RPCElement {
+	return &ServerElement{}
+}
+
+func (e *ServerElement) Name() string {
+	return "server-user-context"
+}
+
+func (e *ServerElement) ProcessRequest(ctx context.Context, req *element.RPCRequest) (*element.RPCRequest, context.Context, error) {
+	if id := metadata.FromIncomingContext(ctx).Get(MetadataKey); id != "" {
+		ctx = NewContext(ctx, id)
+	}
+	return req, ctx, nil
+}
+
+func (e *ServerElement) ProcessResponse(ctx context.Context, resp *element.RPCResponse) (*element.RPCResponse, context.Context, error) {
+	return resp, ctx, nil
+}
+
+func (e *ServerElement) Close() error {
+	return nil
+}
@@ -1,26 +1,52 @@
 package services
 
 import (
+	"expvar"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/appnet-org/arpc/pkg/logging"
 	"github.com/appnet-org/arpc/pkg/rpc"
 	"github.com/appnet-org/arpc/pkg/rpc/element"
-	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/appnetorg/online-boutique-arpc/services/affinity"
+	"github.com/appnetorg/online-boutique-arpc/services/backpressure"
+	"github.com/appnetorg/online-boutique-arpc/services/capture"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/discovery"
+	"github.com/appnetorg/online-boutique-arpc/services/loadshed"
+	"github.com/appnetorg/online-boutique-arpc/services/methodfilter"
+	"github.com/appnetorg/online-boutique-arpc/services/resolver"
+	"github.com/appnetorg/online-boutique-arpc/services/rpclog"
+	"github.com/appnetorg/online-boutique-arpc/services/startup"
 	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+	"github.com/appnetorg/online-boutique-arpc/services/watchdog"
 	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
 )
 
+func init() {
+	config.OnReload(func() {
+		logging.SetLevel(getLoggingConfig().Level)
+	})
+}
+
 // getLoggingConfig reads logging configuration from environment variables with defaults
 func getLoggingConfig() *logging.Config {
-	level := os.Getenv("LOG_LEVEL")
+	level := config.Get("LOG_LEVEL")
 	if level == "" {
 		level = "debug"
 	}
 
-	format := os.Getenv("LOG_FORMAT")
+	format := config.Get("LOG_FORMAT")
 	if format == "" {
 		format = "console"
 	}
@@ -31,24 +57,295 @@ func getLoggingConfig() *logging.Config {
 	}
 }
 
+// envDuration parses a duration from an environment variable, returning def
+// if it is unset or invalid.
+func envDuration(envKey string, def time.Duration) time.Duration {
+	v := config.Get(envKey)
+	if v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q: %v", envKey, v, err)
+		return def
+	}
+	return d
+}
+
+// envInt parses an integer from an environment variable, returning def if it
+// is unset or invalid.
+func envInt(envKey string, def int) int {
+	v := config.Get(envKey)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Printf("Ignoring invalid %s=%q: %v", envKey, v, err)
+		return def
+	}
+	return n
+}
+
+// envList splits a comma-separated environment variable into its trimmed,
+// non-empty items, returning def if it is unset.
+func envList(envKey string, def []string) []string {
+	v, ok := config.Lookup(envKey)
+	if !ok {
+		return def
+	}
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// loadShedConfig reads the load-shedding thresholds. Both are off unless set.
+func loadShedConfig() loadshed.Config {
+	return loadshed.Config{
+		MaxInFlight: envInt("LOADSHED_MAX_INFLIGHT", 0),
+		MaxP99:      envDuration("LOADSHED_MAX_P99", 0),
+	}
+}
+
+// backpressureConfig reads the bounds on the requests a server handles at
+// once (see package backpressure).
+func backpressureConfig() backpressure.Config {
+	policy := strings.ToLower(config.Get("BACKPRESSURE_POLICY"))
+	if policy != backpressure.PolicyDropOldest {
+		policy = backpressure.PolicyReject
+	}
+	return backpressure.Config{
+		MaxConcurrent: envInt("BACKPRESSURE_MAX_CONCURRENT", 0),
+		MaxQueue:      envInt("BACKPRESSURE_MAX_QUEUE", 0),
+		QueueTimeout:  envDuration("BACKPRESSURE_QUEUE_TIMEOUT", time.Second),
+		Policy:        policy,
+	}
+}
+
+// newBackpressureElement returns a backpressure element whose bounds follow
+// configuration reloads.
+func newBackpressureElement() *backpressure.ServerBackpressureElement {
+	e := backpressure.NewServerBackpressureElement(backpressureConfig())
+	config.OnReload(func() {
+		e.SetConfig(backpressureConfig())
+	})
+	return e
+}
+
+// newLoadShedElement returns a load-shedding element whose thresholds follow
+// configuration reloads.
+func newLoadShedElement() *loadshed.ServerLoadShedElement {
+	e := loadshed.NewServerLoadShedElement(loadShedConfig())
+	config.OnReload(func() {
+		e.SetConfig(loadShedConfig())
+	})
+	return e
+}
+
 func mustMapEnv(target *string, envKey string) {
-	v := os.Getenv(envKey)
+	v := config.Get(envKey)
 	if v == "" {
 		panic(fmt.Sprintf("environment variable %q not set", envKey))
 	}
 	*target = v
 }
 
-// mustConnARPC creates an aRPC client with tracing, similar to mustConnGRPC
-func mustConnARPC(client **rpc.Client, addr string) {
+// mapServiceAddr sets target to the address in envKey. If that is empty and
+// DISCOVERY is "kubernetes" or "consul", the logical service name is resolved
+// through that registry instead.
+func mapServiceAddr(target *string, envKey, service string) {
+	if v := config.Get(envKey); v != "" {
+		*target = v
+		return
+	}
+	switch config.Get("DISCOVERY") {
+	case "kubernetes":
+		*target = discovery.Target(discovery.SchemeKubernetes, service+":arpc-"+service)
+	case "consul":
+		*target = discovery.Target(discovery.SchemeConsul, service)
+	default:
+		panic(fmt.Sprintf("environment variable %q not set", envKey))
+	}
+}
+
+// mustConnARPC creates a pool of aRPC clients with tracing for addr, which may
+// name several replicas (see the resolver package).
+func mustConnARPC(pool **resolver.Pool, addr string) {
 	log.Printf("Attempting to connect to aRPC server at: %s", addr)
 
-	serializer := &serializer.SymphonySerializer{}
-	clientElements := []element.RPCElement{tracing.NewClientTracingElement()}
+	dial := func(addr string, elements ...element.RPCElement) (*rpc.Client, error) {
+		clientElements := append([]element.RPCElement{rpclog.NewClientElement(addr), tracing.NewClientTracingElement(), usercontext.NewClientElement(), affinity.NewClientElement(), codec.NewClientElement()}, elements...)
+		return rpc.NewClient(codec.NewClient(), addr, clientElements)
+	}
 
 	var err error
-	*client, err = rpc.NewClient(serializer, addr, clientElements)
+	if discovery.IsTarget(addr) {
+		var lookup resolver.LookupFunc
+		if lookup, err = discovery.Lookup(addr); err == nil {
+			*pool, err = resolver.NewWithLookup(addr, lookup, true, resolverConfig(), dial)
+		}
+	} else {
+		*pool, err = resolver.New(addr, resolverConfig(), dial)
+	}
 	if err != nil {
 		panic(errors.Wrapf(err, "arpc: failed to connect %s", addr))
 	}
 }
+
+// newRedisClient connects to the Redis deployment configured under prefix.
+// <prefix>_REDIS_MODE selects how: "single" (the default) for one server at
+// <prefix>_REDIS_ADDR, "sentinel" for the master named <prefix>_REDIS_MASTER
+// as reported by the comma-separated sentinels at <prefix>_REDIS_ADDR, and
+// "cluster" for the cluster whose comma-separated seed nodes are at
+// <prefix>_REDIS_ADDR. Sentinel and cluster clients follow failovers.
+func newRedisClient(prefix string) redis.UniversalClient {
+	var addr string
+	mustMapEnv(&addr, prefix+"_REDIS_ADDR")
+	addrs := strings.Split(addr, ",")
+	for i := range addrs {
+		addrs[i] = strings.TrimSpace(addrs[i])
+	}
+
+	switch mode := config.Get(prefix + "_REDIS_MODE"); mode {
+	case "", "single":
+		return redis.NewClient(&redis.Options{Addr: addr})
+	case "sentinel":
+		var master string
+		mustMapEnv(&master, prefix+"_REDIS_MASTER")
+		log.Printf("Connecting to Redis master %q through sentinels %v", master, addrs)
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    master,
+			SentinelAddrs: addrs,
+		})
+	case "cluster":
+		log.Printf("Connecting to Redis cluster through %v", addrs)
+		return redis.NewClusterClient(&redis.ClusterOptions{Addrs: addrs})
+	default:
+		panic(fmt.Sprintf("unknown %s_REDIS_MODE %q", prefix, mode))
+	}
+}
+
+// serverElements returns the RPC elements of a server, each limited to the
+// methods configured for it (see methodfilter). The codec element is added
+// first, so that it marks replies for encoding after the others have seen
+// them, then the logging element, so that the durations it logs include the
+// other elements, then the backpressure element, so that requests waiting for
+// a slot have not yet used any, and the capture element last, so that it
+// records the calls as the handler sees them.
+func serverElements(elements ...element.RPCElement) []element.RPCElement {
+	elements = append([]element.RPCElement{codec.NewServerElement(), rpclog.NewServerElement(), newBackpressureElement()}, elements...)
+	elements = append(elements, capture.NewServerElement())
+	wrapped := make([]element.RPCElement, len(elements))
+	for i, e := range elements {
+		wrapped[i] = methodfilter.Wrap(e)
+	}
+	return wrapped
+}
+
+// resolverConfig reads the client load-balancing settings.
+func resolverConfig() resolver.Config {
+	return resolver.Config{
+		RefreshInterval: envDuration("RESOLVER_REFRESH_INTERVAL", resolver.DefaultConfig.RefreshInterval),
+		SubsetSize:      envInt("RESOLVER_SUBSET_SIZE", 0),
+		MaxFailures:     envInt("RESOLVER_MAX_FAILURES", resolver.DefaultConfig.MaxFailures),
+		EjectFor:        envDuration("RESOLVER_EJECT_DURATION", resolver.DefaultConfig.EjectFor),
+	}
+}
+
+// startupConfig reads how dependencies are checked at startup (see package
+// startup). STARTUP_CHECKS is "off" unless set to "strict" or "lazy".
+func startupConfig() startup.Config {
+	mode := config.Get("STARTUP_CHECKS")
+	if mode == "" {
+		mode = startup.Off
+	}
+	return startup.Config{
+		Mode:       mode,
+		Deadline:   envDuration("STARTUP_CHECK_DEADLINE", time.Minute),
+		Timeout:    envDuration("STARTUP_CHECK_TIMEOUT", 2*time.Second),
+		MinBackoff: envDuration("STARTUP_CHECK_MIN_BACKOFF", 250*time.Millisecond),
+		MaxBackoff: envDuration("STARTUP_CHECK_MAX_BACKOFF", 5*time.Second),
+	}
+}
+
+// newStartupChecker returns the dependency checker of the service. Its
+// status is published with expvar as "startup" and, if STARTUP_STATUS_ADDR is
+// set, served there at /ready for services without an HTTP server of their
+// own. Services sharing a process, as they do in end-to-end tests, publish
+// the status of the first of them.
+func newStartupChecker() *startup.Checker {
+	c := startup.New(startupConfig())
+	publishStartupOnce.Do(func() {
+		expvar.Publish("startup", expvar.Func(c.Var))
+	})
+	if config.Get("STARTUP_STATUS_ADDR") != "" {
+		statusMux.Handle("/ready", c)
+		serveStatus()
+	}
+	return c
+}
+
+// statusMux serves the status server at STARTUP_STATUS_ADDR. Services add
+// their admin endpoints to it.
+var statusMux = http.NewServeMux()
+
+var publishStartupOnce, serveStatusOnce sync.Once
+
+// serveStatus starts the status server, if STARTUP_STATUS_ADDR is set and it
+// is not running yet.
+func serveStatus() {
+	addr := config.Get("STARTUP_STATUS_ADDR")
+	if addr == "" {
+		return
+	}
+	serveStatusOnce.Do(func() {
+		statusMux.Handle("/debug/vars", expvar.Handler())
+		go func() {
+			log.Printf("Serving startup status at %s", addr)
+			log.Printf("Startup status server stopped: %v", http.ListenAndServe(addr, statusMux))
+		}()
+	})
+}
+
+// watchdogConfig reads the thresholds of the goroutine and memory watchdog
+// (see package watchdog). Both are off unless set.
+func watchdogConfig() watchdog.Config {
+	dir := config.Get("WATCHDOG_PROFILE_DIR")
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "profiles")
+	}
+	return watchdog.Config{
+		Interval:      envDuration("WATCHDOG_INTERVAL", 10*time.Second),
+		MaxGoroutines: envInt("WATCHDOG_MAX_GOROUTINES", 0),
+		MaxHeapBytes:  uint64(max(envInt("WATCHDOG_MAX_HEAP_MB", 0), 0)) << 20,
+		Cooldown:      envDuration("WATCHDOG_COOLDOWN", 5*time.Minute),
+		Dir:           dir,
+		Keep:          envInt("WATCHDOG_KEEP", 10),
+	}
+}
+
+// StartWatchdog starts the goroutine and memory watchdog of the service. Its
+// recent captures are listed on the status server at /admin/profiles and
+// served below it by file name.
+func StartWatchdog(service string) {
+	w := watchdog.New(service, watchdogConfig())
+	config.OnReload(func() {
+		w.SetConfig(watchdogConfig())
+	})
+	statusMux.HandleFunc("GET /admin/profiles", w.ServeCaptures)
+	statusMux.HandleFunc("GET /admin/profiles/{file}", w.ServeProfile)
+	serveStatus()
+	go w.Run()
+}
+
+// mustCheckStartup runs the dependency checks, exiting if a strict check
+// fails.
+func mustCheckStartup(c *startup.Checker) {
+	if err := c.Run(); err != nil {
+		log.Fatalf("%v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRedisClientsComeFromNewRedisClient checks that every service connects
+// to Redis through newRedisClient, so that each store follows its
+// <prefix>_REDIS_MODE.
+func TestRedisClientsComeFromNewRedisClient(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Name.Name == "newRedisClient" {
+				continue
+			}
+			ast.Inspect(fn, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "redis" && strings.HasPrefix(sel.Sel.Name, "New") && strings.HasSuffix(sel.Sel.Name, "Client") {
+					t.Errorf("%s: %s calls redis.%s instead of newRedisClient", fset.Position(sel.Pos()), fn.Name.Name, sel.Sel.Name)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// TestHandlersLogThroughRPCLog checks that what the handlers of these files
+// log, in the functions given a request, goes through rpclog as structured
+// lines rather than through the log package.
+func TestHandlersLogThroughRPCLog(t *testing.T) {
+	files := []string{"checkout.go", "payment.go", "payment_webhooks.go", "frontend_privacy.go", "frontend_graphql.go"}
+	fset := token.NewFileSet()
+	for _, name := range files {
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !handlesRequest(fn) {
+				continue
+			}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				sel, ok := n.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "log" && strings.HasPrefix(sel.Sel.Name, "Print") {
+					t.Errorf("%s: %s calls log.%s instead of rpclog", fset.Position(sel.Pos()), fn.Name.Name, sel.Sel.Name)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// handlesRequest reports whether fn is given a context.Context or an
+// *http.Request.
+func handlesRequest(fn *ast.FuncDecl) bool {
+	for _, p := range fn.Type.Params.List {
+		typ := p.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		sel, ok := typ.(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok && (pkg.Name+"."+sel.Sel.Name == "context.Context" || pkg.Name+"."+sel.Sel.Name == "http.Request") {
+			return true
+		}
+	}
+	return false
+}
+
+// TestSourcesAreGofmted checks that the files of the package are formatted
+// as gofmt formats them.
+func TestSourcesAreGofmted(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range files {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatted, err := format.Source(src)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !bytes.Equal(src, formatted) {
+			t.Errorf("%s is not formatted with gofmt", name)
+		}
+	}
+}
@@ -50,6 +50,20 @@ type PlaceOrderPayload struct {
 	CcMonth       int64  `validate:"required,gte=1,lte=12"`
 	CcYear        int64  `validate:"required"`
 	CcCVV         int64  `validate:"required"`
+	Note          string `validate:"max=500"`
+}
+
+type NotifyWhenAvailablePayload struct {
+	Email     string `validate:"required,email"`
+	ProductID string `validate:"required"`
+}
+
+type ContactPayload struct {
+	Name    string `validate:"required,max=128"`
+	Email   string `validate:"required,email,max=254"`
+	Subject string `validate:"required,max=200"`
+	Message string `validate:"required,max=5000"`
+	OrderID string `validate:"omitempty,uuid"`
 }
 
 type SetCurrencyPayload struct {
@@ -65,6 +79,14 @@ func (po *PlaceOrderPayload) Validate() error {
 	return validate.Struct(po)
 }
 
+func (na *NotifyWhenAvailablePayload) Validate() error {
+	return validate.Struct(na)
+}
+
+func (cp *ContactPayload) Validate() error {
+	return validate.Struct(cp)
+}
+
 func (sc *SetCurrencyPayload) Validate() error {
 	return validate.Struct(sc)
 }
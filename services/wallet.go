@@ -0,0 +1,336 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/appnet-org/arpc/pkg/logging"
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/redis/go-redis/v9"
+
+	pb "github.com/appnetorg/online-boutique-arpc/proto"
+	"github.com/appnetorg/online-boutique-arpc/services/audit"
+	"github.com/appnetorg/online-boutique-arpc/services/codec"
+	"github.com/appnetorg/online-boutique-arpc/services/config"
+	"github.com/appnetorg/online-boutique-arpc/services/recovery"
+	"github.com/appnetorg/online-boutique-arpc/services/tenant"
+	"github.com/appnetorg/online-boutique-arpc/services/tracing"
+	"github.com/appnetorg/online-boutique-arpc/services/usercontext"
+)
+
+type InsufficientWalletBalanceErr struct{}
+
+func (e InsufficientWalletBalanceErr) Error() string {
+	return "insufficient wallet balance"
+}
+
+type UnknownGiftCardErr struct{}
+
+func (e UnknownGiftCardErr) Error() string {
+	return "unknown gift card"
+}
+
+type GiftCardRedeemedErr struct{}
+
+func (e GiftCardRedeemedErr) Error() string {
+	return "gift card already redeemed"
+}
+
+// walletRetries bounds the attempts at an update that lost a race with
+// another one on the same wallet.
+const walletRetries = 5
+
+// giftCards are the gift cards that can be redeemed, by code, from
+// GIFT_CARDS as a comma-separated list of CODE=CURRENCY:AMOUNT, such as
+// "WELCOME25=USD:25". Codes are not case-sensitive.
+var giftCards = config.NewValue(func() map[string]*pb.Money {
+	cards := map[string]*pb.Money{}
+	for _, entry := range envList("GIFT_CARDS", nil) {
+		code, value, ok := strings.Cut(entry, "=")
+		currency, amount, ok2 := strings.Cut(value, ":")
+		f, err := strconv.ParseFloat(amount, 64)
+		if !ok || !ok2 || err != nil || f <= 0 {
+			log.Printf("Ignoring invalid gift card %q", entry)
+			continue
+		}
+		cents := int64(math.Round(f * 100))
+		cards[strings.ToUpper(strings.TrimSpace(code))] = nanosToMoney(strings.TrimSpace(currency), cents*10000000)
+	}
+	return cards
+})
+
+// walletDebit is a debit as recorded, for its refund.
+type walletDebit struct {
+	UserID   string `json:"user_id"`
+	Currency string `json:"currency"`
+	Nanos    int64  `json:"nanos"`
+	Refunded bool   `json:"refunded"`
+}
+
+// NewWalletService returns a new server for the WalletService
+func NewWalletService(port int) *WalletService {
+	return &WalletService{
+		port: port,
+	}
+}
+
+// WalletService implements the WalletService
+type WalletService struct {
+	port int
+
+	rdb   redis.UniversalClient // Balances, debits and redeemed gift cards
+	audit *audit.Log
+}
+
+// Run starts the server
+func (s *WalletService) Run() error {
+	err := logging.Init(getLoggingConfig())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to initialize logging: %v", err))
+	}
+
+	s.rdb = newRedisClient("WALLET")
+	s.audit = audit.New(s.rdb, "WalletService")
+	s.audit.RecordConfigChanges()
+
+	checker := newStartupChecker()
+	checker.Add("redis", func(ctx context.Context) error {
+		return s.rdb.Ping(ctx).Err()
+	})
+	mustCheckStartup(checker)
+
+	serializer := codec.NewServer()
+	rpcElements := serverElements(tracing.NewServerTracingElement(), recovery.NewServerRecoveryElement(), usercontext.NewServerElement(),
+		audit.NewServerElement(s.audit, "RedeemGiftCard", "Debit", "Refund"))
+	server, err := rpc.NewServer("0.0.0.0:"+strconv.Itoa(s.port), serializer, rpcElements)
+	if err != nil {
+		log.Fatalf("Failed to start aRPC server: %v", err)
+	}
+
+	pb.RegisterWalletServiceServer(server, s)
+	log.Printf("WalletService running at port: %d", s.port)
+	server.Start()
+	return nil
+}
+
+// GetBalance returns the user's balance in a currency
+func (s *WalletService) GetBalance(ctx context.Context, req *pb.GetWalletBalanceRequest) (_ *pb.WalletBalance, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.UserID(ctx, "")
+	balance, err := s.rdb.HGet(ctx, walletKey(ctx, userID), req.GetCurrencyCode()).Int64()
+	if err != nil && err != redis.Nil {
+		log.Printf("Failed to read wallet of user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+	return &pb.WalletBalance{Balance: nanosToMoney(req.GetCurrencyCode(), balance)}, ctx, nil
+}
+
+// RedeemGiftCard adds the value of a gift card to the user's balance in its
+// currency. Each gift card can be redeemed once.
+func (s *WalletService) RedeemGiftCard(ctx context.Context, req *pb.RedeemGiftCardRequest) (_ *pb.WalletBalance, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.UserID(ctx, "")
+	code := strings.ToUpper(strings.TrimSpace(req.GetCode()))
+	value, ok := giftCards.Get()[code]
+	if !ok {
+		log.Printf("Unknown gift card redeemed by user_id = %v", userID)
+		return nil, ctx, UnknownGiftCardErr{}
+	}
+
+	nanos, err := moneyToNanos(value)
+	if err != nil {
+		log.Printf("Gift card %s has a value out of range: %v", code, err)
+		return nil, ctx, err
+	}
+
+	key, cardKey := walletKey(ctx, userID), tenant.Key(ctx, "giftcard:"+code)
+	var balance int64
+	err = s.update(ctx, func(tx *redis.Tx) error {
+		n, err := tx.Exists(ctx, cardKey).Result()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return GiftCardRedeemedErr{}
+		}
+		balance, err = hgetInt64(ctx, tx, key, value.GetCurrencyCode())
+		if err != nil {
+			return err
+		}
+		if balance > math.MaxInt64-nanos {
+			return ErrOutOfRange
+		}
+		balance += nanos
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, cardKey, userID, 0)
+			pipe.HSet(ctx, key, value.GetCurrencyCode(), balance)
+			return nil
+		})
+		return err
+	}, key, cardKey)
+	if err != nil {
+		log.Printf("Failed to redeem gift card for user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+
+	log.Printf("Gift card of %v %v redeemed by user_id = %v", value.GetUnits(), value.GetCurrencyCode(), userID)
+	return &pb.WalletBalance{Balance: nanosToMoney(value.GetCurrencyCode(), balance)}, ctx, nil
+}
+
+// Debit takes an amount from the user's balance in its currency, failing if
+// the balance is short of it.
+func (s *WalletService) Debit(ctx context.Context, req *pb.WalletDebitRequest) (_ *pb.WalletBalance, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	userID := usercontext.UserID(ctx, "")
+	amount := req.GetAmount()
+	if !IsValid(amount) || !IsPositive(amount) || amount.GetCurrencyCode() == "" {
+		return nil, ctx, InvalidAmountErr{}
+	}
+	nanos, err := moneyToNanos(amount)
+	if err != nil {
+		return nil, ctx, InvalidAmountErr{}
+	}
+	if req.GetDebitId() == "" {
+		return nil, ctx, errors.New("missing debit ID")
+	}
+
+	currency := amount.GetCurrencyCode()
+	key, debitKey := walletKey(ctx, userID), walletDebitKey(ctx, req.GetDebitId())
+	var balance int64
+	err = s.update(ctx, func(tx *redis.Tx) error {
+		var err error
+		if balance, err = hgetInt64(ctx, tx, key, currency); err != nil {
+			return err
+		}
+		n, err := tx.Exists(ctx, debitKey).Result()
+		if err != nil || n > 0 {
+			return err
+		}
+		if balance < nanos {
+			return InsufficientWalletBalanceErr{}
+		}
+		record, err := json.Marshal(walletDebit{UserID: userID, Currency: currency, Nanos: nanos})
+		if err != nil {
+			return err
+		}
+		balance -= nanos
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, debitKey, record, 0)
+			pipe.HSet(ctx, key, currency, balance)
+			return nil
+		})
+		return err
+	}, key, debitKey)
+	if err != nil {
+		log.Printf("Failed to debit wallet of user_id = %v: %v", userID, err)
+		return nil, ctx, err
+	}
+
+	log.Printf("Debit %v from wallet of user_id = %v", req.GetDebitId(), userID)
+	return &pb.WalletBalance{Balance: nanosToMoney(currency, balance)}, ctx, nil
+}
+
+// Refund gives a debit back to the wallet it was taken from.
+func (s *WalletService) Refund(ctx context.Context, req *pb.WalletRefundRequest) (_ *pb.WalletBalance, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	debitKey := walletDebitKey(ctx, req.GetDebitId())
+	data, err := s.rdb.Get(ctx, debitKey).Bytes()
+	if err == redis.Nil {
+		return nil, ctx, fmt.Errorf("unknown debit %q", req.GetDebitId())
+	} else if err != nil {
+		return nil, ctx, err
+	}
+	var debit walletDebit
+	if err := json.Unmarshal(data, &debit); err != nil {
+		return nil, ctx, err
+	}
+
+	key := walletKey(ctx, debit.UserID)
+	var balance int64
+	err = s.update(ctx, func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, debitKey).Bytes()
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &debit); err != nil {
+			return err
+		}
+		if balance, err = hgetInt64(ctx, tx, key, debit.Currency); err != nil || debit.Refunded {
+			return err
+		}
+		debit.Refunded = true
+		record, err := json.Marshal(debit)
+		if err != nil {
+			return err
+		}
+		balance += debit.Nanos
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, debitKey, record, 0)
+			pipe.HSet(ctx, key, debit.Currency, balance)
+			return nil
+		})
+		return err
+	}, key, debitKey)
+	if err != nil {
+		log.Printf("Failed to refund debit %v: %v", req.GetDebitId(), err)
+		return nil, ctx, err
+	}
+
+	log.Printf("Debit %v refunded to wallet of user_id = %v", req.GetDebitId(), debit.UserID)
+	return &pb.WalletBalance{Balance: nanosToMoney(debit.Currency, balance)}, ctx, nil
+}
+
+// ListAuditEntries returns a page of the audit log of gift card
+// redemptions, debits and refunds
+func (s *WalletService) ListAuditEntries(ctx context.Context, req *pb.ListAuditEntriesRequest) (_ *pb.AuditEntries, _ context.Context, err error) {
+	defer recovery.Recover(ctx, &err)
+
+	entries, err := s.audit.List(ctx, req.GetFromSeq(), int(req.GetLimit()))
+	if err != nil {
+		log.Printf("Failed to list audit entries: %v", err)
+		return nil, ctx, err
+	}
+	if !entries.GetIntact() {
+		log.Printf("Audit log is broken at entry %d", entries.GetBrokenSeq())
+	}
+	return entries, ctx, nil
+}
+
+// update runs fn in a transaction watching keys, retrying when another
+// client changed them first.
+func (s *WalletService) update(ctx context.Context, fn func(*redis.Tx) error, keys ...string) error {
+	var err error
+	for range walletRetries {
+		if err = s.rdb.Watch(ctx, fn, keys...); err != redis.TxFailedErr {
+			return err
+		}
+	}
+	return err
+}
+
+func walletKey(ctx context.Context, userID string) string {
+	return tenant.Key(ctx, "wallet:"+userID)
+}
+
+func walletDebitKey(ctx context.Context, debitID string) string {
+	return tenant.Key(ctx, "wallet-debit:"+debitID)
+}
+
+// hgetInt64 reads an integer field of a hash, zero if it is not set.
+func hgetInt64(ctx context.Context, tx *redis.Tx, key, field string) (int64, error) {
+	n, err := tx.HGet(ctx, key, field).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return n, err
+}
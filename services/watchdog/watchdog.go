@@ -0,0 +1,201 @@
+// Package watchdog samples the goroutine count and heap size of a service
+// and writes a pprof profile when one crosses its threshold, so that the
+// state of a leaking service is on disk by the time anyone looks, even hours
+// into a load test.
+//
+// A goroutine threshold captures a goroutine profile, a heap threshold a heap
+// profile. A kind is captured at most once per Cooldown while its threshold
+// is crossed, and only the Keep most recent captures are kept. The captures
+// are listed by ServeCaptures and served, for `go tool pprof`, by
+// ServeProfile.
+package watchdog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+)
+
+// Kinds of profile captured.
+const (
+	KindGoroutine = "goroutine"
+	KindHeap      = "heap"
+)
+
+// Config holds the thresholds and where captures go. A zero threshold
+// disables that check.
+type Config struct {
+	Interval      time.Duration
+	MaxGoroutines int
+	MaxHeapBytes  uint64
+	Cooldown      time.Duration
+	Dir           string
+	Keep          int
+}
+
+// Capture is a profile written by the watchdog.
+type Capture struct {
+	Time       time.Time `json:"time"`
+	Kind       string    `json:"kind"`
+	Reason     string    `json:"reason"`
+	Goroutines int       `json:"goroutines"`
+	HeapBytes  uint64    `json:"heap_bytes"`
+	File       string    `json:"file"`
+	Size       int64     `json:"size"`
+
+	path string
+}
+
+// Watchdog watches one service.
+type Watchdog struct {
+	service string
+
+	mu       sync.Mutex
+	cfg      Config
+	captures []Capture // oldest first
+	last     map[string]time.Time
+}
+
+// New returns a watchdog for service. It does nothing until Run.
+func New(service string, cfg Config) *Watchdog {
+	return &Watchdog{service: service, cfg: cfg, last: make(map[string]time.Time)}
+}
+
+// SetConfig replaces the thresholds. The interval only changes on the next
+// sample.
+func (w *Watchdog) SetConfig(cfg Config) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cfg = cfg
+}
+
+// Run samples the service every interval, forever.
+func (w *Watchdog) Run() {
+	for {
+		w.mu.Lock()
+		interval := w.cfg.Interval
+		w.mu.Unlock()
+		if interval <= 0 {
+			interval = 10 * time.Second
+		}
+		time.Sleep(interval)
+		w.Check(time.Now())
+	}
+}
+
+// Check samples the service once, capturing the profiles whose thresholds
+// are crossed.
+func (w *Watchdog) Check(now time.Time) {
+	goroutines := runtime.NumGoroutine()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+
+	w.mu.Lock()
+	cfg := w.cfg
+	w.mu.Unlock()
+	if cfg.MaxGoroutines > 0 && goroutines > cfg.MaxGoroutines {
+		w.capture(now, KindGoroutine, fmt.Sprintf("%d goroutines, above %d", goroutines, cfg.MaxGoroutines), goroutines, ms.HeapAlloc)
+	}
+	if cfg.MaxHeapBytes > 0 && ms.HeapAlloc > cfg.MaxHeapBytes {
+		w.capture(now, KindHeap, fmt.Sprintf("%d heap bytes, above %d", ms.HeapAlloc, cfg.MaxHeapBytes), goroutines, ms.HeapAlloc)
+	}
+}
+
+// capture writes a profile of kind unless one was written within the
+// cooldown, and drops the oldest captures beyond Keep.
+func (w *Watchdog) capture(now time.Time, kind, reason string, goroutines int, heapBytes uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if last, ok := w.last[kind]; ok && now.Sub(last) < w.cfg.Cooldown {
+		return
+	}
+	w.last[kind] = now
+
+	if err := os.MkdirAll(w.cfg.Dir, 0o755); err != nil {
+		log.Printf("watchdog: failed to capture %s profile: %v", kind, err)
+		return
+	}
+	name := fmt.Sprintf("%s-%s-%s.pb.gz", w.service, kind, now.UTC().Format("20060102T150405Z"))
+	file := filepath.Join(w.cfg.Dir, name)
+	size, err := writeProfile(file, kind)
+	if err != nil {
+		log.Printf("watchdog: failed to capture %s profile: %v", kind, err)
+		return
+	}
+	log.Printf("watchdog: captured %s profile %s: %s", kind, name, reason)
+	w.captures = append(w.captures, Capture{
+		Time:       now,
+		Kind:       kind,
+		Reason:     reason,
+		Goroutines: goroutines,
+		HeapBytes:  heapBytes,
+		File:       name,
+		Size:       size,
+		path:       file,
+	})
+
+	keep := max(w.cfg.Keep, 1)
+	for len(w.captures) > keep {
+		if err := os.Remove(w.captures[0].path); err != nil && !os.IsNotExist(err) {
+			log.Printf("watchdog: failed to remove old profile %s: %v", w.captures[0].File, err)
+		}
+		w.captures = w.captures[1:]
+	}
+}
+
+// writeProfile writes the profile of kind to name and returns its size.
+func writeProfile(name, kind string) (int64, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	if err := pprof.Lookup(kind).WriteTo(f, 0); err != nil {
+		f.Close()
+		return 0, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	return fi.Size(), f.Close()
+}
+
+// Captures returns the recent captures, most recent first.
+func (w *Watchdog) Captures() []Capture {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := make([]Capture, len(w.captures))
+	for i, c := range w.captures {
+		out[len(out)-1-i] = c
+	}
+	return out
+}
+
+// ServeCaptures lists the recent captures as JSON.
+func (w *Watchdog) ServeCaptures(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(map[string]any{"captures": w.Captures()}); err != nil {
+		log.Printf("watchdog: error writing captures: %v", err)
+	}
+}
+
+// ServeProfile serves the recent capture named by the "file" path value.
+func (w *Watchdog) ServeProfile(rw http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("file")
+	for _, c := range w.Captures() {
+		if c.File == name {
+			rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+			http.ServeFile(rw, r, c.path)
+			return
+		}
+	}
+	http.NotFound(rw, r)
+}
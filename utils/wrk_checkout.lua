@@ -1,4 +1,84 @@
-wrk.method = "POST"
-wrk.path = "/cart/checkout"
-wrk.body = "email=test@example.com&street_address=123 Main St&zip_code=98101&city=Seattle&state=WA&country=USA&credit_card_number=4111111111111111&credit_card_expiration_month=12&credit_card_expiration_year=2025&credit_card_cvv=123"
-wrk.headers["Content-Type"] = "application/x-www-form-urlencoded"
+-- Places orders the way the storefront expects: every checkout needs a
+-- one-time checkout_nonce and the cart_hash from the cart page of the same
+-- session, so each connection cycles through adding an item, reading the
+-- cart and submitting the form (as services/probe does). The steps are kept
+-- per thread, so run as many connections as threads (-c equal to -t).
+
+local product_id = "1YMWWN1N4O"
+
+local form = {
+   email = "test@example.com",
+   street_address = "123 Main St",
+   zip_code = "98101",
+   city = "Seattle",
+   state = "WA",
+   country = "USA",
+   credit_card_number = "4111111111111111",
+   credit_card_expiration_month = "12",
+   credit_card_expiration_year = tostring(tonumber(os.date("%Y")) + 1),
+   credit_card_cvv = "123",
+   accept_terms = "true",
+   confirm_age = "true",
+}
+
+local form_headers = { ["Content-Type"] = "application/x-www-form-urlencoded" }
+
+local step = "add"
+local cookie = nil
+local hidden = {}
+
+local function encode(s)
+   return (s:gsub("[^%w%-_%.~]", function(c)
+      return string.format("%%%02X", string.byte(c))
+   end))
+end
+
+local function with_cookie(headers)
+   local h = {}
+   for k, v in pairs(headers or {}) do
+      h[k] = v
+   end
+   if cookie then
+      h["Cookie"] = cookie
+   end
+   return h
+end
+
+request = function()
+   if step == "add" then
+      return wrk.format("POST", "/cart", with_cookie(form_headers), "product_id=" .. product_id .. "&quantity=1")
+   elseif step == "cart" then
+      return wrk.format("GET", "/cart", with_cookie())
+   end
+   local body = {}
+   for k, v in pairs(form) do
+      body[#body + 1] = k .. "=" .. encode(v)
+   end
+   for k, v in pairs(hidden) do
+      body[#body + 1] = k .. "=" .. encode(v)
+   end
+   return wrk.format("POST", "/cart/checkout", with_cookie(form_headers), table.concat(body, "&"))
+end
+
+response = function(status, headers, body)
+   for k, v in pairs(headers) do
+      if k:lower() == "set-cookie" then
+         local session = v:match("(shop_session%-id=[^;]+)")
+         if session then
+            cookie = session
+         end
+      end
+   end
+
+   if step == "add" then
+      step = status < 400 and "cart" or "add"
+   elseif step == "cart" then
+      hidden = {}
+      for _, name in ipairs({ "checkout_nonce", "cart_hash", "terms_version" }) do
+         hidden[name] = body:match('name="' .. name .. '" value="([^"]*)"')
+      end
+      step = hidden.checkout_nonce and "checkout" or "add"
+   else
+      step = "add"
+   end
+end